@@ -37,12 +37,18 @@ type ClientService interface {
 
 	DeleteIpamIps(params *DeleteIpamIpsParams, opts ...ClientOption) (*DeleteIpamIpsOK, error)
 
+	GetIpamIPInuse(params *GetIpamIPInuseParams, opts ...ClientOption) (*GetIpamIPInuseOK, error)
+
+	GetIpamIps(params *GetIpamIpsParams, opts ...ClientOption) (*GetIpamIpsOK, error)
+
 	GetWorkloadendpoint(params *GetWorkloadendpointParams, opts ...ClientOption) (*GetWorkloadendpointOK, error)
 
 	PostIpamIP(params *PostIpamIPParams, opts ...ClientOption) (*PostIpamIPOK, error)
 
 	PostIpamIps(params *PostIpamIpsParams, opts ...ClientOption) (*PostIpamIpsOK, error)
 
+	PutWorkloadendpointSlaac(params *PutWorkloadendpointSlaacParams, opts ...ClientOption) (*PutWorkloadendpointSlaacOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -126,6 +132,86 @@ func (a *Client) DeleteIpamIps(params *DeleteIpamIpsParams, opts ...ClientOption
 	panic(msg)
 }
 
+/*
+GetIpamIPInuse checks whether an ip is in use on the node
+
+Check whether any pod known by the kubelet of the node still uses the ip, before the ip is released by garbage collection. The caller has to be able to list pods
+*/
+func (a *Client) GetIpamIPInuse(params *GetIpamIPInuseParams, opts ...ClientOption) (*GetIpamIPInuseOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamIPInuseParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetIpamIPInuse",
+		Method:             "GET",
+		PathPattern:        "/ipam/ip/inuse",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamIPInuseReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamIPInuseOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpamIPInuse: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+GetIpamIps lists the ip allocations on the node
+
+List the ip allocations made by the agent for the pods on the node without querying the api server, optionally only the ones of the pods in a namespace or of a pod
+*/
+func (a *Client) GetIpamIps(params *GetIpamIpsParams, opts ...ClientOption) (*GetIpamIpsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamIpsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetIpamIps",
+		Method:             "GET",
+		PathPattern:        "/ipam/ips",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamIpsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamIpsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpamIps: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetWorkloadendpoint gets workloadendpoint status
 
@@ -246,6 +332,46 @@ func (a *Client) PostIpamIps(params *PostIpamIpsParams, opts ...ClientOption) (*
 	panic(msg)
 }
 
+/*
+PutWorkloadendpointSlaac records SLAAC addresses of a pod interface
+
+Send a request to daemonset to record the IPv6 addresses derived from router advertisements on a pod interface
+*/
+func (a *Client) PutWorkloadendpointSlaac(params *PutWorkloadendpointSlaacParams, opts ...ClientOption) (*PutWorkloadendpointSlaacOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPutWorkloadendpointSlaacParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PutWorkloadendpointSlaac",
+		Method:             "PUT",
+		PathPattern:        "/workloadendpoint/slaac",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PutWorkloadendpointSlaacReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PutWorkloadendpointSlaacOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PutWorkloadendpointSlaac: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
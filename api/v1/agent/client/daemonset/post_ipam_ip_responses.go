@@ -32,12 +32,30 @@ func (o *PostIpamIPReader) ReadResponse(response runtime.ClientResponse, consume
 			return nil, err
 		}
 		return result, nil
+	case 404:
+		result := NewPostIpamIPOwnerNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 409:
+		result := NewPostIpamIPOverlap()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewPostIpamIPFailure()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
 			return nil, err
 		}
 		return nil, result
+	case 503:
+		result := NewPostIpamIPExhausted()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	default:
 		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
 	}
@@ -106,6 +124,128 @@ func (o *PostIpamIPOK) readResponse(response runtime.ClientResponse, consumer ru
 	return nil
 }
 
+// NewPostIpamIPOwnerNotFound creates a PostIpamIPOwnerNotFound with default headers values
+func NewPostIpamIPOwnerNotFound() *PostIpamIPOwnerNotFound {
+	return &PostIpamIPOwnerNotFound{}
+}
+
+/*
+PostIpamIPOwnerNotFound describes a response with status code 404, with default header values.
+
+Owner controller of the pod not found
+*/
+type PostIpamIPOwnerNotFound struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam Ip owner not found response has a 2xx status code
+func (o *PostIpamIPOwnerNotFound) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam Ip owner not found response has a 3xx status code
+func (o *PostIpamIPOwnerNotFound) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam Ip owner not found response has a 4xx status code
+func (o *PostIpamIPOwnerNotFound) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this post ipam Ip owner not found response has a 5xx status code
+func (o *PostIpamIPOwnerNotFound) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post ipam Ip owner not found response a status code equal to that given
+func (o *PostIpamIPOwnerNotFound) IsCode(code int) bool {
+	return code == 404
+}
+
+func (o *PostIpamIPOwnerNotFound) Error() string {
+	return fmt.Sprintf("[POST /ipam/ip][%d] postIpamIpOwnerNotFound  %+v", 404, o.Payload)
+}
+
+func (o *PostIpamIPOwnerNotFound) String() string {
+	return fmt.Sprintf("[POST /ipam/ip][%d] postIpamIpOwnerNotFound  %+v", 404, o.Payload)
+}
+
+func (o *PostIpamIPOwnerNotFound) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamIPOwnerNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewPostIpamIPOverlap creates a PostIpamIPOverlap with default headers values
+func NewPostIpamIPOverlap() *PostIpamIPOverlap {
+	return &PostIpamIPOverlap{}
+}
+
+/*
+PostIpamIPOverlap describes a response with status code 409, with default header values.
+
+Overlapping IP ranges
+*/
+type PostIpamIPOverlap struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam Ip overlap response has a 2xx status code
+func (o *PostIpamIPOverlap) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam Ip overlap response has a 3xx status code
+func (o *PostIpamIPOverlap) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam Ip overlap response has a 4xx status code
+func (o *PostIpamIPOverlap) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this post ipam Ip overlap response has a 5xx status code
+func (o *PostIpamIPOverlap) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post ipam Ip overlap response a status code equal to that given
+func (o *PostIpamIPOverlap) IsCode(code int) bool {
+	return code == 409
+}
+
+func (o *PostIpamIPOverlap) Error() string {
+	return fmt.Sprintf("[POST /ipam/ip][%d] postIpamIpOverlap  %+v", 409, o.Payload)
+}
+
+func (o *PostIpamIPOverlap) String() string {
+	return fmt.Sprintf("[POST /ipam/ip][%d] postIpamIpOverlap  %+v", 409, o.Payload)
+}
+
+func (o *PostIpamIPOverlap) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamIPOverlap) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
 // NewPostIpamIPFailure creates a PostIpamIPFailure with default headers values
 func NewPostIpamIPFailure() *PostIpamIPFailure {
 	return &PostIpamIPFailure{}
@@ -166,3 +306,64 @@ func (o *PostIpamIPFailure) readResponse(response runtime.ClientResponse, consum
 
 	return nil
 }
+
+// NewPostIpamIPExhausted creates a PostIpamIPExhausted with default headers values
+func NewPostIpamIPExhausted() *PostIpamIPExhausted {
+	return &PostIpamIPExhausted{}
+}
+
+/*
+PostIpamIPExhausted describes a response with status code 503, with default header values.
+
+IP addresses of the pools exhausted
+*/
+type PostIpamIPExhausted struct {
+	Payload models.Error
+}
+
+// IsSuccess returns true when this post ipam Ip exhausted response has a 2xx status code
+func (o *PostIpamIPExhausted) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post ipam Ip exhausted response has a 3xx status code
+func (o *PostIpamIPExhausted) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post ipam Ip exhausted response has a 4xx status code
+func (o *PostIpamIPExhausted) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this post ipam Ip exhausted response has a 5xx status code
+func (o *PostIpamIPExhausted) IsServerError() bool {
+	return true
+}
+
+// IsCode returns true when this post ipam Ip exhausted response a status code equal to that given
+func (o *PostIpamIPExhausted) IsCode(code int) bool {
+	return code == 503
+}
+
+func (o *PostIpamIPExhausted) Error() string {
+	return fmt.Sprintf("[POST /ipam/ip][%d] postIpamIpExhausted  %+v", 503, o.Payload)
+}
+
+func (o *PostIpamIPExhausted) String() string {
+	return fmt.Sprintf("[POST /ipam/ip][%d] postIpamIpExhausted  %+v", 503, o.Payload)
+}
+
+func (o *PostIpamIPExhausted) GetPayload() models.Error {
+	return o.Payload
+}
+
+func (o *PostIpamIPExhausted) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
@@ -33,6 +33,8 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetFeaturez(params *GetFeaturezParams, opts ...ClientOption) (*GetFeaturezOK, error)
+
 	GetRuntimeLiveness(params *GetRuntimeLivenessParams, opts ...ClientOption) (*GetRuntimeLivenessOK, error)
 
 	GetRuntimeReadiness(params *GetRuntimeReadinessParams, opts ...ClientOption) (*GetRuntimeReadinessOK, error)
@@ -42,6 +44,46 @@ type ClientService interface {
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+GetFeaturez gets feature gates
+
+Get the status of feature gates
+*/
+func (a *Client) GetFeaturez(params *GetFeaturezParams, opts ...ClientOption) (*GetFeaturezOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetFeaturezParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetFeaturez",
+		Method:             "GET",
+		PathPattern:        "/featurez",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetFeaturezReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetFeaturezOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetFeaturez: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetRuntimeLiveness livenesses probe
 
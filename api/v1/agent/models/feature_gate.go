@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// FeatureGate Feature gate status
+//
+// swagger:model FeatureGate
+type FeatureGate struct {
+
+	// default
+	Default bool `json:"default,omitempty"`
+
+	// enabled
+	Enabled bool `json:"enabled,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// pre release
+	PreRelease string `json:"preRelease,omitempty"`
+}
+
+// Validate validates this feature gate
+func (m *FeatureGate) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this feature gate based on context it is used
+func (m *FeatureGate) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *FeatureGate) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *FeatureGate) UnmarshalBinary(b []byte) error {
+	var res FeatureGate
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// HostPortMapping The hostPort of Pod forwarded to its IP address
+//
+// swagger:model HostPortMapping
+type HostPortMapping struct {
+
+	// container port
+	ContainerPort int64 `json:"containerPort,omitempty"`
+
+	// host IP
+	HostIP string `json:"hostIP,omitempty"`
+
+	// host port
+	HostPort int64 `json:"hostPort,omitempty"`
+
+	// pod IP
+	PodIP string `json:"podIP,omitempty"`
+
+	// protocol
+	Protocol string `json:"protocol,omitempty"`
+
+	// snat
+	Snat bool `json:"snat,omitempty"`
+}
+
+// Validate validates this host port mapping
+func (m *HostPortMapping) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this host port mapping based on context it is used
+func (m *HostPortMapping) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *HostPortMapping) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *HostPortMapping) UnmarshalBinary(b []byte) error {
+	var res HostPortMapping
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
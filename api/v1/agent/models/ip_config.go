@@ -23,10 +23,25 @@ import (
 // swagger:model IpConfig
 type IPConfig struct {
 
+	// accept r a
+	AcceptRA bool `json:"acceptRA,omitempty"`
+
 	// address
 	// Required: true
 	Address *string `json:"address"`
 
+	// delegated prefix
+	DelegatedPrefix string `json:"delegatedPrefix,omitempty"`
+
+	// disable s n a t
+	DisableSNAT bool `json:"disableSNAT,omitempty"`
+
+	// enable gateway detection
+	EnableGatewayDetection bool `json:"enableGatewayDetection,omitempty"`
+
+	// enable IP conflict detection
+	EnableIPConflictDetection bool `json:"enableIPConflictDetection,omitempty"`
+
 	// gateway
 	Gateway string `json:"gateway,omitempty"`
 
@@ -0,0 +1,77 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// IPInUse Pods using the ip on the node
+//
+// swagger:model IpInUse
+type IPInUse struct {
+
+	// in use
+	// Required: true
+	InUse *bool `json:"inUse"`
+
+	// pods
+	Pods []string `json:"pods"`
+}
+
+// Validate validates this Ip in use
+func (m *IPInUse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateInUse(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *IPInUse) validateInUse(formats strfmt.Registry) error {
+
+	if err := validate.Required("inUse", "body", m.InUse); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this Ip in use based on context it is used
+func (m *IPInUse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *IPInUse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *IPInUse) UnmarshalBinary(b []byte) error {
+	var res IPInUse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -32,9 +32,15 @@ type IpamAddArgs struct {
 	// default IPv4 IP pool
 	DefaultIPV4IPPool []string `json:"defaultIPv4IPPool"`
 
+	// default IPv4 subnet
+	DefaultIPV4Subnet string `json:"defaultIPv4Subnet,omitempty"`
+
 	// default IPv6 IP pool
 	DefaultIPV6IPPool []string `json:"defaultIPv6IPPool"`
 
+	// default IPv6 subnet
+	DefaultIPV6Subnet string `json:"defaultIPv6Subnet,omitempty"`
+
 	// if name
 	// Required: true
 	IfName *string `json:"ifName"`
@@ -50,6 +56,9 @@ type IpamAddArgs struct {
 	// pod namespace
 	// Required: true
 	PodNamespace *string `json:"podNamespace"`
+
+	// The remaining seconds of the CNI request, the agent gives up the allocation beyond it
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
 }
 
 // Validate validates this ipam add args
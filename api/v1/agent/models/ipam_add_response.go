@@ -23,9 +23,15 @@ import (
 // swagger:model IpamAddResponse
 type IpamAddResponse struct {
 
+	// bandwidth
+	Bandwidth *Bandwidth `json:"bandwidth,omitempty"`
+
 	// dns
 	DNS *DNS `json:"dns,omitempty"`
 
+	// host ports
+	HostPorts []*HostPortMapping `json:"hostPorts"`
+
 	// ips
 	// Required: true
 	Ips []*IPConfig `json:"ips"`
@@ -38,10 +44,18 @@ type IpamAddResponse struct {
 func (m *IpamAddResponse) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateBandwidth(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDNS(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateHostPorts(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateIps(formats); err != nil {
 		res = append(res, err)
 	}
@@ -56,6 +70,25 @@ func (m *IpamAddResponse) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *IpamAddResponse) validateBandwidth(formats strfmt.Registry) error {
+	if swag.IsZero(m.Bandwidth) { // not required
+		return nil
+	}
+
+	if m.Bandwidth != nil {
+		if err := m.Bandwidth.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("bandwidth")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("bandwidth")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *IpamAddResponse) validateDNS(formats strfmt.Registry) error {
 	if swag.IsZero(m.DNS) { // not required
 		return nil
@@ -75,6 +108,32 @@ func (m *IpamAddResponse) validateDNS(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *IpamAddResponse) validateHostPorts(formats strfmt.Registry) error {
+	if swag.IsZero(m.HostPorts) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.HostPorts); i++ {
+		if swag.IsZero(m.HostPorts[i]) { // not required
+			continue
+		}
+
+		if m.HostPorts[i] != nil {
+			if err := m.HostPorts[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("hostPorts" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("hostPorts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *IpamAddResponse) validateIps(formats strfmt.Registry) error {
 
 	if err := validate.Required("ips", "body", m.Ips); err != nil {
@@ -132,10 +191,18 @@ func (m *IpamAddResponse) validateRoutes(formats strfmt.Registry) error {
 func (m *IpamAddResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateBandwidth(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateDNS(ctx, formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateHostPorts(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateIps(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -150,6 +217,22 @@ func (m *IpamAddResponse) ContextValidate(ctx context.Context, formats strfmt.Re
 	return nil
 }
 
+func (m *IpamAddResponse) contextValidateBandwidth(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Bandwidth != nil {
+		if err := m.Bandwidth.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("bandwidth")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("bandwidth")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *IpamAddResponse) contextValidateDNS(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.DNS != nil {
@@ -166,6 +249,26 @@ func (m *IpamAddResponse) contextValidateDNS(ctx context.Context, formats strfmt
 	return nil
 }
 
+func (m *IpamAddResponse) contextValidateHostPorts(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.HostPorts); i++ {
+
+		if m.HostPorts[i] != nil {
+			if err := m.HostPorts[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("hostPorts" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("hostPorts" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *IpamAddResponse) contextValidateIps(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Ips); i++ {
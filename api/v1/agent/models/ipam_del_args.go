@@ -40,6 +40,9 @@ type IpamDelArgs struct {
 	// pod namespace
 	// Required: true
 	PodNamespace *string `json:"podNamespace"`
+
+	// The remaining seconds of the CNI request, the agent gives up the release beyond it
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
 }
 
 // Validate validates this ipam del args
@@ -53,11 +53,26 @@ func configureAPI(api *restapi.SpiderpoolAgentAPIAPI) http.Handler {
 			return middleware.NotImplemented("operation daemonset.DeleteIpamIps has not yet been implemented")
 		})
 	}
+	if api.RuntimeGetFeaturezHandler == nil {
+		api.RuntimeGetFeaturezHandler = runtimeops.GetFeaturezHandlerFunc(func(params runtimeops.GetFeaturezParams) middleware.Responder {
+			return middleware.NotImplemented("operation runtime.GetFeaturez has not yet been implemented")
+		})
+	}
 	if api.ConnectivityGetIpamHealthyHandler == nil {
 		api.ConnectivityGetIpamHealthyHandler = connectivity.GetIpamHealthyHandlerFunc(func(params connectivity.GetIpamHealthyParams) middleware.Responder {
 			return middleware.NotImplemented("operation connectivity.GetIpamHealthy has not yet been implemented")
 		})
 	}
+	if api.DaemonsetGetIpamIPInuseHandler == nil {
+		api.DaemonsetGetIpamIPInuseHandler = daemonset.GetIpamIPInuseHandlerFunc(func(params daemonset.GetIpamIPInuseParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.GetIpamIPInuse has not yet been implemented")
+		})
+	}
+	if api.DaemonsetGetIpamIpsHandler == nil {
+		api.DaemonsetGetIpamIpsHandler = daemonset.GetIpamIpsHandlerFunc(func(params daemonset.GetIpamIpsParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.GetIpamIps has not yet been implemented")
+		})
+	}
 	if api.RuntimeGetRuntimeLivenessHandler == nil {
 		api.RuntimeGetRuntimeLivenessHandler = runtimeops.GetRuntimeLivenessHandlerFunc(func(params runtimeops.GetRuntimeLivenessParams) middleware.Responder {
 			return middleware.NotImplemented("operation runtime.GetRuntimeLiveness has not yet been implemented")
@@ -35,6 +35,26 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/featurez": {
+      "get": {
+        "description": "Get the status of feature gates",
+        "tags": [
+          "runtime"
+        ],
+        "summary": "Get feature gates",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/FeatureGate"
+              }
+            }
+          }
+        }
+      }
+    },
     "/ipam/healthy": {
       "get": {
         "description": "Check spiderpool daemonset health to make sure whether it's ready\nfor CNI plugin usage\n",
@@ -76,12 +96,33 @@ func init() {
               "$ref": "#/definitions/IpamAddResponse"
             }
           },
+          "404": {
+            "description": "Owner controller of the pod not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "OwnerNotFound"
+          },
+          "409": {
+            "description": "Overlapping IP ranges",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Overlap"
+          },
           "500": {
             "description": "Allocation failure",
             "schema": {
               "$ref": "#/definitions/Error"
             },
             "x-go-name": "Failure"
+          },
+          "503": {
+            "description": "IP addresses of the pools exhausted",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Exhausted"
           }
         }
       },
@@ -115,7 +156,92 @@ func init() {
         }
       }
     },
+    "/ipam/ip/inuse": {
+      "get": {
+        "description": "Check whether any pod known by the kubelet of the node still uses\nthe ip, before the ip is released by garbage collection. The caller\nhas to be able to list pods\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Check whether an ip is in use on the node",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "ip",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpInUse"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to list pods",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Check failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/ips": {
+      "get": {
+        "description": "List the ip allocations made by the agent for the pods on the node without querying the api server,\noptionally only the ones of the pods in a namespace or of a pod\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "List the ip allocations on the node",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "podNamespace",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "podName",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpAllocations"
+            }
+          },
+          "500": {
+            "description": "List failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
       "post": {
         "description": "Assign multiple ip for a pod, case for spiderflat compent\n",
         "tags": [
@@ -222,9 +348,58 @@ func init() {
           }
         }
       }
+    },
+    "/workloadendpoint/slaac": {
+      "put": {
+        "description": "Send a request to daemonset to record the IPv6 addresses derived\nfrom router advertisements on a pod interface\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Record SLAAC addresses of a pod interface",
+        "parameters": [
+          {
+            "name": "slaac-addresses",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SlaacAddresses"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Record failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "Bandwidth": {
+      "description": "The rate limits of the traffic of Pod, rates in bits per second and bursts in bits",
+      "type": "object",
+      "properties": {
+        "egressBurst": {
+          "type": "integer"
+        },
+        "egressRate": {
+          "type": "integer"
+        },
+        "ingressBurst": {
+          "type": "integer"
+        },
+        "ingressRate": {
+          "type": "integer"
+        }
+      }
+    },
     "DNS": {
       "description": "IPAM CNI types DNS",
       "type": "object",
@@ -256,6 +431,97 @@ func init() {
       "description": "API error",
       "type": "string"
     },
+    "FeatureGate": {
+      "description": "Feature gate status",
+      "type": "object",
+      "properties": {
+        "default": {
+          "type": "boolean"
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "preRelease": {
+          "type": "string"
+        }
+      }
+    },
+    "HostPortMapping": {
+      "description": "The hostPort of Pod forwarded to its IP address",
+      "type": "object",
+      "properties": {
+        "containerPort": {
+          "type": "integer"
+        },
+        "hostIP": {
+          "type": "string"
+        },
+        "hostPort": {
+          "type": "integer"
+        },
+        "podIP": {
+          "type": "string"
+        },
+        "protocol": {
+          "type": "string"
+        },
+        "snat": {
+          "type": "boolean"
+        }
+      }
+    },
+    "IpAllocation": {
+      "description": "IP allocated to a pod on the node",
+      "type": "object",
+      "required": [
+        "podNamespace",
+        "podName",
+        "containerID",
+        "nic",
+        "version",
+        "address"
+      ],
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "containerID": {
+          "type": "string"
+        },
+        "ipPool": {
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "version": {
+          "type": "integer",
+          "enum": [
+            4,
+            6
+          ]
+        },
+        "vlan": {
+          "type": "integer"
+        }
+      }
+    },
+    "IpAllocations": {
+      "description": "IP allocations of the pods on the node",
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/IpAllocation"
+      }
+    },
     "IpConfig": {
       "description": "IPAM IPs struct, contains ifName, Address and Gateway",
       "type": "object",
@@ -265,9 +531,24 @@ func init() {
         "nic"
       ],
       "properties": {
+        "acceptRA": {
+          "type": "boolean"
+        },
         "address": {
           "type": "string"
         },
+        "delegatedPrefix": {
+          "type": "string"
+        },
+        "disableSNAT": {
+          "type": "boolean"
+        },
+        "enableGatewayDetection": {
+          "type": "boolean"
+        },
+        "enableIPConflictDetection": {
+          "type": "boolean"
+        },
         "gateway": {
           "type": "string"
         },
@@ -289,6 +570,24 @@ func init() {
         }
       }
     },
+    "IpInUse": {
+      "description": "Pods using the ip on the node",
+      "type": "object",
+      "required": [
+        "inUse"
+      ],
+      "properties": {
+        "inUse": {
+          "type": "boolean"
+        },
+        "pods": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "IpamAddArgs": {
       "description": "IPAM request args",
       "type": "object",
@@ -312,12 +611,18 @@ func init() {
             "type": "string"
           }
         },
+        "defaultIPv4Subnet": {
+          "type": "string"
+        },
         "defaultIPv6IPPool": {
           "type": "array",
           "items": {
             "type": "string"
           }
         },
+        "defaultIPv6Subnet": {
+          "type": "string"
+        },
         "ifName": {
           "type": "string"
         },
@@ -329,6 +634,11 @@ func init() {
         },
         "podNamespace": {
           "type": "string"
+        },
+        "timeoutSeconds": {
+          "description": "The remaining seconds of the CNI request, the agent gives up the allocation beyond it",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -339,10 +649,20 @@ func init() {
         "ips"
       ],
       "properties": {
+        "bandwidth": {
+          "type": "object",
+          "$ref": "#/definitions/Bandwidth"
+        },
         "dns": {
           "type": "object",
           "$ref": "#/definitions/DNS"
         },
+        "hostPorts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/HostPortMapping"
+          }
+        },
         "ips": {
           "type": "array",
           "items": {
@@ -381,6 +701,11 @@ func init() {
         },
         "podNamespace": {
           "type": "string"
+        },
+        "timeoutSeconds": {
+          "description": "The remaining seconds of the CNI request, the agent gives up the release beyond it",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -403,6 +728,37 @@ func init() {
           "type": "string"
         }
       }
+    },
+    "SlaacAddresses": {
+      "description": "IPv6 addresses derived from router advertisements on a pod interface",
+      "type": "object",
+      "required": [
+        "containerID",
+        "ifName",
+        "podNamespace",
+        "podName",
+        "ips"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ips": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        }
+      }
     }
   },
   "x-schemes": [
@@ -424,6 +780,26 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/featurez": {
+      "get": {
+        "description": "Get the status of feature gates",
+        "tags": [
+          "runtime"
+        ],
+        "summary": "Get feature gates",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/FeatureGate"
+              }
+            }
+          }
+        }
+      }
+    },
     "/ipam/healthy": {
       "get": {
         "description": "Check spiderpool daemonset health to make sure whether it's ready\nfor CNI plugin usage\n",
@@ -465,12 +841,33 @@ func init() {
               "$ref": "#/definitions/IpamAddResponse"
             }
           },
+          "404": {
+            "description": "Owner controller of the pod not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "OwnerNotFound"
+          },
+          "409": {
+            "description": "Overlapping IP ranges",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Overlap"
+          },
           "500": {
             "description": "Allocation failure",
             "schema": {
               "$ref": "#/definitions/Error"
             },
             "x-go-name": "Failure"
+          },
+          "503": {
+            "description": "IP addresses of the pools exhausted",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Exhausted"
           }
         }
       },
@@ -504,7 +901,92 @@ func init() {
         }
       }
     },
+    "/ipam/ip/inuse": {
+      "get": {
+        "description": "Check whether any pod known by the kubelet of the node still uses\nthe ip, before the ip is released by garbage collection. The caller\nhas to be able to list pods\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Check whether an ip is in use on the node",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "ip",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpInUse"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to list pods",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Check failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/ips": {
+      "get": {
+        "description": "List the ip allocations made by the agent for the pods on the node without querying the api server,\noptionally only the ones of the pods in a namespace or of a pod\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "List the ip allocations on the node",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "podNamespace",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "podName",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/IpAllocations"
+            }
+          },
+          "500": {
+            "description": "List failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
       "post": {
         "description": "Assign multiple ip for a pod, case for spiderflat compent\n",
         "tags": [
@@ -611,9 +1093,58 @@ func init() {
           }
         }
       }
+    },
+    "/workloadendpoint/slaac": {
+      "put": {
+        "description": "Send a request to daemonset to record the IPv6 addresses derived\nfrom router advertisements on a pod interface\n",
+        "tags": [
+          "daemonset"
+        ],
+        "summary": "Record SLAAC addresses of a pod interface",
+        "parameters": [
+          {
+            "name": "slaac-addresses",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SlaacAddresses"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "500": {
+            "description": "Record failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
     }
   },
   "definitions": {
+    "Bandwidth": {
+      "description": "The rate limits of the traffic of Pod, rates in bits per second and bursts in bits",
+      "type": "object",
+      "properties": {
+        "egressBurst": {
+          "type": "integer"
+        },
+        "egressRate": {
+          "type": "integer"
+        },
+        "ingressBurst": {
+          "type": "integer"
+        },
+        "ingressRate": {
+          "type": "integer"
+        }
+      }
+    },
     "DNS": {
       "description": "IPAM CNI types DNS",
       "type": "object",
@@ -645,6 +1176,97 @@ func init() {
       "description": "API error",
       "type": "string"
     },
+    "FeatureGate": {
+      "description": "Feature gate status",
+      "type": "object",
+      "properties": {
+        "default": {
+          "type": "boolean"
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "preRelease": {
+          "type": "string"
+        }
+      }
+    },
+    "HostPortMapping": {
+      "description": "The hostPort of Pod forwarded to its IP address",
+      "type": "object",
+      "properties": {
+        "containerPort": {
+          "type": "integer"
+        },
+        "hostIP": {
+          "type": "string"
+        },
+        "hostPort": {
+          "type": "integer"
+        },
+        "podIP": {
+          "type": "string"
+        },
+        "protocol": {
+          "type": "string"
+        },
+        "snat": {
+          "type": "boolean"
+        }
+      }
+    },
+    "IpAllocation": {
+      "description": "IP allocated to a pod on the node",
+      "type": "object",
+      "required": [
+        "podNamespace",
+        "podName",
+        "containerID",
+        "nic",
+        "version",
+        "address"
+      ],
+      "properties": {
+        "address": {
+          "type": "string"
+        },
+        "containerID": {
+          "type": "string"
+        },
+        "ipPool": {
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        },
+        "version": {
+          "type": "integer",
+          "enum": [
+            4,
+            6
+          ]
+        },
+        "vlan": {
+          "type": "integer"
+        }
+      }
+    },
+    "IpAllocations": {
+      "description": "IP allocations of the pods on the node",
+      "type": "array",
+      "items": {
+        "$ref": "#/definitions/IpAllocation"
+      }
+    },
     "IpConfig": {
       "description": "IPAM IPs struct, contains ifName, Address and Gateway",
       "type": "object",
@@ -654,9 +1276,24 @@ func init() {
         "nic"
       ],
       "properties": {
+        "acceptRA": {
+          "type": "boolean"
+        },
         "address": {
           "type": "string"
         },
+        "delegatedPrefix": {
+          "type": "string"
+        },
+        "disableSNAT": {
+          "type": "boolean"
+        },
+        "enableGatewayDetection": {
+          "type": "boolean"
+        },
+        "enableIPConflictDetection": {
+          "type": "boolean"
+        },
         "gateway": {
           "type": "string"
         },
@@ -678,6 +1315,24 @@ func init() {
         }
       }
     },
+    "IpInUse": {
+      "description": "Pods using the ip on the node",
+      "type": "object",
+      "required": [
+        "inUse"
+      ],
+      "properties": {
+        "inUse": {
+          "type": "boolean"
+        },
+        "pods": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
     "IpamAddArgs": {
       "description": "IPAM request args",
       "type": "object",
@@ -701,12 +1356,18 @@ func init() {
             "type": "string"
           }
         },
+        "defaultIPv4Subnet": {
+          "type": "string"
+        },
         "defaultIPv6IPPool": {
           "type": "array",
           "items": {
             "type": "string"
           }
         },
+        "defaultIPv6Subnet": {
+          "type": "string"
+        },
         "ifName": {
           "type": "string"
         },
@@ -718,6 +1379,11 @@ func init() {
         },
         "podNamespace": {
           "type": "string"
+        },
+        "timeoutSeconds": {
+          "description": "The remaining seconds of the CNI request, the agent gives up the allocation beyond it",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -728,10 +1394,20 @@ func init() {
         "ips"
       ],
       "properties": {
+        "bandwidth": {
+          "type": "object",
+          "$ref": "#/definitions/Bandwidth"
+        },
         "dns": {
           "type": "object",
           "$ref": "#/definitions/DNS"
         },
+        "hostPorts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/HostPortMapping"
+          }
+        },
         "ips": {
           "type": "array",
           "items": {
@@ -770,6 +1446,11 @@ func init() {
         },
         "podNamespace": {
           "type": "string"
+        },
+        "timeoutSeconds": {
+          "description": "The remaining seconds of the CNI request, the agent gives up the release beyond it",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -792,6 +1473,37 @@ func init() {
           "type": "string"
         }
       }
+    },
+    "SlaacAddresses": {
+      "description": "IPv6 addresses derived from router advertisements on a pod interface",
+      "type": "object",
+      "required": [
+        "containerID",
+        "ifName",
+        "podNamespace",
+        "podName",
+        "ips"
+      ],
+      "properties": {
+        "containerID": {
+          "type": "string"
+        },
+        "ifName": {
+          "type": "string"
+        },
+        "ips": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "podName": {
+          "type": "string"
+        },
+        "podNamespace": {
+          "type": "string"
+        }
+      }
     }
   },
   "x-schemes": [
@@ -0,0 +1,63 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetIpamIPInuseHandlerFunc turns a function with the right signature into a get ipam IP inuse handler
+type GetIpamIPInuseHandlerFunc func(GetIpamIPInuseParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetIpamIPInuseHandlerFunc) Handle(params GetIpamIPInuseParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetIpamIPInuseHandler interface for that can handle valid get ipam IP inuse params
+type GetIpamIPInuseHandler interface {
+	Handle(GetIpamIPInuseParams) middleware.Responder
+}
+
+// NewGetIpamIPInuse creates a new http.Handler for the get ipam IP inuse operation
+func NewGetIpamIPInuse(ctx *middleware.Context, handler GetIpamIPInuseHandler) *GetIpamIPInuse {
+	return &GetIpamIPInuse{Context: ctx, Handler: handler}
+}
+
+/*
+	GetIpamIPInuse swagger:route GET /ipam/ip/inuse daemonset getIpamIpInuse
+
+# Check whether an ip is in use on the node
+
+Check whether any pod known by the kubelet of the node still uses
+the ip, before the ip is released by garbage collection. The caller
+has to be able to list pods
+*/
+type GetIpamIPInuse struct {
+	Context *middleware.Context
+	Handler GetIpamIPInuseHandler
+}
+
+func (o *GetIpamIPInuse) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetIpamIPInuseParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,85 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// NewGetIpamIPInuseParams creates a new GetIpamIPInuseParams object
+//
+// There are no default values defined in the spec.
+func NewGetIpamIPInuseParams() GetIpamIPInuseParams {
+
+	return GetIpamIPInuseParams{}
+}
+
+// GetIpamIPInuseParams contains all the bound params for the get ipam IP inuse operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters GetIpamIPInuse
+type GetIpamIPInuseParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  Required: true
+	  In: query
+	*/
+	IP string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewGetIpamIPInuseParams() beforehand.
+func (o *GetIpamIPInuseParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qIP, qhkIP, _ := qs.GetOK("ip")
+	if err := o.bindIP(qIP, qhkIP, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindIP binds and validates parameter IP from query.
+func (o *GetIpamIPInuseParams) bindIP(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("ip", "query", rawData)
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// AllowEmptyValue: false
+
+	if err := validate.RequiredString("ip", "query", raw); err != nil {
+		return err
+	}
+	o.IP = raw
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetIpamIpsHandlerFunc turns a function with the right signature into a get ipam ips handler
+type GetIpamIpsHandlerFunc func(GetIpamIpsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetIpamIpsHandlerFunc) Handle(params GetIpamIpsParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetIpamIpsHandler interface for that can handle valid get ipam ips params
+type GetIpamIpsHandler interface {
+	Handle(GetIpamIpsParams) middleware.Responder
+}
+
+// NewGetIpamIps creates a new http.Handler for the get ipam ips operation
+func NewGetIpamIps(ctx *middleware.Context, handler GetIpamIpsHandler) *GetIpamIps {
+	return &GetIpamIps{Context: ctx, Handler: handler}
+}
+
+/*
+	GetIpamIps swagger:route GET /ipam/ips daemonset getIpamIps
+
+# List the ip allocations on the node
+
+List the ip allocations made by the agent for the pods on the node without querying the api server,
+optionally only the ones of the pods in a namespace or of a pod
+*/
+type GetIpamIps struct {
+	Context *middleware.Context
+	Handler GetIpamIpsHandler
+}
+
+func (o *GetIpamIps) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetIpamIpsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
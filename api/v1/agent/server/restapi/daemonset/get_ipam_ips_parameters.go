@@ -0,0 +1,107 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetIpamIpsParams creates a new GetIpamIpsParams object
+//
+// There are no default values defined in the spec.
+func NewGetIpamIpsParams() GetIpamIpsParams {
+
+	return GetIpamIpsParams{}
+}
+
+// GetIpamIpsParams contains all the bound params for the get ipam ips operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters GetIpamIps
+type GetIpamIpsParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  In: query
+	*/
+	PodName *string
+	/*
+	  In: query
+	*/
+	PodNamespace *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewGetIpamIpsParams() beforehand.
+func (o *GetIpamIpsParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qPodName, qhkPodName, _ := qs.GetOK("podName")
+	if err := o.bindPodName(qPodName, qhkPodName, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPodNamespace, qhkPodNamespace, _ := qs.GetOK("podNamespace")
+	if err := o.bindPodNamespace(qPodNamespace, qhkPodNamespace, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindPodName binds and validates parameter PodName from query.
+func (o *GetIpamIpsParams) bindPodName(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.PodName = &raw
+
+	return nil
+}
+
+// bindPodNamespace binds and validates parameter PodNamespace from query.
+func (o *GetIpamIpsParams) bindPodNamespace(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.PodNamespace = &raw
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// GetIpamIpsOKCode is the HTTP code returned for type GetIpamIpsOK
+const GetIpamIpsOKCode int = 200
+
+/*
+GetIpamIpsOK Success
+
+swagger:response getIpamIpsOK
+*/
+type GetIpamIpsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.IPAllocations `json:"body,omitempty"`
+}
+
+// NewGetIpamIpsOK creates GetIpamIpsOK with default headers values
+func NewGetIpamIpsOK() *GetIpamIpsOK {
+
+	return &GetIpamIpsOK{}
+}
+
+// WithPayload adds the payload to the get ipam ips o k response
+func (o *GetIpamIpsOK) WithPayload(payload models.IPAllocations) *GetIpamIpsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get ipam ips o k response
+func (o *GetIpamIpsOK) SetPayload(payload models.IPAllocations) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetIpamIpsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = models.IPAllocations{}
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// GetIpamIpsFailureCode is the HTTP code returned for type GetIpamIpsFailure
+const GetIpamIpsFailureCode int = 500
+
+/*
+GetIpamIpsFailure List failure
+
+swagger:response getIpamIpsFailure
+*/
+type GetIpamIpsFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewGetIpamIpsFailure creates GetIpamIpsFailure with default headers values
+func NewGetIpamIpsFailure() *GetIpamIpsFailure {
+
+	return &GetIpamIpsFailure{}
+}
+
+// WithPayload adds the payload to the get ipam ips failure response
+func (o *GetIpamIpsFailure) WithPayload(payload models.Error) *GetIpamIpsFailure {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get ipam ips failure response
+func (o *GetIpamIpsFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetIpamIpsFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
@@ -61,6 +61,92 @@ func (o *PostIpamIPOK) WriteResponse(rw http.ResponseWriter, producer runtime.Pr
 	}
 }
 
+// PostIpamIPOwnerNotFoundCode is the HTTP code returned for type PostIpamIPOwnerNotFound
+const PostIpamIPOwnerNotFoundCode int = 404
+
+/*
+PostIpamIPOwnerNotFound Owner controller of the pod not found
+
+swagger:response postIpamIpOwnerNotFound
+*/
+type PostIpamIPOwnerNotFound struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamIPOwnerNotFound creates PostIpamIPOwnerNotFound with default headers values
+func NewPostIpamIPOwnerNotFound() *PostIpamIPOwnerNotFound {
+
+	return &PostIpamIPOwnerNotFound{}
+}
+
+// WithPayload adds the payload to the post ipam Ip owner not found response
+func (o *PostIpamIPOwnerNotFound) WithPayload(payload models.Error) *PostIpamIPOwnerNotFound {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam Ip owner not found response
+func (o *PostIpamIPOwnerNotFound) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamIPOwnerNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(404)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+// PostIpamIPOverlapCode is the HTTP code returned for type PostIpamIPOverlap
+const PostIpamIPOverlapCode int = 409
+
+/*
+PostIpamIPOverlap Overlapping IP ranges
+
+swagger:response postIpamIpOverlap
+*/
+type PostIpamIPOverlap struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamIPOverlap creates PostIpamIPOverlap with default headers values
+func NewPostIpamIPOverlap() *PostIpamIPOverlap {
+
+	return &PostIpamIPOverlap{}
+}
+
+// WithPayload adds the payload to the post ipam Ip overlap response
+func (o *PostIpamIPOverlap) WithPayload(payload models.Error) *PostIpamIPOverlap {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam Ip overlap response
+func (o *PostIpamIPOverlap) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamIPOverlap) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(409)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
 // PostIpamIPFailureCode is the HTTP code returned for type PostIpamIPFailure
 const PostIpamIPFailureCode int = 500
 
@@ -103,3 +189,46 @@ func (o *PostIpamIPFailure) WriteResponse(rw http.ResponseWriter, producer runti
 		panic(err) // let the recovery middleware deal with this
 	}
 }
+
+// PostIpamIPExhaustedCode is the HTTP code returned for type PostIpamIPExhausted
+const PostIpamIPExhaustedCode int = 503
+
+/*
+PostIpamIPExhausted IP addresses of the pools exhausted
+
+swagger:response postIpamIpExhausted
+*/
+type PostIpamIPExhausted struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPostIpamIPExhausted creates PostIpamIPExhausted with default headers values
+func NewPostIpamIPExhausted() *PostIpamIPExhausted {
+
+	return &PostIpamIPExhausted{}
+}
+
+// WithPayload adds the payload to the post ipam Ip exhausted response
+func (o *PostIpamIPExhausted) WithPayload(payload models.Error) *PostIpamIPExhausted {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the post ipam Ip exhausted response
+func (o *PostIpamIPExhausted) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PostIpamIPExhausted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(503)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
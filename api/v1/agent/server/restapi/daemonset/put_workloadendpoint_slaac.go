@@ -0,0 +1,61 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PutWorkloadendpointSlaacHandlerFunc turns a function with the right signature into a put workloadendpoint slaac handler
+type PutWorkloadendpointSlaacHandlerFunc func(PutWorkloadendpointSlaacParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PutWorkloadendpointSlaacHandlerFunc) Handle(params PutWorkloadendpointSlaacParams) middleware.Responder {
+	return fn(params)
+}
+
+// PutWorkloadendpointSlaacHandler interface for that can handle valid put workloadendpoint slaac params
+type PutWorkloadendpointSlaacHandler interface {
+	Handle(PutWorkloadendpointSlaacParams) middleware.Responder
+}
+
+// NewPutWorkloadendpointSlaac creates a new http.Handler for the put workloadendpoint slaac operation
+func NewPutWorkloadendpointSlaac(ctx *middleware.Context, handler PutWorkloadendpointSlaacHandler) *PutWorkloadendpointSlaac {
+	return &PutWorkloadendpointSlaac{Context: ctx, Handler: handler}
+}
+
+/*
+	PutWorkloadendpointSlaac swagger:route PUT /workloadendpoint/slaac daemonset putWorkloadendpointSlaac
+
+# Record SLAAC addresses of a pod interface
+
+Send a request to daemonset to record the IPv6 addresses derived from router advertisements on a pod interface
+*/
+type PutWorkloadendpointSlaac struct {
+	Context *middleware.Context
+	Handler PutWorkloadendpointSlaacHandler
+}
+
+func (o *PutWorkloadendpointSlaac) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewPutWorkloadendpointSlaacParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
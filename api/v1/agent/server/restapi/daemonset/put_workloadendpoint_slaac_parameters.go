@@ -0,0 +1,87 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/validate"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// NewPutWorkloadendpointSlaacParams creates a new PutWorkloadendpointSlaacParams object
+//
+// There are no default values defined in the spec.
+func NewPutWorkloadendpointSlaacParams() PutWorkloadendpointSlaacParams {
+
+	return PutWorkloadendpointSlaacParams{}
+}
+
+// PutWorkloadendpointSlaacParams contains all the bound params for the put workloadendpoint slaac operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters PutWorkloadendpointSlaac
+type PutWorkloadendpointSlaacParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  Required: true
+	  In: body
+	*/
+	SlaacAddresses *models.SlaacAddresses
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewPutWorkloadendpointSlaacParams() beforehand.
+func (o *PutWorkloadendpointSlaacParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	if runtime.HasBody(r) {
+		defer r.Body.Close()
+		var body models.SlaacAddresses
+		if err := route.Consumer.Consume(r.Body, &body); err != nil {
+			if err == io.EOF {
+				res = append(res, errors.Required("slaacAddresses", "body", ""))
+			} else {
+				res = append(res, errors.NewParseError("slaacAddresses", "body", "", err))
+			}
+		} else {
+			// validate body object
+			if err := body.Validate(route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			ctx := validate.WithOperationRequest(r.Context())
+			if err := body.ContextValidate(ctx, route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			if len(res) == 0 {
+				o.SlaacAddresses = &body
+			}
+		}
+	} else {
+		res = append(res, errors.Required("slaacAddresses", "body", ""))
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package daemonset
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+)
+
+// PutWorkloadendpointSlaacOKCode is the HTTP code returned for type PutWorkloadendpointSlaacOK
+const PutWorkloadendpointSlaacOKCode int = 200
+
+/*
+PutWorkloadendpointSlaacOK Success
+
+swagger:response putWorkloadendpointSlaacOK
+*/
+type PutWorkloadendpointSlaacOK struct {
+}
+
+// NewPutWorkloadendpointSlaacOK creates PutWorkloadendpointSlaacOK with default headers values
+func NewPutWorkloadendpointSlaacOK() *PutWorkloadendpointSlaacOK {
+
+	return &PutWorkloadendpointSlaacOK{}
+}
+
+// WriteResponse to the client
+func (o *PutWorkloadendpointSlaacOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(200)
+}
+
+// PutWorkloadendpointSlaacFailureCode is the HTTP code returned for type PutWorkloadendpointSlaacFailure
+const PutWorkloadendpointSlaacFailureCode int = 500
+
+/*
+PutWorkloadendpointSlaacFailure Record failure
+
+swagger:response putWorkloadendpointSlaacFailure
+*/
+type PutWorkloadendpointSlaacFailure struct {
+
+	/*
+	  In: Body
+	*/
+	Payload models.Error `json:"body,omitempty"`
+}
+
+// NewPutWorkloadendpointSlaacFailure creates PutWorkloadendpointSlaacFailure with default headers values
+func NewPutWorkloadendpointSlaacFailure() *PutWorkloadendpointSlaacFailure {
+
+	return &PutWorkloadendpointSlaacFailure{}
+}
+
+// WithPayload adds the payload to the put workloadendpoint slaac failure response
+func (o *PutWorkloadendpointSlaacFailure) WithPayload(payload models.Error) *PutWorkloadendpointSlaacFailure {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the put workloadendpoint slaac failure response
+func (o *PutWorkloadendpointSlaacFailure) SetPayload(payload models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *PutWorkloadendpointSlaacFailure) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(500)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
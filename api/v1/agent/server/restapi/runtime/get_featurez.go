@@ -0,0 +1,61 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetFeaturezHandlerFunc turns a function with the right signature into a get featurez handler
+type GetFeaturezHandlerFunc func(GetFeaturezParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetFeaturezHandlerFunc) Handle(params GetFeaturezParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetFeaturezHandler interface for that can handle valid get featurez params
+type GetFeaturezHandler interface {
+	Handle(GetFeaturezParams) middleware.Responder
+}
+
+// NewGetFeaturez creates a new http.Handler for the get featurez operation
+func NewGetFeaturez(ctx *middleware.Context, handler GetFeaturezHandler) *GetFeaturez {
+	return &GetFeaturez{Context: ctx, Handler: handler}
+}
+
+/*
+	GetFeaturez swagger:route GET /featurez runtime getFeaturez
+
+# Get feature gates
+
+Get the status of feature gates
+*/
+type GetFeaturez struct {
+	Context *middleware.Context
+	Handler GetFeaturezHandler
+}
+
+func (o *GetFeaturez) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetFeaturezParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -55,9 +55,18 @@ func NewSpiderpoolAgentAPIAPI(spec *loads.Document) *SpiderpoolAgentAPIAPI {
 		DaemonsetDeleteIpamIpsHandler: daemonset.DeleteIpamIpsHandlerFunc(func(params daemonset.DeleteIpamIpsParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.DeleteIpamIps has not yet been implemented")
 		}),
+		RuntimeGetFeaturezHandler: runtimeops.GetFeaturezHandlerFunc(func(params runtimeops.GetFeaturezParams) middleware.Responder {
+			return middleware.NotImplemented("operation runtime.GetFeaturez has not yet been implemented")
+		}),
 		ConnectivityGetIpamHealthyHandler: connectivity.GetIpamHealthyHandlerFunc(func(params connectivity.GetIpamHealthyParams) middleware.Responder {
 			return middleware.NotImplemented("operation connectivity.GetIpamHealthy has not yet been implemented")
 		}),
+		DaemonsetGetIpamIPInuseHandler: daemonset.GetIpamIPInuseHandlerFunc(func(params daemonset.GetIpamIPInuseParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.GetIpamIPInuse has not yet been implemented")
+		}),
+		DaemonsetGetIpamIpsHandler: daemonset.GetIpamIpsHandlerFunc(func(params daemonset.GetIpamIpsParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.GetIpamIps has not yet been implemented")
+		}),
 		RuntimeGetRuntimeLivenessHandler: runtimeops.GetRuntimeLivenessHandlerFunc(func(params runtimeops.GetRuntimeLivenessParams) middleware.Responder {
 			return middleware.NotImplemented("operation runtime.GetRuntimeLiveness has not yet been implemented")
 		}),
@@ -76,6 +85,9 @@ func NewSpiderpoolAgentAPIAPI(spec *loads.Document) *SpiderpoolAgentAPIAPI {
 		DaemonsetPostIpamIpsHandler: daemonset.PostIpamIpsHandlerFunc(func(params daemonset.PostIpamIpsParams) middleware.Responder {
 			return middleware.NotImplemented("operation daemonset.PostIpamIps has not yet been implemented")
 		}),
+		DaemonsetPutWorkloadendpointSlaacHandler: daemonset.PutWorkloadendpointSlaacHandlerFunc(func(params daemonset.PutWorkloadendpointSlaacParams) middleware.Responder {
+			return middleware.NotImplemented("operation daemonset.PutWorkloadendpointSlaac has not yet been implemented")
+		}),
 	}
 }
 
@@ -116,8 +128,14 @@ type SpiderpoolAgentAPIAPI struct {
 	DaemonsetDeleteIpamIPHandler daemonset.DeleteIpamIPHandler
 	// DaemonsetDeleteIpamIpsHandler sets the operation handler for the delete ipam ips operation
 	DaemonsetDeleteIpamIpsHandler daemonset.DeleteIpamIpsHandler
+	// RuntimeGetFeaturezHandler sets the operation handler for the get featurez operation
+	RuntimeGetFeaturezHandler runtimeops.GetFeaturezHandler
 	// ConnectivityGetIpamHealthyHandler sets the operation handler for the get ipam healthy operation
 	ConnectivityGetIpamHealthyHandler connectivity.GetIpamHealthyHandler
+	// DaemonsetGetIpamIPInuseHandler sets the operation handler for the get ipam IP inuse operation
+	DaemonsetGetIpamIPInuseHandler daemonset.GetIpamIPInuseHandler
+	// DaemonsetGetIpamIpsHandler sets the operation handler for the get ipam ips operation
+	DaemonsetGetIpamIpsHandler daemonset.GetIpamIpsHandler
 	// RuntimeGetRuntimeLivenessHandler sets the operation handler for the get runtime liveness operation
 	RuntimeGetRuntimeLivenessHandler runtimeops.GetRuntimeLivenessHandler
 	// RuntimeGetRuntimeReadinessHandler sets the operation handler for the get runtime readiness operation
@@ -130,6 +148,8 @@ type SpiderpoolAgentAPIAPI struct {
 	DaemonsetPostIpamIPHandler daemonset.PostIpamIPHandler
 	// DaemonsetPostIpamIpsHandler sets the operation handler for the post ipam ips operation
 	DaemonsetPostIpamIpsHandler daemonset.PostIpamIpsHandler
+	// DaemonsetPutWorkloadendpointSlaacHandler sets the operation handler for the put workloadendpoint slaac operation
+	DaemonsetPutWorkloadendpointSlaacHandler daemonset.PutWorkloadendpointSlaacHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -213,9 +233,18 @@ func (o *SpiderpoolAgentAPIAPI) Validate() error {
 	if o.DaemonsetDeleteIpamIpsHandler == nil {
 		unregistered = append(unregistered, "daemonset.DeleteIpamIpsHandler")
 	}
+	if o.RuntimeGetFeaturezHandler == nil {
+		unregistered = append(unregistered, "runtime.GetFeaturezHandler")
+	}
 	if o.ConnectivityGetIpamHealthyHandler == nil {
 		unregistered = append(unregistered, "connectivity.GetIpamHealthyHandler")
 	}
+	if o.DaemonsetGetIpamIPInuseHandler == nil {
+		unregistered = append(unregistered, "daemonset.GetIpamIPInuseHandler")
+	}
+	if o.DaemonsetGetIpamIpsHandler == nil {
+		unregistered = append(unregistered, "daemonset.GetIpamIpsHandler")
+	}
 	if o.RuntimeGetRuntimeLivenessHandler == nil {
 		unregistered = append(unregistered, "runtime.GetRuntimeLivenessHandler")
 	}
@@ -234,6 +263,9 @@ func (o *SpiderpoolAgentAPIAPI) Validate() error {
 	if o.DaemonsetPostIpamIpsHandler == nil {
 		unregistered = append(unregistered, "daemonset.PostIpamIpsHandler")
 	}
+	if o.DaemonsetPutWorkloadendpointSlaacHandler == nil {
+		unregistered = append(unregistered, "daemonset.PutWorkloadendpointSlaacHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -333,10 +365,22 @@ func (o *SpiderpoolAgentAPIAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/featurez"] = runtimeops.NewGetFeaturez(o.context, o.RuntimeGetFeaturezHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/ipam/healthy"] = connectivity.NewGetIpamHealthy(o.context, o.ConnectivityGetIpamHealthyHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/ipam/ip/inuse"] = daemonset.NewGetIpamIPInuse(o.context, o.DaemonsetGetIpamIPInuseHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ipam/ips"] = daemonset.NewGetIpamIps(o.context, o.DaemonsetGetIpamIpsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/runtime/liveness"] = runtimeops.NewGetRuntimeLiveness(o.context, o.RuntimeGetRuntimeLivenessHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -358,6 +402,10 @@ func (o *SpiderpoolAgentAPIAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/ipam/ips"] = daemonset.NewPostIpamIps(o.context, o.DaemonsetPostIpamIpsHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/workloadendpoint/slaac"] = daemonset.NewPutWorkloadendpointSlaac(o.context, o.DaemonsetPutWorkloadendpointSlaacHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -33,15 +33,240 @@ type ClientOption func(*runtime.ClientOperation)
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	DeleteIpamEgressip(params *DeleteIpamEgressipParams, opts ...ClientOption) (*DeleteIpamEgressipOK, error)
+
+	GetIpamCapacity(params *GetIpamCapacityParams, opts ...ClientOption) (*GetIpamCapacityOK, error)
+
+	GetIpamConsumers(params *GetIpamConsumersParams, opts ...ClientOption) (*GetIpamConsumersOK, error)
+
+	GetIpamExplain(params *GetIpamExplainParams, opts ...ClientOption) (*GetIpamExplainOK, error)
+
+	GetIpamGcCandidates(params *GetIpamGcCandidatesParams, opts ...ClientOption) (*GetIpamGcCandidatesOK, error)
+
 	GetIpamStatus(params *GetIpamStatusParams, opts ...ClientOption) (*GetIpamStatusOK, error)
 
+	PostIpamEgressip(params *PostIpamEgressipParams, opts ...ClientOption) (*PostIpamEgressipOK, error)
+
 	PostIpamGcIps(params *PostIpamGcIpsParams, opts ...ClientOption) (*PostIpamGcIpsOK, error)
 
+	PostIpamPreprovision(params *PostIpamPreprovisionParams, opts ...ClientOption) (*PostIpamPreprovisionOK, error)
+
 	PutIpamIP(params *PutIpamIPParams, opts ...ClientOption) (*PutIpamIPOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+	DeleteIpamEgressip releases egress i ps
+
+	Release the IP addresses of the IPPool reserved for an egress gateway
+
+object
+*/
+func (a *Client) DeleteIpamEgressip(params *DeleteIpamEgressipParams, opts ...ClientOption) (*DeleteIpamEgressipOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewDeleteIpamEgressipParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "DeleteIpamEgressip",
+		Method:             "DELETE",
+		PathPattern:        "/ipam/egressip",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &DeleteIpamEgressipReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*DeleteIpamEgressipOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for DeleteIpamEgressip: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+	GetIpamCapacity gets capacity
+
+	Get the free or used IPs of a SpiderSubnet or SpiderIPPool, summarized
+
+into CIDRs and paginated
+*/
+func (a *Client) GetIpamCapacity(params *GetIpamCapacityParams, opts ...ClientOption) (*GetIpamCapacityOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamCapacityParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetIpamCapacity",
+		Method:             "GET",
+		PathPattern:        "/ipam/capacity",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamCapacityReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamCapacityOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpamCapacity: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+	GetIpamConsumers gets top consumers
+
+	Get the applications consuming the most IPs of a SpiderSubnet or
+
+SpiderIPPool
+*/
+func (a *Client) GetIpamConsumers(params *GetIpamConsumersParams, opts ...ClientOption) (*GetIpamConsumersOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamConsumersParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetIpamConsumers",
+		Method:             "GET",
+		PathPattern:        "/ipam/consumers",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamConsumersReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamConsumersOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpamConsumers: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+	GetIpamExplain explains IP pool selection
+
+	Replay the selection of candidate IPPools for a NIC of a Pod, and
+
+explain why each IPPool is accepted or rejected
+*/
+func (a *Client) GetIpamExplain(params *GetIpamExplainParams, opts ...ClientOption) (*GetIpamExplainOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamExplainParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetIpamExplain",
+		Method:             "GET",
+		PathPattern:        "/ipam/explain",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamExplainReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamExplainOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpamExplain: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
+/*
+	GetIpamGcCandidates gets garbage collection candidates
+
+	Get the Pods traced by the IP garbage collection with the deadlines
+
+to reclaim their IPs, only the elected spiderpool-controller traces
+the Pods
+*/
+func (a *Client) GetIpamGcCandidates(params *GetIpamGcCandidatesParams, opts ...ClientOption) (*GetIpamGcCandidatesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetIpamGcCandidatesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetIpamGcCandidates",
+		Method:             "GET",
+		PathPattern:        "/ipam/gc_candidates",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetIpamGcCandidatesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetIpamGcCandidatesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for GetIpamGcCandidates: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 GetIpamStatus gets status
 
@@ -82,6 +307,50 @@ func (a *Client) GetIpamStatus(params *GetIpamStatusParams, opts ...ClientOption
 	panic(msg)
 }
 
+/*
+	PostIpamEgressip reserves an egress IP
+
+	Reserve an IP address of the IPPool for an egress gateway object,
+
+which is no longer allocated to any Pod. The IP address reserved for
+the object before is returned if any. The reservation is released
+once the object is gone
+*/
+func (a *Client) PostIpamEgressip(params *PostIpamEgressipParams, opts ...ClientOption) (*PostIpamEgressipOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostIpamEgressipParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostIpamEgressip",
+		Method:             "POST",
+		PathPattern:        "/ipam/egressip",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostIpamEgressipReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostIpamEgressipOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostIpamEgressip: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PostIpamGcIps triggers gc
 
@@ -122,6 +391,49 @@ func (a *Client) PostIpamGcIps(params *PostIpamGcIpsParams, opts ...ClientOption
 	panic(msg)
 }
 
+/*
+	PostIpamPreprovision pres provision auto created IP pools
+
+	Create the auto-created IPPools of SpiderSubnet for the applications
+
+before they are created, so that their first Pods don't wait for the
+creation of the IPPools
+*/
+func (a *Client) PostIpamPreprovision(params *PostIpamPreprovisionParams, opts ...ClientOption) (*PostIpamPreprovisionOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostIpamPreprovisionParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostIpamPreprovision",
+		Method:             "POST",
+		PathPattern:        "/ipam/preprovision",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostIpamPreprovisionReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostIpamPreprovisionOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	// safeguard: normally, absent a default response, unknown success responses return an error above: so this is a codegen issue
+	msg := fmt.Sprintf("unexpected success response for PostIpamPreprovision: API contract not enforced by server. Client expected to get an error, but got: %T", result)
+	panic(msg)
+}
+
 /*
 PutIpamIP forces set ip
 
@@ -0,0 +1,99 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+)
+
+// GetFeaturezReader is a Reader for the GetFeaturez structure.
+type GetFeaturezReader struct {
+	formats strfmt.Registry
+}
+
+// ReadResponse reads a server response into the received o.
+func (o *GetFeaturezReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	switch response.Code() {
+	case 200:
+		result := NewGetFeaturezOK()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return result, nil
+	default:
+		return nil, runtime.NewAPIError("response status code does not match any response statuses defined for this endpoint in the swagger spec", response, response.Code())
+	}
+}
+
+// NewGetFeaturezOK creates a GetFeaturezOK with default headers values
+func NewGetFeaturezOK() *GetFeaturezOK {
+	return &GetFeaturezOK{}
+}
+
+/*
+GetFeaturezOK describes a response with status code 200, with default header values.
+
+Success
+*/
+type GetFeaturezOK struct {
+	Payload []*models.FeatureGate
+}
+
+// IsSuccess returns true when this get featurez o k response has a 2xx status code
+func (o *GetFeaturezOK) IsSuccess() bool {
+	return true
+}
+
+// IsRedirect returns true when this get featurez o k response has a 3xx status code
+func (o *GetFeaturezOK) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this get featurez o k response has a 4xx status code
+func (o *GetFeaturezOK) IsClientError() bool {
+	return false
+}
+
+// IsServerError returns true when this get featurez o k response has a 5xx status code
+func (o *GetFeaturezOK) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this get featurez o k response a status code equal to that given
+func (o *GetFeaturezOK) IsCode(code int) bool {
+	return code == 200
+}
+
+func (o *GetFeaturezOK) Error() string {
+	return fmt.Sprintf("[GET /featurez][%d] getFeaturezOK  %+v", 200, o.Payload)
+}
+
+func (o *GetFeaturezOK) String() string {
+	return fmt.Sprintf("[GET /featurez][%d] getFeaturezOK  %+v", 200, o.Payload)
+}
+
+func (o *GetFeaturezOK) GetPayload() []*models.FeatureGate {
+	return o.Payload
+}
+
+func (o *GetFeaturezOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response payload
+	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
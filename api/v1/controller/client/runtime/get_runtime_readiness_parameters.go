@@ -17,6 +17,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewGetRuntimeReadinessParams creates a new GetRuntimeReadinessParams object,
@@ -63,6 +64,10 @@ GetRuntimeReadinessParams contains all the parameters to send to the API endpoin
 	Typically these are written to a http.Request.
 */
 type GetRuntimeReadinessParams struct {
+
+	// Verbose.
+	Verbose *bool
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -80,7 +85,18 @@ func (o *GetRuntimeReadinessParams) WithDefaults() *GetRuntimeReadinessParams {
 //
 // All values with no default are reset to their zero value.
 func (o *GetRuntimeReadinessParams) SetDefaults() {
-	// no default values defined for this parameter
+	var (
+		verboseDefault = bool(false)
+	)
+
+	val := GetRuntimeReadinessParams{
+		Verbose: &verboseDefault,
+	}
+
+	val.timeout = o.timeout
+	val.Context = o.Context
+	val.HTTPClient = o.HTTPClient
+	*o = val
 }
 
 // WithTimeout adds the timeout to the get runtime readiness params
@@ -116,6 +132,17 @@ func (o *GetRuntimeReadinessParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithVerbose adds the verbose to the get runtime readiness params
+func (o *GetRuntimeReadinessParams) WithVerbose(verbose *bool) *GetRuntimeReadinessParams {
+	o.SetVerbose(verbose)
+	return o
+}
+
+// SetVerbose adds the verbose to the get runtime readiness params
+func (o *GetRuntimeReadinessParams) SetVerbose(verbose *bool) {
+	o.Verbose = verbose
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetRuntimeReadinessParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -124,6 +151,23 @@ func (o *GetRuntimeReadinessParams) WriteToRequest(r runtime.ClientRequest, reg
 	}
 	var res []error
 
+	if o.Verbose != nil {
+
+		// query param verbose
+		var qrVerbose bool
+
+		if o.Verbose != nil {
+			qrVerbose = *o.Verbose
+		}
+		qVerbose := swag.FormatBool(qrVerbose)
+		if qVerbose != "" {
+
+			if err := r.SetQueryParam("verbose", qVerbose); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -10,9 +10,12 @@ package runtime
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // GetRuntimeReadinessReader is a Reader for the GetRuntimeReadiness structure.
@@ -51,6 +54,7 @@ GetRuntimeReadinessOK describes a response with status code 200, with default he
 Success
 */
 type GetRuntimeReadinessOK struct {
+	Payload *models.Readiness
 }
 
 // IsSuccess returns true when this get runtime readiness o k response has a 2xx status code
@@ -79,15 +83,26 @@ func (o *GetRuntimeReadinessOK) IsCode(code int) bool {
 }
 
 func (o *GetRuntimeReadinessOK) Error() string {
-	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessOK ", 200)
+	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessOK  %+v", 200, o.Payload)
 }
 
 func (o *GetRuntimeReadinessOK) String() string {
-	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessOK ", 200)
+	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessOK  %+v", 200, o.Payload)
+}
+
+func (o *GetRuntimeReadinessOK) GetPayload() *models.Readiness {
+	return o.Payload
 }
 
 func (o *GetRuntimeReadinessOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	o.Payload = new(models.Readiness)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
 	return nil
 }
 
@@ -102,6 +117,7 @@ GetRuntimeReadinessInternalServerError describes a response with status code 500
 Failed
 */
 type GetRuntimeReadinessInternalServerError struct {
+	Payload *models.Readiness
 }
 
 // IsSuccess returns true when this get runtime readiness internal server error response has a 2xx status code
@@ -130,14 +146,25 @@ func (o *GetRuntimeReadinessInternalServerError) IsCode(code int) bool {
 }
 
 func (o *GetRuntimeReadinessInternalServerError) Error() string {
-	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessInternalServerError ", 500)
+	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessInternalServerError  %+v", 500, o.Payload)
 }
 
 func (o *GetRuntimeReadinessInternalServerError) String() string {
-	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessInternalServerError ", 500)
+	return fmt.Sprintf("[GET /runtime/readiness][%d] getRuntimeReadinessInternalServerError  %+v", 500, o.Payload)
+}
+
+func (o *GetRuntimeReadinessInternalServerError) GetPayload() *models.Readiness {
+	return o.Payload
 }
 
 func (o *GetRuntimeReadinessInternalServerError) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	o.Payload = new(models.Readiness)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
 	return nil
 }
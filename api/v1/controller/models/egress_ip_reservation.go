@@ -0,0 +1,131 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// EgressIPReservation Egress gateway object to reserve an IP address of the IPPool for
+//
+// swagger:model EgressIPReservation
+type EgressIPReservation struct {
+
+	// api version
+	// Required: true
+	APIVersion *string `json:"apiVersion"`
+
+	// ippool
+	// Required: true
+	Ippool *string `json:"ippool"`
+
+	// kind
+	// Required: true
+	Kind *string `json:"kind"`
+
+	// name
+	// Required: true
+	Name *string `json:"name"`
+
+	// Empty for the cluster scope objects
+	Namespace string `json:"namespace,omitempty"`
+
+	// The reservation is released once the UID of the object mismatches, if specified
+	UID string `json:"uid,omitempty"`
+}
+
+// Validate validates this egress IP reservation
+func (m *EgressIPReservation) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAPIVersion(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateIppool(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateKind(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *EgressIPReservation) validateAPIVersion(formats strfmt.Registry) error {
+
+	if err := validate.Required("apiVersion", "body", m.APIVersion); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *EgressIPReservation) validateIppool(formats strfmt.Registry) error {
+
+	if err := validate.Required("ippool", "body", m.Ippool); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *EgressIPReservation) validateKind(formats strfmt.Registry) error {
+
+	if err := validate.Required("kind", "body", m.Kind); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *EgressIPReservation) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("name", "body", m.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this egress IP reservation based on context it is used
+func (m *EgressIPReservation) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *EgressIPReservation) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *EgressIPReservation) UnmarshalBinary(b []byte) error {
+	var res EgressIPReservation
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
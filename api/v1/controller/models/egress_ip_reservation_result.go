@@ -0,0 +1,53 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// EgressIPReservationResult IP address reserved for the egress gateway object
+//
+// swagger:model EgressIPReservationResult
+type EgressIPReservationResult struct {
+
+	// ip
+	IP string `json:"ip,omitempty"`
+}
+
+// Validate validates this egress IP reservation result
+func (m *EgressIPReservationResult) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this egress IP reservation result based on context it is used
+func (m *EgressIPReservationResult) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *EgressIPReservationResult) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *EgressIPReservationResult) UnmarshalBinary(b []byte) error {
+	var res EgressIPReservationResult
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
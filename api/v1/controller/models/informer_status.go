@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// InformerStatus Lag and queue depth of an informer
+//
+// swagger:model InformerStatus
+type InformerStatus struct {
+
+	// event lag seconds
+	EventLagSeconds int64 `json:"eventLagSeconds,omitempty"`
+
+	// last event age seconds
+	LastEventAgeSeconds int64 `json:"lastEventAgeSeconds,omitempty"`
+
+	// name
+	Name string `json:"name,omitempty"`
+
+	// queue depth
+	QueueDepth int64 `json:"queueDepth,omitempty"`
+}
+
+// Validate validates this informer status
+func (m *InformerStatus) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this informer status based on context it is used
+func (m *InformerStatus) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *InformerStatus) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *InformerStatus) UnmarshalBinary(b []byte) error {
+	var res InformerStatus
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,65 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// PoolDecision Why a candidate IPPool is accepted or rejected
+//
+// swagger:model PoolDecision
+type PoolDecision struct {
+
+	// accepted
+	Accepted bool `json:"accepted,omitempty"`
+
+	// ip version
+	IPVersion int64 `json:"ipVersion,omitempty"`
+
+	// Empty if the auto-created IPPool of SpiderSubnet is not created yet
+	Ippool string `json:"ippool,omitempty"`
+
+	// nic
+	Nic string `json:"nic,omitempty"`
+
+	// reason
+	Reason string `json:"reason,omitempty"`
+}
+
+// Validate validates this pool decision
+func (m *PoolDecision) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this pool decision based on context it is used
+func (m *PoolDecision) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *PoolDecision) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *PoolDecision) UnmarshalBinary(b []byte) error {
+	var res PoolDecision
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
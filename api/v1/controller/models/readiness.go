@@ -0,0 +1,122 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// Readiness Readiness of spiderpool-controller with the status of its informers
+//
+// swagger:model Readiness
+type Readiness struct {
+
+	// informers
+	Informers []*InformerStatus `json:"informers"`
+
+	// reason
+	Reason string `json:"reason,omitempty"`
+}
+
+// Validate validates this readiness
+func (m *Readiness) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateInformers(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Readiness) validateInformers(formats strfmt.Registry) error {
+	if swag.IsZero(m.Informers) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Informers); i++ {
+		if swag.IsZero(m.Informers[i]) { // not required
+			continue
+		}
+
+		if m.Informers[i] != nil {
+			if err := m.Informers[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("informers" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("informers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this readiness based on the context it is used
+func (m *Readiness) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateInformers(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *Readiness) contextValidateInformers(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Informers); i++ {
+
+		if m.Informers[i] != nil {
+			if err := m.Informers[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("informers" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("informers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *Readiness) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *Readiness) UnmarshalBinary(b []byte) error {
+	var res Readiness
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -42,6 +42,36 @@ func configureAPI(api *restapi.SpiderpoolControllerAPIAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	if api.ControllerDeleteIpamEgressipHandler == nil {
+		api.ControllerDeleteIpamEgressipHandler = controller.DeleteIpamEgressipHandlerFunc(func(params controller.DeleteIpamEgressipParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.DeleteIpamEgressip has not yet been implemented")
+		})
+	}
+	if api.RuntimeGetFeaturezHandler == nil {
+		api.RuntimeGetFeaturezHandler = runtimeops.GetFeaturezHandlerFunc(func(params runtimeops.GetFeaturezParams) middleware.Responder {
+			return middleware.NotImplemented("operation runtime.GetFeaturez has not yet been implemented")
+		})
+	}
+	if api.ControllerGetIpamCapacityHandler == nil {
+		api.ControllerGetIpamCapacityHandler = controller.GetIpamCapacityHandlerFunc(func(params controller.GetIpamCapacityParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamCapacity has not yet been implemented")
+		})
+	}
+	if api.ControllerGetIpamConsumersHandler == nil {
+		api.ControllerGetIpamConsumersHandler = controller.GetIpamConsumersHandlerFunc(func(params controller.GetIpamConsumersParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamConsumers has not yet been implemented")
+		})
+	}
+	if api.ControllerGetIpamExplainHandler == nil {
+		api.ControllerGetIpamExplainHandler = controller.GetIpamExplainHandlerFunc(func(params controller.GetIpamExplainParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamExplain has not yet been implemented")
+		})
+	}
+	if api.ControllerGetIpamGcCandidatesHandler == nil {
+		api.ControllerGetIpamGcCandidatesHandler = controller.GetIpamGcCandidatesHandlerFunc(func(params controller.GetIpamGcCandidatesParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamGcCandidates has not yet been implemented")
+		})
+	}
 	if api.ControllerGetIpamStatusHandler == nil {
 		api.ControllerGetIpamStatusHandler = controller.GetIpamStatusHandlerFunc(func(params controller.GetIpamStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.GetIpamStatus has not yet been implemented")
@@ -62,11 +92,21 @@ func configureAPI(api *restapi.SpiderpoolControllerAPIAPI) http.Handler {
 			return middleware.NotImplemented("operation runtime.GetRuntimeStartup has not yet been implemented")
 		})
 	}
+	if api.ControllerPostIpamEgressipHandler == nil {
+		api.ControllerPostIpamEgressipHandler = controller.PostIpamEgressipHandlerFunc(func(params controller.PostIpamEgressipParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.PostIpamEgressip has not yet been implemented")
+		})
+	}
 	if api.ControllerPostIpamGcIpsHandler == nil {
 		api.ControllerPostIpamGcIpsHandler = controller.PostIpamGcIpsHandlerFunc(func(params controller.PostIpamGcIpsParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.PostIpamGcIps has not yet been implemented")
 		})
 	}
+	if api.ControllerPostIpamPreprovisionHandler == nil {
+		api.ControllerPostIpamPreprovisionHandler = controller.PostIpamPreprovisionHandlerFunc(func(params controller.PostIpamPreprovisionParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.PostIpamPreprovision has not yet been implemented")
+		})
+	}
 	if api.ControllerPutIpamIPHandler == nil {
 		api.ControllerPutIpamIPHandler = controller.PutIpamIPHandlerFunc(func(params controller.PutIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.PutIpamIP has not yet been implemented")
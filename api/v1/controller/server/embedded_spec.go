@@ -35,6 +35,428 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/featurez": {
+      "get": {
+        "description": "Get the status of feature gates",
+        "tags": [
+          "runtime"
+        ],
+        "summary": "Get feature gates",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/FeatureGate"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/ipam/capacity": {
+      "get": {
+        "description": "Get the free or used IPs of a SpiderSubnet or SpiderIPPool, summarized\ninto CIDRs and paginated\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Get capacity",
+        "parameters": [
+          {
+            "enum": [
+              "SpiderSubnet",
+              "SpiderIPPool"
+            ],
+            "type": "string",
+            "name": "kind",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "name",
+            "in": "query",
+            "required": true
+          },
+          {
+            "enum": [
+              "free",
+              "used"
+            ],
+            "type": "string",
+            "default": "free",
+            "name": "state",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "continue",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "tenant",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Capacity"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Get capacity failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/consumers": {
+      "get": {
+        "description": "Get the applications consuming the most IPs of a SpiderSubnet or\nSpiderIPPool\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Get top consumers",
+        "parameters": [
+          {
+            "enum": [
+              "SpiderSubnet",
+              "SpiderIPPool"
+            ],
+            "type": "string",
+            "name": "kind",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "name",
+            "in": "query",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 10,
+            "name": "top",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "tenant",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Consumers"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Get consumers failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/egressip": {
+      "post": {
+        "description": "Reserve an IP address of the IPPool for an egress gateway object,\nwhich is no longer allocated to any Pod. The IP address reserved for\nthe object before is returned if any. The reservation is released\nonce the object is gone\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Reserve an egress IP",
+        "parameters": [
+          {
+            "name": "reservation",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/EgressIPReservation"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/EgressIPReservationResult"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to update the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Reserve egress IP failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
+      "delete": {
+        "description": "Release the IP addresses of the IPPool reserved for an egress gateway\nobject\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Release egress IPs",
+        "parameters": [
+          {
+            "name": "reservation",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/EgressIPReservation"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to update the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Release egress IPs failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/explain": {
+      "get": {
+        "description": "Replay the selection of candidate IPPools for a NIC of a Pod, and\nexplain why each IPPool is accepted or rejected\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Explain IPPool selection",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "namespace",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "pod",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "default": "eth0",
+            "name": "nic",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PoolExplanation"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Explain IPPool selection failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/gc_candidates": {
+      "get": {
+        "description": "Get the Pods traced by the IP garbage collection with the deadlines\nto reclaim their IPs, only the elected spiderpool-controller traces\nthe Pods\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Get garbage collection candidates",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/GcCandidates"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Get IP GC candidates failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/gc_ips": {
       "post": {
         "description": "Trigger global gc or specific ip gc with the param\n",
@@ -69,6 +491,59 @@ func init() {
         }
       }
     },
+    "/ipam/preprovision": {
+      "post": {
+        "description": "Create the auto-created IPPools of SpiderSubnet for the applications\nbefore they are created, so that their first Pods don't wait for the\ncreation of the IPPools\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Pre-provision auto-created IPPools",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/PreProvisionRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PreProvisionResult"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to create the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Pre-provision IPPools failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/status": {
       "get": {
         "description": "Get ipam status for spiderpool controller cli debug usage\n",
@@ -105,17 +580,31 @@ func init() {
     },
     "/runtime/readiness": {
       "get": {
-        "description": "Check pod readiness probe",
+        "description": "Check pod readiness probe, with the lag and queue depth of the\ninformers if verbose\n",
         "tags": [
           "runtime"
         ],
         "summary": "Readiness probe",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "name": "verbose",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Readiness"
+            }
           },
           "500": {
-            "description": "Failed"
+            "description": "Failed",
+            "schema": {
+              "$ref": "#/definitions/Readiness"
+            }
           }
         }
       }
@@ -138,6 +627,388 @@ func init() {
       }
     }
   },
+  "definitions": {
+    "Capacity": {
+      "description": "Free or used IPs of a SpiderSubnet or SpiderIPPool summarized into CIDRs",
+      "type": "object",
+      "properties": {
+        "cidrs": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "continue": {
+          "description": "Token to get the next page, empty for the last page",
+          "type": "string"
+        },
+        "freeIPCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ipVersion": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "state": {
+          "type": "string"
+        },
+        "totalIPCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "usedIPCount": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Consumer": {
+      "description": "Application consuming IPs, or Pod not controlled by any application",
+      "type": "object",
+      "properties": {
+        "ipCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        }
+      }
+    },
+    "Consumers": {
+      "description": "Applications consuming the most IPs of a SpiderSubnet or SpiderIPPool",
+      "type": "object",
+      "properties": {
+        "allocatedIPCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "consumers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Consumer"
+          }
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        }
+      }
+    },
+    "EgressIPReservation": {
+      "description": "Egress gateway object to reserve an IP address of the IPPool for",
+      "type": "object",
+      "required": [
+        "ippool",
+        "apiVersion",
+        "kind",
+        "name"
+      ],
+      "properties": {
+        "apiVersion": {
+          "type": "string"
+        },
+        "ippool": {
+          "type": "string"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Empty for the cluster scope objects",
+          "type": "string"
+        },
+        "uid": {
+          "description": "The reservation is released once the UID of the object mismatches, if specified",
+          "type": "string"
+        }
+      }
+    },
+    "EgressIPReservationResult": {
+      "description": "IP address reserved for the egress gateway object",
+      "type": "object",
+      "properties": {
+        "ip": {
+          "type": "string"
+        }
+      }
+    },
+    "Error": {
+      "description": "API error",
+      "type": "string"
+    },
+    "FeatureGate": {
+      "description": "Feature gate status",
+      "type": "object",
+      "properties": {
+        "default": {
+          "type": "boolean"
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "preRelease": {
+          "type": "string"
+        }
+      }
+    },
+    "GcCandidate": {
+      "description": "Pod traced by the IP garbage collection",
+      "type": "object",
+      "properties": {
+        "deadline": {
+          "description": "Time after which the IPs of the Pod are reclaimed",
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "reason": {
+          "description": "Status of the Pod which makes it traced",
+          "type": "string"
+        },
+        "tracingStartTime": {
+          "type": "string"
+        }
+      }
+    },
+    "GcCandidates": {
+      "description": "Pods traced by the IP garbage collection",
+      "type": "object",
+      "properties": {
+        "candidates": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/GcCandidate"
+          }
+        },
+        "elected": {
+          "description": "Whether the spiderpool-controller is elected to trace the Pods",
+          "type": "boolean"
+        },
+        "queueDepth": {
+          "description": "Number of the timed out Pods waiting for the workers to reclaim their IPs",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "InformerStatus": {
+      "description": "Lag and queue depth of an informer",
+      "type": "object",
+      "properties": {
+        "eventLagSeconds": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "lastEventAgeSeconds": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "queueDepth": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "PoolDecision": {
+      "description": "Why a candidate IPPool is accepted or rejected",
+      "type": "object",
+      "properties": {
+        "accepted": {
+          "type": "boolean"
+        },
+        "ipVersion": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ippool": {
+          "description": "Empty if the auto-created IPPool of SpiderSubnet is not created yet",
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    },
+    "PoolExplanation": {
+      "description": "Replay of the candidate IPPool selection for a NIC of a Pod",
+      "type": "object",
+      "properties": {
+        "decisions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PoolDecision"
+          }
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "source": {
+          "description": "Rule which selects the candidate IPPools",
+          "type": "string"
+        }
+      }
+    },
+    "PreProvisionApplication": {
+      "description": "Application to pre-provision the auto-created IPPools for",
+      "type": "object",
+      "required": [
+        "kind",
+        "namespace",
+        "name"
+      ],
+      "properties": {
+        "interface": {
+          "type": "string",
+          "default": "eth0"
+        },
+        "ipv4Subnet": {
+          "type": "string"
+        },
+        "ipv6Subnet": {
+          "type": "string"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "reclaimIPPool": {
+          "type": "boolean",
+          "default": true
+        },
+        "replicas": {
+          "description": "Number of the Pods of the application",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "PreProvisionRequest": {
+      "description": "Applications to pre-provision the auto-created IPPools for",
+      "type": "object",
+      "properties": {
+        "applications": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PreProvisionApplication"
+          }
+        }
+      }
+    },
+    "PreProvisionResult": {
+      "description": "Auto-created IPPools pre-provisioned for the applications",
+      "type": "object",
+      "properties": {
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PreProvisionedPool"
+          }
+        }
+      }
+    },
+    "PreProvisionedPool": {
+      "description": "Auto-created IPPool pre-provisioned for an application",
+      "type": "object",
+      "properties": {
+        "created": {
+          "description": "False if the IPPool already exists",
+          "type": "boolean"
+        },
+        "error": {
+          "type": "string"
+        },
+        "ipCount": {
+          "description": "IP number desired by the IPPool",
+          "type": "integer",
+          "format": "int64"
+        },
+        "ipVersion": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ippool": {
+          "type": "string"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "reason": {
+          "description": "Class of the error, one of PoolExhausted, OwnerNotFound and Overlap, empty for the others",
+          "type": "string"
+        },
+        "subnet": {
+          "type": "string"
+        }
+      }
+    },
+    "Readiness": {
+      "description": "Readiness of spiderpool-controller with the status of its informers",
+      "type": "object",
+      "properties": {
+        "informers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/InformerStatus"
+          }
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    }
+  },
   "x-schemes": [
     "http"
   ]
@@ -157,6 +1028,428 @@ func init() {
   },
   "basePath": "/v1",
   "paths": {
+    "/featurez": {
+      "get": {
+        "description": "Get the status of feature gates",
+        "tags": [
+          "runtime"
+        ],
+        "summary": "Get feature gates",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/FeatureGate"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/ipam/capacity": {
+      "get": {
+        "description": "Get the free or used IPs of a SpiderSubnet or SpiderIPPool, summarized\ninto CIDRs and paginated\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Get capacity",
+        "parameters": [
+          {
+            "enum": [
+              "SpiderSubnet",
+              "SpiderIPPool"
+            ],
+            "type": "string",
+            "name": "kind",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "name",
+            "in": "query",
+            "required": true
+          },
+          {
+            "enum": [
+              "free",
+              "used"
+            ],
+            "type": "string",
+            "default": "free",
+            "name": "state",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 100,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "continue",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "tenant",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Capacity"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Get capacity failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/consumers": {
+      "get": {
+        "description": "Get the applications consuming the most IPs of a SpiderSubnet or\nSpiderIPPool\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Get top consumers",
+        "parameters": [
+          {
+            "enum": [
+              "SpiderSubnet",
+              "SpiderIPPool"
+            ],
+            "type": "string",
+            "name": "kind",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "name",
+            "in": "query",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 10,
+            "name": "top",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "tenant",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Consumers"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Get consumers failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/egressip": {
+      "post": {
+        "description": "Reserve an IP address of the IPPool for an egress gateway object,\nwhich is no longer allocated to any Pod. The IP address reserved for\nthe object before is returned if any. The reservation is released\nonce the object is gone\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Reserve an egress IP",
+        "parameters": [
+          {
+            "name": "reservation",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/EgressIPReservation"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/EgressIPReservationResult"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to update the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Reserve egress IP failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      },
+      "delete": {
+        "description": "Release the IP addresses of the IPPool reserved for an egress gateway\nobject\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Release egress IPs",
+        "parameters": [
+          {
+            "name": "reservation",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/EgressIPReservation"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to update the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Release egress IPs failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/explain": {
+      "get": {
+        "description": "Replay the selection of candidate IPPools for a NIC of a Pod, and\nexplain why each IPPool is accepted or rejected\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Explain IPPool selection",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "namespace",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "name": "pod",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "string",
+            "default": "eth0",
+            "name": "nic",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PoolExplanation"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "Resource not found",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Explain IPPool selection failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
+    "/ipam/gc_candidates": {
+      "get": {
+        "description": "Get the Pods traced by the IP garbage collection with the deadlines\nto reclaim their IPs, only the elected spiderpool-controller traces\nthe Pods\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Get garbage collection candidates",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/GcCandidates"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to get the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Get IP GC candidates failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/gc_ips": {
       "post": {
         "description": "Trigger global gc or specific ip gc with the param\n",
@@ -191,6 +1484,59 @@ func init() {
         }
       }
     },
+    "/ipam/preprovision": {
+      "post": {
+        "description": "Create the auto-created IPPools of SpiderSubnet for the applications\nbefore they are created, so that their first Pods don't wait for the\ncreation of the IPPools\n",
+        "tags": [
+          "controller"
+        ],
+        "summary": "Pre-provision auto-created IPPools",
+        "parameters": [
+          {
+            "name": "request",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/PreProvisionRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/PreProvisionResult"
+            }
+          },
+          "400": {
+            "description": "Invalid request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "BadRequest"
+          },
+          "401": {
+            "description": "Caller not authenticated",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "403": {
+            "description": "Caller not permitted to create the resource",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "500": {
+            "description": "Pre-provision IPPools failure",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "x-go-name": "Failure"
+          }
+        }
+      }
+    },
     "/ipam/status": {
       "get": {
         "description": "Get ipam status for spiderpool controller cli debug usage\n",
@@ -227,17 +1573,31 @@ func init() {
     },
     "/runtime/readiness": {
       "get": {
-        "description": "Check pod readiness probe",
+        "description": "Check pod readiness probe, with the lag and queue depth of the\ninformers if verbose\n",
         "tags": [
           "runtime"
         ],
         "summary": "Readiness probe",
+        "parameters": [
+          {
+            "type": "boolean",
+            "default": false,
+            "name": "verbose",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Readiness"
+            }
           },
           "500": {
-            "description": "Failed"
+            "description": "Failed",
+            "schema": {
+              "$ref": "#/definitions/Readiness"
+            }
           }
         }
       }
@@ -260,6 +1620,388 @@ func init() {
       }
     }
   },
+  "definitions": {
+    "Capacity": {
+      "description": "Free or used IPs of a SpiderSubnet or SpiderIPPool summarized into CIDRs",
+      "type": "object",
+      "properties": {
+        "cidrs": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "continue": {
+          "description": "Token to get the next page, empty for the last page",
+          "type": "string"
+        },
+        "freeIPCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ipVersion": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "state": {
+          "type": "string"
+        },
+        "totalIPCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "usedIPCount": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Consumer": {
+      "description": "Application consuming IPs, or Pod not controlled by any application",
+      "type": "object",
+      "properties": {
+        "ipCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        }
+      }
+    },
+    "Consumers": {
+      "description": "Applications consuming the most IPs of a SpiderSubnet or SpiderIPPool",
+      "type": "object",
+      "properties": {
+        "allocatedIPCount": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "consumers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Consumer"
+          }
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        }
+      }
+    },
+    "EgressIPReservation": {
+      "description": "Egress gateway object to reserve an IP address of the IPPool for",
+      "type": "object",
+      "required": [
+        "ippool",
+        "apiVersion",
+        "kind",
+        "name"
+      ],
+      "properties": {
+        "apiVersion": {
+          "type": "string"
+        },
+        "ippool": {
+          "type": "string"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "description": "Empty for the cluster scope objects",
+          "type": "string"
+        },
+        "uid": {
+          "description": "The reservation is released once the UID of the object mismatches, if specified",
+          "type": "string"
+        }
+      }
+    },
+    "EgressIPReservationResult": {
+      "description": "IP address reserved for the egress gateway object",
+      "type": "object",
+      "properties": {
+        "ip": {
+          "type": "string"
+        }
+      }
+    },
+    "Error": {
+      "description": "API error",
+      "type": "string"
+    },
+    "FeatureGate": {
+      "description": "Feature gate status",
+      "type": "object",
+      "properties": {
+        "default": {
+          "type": "boolean"
+        },
+        "enabled": {
+          "type": "boolean"
+        },
+        "name": {
+          "type": "string"
+        },
+        "preRelease": {
+          "type": "string"
+        }
+      }
+    },
+    "GcCandidate": {
+      "description": "Pod traced by the IP garbage collection",
+      "type": "object",
+      "properties": {
+        "deadline": {
+          "description": "Time after which the IPs of the Pod are reclaimed",
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "node": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "reason": {
+          "description": "Status of the Pod which makes it traced",
+          "type": "string"
+        },
+        "tracingStartTime": {
+          "type": "string"
+        }
+      }
+    },
+    "GcCandidates": {
+      "description": "Pods traced by the IP garbage collection",
+      "type": "object",
+      "properties": {
+        "candidates": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/GcCandidate"
+          }
+        },
+        "elected": {
+          "description": "Whether the spiderpool-controller is elected to trace the Pods",
+          "type": "boolean"
+        },
+        "queueDepth": {
+          "description": "Number of the timed out Pods waiting for the workers to reclaim their IPs",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "InformerStatus": {
+      "description": "Lag and queue depth of an informer",
+      "type": "object",
+      "properties": {
+        "eventLagSeconds": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "lastEventAgeSeconds": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "queueDepth": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "PoolDecision": {
+      "description": "Why a candidate IPPool is accepted or rejected",
+      "type": "object",
+      "properties": {
+        "accepted": {
+          "type": "boolean"
+        },
+        "ipVersion": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ippool": {
+          "description": "Empty if the auto-created IPPool of SpiderSubnet is not created yet",
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    },
+    "PoolExplanation": {
+      "description": "Replay of the candidate IPPool selection for a NIC of a Pod",
+      "type": "object",
+      "properties": {
+        "decisions": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PoolDecision"
+          }
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "nic": {
+          "type": "string"
+        },
+        "pod": {
+          "type": "string"
+        },
+        "source": {
+          "description": "Rule which selects the candidate IPPools",
+          "type": "string"
+        }
+      }
+    },
+    "PreProvisionApplication": {
+      "description": "Application to pre-provision the auto-created IPPools for",
+      "type": "object",
+      "required": [
+        "kind",
+        "namespace",
+        "name"
+      ],
+      "properties": {
+        "interface": {
+          "type": "string",
+          "default": "eth0"
+        },
+        "ipv4Subnet": {
+          "type": "string"
+        },
+        "ipv6Subnet": {
+          "type": "string"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "reclaimIPPool": {
+          "type": "boolean",
+          "default": true
+        },
+        "replicas": {
+          "description": "Number of the Pods of the application",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "PreProvisionRequest": {
+      "description": "Applications to pre-provision the auto-created IPPools for",
+      "type": "object",
+      "properties": {
+        "applications": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PreProvisionApplication"
+          }
+        }
+      }
+    },
+    "PreProvisionResult": {
+      "description": "Auto-created IPPools pre-provisioned for the applications",
+      "type": "object",
+      "properties": {
+        "items": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PreProvisionedPool"
+          }
+        }
+      }
+    },
+    "PreProvisionedPool": {
+      "description": "Auto-created IPPool pre-provisioned for an application",
+      "type": "object",
+      "properties": {
+        "created": {
+          "description": "False if the IPPool already exists",
+          "type": "boolean"
+        },
+        "error": {
+          "type": "string"
+        },
+        "ipCount": {
+          "description": "IP number desired by the IPPool",
+          "type": "integer",
+          "format": "int64"
+        },
+        "ipVersion": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ippool": {
+          "type": "string"
+        },
+        "kind": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "namespace": {
+          "type": "string"
+        },
+        "reason": {
+          "description": "Class of the error, one of PoolExhausted, OwnerNotFound and Overlap, empty for the others",
+          "type": "string"
+        },
+        "subnet": {
+          "type": "string"
+        }
+      }
+    },
+    "Readiness": {
+      "description": "Readiness of spiderpool-controller with the status of its informers",
+      "type": "object",
+      "properties": {
+        "informers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/InformerStatus"
+          }
+        },
+        "reason": {
+          "type": "string"
+        }
+      }
+    }
+  },
   "x-schemes": [
     "http"
   ]
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DeleteIpamEgressipHandlerFunc turns a function with the right signature into a delete ipam egressip handler
+type DeleteIpamEgressipHandlerFunc func(DeleteIpamEgressipParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DeleteIpamEgressipHandlerFunc) Handle(params DeleteIpamEgressipParams) middleware.Responder {
+	return fn(params)
+}
+
+// DeleteIpamEgressipHandler interface for that can handle valid delete ipam egressip params
+type DeleteIpamEgressipHandler interface {
+	Handle(DeleteIpamEgressipParams) middleware.Responder
+}
+
+// NewDeleteIpamEgressip creates a new http.Handler for the delete ipam egressip operation
+func NewDeleteIpamEgressip(ctx *middleware.Context, handler DeleteIpamEgressipHandler) *DeleteIpamEgressip {
+	return &DeleteIpamEgressip{Context: ctx, Handler: handler}
+}
+
+/*
+	DeleteIpamEgressip swagger:route DELETE /ipam/egressip controller deleteIpamEgressip
+
+# Release egress IPs
+
+Release the IP addresses of the IPPool reserved for an egress gateway
+object
+*/
+type DeleteIpamEgressip struct {
+	Context *middleware.Context
+	Handler DeleteIpamEgressipHandler
+}
+
+func (o *DeleteIpamEgressip) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewDeleteIpamEgressipParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
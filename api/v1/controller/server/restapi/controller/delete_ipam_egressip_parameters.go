@@ -0,0 +1,87 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/validate"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+)
+
+// NewDeleteIpamEgressipParams creates a new DeleteIpamEgressipParams object
+//
+// There are no default values defined in the spec.
+func NewDeleteIpamEgressipParams() DeleteIpamEgressipParams {
+
+	return DeleteIpamEgressipParams{}
+}
+
+// DeleteIpamEgressipParams contains all the bound params for the delete ipam egressip operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters DeleteIpamEgressip
+type DeleteIpamEgressipParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  Required: true
+	  In: body
+	*/
+	Reservation *models.EgressIPReservation
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewDeleteIpamEgressipParams() beforehand.
+func (o *DeleteIpamEgressipParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	if runtime.HasBody(r) {
+		defer r.Body.Close()
+		var body models.EgressIPReservation
+		if err := route.Consumer.Consume(r.Body, &body); err != nil {
+			if err == io.EOF {
+				res = append(res, errors.Required("reservation", "body", ""))
+			} else {
+				res = append(res, errors.NewParseError("reservation", "body", "", err))
+			}
+		} else {
+			// validate body object
+			if err := body.Validate(route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			ctx := validate.WithOperationRequest(r.Context())
+			if err := body.ContextValidate(ctx, route.Formats); err != nil {
+				res = append(res, err)
+			}
+
+			if len(res) == 0 {
+				o.Reservation = &body
+			}
+		}
+	} else {
+		res = append(res, errors.Required("reservation", "body", ""))
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
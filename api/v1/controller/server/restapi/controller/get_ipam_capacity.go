@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetIpamCapacityHandlerFunc turns a function with the right signature into a get ipam capacity handler
+type GetIpamCapacityHandlerFunc func(GetIpamCapacityParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetIpamCapacityHandlerFunc) Handle(params GetIpamCapacityParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetIpamCapacityHandler interface for that can handle valid get ipam capacity params
+type GetIpamCapacityHandler interface {
+	Handle(GetIpamCapacityParams) middleware.Responder
+}
+
+// NewGetIpamCapacity creates a new http.Handler for the get ipam capacity operation
+func NewGetIpamCapacity(ctx *middleware.Context, handler GetIpamCapacityHandler) *GetIpamCapacity {
+	return &GetIpamCapacity{Context: ctx, Handler: handler}
+}
+
+/*
+	GetIpamCapacity swagger:route GET /ipam/capacity controller getIpamCapacity
+
+# Get capacity
+
+Get the free or used IPs of a SpiderSubnet or SpiderIPPool, summarized
+into CIDRs and paginated
+*/
+type GetIpamCapacity struct {
+	Context *middleware.Context
+	Handler GetIpamCapacityHandler
+}
+
+func (o *GetIpamCapacity) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetIpamCapacityParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetIpamConsumersHandlerFunc turns a function with the right signature into a get ipam consumers handler
+type GetIpamConsumersHandlerFunc func(GetIpamConsumersParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetIpamConsumersHandlerFunc) Handle(params GetIpamConsumersParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetIpamConsumersHandler interface for that can handle valid get ipam consumers params
+type GetIpamConsumersHandler interface {
+	Handle(GetIpamConsumersParams) middleware.Responder
+}
+
+// NewGetIpamConsumers creates a new http.Handler for the get ipam consumers operation
+func NewGetIpamConsumers(ctx *middleware.Context, handler GetIpamConsumersHandler) *GetIpamConsumers {
+	return &GetIpamConsumers{Context: ctx, Handler: handler}
+}
+
+/*
+	GetIpamConsumers swagger:route GET /ipam/consumers controller getIpamConsumers
+
+# Get top consumers
+
+Get the applications consuming the most IPs of a SpiderSubnet or
+SpiderIPPool
+*/
+type GetIpamConsumers struct {
+	Context *middleware.Context
+	Handler GetIpamConsumersHandler
+}
+
+func (o *GetIpamConsumers) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetIpamConsumersParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
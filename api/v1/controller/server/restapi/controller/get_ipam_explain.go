@@ -0,0 +1,62 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetIpamExplainHandlerFunc turns a function with the right signature into a get ipam explain handler
+type GetIpamExplainHandlerFunc func(GetIpamExplainParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetIpamExplainHandlerFunc) Handle(params GetIpamExplainParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetIpamExplainHandler interface for that can handle valid get ipam explain params
+type GetIpamExplainHandler interface {
+	Handle(GetIpamExplainParams) middleware.Responder
+}
+
+// NewGetIpamExplain creates a new http.Handler for the get ipam explain operation
+func NewGetIpamExplain(ctx *middleware.Context, handler GetIpamExplainHandler) *GetIpamExplain {
+	return &GetIpamExplain{Context: ctx, Handler: handler}
+}
+
+/*
+	GetIpamExplain swagger:route GET /ipam/explain controller getIpamExplain
+
+# Explain IPPool selection
+
+Replay the selection of candidate IPPools for a NIC of a Pod, and
+explain why each IPPool is accepted or rejected
+*/
+type GetIpamExplain struct {
+	Context *middleware.Context
+	Handler GetIpamExplainHandler
+}
+
+func (o *GetIpamExplain) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetIpamExplainParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
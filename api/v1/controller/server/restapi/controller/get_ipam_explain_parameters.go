@@ -0,0 +1,152 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/validate"
+)
+
+// NewGetIpamExplainParams creates a new GetIpamExplainParams object
+// with the default values initialized.
+func NewGetIpamExplainParams() GetIpamExplainParams {
+
+	var (
+		// initialize parameters with default values
+
+		nicDefault = string("eth0")
+	)
+
+	return GetIpamExplainParams{
+		Nic: &nicDefault,
+	}
+}
+
+// GetIpamExplainParams contains all the bound params for the get ipam explain operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters GetIpamExplain
+type GetIpamExplainParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  Required: true
+	  In: query
+	*/
+	Namespace string
+	/*
+	  In: query
+	  Default: "eth0"
+	*/
+	Nic *string
+	/*
+	  Required: true
+	  In: query
+	*/
+	Pod string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewGetIpamExplainParams() beforehand.
+func (o *GetIpamExplainParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qNamespace, qhkNamespace, _ := qs.GetOK("namespace")
+	if err := o.bindNamespace(qNamespace, qhkNamespace, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qNic, qhkNic, _ := qs.GetOK("nic")
+	if err := o.bindNic(qNic, qhkNic, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qPod, qhkPod, _ := qs.GetOK("pod")
+	if err := o.bindPod(qPod, qhkPod, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindNamespace binds and validates parameter Namespace from query.
+func (o *GetIpamExplainParams) bindNamespace(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("namespace", "query", rawData)
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// AllowEmptyValue: false
+
+	if err := validate.RequiredString("namespace", "query", raw); err != nil {
+		return err
+	}
+	o.Namespace = raw
+
+	return nil
+}
+
+// bindNic binds and validates parameter Nic from query.
+func (o *GetIpamExplainParams) bindNic(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetIpamExplainParams()
+		return nil
+	}
+	o.Nic = &raw
+
+	return nil
+}
+
+// bindPod binds and validates parameter Pod from query.
+func (o *GetIpamExplainParams) bindPod(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("pod", "query", rawData)
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// AllowEmptyValue: false
+
+	if err := validate.RequiredString("pod", "query", raw); err != nil {
+		return err
+	}
+	o.Pod = raw
+
+	return nil
+}
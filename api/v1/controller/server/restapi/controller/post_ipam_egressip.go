@@ -0,0 +1,64 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// PostIpamEgressipHandlerFunc turns a function with the right signature into a post ipam egressip handler
+type PostIpamEgressipHandlerFunc func(PostIpamEgressipParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn PostIpamEgressipHandlerFunc) Handle(params PostIpamEgressipParams) middleware.Responder {
+	return fn(params)
+}
+
+// PostIpamEgressipHandler interface for that can handle valid post ipam egressip params
+type PostIpamEgressipHandler interface {
+	Handle(PostIpamEgressipParams) middleware.Responder
+}
+
+// NewPostIpamEgressip creates a new http.Handler for the post ipam egressip operation
+func NewPostIpamEgressip(ctx *middleware.Context, handler PostIpamEgressipHandler) *PostIpamEgressip {
+	return &PostIpamEgressip{Context: ctx, Handler: handler}
+}
+
+/*
+	PostIpamEgressip swagger:route POST /ipam/egressip controller postIpamEgressip
+
+# Reserve an egress IP
+
+Reserve an IP address of the IPPool for an egress gateway object,
+which is no longer allocated to any Pod. The IP address reserved for
+the object before is returned if any. The reservation is released
+once the object is gone
+*/
+type PostIpamEgressip struct {
+	Context *middleware.Context
+	Handler PostIpamEgressipHandler
+}
+
+func (o *PostIpamEgressip) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewPostIpamEgressipParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
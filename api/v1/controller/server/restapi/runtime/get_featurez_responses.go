@@ -0,0 +1,65 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+)
+
+// GetFeaturezOKCode is the HTTP code returned for type GetFeaturezOK
+const GetFeaturezOKCode int = 200
+
+/*
+GetFeaturezOK Success
+
+swagger:response getFeaturezOK
+*/
+type GetFeaturezOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload []*models.FeatureGate `json:"body,omitempty"`
+}
+
+// NewGetFeaturezOK creates GetFeaturezOK with default headers values
+func NewGetFeaturezOK() *GetFeaturezOK {
+
+	return &GetFeaturezOK{}
+}
+
+// WithPayload adds the payload to the get featurez o k response
+func (o *GetFeaturezOK) WithPayload(payload []*models.FeatureGate) *GetFeaturezOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get featurez o k response
+func (o *GetFeaturezOK) SetPayload(payload []*models.FeatureGate) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetFeaturezOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if payload == nil {
+		// return empty array
+		payload = make([]*models.FeatureGate, 0, 50)
+	}
+
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
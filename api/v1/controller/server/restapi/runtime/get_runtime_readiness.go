@@ -37,7 +37,8 @@ func NewGetRuntimeReadiness(ctx *middleware.Context, handler GetRuntimeReadiness
 
 # Readiness probe
 
-Check pod readiness probe
+Check pod readiness probe, with the lag and queue depth of the
+informers if verbose
 */
 type GetRuntimeReadiness struct {
 	Context *middleware.Context
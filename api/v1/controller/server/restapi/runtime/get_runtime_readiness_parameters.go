@@ -12,15 +12,25 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewGetRuntimeReadinessParams creates a new GetRuntimeReadinessParams object
-//
-// There are no default values defined in the spec.
+// with the default values initialized.
 func NewGetRuntimeReadinessParams() GetRuntimeReadinessParams {
 
-	return GetRuntimeReadinessParams{}
+	var (
+		// initialize parameters with default values
+
+		verboseDefault = bool(false)
+	)
+
+	return GetRuntimeReadinessParams{
+		Verbose: &verboseDefault,
+	}
 }
 
 // GetRuntimeReadinessParams contains all the bound params for the get runtime readiness operation
@@ -31,6 +41,12 @@ type GetRuntimeReadinessParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*
+	  In: query
+	  Default: false
+	*/
+	Verbose *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -42,8 +58,38 @@ func (o *GetRuntimeReadinessParams) BindRequest(r *http.Request, route *middlewa
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qVerbose, qhkVerbose, _ := qs.GetOK("verbose")
+	if err := o.bindVerbose(qVerbose, qhkVerbose, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindVerbose binds and validates parameter Verbose from query.
+func (o *GetRuntimeReadinessParams) bindVerbose(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewGetRuntimeReadinessParams()
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("verbose", "query", "bool", raw)
+	}
+	o.Verbose = &value
+
+	return nil
+}
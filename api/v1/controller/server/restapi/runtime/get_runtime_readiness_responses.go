@@ -12,6 +12,8 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
 )
 
 // GetRuntimeReadinessOKCode is the HTTP code returned for type GetRuntimeReadinessOK
@@ -23,6 +25,11 @@ GetRuntimeReadinessOK Success
 swagger:response getRuntimeReadinessOK
 */
 type GetRuntimeReadinessOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Readiness `json:"body,omitempty"`
 }
 
 // NewGetRuntimeReadinessOK creates GetRuntimeReadinessOK with default headers values
@@ -31,12 +38,27 @@ func NewGetRuntimeReadinessOK() *GetRuntimeReadinessOK {
 	return &GetRuntimeReadinessOK{}
 }
 
+// WithPayload adds the payload to the get runtime readiness o k response
+func (o *GetRuntimeReadinessOK) WithPayload(payload *models.Readiness) *GetRuntimeReadinessOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get runtime readiness o k response
+func (o *GetRuntimeReadinessOK) SetPayload(payload *models.Readiness) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *GetRuntimeReadinessOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
 // GetRuntimeReadinessInternalServerErrorCode is the HTTP code returned for type GetRuntimeReadinessInternalServerError
@@ -48,6 +70,11 @@ GetRuntimeReadinessInternalServerError Failed
 swagger:response getRuntimeReadinessInternalServerError
 */
 type GetRuntimeReadinessInternalServerError struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Readiness `json:"body,omitempty"`
 }
 
 // NewGetRuntimeReadinessInternalServerError creates GetRuntimeReadinessInternalServerError with default headers values
@@ -56,10 +83,25 @@ func NewGetRuntimeReadinessInternalServerError() *GetRuntimeReadinessInternalSer
 	return &GetRuntimeReadinessInternalServerError{}
 }
 
+// WithPayload adds the payload to the get runtime readiness internal server error response
+func (o *GetRuntimeReadinessInternalServerError) WithPayload(payload *models.Readiness) *GetRuntimeReadinessInternalServerError {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get runtime readiness internal server error response
+func (o *GetRuntimeReadinessInternalServerError) SetPayload(payload *models.Readiness) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *GetRuntimeReadinessInternalServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(500)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
@@ -12,11 +12,17 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // GetRuntimeReadinessURL generates an URL for the get runtime readiness operation
 type GetRuntimeReadinessURL struct {
+	Verbose *bool
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -46,6 +52,18 @@ func (o *GetRuntimeReadinessURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var verboseQ string
+	if o.Verbose != nil {
+		verboseQ = swag.FormatBool(*o.Verbose)
+	}
+	if verboseQ != "" {
+		qs.Set("verbose", verboseQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -48,6 +48,24 @@ func NewSpiderpoolControllerAPIAPI(spec *loads.Document) *SpiderpoolControllerAP
 
 		JSONProducer: runtime.JSONProducer(),
 
+		ControllerDeleteIpamEgressipHandler: controller.DeleteIpamEgressipHandlerFunc(func(params controller.DeleteIpamEgressipParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.DeleteIpamEgressip has not yet been implemented")
+		}),
+		RuntimeGetFeaturezHandler: runtimeops.GetFeaturezHandlerFunc(func(params runtimeops.GetFeaturezParams) middleware.Responder {
+			return middleware.NotImplemented("operation runtime.GetFeaturez has not yet been implemented")
+		}),
+		ControllerGetIpamCapacityHandler: controller.GetIpamCapacityHandlerFunc(func(params controller.GetIpamCapacityParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamCapacity has not yet been implemented")
+		}),
+		ControllerGetIpamConsumersHandler: controller.GetIpamConsumersHandlerFunc(func(params controller.GetIpamConsumersParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamConsumers has not yet been implemented")
+		}),
+		ControllerGetIpamExplainHandler: controller.GetIpamExplainHandlerFunc(func(params controller.GetIpamExplainParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamExplain has not yet been implemented")
+		}),
+		ControllerGetIpamGcCandidatesHandler: controller.GetIpamGcCandidatesHandlerFunc(func(params controller.GetIpamGcCandidatesParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.GetIpamGcCandidates has not yet been implemented")
+		}),
 		ControllerGetIpamStatusHandler: controller.GetIpamStatusHandlerFunc(func(params controller.GetIpamStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.GetIpamStatus has not yet been implemented")
 		}),
@@ -60,9 +78,15 @@ func NewSpiderpoolControllerAPIAPI(spec *loads.Document) *SpiderpoolControllerAP
 		RuntimeGetRuntimeStartupHandler: runtimeops.GetRuntimeStartupHandlerFunc(func(params runtimeops.GetRuntimeStartupParams) middleware.Responder {
 			return middleware.NotImplemented("operation runtime.GetRuntimeStartup has not yet been implemented")
 		}),
+		ControllerPostIpamEgressipHandler: controller.PostIpamEgressipHandlerFunc(func(params controller.PostIpamEgressipParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.PostIpamEgressip has not yet been implemented")
+		}),
 		ControllerPostIpamGcIpsHandler: controller.PostIpamGcIpsHandlerFunc(func(params controller.PostIpamGcIpsParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.PostIpamGcIps has not yet been implemented")
 		}),
+		ControllerPostIpamPreprovisionHandler: controller.PostIpamPreprovisionHandlerFunc(func(params controller.PostIpamPreprovisionParams) middleware.Responder {
+			return middleware.NotImplemented("operation controller.PostIpamPreprovision has not yet been implemented")
+		}),
 		ControllerPutIpamIPHandler: controller.PutIpamIPHandlerFunc(func(params controller.PutIpamIPParams) middleware.Responder {
 			return middleware.NotImplemented("operation controller.PutIpamIP has not yet been implemented")
 		}),
@@ -102,6 +126,18 @@ type SpiderpoolControllerAPIAPI struct {
 	//   - application/json
 	JSONProducer runtime.Producer
 
+	// ControllerDeleteIpamEgressipHandler sets the operation handler for the delete ipam egressip operation
+	ControllerDeleteIpamEgressipHandler controller.DeleteIpamEgressipHandler
+	// RuntimeGetFeaturezHandler sets the operation handler for the get featurez operation
+	RuntimeGetFeaturezHandler runtimeops.GetFeaturezHandler
+	// ControllerGetIpamCapacityHandler sets the operation handler for the get ipam capacity operation
+	ControllerGetIpamCapacityHandler controller.GetIpamCapacityHandler
+	// ControllerGetIpamConsumersHandler sets the operation handler for the get ipam consumers operation
+	ControllerGetIpamConsumersHandler controller.GetIpamConsumersHandler
+	// ControllerGetIpamExplainHandler sets the operation handler for the get ipam explain operation
+	ControllerGetIpamExplainHandler controller.GetIpamExplainHandler
+	// ControllerGetIpamGcCandidatesHandler sets the operation handler for the get ipam gc candidates operation
+	ControllerGetIpamGcCandidatesHandler controller.GetIpamGcCandidatesHandler
 	// ControllerGetIpamStatusHandler sets the operation handler for the get ipam status operation
 	ControllerGetIpamStatusHandler controller.GetIpamStatusHandler
 	// RuntimeGetRuntimeLivenessHandler sets the operation handler for the get runtime liveness operation
@@ -110,8 +146,12 @@ type SpiderpoolControllerAPIAPI struct {
 	RuntimeGetRuntimeReadinessHandler runtimeops.GetRuntimeReadinessHandler
 	// RuntimeGetRuntimeStartupHandler sets the operation handler for the get runtime startup operation
 	RuntimeGetRuntimeStartupHandler runtimeops.GetRuntimeStartupHandler
+	// ControllerPostIpamEgressipHandler sets the operation handler for the post ipam egressip operation
+	ControllerPostIpamEgressipHandler controller.PostIpamEgressipHandler
 	// ControllerPostIpamGcIpsHandler sets the operation handler for the post ipam gc ips operation
 	ControllerPostIpamGcIpsHandler controller.PostIpamGcIpsHandler
+	// ControllerPostIpamPreprovisionHandler sets the operation handler for the post ipam preprovision operation
+	ControllerPostIpamPreprovisionHandler controller.PostIpamPreprovisionHandler
 	// ControllerPutIpamIPHandler sets the operation handler for the put ipam IP operation
 	ControllerPutIpamIPHandler controller.PutIpamIPHandler
 
@@ -191,6 +231,24 @@ func (o *SpiderpoolControllerAPIAPI) Validate() error {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.ControllerDeleteIpamEgressipHandler == nil {
+		unregistered = append(unregistered, "controller.DeleteIpamEgressipHandler")
+	}
+	if o.RuntimeGetFeaturezHandler == nil {
+		unregistered = append(unregistered, "runtime.GetFeaturezHandler")
+	}
+	if o.ControllerGetIpamCapacityHandler == nil {
+		unregistered = append(unregistered, "controller.GetIpamCapacityHandler")
+	}
+	if o.ControllerGetIpamConsumersHandler == nil {
+		unregistered = append(unregistered, "controller.GetIpamConsumersHandler")
+	}
+	if o.ControllerGetIpamExplainHandler == nil {
+		unregistered = append(unregistered, "controller.GetIpamExplainHandler")
+	}
+	if o.ControllerGetIpamGcCandidatesHandler == nil {
+		unregistered = append(unregistered, "controller.GetIpamGcCandidatesHandler")
+	}
 	if o.ControllerGetIpamStatusHandler == nil {
 		unregistered = append(unregistered, "controller.GetIpamStatusHandler")
 	}
@@ -203,9 +261,15 @@ func (o *SpiderpoolControllerAPIAPI) Validate() error {
 	if o.RuntimeGetRuntimeStartupHandler == nil {
 		unregistered = append(unregistered, "runtime.GetRuntimeStartupHandler")
 	}
+	if o.ControllerPostIpamEgressipHandler == nil {
+		unregistered = append(unregistered, "controller.PostIpamEgressipHandler")
+	}
 	if o.ControllerPostIpamGcIpsHandler == nil {
 		unregistered = append(unregistered, "controller.PostIpamGcIpsHandler")
 	}
+	if o.ControllerPostIpamPreprovisionHandler == nil {
+		unregistered = append(unregistered, "controller.PostIpamPreprovisionHandler")
+	}
 	if o.ControllerPutIpamIPHandler == nil {
 		unregistered = append(unregistered, "controller.PutIpamIPHandler")
 	}
@@ -297,6 +361,30 @@ func (o *SpiderpoolControllerAPIAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/ipam/egressip"] = controller.NewDeleteIpamEgressip(o.context, o.ControllerDeleteIpamEgressipHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/featurez"] = runtimeops.NewGetFeaturez(o.context, o.RuntimeGetFeaturezHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ipam/capacity"] = controller.NewGetIpamCapacity(o.context, o.ControllerGetIpamCapacityHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ipam/consumers"] = controller.NewGetIpamConsumers(o.context, o.ControllerGetIpamConsumersHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ipam/explain"] = controller.NewGetIpamExplain(o.context, o.ControllerGetIpamExplainHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/ipam/gc_candidates"] = controller.NewGetIpamGcCandidates(o.context, o.ControllerGetIpamGcCandidatesHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -316,7 +404,15 @@ func (o *SpiderpoolControllerAPIAPI) initHandlerCache() {
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
+	o.handlers["POST"]["/ipam/egressip"] = controller.NewPostIpamEgressip(o.context, o.ControllerPostIpamEgressipHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
 	o.handlers["POST"]["/ipam/gc_ips"] = controller.NewPostIpamGcIps(o.context, o.ControllerPostIpamGcIpsHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/ipam/preprovision"] = controller.NewPostIpamPreprovision(o.context, o.ControllerPostIpamPreprovisionHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
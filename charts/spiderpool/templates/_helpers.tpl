@@ -208,4 +208,14 @@ insight labels
 */}}
 {{- define "insight.labels" -}}
 operator.insight.io/managed-by: insight
-{{- end}}
\ No newline at end of file
+{{- end}}
+{{/*
+render the feature gates as the value of flag --feature-gates
+*/}}
+{{- define "spiderpool.featureGates" -}}
+{{- $gates := list -}}
+{{- range $name, $enabled := .Values.feature.featureGates -}}
+{{- $gates = append $gates (printf "%s=%t" $name $enabled) -}}
+{{- end -}}
+{{- join "," $gates -}}
+{{- end -}}
@@ -14,19 +14,28 @@ import (
 	"github.com/spf13/pflag"
 	"go.uber.org/atomic"
 	"gopkg.in/yaml.v3"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/client"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/server"
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	"github.com/spidernet-io/spiderpool/pkg/ipam"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/kubeletclient"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
 	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/utils/label"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
@@ -55,11 +64,33 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_UPDATE_CR_RETRY_UNIT_TIME", "50", false, nil, nil, &agentContext.Cfg.UpdateCRRetryUnitTime},
 	{"SPIDERPOOL_WORKLOADENDPOINT_MAX_HISTORY_RECORDS", "100", true, nil, nil, &agentContext.Cfg.WorkloadEndpointMaxHistoryRecords},
 	{"SPIDERPOOL_IPPOOL_MAX_ALLOCATED_IPS", "5000", true, nil, nil, &agentContext.Cfg.IPPoolMaxAllocatedIPs},
+	{"SPIDERPOOL_IPPOOL_GAP_FILLING_ENABLED", "false", false, nil, &agentContext.Cfg.EnableIPPoolGapFilling, nil},
 	{"SPIDERPOOL_GOPS_LISTEN_PORT", "5712", false, &agentContext.Cfg.GopsListenPort, nil, nil},
 	{"SPIDERPOOL_PYROSCOPE_PUSH_SERVER_ADDRESS", "", false, &agentContext.Cfg.PyroscopeAddress, nil, nil},
 	{"SPIDERPOOL_LIMITER_MAX_QUEUE_SIZE", "1000", true, nil, nil, &agentContext.Cfg.LimiterMaxQueueSize},
 	{"SPIDERPOOL_ENABLED_STATEFULSET", "true", true, nil, &agentContext.Cfg.EnableStatefulSet, nil},
 	{"SPIDERPOOL_WAIT_SUBNET_POOL_TIME_IN_SECOND", "2", false, nil, nil, &agentContext.Cfg.WaitSubnetPoolTime},
+	{"SPIDERPOOL_NODE_NAME", "", false, &agentContext.Cfg.NodeName, nil, nil},
+	{"SPIDERPOOL_IP_CONFLICT_MONITOR_INTERFACES", "", false, &agentContext.Cfg.IPConflictMonitorInterfaces, nil, nil},
+	{"SPIDERPOOL_CNI_CONF_DIR", "", false, &agentContext.Cfg.CNIConfDir, nil, nil},
+	{"SPIDERPOOL_KUBELET_ADDRESS", "", false, &agentContext.Cfg.KubeletAddress, nil, nil},
+	{"SPIDERPOOL_KUBELET_CA_FILE", "", false, &agentContext.Cfg.KubeletCAFile, nil, nil},
+	{"SPIDERPOOL_NODE_IPPOOL_LABELS_ENABLED", "false", false, nil, &agentContext.Cfg.EnableNodeIPPoolLabels, nil},
+	{"SPIDERPOOL_SUBNET_DISCOVERY_MODE", "", false, &agentContext.Cfg.SubnetDiscoveryMode, nil, nil},
+	{"SPIDERPOOL_SUBNET_DISCOVERY_INTERFACES", "", false, &agentContext.Cfg.SubnetDiscoveryInterfaces, nil, nil},
+	{"SPIDERPOOL_CACHE_READS_ENABLED", "false", false, nil, &agentContext.Cfg.EnableCacheReads, nil},
+	{"SPIDERPOOL_POD_CACHE_LABEL_SELECTOR", "", false, &agentContext.Cfg.PodCacheLabelSelector, nil, nil},
+	{"SPIDERPOOL_POD_CACHE_FIELD_SELECTOR", "", false, &agentContext.Cfg.PodCacheFieldSelector, nil, nil},
+	{"SPIDERPOOL_IPPOOL_STATUS_SHARD_SIZE", "0", false, nil, nil, &agentContext.Cfg.IPPoolStatusShardSize},
+	{"SPIDERPOOL_FREEZE_RESYNC_PERIOD_IN_SECOND", "10", false, nil, nil, &agentContext.Cfg.FreezeResyncPeriod},
+	{"SPIDERPOOL_ALLOCATION_POLICY_URL", "", false, &agentContext.Cfg.AllocationPolicyURL, nil, nil},
+	{"SPIDERPOOL_ALLOCATION_POLICY_TIMEOUT_IN_MILLISECOND", "3000", false, nil, nil, &agentContext.Cfg.AllocationPolicyTimeout},
+	{"SPIDERPOOL_ALLOCATION_POLICY_FAILURE_POLICY", "Fail", false, &agentContext.Cfg.AllocationPolicyFailurePolicy, nil, nil},
+	{"SPIDERPOOL_ALLOCATION_JOURNAL_PATH", "", false, &agentContext.Cfg.AllocationJournalPath, nil, nil},
+	{"SPIDERPOOL_IPAM_POOL_SELECTION_TIMEOUT_IN_SECOND", "0", false, nil, nil, &agentContext.Cfg.PoolSelectionTimeout},
+	{"SPIDERPOOL_IPAM_IP_ALLOCATION_TIMEOUT_IN_SECOND", "0", false, nil, nil, &agentContext.Cfg.IPAllocationTimeout},
+	{"SPIDERPOOL_IPAM_ENDPOINT_UPDATE_TIMEOUT_IN_SECOND", "0", false, nil, nil, &agentContext.Cfg.EndpointUpdateTimeout},
+	{"SPIDERPOOL_IPAM_RELEASE_TIMEOUT_IN_SECOND", "0", false, nil, nil, &agentContext.Cfg.ReleaseTimeout},
 	{"GOLANG_ENV_MAXPROCS", "8", false, nil, nil, &agentContext.Cfg.GoMaxProcs},
 	{"GIT_COMMIT_VERSION", "", false, &agentContext.Cfg.CommitVersion, nil, nil},
 	{"GIT_COMMIT_TIME", "", false, &agentContext.Cfg.CommitTime, nil, nil},
@@ -87,10 +118,37 @@ type Config struct {
 	UpdateCRRetryUnitTime             int
 	WorkloadEndpointMaxHistoryRecords int
 	IPPoolMaxAllocatedIPs             int
+	EnableIPPoolGapFilling            bool
 	WaitSubnetPoolTime                int
 
 	LimiterMaxQueueSize int
 
+	NodeName                    string
+	IPConflictMonitorInterfaces string
+	CNIConfDir                  string
+	KubeletAddress              string
+	KubeletCAFile               string
+	EnableNodeIPPoolLabels      bool
+	SubnetDiscoveryMode         string
+	SubnetDiscoveryInterfaces   string
+	EnableCacheReads            bool
+	IPPoolStatusShardSize       int
+	FreezeResyncPeriod          int
+
+	PodCacheLabelSelector string
+	PodCacheFieldSelector string
+
+	AllocationPolicyURL           string
+	AllocationPolicyTimeout       int
+	AllocationPolicyFailurePolicy string
+
+	AllocationJournalPath string
+
+	PoolSelectionTimeout  int
+	IPAllocationTimeout   int
+	EndpointUpdateTimeout int
+	ReleaseTimeout        int
+
 	// configmap
 	IpamUnixSocketPath                string   `yaml:"ipamUnixSocketPath"`
 	EnableIPv4                        bool     `yaml:"enableIPv4"`
@@ -103,6 +161,15 @@ type Config struct {
 	EnableStatefulSet                 bool     `yaml:"enableStatefulSet"`
 	EnableSpiderSubnet                bool     `yaml:"enableSpiderSubnet"`
 	ClusterSubnetDefaultFlexibleIPNum int      `yaml:"clusterSubnetDefaultFlexibleIPNumber"`
+	ClusterSubnetDefaultReclaimIPPool *bool    `yaml:"clusterSubnetDefaultReclaimIPPool"`
+	ApplicationLabelKeys              []string `yaml:"applicationLabelKeys"`
+	EnableGatewayDetection            bool     `yaml:"enableGatewayDetection"`
+	EnableIPConflictDetection         bool     `yaml:"enableIPConflictDetection"`
+	EnablePodAssignedAnnotation       bool     `yaml:"enablePodAssignedAnnotation"`
+
+	// MetricHistogramBuckets overrides the bucket boundaries of the histograms
+	// matching the patterns.
+	MetricHistogramBuckets []metric.HistogramBuckets `yaml:"metricHistogramBuckets"`
 
 	GoMaxProcs int
 }
@@ -126,6 +193,11 @@ type AgentContext struct {
 	PodManager      podmanager.PodManager
 	StsManager      statefulsetmanager.StatefulSetManager
 	SubnetManager   subnetmanager.SubnetManager
+	FreezeMonitor   configmanager.FreezeMonitor
+
+	ClientSet     *kubernetes.Clientset
+	KubeletClient kubeletclient.KubeletClient
+	APIAuthorizer apiauthorizer.APIAuthorizer
 
 	// handler
 	HttpServer        *server.Server
@@ -142,6 +214,7 @@ type AgentContext struct {
 // BindAgentDaemonFlags bind agent cli daemon flags
 func (ac *AgentContext) BindAgentDaemonFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&ac.Cfg.ConfigPath, "config-path", "/tmp/spiderpool/config-map/conf.yml", "spiderpool-agent configmap file")
+	features.DefaultMutableFeatureGate.AddFlag(flags)
 }
 
 // ParseConfiguration set the env to AgentConfiguration
@@ -194,11 +267,23 @@ func (ac *AgentContext) LoadConfigmap() error {
 		return fmt.Errorf("failed to read configmap file, error: %v", err)
 	}
 
+	if err := configmanager.ValidateConfigmapKeys(configmapBytes); err != nil {
+		return fmt.Errorf("failed to validate configmap, error: %w", err)
+	}
+
 	err = yaml.Unmarshal(configmapBytes, &ac.Cfg)
 	if nil != err {
 		return fmt.Errorf("failed to parse configmap, error: %v", err)
 	}
 
+	if err := label.ValidateApplicationLabelKeys(ac.Cfg.ApplicationLabelKeys); err != nil {
+		return err
+	}
+
+	if err := metric.ValidateHistogramBuckets(ac.Cfg.MetricHistogramBuckets); err != nil {
+		return fmt.Errorf("failed to validate metricHistogramBuckets, error: %w", err)
+	}
+
 	if ac.Cfg.IpamUnixSocketPath == "" {
 		ac.Cfg.IpamUnixSocketPath = constant.DefaultIPAMUnixSocketPath
 	}
@@ -215,3 +300,76 @@ func (ac *AgentContext) LoadConfigmap() error {
 
 	return nil
 }
+
+// ApplySpiderpoolConfiguration overrides the configuration loaded from
+// ConfigMap and env with the fields specified in SpiderpoolConfiguration.
+func (ac *AgentContext) ApplySpiderpoolConfiguration(spec spiderpoolv1.SpiderpoolConfigurationSpec) error {
+	if err := features.DefaultMutableFeatureGate.SetFromMap(spec.FeatureGates); err != nil {
+		return err
+	}
+
+	if spec.EnableIPv4 != nil {
+		ac.Cfg.EnableIPv4 = *spec.EnableIPv4
+	}
+	if spec.EnableIPv6 != nil {
+		ac.Cfg.EnableIPv6 = *spec.EnableIPv6
+	}
+	if spec.EnableStatefulSet != nil {
+		ac.Cfg.EnableStatefulSet = *spec.EnableStatefulSet
+	}
+	if spec.EnableSpiderSubnet != nil {
+		ac.Cfg.EnableSpiderSubnet = *spec.EnableSpiderSubnet
+	}
+	if spec.ClusterDefaultIPv4IPPool != nil {
+		ac.Cfg.ClusterDefaultIPv4IPPool = spec.ClusterDefaultIPv4IPPool
+	}
+	if spec.ClusterDefaultIPv6IPPool != nil {
+		ac.Cfg.ClusterDefaultIPv6IPPool = spec.ClusterDefaultIPv6IPPool
+	}
+	if spec.ClusterDefaultIPv4Subnet != nil {
+		ac.Cfg.ClusterDefaultIPv4Subnet = spec.ClusterDefaultIPv4Subnet
+	}
+	if spec.ClusterDefaultIPv6Subnet != nil {
+		ac.Cfg.ClusterDefaultIPv6Subnet = spec.ClusterDefaultIPv6Subnet
+	}
+	if spec.ClusterSubnetDefaultFlexibleIPNumber != nil {
+		ac.Cfg.ClusterSubnetDefaultFlexibleIPNum = int(*spec.ClusterSubnetDefaultFlexibleIPNumber)
+	}
+
+	if spec.Retry != nil {
+		if spec.Retry.UpdateCRMaxRetries != nil {
+			ac.Cfg.UpdateCRMaxRetries = int(*spec.Retry.UpdateCRMaxRetries)
+		}
+		if spec.Retry.UpdateCRRetryUnitTimeMilliseconds != nil {
+			ac.Cfg.UpdateCRRetryUnitTime = int(*spec.Retry.UpdateCRRetryUnitTimeMilliseconds)
+		}
+	}
+
+	return nil
+}
+
+// ActiveSpiderpoolConfiguration returns the configuration that is active on
+// spiderpool-agent.
+func (ac *AgentContext) ActiveSpiderpoolConfiguration() spiderpoolv1.SpiderpoolConfigurationSpec {
+	featureGates := map[string]bool{}
+	for _, gate := range features.ListFeatureGates(features.DefaultFeatureGate) {
+		featureGates[gate.Name] = gate.Enabled
+	}
+
+	return spiderpoolv1.SpiderpoolConfigurationSpec{
+		EnableIPv4:                           pointer.Bool(ac.Cfg.EnableIPv4),
+		EnableIPv6:                           pointer.Bool(ac.Cfg.EnableIPv6),
+		EnableStatefulSet:                    pointer.Bool(ac.Cfg.EnableStatefulSet),
+		EnableSpiderSubnet:                   pointer.Bool(ac.Cfg.EnableSpiderSubnet),
+		ClusterDefaultIPv4IPPool:             ac.Cfg.ClusterDefaultIPv4IPPool,
+		ClusterDefaultIPv6IPPool:             ac.Cfg.ClusterDefaultIPv6IPPool,
+		ClusterDefaultIPv4Subnet:             ac.Cfg.ClusterDefaultIPv4Subnet,
+		ClusterDefaultIPv6Subnet:             ac.Cfg.ClusterDefaultIPv6Subnet,
+		ClusterSubnetDefaultFlexibleIPNumber: pointer.Int64(int64(ac.Cfg.ClusterSubnetDefaultFlexibleIPNum)),
+		Retry: &spiderpoolv1.RetryConfiguration{
+			UpdateCRMaxRetries:                pointer.Int64(int64(ac.Cfg.UpdateCRMaxRetries)),
+			UpdateCRRetryUnitTimeMilliseconds: pointer.Int64(int64(ac.Cfg.UpdateCRRetryUnitTime)),
+		},
+		FeatureGates: featureGates,
+	}
+}
@@ -4,19 +4,21 @@
 package cmd
 
 import (
-	"strconv"
-
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/utils/cachescope"
 )
 
 var scheme = runtime.NewScheme()
@@ -24,29 +26,55 @@ var scheme = runtime.NewScheme()
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(spiderpoolv1.AddToScheme(scheme))
+	utilruntime.Must(netv1.AddToScheme(scheme))
 }
 
 func newCRDManager() (ctrl.Manager, error) {
+	cacheOptions, err := cachescope.Config{
+		PodLabelSelector: agentContext.Cfg.PodCacheLabelSelector,
+		PodFieldSelector: agentContext.Cfg.PodCacheFieldSelector,
+	}.CacheOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     "0",
 		HealthProbeBindAddress: "0",
-		ClientDisableCacheFor: []client.Object{
-			&spiderpoolv1.SpiderSubnet{},
-			&spiderpoolv1.SpiderIPPool{},
-			&spiderpoolv1.SpiderEndpoint{},
-		},
+		ClientDisableCacheFor:  uncachedObjects(),
+		NewCache:               cache.BuilderWithOptions(cacheOptions),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := mgr.GetFieldIndexer().IndexField(agentContext.InnerCtx, &spiderpoolv1.SpiderReservedIP{}, "spec.ipVersion", func(raw client.Object) []string {
-		reservedIP := raw.(*spiderpoolv1.SpiderReservedIP)
-		return []string{strconv.FormatInt(*reservedIP.Spec.IPVersion, 10)}
-	}); err != nil {
+	return mgr, nil
+}
+
+// uncachedObjects returns the objects always read from API server by the
+// client of the runtime manager. IPPools and Subnets are read from the cache
+// only if cache reads are enabled, and the managers still read them from API
+// server on the paths that need the latest version.
+func uncachedObjects() []client.Object {
+	if agentContext.Cfg.EnableCacheReads {
+		return []client.Object{&spiderpoolv1.SpiderEndpoint{}}
+	}
+
+	return []client.Object{
+		&spiderpoolv1.SpiderSubnet{},
+		&spiderpoolv1.SpiderIPPool{},
+		&spiderpoolv1.SpiderEndpoint{},
+	}
+}
+
+// newConfigManager uses a client without cache, because it is used to read
+// SpiderpoolConfiguration before the runtime manager is initialized.
+func newConfigManager() (configmanager.ConfigManager, error) {
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
 		return nil, err
 	}
 
-	return mgr, nil
+	return configmanager.NewConfigManager(c)
 }
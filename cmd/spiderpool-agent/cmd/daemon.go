@@ -10,23 +10,47 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/google/gops/agent"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/pyroscope-io/client/pyroscope"
-
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/spidernet-io/spiderpool/pkg/allocationjournal"
+	"github.com/spidernet-io/spiderpool/pkg/allocationpolicy"
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/chaos"
+	"github.com/spidernet-io/spiderpool/pkg/cniconfmanager"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	"github.com/spidernet-io/spiderpool/pkg/ipam"
+	"github.com/spidernet-io/spiderpool/pkg/ipconflictmonitor"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/kubeletclient"
 	"github.com/spidernet-io/spiderpool/pkg/limiter"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
+	"github.com/spidernet-io/spiderpool/pkg/nodelabelmanager"
 	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/singletons"
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/subnetdiscovery"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
@@ -65,7 +89,28 @@ func DaemonMain() {
 	if err := agentContext.LoadConfigmap(); err != nil {
 		logger.Sugar().Fatal("failed to load Configmap: %v", err)
 	}
+
+	logger.Info("Begin to load SpiderpoolConfiguration")
+	configManager, err := newConfigManager()
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	spiderpoolConfig, err := configManager.GetSpiderpoolConfiguration(context.TODO())
+	if err != nil {
+		logger.Sugar().Fatalf("failed to get SpiderpoolConfiguration: %v", err)
+	}
+	if spiderpoolConfig != nil {
+		if err := agentContext.ApplySpiderpoolConfiguration(spiderpoolConfig.Spec); err != nil {
+			logger.Sugar().Fatalf("failed to apply SpiderpoolConfiguration: %v", err)
+		}
+		if err := configManager.UpdateComponentStatus(context.TODO(), BinNameAgent, spiderpoolConfig.Generation, agentContext.ActiveSpiderpoolConfiguration()); err != nil {
+			logger.Sugar().Warnf("failed to update the status of SpiderpoolConfiguration: %v", err)
+		}
+	}
 	logger.Sugar().Infof("Spiderpool-agent config: %+v", agentContext.Cfg)
+	for _, gate := range features.ListFeatureGates(features.DefaultFeatureGate) {
+		logger.Sugar().Infof("Feature gate %s=%t (%s, default=%t)", gate.Name, gate.Enabled, gate.PreRelease, gate.Default)
+	}
 
 	if agentContext.Cfg.GopsListenPort != "" {
 		address := "127.0.0.1:" + agentContext.Cfg.GopsListenPort
@@ -115,6 +160,7 @@ func DaemonMain() {
 		agentContext.Cfg.ClusterDefaultIPv4Subnet,
 		agentContext.Cfg.ClusterDefaultIPv6Subnet,
 		agentContext.Cfg.ClusterSubnetDefaultFlexibleIPNum,
+		pointer.BoolDeref(agentContext.Cfg.ClusterSubnetDefaultReclaimIPPool, true),
 	)
 
 	agentContext.InnerCtx, agentContext.InnerCancel = context.WithCancel(context.Background())
@@ -125,21 +171,64 @@ func DaemonMain() {
 	}
 	agentContext.CRDManager = mgr
 
+	logger.Debug("Begin to initialize K8s clientset")
+	clientSet, err := kubernetes.NewForConfig(ctrl.GetConfigOrDie())
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+	agentContext.ClientSet = clientSet
+
+	// The events are reported by IPAM as well, e.g. for the allocations
+	// deferred by the allocation windows of IPPools.
+	initEventRecorder()
+
 	// init managers...
 	initAgentServiceManagers(agentContext.InnerCtx)
 
+	var journal allocationjournal.Journal
+	if agentContext.Cfg.AllocationJournalPath != "" {
+		logger.Info("Begin to initialize allocation journal")
+		journal, err = allocationjournal.NewJournal(allocationjournal.JournalConfig{
+			Path: agentContext.Cfg.AllocationJournalPath,
+		})
+		if nil != err {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	nodeName := agentContext.Cfg.NodeName
+	if nodeName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Sugar().Fatalf("failed to get hostname, reason=%v", err)
+		}
+		nodeName = hostname
+	}
+
 	logger.Info("Begin to initialize IPAM")
 	ipam, err := ipam.NewIPAM(
 		ipam.IPAMConfig{
-			EnableIPv4:               agentContext.Cfg.EnableIPv4,
-			EnableIPv6:               agentContext.Cfg.EnableIPv6,
-			ClusterDefaultIPv4IPPool: agentContext.Cfg.ClusterDefaultIPv4IPPool,
-			ClusterDefaultIPv6IPPool: agentContext.Cfg.ClusterDefaultIPv6IPPool,
-			EnableSpiderSubnet:       agentContext.Cfg.EnableSpiderSubnet,
-			EnableStatefulSet:        agentContext.Cfg.EnableStatefulSet,
-			OperationRetries:         agentContext.Cfg.UpdateCRMaxRetries,
-			OperationGapDuration:     time.Duration(agentContext.Cfg.WaitSubnetPoolTime) * time.Second,
-			LimiterConfig:            limiter.LimiterConfig{MaxQueueSize: &agentContext.Cfg.LimiterMaxQueueSize},
+			EnableIPv4:                  agentContext.Cfg.EnableIPv4,
+			EnableIPv6:                  agentContext.Cfg.EnableIPv6,
+			ClusterDefaultIPv4IPPool:    agentContext.Cfg.ClusterDefaultIPv4IPPool,
+			ClusterDefaultIPv6IPPool:    agentContext.Cfg.ClusterDefaultIPv6IPPool,
+			EnableSpiderSubnet:          agentContext.Cfg.EnableSpiderSubnet,
+			EnableStatefulSet:           agentContext.Cfg.EnableStatefulSet,
+			EnableGatewayDetection:      agentContext.Cfg.EnableGatewayDetection,
+			EnableIPConflictDetection:   agentContext.Cfg.EnableIPConflictDetection,
+			EnablePodAssignedAnnotation: agentContext.Cfg.EnablePodAssignedAnnotation,
+			OperationRetries:            agentContext.Cfg.UpdateCRMaxRetries,
+			OperationGapDuration:        time.Duration(agentContext.Cfg.WaitSubnetPoolTime) * time.Second,
+			LimiterConfig:               limiter.LimiterConfig{MaxQueueSize: &agentContext.Cfg.LimiterMaxQueueSize},
+			Freeze:                      agentContext.FreezeMonitor,
+			InterfaceExists:             interfaceExists,
+			NodeName:                    nodeName,
+			StageTimeouts: ipam.StageTimeouts{
+				PoolSelection:  time.Duration(agentContext.Cfg.PoolSelectionTimeout) * time.Second,
+				IPAllocation:   time.Duration(agentContext.Cfg.IPAllocationTimeout) * time.Second,
+				EndpointUpdate: time.Duration(agentContext.Cfg.EndpointUpdateTimeout) * time.Second,
+				Release:        time.Duration(agentContext.Cfg.ReleaseTimeout) * time.Second,
+			},
 		},
 		agentContext.IPPoolManager,
 		agentContext.EndpointManager,
@@ -148,6 +237,7 @@ func DaemonMain() {
 		agentContext.PodManager,
 		agentContext.StsManager,
 		agentContext.SubnetManager,
+		journal,
 	)
 	if nil != err {
 		logger.Fatal(err.Error())
@@ -168,6 +258,27 @@ func DaemonMain() {
 		}
 	}()
 
+	logger.Info("Begin to initialize kubelet client")
+	kubeletClient, err := kubeletclient.NewKubeletClient(
+		kubeletclient.KubeletClientConfig{
+			Address:    agentContext.Cfg.KubeletAddress,
+			ServerName: agentContext.Cfg.NodeName,
+			CAFile:     agentContext.Cfg.KubeletCAFile,
+		},
+		ctrl.GetConfigOrDie(),
+	)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+	agentContext.KubeletClient = kubeletClient
+
+	logger.Debug("Begin to initialize the authorizer of OpenAPI HTTP server")
+	authorizer, err := apiauthorizer.NewAPIAuthorizer(agentContext.ClientSet)
+	if nil != err {
+		logger.Fatal(err.Error())
+	}
+	agentContext.APIAuthorizer = authorizer
+
 	logger.Info("Begin to initialize spiderpool-agent OpenAPI HTTP server")
 	srv, err := newAgentOpenAPIHttpServer()
 	if nil != err {
@@ -212,6 +323,26 @@ func DaemonMain() {
 	}
 	agentContext.unixClient = spiderpoolAgentAPI
 
+	if agentContext.Cfg.IPConflictMonitorInterfaces != "" {
+		logger.Info("Begin to initialize IP conflict monitor")
+		initIPConflictMonitor(agentContext.InnerCtx)
+	}
+
+	if agentContext.Cfg.CNIConfDir != "" {
+		logger.Info("Begin to initialize CNI config manager")
+		initCNIConfManager(agentContext.InnerCtx)
+	}
+
+	if agentContext.Cfg.EnableNodeIPPoolLabels {
+		logger.Info("Begin to initialize node label manager")
+		initNodeLabelManager(agentContext.InnerCtx)
+	}
+
+	if agentContext.Cfg.SubnetDiscoveryMode != "" {
+		logger.Info("Begin to initialize subnet discovery")
+		initSubnetDiscovery(agentContext.InnerCtx)
+	}
+
 	// TODO (Icarus9913): improve k8s StartupProbe
 	logger.Info("Set spiderpool-agent startup probe ready")
 	agentContext.IsStartupProbe.Store(true)
@@ -268,13 +399,60 @@ func initAgentServiceManagers(ctx context.Context) {
 	}
 	agentContext.NSManager = nsManager
 
+	logger.Debug("Begin to initialize maintenance freeze monitor")
+	freezeConfigManager, err := configmanager.NewConfigManager(agentContext.CRDManager.GetClient())
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	freezeMonitor, err := configmanager.NewFreezeMonitor(
+		configmanager.FreezeMonitorConfig{
+			Component:    constant.SpiderpoolAgent,
+			ResyncPeriod: time.Duration(agentContext.Cfg.FreezeResyncPeriod) * time.Second,
+		},
+		freezeConfigManager,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	freezeMonitor.Start(logutils.IntoContext(ctx, logger.Named("Freeze-Monitor")))
+	agentContext.FreezeMonitor = freezeMonitor
+
+	// Options shared by the managers of Pods, ReservedIPs, IPPools and Subnets.
+	managerOpts := []manageroption.Option{
+		manageroption.WithCache(agentContext.CRDManager.GetClient()),
+		manageroption.WithMetrics(metric.ManagerMetrics{}),
+		manageroption.WithRetryPolicy(agentContext.Cfg.UpdateCRMaxRetries, time.Duration(agentContext.Cfg.UpdateCRRetryUnitTime)*time.Millisecond),
+		manageroption.WithIndexer(agentContext.CRDManager.GetFieldIndexer()),
+		manageroption.WithFreeze(freezeMonitor),
+		manageroption.WithScales(podmanager.NewScaleGetter(agentContext.CRDManager.GetRESTMapper(), dynamic.NewForConfigOrDie(ctrl.GetConfigOrDie()))),
+	}
+
+	if agentContext.Cfg.AllocationPolicyURL != "" {
+		logger.Debug("Begin to initialize allocation policy")
+		failurePolicy, err := allocationpolicy.ParseFailurePolicy(agentContext.Cfg.AllocationPolicyFailurePolicy)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		policy, err := allocationpolicy.NewAllocationPolicy(
+			allocationpolicy.AllocationPolicyConfig{
+				URL:           agentContext.Cfg.AllocationPolicyURL,
+				Timeout:       time.Duration(agentContext.Cfg.AllocationPolicyTimeout) * time.Millisecond,
+				FailurePolicy: failurePolicy,
+			},
+			nil,
+		)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		managerOpts = append(managerOpts, manageroption.WithAllocationPolicy(policy))
+	}
+
 	logger.Debug("Begin to initialize Pod manager")
 	podManager, err := podmanager.NewPodManager(
-		podmanager.PodManagerConfig{
-			MaxConflictRetries:    agentContext.Cfg.UpdateCRMaxRetries,
-			ConflictRetryUnitTime: time.Duration(agentContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
-		},
+		podmanager.PodManagerConfig{},
 		agentContext.CRDManager.GetClient(),
+		agentContext.CRDManager.GetAPIReader(),
+		managerOpts...,
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -294,6 +472,7 @@ func initAgentServiceManagers(ctx context.Context) {
 			MaxConflictRetries:    agentContext.Cfg.UpdateCRMaxRetries,
 			ConflictRetryUnitTime: time.Duration(agentContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
 			MaxHistoryRecords:     &agentContext.Cfg.WorkloadEndpointMaxHistoryRecords,
+			ApplicationLabelKeys:  agentContext.Cfg.ApplicationLabelKeys,
 		},
 		agentContext.CRDManager.GetClient(),
 	)
@@ -303,7 +482,7 @@ func initAgentServiceManagers(ctx context.Context) {
 	agentContext.EndpointManager = endpointManager
 
 	logger.Debug("Begin to initialize ReservedIP manager")
-	rIPManager, err := reservedipmanager.NewReservedIPManager(agentContext.CRDManager.GetClient())
+	rIPManager, err := reservedipmanager.NewReservedIPManager(agentContext.CRDManager.GetClient(), managerOpts...)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
@@ -312,12 +491,14 @@ func initAgentServiceManagers(ctx context.Context) {
 	logger.Debug("Begin to initialize IPPool manager")
 	ipPoolManager, err := ippoolmanager.NewIPPoolManager(
 		ippoolmanager.IPPoolManagerConfig{
-			MaxConflictRetries:    agentContext.Cfg.UpdateCRMaxRetries,
-			ConflictRetryUnitTime: time.Duration(agentContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
-			MaxAllocatedIPs:       &agentContext.Cfg.IPPoolMaxAllocatedIPs,
+			MaxAllocatedIPs:  &agentContext.Cfg.IPPoolMaxAllocatedIPs,
+			EnableGapFilling: agentContext.Cfg.EnableIPPoolGapFilling,
+			StatusShardSize:  agentContext.Cfg.IPPoolStatusShardSize,
 		},
-		agentContext.CRDManager.GetClient(),
+		chaos.WrapClient(agentContext.CRDManager.GetClient()),
+		agentContext.CRDManager.GetAPIReader(),
 		agentContext.RIPManager,
+		managerOpts...,
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -328,12 +509,13 @@ func initAgentServiceManagers(ctx context.Context) {
 		logger.Debug("Begin to initialize Subnet manager")
 		subnetManager, err := subnetmanager.NewSubnetManager(
 			subnetmanager.SubnetManagerConfig{
-				MaxConflictRetries:    agentContext.Cfg.UpdateCRMaxRetries,
-				ConflictRetryUnitTime: time.Duration(agentContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
+				ApplicationLabelKeys: agentContext.Cfg.ApplicationLabelKeys,
 			},
 			agentContext.CRDManager.GetClient(),
+			agentContext.CRDManager.GetAPIReader(),
 			agentContext.IPPoolManager,
 			agentContext.CRDManager.GetScheme(),
+			managerOpts...,
 		)
 		if err != nil {
 			logger.Fatal(err.Error())
@@ -343,3 +525,183 @@ func initAgentServiceManagers(ctx context.Context) {
 		logger.Info("Feature SpiderSubnet is disabled")
 	}
 }
+
+// initEventRecorder initializes the recorder of the events reported by
+// spiderpool-agent.
+func initEventRecorder() {
+	event.InitEventRecorder(agentContext.ClientSet, agentContext.CRDManager.GetScheme(), constant.SpiderpoolAgent)
+}
+
+// initIPConflictMonitor monitors the conflicts of the IP addresses allocated
+// to the Pods on the node, and reports them with Pod events.
+func initIPConflictMonitor(ctx context.Context) {
+	nodeName := agentContext.Cfg.NodeName
+	if nodeName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Sugar().Fatalf("failed to get hostname, reason=%v", err)
+		}
+		nodeName = hostname
+	}
+
+	var interfaces []string
+	for _, iface := range strings.Split(agentContext.Cfg.IPConflictMonitorInterfaces, ",") {
+		if iface = strings.TrimSpace(iface); iface != "" {
+			interfaces = append(interfaces, iface)
+		}
+	}
+
+	monitor, err := ipconflictmonitor.NewIPConflictMonitor(
+		ipconflictmonitor.IPConflictMonitorConfig{
+			Interfaces: interfaces,
+			NodeName:   nodeName,
+		},
+		agentContext.CRDManager.GetClient(),
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	monitor.Start(logutils.IntoContext(ctx, logger.Named("IP-Conflict-Monitor")))
+}
+
+// initCNIConfManager maintains the CNI config files of the node generated
+// from NetworkAttachmentDefinitions, and regenerates them on their changes.
+func initCNIConfManager(ctx context.Context) {
+	nodeName := agentContext.Cfg.NodeName
+	if nodeName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Sugar().Fatalf("failed to get hostname, reason=%v", err)
+		}
+		nodeName = hostname
+	}
+
+	manager, err := cniconfmanager.NewCNIConfManager(
+		cniconfmanager.CNIConfManagerConfig{
+			ConfDir:  agentContext.Cfg.CNIConfDir,
+			NodeName: nodeName,
+		},
+		agentContext.CRDManager.GetClient(),
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	informer, err := agentContext.CRDManager.GetCache().GetInformer(ctx, &netv1.NetworkAttachmentDefinition{})
+	if err != nil {
+		logger.Sugar().Warnf("Failed to watch NetworkAttachmentDefinitions, regenerate CNI config files periodically only: %v", err)
+	} else {
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(obj interface{}) { manager.Enqueue() },
+			UpdateFunc: func(oldObj, newObj interface{}) { manager.Enqueue() },
+			DeleteFunc: func(obj interface{}) { manager.Enqueue() },
+		})
+	}
+
+	// The VLANs of IPPools are rendered into the CNI config files too.
+	poolInformer, err := agentContext.CRDManager.GetCache().GetInformer(ctx, &spiderpoolv1.SpiderIPPool{})
+	if err != nil {
+		logger.Sugar().Warnf("Failed to watch IPPools, regenerate CNI config files periodically only: %v", err)
+	} else {
+		poolInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { manager.Enqueue() },
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// Skip the updates of the IP allocations in the status.
+				oldPool, oldOK := oldObj.(*spiderpoolv1.SpiderIPPool)
+				newPool, newOK := newObj.(*spiderpoolv1.SpiderIPPool)
+				if oldOK && newOK && oldPool.Generation == newPool.Generation {
+					return
+				}
+				manager.Enqueue()
+			},
+			DeleteFunc: func(obj interface{}) { manager.Enqueue() },
+		})
+	}
+
+	manager.Start(logutils.IntoContext(ctx, logger.Named("CNI-Conf-Manager")))
+}
+
+// initNodeLabelManager advertises the IPPools usable on the node with its
+// labels, and refreshes them on the changes of IPPools.
+func initNodeLabelManager(ctx context.Context) {
+	nodeName := agentContext.Cfg.NodeName
+	if nodeName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Sugar().Fatalf("failed to get hostname, reason=%v", err)
+		}
+		nodeName = hostname
+	}
+
+	manager, err := nodelabelmanager.NewNodeLabelManager(
+		nodelabelmanager.NodeLabelManagerConfig{
+			NodeName: nodeName,
+		},
+		agentContext.CRDManager.GetClient(),
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	informer, err := agentContext.CRDManager.GetCache().GetInformer(ctx, &spiderpoolv1.SpiderIPPool{})
+	if err != nil {
+		logger.Sugar().Warnf("Failed to watch IPPools, refresh the labels of the node periodically only: %v", err)
+	} else {
+		informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) { manager.Enqueue() },
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// Skip the updates of the IP allocations in the status.
+				oldPool, oldOK := oldObj.(*spiderpoolv1.SpiderIPPool)
+				newPool, newOK := newObj.(*spiderpoolv1.SpiderIPPool)
+				if oldOK && newOK && oldPool.Generation == newPool.Generation &&
+					reflect.DeepEqual(oldPool.Annotations, newPool.Annotations) &&
+					oldPool.DeletionTimestamp.Equal(newPool.DeletionTimestamp) {
+					return
+				}
+				manager.Enqueue()
+			},
+			DeleteFunc: func(obj interface{}) { manager.Enqueue() },
+		})
+	}
+
+	manager.Start(logutils.IntoContext(ctx, logger.Named("Node-Label-Manager")))
+}
+
+// initSubnetDiscovery proposes or creates the SpiderSubnet drafts of the L2
+// segments which the interfaces of the node are attached to.
+func initSubnetDiscovery(ctx context.Context) {
+	nodeName := agentContext.Cfg.NodeName
+	if nodeName == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logger.Sugar().Fatalf("failed to get hostname, reason=%v", err)
+		}
+		nodeName = hostname
+	}
+
+	var interfaces []string
+	for _, iface := range strings.Split(agentContext.Cfg.SubnetDiscoveryInterfaces, ",") {
+		if iface = strings.TrimSpace(iface); iface != "" {
+			interfaces = append(interfaces, iface)
+		}
+	}
+
+	discovery, err := subnetdiscovery.NewSubnetDiscovery(
+		subnetdiscovery.SubnetDiscoveryConfig{
+			NodeName:   nodeName,
+			Mode:       agentContext.Cfg.SubnetDiscoveryMode,
+			Interfaces: interfaces,
+		},
+		agentContext.CRDManager.GetClient(),
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	discovery.Start(logutils.IntoContext(ctx, logger.Named("Subnet-Discovery")))
+}
+
+// interfaceExists reports whether the host interface exists on the node.
+func interfaceExists(name string) bool {
+	_, err := net.InterfaceByName(name)
+	return err == nil
+}
@@ -32,6 +32,10 @@ func newAgentOpenAPIHttpServer() (*agentOpenAPIServer.Server, error) {
 	api.RuntimeGetRuntimeStartupHandler = httpGetAgentStartup
 	api.RuntimeGetRuntimeReadinessHandler = httpGetAgentReadiness
 	api.RuntimeGetRuntimeLivenessHandler = httpGetAgentLiveness
+	api.RuntimeGetFeaturezHandler = httpGetAgentFeaturez
+
+	// daemonset API
+	api.DaemonsetGetIpamIPInuseHandler = httpGetAgentIpamIPInuse
 
 	// new agent OpenAPI server with api
 	srv := agentOpenAPIServer.NewServer(api)
@@ -6,12 +6,15 @@ package cmd
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-openapi/runtime/middleware"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/server/restapi/daemonset"
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
@@ -23,6 +26,9 @@ var (
 	unixDeleteAgentIpamIp  = &_unixDeleteAgentIpamIp{}
 	unixPostAgentIpamIps   = &_unixPostAgentIpamIps{}
 	unixDeleteAgentIpamIps = &_unixDeleteAgentIpamIps{}
+	unixGetAgentIpamIps    = &_unixGetAgentIpamIps{}
+
+	httpGetAgentIpamIPInuse = &_httpGetAgentIpamIPInuse{}
 )
 
 type _unixPostAgentIpamIp struct{}
@@ -37,6 +43,8 @@ func (g *_unixPostAgentIpamIp) Handle(params daemonset.PostIpamIPParams) middlew
 		zap.String("PodName", *params.IpamAddArgs.PodName),
 	)
 	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+	ctx, cancel := withCNIRequestTimeout(ctx, params.IpamAddArgs.TimeoutSeconds)
+	defer cancel()
 
 	// The total count of IP allocations.
 	metric.IpamAllocationTotalCounts.Add(ctx, 1)
@@ -55,12 +63,27 @@ func (g *_unixPostAgentIpamIp) Handle(params daemonset.PostIpamIPParams) middlew
 		metric.IpamAllocationFailureCounts.Add(ctx, 1)
 		gatherIPAMAllocationErrMetric(ctx, err)
 		logger.Error(err.Error())
-		return daemonset.NewPostIpamIPFailure().WithPayload(models.Error(err.Error()))
+		return postIpamIPFailure(err)
 	}
 
 	return daemonset.NewPostIpamIPOK().WithPayload(resp)
 }
 
+// postIpamIPFailure maps the class of the allocation error to the response
+// code, so that the CNI plugin is able to tell the retriable failures apart.
+func postIpamIPFailure(err error) middleware.Responder {
+	switch {
+	case errors.Is(err, constant.ErrPoolExhausted):
+		return daemonset.NewPostIpamIPExhausted().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrOwnerNotFound):
+		return daemonset.NewPostIpamIPOwnerNotFound().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrOverlap):
+		return daemonset.NewPostIpamIPOverlap().WithPayload(models.Error(err.Error()))
+	default:
+		return daemonset.NewPostIpamIPFailure().WithPayload(models.Error(err.Error()))
+	}
+}
+
 type _unixDeleteAgentIpamIp struct{}
 
 // Handle handles DELETE requests for /ipam/ip.
@@ -73,6 +96,8 @@ func (g *_unixDeleteAgentIpamIp) Handle(params daemonset.DeleteIpamIPParams) mid
 		zap.String("PodName", *params.IpamDelArgs.PodName),
 	)
 	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+	ctx, cancel := withCNIRequestTimeout(ctx, params.IpamDelArgs.TimeoutSeconds)
+	defer cancel()
 
 	// The total count of IP releasing.
 	metric.IpamReleaseTotalCounts.Add(ctx, 1)
@@ -114,6 +139,18 @@ func (g *_unixDeleteAgentIpamIps) Handle(params daemonset.DeleteIpamIpsParams) m
 	return daemonset.NewDeleteIpamIpsOK()
 }
 
+// withCNIRequestTimeout bounds the handling of the CNI request with the
+// remaining seconds of it, so that the IPPools are not written any more once
+// the CNI plugin has given up. Without the timeout, the context is still
+// canceled when the CNI plugin disconnects.
+func withCNIRequestTimeout(ctx context.Context, timeoutSeconds int64) (context.Context, context.CancelFunc) {
+	if timeoutSeconds <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+}
+
 func gatherIPAMAllocationErrMetric(ctx context.Context, err error) {
 	internal := true
 	if errors.Is(err, constant.ErrWrongInput) {
@@ -132,6 +169,14 @@ func gatherIPAMAllocationErrMetric(ctx context.Context, err error) {
 		metric.IpamAllocationErrIPUsedOutCounts.Add(ctx, 1)
 		internal = false
 	}
+	if errors.Is(err, constant.ErrFrozen) {
+		metric.IpamAllocationErrFrozenCounts.Add(ctx, 1)
+		internal = false
+	}
+	if errors.Is(err, constant.ErrUnsupportedNode) {
+		metric.IpamAllocationErrUnsupportedNodeCounts.Add(ctx, 1)
+		internal = false
+	}
 
 	if internal {
 		metric.IpamAllocationErrInternalCounts.Add(ctx, 1)
@@ -149,3 +194,76 @@ func gatherIPAMReleasingErrMetric(ctx context.Context, err error) {
 		metric.IpamReleaseErrInternalCounts.Add(ctx, 1)
 	}
 }
+
+type _unixGetAgentIpamIps struct{}
+
+// Handle handles GET requests for /ipam/ips. It is only served on the unix
+// socket, since the Pods on the node and their IPs are revealed.
+func (g *_unixGetAgentIpamIps) Handle(params daemonset.GetIpamIpsParams) middleware.Responder {
+	logger := logutils.Logger.Named("IPAM")
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	allocations, err := agentContext.IPAM.ListAllocations(ctx)
+	if err != nil {
+		logger.Error(err.Error())
+		return daemonset.NewGetIpamIpsFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	matched := models.IPAllocations{}
+	for _, a := range allocations {
+		if params.PodNamespace != nil && *a.PodNamespace != *params.PodNamespace {
+			continue
+		}
+		if params.PodName != nil && *a.PodName != *params.PodName {
+			continue
+		}
+		matched = append(matched, a)
+	}
+
+	return daemonset.NewGetIpamIpsOK().WithPayload(matched)
+}
+
+type _httpGetAgentIpamIPInuse struct{}
+
+// Handle handles GET requests for /ipam/ip/inuse. The caller has to be able
+// to list Pods, since the Pods on the node using the IP are revealed.
+func (g *_httpGetAgentIpamIPInuse) Handle(params daemonset.GetIpamIPInuseParams) middleware.Responder {
+	logger := logutils.Logger.Named("IPAM").With(zap.String("IP", params.IP))
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	err := agentContext.APIAuthorizer.Authorize(params.HTTPRequest, apiauthorizer.ResourceAttributes{
+		Verb:     "list",
+		Version:  corev1.SchemeGroupVersion.Version,
+		Resource: "pods",
+	})
+	if err != nil {
+		logger.Sugar().Warnf("Failed to authorize the request: %v", err)
+		switch {
+		case errors.Is(err, constant.ErrUnauthorized):
+			return daemonset.NewGetIpamIPInuseUnauthorized().WithPayload(models.Error(err.Error()))
+		case errors.Is(err, constant.ErrForbidden):
+			return daemonset.NewGetIpamIPInuseForbidden().WithPayload(models.Error(err.Error()))
+		default:
+			return daemonset.NewGetIpamIPInuseFailure().WithPayload(models.Error(err.Error()))
+		}
+	}
+
+	pods, err := agentContext.KubeletClient.PodsUsingIP(ctx, params.IP)
+	if err != nil {
+		logger.Error(err.Error())
+		if errors.Is(err, constant.ErrWrongInput) {
+			return daemonset.NewGetIpamIPInuseBadRequest().WithPayload(models.Error(err.Error()))
+		}
+		return daemonset.NewGetIpamIPInuseFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	inUse := len(pods) > 0
+	if inUse {
+		logger.Sugar().Infof("IP is still used by Pods %v on the node", pods)
+	}
+
+	return daemonset.NewGetIpamIPInuseOK().WithPayload(&models.IPInUse{
+		InUse: &inUse,
+		Pods:  pods,
+	})
+}
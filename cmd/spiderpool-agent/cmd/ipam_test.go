@@ -0,0 +1,146 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/ipam"
+)
+
+// fakeIPAM lists the preset IP allocations of the node.
+type fakeIPAM struct {
+	ipam.IPAM
+
+	allocations models.IPAllocations
+	err         error
+}
+
+func (f *fakeIPAM) ListAllocations(ctx context.Context) (models.IPAllocations, error) {
+	return f.allocations, f.err
+}
+
+func newIPAllocation(namespace, name, address string) *models.IPAllocation {
+	return &models.IPAllocation{
+		PodNamespace: pointer.String(namespace),
+		PodName:      pointer.String(name),
+		ContainerID:  pointer.String("dummy"),
+		Nic:          pointer.String("eth0"),
+		Version:      pointer.Int64(4),
+		Address:      pointer.String(address),
+		IPPool:       "default-v4-ippool",
+	}
+}
+
+var _ = Describe("IPAM API", Label("ipam_test"), func() {
+	Describe("GET /ipam/ips", func() {
+		var fake *fakeIPAM
+		var origIPAM ipam.IPAM
+
+		BeforeEach(func() {
+			fake = &fakeIPAM{
+				allocations: models.IPAllocations{
+					newIPAllocation("default", "nginx", "172.18.40.10/24"),
+					newIPAllocation("default", "redis", "172.18.40.11/24"),
+					newIPAllocation("kube-system", "nginx", "172.18.40.12/24"),
+				},
+			}
+			origIPAM = agentContext.IPAM
+			agentContext.IPAM = fake
+			DeferCleanup(func() {
+				agentContext.IPAM = origIPAM
+			})
+		})
+
+		get := func(handler http.Handler, target string) *httptest.ResponseRecorder {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+
+			return rr
+		}
+
+		listOnUnixServer := func(target string) []string {
+			srv, err := NewAgentOpenAPIUnixServer()
+			Expect(err).NotTo(HaveOccurred())
+
+			rr := get(srv.GetHandler(), target)
+			Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+			var allocations models.IPAllocations
+			err = json.Unmarshal(rr.Body.Bytes(), &allocations)
+			Expect(err).NotTo(HaveOccurred())
+
+			var addresses []string
+			for _, a := range allocations {
+				addresses = append(addresses, *a.Address)
+			}
+
+			return addresses
+		}
+
+		It("lists all IP allocations of the node", func() {
+			srv, err := NewAgentOpenAPIUnixServer()
+			Expect(err).NotTo(HaveOccurred())
+
+			rr := get(srv.GetHandler(), "/v1/ipam/ips")
+			Expect(rr.Code).To(Equal(http.StatusOK))
+
+			var allocations models.IPAllocations
+			err = json.Unmarshal(rr.Body.Bytes(), &allocations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allocations).To(Equal(fake.allocations))
+		})
+
+		It("lists the IP allocations of the Pods in the namespace", func() {
+			Expect(listOnUnixServer("/v1/ipam/ips?podNamespace=default")).To(Equal([]string{"172.18.40.10/24", "172.18.40.11/24"}))
+		})
+
+		It("lists the IP allocations of the Pod", func() {
+			Expect(listOnUnixServer("/v1/ipam/ips?podNamespace=default&podName=nginx")).To(Equal([]string{"172.18.40.10/24"}))
+		})
+
+		It("lists the IP allocations of the Pods with the name in all namespaces", func() {
+			Expect(listOnUnixServer("/v1/ipam/ips?podName=nginx")).To(Equal([]string{"172.18.40.10/24", "172.18.40.12/24"}))
+		})
+
+		It("lists nothing if no Pod matches", func() {
+			Expect(listOnUnixServer("/v1/ipam/ips?podNamespace=monitoring")).To(BeEmpty())
+		})
+
+		It("fails to list the IP allocations before they are seeded", func() {
+			fake.err = errors.New("IP allocations of the node are not synced yet")
+
+			srv, err := NewAgentOpenAPIUnixServer()
+			Expect(err).NotTo(HaveOccurred())
+
+			rr := get(srv.GetHandler(), "/v1/ipam/ips")
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+			Expect(rr.Body.String()).To(ContainSubstring(fake.err.Error()))
+		})
+
+		It("is not served on the http port", func() {
+			origHttpPort := agentContext.Cfg.HttpPort
+			agentContext.Cfg.HttpPort = "5710"
+			DeferCleanup(func() {
+				agentContext.Cfg.HttpPort = origHttpPort
+			})
+
+			srv, err := newAgentOpenAPIHttpServer()
+			Expect(err).NotTo(HaveOccurred())
+
+			rr := get(srv.GetHandler(), "/v1/ipam/ips")
+			Expect(rr.Code).To(Equal(http.StatusNotImplemented))
+			Expect(rr.Body.String()).NotTo(ContainSubstring("172.18.40.10"))
+		})
+	})
+})
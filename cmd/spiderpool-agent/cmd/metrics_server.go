@@ -14,7 +14,8 @@ import (
 
 // initAgentMetricsServer will start an opentelemetry http server for spiderpool agent.
 func initAgentMetricsServer(ctx context.Context) {
-	metricController, err := metric.InitMetricController(ctx, constant.SpiderpoolAgent, agentContext.Cfg.EnabledMetric)
+	metricController, err := metric.InitMetricController(ctx, constant.SpiderpoolAgent, agentContext.Cfg.EnabledMetric,
+		metric.WithHistogramBuckets(agentContext.Cfg.MetricHistogramBuckets...))
 	if nil != err {
 		logger.Fatal(err.Error())
 	}
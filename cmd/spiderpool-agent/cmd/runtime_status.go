@@ -6,8 +6,11 @@ package cmd
 import (
 	"github.com/go-openapi/runtime/middleware"
 
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+
 	"github.com/spidernet-io/spiderpool/api/v1/agent/client/connectivity"
 	"github.com/spidernet-io/spiderpool/api/v1/agent/server/restapi/runtime"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 )
 
 // Singleton
@@ -15,6 +18,7 @@ var (
 	httpGetAgentStartup   = &_httpGetAgentStartup{agentContext}
 	httpGetAgentReadiness = &_httpGetAgentReadiness{agentContext}
 	httpGetAgentLiveness  = &_httpGetAgentLiveness{agentContext}
+	httpGetAgentFeaturez  = &_httpGetAgentFeaturez{}
 )
 
 type _httpGetAgentStartup struct {
@@ -59,3 +63,21 @@ func (g *_httpGetAgentLiveness) Handle(params runtime.GetRuntimeLivenessParams)
 
 	return runtime.NewGetRuntimeLivenessOK()
 }
+
+type _httpGetAgentFeaturez struct{}
+
+// Handle handles GET requests for the status of feature gates.
+func (g *_httpGetAgentFeaturez) Handle(params runtime.GetFeaturezParams) middleware.Responder {
+	gates := features.ListFeatureGates(features.DefaultFeatureGate)
+	payload := make([]*models.FeatureGate, 0, len(gates))
+	for _, gate := range gates {
+		payload = append(payload, &models.FeatureGate{
+			Name:       gate.Name,
+			Enabled:    gate.Enabled,
+			Default:    gate.Default,
+			PreRelease: gate.PreRelease,
+		})
+	}
+
+	return runtime.NewGetFeaturezOK().WithPayload(payload)
+}
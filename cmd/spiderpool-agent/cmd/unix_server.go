@@ -41,6 +41,8 @@ func NewAgentOpenAPIUnixServer() (*agentOpenAPIServer.Server, error) {
 	api.DaemonsetDeleteIpamIPHandler = unixDeleteAgentIpamIp
 	api.DaemonsetPostIpamIpsHandler = unixPostAgentIpamIps
 	api.DaemonsetDeleteIpamIpsHandler = unixDeleteAgentIpamIps
+	api.DaemonsetGetIpamIpsHandler = unixGetAgentIpamIps
+	api.DaemonsetPutWorkloadendpointSlaacHandler = unixPutWorkloadendpointSlaac
 
 	// new agent OpenAPI server with api
 	srv := agentOpenAPIServer.NewServer(api)
@@ -0,0 +1,43 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/go-openapi/runtime/middleware"
+	"go.uber.org/zap"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/server/restapi/daemonset"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// Singleton.
+var unixPutWorkloadendpointSlaac = &_unixPutWorkloadendpointSlaac{}
+
+type _unixPutWorkloadendpointSlaac struct{}
+
+// Handle handles PUT requests for /workloadendpoint/slaac.
+func (g *_unixPutWorkloadendpointSlaac) Handle(params daemonset.PutWorkloadendpointSlaacParams) middleware.Responder {
+	logger := logutils.Logger.Named("IPAM").With(
+		zap.String("ContainerID", *params.SlaacAddresses.ContainerID),
+		zap.String("IfName", *params.SlaacAddresses.IfName),
+		zap.String("PodNamespace", *params.SlaacAddresses.PodNamespace),
+		zap.String("PodName", *params.SlaacAddresses.PodName),
+	)
+	ctx := logutils.IntoContext(params.HTTPRequest.Context(), logger)
+
+	endpoint, err := agentContext.EndpointManager.GetEndpointByName(ctx, *params.SlaacAddresses.PodNamespace, *params.SlaacAddresses.PodName)
+	if err != nil {
+		logger.Error(err.Error())
+		return daemonset.NewPutWorkloadendpointSlaacFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	if err := agentContext.EndpointManager.RecordSLAACIPs(ctx, *params.SlaacAddresses.ContainerID, *params.SlaacAddresses.IfName, params.SlaacAddresses.Ips, endpoint); err != nil {
+		logger.Error(err.Error())
+		return daemonset.NewPutWorkloadendpointSlaacFailure().WithPayload(models.Error(err.Error()))
+	}
+	logger.Sugar().Infof("Record SLAAC IP addresses %v", params.SlaacAddresses.Ips)
+
+	return daemonset.NewPutWorkloadendpointSlaacOK()
+}
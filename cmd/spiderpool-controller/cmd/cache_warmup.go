@@ -0,0 +1,63 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// warmupCaches primes the caches of the runtime manager before the webhooks
+// of spiderpool-controller are registered. Right after a restart, the
+// webhook server is started before the informers of the runtime manager
+// sync, so the early webhook requests would read empty caches and fail the
+// validation spuriously.
+func warmupCaches(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(controllerContext.Cfg.CacheWarmupTimeout)*time.Second)
+	defer cancel()
+
+	// Objects read by the webhooks through the cached client. Getting their
+	// informers makes the cache start and sync them.
+	cache := controllerContext.CRDManager.GetCache()
+	for _, obj := range []client.Object{
+		&corev1.Namespace{},
+		&spiderpoolv1.SpiderReservedIP{},
+	} {
+		if _, err := cache.GetInformer(ctx, obj); err != nil {
+			return fmt.Errorf("failed to get informer for %T: %w", obj, err)
+		}
+	}
+
+	if !cache.WaitForCacheSync(ctx) {
+		return fmt.Errorf("timeout to wait for the caches to sync: %w", ctx.Err())
+	}
+
+	// IPPools, Subnets and Endpoints are read from API server unless cache
+	// reads are enabled, make sure that their resources are served before
+	// accepting any requests.
+	lists := []client.ObjectList{
+		&spiderpoolv1.SpiderIPPoolList{},
+		&spiderpoolv1.SpiderEndpointList{},
+	}
+	if controllerContext.Cfg.EnableSpiderSubnet {
+		lists = append(lists, &spiderpoolv1.SpiderSubnetList{})
+	}
+
+	reader := controllerContext.CRDManager.GetAPIReader()
+	for _, list := range lists {
+		if err := reader.List(ctx, list); err != nil {
+			return fmt.Errorf("failed to pre-list %T: %w", list, err)
+		}
+		logger.Sugar().Debugf("Pre-listed %d objects of %T", meta.LenList(list), list)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// fakeCache records the informers got, and fails with the preset error.
+type fakeCache struct {
+	cache.Cache
+
+	informers []client.Object
+	err       error
+	synced    bool
+}
+
+func (f *fakeCache) GetInformer(ctx context.Context, obj client.Object) (cache.Informer, error) {
+	f.informers = append(f.informers, obj)
+	return nil, f.err
+}
+
+func (f *fakeCache) WaitForCacheSync(ctx context.Context) bool {
+	return f.synced
+}
+
+// fakeAPIReader records the lists, and fails the list of the preset type.
+type fakeAPIReader struct {
+	client.Reader
+
+	lists   []client.ObjectList
+	failure client.ObjectList
+}
+
+func (f *fakeAPIReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	f.lists = append(f.lists, list)
+	if f.failure != nil && fmt.Sprintf("%T", f.failure) == fmt.Sprintf("%T", list) {
+		return fmt.Errorf("the server is currently unable to handle the request")
+	}
+	return f.Reader.List(ctx, list, opts...)
+}
+
+// fakeManager serves the fake cache and API reader.
+type fakeManager struct {
+	ctrl.Manager
+
+	cache  *fakeCache
+	reader *fakeAPIReader
+}
+
+func (f *fakeManager) GetCache() cache.Cache {
+	return f.cache
+}
+
+func (f *fakeManager) GetAPIReader() client.Reader {
+	return f.reader
+}
+
+var _ = Describe("Cache warmup", Label("cache_warmup_test"), func() {
+	var manager *fakeManager
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(spiderpoolv1.AddToScheme(scheme)).To(Succeed())
+
+		manager = &fakeManager{
+			cache:  &fakeCache{synced: true},
+			reader: &fakeAPIReader{Reader: fake.NewClientBuilder().WithScheme(scheme).Build()},
+		}
+
+		origCfg := controllerContext.Cfg
+		origManager := controllerContext.CRDManager
+		controllerContext.Cfg.CacheWarmupTimeout = 10
+		controllerContext.Cfg.EnableSpiderSubnet = false
+		controllerContext.CRDManager = manager
+		DeferCleanup(func() {
+			controllerContext.Cfg = origCfg
+			controllerContext.CRDManager = origManager
+		})
+	})
+
+	It("syncs the caches and pre-lists the resources read from API server", func() {
+		Expect(warmupCaches(context.TODO())).To(Succeed())
+		Expect(manager.cache.informers).To(HaveLen(2))
+		Expect(manager.reader.lists).To(HaveLen(2))
+		Expect(manager.reader.lists[0]).To(BeAssignableToTypeOf(&spiderpoolv1.SpiderIPPoolList{}))
+		Expect(manager.reader.lists[1]).To(BeAssignableToTypeOf(&spiderpoolv1.SpiderEndpointList{}))
+	})
+
+	It("pre-lists the SpiderSubnets if the SpiderSubnet feature is enabled", func() {
+		controllerContext.Cfg.EnableSpiderSubnet = true
+
+		Expect(warmupCaches(context.TODO())).To(Succeed())
+		Expect(manager.reader.lists).To(HaveLen(3))
+		Expect(manager.reader.lists[2]).To(BeAssignableToTypeOf(&spiderpoolv1.SpiderSubnetList{}))
+	})
+
+	It("fails to get the informers", func() {
+		manager.cache.err = fmt.Errorf("no matches for kind")
+
+		err := warmupCaches(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring("failed to get informer")))
+		Expect(manager.reader.lists).To(BeEmpty())
+	})
+
+	It("fails if the caches are not synced in time", func() {
+		manager.cache.synced = false
+
+		err := warmupCaches(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring("timeout to wait for the caches to sync")))
+		Expect(manager.reader.lists).To(BeEmpty())
+	})
+
+	It("fails to pre-list the resources", func() {
+		manager.reader.failure = &spiderpoolv1.SpiderEndpointList{}
+
+		err := warmupCaches(context.TODO())
+		Expect(err).To(MatchError(ContainSubstring("SpiderEndpointList")))
+	})
+})
@@ -0,0 +1,188 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/go-openapi/runtime/middleware"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	subnetmanagercontrollers "github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+	"github.com/spidernet-io/spiderpool/pkg/tenantmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	capacityStateFree = "free"
+	capacityStateUsed = "used"
+)
+
+// Singleton
+var httpGetControllerCapacity = &_httpGetControllerCapacity{controllerContext}
+
+type _httpGetControllerCapacity struct {
+	*ControllerContext
+}
+
+// Handle handles GET requests for the free or used IPs of SpiderSubnet or
+// SpiderIPPool. The IPs are summarized into CIDRs, and the continue token
+// of a page is the first IP of the next page, so that the pagination keeps
+// stable while the IPs are allocated or released.
+func (g *_httpGetControllerCapacity) Handle(params controller.GetIpamCapacityParams) middleware.Responder {
+	if err := authorizeAPIRequest(params.HTTPRequest, params.Kind, params.Name); err != nil {
+		return capacityAuthorizeFailure(err)
+	}
+
+	ctx := params.HTTPRequest.Context()
+	client := g.CRDManager.GetClient()
+
+	var ipVersion types.IPVersion
+	var totalIPs, freeIPs, usedIPs []net.IP
+	switch params.Kind {
+	case constant.SpiderSubnetKind:
+		if !g.Cfg.EnableSpiderSubnet {
+			return controller.NewGetIpamCapacityBadRequest().WithPayload("feature SpiderSubnet is disabled")
+		}
+
+		var subnet spiderpoolv1.SpiderSubnet
+		if err := client.Get(ctx, apitypes.NamespacedName{Name: params.Name}, &subnet); err != nil {
+			return capacityGetFailure(params.Kind, params.Name, err)
+		}
+		if params.Tenant != nil && !tenantmanager.IsSubnetInTenant(&subnet, *params.Tenant) {
+			return controller.NewGetIpamCapacityNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s not found in %s %s", params.Kind, params.Name, constant.SpiderTenantKind, *params.Tenant)))
+		}
+
+		var err error
+		ipVersion = *subnet.Spec.IPVersion
+		totalIPs, err = spiderpoolip.AssembleTotalIPs(ipVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+		if err != nil {
+			return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+		}
+		freeIPs, err = subnetmanagercontrollers.GenSubnetFreeIPs(&subnet)
+		if err != nil {
+			return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+		}
+		usedIPs = spiderpoolip.IPsDiffSet(totalIPs, freeIPs, true)
+
+	case constant.SpiderIPPoolKind:
+		var pool spiderpoolv1.SpiderIPPool
+		if err := client.Get(ctx, apitypes.NamespacedName{Name: params.Name}, &pool); err != nil {
+			return capacityGetFailure(params.Kind, params.Name, err)
+		}
+		if params.Tenant != nil {
+			ok, err := tenantmanager.IsIPPoolInTenant(ctx, client, &pool, *params.Tenant)
+			if err != nil {
+				return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+			}
+			if !ok {
+				return controller.NewGetIpamCapacityNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s not found in %s %s", params.Kind, params.Name, constant.SpiderTenantKind, *params.Tenant)))
+			}
+		}
+
+		if err := ippoolmanager.AssembleIPPoolShards(ctx, client, nil, &pool); err != nil {
+			return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+		}
+
+		var err error
+		ipVersion = *pool.Spec.IPVersion
+		totalIPs, err = spiderpoolip.AssembleTotalIPs(ipVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+		if err != nil {
+			return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+		}
+		for ip := range pool.Status.AllocatedIPs {
+			usedIPs = append(usedIPs, net.ParseIP(ip))
+		}
+		freeIPs = spiderpoolip.IPsDiffSet(totalIPs, usedIPs, true)
+
+	default:
+		return controller.NewGetIpamCapacityBadRequest().WithPayload(models.Error(fmt.Sprintf("unsupported kind '%s'", params.Kind)))
+	}
+
+	ips := freeIPs
+	if *params.State == capacityStateUsed {
+		ips = usedIPs
+	}
+	cidrs, err := spiderpoolip.ConvertIPsToCIDRs(ipVersion, ips)
+	if err != nil {
+		return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	var continueToken string
+	if params.Continue != nil {
+		continueToken = *params.Continue
+	}
+	page, next, err := paginateCIDRs(cidrs, int(*params.Limit), continueToken)
+	if err != nil {
+		return controller.NewGetIpamCapacityBadRequest().WithPayload(models.Error(err.Error()))
+	}
+
+	return controller.NewGetIpamCapacityOK().WithPayload(&models.Capacity{
+		Kind:         params.Kind,
+		Name:         params.Name,
+		IPVersion:    ipVersion,
+		TotalIPCount: int64(len(totalIPs)),
+		FreeIPCount:  int64(len(freeIPs)),
+		UsedIPCount:  int64(len(usedIPs)),
+		State:        *params.State,
+		Cidrs:        page,
+		Continue:     next,
+	})
+}
+
+func capacityGetFailure(kind, name string, err error) middleware.Responder {
+	if apierrors.IsNotFound(err) {
+		return controller.NewGetIpamCapacityNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s not found", kind, name)))
+	}
+
+	return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+}
+
+func capacityAuthorizeFailure(err error) middleware.Responder {
+	switch {
+	case errors.Is(err, constant.ErrUnauthorized):
+		return controller.NewGetIpamCapacityUnauthorized().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrForbidden):
+		return controller.NewGetIpamCapacityForbidden().WithPayload(models.Error(err.Error()))
+	default:
+		return controller.NewGetIpamCapacityFailure().WithPayload(models.Error(err.Error()))
+	}
+}
+
+// paginateCIDRs returns at most limit sorted CIDRs starting from the one
+// which contains or follows the IP of the continue token, and the token of
+// the next page which is empty for the last page.
+func paginateCIDRs(cidrs []string, limit int, continueToken string) ([]string, string, error) {
+	start := 0
+	if continueToken != "" {
+		from := net.ParseIP(continueToken)
+		if from == nil {
+			return nil, "", fmt.Errorf("invalid continue token '%s'", continueToken)
+		}
+
+		for ; start < len(cidrs); start++ {
+			_, ipNet, _ := net.ParseCIDR(cidrs[start])
+			if ipNet.Contains(from) || spiderpoolip.Cmp(ipNet.IP, from) > 0 {
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end >= len(cidrs) {
+		return cidrs[start:], "", nil
+	}
+
+	next, _, _ := net.ParseCIDR(cidrs[end])
+	return cidrs[start:end], next.String(), nil
+}
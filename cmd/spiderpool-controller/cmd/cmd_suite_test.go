@@ -0,0 +1,41 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+)
+
+// The daemon command parses the environment of its Pod on init, which runs
+// after the package-level variables are initialized.
+var _ = func() error {
+	if err := os.Setenv("SPIDERPOOL_POD_NAMESPACE", "kube-system"); err != nil {
+		return err
+	}
+	return os.Setenv("SPIDERPOOL_POD_NAME", "spiderpool-controller")
+}()
+
+func TestCmd(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cmd Suite")
+}
+
+// fakeAPIAuthorizer records the attributes of the requests, and denies the
+// resources with the preset errors.
+type fakeAPIAuthorizer struct {
+	attrs []apiauthorizer.ResourceAttributes
+	errs  map[string]error
+}
+
+func (f *fakeAPIAuthorizer) Authorize(req *http.Request, attrs apiauthorizer.ResourceAttributes) error {
+	f.attrs = append(f.attrs, attrs)
+	return f.errs[attrs.Resource]
+}
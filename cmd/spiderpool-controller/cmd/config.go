@@ -15,19 +15,29 @@ import (
 	"go.uber.org/atomic"
 	"gopkg.in/yaml.v2"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/spidernet-io/spiderpool/api/v1/controller/server"
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
+	"github.com/spidernet-io/spiderpool/pkg/ipam"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
 	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/utils/label"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
@@ -54,15 +64,43 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_GOPS_LISTEN_PORT", "5724", false, &controllerContext.Cfg.GopsListenPort, nil, nil},
 	{"SPIDERPOOL_PYROSCOPE_PUSH_SERVER_ADDRESS", "", false, &controllerContext.Cfg.PyroscopeAddress, nil, nil},
 	{"SPIDERPOOL_WORKLOADENDPOINT_MAX_HISTORY_RECORDS", "100", false, nil, nil, &controllerContext.Cfg.WorkloadEndpointMaxHistoryRecords},
+	{"SPIDERPOOL_ENDPOINT_TTL", "", false, &controllerContext.Cfg.EndpointTTL, nil, nil},
+	{"SPIDERPOOL_ENDPOINT_TTL_RESYNC_PERIOD", "600", false, nil, nil, &controllerContext.Cfg.EndpointTTLResyncPeriod},
+	{"SPIDERPOOL_RESERVEDIP_EXPIRY_RESYNC_PERIOD", "60", false, nil, nil, &controllerContext.Cfg.ReservedIPExpiryResyncPeriod},
+	{"SPIDERPOOL_RESERVEDIP_EXPIRY_WARNING_PERIOD", "3600", false, nil, nil, &controllerContext.Cfg.ReservedIPExpiryWarningPeriod},
 	{"SPIDERPOOL_IPPOOL_MAX_ALLOCATED_IPS", "5000", false, nil, nil, &controllerContext.Cfg.IPPoolMaxAllocatedIPs},
+	{"SPIDERPOOL_IPPOOL_TOP_CONSUMER_METRIC_COUNT", "5", false, nil, nil, &controllerContext.Cfg.IPPoolTopConsumerMetricCount},
+	{"SPIDERPOOL_IPPOOL_EXHAUSTION_FORECAST_WINDOW", "3600", false, nil, nil, &controllerContext.Cfg.IPPoolExhaustionForecastWindow},
+	{"SPIDERPOOL_IPPOOL_EXHAUSTION_ETA_THRESHOLD", "0", false, nil, nil, &controllerContext.Cfg.IPPoolExhaustionETAThreshold},
+	{"SPIDERPOOL_IPPOOL_POLICY_PROJECTION_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableIPPoolPolicyProjection, nil},
+	{"SPIDERPOOL_IPPOOL_EXHAUSTION_ADMISSION_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableIPPoolExhaustionAdmission, nil},
+	{"SPIDERPOOL_POD_ANNOTATION_ADMISSION_ENABLED", "false", false, nil, &controllerContext.Cfg.EnablePodAnnotationAdmission, nil},
+	{"SPIDERPOOL_POD_NODE_AFFINITY_ADMISSION_ENABLED", "false", false, nil, &controllerContext.Cfg.EnablePodNodeAffinityAdmission, nil},
+	{"SPIDERPOOL_POD_IP_ENV_ADMISSION_ENABLED", "false", false, nil, &controllerContext.Cfg.EnablePodIPEnvAdmission, nil},
+	{"SPIDERPOOL_NETWORK_ATTACHMENT_ADMISSION_WARNING_ONLY", "false", false, nil, &controllerContext.Cfg.NetworkAttachmentAdmissionWarningOnly, nil},
+	{"SPIDERPOOL_WEBHOOK_REPORT_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableWebhookReport, nil},
+	{"SPIDERPOOL_WEBHOOK_REPORT_FLUSH_PERIOD", "30", false, nil, nil, &controllerContext.Cfg.WebhookReportFlushPeriod},
+	{"SPIDERPOOL_STORAGE_VERSION_MIGRATION_ENABLED", "true", false, nil, &controllerContext.Cfg.EnableStorageVersionMigration, nil},
 	{"SPIDERPOOL_SUBNET_RESYNC_PERIOD", "300", false, nil, nil, &controllerContext.Cfg.SubnetResyncPeriod},
 	{"SPIDERPOOL_SUBNET_APPLICATION_CONTROLLER_WORKERS", "5", true, nil, nil, &controllerContext.Cfg.SubnetAppControllerWorkers},
 	{"SPIDERPOOL_SUBNET_INFORMER_WORKERS", "3", true, nil, nil, &controllerContext.Cfg.SubnetInformerWorkers},
 	{"SPIDERPOOL_SUBNET_INFORMER_MAX_WORKQUEUE_LENGTH", "10000", false, nil, nil, &controllerContext.Cfg.SubnetInformerMaxWorkqueueLength},
+	{"SPIDERPOOL_SUBNET_APP_RECONCILE_INTERVAL", "5", false, nil, nil, &controllerContext.Cfg.SubnetAppReconcileInterval},
+	{"SPIDERPOOL_SUBNET_MISSING_FALLBACK_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableSubnetMissingFallback, nil},
+	{"SPIDERPOOL_SUBNET_HPA_SCALE_EVENTS_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableSubnetHPAScaleEvents, nil},
+	{"SPIDERPOOL_AUTO_POOL_EXPANSION_THRESHOLD", "0", false, nil, nil, &controllerContext.Cfg.AutoPoolExpansionThreshold},
+	{"SPIDERPOOL_AUTO_POOL_EXPANSION_STEP", "5", false, nil, nil, &controllerContext.Cfg.AutoPoolExpansionStep},
+	{"SPIDERPOOL_AUTO_POOL_EXPANSION_MAX_IPS", "50", false, nil, nil, &controllerContext.Cfg.AutoPoolExpansionMaxIPs},
+	{"SPIDERPOOL_AUTO_POOL_PRUNE_TTL", "0", false, nil, nil, &controllerContext.Cfg.AutoPoolPruneTTL},
+	{"SPIDERPOOL_AUTO_POOL_FRAGMENTATION_RANGE_THRESHOLD", "0", false, nil, nil, &controllerContext.Cfg.AutoPoolFragmentationRangeThreshold},
 	{"SPIDERPOOL_UPDATE_CR_MAX_RETRIES", "4", false, nil, nil, &controllerContext.Cfg.UpdateCRMaxRetries},
 	{"SPIDERPOOL_UPDATE_CR_RETRY_UNIT_TIME", "50", false, nil, nil, &controllerContext.Cfg.UpdateCRRetryUnitTime},
 	{"SPIDERPOOL_GC_IP_ENABLED", "true", true, nil, &gcIPConfig.EnableGCIP, nil},
 	{"SPIDERPOOL_GC_TERMINATING_POD_IP_ENABLED", "true", true, nil, &gcIPConfig.EnableGCForTerminatingPod, nil},
+	{"SPIDERPOOL_GC_EVICTED_POD_IP_ENABLED", "true", true, nil, &gcIPConfig.EnableGCForEvictedPod, nil},
+	{"SPIDERPOOL_GC_KUBELET_CROSS_CHECK_ENABLED", "false", false, nil, &gcIPConfig.EnableKubeletCrossCheck, nil},
+	{"SPIDERPOOL_GC_KUBELET_CROSS_CHECK_FAILURE_TIMEOUT", "600", false, nil, nil, &gcIPConfig.KubeletCrossCheckFailureTimeout},
+	{"SPIDERPOOL_AGENT_HTTP_PORT", "5710", false, nil, nil, &gcIPConfig.AgentHTTPPort},
 	{"SPIDERPOOL_GC_IP_WORKER_NUM", "3", true, nil, nil, &gcIPConfig.ReleaseIPWorkerNum},
 	{"SPIDERPOOL_GC_CHANNEL_BUFFER", "5000", true, nil, nil, &gcIPConfig.GCIPChannelBuffer},
 	{"SPIDERPOOL_GC_MAX_PODENTRY_DB_CAP", "100000", true, nil, nil, &gcIPConfig.MaxPodEntryDatabaseCap},
@@ -85,6 +123,16 @@ var envInfo = []envConf{
 	{"SPIDERPOOL_WORKQUEUE_RETRY_DELAY_DURATION", "5", true, nil, nil, &controllerContext.Cfg.WorkQueueRequeueDelayDuration},
 	{"SPIDERPOOL_IPPOOL_INFORMER_WORKERS", "3", true, nil, nil, &controllerContext.Cfg.IPPoolInformerWorkers},
 	{"SPIDERPOOL_WORKQUEUE_MAX_RETRIES", "500", true, nil, nil, &controllerContext.Cfg.WorkQueueMaxRetries},
+	{"SPIDERPOOL_NETWORK_TEST_PROBE_IMAGE", "", false, &controllerContext.Cfg.NetworkTestProbeImage, nil, nil},
+	{"SPIDERPOOL_CACHE_WARMUP_TIMEOUT", "60", true, nil, nil, &controllerContext.Cfg.CacheWarmupTimeout},
+	{"SPIDERPOOL_LIST_PAGE_SIZE", "500", false, nil, nil, &controllerContext.Cfg.ListPageSize},
+	{"SPIDERPOOL_LIST_PAGE_INTERVAL", "0", false, nil, nil, &controllerContext.Cfg.ListPageInterval},
+	{"SPIDERPOOL_CACHE_READS_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableCacheReads, nil},
+	{"SPIDERPOOL_POD_CACHE_LABEL_SELECTOR", "", false, &controllerContext.Cfg.PodCacheLabelSelector, nil, nil},
+	{"SPIDERPOOL_POD_CACHE_FIELD_SELECTOR", "", false, &controllerContext.Cfg.PodCacheFieldSelector, nil, nil},
+	{"SPIDERPOOL_IPPOOL_STATUS_SHARD_SIZE", "0", false, nil, nil, &controllerContext.Cfg.IPPoolStatusShardSize},
+	{"SPIDERPOOL_FREEZE_RESYNC_PERIOD_IN_SECOND", "10", false, nil, nil, &controllerContext.Cfg.FreezeResyncPeriod},
+	{"SPIDERPOOL_API_AUTHORIZATION_ENABLED", "false", false, nil, &controllerContext.Cfg.EnableAPIAuthorization, nil},
 }
 
 type Config struct {
@@ -96,9 +144,19 @@ type Config struct {
 	ControllerPodName      string
 
 	// flags
-	ConfigPath        string
-	TlsServerCertPath string
-	TlsServerKeyPath  string
+	ConfigPath               string
+	TlsServerCertPath        string
+	TlsServerKeyPath         string
+	WebhookConfigurationName string
+	WebhookNamespaceSelector string
+	WebhookObjectSelector    string
+	WebhookURL               string
+	WebhookCABundlePath      string
+	WebhookServiceName       string
+	Kubeconfig               string
+	InstallCRDs              bool
+	InstallWebhookConfigs    bool
+	LiteMode                 bool
 
 	// env
 	LogLevel      string
@@ -114,19 +172,63 @@ type Config struct {
 	UpdateCRMaxRetries                int
 	UpdateCRRetryUnitTime             int
 	WorkloadEndpointMaxHistoryRecords int
+	EndpointTTL                       string
+	EndpointTTLResyncPeriod           int
+	ReservedIPExpiryResyncPeriod      int
+	ReservedIPExpiryWarningPeriod     int
 	IPPoolMaxAllocatedIPs             int
-
-	SubnetResyncPeriod               int
-	SubnetAppControllerWorkers       int
-	SubnetInformerWorkers            int
-	SubnetInformerMaxWorkqueueLength int
-	WorkQueueMaxRetries              int
+	IPPoolTopConsumerMetricCount      int
+	IPPoolExhaustionForecastWindow    int
+	IPPoolExhaustionETAThreshold      int
+
+	EnableIPPoolExhaustionAdmission bool
+	EnablePodAnnotationAdmission    bool
+	EnablePodNodeAffinityAdmission  bool
+	EnablePodIPEnvAdmission         bool
+	EnableIPPoolPolicyProjection    bool
+
+	NetworkAttachmentAdmissionWarningOnly bool
+
+	EnableWebhookReport      bool
+	WebhookReportFlushPeriod int
+
+	EnableStorageVersionMigration bool
+
+	SubnetResyncPeriod                  int
+	SubnetAppControllerWorkers          int
+	SubnetInformerWorkers               int
+	SubnetInformerMaxWorkqueueLength    int
+	SubnetAppReconcileInterval          int
+	EnableSubnetMissingFallback         bool
+	EnableSubnetHPAScaleEvents          bool
+	AutoPoolExpansionThreshold          int
+	AutoPoolExpansionStep               int
+	AutoPoolExpansionMaxIPs             int
+	AutoPoolPruneTTL                    int
+	AutoPoolFragmentationRangeThreshold int
+	WorkQueueMaxRetries                 int
 	// if IPPoolWorkQueueRequeueDelayDuration is negative number, we would not requeue it
 	WorkQueueRequeueDelayDuration int
 
 	IPPoolInformerWorkers            int
 	IPPoolInformerMaxWorkQueueLength int
 
+	NetworkTestProbeImage string
+
+	CacheWarmupTimeout int
+
+	ListPageSize     int
+	ListPageInterval int
+
+	EnableCacheReads      bool
+	IPPoolStatusShardSize int
+	FreezeResyncPeriod    int
+
+	PodCacheLabelSelector string
+	PodCacheFieldSelector string
+
+	EnableAPIAuthorization bool
+
 	LeaseDuration      int
 	LeaseRenewDeadline int
 	LeaseRetryPeriod   int
@@ -142,6 +244,12 @@ type Config struct {
 	ClusterDefaultIPv4Subnet          []string `yaml:"clusterDefaultIPv4Subnet"`
 	ClusterDefaultIPv6Subnet          []string `yaml:"clusterDefaultIPv6Subnet"`
 	ClusterSubnetDefaultFlexibleIPNum int      `yaml:"clusterSubnetDefaultFlexibleIPNumber"`
+	ClusterSubnetDefaultReclaimIPPool *bool    `yaml:"clusterSubnetDefaultReclaimIPPool"`
+	ApplicationLabelKeys              []string `yaml:"applicationLabelKeys"`
+
+	// MetricHistogramBuckets overrides the bucket boundaries of the histograms
+	// matching the patterns.
+	MetricHistogramBuckets []metric.HistogramBuckets `yaml:"metricHistogramBuckets"`
 
 	GoMaxProcs int
 }
@@ -154,6 +262,9 @@ type ControllerContext struct {
 	InnerCtx    context.Context
 	InnerCancel context.CancelFunc
 
+	// RestConfig is the config of the cluster which spiderpool-controller works for.
+	RestConfig *rest.Config
+
 	// kubernetes Clientset
 	ClientSet *kubernetes.Clientset
 
@@ -168,10 +279,14 @@ type ControllerContext struct {
 	PodManager      podmanager.PodManager
 	GCManager       gcmanager.GCManager
 	StsManager      statefulsetmanager.StatefulSetManager
+	PoolExplainer   ipam.PoolExplainer
+	FreezeMonitor   configmanager.FreezeMonitor
 	Leader          election.SpiderLeaseElector
+	WebhookReporter webhookmanager.WebhookReporter
 
 	// handler
 	HttpServer        *server.Server
+	APIAuthorizer     apiauthorizer.APIAuthorizer
 	MetricsHttpServer *http.Server
 
 	// webhook http client
@@ -179,6 +294,9 @@ type ControllerContext struct {
 
 	// probe
 	IsStartupProbe atomic.Bool
+	// IsCacheWarmedUp is set after the caches are synced and the webhooks
+	// are registered, the controller is not ready until then.
+	IsCacheWarmedUp atomic.Bool
 }
 
 // BindControllerDaemonFlags bind controller cli daemon flags
@@ -186,6 +304,17 @@ func (cc *ControllerContext) BindControllerDaemonFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&cc.Cfg.ConfigPath, "config-path", "/tmp/spiderpool/config-map/conf.yml", "spiderpool-controller configmap file")
 	flags.StringVar(&cc.Cfg.TlsServerCertPath, "tls-server-cert", "", "file path of server cert")
 	flags.StringVar(&cc.Cfg.TlsServerKeyPath, "tls-server-key", "", "file path of server key")
+	flags.StringVar(&cc.Cfg.WebhookConfigurationName, "webhook-configuration-name", constant.SpiderpoolController, "name of the mutating and validating webhook configurations of spiderpool-controller")
+	flags.StringVar(&cc.Cfg.WebhookNamespaceSelector, "webhook-namespace-selector", "", "label selector of namespaces which spiderpool webhooks apply to, e.g. 'kubernetes.io/metadata.name notin (kube-system)'")
+	flags.StringVar(&cc.Cfg.WebhookObjectSelector, "webhook-object-selector", "", "label selector of objects which spiderpool webhooks apply to")
+	flags.StringVar(&cc.Cfg.WebhookURL, "webhook-url", "", "base URL of the webhook server, e.g. 'https://10.6.0.10:5722', which the webhooks are called with instead of the Service reference")
+	flags.StringVar(&cc.Cfg.WebhookCABundlePath, "webhook-ca-bundle", "", "file path of the PEM encoded CA bundle set to the webhook configurations")
+	flags.StringVar(&cc.Cfg.WebhookServiceName, "webhook-service-name", constant.SpiderpoolController, "name of the Service of the webhook server, which the installed webhook configurations refer to")
+	flags.BoolVar(&cc.Cfg.InstallCRDs, "install-crds", false, "install or upgrade the CRDs of Spiderpool with server-side apply at startup")
+	flags.BoolVar(&cc.Cfg.InstallWebhookConfigs, "install-webhook-configs", false, "install or upgrade the webhook configurations of Spiderpool with server-side apply at startup")
+	flags.BoolVar(&cc.Cfg.LiteMode, "lite-mode", false, "run in lite mode for small clusters, which disables SpiderSubnet, caches the Pods not in host network only and slows down the resyncs")
+	flags.StringVar(&cc.Cfg.Kubeconfig, "kubeconfig", "", "file path of the kubeconfig of the cluster which spiderpool-controller works for, only required if out-of-cluster")
+	features.DefaultMutableFeatureGate.AddFlag(flags)
 }
 
 // ParseConfiguration set the env to AgentConfiguration
@@ -244,10 +373,128 @@ func (cc *ControllerContext) LoadConfigmap() error {
 		return fmt.Errorf("failed to read configmap file, error: %v", err)
 	}
 
+	if err := configmanager.ValidateConfigmapKeys(configmapBytes); err != nil {
+		return fmt.Errorf("failed to validate configmap, error: %w", err)
+	}
+
 	err = yaml.Unmarshal(configmapBytes, &cc.Cfg)
 	if nil != err {
 		return fmt.Errorf("failed to parse configmap, error: %v", err)
 	}
 
+	if err := label.ValidateApplicationLabelKeys(cc.Cfg.ApplicationLabelKeys); err != nil {
+		return err
+	}
+
+	if err := metric.ValidateHistogramBuckets(cc.Cfg.MetricHistogramBuckets); err != nil {
+		return fmt.Errorf("failed to validate metricHistogramBuckets, error: %w", err)
+	}
+
+	return nil
+}
+
+// ApplySpiderpoolConfiguration overrides the configuration loaded from
+// ConfigMap and env with the fields specified in SpiderpoolConfiguration.
+func (cc *ControllerContext) ApplySpiderpoolConfiguration(spec spiderpoolv1.SpiderpoolConfigurationSpec) error {
+	if err := features.DefaultMutableFeatureGate.SetFromMap(spec.FeatureGates); err != nil {
+		return err
+	}
+
+	if spec.EnableIPv4 != nil {
+		cc.Cfg.EnableIPv4 = *spec.EnableIPv4
+	}
+	if spec.EnableIPv6 != nil {
+		cc.Cfg.EnableIPv6 = *spec.EnableIPv6
+	}
+	if spec.EnableStatefulSet != nil {
+		cc.Cfg.EnableStatefulSet = *spec.EnableStatefulSet
+	}
+	if spec.EnableSpiderSubnet != nil {
+		cc.Cfg.EnableSpiderSubnet = *spec.EnableSpiderSubnet
+	}
+	if spec.ClusterDefaultIPv4IPPool != nil {
+		cc.Cfg.ClusterDefaultIPv4IPPool = spec.ClusterDefaultIPv4IPPool
+	}
+	if spec.ClusterDefaultIPv6IPPool != nil {
+		cc.Cfg.ClusterDefaultIPv6IPPool = spec.ClusterDefaultIPv6IPPool
+	}
+	if spec.ClusterDefaultIPv4Subnet != nil {
+		cc.Cfg.ClusterDefaultIPv4Subnet = spec.ClusterDefaultIPv4Subnet
+	}
+	if spec.ClusterDefaultIPv6Subnet != nil {
+		cc.Cfg.ClusterDefaultIPv6Subnet = spec.ClusterDefaultIPv6Subnet
+	}
+	if spec.ClusterSubnetDefaultFlexibleIPNumber != nil {
+		cc.Cfg.ClusterSubnetDefaultFlexibleIPNum = int(*spec.ClusterSubnetDefaultFlexibleIPNumber)
+	}
+
+	if spec.GC != nil {
+		if spec.GC.Enabled != nil {
+			gcIPConfig.EnableGCIP = *spec.GC.Enabled
+		}
+		if spec.GC.TerminatingPodEnabled != nil {
+			gcIPConfig.EnableGCForTerminatingPod = *spec.GC.TerminatingPodEnabled
+		}
+		if spec.GC.EvictedPodEnabled != nil {
+			gcIPConfig.EnableGCForEvictedPod = *spec.GC.EvictedPodEnabled
+		}
+		if spec.GC.KubeletCrossCheckEnabled != nil {
+			gcIPConfig.EnableKubeletCrossCheck = *spec.GC.KubeletCrossCheckEnabled
+		}
+		if spec.GC.IntervalSeconds != nil {
+			gcIPConfig.DefaultGCIntervalDuration = int(*spec.GC.IntervalSeconds)
+		}
+		if spec.GC.AdditionalGraceDelaySeconds != nil {
+			gcIPConfig.AdditionalGraceDelay = int(*spec.GC.AdditionalGraceDelaySeconds)
+		}
+	}
+
+	if spec.Retry != nil {
+		if spec.Retry.UpdateCRMaxRetries != nil {
+			cc.Cfg.UpdateCRMaxRetries = int(*spec.Retry.UpdateCRMaxRetries)
+		}
+		if spec.Retry.UpdateCRRetryUnitTimeMilliseconds != nil {
+			cc.Cfg.UpdateCRRetryUnitTime = int(*spec.Retry.UpdateCRRetryUnitTimeMilliseconds)
+		}
+		if spec.Retry.WorkQueueMaxRetries != nil {
+			cc.Cfg.WorkQueueMaxRetries = int(*spec.Retry.WorkQueueMaxRetries)
+		}
+	}
+
 	return nil
 }
+
+// ActiveSpiderpoolConfiguration returns the configuration that is active on
+// spiderpool-controller.
+func (cc *ControllerContext) ActiveSpiderpoolConfiguration() spiderpoolv1.SpiderpoolConfigurationSpec {
+	featureGates := map[string]bool{}
+	for _, gate := range features.ListFeatureGates(features.DefaultFeatureGate) {
+		featureGates[gate.Name] = gate.Enabled
+	}
+
+	return spiderpoolv1.SpiderpoolConfigurationSpec{
+		EnableIPv4:                           pointer.Bool(cc.Cfg.EnableIPv4),
+		EnableIPv6:                           pointer.Bool(cc.Cfg.EnableIPv6),
+		EnableStatefulSet:                    pointer.Bool(cc.Cfg.EnableStatefulSet),
+		EnableSpiderSubnet:                   pointer.Bool(cc.Cfg.EnableSpiderSubnet),
+		ClusterDefaultIPv4IPPool:             cc.Cfg.ClusterDefaultIPv4IPPool,
+		ClusterDefaultIPv6IPPool:             cc.Cfg.ClusterDefaultIPv6IPPool,
+		ClusterDefaultIPv4Subnet:             cc.Cfg.ClusterDefaultIPv4Subnet,
+		ClusterDefaultIPv6Subnet:             cc.Cfg.ClusterDefaultIPv6Subnet,
+		ClusterSubnetDefaultFlexibleIPNumber: pointer.Int64(int64(cc.Cfg.ClusterSubnetDefaultFlexibleIPNum)),
+		GC: &spiderpoolv1.GCConfiguration{
+			Enabled:                     pointer.Bool(gcIPConfig.EnableGCIP),
+			TerminatingPodEnabled:       pointer.Bool(gcIPConfig.EnableGCForTerminatingPod),
+			EvictedPodEnabled:           pointer.Bool(gcIPConfig.EnableGCForEvictedPod),
+			KubeletCrossCheckEnabled:    pointer.Bool(gcIPConfig.EnableKubeletCrossCheck),
+			IntervalSeconds:             pointer.Int64(int64(gcIPConfig.DefaultGCIntervalDuration)),
+			AdditionalGraceDelaySeconds: pointer.Int64(int64(gcIPConfig.AdditionalGraceDelay)),
+		},
+		Retry: &spiderpoolv1.RetryConfiguration{
+			UpdateCRMaxRetries:                pointer.Int64(int64(cc.Cfg.UpdateCRMaxRetries)),
+			UpdateCRRetryUnitTimeMilliseconds: pointer.Int64(int64(cc.Cfg.UpdateCRRetryUnitTime)),
+			WorkQueueMaxRetries:               pointer.Int64(int64(cc.Cfg.WorkQueueMaxRetries)),
+		},
+		FeatureGates: featureGates,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+// writeConfigmap writes the configmap data to a file, and returns the
+// ControllerContext reading it.
+func writeConfigmap(data string) *ControllerContext {
+	path := filepath.Join(GinkgoT().TempDir(), "conf.yml")
+	Expect(os.WriteFile(path, []byte(data), 0o600)).To(Succeed())
+
+	return &ControllerContext{Cfg: Config{ConfigPath: path}}
+}
+
+var _ = Describe("LoadConfigmap", Label("config_test"), func() {
+	It("loads the bucket boundaries of histograms", func() {
+		cc := writeConfigmap(`
+metricHistogramBuckets:
+  - pattern: "ipam_*_histogram"
+    boundaries: [0.005, 0.01, 0.05]
+  - pattern: "*_seconds"
+    boundaries: [1, 10]
+`)
+
+		Expect(cc.LoadConfigmap()).To(Succeed())
+		Expect(cc.Cfg.MetricHistogramBuckets).To(Equal([]metric.HistogramBuckets{
+			{Pattern: "ipam_*_histogram", Boundaries: []float64{0.005, 0.01, 0.05}},
+			{Pattern: "*_seconds", Boundaries: []float64{1, 10}},
+		}))
+	})
+
+	It("loads no bucket boundaries of histograms if not specified", func() {
+		cc := writeConfigmap(`enableIPv4: true`)
+
+		Expect(cc.LoadConfigmap()).To(Succeed())
+		Expect(cc.Cfg.MetricHistogramBuckets).To(BeEmpty())
+	})
+
+	It("fails to load the bucket boundaries not in increasing order", func() {
+		cc := writeConfigmap(`
+metricHistogramBuckets:
+  - pattern: "*_seconds"
+    boundaries: [10, 1]
+`)
+
+		Expect(cc.LoadConfigmap()).To(MatchError("failed to validate metricHistogramBuckets, error: the boundaries [10 1] of histogram buckets '*_seconds' are not in strictly increasing order"))
+	})
+
+	It("fails to load the bucket boundaries without a pattern", func() {
+		cc := writeConfigmap(`
+metricHistogramBuckets:
+  - boundaries: [1, 10]
+`)
+
+		Expect(cc.LoadConfigmap()).To(MatchError(ContainSubstring("the pattern of histogram buckets 0 is not specified")))
+	})
+
+	It("fails to load the malformed bucket boundaries", func() {
+		cc := writeConfigmap(`
+metricHistogramBuckets:
+  - pattern: "*_seconds"
+    boundaries: fast
+`)
+
+		Expect(cc.LoadConfigmap()).To(MatchError(ContainSubstring("failed to parse configmap")))
+	})
+})
@@ -0,0 +1,131 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/runtime/middleware"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/tenantmanager"
+)
+
+// Singleton
+var httpGetControllerConsumers = &_httpGetControllerConsumers{controllerContext}
+
+type _httpGetControllerConsumers struct {
+	*ControllerContext
+}
+
+// Handle handles GET requests for the applications consuming the most IPs of
+// SpiderSubnet or SpiderIPPool. The IPs of SpiderSubnet are aggregated over
+// all of the IPPools in it.
+func (g *_httpGetControllerConsumers) Handle(params controller.GetIpamConsumersParams) middleware.Responder {
+	if err := authorizeAPIRequest(params.HTTPRequest, params.Kind, params.Name); err != nil {
+		return consumersAuthorizeFailure(err)
+	}
+
+	ctx := params.HTTPRequest.Context()
+	c := g.CRDManager.GetClient()
+
+	var pools []*spiderpoolv1.SpiderIPPool
+	switch params.Kind {
+	case constant.SpiderSubnetKind:
+		if !g.Cfg.EnableSpiderSubnet {
+			return controller.NewGetIpamConsumersBadRequest().WithPayload("feature SpiderSubnet is disabled")
+		}
+
+		var subnet spiderpoolv1.SpiderSubnet
+		if err := c.Get(ctx, apitypes.NamespacedName{Name: params.Name}, &subnet); err != nil {
+			return consumersGetFailure(params.Kind, params.Name, err)
+		}
+		if params.Tenant != nil && !tenantmanager.IsSubnetInTenant(&subnet, *params.Tenant) {
+			return controller.NewGetIpamConsumersNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s not found in %s %s", params.Kind, params.Name, constant.SpiderTenantKind, *params.Tenant)))
+		}
+
+		var poolList spiderpoolv1.SpiderIPPoolList
+		if err := c.List(ctx, &poolList, client.MatchingLabels{constant.LabelIPPoolOwnerSpiderSubnet: subnet.Name}); err != nil {
+			return controller.NewGetIpamConsumersFailure().WithPayload(models.Error(err.Error()))
+		}
+		for i := range poolList.Items {
+			pools = append(pools, &poolList.Items[i])
+		}
+
+	case constant.SpiderIPPoolKind:
+		var pool spiderpoolv1.SpiderIPPool
+		if err := c.Get(ctx, apitypes.NamespacedName{Name: params.Name}, &pool); err != nil {
+			return consumersGetFailure(params.Kind, params.Name, err)
+		}
+		if params.Tenant != nil {
+			ok, err := tenantmanager.IsIPPoolInTenant(ctx, c, &pool, *params.Tenant)
+			if err != nil {
+				return controller.NewGetIpamConsumersFailure().WithPayload(models.Error(err.Error()))
+			}
+			if !ok {
+				return controller.NewGetIpamConsumersNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s not found in %s %s", params.Kind, params.Name, constant.SpiderTenantKind, *params.Tenant)))
+			}
+		}
+		pools = append(pools, &pool)
+
+	default:
+		return controller.NewGetIpamConsumersBadRequest().WithPayload(models.Error(fmt.Sprintf("unsupported kind '%s'", params.Kind)))
+	}
+
+	for _, pool := range pools {
+		if err := ippoolmanager.AssembleIPPoolShards(ctx, c, nil, pool); err != nil {
+			return controller.NewGetIpamConsumersFailure().WithPayload(models.Error(err.Error()))
+		}
+	}
+
+	var allocatedIPCount int64
+	for _, pool := range pools {
+		allocatedIPCount += int64(len(pool.Status.AllocatedIPs))
+	}
+
+	consumers := ippoolmanager.TopIPConsumers(int(*params.Top), pools...)
+	payload := &models.Consumers{
+		Kind:             params.Kind,
+		Name:             params.Name,
+		AllocatedIPCount: allocatedIPCount,
+		Consumers:        make([]*models.Consumer, 0, len(consumers)),
+	}
+	for _, consumer := range consumers {
+		payload.Consumers = append(payload.Consumers, &models.Consumer{
+			Namespace: consumer.Namespace,
+			Kind:      consumer.Kind,
+			Name:      consumer.Name,
+			IPCount:   int64(consumer.IPCount),
+		})
+	}
+
+	return controller.NewGetIpamConsumersOK().WithPayload(payload)
+}
+
+func consumersGetFailure(kind, name string, err error) middleware.Responder {
+	if apierrors.IsNotFound(err) {
+		return controller.NewGetIpamConsumersNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s not found", kind, name)))
+	}
+
+	return controller.NewGetIpamConsumersFailure().WithPayload(models.Error(err.Error()))
+}
+
+func consumersAuthorizeFailure(err error) middleware.Responder {
+	switch {
+	case errors.Is(err, constant.ErrUnauthorized):
+		return controller.NewGetIpamConsumersUnauthorized().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrForbidden):
+		return controller.NewGetIpamConsumersForbidden().WithPayload(models.Error(err.Error()))
+	default:
+		return controller.NewGetIpamConsumersFailure().WithPayload(models.Error(err.Error()))
+	}
+}
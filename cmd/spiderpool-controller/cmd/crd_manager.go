@@ -14,13 +14,20 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+	"github.com/spidernet-io/spiderpool/pkg/utils/cachescope"
 )
 
 var scheme = runtime.NewScheme()
@@ -28,6 +35,8 @@ var scheme = runtime.NewScheme()
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(spiderpoolv1.AddToScheme(scheme))
+	utilruntime.Must(spiderpoolv2beta1.AddToScheme(scheme))
+	utilruntime.Must(netv1.AddToScheme(scheme))
 }
 
 func newCRDManager() (ctrl.Manager, error) {
@@ -36,29 +45,24 @@ func newCRDManager() (ctrl.Manager, error) {
 		return nil, err
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	cacheOptions, err := cacheScope().CacheOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	mgr, err := ctrl.NewManager(controllerContext.RestConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Port:                   port,
 		CertDir:                path.Dir(controllerContext.Cfg.TlsServerCertPath),
 		MetricsBindAddress:     "0",
 		HealthProbeBindAddress: "0",
-		ClientDisableCacheFor: []client.Object{
-			&spiderpoolv1.SpiderSubnet{},
-			&spiderpoolv1.SpiderIPPool{},
-			&spiderpoolv1.SpiderEndpoint{},
-		},
+		ClientDisableCacheFor:  uncachedObjects(),
+		NewCache:               cache.BuilderWithOptions(cacheOptions),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := mgr.GetFieldIndexer().IndexField(controllerContext.InnerCtx, &spiderpoolv1.SpiderReservedIP{}, "spec.ipVersion", func(raw client.Object) []string {
-		reservedIP := raw.(*spiderpoolv1.SpiderReservedIP)
-		return []string{strconv.FormatInt(*reservedIP.Spec.IPVersion, 10)}
-	}); err != nil {
-		return nil, err
-	}
-
 	// register a http handler for webhook health check
 	mgr.GetWebhookServer().Register(webhookMutateRoute, &_webhookHealthCheck{})
 
@@ -115,3 +119,56 @@ func newWebhookHealthCheckClient() *http.Client {
 
 	return httpClient
 }
+
+// newRestConfig loads the config of the cluster which spiderpool-controller
+// works for. It is loaded from the kubeconfig file if specified, so that
+// spiderpool-controller could run out of the cluster, e.g. in a management
+// cluster. Otherwise, the in-cluster config is used.
+func newRestConfig() (*rest.Config, error) {
+	if controllerContext.Cfg.Kubeconfig == "" {
+		return ctrl.GetConfig()
+	}
+
+	config, err := clientcmd.BuildConfigFromFlags("", controllerContext.Cfg.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig %s: %w", controllerContext.Cfg.Kubeconfig, err)
+	}
+
+	return config, nil
+}
+
+// uncachedObjects returns the objects always read from API server by the
+// client of the runtime manager. IPPools and Subnets are read from the cache
+// only if cache reads are enabled, and the managers still read them from API
+// server on the paths that need the latest version.
+func uncachedObjects() []client.Object {
+	if controllerContext.Cfg.EnableCacheReads {
+		return []client.Object{&spiderpoolv1.SpiderEndpoint{}}
+	}
+
+	return []client.Object{
+		&spiderpoolv1.SpiderSubnet{},
+		&spiderpoolv1.SpiderIPPool{},
+		&spiderpoolv1.SpiderEndpoint{},
+	}
+}
+
+// cacheScope returns the scope of the Pods cached by the runtime manager and
+// watched by the IP garbage collection.
+func cacheScope() cachescope.Config {
+	return cachescope.Config{
+		PodLabelSelector: controllerContext.Cfg.PodCacheLabelSelector,
+		PodFieldSelector: controllerContext.Cfg.PodCacheFieldSelector,
+	}
+}
+
+// newConfigManager uses a client without cache, because it is used to read
+// SpiderpoolConfiguration before the runtime manager is initialized.
+func newConfigManager() (configmanager.ConfigManager, error) {
+	c, err := client.New(controllerContext.RestConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return configmanager.NewConfigManager(c)
+}
@@ -10,28 +10,44 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/google/gops/agent"
 	"github.com/pyroscope-io/client/pyroscope"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	ctrl "sigs.k8s.io/controller-runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/chaos"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/coordinatormanager"
+	"github.com/spidernet-io/spiderpool/pkg/crdmanager"
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
+	"github.com/spidernet-io/spiderpool/pkg/ipam"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	crdclientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
+	"github.com/spidernet-io/spiderpool/pkg/networktestmanager"
 	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/singletons"
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/tenantmanager"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
@@ -69,7 +85,45 @@ func DaemonMain() {
 	if err := controllerContext.LoadConfigmap(); err != nil {
 		logger.Sugar().Fatal("failed to load Configmap: %v", err)
 	}
+
+	restConfig, err := newRestConfig()
+	if err != nil {
+		logger.Sugar().Fatalf("failed to load the config of cluster: %v", err)
+	}
+	controllerContext.RestConfig = restConfig
+
+	if controllerContext.Cfg.InstallCRDs || controllerContext.Cfg.InstallWebhookConfigs {
+		logger.Info("Begin to install CRDs and webhook configurations")
+		installManifests(context.TODO())
+	}
+
+	logger.Info("Begin to load SpiderpoolConfiguration")
+	configManager, err := newConfigManager()
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	spiderpoolConfig, err := configManager.GetSpiderpoolConfiguration(context.TODO())
+	if err != nil {
+		logger.Sugar().Fatalf("failed to get SpiderpoolConfiguration: %v", err)
+	}
+	if spiderpoolConfig != nil {
+		if err := controllerContext.ApplySpiderpoolConfiguration(spiderpoolConfig.Spec); err != nil {
+			logger.Sugar().Fatalf("failed to apply SpiderpoolConfiguration: %v", err)
+		}
+	}
+	if controllerContext.Cfg.LiteMode {
+		logger.Info("Spiderpool-controller runs in lite mode, SpiderSubnet is disabled")
+		controllerContext.ApplyLiteMode()
+	}
+	if spiderpoolConfig != nil {
+		if err := configManager.UpdateComponentStatus(context.TODO(), BinNameController, spiderpoolConfig.Generation, controllerContext.ActiveSpiderpoolConfiguration()); err != nil {
+			logger.Sugar().Warnf("failed to update the status of SpiderpoolConfiguration: %v", err)
+		}
+	}
 	logger.Sugar().Infof("Spiderpool-controller config: %+v", controllerContext.Cfg)
+	for _, gate := range features.ListFeatureGates(features.DefaultFeatureGate) {
+		logger.Sugar().Infof("Feature gate %s=%t (%s, default=%t)", gate.Name, gate.Enabled, gate.PreRelease, gate.Default)
+	}
 
 	if controllerContext.Cfg.GopsListenPort != "" {
 		address := "127.0.0.1:" + controllerContext.Cfg.GopsListenPort
@@ -119,6 +173,7 @@ func DaemonMain() {
 		controllerContext.Cfg.ClusterDefaultIPv4Subnet,
 		controllerContext.Cfg.ClusterDefaultIPv6Subnet,
 		controllerContext.Cfg.ClusterSubnetDefaultFlexibleIPNum,
+		pointer.BoolDeref(controllerContext.Cfg.ClusterSubnetDefaultReclaimIPPool, true),
 	)
 
 	controllerContext.InnerCtx, controllerContext.InnerCancel = context.WithCancel(context.Background())
@@ -136,6 +191,15 @@ func DaemonMain() {
 	}
 	controllerContext.ClientSet = clientSet
 
+	if controllerContext.Cfg.EnableAPIAuthorization {
+		logger.Debug("Begin to initialize the authorizer of OpenAPI HTTP server")
+		authorizer, err := apiauthorizer.NewAPIAuthorizer(controllerContext.ClientSet)
+		if nil != err {
+			logger.Fatal(err.Error())
+		}
+		controllerContext.APIAuthorizer = authorizer
+	}
+
 	logger.Debug("Begin to initialize K8s event recorder")
 	event.InitEventRecorder(controllerContext.ClientSet, mgr.GetScheme(), constant.Spiderpool)
 
@@ -172,11 +236,38 @@ func DaemonMain() {
 	logger.Info("Set spiderpool-controller Startup probe ready")
 	controllerContext.IsStartupProbe.Store(true)
 
+	logger.Info("Begin to warm up the caches of spiderpool-controller")
+	if err := warmupCaches(controllerContext.InnerCtx); err != nil {
+		logger.Sugar().Fatalf("failed to warm up caches: %v", err)
+	}
+
+	logger.Info("Begin to set up webhooks")
+	initWebhookReporter(controllerContext.InnerCtx)
+	initControllerWebhooks()
+	controllerContext.IsCacheWarmedUp.Store(true)
+
 	// The CRD webhook of Spiderpool must be started before informer, so that
 	// informer can normally request to some CRs in the cluster without being
 	// disturbed by an abnormal webhook.
 	checkWebhookReady()
 
+	logger.Info("Begin to initialize webhook configuration reconciler")
+	initWebhookConfigReconciler(controllerContext.InnerCtx)
+
+	initNetworkTestReconciler(controllerContext.InnerCtx)
+	initCoordinatorReconciler(controllerContext.InnerCtx)
+	if controllerContext.Cfg.EnableStorageVersionMigration {
+		initStorageVersionMigrator(controllerContext.InnerCtx)
+	}
+	if controllerContext.Cfg.EnableSpiderSubnet {
+		initTenantReconciler(controllerContext.InnerCtx)
+	}
+	if controllerContext.Cfg.EndpointTTL != "" {
+		initEndpointTTLReconciler(controllerContext.InnerCtx)
+	}
+	initReservedIPExpiryReconciler(controllerContext.InnerCtx)
+	initUnsupportedNodeReporter(controllerContext.InnerCtx)
+
 	setupInformers()
 
 	sigCh := make(chan os.Signal, 1)
@@ -227,13 +318,43 @@ func initControllerServiceManagers(ctx context.Context) {
 	}
 	controllerContext.NSManager = nsManager
 
+	logger.Debug("Begin to initialize maintenance freeze monitor")
+	freezeConfigManager, err := configmanager.NewConfigManager(controllerContext.CRDManager.GetClient())
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	freezeMonitor, err := configmanager.NewFreezeMonitor(
+		configmanager.FreezeMonitorConfig{
+			Component:    constant.SpiderpoolController,
+			ResyncPeriod: time.Duration(controllerContext.Cfg.FreezeResyncPeriod) * time.Second,
+			RecordEvents: true,
+		},
+		freezeConfigManager,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	freezeMonitor.Start(logutils.IntoContext(ctx, logger.Named("Freeze-Monitor")))
+	controllerContext.FreezeMonitor = freezeMonitor
+
+	// Options shared by the managers of Pods, ReservedIPs, IPPools and Subnets.
+	managerOpts := []manageroption.Option{
+		manageroption.WithCache(controllerContext.CRDManager.GetClient()),
+		manageroption.WithMetrics(metric.ManagerMetrics{}),
+		manageroption.WithRetryPolicy(controllerContext.Cfg.UpdateCRMaxRetries, time.Duration(controllerContext.Cfg.UpdateCRRetryUnitTime)*time.Millisecond),
+		manageroption.WithIndexer(controllerContext.CRDManager.GetFieldIndexer()),
+		manageroption.WithFreeze(freezeMonitor),
+	}
+
 	logger.Debug("Begin to initialize Pod manager")
 	podManager, err := podmanager.NewPodManager(
 		podmanager.PodManagerConfig{
-			MaxConflictRetries:    controllerContext.Cfg.UpdateCRMaxRetries,
-			ConflictRetryUnitTime: time.Duration(controllerContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
+			ListPageSize:     int64(controllerContext.Cfg.ListPageSize),
+			ListPageInterval: time.Duration(controllerContext.Cfg.ListPageInterval) * time.Millisecond,
 		},
 		controllerContext.CRDManager.GetClient(),
+		controllerContext.CRDManager.GetAPIReader(),
+		managerOpts...,
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
@@ -253,6 +374,7 @@ func initControllerServiceManagers(ctx context.Context) {
 			MaxConflictRetries:    controllerContext.Cfg.UpdateCRMaxRetries,
 			ConflictRetryUnitTime: time.Duration(controllerContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
 			MaxHistoryRecords:     &controllerContext.Cfg.WorkloadEndpointMaxHistoryRecords,
+			ApplicationLabelKeys:  controllerContext.Cfg.ApplicationLabelKeys,
 		},
 		controllerContext.CRDManager.GetClient(),
 	)
@@ -262,79 +384,142 @@ func initControllerServiceManagers(ctx context.Context) {
 	controllerContext.EndpointManager = endpointManager
 
 	logger.Debug("Begin to initialize ReservedIP manager")
-	rIPManager, err := reservedipmanager.NewReservedIPManager(controllerContext.CRDManager.GetClient())
+	rIPManager, err := reservedipmanager.NewReservedIPManager(controllerContext.CRDManager.GetClient(), managerOpts...)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
 	controllerContext.RIPManager = rIPManager
 
-	logger.Debug("Begin to set up ReservedIP webhook")
-	if err := (&reservedipmanager.ReservedIPWebhook{
-		Client:     controllerContext.CRDManager.GetClient(),
-		EnableIPv4: controllerContext.Cfg.EnableIPv4,
-		EnableIPv6: controllerContext.Cfg.EnableIPv6,
-	}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
-		logger.Fatal(err.Error())
-	}
-
 	logger.Debug("Begin to initialize IPPool manager")
 	ipPoolManager, err := ippoolmanager.NewIPPoolManager(
 		ippoolmanager.IPPoolManagerConfig{
-			MaxConflictRetries:    controllerContext.Cfg.UpdateCRMaxRetries,
-			ConflictRetryUnitTime: time.Duration(controllerContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
-			MaxAllocatedIPs:       &controllerContext.Cfg.IPPoolMaxAllocatedIPs,
+			MaxAllocatedIPs:  &controllerContext.Cfg.IPPoolMaxAllocatedIPs,
+			ListPageSize:     int64(controllerContext.Cfg.ListPageSize),
+			ListPageInterval: time.Duration(controllerContext.Cfg.ListPageInterval) * time.Millisecond,
+			StatusShardSize:  controllerContext.Cfg.IPPoolStatusShardSize,
 		},
-		controllerContext.CRDManager.GetClient(),
+		chaos.WrapClient(controllerContext.CRDManager.GetClient()),
+		controllerContext.CRDManager.GetAPIReader(),
 		controllerContext.RIPManager,
+		managerOpts...,
 	)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
 	controllerContext.IPPoolManager = ipPoolManager
 
-	logger.Debug("Begin to set up IPPool webhook")
-	if err := (&ippoolmanager.IPPoolWebhook{
-		Client:             controllerContext.CRDManager.GetClient(),
-		Scheme:             controllerContext.CRDManager.GetScheme(),
-		EnableIPv4:         controllerContext.Cfg.EnableIPv4,
-		EnableIPv6:         controllerContext.Cfg.EnableIPv6,
-		EnableSpiderSubnet: controllerContext.Cfg.EnableSpiderSubnet,
-	}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
-		logger.Fatal(err.Error())
-	}
-
 	if controllerContext.Cfg.EnableSpiderSubnet {
 		logger.Debug("Begin to initialize Subnet manager")
 		subnetManager, err := subnetmanager.NewSubnetManager(
 			subnetmanager.SubnetManagerConfig{
-				MaxConflictRetries:    controllerContext.Cfg.UpdateCRMaxRetries,
-				ConflictRetryUnitTime: time.Duration(controllerContext.Cfg.UpdateCRRetryUnitTime) * time.Millisecond,
+				ApplicationLabelKeys: controllerContext.Cfg.ApplicationLabelKeys,
+				ListPageSize:         int64(controllerContext.Cfg.ListPageSize),
+				ListPageInterval:     time.Duration(controllerContext.Cfg.ListPageInterval) * time.Millisecond,
 			},
 			controllerContext.CRDManager.GetClient(),
+			controllerContext.CRDManager.GetAPIReader(),
 			controllerContext.IPPoolManager,
 			controllerContext.CRDManager.GetScheme(),
+			managerOpts...,
 		)
 		if err != nil {
 			logger.Fatal(err.Error())
 		}
 		controllerContext.SubnetManager = subnetManager
+	} else {
+		logger.Info("Feature SpiderSubnet is disabled")
+	}
 
+	logger.Debug("Begin to initialize IPPool selection explainer")
+	poolExplainer, err := ipam.NewPoolExplainer(
+		ipam.IPAMConfig{
+			EnableIPv4:               controllerContext.Cfg.EnableIPv4,
+			EnableIPv6:               controllerContext.Cfg.EnableIPv6,
+			ClusterDefaultIPv4IPPool: controllerContext.Cfg.ClusterDefaultIPv4IPPool,
+			ClusterDefaultIPv6IPPool: controllerContext.Cfg.ClusterDefaultIPv6IPPool,
+			EnableSpiderSubnet:       controllerContext.Cfg.EnableSpiderSubnet,
+			EnableStatefulSet:        controllerContext.Cfg.EnableStatefulSet,
+		},
+		controllerContext.IPPoolManager,
+		controllerContext.NodeManager,
+		controllerContext.NSManager,
+		controllerContext.PodManager,
+		controllerContext.SubnetManager,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	controllerContext.PoolExplainer = poolExplainer
+}
+
+// initControllerWebhooks registers the webhooks of spiderpool-controller, it
+// should be called after the caches are warmed up.
+func initControllerWebhooks() {
+	logger.Debug("Begin to set up ReservedIP webhook")
+	if err := (&reservedipmanager.ReservedIPWebhook{
+		Client:     controllerContext.CRDManager.GetClient(),
+		EnableIPv4: controllerContext.Cfg.EnableIPv4,
+		EnableIPv6: controllerContext.Cfg.EnableIPv6,
+	}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	logger.Debug("Begin to set up IPPool webhook")
+	if err := (&ippoolmanager.IPPoolWebhook{
+		Client:             controllerContext.CRDManager.GetClient(),
+		Scheme:             controllerContext.CRDManager.GetScheme(),
+		EnableIPv4:         controllerContext.Cfg.EnableIPv4,
+		EnableIPv6:         controllerContext.Cfg.EnableIPv6,
+		EnableSpiderSubnet: controllerContext.Cfg.EnableSpiderSubnet,
+
+		NetworkAttachmentWarningOnly: controllerContext.Cfg.NetworkAttachmentAdmissionWarningOnly,
+		Reporter:                     controllerContext.WebhookReporter,
+	}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	if controllerContext.Cfg.EnableIPPoolExhaustionAdmission || controllerContext.Cfg.EnablePodAnnotationAdmission ||
+		controllerContext.Cfg.EnablePodNodeAffinityAdmission || controllerContext.Cfg.EnablePodIPEnvAdmission {
+		logger.Debug("Begin to set up Pod webhook")
+		if err := (&podmanager.PodWebhook{
+			Client:                      controllerContext.CRDManager.GetClient(),
+			EnableAnnotationValidation:  controllerContext.Cfg.EnablePodAnnotationAdmission,
+			EnableExhaustionCheck:       controllerContext.Cfg.EnableIPPoolExhaustionAdmission,
+			EnableNodeAffinityInjection: controllerContext.Cfg.EnablePodNodeAffinityAdmission,
+			EnableIPEnvInjection:        controllerContext.Cfg.EnablePodIPEnvAdmission,
+			EnableIPv4:                  controllerContext.Cfg.EnableIPv4,
+			EnableIPv6:                  controllerContext.Cfg.EnableIPv6,
+			EnableSpiderSubnet:          controllerContext.Cfg.EnableSpiderSubnet,
+			ClusterDefaultIPv4IPPool:    controllerContext.Cfg.ClusterDefaultIPv4IPPool,
+			ClusterDefaultIPv6IPPool:    controllerContext.Cfg.ClusterDefaultIPv6IPPool,
+			ClusterDefaultIPv4Subnet:    controllerContext.Cfg.ClusterDefaultIPv4Subnet,
+			ClusterDefaultIPv6Subnet:    controllerContext.Cfg.ClusterDefaultIPv6Subnet,
+		}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	if controllerContext.Cfg.EnableSpiderSubnet {
 		logger.Debug("Begin to set up Subnet webhook")
 		if err := (&subnetmanager.SubnetWebhook{
 			Client:     controllerContext.CRDManager.GetClient(),
 			EnableIPv4: controllerContext.Cfg.EnableIPv4,
 			EnableIPv6: controllerContext.Cfg.EnableIPv6,
+
+			NetworkAttachmentWarningOnly: controllerContext.Cfg.NetworkAttachmentAdmissionWarningOnly,
+			Reporter:                     controllerContext.WebhookReporter,
 		}).SetupWebhookWithManager(controllerContext.CRDManager); err != nil {
 			logger.Fatal(err.Error())
 		}
-	} else {
-		logger.Info("Feature SpiderSubnet is disabled")
 	}
 }
 
 func initGCManager(ctx context.Context) {
 	// EnableStatefulSet was determined by Configmap.
 	gcIPConfig.EnableStatefulSet = controllerContext.Cfg.EnableStatefulSet
+	gcIPConfig.PodLabelSelector = controllerContext.Cfg.PodCacheLabelSelector
+	gcIPConfig.PodFieldSelector = controllerContext.Cfg.PodCacheFieldSelector
+	gcIPConfig.RESTConfig = controllerContext.RestConfig
 	gcManager, err := gcmanager.NewGCManager(
 		ctx,
 		controllerContext.ClientSet,
@@ -383,7 +568,7 @@ func initSpiderControllerLeaderElect(ctx context.Context) {
 
 // initK8sClientSet will new kubernetes Clientset
 func initK8sClientSet() (*kubernetes.Clientset, error) {
-	clientSet, err := kubernetes.NewForConfig(ctrl.GetConfigOrDie())
+	clientSet, err := kubernetes.NewForConfig(controllerContext.RestConfig)
 	if nil != err {
 		return nil, fmt.Errorf("failed to init K8s clientset: %v", err)
 	}
@@ -395,7 +580,7 @@ func initK8sClientSet() (*kubernetes.Clientset, error) {
 // because these informers count on webhook
 func setupInformers() {
 	// start SpiderIPPool informer
-	crdClient, err := crdclientset.NewForConfig(ctrl.GetConfigOrDie())
+	crdClient, err := crdclientset.NewForConfig(controllerContext.RestConfig)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
@@ -411,9 +596,20 @@ func setupInformers() {
 			MaxWorkqueueLength:            controllerContext.Cfg.IPPoolInformerMaxWorkQueueLength,
 			WorkQueueRequeueDelayDuration: time.Duration(controllerContext.Cfg.WorkQueueRequeueDelayDuration) * time.Second,
 			WorkQueueMaxRetries:           controllerContext.Cfg.WorkQueueMaxRetries,
+			TopConsumerMetricCount:        controllerContext.Cfg.IPPoolTopConsumerMetricCount,
+			ExhaustionForecastWindow:      time.Duration(controllerContext.Cfg.IPPoolExhaustionForecastWindow) * time.Second,
+			ExhaustionETAThreshold:        time.Duration(controllerContext.Cfg.IPPoolExhaustionETAThreshold) * time.Second,
+			AutoExpansionThreshold:        controllerContext.Cfg.AutoPoolExpansionThreshold,
+			AutoExpansionStep:             controllerContext.Cfg.AutoPoolExpansionStep,
+			AutoExpansionMaxIPs:           controllerContext.Cfg.AutoPoolExpansionMaxIPs,
+			EnablePolicyProjection:        controllerContext.Cfg.EnableIPPoolPolicyProjection,
+			AutoPoolPruneTTL:              time.Duration(controllerContext.Cfg.AutoPoolPruneTTL) * time.Second,
+			FragmentationRangeThreshold:   controllerContext.Cfg.AutoPoolFragmentationRangeThreshold,
+			StatusShardSize:               controllerContext.Cfg.IPPoolStatusShardSize,
 		},
 		controllerContext.CRDManager.GetClient(),
 		controllerContext.RIPManager,
+		manageroption.WithFreeze(controllerContext.FreezeMonitor),
 	)
 	err = ipPoolController.SetupInformer(controllerContext.InnerCtx, crdClient, controllerContext.Leader)
 	if nil != err {
@@ -421,18 +617,6 @@ func setupInformers() {
 	}
 
 	if controllerContext.Cfg.EnableSpiderSubnet {
-		logger.Info("Begin to set up Subnet informer")
-		if err := (&subnetmanager.SubnetController{
-			Client:                  controllerContext.CRDManager.GetClient(),
-			Scheme:                  controllerContext.CRDManager.GetScheme(),
-			LeaderRetryElectGap:     time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
-			ResyncPeriod:            time.Duration(controllerContext.Cfg.SubnetResyncPeriod) * time.Second,
-			SubnetControllerWorkers: controllerContext.Cfg.SubnetInformerWorkers,
-			MaxWorkqueueLength:      controllerContext.Cfg.SubnetInformerMaxWorkqueueLength,
-		}).SetupInformer(controllerContext.InnerCtx, crdClient, controllerContext.Leader); err != nil {
-			logger.Fatal(err.Error())
-		}
-
 		logger.Info("Begin to set up auto-created IPPool controller")
 		subnetAppController, err := subnetmanager.NewSubnetAppController(
 			controllerContext.CRDManager.GetClient(),
@@ -444,11 +628,30 @@ func setupInformers() {
 				MaxWorkqueueLength:            controllerContext.Cfg.SubnetInformerMaxWorkqueueLength,
 				WorkQueueRequeueDelayDuration: time.Duration(controllerContext.Cfg.WorkQueueRequeueDelayDuration) * time.Second,
 				LeaderRetryElectGap:           time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
+				AppReconcileInterval:          time.Duration(controllerContext.Cfg.SubnetAppReconcileInterval) * time.Second,
+				EnableSubnetMissingFallback:   controllerContext.Cfg.EnableSubnetMissingFallback,
+				EnableHPAScaleEvents:          controllerContext.Cfg.EnableSubnetHPAScaleEvents,
+				Freeze:                        controllerContext.FreezeMonitor,
+				DynamicClient:                 dynamic.NewForConfigOrDie(controllerContext.RestConfig),
 			})
 		if nil != err {
 			logger.Fatal(err.Error())
 		}
 
+		logger.Info("Begin to set up Subnet informer")
+		if err := (&subnetmanager.SubnetController{
+			Client:                  controllerContext.CRDManager.GetClient(),
+			Scheme:                  controllerContext.CRDManager.GetScheme(),
+			LeaderRetryElectGap:     time.Duration(controllerContext.Cfg.LeaseRetryGap) * time.Second,
+			ResyncPeriod:            time.Duration(controllerContext.Cfg.SubnetResyncPeriod) * time.Second,
+			SubnetControllerWorkers: controllerContext.Cfg.SubnetInformerWorkers,
+			MaxWorkqueueLength:      controllerContext.Cfg.SubnetInformerMaxWorkqueueLength,
+			OnSubnetAvailable:       subnetAppController.EnqueuePendingApps,
+			Freeze:                  controllerContext.FreezeMonitor,
+		}).SetupInformer(controllerContext.InnerCtx, crdClient, controllerContext.Leader); err != nil {
+			logger.Fatal(err.Error())
+		}
+
 		err = subnetAppController.SetupInformer(controllerContext.InnerCtx, controllerContext.ClientSet, controllerContext.Leader)
 		if nil != err {
 			logger.Fatal(err.Error())
@@ -456,6 +659,236 @@ func setupInformers() {
 	}
 }
 
+// initWebhookReporter aggregates the denials of the webhooks into the
+// SpiderWebhookReport if enabled.
+func initWebhookReporter(ctx context.Context) {
+	if !controllerContext.Cfg.EnableWebhookReport {
+		return
+	}
+
+	reporter, err := webhookmanager.NewWebhookReporter(
+		webhookmanager.WebhookReporterConfig{
+			FlushPeriod: time.Duration(controllerContext.Cfg.WebhookReportFlushPeriod) * time.Second,
+		},
+		controllerContext.CRDManager.GetClient(),
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	controllerContext.WebhookReporter = reporter
+	reporter.Start(logutils.IntoContext(ctx, logger.Named("Webhook-Reporter")))
+}
+
+// initWebhookConfigReconciler keeps the selectors of Spiderpool webhooks in
+// sync with the flags of spiderpool-controller. Without any webhook
+// selector, URL or CA bundle specified, the webhook configurations are kept
+// as the static manifests, and only the conversion webhook of the
+// SpiderIPPool CRD is set.
+func initWebhookConfigReconciler(ctx context.Context) {
+	reconciler, err := webhookmanager.NewWebhookConfigReconciler(newWebhookConfigReconcilerConfig(), controllerContext.CRDManager.GetClient())
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reconciler.Start(logutils.IntoContext(ctx, logger.Named("Webhook-Config-Reconciler")))
+}
+
+// newWebhookConfigReconcilerConfig parses the webhook flags of
+// spiderpool-controller.
+func newWebhookConfigReconcilerConfig() webhookmanager.WebhookConfigReconcilerConfig {
+	var namespaceSelector, objectSelector *metav1.LabelSelector
+	var err error
+	if controllerContext.Cfg.WebhookNamespaceSelector != "" {
+		namespaceSelector, err = metav1.ParseToLabelSelector(controllerContext.Cfg.WebhookNamespaceSelector)
+		if err != nil {
+			logger.Sugar().Fatalf("failed to parse webhook namespace selector '%s': %v", controllerContext.Cfg.WebhookNamespaceSelector, err)
+		}
+	}
+	if controllerContext.Cfg.WebhookObjectSelector != "" {
+		objectSelector, err = metav1.ParseToLabelSelector(controllerContext.Cfg.WebhookObjectSelector)
+		if err != nil {
+			logger.Sugar().Fatalf("failed to parse webhook object selector '%s': %v", controllerContext.Cfg.WebhookObjectSelector, err)
+		}
+	}
+
+	var caBundle []byte
+	if controllerContext.Cfg.WebhookCABundlePath != "" {
+		caBundle, err = os.ReadFile(controllerContext.Cfg.WebhookCABundlePath)
+		if err != nil {
+			logger.Sugar().Fatalf("failed to read webhook CA bundle '%s': %v", controllerContext.Cfg.WebhookCABundlePath, err)
+		}
+	}
+
+	port, err := strconv.Atoi(controllerContext.Cfg.WebhookPort)
+	if err != nil {
+		logger.Sugar().Fatalf("failed to parse webhook port '%s': %v", controllerContext.Cfg.WebhookPort, err)
+	}
+
+	return webhookmanager.WebhookConfigReconcilerConfig{
+		WebhookConfigurationName: controllerContext.Cfg.WebhookConfigurationName,
+		NamespaceSelector:        namespaceSelector,
+		ObjectSelector:           objectSelector,
+		URL:                      controllerContext.Cfg.WebhookURL,
+		CABundle:                 caBundle,
+		ServiceName:              controllerContext.Cfg.WebhookServiceName,
+		ServiceNamespace:         controllerContext.Cfg.ControllerPodNamespace,
+		ServicePort:              int32(port),
+		EnablePodWebhook:         controllerContext.Cfg.EnableIPPoolExhaustionAdmission || controllerContext.Cfg.EnablePodAnnotationAdmission,
+		EnablePodMutatingWebhook: controllerContext.Cfg.EnablePodNodeAffinityAdmission || controllerContext.Cfg.EnablePodIPEnvAdmission,
+		Version:                  controllerContext.Cfg.AppVersion,
+	}
+}
+
+// installManifests installs or upgrades the CRDs and webhook configurations
+// of Spiderpool, for the deployments where Helm hooks aren't usable.
+func installManifests(ctx context.Context) {
+	c, err := client.New(controllerContext.RestConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		logger.Sugar().Fatalf("failed to create client to install manifests: %v", err)
+	}
+
+	if controllerContext.Cfg.InstallCRDs {
+		installer, err := crdmanager.NewCRDInstaller(
+			crdmanager.CRDInstallerConfig{
+				Version: controllerContext.Cfg.AppVersion,
+			},
+			c,
+		)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := installer.Install(logutils.IntoContext(ctx, logger.Named("CRD-Installer"))); err != nil {
+			logger.Sugar().Fatalf("failed to install CRDs: %v", err)
+		}
+	}
+
+	if controllerContext.Cfg.InstallWebhookConfigs {
+		reconciler, err := webhookmanager.NewWebhookConfigReconciler(newWebhookConfigReconcilerConfig(), c)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		if err := reconciler.Install(logutils.IntoContext(ctx, logger.Named("Webhook-Config-Installer"))); err != nil {
+			logger.Sugar().Fatalf("failed to install webhook configurations: %v", err)
+		}
+	}
+}
+
+// initStorageVersionMigrator rewrites the objects stored with the old
+// versions of the CRDs of Spiderpool. It must be started after the webhooks
+// are ready, since the objects are rewritten through them.
+func initStorageVersionMigrator(ctx context.Context) {
+	migrator, err := crdmanager.NewStorageVersionMigrator(
+		crdmanager.StorageVersionMigratorConfig{},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	migrator.Start(logutils.IntoContext(ctx, logger.Named("Storage-Version-Migrator")))
+}
+
+// initUnsupportedNodeReporter marks the Windows nodes of the mixed-OS
+// cluster with the condition SpiderpoolUnsupported.
+func initUnsupportedNodeReporter(ctx context.Context) {
+	reporter, err := nodemanager.NewUnsupportedNodeReporter(
+		nodemanager.UnsupportedNodeReporterConfig{},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reporter.Start(logutils.IntoContext(ctx, logger.Named("Unsupported-Node-Reporter")))
+}
+
+// initNetworkTestReconciler runs SpiderNetworkTests to validate the
+// connectivity of IPPools.
+func initNetworkTestReconciler(ctx context.Context) {
+	reconciler, err := networktestmanager.NewNetworkTestReconciler(
+		networktestmanager.NetworkTestReconcilerConfig{
+			ProbeImage: controllerContext.Cfg.NetworkTestProbeImage,
+		},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reconciler.Start(logutils.IntoContext(ctx, logger.Named("Network-Test-Reconciler")))
+}
+
+// initCoordinatorReconciler reconciles the SpiderCoordinator into the
+// configuration of the coordinator plugin.
+func initCoordinatorReconciler(ctx context.Context) {
+	reconciler, err := coordinatormanager.NewCoordinatorReconciler(
+		coordinatormanager.CoordinatorReconcilerConfig{
+			ConfigMapNamespace: controllerContext.Cfg.ControllerPodNamespace,
+		},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.CRDManager.GetAPIReader(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reconciler.Start(logutils.IntoContext(ctx, logger.Named("Coordinator-Reconciler")))
+}
+
+// initTenantReconciler reconciles the IP usage of the SpiderSubnets of each
+// SpiderTenant into its status.
+func initTenantReconciler(ctx context.Context) {
+	reconciler, err := tenantmanager.NewTenantReconciler(
+		tenantmanager.TenantReconcilerConfig{},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reconciler.Start(logutils.IntoContext(ctx, logger.Named("Tenant-Reconciler")))
+}
+
+// initEndpointTTLReconciler deletes the SpiderEndpoints of the terminated
+// Pods once they outlive the TTL of their owner controller kind.
+func initEndpointTTLReconciler(ctx context.Context) {
+	ttls, err := workloadendpointmanager.ParseEndpointTTLs(controllerContext.Cfg.EndpointTTL)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	reconciler, err := workloadendpointmanager.NewEndpointTTLReconciler(
+		workloadendpointmanager.EndpointTTLReconcilerConfig{
+			TTLs:             ttls,
+			ResyncPeriod:     time.Duration(controllerContext.Cfg.EndpointTTLResyncPeriod) * time.Second,
+			ListPageSize:     int64(controllerContext.Cfg.ListPageSize),
+			ListPageInterval: time.Duration(controllerContext.Cfg.ListPageInterval) * time.Millisecond,
+		},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reconciler.Start(logutils.IntoContext(ctx, logger.Named("Endpoint-TTL-Reconciler")))
+}
+
+// initReservedIPExpiryReconciler deletes the SpiderReservedIPs reaching
+// their 'spec.expiresAt'.
+func initReservedIPExpiryReconciler(ctx context.Context) {
+	reconciler, err := reservedipmanager.NewReservedIPExpiryReconciler(
+		reservedipmanager.ReservedIPExpiryReconcilerConfig{
+			ResyncPeriod:  time.Duration(controllerContext.Cfg.ReservedIPExpiryResyncPeriod) * time.Second,
+			WarningPeriod: time.Duration(controllerContext.Cfg.ReservedIPExpiryWarningPeriod) * time.Second,
+		},
+		controllerContext.CRDManager.GetClient(),
+		controllerContext.Leader,
+	)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	reconciler.Start(logutils.IntoContext(ctx, logger.Named("ReservedIP-Expiry-Reconciler")))
+}
+
 func checkWebhookReady() {
 	controllerContext.webhookClient = newWebhookHealthCheckClient()
 
@@ -0,0 +1,96 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/go-openapi/runtime/middleware"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// Singleton
+var (
+	httpPostControllerEgressIP   = &_httpPostControllerEgressIP{controllerContext}
+	httpDeleteControllerEgressIP = &_httpDeleteControllerEgressIP{controllerContext}
+)
+
+type _httpPostControllerEgressIP struct {
+	*ControllerContext
+}
+
+// Handle handles POST requests to reserve an IP address of the IPPool for an
+// egress gateway object. The caller has to be able to update the
+// SpiderIPPool.
+func (g *_httpPostControllerEgressIP) Handle(params controller.PostIpamEgressipParams) middleware.Responder {
+	if err := authorizeAPIRequestWithVerb(params.HTTPRequest, "update", constant.SpiderIPPoolKind, *params.Reservation.Ippool); err != nil {
+		switch {
+		case errors.Is(err, constant.ErrUnauthorized):
+			return controller.NewPostIpamEgressipUnauthorized().WithPayload(models.Error(err.Error()))
+		case errors.Is(err, constant.ErrForbidden):
+			return controller.NewPostIpamEgressipForbidden().WithPayload(models.Error(err.Error()))
+		default:
+			return controller.NewPostIpamEgressipFailure().WithPayload(models.Error(err.Error()))
+		}
+	}
+
+	ip, err := g.IPPoolManager.ReserveEgressIP(params.HTTPRequest.Context(), *params.Reservation.Ippool, egressIPReservationOf(params.Reservation))
+	if err != nil {
+		switch {
+		case apierrors.IsNotFound(err):
+			return controller.NewPostIpamEgressipNotFound().WithPayload(models.Error(err.Error()))
+		case errors.Is(err, constant.ErrWrongInput):
+			return controller.NewPostIpamEgressipBadRequest().WithPayload(models.Error(err.Error()))
+		default:
+			return controller.NewPostIpamEgressipFailure().WithPayload(models.Error(err.Error()))
+		}
+	}
+
+	return controller.NewPostIpamEgressipOK().WithPayload(&models.EgressIPReservationResult{IP: ip})
+}
+
+type _httpDeleteControllerEgressIP struct {
+	*ControllerContext
+}
+
+// Handle handles DELETE requests to release the IP addresses of the IPPool
+// reserved for an egress gateway object. The caller has to be able to
+// update the SpiderIPPool.
+func (g *_httpDeleteControllerEgressIP) Handle(params controller.DeleteIpamEgressipParams) middleware.Responder {
+	if err := authorizeAPIRequestWithVerb(params.HTTPRequest, "update", constant.SpiderIPPoolKind, *params.Reservation.Ippool); err != nil {
+		switch {
+		case errors.Is(err, constant.ErrUnauthorized):
+			return controller.NewDeleteIpamEgressipUnauthorized().WithPayload(models.Error(err.Error()))
+		case errors.Is(err, constant.ErrForbidden):
+			return controller.NewDeleteIpamEgressipForbidden().WithPayload(models.Error(err.Error()))
+		default:
+			return controller.NewDeleteIpamEgressipFailure().WithPayload(models.Error(err.Error()))
+		}
+	}
+
+	err := g.IPPoolManager.ReleaseEgressIP(params.HTTPRequest.Context(), *params.Reservation.Ippool, egressIPReservationOf(params.Reservation))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return controller.NewDeleteIpamEgressipNotFound().WithPayload(models.Error(err.Error()))
+		}
+		return controller.NewDeleteIpamEgressipFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	return controller.NewDeleteIpamEgressipOK()
+}
+
+func egressIPReservationOf(r *models.EgressIPReservation) spiderpoolv1.EgressIPReservation {
+	return spiderpoolv1.EgressIPReservation{
+		APIVersion: *r.APIVersion,
+		Kind:       *r.Kind,
+		Namespace:  r.Namespace,
+		Name:       *r.Name,
+		UID:        r.UID,
+	}
+}
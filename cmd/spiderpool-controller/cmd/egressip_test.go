@@ -0,0 +1,155 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// fakeEgressIPPoolManager records the egress IP reservations, and returns
+// the preset error.
+type fakeEgressIPPoolManager struct {
+	ippoolmanager.IPPoolManager
+
+	poolName    string
+	reservation *spiderpoolv1.EgressIPReservation
+	err         error
+}
+
+func (f *fakeEgressIPPoolManager) ReserveEgressIP(ctx context.Context, poolName string, egress spiderpoolv1.EgressIPReservation) (string, error) {
+	f.poolName, f.reservation = poolName, &egress
+	if f.err != nil {
+		return "", f.err
+	}
+	return "172.18.40.10", nil
+}
+
+func (f *fakeEgressIPPoolManager) ReleaseEgressIP(ctx context.Context, poolName string, egress spiderpoolv1.EgressIPReservation) error {
+	f.poolName, f.reservation = poolName, &egress
+	return f.err
+}
+
+var _ = Describe("EgressIP API", Label("egressip_test"), func() {
+	const body = `{"ippool":"egress-ippool","apiVersion":"egressgateway.spidernet.io/v1beta1","kind":"EgressGateway","name":"egw","uid":"9c1f2f4e"}`
+
+	var ipPoolManager *fakeEgressIPPoolManager
+
+	BeforeEach(func() {
+		origCfg := controllerContext.Cfg
+		origAuthorizer := controllerContext.APIAuthorizer
+		origIPPoolManager := controllerContext.IPPoolManager
+		controllerContext.Cfg.HttpPort = "5720"
+		ipPoolManager = &fakeEgressIPPoolManager{}
+		controllerContext.IPPoolManager = ipPoolManager
+		DeferCleanup(func() {
+			controllerContext.Cfg = origCfg
+			controllerContext.APIAuthorizer = origAuthorizer
+			controllerContext.IPPoolManager = origIPPoolManager
+		})
+	})
+
+	request := func(method string) *httptest.ResponseRecorder {
+		srv, err := newControllerOpenAPIServer()
+		Expect(err).NotTo(HaveOccurred())
+
+		req := httptest.NewRequest(method, "/v1/ipam/egressip", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		srv.GetHandler().ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	DescribeTable("denies the request",
+		func(method string, authorizer apiauthorizer.APIAuthorizer, code int) {
+			controllerContext.APIAuthorizer = authorizer
+
+			rr := request(method)
+			Expect(rr.Code).To(Equal(code))
+			Expect(ipPoolManager.reservation).To(BeNil())
+		},
+		Entry("to reserve if the API authorization is disabled", http.MethodPost, nil, http.StatusForbidden),
+		Entry("to release if the API authorization is disabled", http.MethodDelete, nil, http.StatusForbidden),
+		Entry("to reserve without a valid token", http.MethodPost,
+			&fakeAPIAuthorizer{errs: map[string]error{"spiderippools": constant.ErrUnauthorized}}, http.StatusUnauthorized),
+		Entry("to release of the caller not allowed to update the SpiderIPPool", http.MethodDelete,
+			&fakeAPIAuthorizer{errs: map[string]error{"spiderippools": constant.ErrForbidden}}, http.StatusForbidden),
+	)
+
+	It("reserves the egress IP for the caller allowed to update the SpiderIPPool", func() {
+		authorizer := &fakeAPIAuthorizer{}
+		controllerContext.APIAuthorizer = authorizer
+
+		rr := request(http.MethodPost)
+		Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+
+		var result models.EgressIPReservationResult
+		Expect(json.Unmarshal(rr.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.IP).To(Equal("172.18.40.10"))
+
+		Expect(ipPoolManager.poolName).To(Equal("egress-ippool"))
+		Expect(*ipPoolManager.reservation).To(Equal(spiderpoolv1.EgressIPReservation{
+			APIVersion: "egressgateway.spidernet.io/v1beta1",
+			Kind:       "EgressGateway",
+			Name:       "egw",
+			UID:        "9c1f2f4e",
+		}))
+		Expect(authorizer.attrs).To(ConsistOf(apiauthorizer.ResourceAttributes{
+			Verb:     "update",
+			Group:    constant.SpiderpoolAPIGroup,
+			Version:  constant.SpiderpoolAPIVersionV1,
+			Resource: "spiderippools",
+			Name:     "egress-ippool",
+		}))
+	})
+
+	It("releases the egress IPs for the caller allowed to update the SpiderIPPool", func() {
+		controllerContext.APIAuthorizer = &fakeAPIAuthorizer{}
+
+		rr := request(http.MethodDelete)
+		Expect(rr.Code).To(Equal(http.StatusOK), rr.Body.String())
+		Expect(ipPoolManager.poolName).To(Equal("egress-ippool"))
+		Expect(ipPoolManager.reservation.Name).To(Equal("egw"))
+	})
+
+	DescribeTable("fails to reserve the egress IP",
+		func(err error, code int) {
+			controllerContext.APIAuthorizer = &fakeAPIAuthorizer{}
+			ipPoolManager.err = err
+
+			rr := request(http.MethodPost)
+			Expect(rr.Code).To(Equal(code))
+
+			var message models.Error
+			Expect(json.Unmarshal(rr.Body.Bytes(), &message)).To(Succeed())
+			Expect(string(message)).To(Equal(err.Error()))
+		},
+		Entry("from the IPPool not found", apierrors.NewNotFound(spiderpoolv1.Resource("spiderippools"), "egress-ippool"), http.StatusNotFound),
+		Entry("from the terminating IPPool", fmt.Errorf("%w, terminating IPPool egress-ippool", constant.ErrWrongInput), http.StatusBadRequest),
+		Entry("from the exhausted IPPool", &constant.PoolExhaustedError{Kind: constant.SpiderIPPoolKind, Names: []string{"egress-ippool"}}, http.StatusInternalServerError),
+	)
+
+	It("fails to release the egress IPs from the IPPool not found", func() {
+		controllerContext.APIAuthorizer = &fakeAPIAuthorizer{}
+		ipPoolManager.err = apierrors.NewNotFound(spiderpoolv1.Resource("spiderippools"), "egress-ippool")
+
+		rr := request(http.MethodDelete)
+		Expect(rr.Code).To(Equal(http.StatusNotFound))
+	})
+})
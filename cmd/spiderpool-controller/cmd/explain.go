@@ -0,0 +1,83 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/runtime/middleware"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// Singleton
+var httpGetControllerExplain = &_httpGetControllerExplain{controllerContext}
+
+type _httpGetControllerExplain struct {
+	*ControllerContext
+}
+
+// Handle handles GET requests to explain why each candidate IPPool is
+// accepted or rejected for a NIC of a Pod. The caller has to be able to get
+// the SpiderIPPools, since the explanation reveals any of them, and the Pod,
+// since its annotations and labels are revealed.
+func (g *_httpGetControllerExplain) Handle(params controller.GetIpamExplainParams) middleware.Responder {
+	if err := authorizeAPIRequest(params.HTTPRequest, constant.SpiderIPPoolKind, ""); err != nil {
+		return explainAuthorizeFailure(err)
+	}
+	if err := authorizePodAPIRequest(params.HTTPRequest, params.Namespace, params.Pod); err != nil {
+		return explainAuthorizeFailure(err)
+	}
+
+	ctx := params.HTTPRequest.Context()
+	pod, err := g.PodManager.GetPodByName(ctx, params.Namespace, params.Pod, constant.UseCache)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return controller.NewGetIpamExplainNotFound().WithPayload(models.Error(fmt.Sprintf("%s %s/%s not found", constant.KindPod, params.Namespace, params.Pod)))
+		}
+		return controller.NewGetIpamExplainFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	explanation, err := g.PoolExplainer.Explain(ctx, pod, *params.Nic)
+	if err != nil {
+		if errors.Is(err, constant.ErrWrongInput) || errors.Is(err, constant.ErrNoAvailablePool) {
+			return controller.NewGetIpamExplainBadRequest().WithPayload(models.Error(err.Error()))
+		}
+		return controller.NewGetIpamExplainFailure().WithPayload(models.Error(err.Error()))
+	}
+
+	payload := &models.PoolExplanation{
+		Namespace: params.Namespace,
+		Pod:       params.Pod,
+		Nic:       *params.Nic,
+		Source:    explanation.Source,
+		Decisions: make([]*models.PoolDecision, 0, len(explanation.Decisions)),
+	}
+	for _, d := range explanation.Decisions {
+		payload.Decisions = append(payload.Decisions, &models.PoolDecision{
+			Nic:       d.NIC,
+			IPVersion: d.IPVersion,
+			Ippool:    d.IPPool,
+			Accepted:  d.Accepted,
+			Reason:    d.Reason,
+		})
+	}
+
+	return controller.NewGetIpamExplainOK().WithPayload(payload)
+}
+
+func explainAuthorizeFailure(err error) middleware.Responder {
+	switch {
+	case errors.Is(err, constant.ErrUnauthorized):
+		return controller.NewGetIpamExplainUnauthorized().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrForbidden):
+		return controller.NewGetIpamExplainForbidden().WithPayload(models.Error(err.Error()))
+	default:
+		return controller.NewGetIpamExplainFailure().WithPayload(models.Error(err.Error()))
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+)
+
+// fakePodManager finds no Pod, and counts the lookups.
+type fakePodManager struct {
+	podmanager.PodManager
+
+	gets int
+}
+
+func (f *fakePodManager) GetPodByName(ctx context.Context, namespace, podName string, cached bool) (*corev1.Pod, error) {
+	f.gets++
+	return nil, apierrors.NewNotFound(corev1.Resource("pods"), podName)
+}
+
+var _ = Describe("Explain API", Label("explain_test"), func() {
+	var podManager *fakePodManager
+
+	BeforeEach(func() {
+		origCfg := controllerContext.Cfg
+		origAuthorizer := controllerContext.APIAuthorizer
+		origPodManager := controllerContext.PodManager
+		controllerContext.Cfg.HttpPort = "5720"
+		podManager = &fakePodManager{}
+		controllerContext.PodManager = podManager
+		DeferCleanup(func() {
+			controllerContext.Cfg = origCfg
+			controllerContext.APIAuthorizer = origAuthorizer
+			controllerContext.PodManager = origPodManager
+		})
+	})
+
+	get := func() *httptest.ResponseRecorder {
+		srv, err := newControllerOpenAPIServer()
+		Expect(err).NotTo(HaveOccurred())
+
+		rr := httptest.NewRecorder()
+		srv.GetHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/ipam/explain?namespace=default&pod=demo", nil))
+
+		return rr
+	}
+
+	It("denies the caller not allowed to get the Pod", func() {
+		authorizer := &fakeAPIAuthorizer{errs: map[string]error{"pods": constant.ErrForbidden}}
+		controllerContext.APIAuthorizer = authorizer
+
+		rr := get()
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(podManager.gets).To(BeZero())
+		Expect(authorizer.attrs).To(ContainElement(apiauthorizer.ResourceAttributes{
+			Verb:      "get",
+			Version:   "v1",
+			Resource:  "pods",
+			Namespace: "default",
+			Name:      "demo",
+		}))
+	})
+
+	It("denies the caller not allowed to get the SpiderIPPools", func() {
+		authorizer := &fakeAPIAuthorizer{errs: map[string]error{"spiderippools": constant.ErrForbidden}}
+		controllerContext.APIAuthorizer = authorizer
+
+		rr := get()
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(podManager.gets).To(BeZero())
+	})
+
+	It("denies the request without a valid token", func() {
+		controllerContext.APIAuthorizer = &fakeAPIAuthorizer{errs: map[string]error{"spiderippools": constant.ErrUnauthorized, "pods": constant.ErrUnauthorized}}
+
+		rr := get()
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(podManager.gets).To(BeZero())
+	})
+
+	It("explains the Pod for the caller allowed to get it", func() {
+		authorizer := &fakeAPIAuthorizer{}
+		controllerContext.APIAuthorizer = authorizer
+
+		rr := get()
+		Expect(rr.Code).To(Equal(http.StatusNotFound))
+		Expect(podManager.gets).To(Equal(1))
+		Expect(authorizer.attrs).To(HaveLen(2))
+	})
+})
@@ -0,0 +1,73 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// Singleton
+var httpGetControllerGCCandidates = &_httpGetControllerGCCandidates{controllerContext}
+
+type _httpGetControllerGCCandidates struct {
+	*ControllerContext
+}
+
+// Handle handles GET requests for the Pods traced by the IP GC, sorted by
+// their deadlines. The caller has to be able to get the SpiderIPPools, since
+// the IPs of the Pods are reclaimed from them.
+func (g *_httpGetControllerGCCandidates) Handle(params controller.GetIpamGcCandidatesParams) middleware.Responder {
+	if err := authorizeAPIRequest(params.HTTPRequest, constant.SpiderIPPoolKind, ""); err != nil {
+		return gcCandidatesAuthorizeFailure(err)
+	}
+
+	if !gcIPConfig.EnableGCIP {
+		return controller.NewGetIpamGcCandidatesBadRequest().WithPayload("IP garbage collection is disabled")
+	}
+	if g.GCManager == nil {
+		return controller.NewGetIpamGcCandidatesFailure().WithPayload("IP garbage collection is not started yet")
+	}
+
+	podEntries := g.GCManager.GetPodDatabase().ListAllPodEntries()
+	sort.Slice(podEntries, func(i, j int) bool {
+		return podEntries[i].TracingStopTime.Before(podEntries[j].TracingStopTime)
+	})
+
+	payload := &models.GcCandidates{
+		Elected:    g.Leader != nil && g.Leader.IsElected(),
+		QueueDepth: int64(g.GCManager.QueueDepth()),
+		Candidates: make([]*models.GcCandidate, 0, len(podEntries)),
+	}
+	for _, e := range podEntries {
+		payload.Candidates = append(payload.Candidates, &models.GcCandidate{
+			Namespace:        e.Namespace,
+			Pod:              e.PodName,
+			Node:             e.NodeName,
+			Reason:           string(e.PodTracingReason),
+			TracingStartTime: e.TracingStartTime.Format(time.RFC3339),
+			Deadline:         e.TracingStopTime.Format(time.RFC3339),
+		})
+	}
+
+	return controller.NewGetIpamGcCandidatesOK().WithPayload(payload)
+}
+
+func gcCandidatesAuthorizeFailure(err error) middleware.Responder {
+	switch {
+	case errors.Is(err, constant.ErrUnauthorized):
+		return controller.NewGetIpamGcCandidatesUnauthorized().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrForbidden):
+		return controller.NewGetIpamGcCandidatesForbidden().WithPayload(models.Error(err.Error()))
+	default:
+		return controller.NewGetIpamGcCandidatesFailure().WithPayload(models.Error(err.Error()))
+	}
+}
@@ -4,12 +4,19 @@
 package cmd
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/go-openapi/loads"
+	corev1 "k8s.io/api/core/v1"
+
 	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
 	controllerOpenAPIServer "github.com/spidernet-io/spiderpool/api/v1/controller/server"
 	controllerOpenAPIRestapi "github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi"
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 )
 
 // newControllerOpenAPIServer instantiates a new instance of the controller OpenAPI server on the http.
@@ -32,6 +39,16 @@ func newControllerOpenAPIServer() (*controllerOpenAPIServer.Server, error) {
 	api.RuntimeGetRuntimeStartupHandler = httpGetControllerStartup
 	api.RuntimeGetRuntimeReadinessHandler = httpGetControllerReadiness
 	api.RuntimeGetRuntimeLivenessHandler = httpGetControllerLiveness
+	api.RuntimeGetFeaturezHandler = httpGetControllerFeaturez
+
+	// controller API
+	api.ControllerGetIpamCapacityHandler = httpGetControllerCapacity
+	api.ControllerGetIpamConsumersHandler = httpGetControllerConsumers
+	api.ControllerGetIpamExplainHandler = httpGetControllerExplain
+	api.ControllerGetIpamGcCandidatesHandler = httpGetControllerGCCandidates
+	api.ControllerPostIpamPreprovisionHandler = httpPostControllerPreProvision
+	api.ControllerPostIpamEgressipHandler = httpPostControllerEgressIP
+	api.ControllerDeleteIpamEgressipHandler = httpDeleteControllerEgressIP
 
 	// new controller OpenAPI server with api
 	srv := controllerOpenAPIServer.NewServer(api)
@@ -49,3 +66,58 @@ func newControllerOpenAPIServer() (*controllerOpenAPIServer.Server, error) {
 
 	return srv, nil
 }
+
+// authorizeAPIRequest checks that the caller of the controller API could get
+// the SpiderSubnet or SpiderIPPool with its Kubernetes RBAC, if the
+// authorization is enabled. The unsupported kinds are left to the handlers.
+func authorizeAPIRequest(req *http.Request, kind, name string) error {
+	return authorizeAPIRequestWithVerb(req, "get", kind, name)
+}
+
+// authorizeAPIRequestWithVerb is authorizeAPIRequest with the verb the caller
+// has to be permitted. The requests changing the resources are always denied
+// if the authorization is disabled, since anyone reaching the HTTP port
+// could call them.
+func authorizeAPIRequestWithVerb(req *http.Request, verb, kind, name string) error {
+	if controllerContext.APIAuthorizer == nil {
+		if verb == "get" || verb == "list" {
+			return nil
+		}
+		return fmt.Errorf("%w: the API authorization is disabled, %s is not allowed to %s", constant.ErrForbidden, req.URL.Path, verb)
+	}
+
+	var resource string
+	switch kind {
+	case constant.SpiderSubnetKind:
+		resource = "spidersubnets"
+	case constant.SpiderIPPoolKind:
+		resource = "spiderippools"
+	default:
+		return nil
+	}
+
+	return controllerContext.APIAuthorizer.Authorize(req, apiauthorizer.ResourceAttributes{
+		Verb:     verb,
+		Group:    spiderpoolv1.GroupVersion.Group,
+		Version:  spiderpoolv1.GroupVersion.Version,
+		Resource: resource,
+		Name:     name,
+	})
+}
+
+// authorizePodAPIRequest checks that the caller of the controller API could
+// get the Pod with its Kubernetes RBAC, if the authorization is enabled.
+func authorizePodAPIRequest(req *http.Request, namespace, name string) error {
+	if controllerContext.APIAuthorizer == nil {
+		return nil
+	}
+
+	return controllerContext.APIAuthorizer.Authorize(req, apiauthorizer.ResourceAttributes{
+		Verb:      "get",
+		Group:     corev1.GroupName,
+		Version:   corev1.SchemeGroupVersion.Version,
+		Resource:  "pods",
+		Namespace: namespace,
+		Name:      name,
+	})
+}
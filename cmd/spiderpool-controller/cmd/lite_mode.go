@@ -0,0 +1,47 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/spidernet-io/spiderpool/pkg/utils/cachescope"
+)
+
+// The minimum periods in seconds of the resyncs in lite mode.
+const (
+	liteFreezeResyncPeriod           = 60
+	liteEndpointTTLResyncPeriod      = 1800
+	liteReservedIPExpiryResyncPeriod = 600
+	liteWebhookReportFlushPeriod     = 300
+	liteGCIntervalDuration           = 1800
+)
+
+// litePodFieldSelector scopes the Pods cached in lite mode in addition to the
+// configured one, the hostNetwork Pods never get IP addresses from Spiderpool.
+const litePodFieldSelector = "spec.hostNetwork=false"
+
+// ApplyLiteMode trims the configuration for the small clusters, e.g. K3s on
+// edge nodes, if lite mode is enabled. The SpiderSubnet feature is disabled,
+// the Pods in host network are not cached, and the resyncs are slowed down to
+// their minimum periods in lite mode.
+func (cc *ControllerContext) ApplyLiteMode() {
+	if !cc.Cfg.LiteMode {
+		return
+	}
+
+	cc.Cfg.EnableSpiderSubnet = false
+	cc.Cfg.FreezeResyncPeriod = atLeast(cc.Cfg.FreezeResyncPeriod, liteFreezeResyncPeriod)
+	cc.Cfg.EndpointTTLResyncPeriod = atLeast(cc.Cfg.EndpointTTLResyncPeriod, liteEndpointTTLResyncPeriod)
+	cc.Cfg.ReservedIPExpiryResyncPeriod = atLeast(cc.Cfg.ReservedIPExpiryResyncPeriod, liteReservedIPExpiryResyncPeriod)
+	cc.Cfg.WebhookReportFlushPeriod = atLeast(cc.Cfg.WebhookReportFlushPeriod, liteWebhookReportFlushPeriod)
+	gcIPConfig.DefaultGCIntervalDuration = atLeast(gcIPConfig.DefaultGCIntervalDuration, liteGCIntervalDuration)
+	cc.Cfg.PodCacheFieldSelector = cachescope.JoinSelectors(litePodFieldSelector, cc.Cfg.PodCacheFieldSelector)
+}
+
+func atLeast(value, min int) int {
+	if value < min {
+		return min
+	}
+
+	return value
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/gcmanager"
+)
+
+var _ = Describe("Lite mode", Label("lite_mode_test"), func() {
+	var cc *ControllerContext
+
+	BeforeEach(func() {
+		originalGCIPConfig := *gcIPConfig
+		DeferCleanup(func() {
+			*gcIPConfig = originalGCIPConfig
+		})
+		*gcIPConfig = gcmanager.GarbageCollectionConfig{DefaultGCIntervalDuration: 600}
+
+		cc = &ControllerContext{Cfg: Config{
+			EnableSpiderSubnet:           true,
+			FreezeResyncPeriod:           10,
+			EndpointTTLResyncPeriod:      600,
+			ReservedIPExpiryResyncPeriod: 60,
+			WebhookReportFlushPeriod:     30,
+		}}
+	})
+
+	Describe("ApplyLiteMode", func() {
+		It("keeps the configuration if lite mode is disabled", func() {
+			original := cc.Cfg
+
+			cc.ApplyLiteMode()
+			Expect(cc.Cfg).To(Equal(original))
+			Expect(*gcIPConfig).To(Equal(gcmanager.GarbageCollectionConfig{DefaultGCIntervalDuration: 600}))
+		})
+
+		It("disables SpiderSubnet and slows down the resyncs to their minimum periods", func() {
+			cc.Cfg.LiteMode = true
+
+			cc.ApplyLiteMode()
+			Expect(cc.Cfg.EnableSpiderSubnet).To(BeFalse())
+			Expect(cc.Cfg.FreezeResyncPeriod).To(Equal(liteFreezeResyncPeriod))
+			Expect(cc.Cfg.EndpointTTLResyncPeriod).To(Equal(liteEndpointTTLResyncPeriod))
+			Expect(cc.Cfg.ReservedIPExpiryResyncPeriod).To(Equal(liteReservedIPExpiryResyncPeriod))
+			Expect(cc.Cfg.WebhookReportFlushPeriod).To(Equal(liteWebhookReportFlushPeriod))
+			Expect(gcIPConfig.DefaultGCIntervalDuration).To(Equal(liteGCIntervalDuration))
+			Expect(cc.Cfg.PodCacheFieldSelector).To(Equal("spec.hostNetwork=false"))
+		})
+
+		It("caches the Pods not in host network in addition to the configured scope", func() {
+			cc.Cfg.LiteMode = true
+			cc.Cfg.PodCacheFieldSelector = "metadata.namespace=edge"
+
+			cc.ApplyLiteMode()
+			Expect(cc.Cfg.PodCacheFieldSelector).To(Equal("spec.hostNetwork=false,metadata.namespace=edge"))
+		})
+
+		It("keeps the resync periods longer than the minimum ones", func() {
+			cc.Cfg.LiteMode = true
+			cc.Cfg.FreezeResyncPeriod = 120
+			cc.Cfg.EndpointTTLResyncPeriod = 3600
+			cc.Cfg.ReservedIPExpiryResyncPeriod = 1200
+			cc.Cfg.WebhookReportFlushPeriod = 600
+			gcIPConfig.DefaultGCIntervalDuration = 3600
+
+			cc.ApplyLiteMode()
+			Expect(cc.Cfg.FreezeResyncPeriod).To(Equal(120))
+			Expect(cc.Cfg.EndpointTTLResyncPeriod).To(Equal(3600))
+			Expect(cc.Cfg.ReservedIPExpiryResyncPeriod).To(Equal(1200))
+			Expect(cc.Cfg.WebhookReportFlushPeriod).To(Equal(600))
+			Expect(gcIPConfig.DefaultGCIntervalDuration).To(Equal(3600))
+		})
+	})
+})
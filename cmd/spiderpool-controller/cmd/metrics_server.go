@@ -7,14 +7,22 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/informerstatus"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
 )
 
+// informerMetricsPeriod is the period to record the lag and queue depth of informers.
+const informerMetricsPeriod = 10 * time.Second
+
 // initControllerMetricsServer will start an opentelemetry http server for spiderpool controller.
 func initControllerMetricsServer(ctx context.Context) {
-	metricController, err := metric.InitMetricController(ctx, constant.SpiderpoolController, controllerContext.Cfg.EnabledMetric)
+	metricController, err := metric.InitMetricController(ctx, constant.SpiderpoolController, controllerContext.Cfg.EnabledMetric,
+		metric.WithHistogramBuckets(controllerContext.Cfg.MetricHistogramBuckets...))
 	if nil != err {
 		logger.Fatal(err.Error())
 	}
@@ -41,5 +49,7 @@ func initControllerMetricsServer(ctx context.Context) {
 		}()
 
 		controllerContext.MetricsHttpServer = metricsSrv
+
+		go wait.Until(informerstatus.RecordMetrics, informerMetricsPeriod, ctx.Done())
 	}
 }
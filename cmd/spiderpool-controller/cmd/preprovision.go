@@ -0,0 +1,117 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/runtime/middleware"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/singletons"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// Singleton
+var httpPostControllerPreProvision = &_httpPostControllerPreProvision{controllerContext}
+
+type _httpPostControllerPreProvision struct {
+	*ControllerContext
+}
+
+// Handle handles POST requests to pre-provision the auto-created IPPools of
+// SpiderSubnet for the applications not created yet. Each IPPool is sized
+// for the replicas of its application plus the cluster default flexible IP
+// number. The caller has to be able to create the SpiderIPPools.
+func (g *_httpPostControllerPreProvision) Handle(params controller.PostIpamPreprovisionParams) middleware.Responder {
+	if err := authorizeAPIRequestWithVerb(params.HTTPRequest, "create", constant.SpiderIPPoolKind, ""); err != nil {
+		return preProvisionAuthorizeFailure(err)
+	}
+
+	if !g.Cfg.EnableSpiderSubnet || g.SubnetManager == nil {
+		return controller.NewPostIpamPreprovisionBadRequest().WithPayload("feature SpiderSubnet is disabled")
+	}
+	if params.Request == nil || len(params.Request.Applications) == 0 {
+		return controller.NewPostIpamPreprovisionBadRequest().WithPayload("no application is specified")
+	}
+	for _, app := range params.Request.Applications {
+		if app == nil || app.Kind == nil || app.Namespace == nil || app.Name == nil {
+			return controller.NewPostIpamPreprovisionBadRequest().WithPayload("kind, namespace and name of the applications must be specified")
+		}
+		if app.IPV4Subnet == "" && app.IPV6Subnet == "" {
+			return controller.NewPostIpamPreprovisionBadRequest().WithPayload(models.Error(fmt.Sprintf("no SpiderSubnet is specified for %s %s/%s", *app.Kind, *app.Namespace, *app.Name)))
+		}
+		if app.Replicas < 0 {
+			return controller.NewPostIpamPreprovisionBadRequest().WithPayload(models.Error(fmt.Sprintf("invalid replicas %d of %s %s/%s", app.Replicas, *app.Kind, *app.Namespace, *app.Name)))
+		}
+	}
+
+	ctx := params.HTTPRequest.Context()
+	result := &models.PreProvisionResult{}
+	for _, app := range params.Request.Applications {
+		podController := types.PodTopController{
+			Kind:      *app.Kind,
+			Namespace: *app.Namespace,
+			Name:      *app.Name,
+		}
+		ifName := constant.ClusterDefaultInterfaceName
+		if app.Interface != nil && *app.Interface != "" {
+			ifName = *app.Interface
+		}
+		reclaimIPPool := pointer.BoolDeref(app.ReclaimIPPool, singletons.ClusterDefaultPool.ClusterSubnetDefaultReclaimIPPool)
+		ipNum := int(app.Replicas) + g.Cfg.ClusterSubnetDefaultFlexibleIPNum
+
+		for _, s := range []struct {
+			ipVersion types.IPVersion
+			subnet    string
+		}{
+			{constant.IPv4, app.IPV4Subnet},
+			{constant.IPv6, app.IPV6Subnet},
+		} {
+			if s.subnet == "" {
+				continue
+			}
+
+			item := &models.PreProvisionedPool{
+				Kind:      *app.Kind,
+				Namespace: *app.Namespace,
+				Name:      *app.Name,
+				IPVersion: s.ipVersion,
+				Subnet:    s.subnet,
+				IPCount:   int64(ipNum),
+			}
+			pool, created, err := g.SubnetManager.PreProvisionIPPool(ctx, s.subnet, podController, ipNum, s.ipVersion, reclaimIPPool, ifName)
+			if err != nil {
+				logger.Sugar().Warnf("failed to pre-provision IPv%d IPPool of %s %s/%s from SpiderSubnet %s: %v",
+					s.ipVersion, *app.Kind, *app.Namespace, *app.Name, s.subnet, err)
+				item.Error = err.Error()
+				item.Reason = constant.ErrReasonOf(err)
+			} else {
+				item.Ippool = pool.Name
+				item.Created = created
+				if pool.Status.AutoDesiredIPCount != nil {
+					item.IPCount = *pool.Status.AutoDesiredIPCount
+				}
+			}
+			result.Items = append(result.Items, item)
+		}
+	}
+
+	return controller.NewPostIpamPreprovisionOK().WithPayload(result)
+}
+
+func preProvisionAuthorizeFailure(err error) middleware.Responder {
+	switch {
+	case errors.Is(err, constant.ErrUnauthorized):
+		return controller.NewPostIpamPreprovisionUnauthorized().WithPayload(models.Error(err.Error()))
+	case errors.Is(err, constant.ErrForbidden):
+		return controller.NewPostIpamPreprovisionForbidden().WithPayload(models.Error(err.Error()))
+	default:
+		return controller.NewPostIpamPreprovisionFailure().WithPayload(models.Error(err.Error()))
+	}
+}
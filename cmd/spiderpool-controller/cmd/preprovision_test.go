@@ -0,0 +1,84 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+var _ = Describe("PreProvision API", Label("preprovision_test"), func() {
+	const body = `{"applications":[{"kind":"Deployment","namespace":"default","name":"demo","replicas":10,"ipv4Subnet":"subnet-v4"}]}`
+
+	BeforeEach(func() {
+		origCfg := controllerContext.Cfg
+		origAuthorizer := controllerContext.APIAuthorizer
+		controllerContext.Cfg.HttpPort = "5720"
+		controllerContext.Cfg.EnableSpiderSubnet = false
+		DeferCleanup(func() {
+			controllerContext.Cfg = origCfg
+			controllerContext.APIAuthorizer = origAuthorizer
+		})
+	})
+
+	post := func() *httptest.ResponseRecorder {
+		srv, err := newControllerOpenAPIServer()
+		Expect(err).NotTo(HaveOccurred())
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/ipam/preprovision", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		srv.GetHandler().ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	It("denies the request if the API authorization is disabled", func() {
+		controllerContext.APIAuthorizer = nil
+
+		rr := post()
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(rr.Body.String()).To(ContainSubstring("API authorization is disabled"))
+	})
+
+	It("denies the request without a valid token", func() {
+		authorizer := &fakeAPIAuthorizer{errs: map[string]error{"spiderippools": constant.ErrUnauthorized}}
+		controllerContext.APIAuthorizer = authorizer
+
+		rr := post()
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("denies the caller not allowed to create SpiderIPPools", func() {
+		authorizer := &fakeAPIAuthorizer{errs: map[string]error{"spiderippools": constant.ErrForbidden}}
+		controllerContext.APIAuthorizer = authorizer
+
+		rr := post()
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+		Expect(authorizer.attrs).To(HaveLen(1))
+		Expect(authorizer.attrs[0].Verb).To(Equal("create"))
+		Expect(authorizer.attrs[0].Resource).To(Equal("spiderippools"))
+	})
+
+	It("handles the request of the caller allowed to create SpiderIPPools", func() {
+		controllerContext.APIAuthorizer = &fakeAPIAuthorizer{}
+
+		rr := post()
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		Expect(rr.Body.String()).To(ContainSubstring("feature SpiderSubnet is disabled"))
+	})
+
+	It("still allows reading the resources if the API authorization is disabled", func() {
+		controllerContext.APIAuthorizer = nil
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/ipam/capacity", nil)
+		Expect(authorizeAPIRequest(req, constant.SpiderIPPoolKind, "default-v4-ippool")).To(Succeed())
+	})
+})
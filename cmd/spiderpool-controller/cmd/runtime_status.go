@@ -4,9 +4,15 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/go-openapi/runtime/middleware"
 
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+
 	"github.com/spidernet-io/spiderpool/api/v1/controller/server/restapi/runtime"
+	"github.com/spidernet-io/spiderpool/pkg/features"
+	"github.com/spidernet-io/spiderpool/pkg/informerstatus"
 )
 
 // Singleton
@@ -14,6 +20,7 @@ var (
 	httpGetControllerStartup   = &_httpGetControllerStartup{controllerContext}
 	httpGetControllerReadiness = &_httpGetControllerReadiness{controllerContext}
 	httpGetControllerLiveness  = &_httpGetControllerLiveness{controllerContext}
+	httpGetControllerFeaturez  = &_httpGetControllerFeaturez{}
 )
 
 type _httpGetControllerStartup struct {
@@ -35,12 +42,54 @@ type _httpGetControllerReadiness struct {
 
 // Handle handles GET requests for k8s readiness probe.
 func (g *_httpGetControllerReadiness) Handle(params runtime.GetRuntimeReadinessParams) middleware.Responder {
+	verbose := params.Verbose != nil && *params.Verbose
+
+	if !g.IsCacheWarmedUp.Load() {
+		logger.Warn("spiderpool controller is not ready, the caches are warming up")
+		resp := runtime.NewGetRuntimeReadinessInternalServerError()
+		if verbose {
+			resp.SetPayload(readinessPayload("the caches are warming up"))
+		}
+		return resp
+	}
+
 	if err := WebhookHealthyCheck(g.webhookClient, g.Cfg.WebhookPort); err != nil {
 		logger.Sugar().Errorf("failed to check spiderpool controller readiness probe, error: %v", err)
-		return runtime.NewGetRuntimeReadinessInternalServerError()
+		resp := runtime.NewGetRuntimeReadinessInternalServerError()
+		if verbose {
+			resp.SetPayload(readinessPayload(err.Error()))
+		}
+		return resp
+	}
+
+	resp := runtime.NewGetRuntimeReadinessOK()
+	if verbose {
+		resp.SetPayload(readinessPayload(""))
+	}
+	return resp
+}
+
+// readinessPayload reports the lag and queue depth of the informers.
+func readinessPayload(reason string) *models.Readiness {
+	now := time.Now()
+	statuses := informerstatus.List()
+	informers := make([]*models.InformerStatus, 0, len(statuses))
+	for _, s := range statuses {
+		informer := &models.InformerStatus{
+			Name:            s.Name,
+			EventLagSeconds: int64(s.EventLag.Seconds()),
+			QueueDepth:      int64(s.QueueDepth),
+		}
+		if !s.LastEventTime.IsZero() {
+			informer.LastEventAgeSeconds = int64(now.Sub(s.LastEventTime).Seconds())
+		}
+		informers = append(informers, informer)
 	}
 
-	return runtime.NewGetRuntimeReadinessOK()
+	return &models.Readiness{
+		Informers: informers,
+		Reason:    reason,
+	}
 }
 
 type _httpGetControllerLiveness struct {
@@ -56,3 +105,21 @@ func (g *_httpGetControllerLiveness) Handle(params runtime.GetRuntimeLivenessPar
 
 	return runtime.NewGetRuntimeLivenessOK()
 }
+
+type _httpGetControllerFeaturez struct{}
+
+// Handle handles GET requests for the status of feature gates.
+func (g *_httpGetControllerFeaturez) Handle(params runtime.GetFeaturezParams) middleware.Responder {
+	gates := features.ListFeatureGates(features.DefaultFeatureGate)
+	payload := make([]*models.FeatureGate, 0, len(gates))
+	for _, gate := range gates {
+		payload = append(payload, &models.FeatureGate{
+			Name:       gate.Name,
+			Enabled:    gate.Enabled,
+			Default:    gate.Default,
+			PreRelease: gate.PreRelease,
+		})
+	}
+
+	return runtime.NewGetFeaturezOK().WithPayload(payload)
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+)
+
+var _ = Describe("Runtime status API", Label("runtime_status_test"), func() {
+	var webhook *httptest.Server
+
+	BeforeEach(func() {
+		webhook = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		DeferCleanup(webhook.Close)
+
+		_, port, err := net.SplitHostPort(webhook.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+
+		origCfg := controllerContext.Cfg
+		origClient := controllerContext.webhookClient
+		origWarmedUp := controllerContext.IsCacheWarmedUp.Load()
+		controllerContext.Cfg.HttpPort = "5720"
+		controllerContext.Cfg.WebhookPort = port
+		controllerContext.webhookClient = &http.Client{
+			Transport: &http.Transport{
+				// #nosec G402 -- the certificate of the test server is self-signed.
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+		DeferCleanup(func() {
+			controllerContext.Cfg = origCfg
+			controllerContext.webhookClient = origClient
+			controllerContext.IsCacheWarmedUp.Store(origWarmedUp)
+		})
+	})
+
+	getReadiness := func() (int, *models.Readiness) {
+		srv, err := newControllerOpenAPIServer()
+		Expect(err).NotTo(HaveOccurred())
+
+		rr := httptest.NewRecorder()
+		srv.GetHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/runtime/readiness?verbose=true", nil))
+
+		var readiness models.Readiness
+		Expect(json.Unmarshal(rr.Body.Bytes(), &readiness)).To(Succeed())
+
+		return rr.Code, &readiness
+	}
+
+	It("is not ready while the caches are warming up", func() {
+		controllerContext.IsCacheWarmedUp.Store(false)
+
+		code, readiness := getReadiness()
+		Expect(code).To(Equal(http.StatusInternalServerError))
+		Expect(readiness.Reason).To(Equal("the caches are warming up"))
+	})
+
+	It("is ready once the caches are warmed up and the webhook is healthy", func() {
+		controllerContext.IsCacheWarmedUp.Store(true)
+
+		code, readiness := getReadiness()
+		Expect(code).To(Equal(http.StatusOK))
+		Expect(readiness.Reason).To(BeEmpty())
+	})
+
+	It("is not ready if the webhook is not reachable", func() {
+		controllerContext.IsCacheWarmedUp.Store(true)
+		webhook.Close()
+
+		code, readiness := getReadiness()
+		Expect(code).To(Equal(http.StatusInternalServerError))
+		Expect(readiness.Reason).To(ContainSubstring("webhook server is not reachable"))
+	})
+})
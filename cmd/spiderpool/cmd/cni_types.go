@@ -52,9 +52,16 @@ type IPAMConfig struct {
 
 	DefaultIPv4IPPool []string `json:"default_ipv4_ippool"`
 	DefaultIPv6IPPool []string `json:"default_ipv6_ippool"`
+	IPv4Pools         []string `json:"ipv4_pools"`
+	IPv6Pools         []string `json:"ipv6_pools"`
+	IPv4Subnet        string   `json:"ipv4_subnet"`
+	IPv6Subnet        string   `json:"ipv6_subnet"`
 	CleanGateway      bool     `json:"clean_gateway"`
 
 	IpamUnixSocketPath string `json:"ipam_unix_socket_path"`
+	// RequestTimeout is the timeout in seconds of each CNI request, the
+	// spiderpool-agent gives up the request beyond it.
+	RequestTimeout int `json:"request_timeout"`
 }
 
 // LoadNetConf converts inputs (i.e. stdin) to NetConf
@@ -74,6 +81,17 @@ func LoadNetConf(argsStdin []byte) (*NetConf, error) {
 		netConf.IPAM.IpamUnixSocketPath = constant.DefaultIPAMUnixSocketPath
 	}
 
+	if netConf.IPAM.RequestTimeout < 0 {
+		return nil, fmt.Errorf("invalid CNI configuration \"%s\": %w: 'request_timeout' must not be negative", argsStdin, constant.ErrWrongInput)
+	}
+	if netConf.IPAM.RequestTimeout == 0 {
+		netConf.IPAM.RequestTimeout = constant.DefaultCNIRequestTimeout
+	}
+
+	if err := validateIPAMPools(&netConf.IPAM); nil != err {
+		return nil, fmt.Errorf("invalid CNI configuration \"%s\": %w", argsStdin, err)
+	}
+
 	for _, vers := range SupportCNIVersions {
 		if netConf.CNIVersion == vers {
 			return netConf, nil
@@ -82,3 +100,50 @@ func LoadNetConf(argsStdin []byte) (*NetConf, error) {
 
 	return nil, fmt.Errorf("mismatch the given CNI Version: %s, spiderpool supports CNI version %#v", netConf.CNIVersion, SupportCNIVersions)
 }
+
+// validateIPAMPools checks the IPPools and SpiderSubnets specified in the ipam section, and merges
+// 'default_ipv4_ippool'/'default_ipv6_ippool' into 'ipv4_pools'/'ipv6_pools' whose order is the
+// order to allocate IP from.
+func validateIPAMPools(ipam *IPAMConfig) error {
+	v4Pools, err := mergeIPAMPools("ipv4_pools", ipam.IPv4Pools, "default_ipv4_ippool", ipam.DefaultIPv4IPPool)
+	if nil != err {
+		return err
+	}
+	v6Pools, err := mergeIPAMPools("ipv6_pools", ipam.IPv6Pools, "default_ipv6_ippool", ipam.DefaultIPv6IPPool)
+	if nil != err {
+		return err
+	}
+
+	if ipam.IPv4Subnet != "" && len(v4Pools) != 0 {
+		return fmt.Errorf("%w: 'ipv4_subnet' can't be used with 'ipv4_pools'", constant.ErrWrongInput)
+	}
+	if ipam.IPv6Subnet != "" && len(v6Pools) != 0 {
+		return fmt.Errorf("%w: 'ipv6_subnet' can't be used with 'ipv6_pools'", constant.ErrWrongInput)
+	}
+
+	ipam.IPv4Pools, ipam.IPv6Pools = v4Pools, v6Pools
+
+	return nil
+}
+
+func mergeIPAMPools(poolsKey string, pools []string, defaultPoolsKey string, defaultPools []string) ([]string, error) {
+	if len(pools) != 0 && len(defaultPools) != 0 {
+		return nil, fmt.Errorf("%w: '%s' can't be used with '%s'", constant.ErrWrongInput, poolsKey, defaultPoolsKey)
+	}
+	if len(pools) == 0 {
+		pools, poolsKey = defaultPools, defaultPoolsKey
+	}
+
+	seen := make(map[string]struct{}, len(pools))
+	for _, pool := range pools {
+		if pool == "" {
+			return nil, fmt.Errorf("%w: '%s' contains an empty IPPool name", constant.ErrWrongInput, poolsKey)
+		}
+		if _, ok := seen[pool]; ok {
+			return nil, fmt.Errorf("%w: '%s' contains duplicate IPPool '%s'", constant.ErrWrongInput, poolsKey, pool)
+		}
+		seen[pool] = struct{}{}
+	}
+
+	return pools, nil
+}
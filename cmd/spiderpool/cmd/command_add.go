@@ -34,6 +34,16 @@ var (
 	ErrDeleteIPAM       = fmt.Errorf("spiderpool IP releasing error")
 )
 
+// CNI error codes of the IP allocation failures. The CNI spec reserves the
+// codes 0-99 for the well-known errors, so the plugin specific ones start
+// from 100.
+const (
+	ErrCodeIPAMFailure uint = iota + 100
+	ErrCodePoolExhausted
+	ErrCodeOwnerNotFound
+	ErrCodeOverlap
+)
+
 // CmdAdd follows CNI SPEC cmdAdd.
 func CmdAdd(args *skel.CmdArgs) (err error) {
 	var logger *zap.Logger
@@ -95,9 +105,13 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		return err
 	}
 
+	// The deadline bounds the whole CNI request, including the health check.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.IPAM.RequestTimeout)*time.Second)
+	defer cancel()
+
 	// GET /ipam/healthy
 	logger.Debug("Sending health check to spider agent.")
-	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParams())
+	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParamsWithContext(ctx))
 	if nil != err {
 		logger.Error(err.Error())
 		return ErrAgentHealthCheck
@@ -112,20 +126,20 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 		NetNamespace:      &args.Netns,
 		PodName:           (*string)(&k8sArgs.K8S_POD_NAME),
 		PodNamespace:      (*string)(&k8sArgs.K8S_POD_NAMESPACE),
-		DefaultIPV4IPPool: conf.IPAM.DefaultIPv4IPPool,
-		DefaultIPV6IPPool: conf.IPAM.DefaultIPv6IPPool,
+		DefaultIPV4IPPool: conf.IPAM.IPv4Pools,
+		DefaultIPV6IPPool: conf.IPAM.IPv6Pools,
+		DefaultIPV4Subnet: conf.IPAM.IPv4Subnet,
+		DefaultIPV6Subnet: conf.IPAM.IPv6Subnet,
 		CleanGateway:      conf.IPAM.CleanGateway,
+		TimeoutSeconds:    remainingSeconds(ctx),
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
-	defer cancel()
-
 	params := daemonset.NewPostIpamIPParamsWithContext(ctx)
 	params.SetIpamAddArgs(ipamAddArgs)
 	ipamResponse, err := spiderpoolAgentAPI.Daemonset.PostIpamIP(params)
 	if nil != err {
 		logger.Error(err.Error())
-		return fmt.Errorf("%w: %v", ErrPostIPAM, err)
+		return postIPAMError(err)
 	}
 	// validate spiderpool-agent response
 	if err = ipamResponse.Payload.Validate(strfmt.Default); nil != err {
@@ -219,3 +233,25 @@ func assembleResult(cniVersion, IfName string, ipamResponse *daemonset.PostIpamI
 
 	return result, nil
 }
+
+// postIPAMError maps the error response of spiderpool-agent to the CNI error
+// of its class, so that the container runtime is able to tell them apart.
+func postIPAMError(err error) *types.Error {
+	var code uint
+	var class error
+	var details string
+	switch e := err.(type) {
+	case *daemonset.PostIpamIPExhausted:
+		code, class, details = ErrCodePoolExhausted, constant.ErrPoolExhausted, string(e.Payload)
+	case *daemonset.PostIpamIPOwnerNotFound:
+		code, class, details = ErrCodeOwnerNotFound, constant.ErrOwnerNotFound, string(e.Payload)
+	case *daemonset.PostIpamIPOverlap:
+		code, class, details = ErrCodeOverlap, constant.ErrOverlap, string(e.Payload)
+	case *daemonset.PostIpamIPFailure:
+		return types.NewError(ErrCodeIPAMFailure, ErrPostIPAM.Error(), string(e.Payload))
+	default:
+		return types.NewError(ErrCodeIPAMFailure, ErrPostIPAM.Error(), err.Error())
+	}
+
+	return types.NewError(code, fmt.Sprintf("%s: %s", ErrPostIPAM, class), details)
+}
@@ -4,8 +4,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/containernetworking/cni/pkg/skel"
 	"github.com/containernetworking/cni/pkg/types"
@@ -78,9 +80,13 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 		return err
 	}
 
+	// The deadline bounds the whole CNI request, including the health check.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(conf.IPAM.RequestTimeout)*time.Second)
+	defer cancel()
+
 	// GET /ipam/healthy
 	logger.Debug("Sending health check to spider agent.")
-	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParams())
+	_, err = spiderpoolAgentAPI.Connectivity.GetIpamHealthy(connectivity.NewGetIpamHealthyParamsWithContext(ctx))
 	if nil != err {
 		logger.Error(err.Error())
 		return ErrAgentHealthCheck
@@ -90,14 +96,15 @@ func CmdDel(args *skel.CmdArgs) (err error) {
 	// DELETE /ipam/ip
 	logger.Info("Sending IP release request to spider agent.")
 	ipamDelArgs := &models.IpamDelArgs{
-		ContainerID:  &args.ContainerID,
-		IfName:       &args.IfName,
-		NetNamespace: args.Netns,
-		PodName:      (*string)(&k8sArgs.K8S_POD_NAME),
-		PodNamespace: (*string)(&k8sArgs.K8S_POD_NAMESPACE),
+		ContainerID:    &args.ContainerID,
+		IfName:         &args.IfName,
+		NetNamespace:   args.Netns,
+		PodName:        (*string)(&k8sArgs.K8S_POD_NAME),
+		PodNamespace:   (*string)(&k8sArgs.K8S_POD_NAMESPACE),
+		TimeoutSeconds: remainingSeconds(ctx),
 	}
 
-	params := daemonset.NewDeleteIpamIPParams()
+	params := daemonset.NewDeleteIpamIPParamsWithContext(ctx)
 	params.SetIpamDelArgs(ipamDelArgs)
 	_, err = spiderpoolAgentAPI.Daemonset.DeleteIpamIP(params)
 	if nil != err {
@@ -5,6 +5,7 @@ package cmd_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -146,7 +147,8 @@ var _ = Describe("spiderpool plugin", Label("unitest", "ipam_plugin_test"), func
 				} else if !configSets.isHealthy {
 					expectErr = cmd.ErrAgentHealthCheck
 				} else if !configSets.isPostIPAM {
-					expectErr = cmd.ErrPostIPAM
+					Expect(err).To(MatchError(ContainSubstring(cmd.ErrPostIPAM.Error())))
+					return
 				} else {
 					Expect(err).NotTo(HaveOccurred())
 				}
@@ -350,6 +352,34 @@ var _ = Describe("spiderpool plugin", Label("unitest", "ipam_plugin_test"), func
 			}, nil, nil),
 		)
 
+		DescribeTable("maps the failed allocations to CNI errors",
+			func(statusCode int, expectCode uint, expectMsg string) {
+				server.RouteToHandler("GET", healthCheckRoute, getHealthHandleFunc(true))
+				server.RouteToHandler("POST", ipamReqRoute, ghttp.RespondWithJSONEncoded(statusCode, "no IP addresses left"))
+
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+				args.StdinData = netConfBytes
+
+				_, _, err = testutils.CmdAddWithArgs(args, func() error {
+					return cmd.CmdAdd(args)
+				})
+				var cniErr *types.Error
+				Expect(errors.As(err, &cniErr)).To(BeTrue())
+				Expect(cniErr.Code).To(Equal(expectCode))
+				Expect(cniErr.Msg).To(Equal(expectMsg))
+				Expect(cniErr.Details).To(Equal("no IP addresses left"))
+			},
+			Entry("pool exhausted", daemonset.PostIpamIPExhaustedCode, cmd.ErrCodePoolExhausted,
+				cmd.ErrPostIPAM.Error()+": "+constant.ErrPoolExhausted.Error()),
+			Entry("owner not found", daemonset.PostIpamIPOwnerNotFoundCode, cmd.ErrCodeOwnerNotFound,
+				cmd.ErrPostIPAM.Error()+": "+constant.ErrOwnerNotFound.Error()),
+			Entry("overlap", daemonset.PostIpamIPOverlapCode, cmd.ErrCodeOverlap,
+				cmd.ErrPostIPAM.Error()+": "+constant.ErrOverlap.Error()),
+			Entry("internal failure", daemonset.PostIpamIPFailureCode, cmd.ErrCodeIPAMFailure,
+				cmd.ErrPostIPAM.Error()),
+		)
+
 		DescribeTable("test cmdDel",
 			func(configSets ConfigWorkableSets, cmdArgs func() *skel.CmdArgs) {
 				var ipamDeleteHandleFunc http.HandlerFunc
@@ -491,8 +521,62 @@ var _ = Describe("spiderpool plugin", Label("unitest", "ipam_plugin_test"), func
 
 			Expect(conf.IPAM.LogLevel).Should(Equal(cmd.DefaultLogLevelStr))
 			Expect(conf.IPAM.IpamUnixSocketPath).Should(Equal(constant.DefaultIPAMUnixSocketPath))
+			Expect(conf.IPAM.RequestTimeout).Should(Equal(constant.DefaultCNIRequestTimeout))
 		})
 
+		It("Invalid request timeout of network configuration", func() {
+			netConf.IPAM.RequestTimeout = -1
+
+			netConfBytes, err := json.Marshal(netConf)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = cmd.LoadNetConf(netConfBytes)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("Merge default IPPools of network configuration into ordered IPPools", func() {
+			netConf.IPAM.DefaultIPv4IPPool = []string{"v4-pool2", "v4-pool1"}
+			netConf.IPAM.IPv6Pools = []string{"v6-pool1"}
+
+			netConfBytes, err := json.Marshal(netConf)
+			Expect(err).NotTo(HaveOccurred())
+
+			conf, err := cmd.LoadNetConf(netConfBytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf.IPAM.IPv4Pools).To(Equal([]string{"v4-pool2", "v4-pool1"}))
+			Expect(conf.IPAM.IPv6Pools).To(Equal([]string{"v6-pool1"}))
+		})
+
+		DescribeTable("Invalid IPPools or Subnets of network configuration",
+			func(setup func(ipam *cmd.IPAMConfig)) {
+				setup(&netConf.IPAM)
+
+				netConfBytes, err := json.Marshal(netConf)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = cmd.LoadNetConf(netConfBytes)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			},
+			Entry("both ipv4_pools and default_ipv4_ippool", func(ipam *cmd.IPAMConfig) {
+				ipam.IPv4Pools = []string{"v4-pool1"}
+				ipam.DefaultIPv4IPPool = []string{"v4-pool2"}
+			}),
+			Entry("empty IPPool name", func(ipam *cmd.IPAMConfig) {
+				ipam.IPv6Pools = []string{""}
+			}),
+			Entry("duplicate IPPools", func(ipam *cmd.IPAMConfig) {
+				ipam.IPv4Pools = []string{"v4-pool1", "v4-pool1"}
+			}),
+			Entry("both ipv4_subnet and ipv4_pools", func(ipam *cmd.IPAMConfig) {
+				ipam.IPv4Subnet = "v4-subnet"
+				ipam.DefaultIPv4IPPool = []string{"v4-pool1"}
+			}),
+			Entry("both ipv6_subnet and ipv6_pools", func(ipam *cmd.IPAMConfig) {
+				ipam.IPv6Subnet = "v6-subnet"
+				ipam.IPv6Pools = []string{"v6-pool1"}
+			}),
+		)
+
 		It("Failed to load args with cmdAdd and cmdDel", func() {
 			patches := gomonkey.ApplyFuncSeq(types.LoadArgs, []gomonkey.OutputCell{
 				{Values: gomonkey.Params{constant.ErrUnknown}},
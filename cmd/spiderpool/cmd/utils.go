@@ -4,7 +4,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"time"
+
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"go.uber.org/zap"
 )
@@ -19,3 +23,14 @@ func setupFileLogging(conf *NetConf) (*zap.Logger, error) {
 	return logutils.InitFileLogger(*v, conf.IPAM.LogFilePath,
 		conf.IPAM.LogFileMaxSize, conf.IPAM.LogFileMaxAge, conf.IPAM.LogFileMaxCount)
 }
+
+// remainingSeconds returns the seconds left before the deadline of the CNI
+// request, so that spiderpool-agent gives up the request beyond it.
+func remainingSeconds(ctx context.Context) int64 {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+
+	return int64(math.Ceil(time.Until(deadline).Seconds()))
+}
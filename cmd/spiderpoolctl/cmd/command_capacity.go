@@ -0,0 +1,78 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// capacityCmd represents the capacity command.
+var capacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "show free or used IPs of SpiderSubnet or SpiderIPPool",
+	Long:  `show free or used IPs of SpiderSubnet or SpiderIPPool summarized into CIDRs, requested from spiderpool-controller`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+		kind, _ := flags.GetString("kind")
+		name, _ := flags.GetString("name")
+		state, _ := flags.GetString("state")
+		limit, _ := flags.GetInt64("limit")
+		continueToken, _ := flags.GetString("continue")
+		tenant, _ := flags.GetString("tenant")
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		params := controller.NewGetIpamCapacityParams().
+			WithKind(kind).
+			WithName(name).
+			WithState(&state).
+			WithLimit(&limit)
+		if continueToken != "" {
+			params.SetContinue(&continueToken)
+		}
+		if tenant != "" {
+			params.SetTenant(&tenant)
+		}
+
+		resp, err := client.Controller.GetIpamCapacity(params, authOption(flags))
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(resp.Payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		return nil
+	},
+}
+
+func init() {
+	capacityCmd.PersistentFlags().String("address", "localhost:5720", "[optional] http address of spiderpool-controller")
+	capacityCmd.PersistentFlags().String("kind", constant.SpiderSubnetKind, "[optional] kind of the resource, SpiderSubnet or SpiderIPPool")
+	capacityCmd.PersistentFlags().String("name", "", "[required] name of the resource")
+	capacityCmd.PersistentFlags().String("state", "free", "[optional] show free or used IPs")
+	capacityCmd.PersistentFlags().Int64("limit", 100, "[optional] max number of CIDRs in a page")
+	capacityCmd.PersistentFlags().String("continue", "", "[optional] continue token returned by the previous page")
+	capacityCmd.PersistentFlags().String("tenant", "", "[optional] only show the resource of the SpiderTenant")
+	addAuthFlags(capacityCmd)
+
+	err := capacityCmd.MarkPersistentFlagRequired("name")
+	if nil != err {
+		logger.Error(err.Error())
+	}
+
+	rootCmd.AddCommand(capacityCmd)
+}
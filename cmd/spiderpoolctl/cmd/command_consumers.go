@@ -0,0 +1,70 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// consumersCmd represents the consumers command.
+var consumersCmd = &cobra.Command{
+	Use:   "consumers",
+	Short: "show applications consuming the most IPs of SpiderSubnet or SpiderIPPool",
+	Long:  `show applications consuming the most IPs of SpiderSubnet or SpiderIPPool, requested from spiderpool-controller`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+		kind, _ := flags.GetString("kind")
+		name, _ := flags.GetString("name")
+		top, _ := flags.GetInt64("top")
+		tenant, _ := flags.GetString("tenant")
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		params := controller.NewGetIpamConsumersParams().
+			WithKind(kind).
+			WithName(name).
+			WithTop(&top)
+		if tenant != "" {
+			params.SetTenant(&tenant)
+		}
+
+		resp, err := client.Controller.GetIpamConsumers(params, authOption(flags))
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(resp.Payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		return nil
+	},
+}
+
+func init() {
+	consumersCmd.PersistentFlags().String("address", "localhost:5720", "[optional] http address of spiderpool-controller")
+	consumersCmd.PersistentFlags().String("kind", constant.SpiderSubnetKind, "[optional] kind of the resource, SpiderSubnet or SpiderIPPool")
+	consumersCmd.PersistentFlags().String("name", "", "[required] name of the resource")
+	consumersCmd.PersistentFlags().Int64("top", 10, "[optional] max number of applications to show")
+	consumersCmd.PersistentFlags().String("tenant", "", "[optional] only show the resource of the SpiderTenant")
+	addAuthFlags(consumersCmd)
+
+	err := consumersCmd.MarkPersistentFlagRequired("name")
+	if nil != err {
+		logger.Error(err.Error())
+	}
+
+	rootCmd.AddCommand(consumersCmd)
+}
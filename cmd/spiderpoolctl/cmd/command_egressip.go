@@ -0,0 +1,108 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+)
+
+// egressIPCmd represents the base command.
+var egressIPCmd = &cobra.Command{
+	Use:   "egressip",
+	Short: "spiderpoolctl egressip cli",
+	Long:  `spiderpoolctl egressip cli to reserve the IP addresses of IPPools for egress gateway objects`,
+}
+
+// egressIPReserveCmd represents the reserve command.
+var egressIPReserveCmd = &cobra.Command{
+	Use:   "reserve",
+	Short: "reserve an IP address of the IPPool for an egress gateway object",
+	Long: `reserve an IP address of the IPPool for an egress gateway object, requested from spiderpool-controller.
+The reserved IP address is no longer allocated to any pod, and it is printed. The IP address reserved for
+the object before is printed if any. The reservation is released once the object is gone`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		params := controller.NewPostIpamEgressipParams().WithReservation(egressIPReservationFromFlags(cmd))
+		resp, err := client.Controller.PostIpamEgressip(params, authOption(flags))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), resp.Payload.IP)
+
+		return nil
+	},
+}
+
+// egressIPReleaseCmd represents the release command.
+var egressIPReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "release the IP addresses of the IPPool reserved for an egress gateway object",
+	Long:  `release the IP addresses of the IPPool reserved for an egress gateway object, requested from spiderpool-controller`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		params := controller.NewDeleteIpamEgressipParams().WithReservation(egressIPReservationFromFlags(cmd))
+		_, err := client.Controller.DeleteIpamEgressip(params, authOption(flags))
+
+		return err
+	},
+}
+
+func egressIPReservationFromFlags(cmd *cobra.Command) *models.EgressIPReservation {
+	flags := cmd.Flags()
+	ippool, _ := flags.GetString("ippool")
+	apiVersion, _ := flags.GetString("api-version")
+	kind, _ := flags.GetString("kind")
+	namespace, _ := flags.GetString("namespace")
+	name, _ := flags.GetString("name")
+	uid, _ := flags.GetString("uid")
+
+	return &models.EgressIPReservation{
+		Ippool:     &ippool,
+		APIVersion: &apiVersion,
+		Kind:       &kind,
+		Namespace:  namespace,
+		Name:       &name,
+		UID:        uid,
+	}
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{egressIPReserveCmd, egressIPReleaseCmd} {
+		cmd.PersistentFlags().String("address", "localhost:5720", "[optional] http address of spiderpool-controller")
+		cmd.PersistentFlags().String("ippool", "", "[required] IPPool to reserve the IP address of")
+		cmd.PersistentFlags().String("api-version", "", "[required] API version of the egress gateway object, e.g. 'egressgateway.spidernet.io/v1beta1'")
+		cmd.PersistentFlags().String("kind", "", "[required] kind of the egress gateway object, e.g. 'EgressGateway'")
+		cmd.PersistentFlags().String("namespace", "", "[optional] namespace of the egress gateway object, empty for the cluster scope objects")
+		cmd.PersistentFlags().String("name", "", "[required] name of the egress gateway object")
+		cmd.PersistentFlags().String("uid", "", "[optional] UID of the egress gateway object, the reservation is released once it mismatches")
+		addAuthFlags(cmd)
+
+		_ = cmd.MarkPersistentFlagRequired("ippool")
+		_ = cmd.MarkPersistentFlagRequired("api-version")
+		_ = cmd.MarkPersistentFlagRequired("kind")
+		_ = cmd.MarkPersistentFlagRequired("name")
+
+		egressIPCmd.AddCommand(cmd)
+	}
+
+	rootCmd.AddCommand(egressIPCmd)
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+)
+
+// explainCmd represents the base command.
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "spiderpoolctl explain cli",
+	Long:  `spiderpoolctl explain cli to explain the decisions of spiderpool`,
+}
+
+// explainPodCmd represents the pod command.
+var explainPodCmd = &cobra.Command{
+	Use:   "pod <namespace>/<name>",
+	Short: "explain why each candidate IPPool is accepted or rejected for a pod",
+	Long: `explain why each candidate IPPool is accepted or rejected for a NIC of a pod, requested from spiderpool-controller.
+The selection of candidate IPPools is replayed with the pod annotations, namespace defaults, cluster defaults,
+and the affinities and exhaustion of IPPools, the IPPools of CNI network configuration are not involved`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		namespace, name, ok := strings.Cut(args[0], "/")
+		if !ok || namespace == "" || name == "" {
+			return fmt.Errorf("invalid pod '%s', expect '<namespace>/<name>'", args[0])
+		}
+
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+		nic, _ := flags.GetString("nic")
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		params := controller.NewGetIpamExplainParams().
+			WithNamespace(namespace).
+			WithPod(name).
+			WithNic(&nic)
+
+		resp, err := client.Controller.GetIpamExplain(params, authOption(flags))
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(resp.Payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		return nil
+	},
+}
+
+func init() {
+	explainPodCmd.PersistentFlags().String("address", "localhost:5720", "[optional] http address of spiderpool-controller")
+	explainPodCmd.PersistentFlags().String("nic", "eth0", "[optional] NIC of the pod")
+	addAuthFlags(explainPodCmd)
+
+	explainCmd.AddCommand(explainPodCmd)
+	rootCmd.AddCommand(explainCmd)
+}
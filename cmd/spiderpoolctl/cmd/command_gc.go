@@ -4,7 +4,13 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
 )
 
 // gcCmd represents the gc command.
@@ -17,6 +23,37 @@ var gcCmd = &cobra.Command{
 	},
 }
 
+// gcCandidatesCmd represents the gc candidates command.
+var gcCandidatesCmd = &cobra.Command{
+	Use:   "candidates",
+	Short: "show pods traced by the IP GC",
+	Long:  `show pods traced by the IP GC with the deadlines to reclaim their IPs, requested from spiderpool-controller`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		resp, err := client.Controller.GetIpamGcCandidates(controller.NewGetIpamGcCandidatesParams(), authOption(flags))
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(resp.Payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		return nil
+	},
+}
+
 func init() {
+	gcCandidatesCmd.PersistentFlags().String("address", "localhost:5720", "[optional] http address of spiderpool-controller")
+	addAuthFlags(gcCandidatesCmd)
+	gcCmd.AddCommand(gcCandidatesCmd)
+
 	rootCmd.AddCommand(gcCmd)
 }
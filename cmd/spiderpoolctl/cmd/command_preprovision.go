@@ -0,0 +1,119 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	controllerOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/controller/client"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
+	"github.com/spidernet-io/spiderpool/api/v1/controller/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// preProvisionCmd represents the preprovision command.
+var preProvisionCmd = &cobra.Command{
+	Use:   "preprovision",
+	Short: "pre-provision the auto-created IPPools for applications not created yet",
+	Long: `pre-provision the auto-created IPPools of SpiderSubnet for applications not created yet, requested from spiderpool-controller.
+The applications are read from the file of '--filename' in YAML or JSON, or given by the flags for a single application.
+Each IPPool is sized for the replicas of its application plus the cluster default flexible IP number,
+and it is adopted by the application once created`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		address, _ := flags.GetString("address")
+		filename, _ := flags.GetString("filename")
+
+		request := &models.PreProvisionRequest{}
+		if filename != "" {
+			data, err := os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			if err := yaml.Unmarshal(data, request); err != nil {
+				return fmt.Errorf("failed to parse applications of file '%s': %w", filename, err)
+			}
+		} else {
+			app, err := preProvisionApplicationFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			request.Applications = append(request.Applications, app)
+		}
+
+		client := controllerOpenAPIClient.NewHTTPClientWithConfig(nil,
+			controllerOpenAPIClient.DefaultTransportConfig().WithHost(address))
+
+		params := controller.NewPostIpamPreprovisionParams().WithRequest(request)
+		resp, err := client.Controller.PostIpamPreprovision(params, authOption(flags))
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(resp.Payload, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+		for _, item := range resp.Payload.Items {
+			if item.Error != "" {
+				if classErr := constant.ErrOfReason(item.Reason); classErr != nil {
+					return fmt.Errorf("failed to pre-provision some IPPools: %w", classErr)
+				}
+				return fmt.Errorf("failed to pre-provision some IPPools")
+			}
+		}
+
+		return nil
+	},
+}
+
+func preProvisionApplicationFromFlags(cmd *cobra.Command) (*models.PreProvisionApplication, error) {
+	flags := cmd.Flags()
+	app, _ := flags.GetString("app")
+	replicas, _ := flags.GetInt64("replicas")
+	ipv4Subnet, _ := flags.GetString("ipv4-subnet")
+	ipv6Subnet, _ := flags.GetString("ipv6-subnet")
+	nic, _ := flags.GetString("nic")
+	reclaimIPPool, _ := flags.GetBool("reclaim-ippool")
+
+	kind, namespacedName, ok := strings.Cut(app, "/")
+	namespace, name, ok2 := strings.Cut(namespacedName, "/")
+	if !ok || !ok2 || kind == "" || namespace == "" || name == "" {
+		return nil, fmt.Errorf("invalid application '%s', expect '<kind>/<namespace>/<name>' or '--filename'", app)
+	}
+
+	return &models.PreProvisionApplication{
+		Kind:          &kind,
+		Namespace:     &namespace,
+		Name:          &name,
+		Replicas:      replicas,
+		IPV4Subnet:    ipv4Subnet,
+		IPV6Subnet:    ipv6Subnet,
+		Interface:     &nic,
+		ReclaimIPPool: &reclaimIPPool,
+	}, nil
+}
+
+func init() {
+	preProvisionCmd.PersistentFlags().String("address", "localhost:5720", "[optional] http address of spiderpool-controller")
+	preProvisionCmd.PersistentFlags().StringP("filename", "f", "", "[optional] file of the applications in YAML or JSON")
+	preProvisionCmd.PersistentFlags().String("app", "", "[optional] application in the format '<kind>/<namespace>/<name>', e.g. 'Deployment/default/demo'")
+	preProvisionCmd.PersistentFlags().Int64("replicas", 0, "[optional] replicas of the application")
+	preProvisionCmd.PersistentFlags().String("ipv4-subnet", "", "[optional] IPv4 SpiderSubnet of the application")
+	preProvisionCmd.PersistentFlags().String("ipv6-subnet", "", "[optional] IPv6 SpiderSubnet of the application")
+	preProvisionCmd.PersistentFlags().String("nic", "eth0", "[optional] NIC of the application pods")
+	preProvisionCmd.PersistentFlags().Bool("reclaim-ippool", true, "[optional] reclaim the IPPools once the application is deleted")
+	addAuthFlags(preProvisionCmd)
+
+	rootCmd.AddCommand(preProvisionCmd)
+}
@@ -4,8 +4,18 @@
 package cmd
 
 import (
+	"errors"
+	"os"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/spidernet-io/spiderpool/api/v1/controller/client/controller"
 	"github.com/spidernet-io/spiderpool/pkg/cmdgenmd"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 )
 
@@ -24,7 +34,30 @@ var rootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		logger.Fatal(err.Error())
+		logger.Error(err.Error())
+		os.Exit(exitCodeOf(err))
+	}
+}
+
+// Exit codes of the error classes, for the scripts to branch on.
+const (
+	exitCodeFailure       = 1
+	exitCodePoolExhausted = 3
+	exitCodeOwnerNotFound = 4
+	exitCodeOverlap       = 5
+)
+
+// exitCodeOf returns the exit code of the class of the error.
+func exitCodeOf(err error) int {
+	switch {
+	case errors.Is(err, constant.ErrPoolExhausted):
+		return exitCodePoolExhausted
+	case errors.Is(err, constant.ErrOwnerNotFound):
+		return exitCodeOwnerNotFound
+	case errors.Is(err, constant.ErrOverlap):
+		return exitCodeOverlap
+	default:
+		return exitCodeFailure
 	}
 }
 
@@ -33,3 +66,41 @@ func init() {
 
 	rootCmd.AddCommand(cmdgenmd.GenMarkDownCmd(SPIDERPOOL_CTL, rootCmd, logger))
 }
+
+// addAuthFlags adds the flags of the credential which the API of
+// spiderpool-controller is called with, required if its authorization is
+// enabled.
+func addAuthFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("token", "", "[optional] bearer token to authenticate to spiderpool-controller")
+	cmd.PersistentFlags().String("as", "", "[optional] username to impersonate")
+	cmd.PersistentFlags().StringArray("as-group", nil, "[optional] group to impersonate, could be repeated")
+}
+
+// authOption returns the client option setting the bearer token and the
+// impersonation headers of the flags on the request.
+func authOption(flags *pflag.FlagSet) controller.ClientOption {
+	token, _ := flags.GetString("token")
+	user, _ := flags.GetString("as")
+	groups, _ := flags.GetStringArray("as-group")
+
+	return func(op *runtime.ClientOperation) {
+		op.AuthInfo = runtime.ClientAuthInfoWriterFunc(func(req runtime.ClientRequest, _ strfmt.Registry) error {
+			if token != "" {
+				if err := req.SetHeaderParam("Authorization", "Bearer "+token); err != nil {
+					return err
+				}
+			}
+			if user != "" {
+				if err := req.SetHeaderParam(authenticationv1.ImpersonateUserHeader, user); err != nil {
+					return err
+				}
+			}
+			if len(groups) != 0 {
+				if err := req.SetHeaderParam(authenticationv1.ImpersonateGroupHeader, groups...); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package allocationjournal records the IP allocations of spiderpool-agent in
+// an append-only file before they are written to the IPPools, so that the
+// allocations interrupted by a restart of the agent could be found and
+// repaired when it starts again. It records the releases deferred while
+// kube-apiserver is unavailable as well.
+package allocationjournal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// Op is the operation of a record of the journal.
+type Op string
+
+const (
+	// OpBegin records that the IP addresses are going to be allocated to
+	// the container from the IPPools.
+	OpBegin Op = "begin"
+	// OpCommit records that the allocation is returned to the CNI plugin.
+	OpCommit Op = "commit"
+	// OpAbort records that the allocation is failed and rolled back, or is
+	// repaired after the restart.
+	OpAbort Op = "abort"
+	// OpDeferRelease records that the release of the container is
+	// acknowledged to the CNI plugin, but is not done in the IPPools yet.
+	OpDeferRelease Op = "deferRelease"
+	// OpReleased records that the deferred release is done.
+	OpReleased Op = "released"
+)
+
+// Entry is a record of the journal, only OpBegin and OpDeferRelease records
+// carry the details of the allocation.
+type Entry struct {
+	Op          Op        `json:"op"`
+	ContainerID string    `json:"containerID"`
+	Namespace   string    `json:"namespace,omitempty"`
+	Pod         string    `json:"pod,omitempty"`
+	NIC         string    `json:"nic,omitempty"`
+	IPPools     []string  `json:"ippools,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+type Journal interface {
+	// Begin records the allocation before the IP addresses are written to
+	// the IPPools, it is in flight until committed or aborted.
+	Begin(entry Entry) error
+	// Commit ends the allocation of the container returned to the CNI
+	// plugin.
+	Commit(containerID string) error
+	// Abort ends the allocation of the container which is rolled back or
+	// repaired.
+	Abort(containerID string) error
+	// InFlight returns the allocations which are neither committed nor
+	// aborted, sorted by the time they began.
+	InFlight() []Entry
+	// DeferRelease records the release which is acknowledged to the CNI
+	// plugin before it is done, it is pending until released.
+	DeferRelease(entry Entry) error
+	// Released ends the deferred release of the container.
+	Released(containerID string) error
+	// PendingReleases returns the deferred releases which are not done yet,
+	// sorted by the time they were deferred.
+	PendingReleases() []Entry
+	Close() error
+}
+
+type journal struct {
+	config JournalConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	records  int
+	inFlight map[string]Entry
+	releases map[string]Entry
+}
+
+// NewJournal opens the journal at config.Path, and loads the allocations in
+// flight when it was closed last time. The journal is compacted to them, the
+// torn record written on a crash is dropped.
+func NewJournal(config JournalConfig) (Journal, error) {
+	if config.Path == "" {
+		return nil, fmt.Errorf("journal path %w", constant.ErrMissingRequiredParam)
+	}
+
+	j := &journal{
+		config:   setDefaultsForJournalConfig(config),
+		inFlight: map[string]Entry{},
+		releases: map[string]Entry{},
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.config.Path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of journal %s: %w", j.config.Path, err)
+	}
+	if err := j.load(); err != nil {
+		return nil, err
+	}
+	if err := j.compact(); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (j *journal) Begin(entry Entry) error {
+	return j.start(OpBegin, entry, j.inFlight)
+}
+
+func (j *journal) Commit(containerID string) error {
+	return j.end(OpCommit, containerID, j.inFlight)
+}
+
+func (j *journal) Abort(containerID string) error {
+	return j.end(OpAbort, containerID, j.inFlight)
+}
+
+func (j *journal) DeferRelease(entry Entry) error {
+	return j.start(OpDeferRelease, entry, j.releases)
+}
+
+func (j *journal) Released(containerID string) error {
+	return j.end(OpReleased, containerID, j.releases)
+}
+
+func (j *journal) start(op Op, entry Entry, pending map[string]Entry) error {
+	if entry.ContainerID == "" {
+		return fmt.Errorf("container ID %w", constant.ErrMissingRequiredParam)
+	}
+
+	entry.Op = op
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.append(entry); err != nil {
+		return err
+	}
+	pending[entry.ContainerID] = entry
+
+	return nil
+}
+
+func (j *journal) end(op Op, containerID string, pending map[string]Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if _, ok := pending[containerID]; !ok {
+		return nil
+	}
+
+	if err := j.append(Entry{Op: op, ContainerID: containerID, Time: time.Now()}); err != nil {
+		return err
+	}
+	delete(pending, containerID)
+
+	if j.records >= j.config.MaxRecords {
+		return j.compact()
+	}
+
+	return nil
+}
+
+func (j *journal) InFlight() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return sortedEntries(j.inFlight)
+}
+
+func (j *journal) PendingReleases() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return sortedEntries(j.releases)
+}
+
+func (j *journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+
+	return err
+}
+
+// append writes the record and syncs it to the disk, so that it survives the
+// crash right after.
+func (j *journal) append(entry Entry) error {
+	if j.file == nil {
+		return fmt.Errorf("journal %s is closed", j.config.Path)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := j.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal %s: %w", j.config.Path, err)
+	}
+	if err := j.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal %s: %w", j.config.Path, err)
+	}
+	j.records++
+
+	return nil
+}
+
+func (j *journal) load() error {
+	f, err := os.Open(j.config.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open journal %s: %w", j.config.Path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+
+		switch entry.Op {
+		case OpBegin:
+			j.inFlight[entry.ContainerID] = entry
+		case OpCommit, OpAbort:
+			delete(j.inFlight, entry.ContainerID)
+		case OpDeferRelease:
+			j.releases[entry.ContainerID] = entry
+		case OpReleased:
+			delete(j.releases, entry.ContainerID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read journal %s: %w", j.config.Path, err)
+	}
+
+	return nil
+}
+
+// compact rewrites the journal with the allocations in flight and the pending
+// releases, the new file replaces the old one atomically.
+func (j *journal) compact() error {
+	tmpPath := j.config.Path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create journal %s: %w", tmpPath, err)
+	}
+
+	records := 0
+	for _, e := range append(sortedEntries(j.inFlight), sortedEntries(j.releases)...) {
+		line, err := json.Marshal(e)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		if _, err := tmp.Write(append(line, '\n')); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write journal %s: %w", tmpPath, err)
+		}
+		records++
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync journal %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+	if err := os.Rename(tmpPath, j.config.Path); err != nil {
+		return fmt.Errorf("failed to replace journal %s: %w", j.config.Path, err)
+	}
+
+	f, err := os.OpenFile(j.config.Path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open journal %s: %w", j.config.Path, err)
+	}
+	j.file = f
+	j.records = records
+
+	return nil
+}
+
+func sortedEntries(pending map[string]Entry) []Entry {
+	entries := make([]Entry, 0, len(pending))
+	for _, e := range pending {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].Time.Before(entries[b].Time)
+	})
+
+	return entries
+}
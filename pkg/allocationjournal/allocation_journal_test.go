@@ -0,0 +1,144 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package allocationjournal_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/allocationjournal"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+var _ = Describe("AllocationJournal", Label("allocation_journal_test"), func() {
+	var path string
+	var journal allocationjournal.Journal
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "allocation.journal")
+
+		var err error
+		journal, err = allocationjournal.NewJournal(allocationjournal.JournalConfig{Path: path})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(journal.Close)
+	})
+
+	reopen := func() allocationjournal.Journal {
+		Expect(journal.Close()).To(Succeed())
+		j, err := allocationjournal.NewJournal(allocationjournal.JournalConfig{Path: path})
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(j.Close)
+		return j
+	}
+
+	It("inputs empty path", func() {
+		j, err := allocationjournal.NewJournal(allocationjournal.JournalConfig{})
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		Expect(j).To(BeNil())
+	})
+
+	It("rejects the entry without container ID", func() {
+		err := journal.Begin(allocationjournal.Entry{Pod: "pod"})
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+	})
+
+	It("keeps the allocations in flight until they end", func() {
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c1", Namespace: "ns", Pod: "p1", NIC: "eth0", IPPools: []string{"v4"}})).To(Succeed())
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c2", Namespace: "ns", Pod: "p2"})).To(Succeed())
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c3", Namespace: "ns", Pod: "p3"})).To(Succeed())
+		Expect(journal.Commit("c2")).To(Succeed())
+		Expect(journal.Abort("c3")).To(Succeed())
+		Expect(journal.Abort("unknown")).To(Succeed())
+
+		entries := journal.InFlight()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Op).To(Equal(allocationjournal.OpBegin))
+		Expect(entries[0].ContainerID).To(Equal("c1"))
+		Expect(entries[0].IPPools).To(Equal([]string{"v4"}))
+		Expect(entries[0].Time.IsZero()).To(BeFalse())
+	})
+
+	It("replays the allocations in flight after restart", func() {
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c1", Pod: "p1", IPPools: []string{"v4", "v6"}})).To(Succeed())
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c2", Pod: "p2"})).To(Succeed())
+		Expect(journal.Commit("c2")).To(Succeed())
+
+		journal = reopen()
+		entries := journal.InFlight()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ContainerID).To(Equal("c1"))
+		Expect(entries[0].IPPools).To(Equal([]string{"v4", "v6"}))
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(data), "\n")).To(Equal(1))
+	})
+
+	It("drops the torn record written on a crash", func() {
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c1"})).To(Succeed())
+
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString(`{"op":"begin","containerID":"c2`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+
+		journal = reopen()
+		entries := journal.InFlight()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ContainerID).To(Equal("c1"))
+
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c3"})).To(Succeed())
+		journal = reopen()
+		Expect(journal.InFlight()).To(HaveLen(2))
+	})
+
+	It("compacts the journal", func() {
+		Expect(journal.Close()).To(Succeed())
+
+		var err error
+		journal, err = allocationjournal.NewJournal(allocationjournal.JournalConfig{Path: path, MaxRecords: 4})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c1"})).To(Succeed())
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c2"})).To(Succeed())
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c3"})).To(Succeed())
+		Expect(journal.Commit("c2")).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(data), "\n")).To(Equal(2))
+
+		journal = reopen()
+		Expect(journal.InFlight()).To(HaveLen(2))
+	})
+
+	It("keeps the deferred releases pending until released", func() {
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c1"})).To(Succeed())
+		Expect(journal.DeferRelease(allocationjournal.Entry{ContainerID: "c2", Namespace: "ns", Pod: "p2", NIC: "eth0"})).To(Succeed())
+		Expect(journal.DeferRelease(allocationjournal.Entry{ContainerID: "c3", Namespace: "ns", Pod: "p3", NIC: "eth0"})).To(Succeed())
+		Expect(journal.Released("c3")).To(Succeed())
+		Expect(journal.Released("c1")).To(Succeed())
+
+		journal = reopen()
+		Expect(journal.InFlight()).To(HaveLen(1))
+		releases := journal.PendingReleases()
+		Expect(releases).To(HaveLen(1))
+		Expect(releases[0].Op).To(Equal(allocationjournal.OpDeferRelease))
+		Expect(releases[0].ContainerID).To(Equal("c2"))
+		Expect(releases[0].Pod).To(Equal("p2"))
+
+		Expect(journal.Released("c2")).To(Succeed())
+		Expect(journal.PendingReleases()).To(BeEmpty())
+	})
+
+	It("fails to write after closed", func() {
+		Expect(journal.Close()).To(Succeed())
+		Expect(journal.Begin(allocationjournal.Entry{ContainerID: "c1"})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,24 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package allocationjournal
+
+const defaultMaxRecords = 1000
+
+type JournalConfig struct {
+	// Path is the file the journal is appended to, it should be on the host
+	// to survive the restarts of spiderpool-agent.
+	Path string
+
+	// MaxRecords is the number of records at which the journal is compacted
+	// to the allocations still in flight.
+	MaxRecords int
+}
+
+func setDefaultsForJournalConfig(config JournalConfig) JournalConfig {
+	if config.MaxRecords <= 0 {
+		config.MaxRecords = defaultMaxRecords
+	}
+
+	return config
+}
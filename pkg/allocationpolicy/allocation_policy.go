@@ -0,0 +1,126 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package allocationpolicy asks an external policy engine, e.g. a CMDB or a
+// security check, whether an IP address could be allocated to a Pod before
+// the allocation is recorded in the IPPool.
+package allocationpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// Review is the candidate allocation POSTed to the policy webhook.
+type Review struct {
+	Namespace           string `json:"namespace"`
+	Pod                 string `json:"pod"`
+	PodUID              string `json:"podUID"`
+	Node                string `json:"node"`
+	OwnerControllerType string `json:"ownerControllerType"`
+	OwnerControllerName string `json:"ownerControllerName"`
+	Interface           string `json:"interface"`
+	IPPool              string `json:"ippool"`
+	IPVersion           int64  `json:"ipVersion"`
+	IP                  string `json:"ip"`
+}
+
+// Decision is the answer of the policy webhook. The denied allocation could
+// suggest another IP address of the same IPPool instead.
+type Decision struct {
+	Allowed     bool   `json:"allowed"`
+	Reason      string `json:"reason,omitempty"`
+	SuggestedIP string `json:"suggestedIP,omitempty"`
+}
+
+type AllocationPolicy interface {
+	Review(ctx context.Context, review *Review) (*Decision, error)
+}
+
+type allocationPolicy struct {
+	config AllocationPolicyConfig
+	client *http.Client
+}
+
+// NewAllocationPolicy returns the policy reviewing the allocations with the
+// webhook at config.URL. The default HTTP client is used if httpClient is
+// nil.
+func NewAllocationPolicy(config AllocationPolicyConfig, httpClient *http.Client) (AllocationPolicy, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("policy webhook URL %w", constant.ErrMissingRequiredParam)
+	}
+
+	config = setDefaultsForAllocationPolicyConfig(config)
+	if _, err := ParseFailurePolicy(string(config.FailurePolicy)); err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &allocationPolicy{
+		config: config,
+		client: httpClient,
+	}, nil
+}
+
+// Review returns the decision of the policy webhook on the allocation. If the
+// webhook fails to answer, the allocation is allowed with the failure policy
+// Ignore, or the error is returned.
+func (ap *allocationPolicy) Review(ctx context.Context, review *Review) (*Decision, error) {
+	decision, err := ap.review(ctx, review)
+	if err != nil {
+		if ap.config.FailurePolicy == FailurePolicyIgnore {
+			logutils.FromContext(ctx).Sugar().Warnf("Ignore the failure of allocation policy: %v", err)
+			return &Decision{Allowed: true}, nil
+		}
+		return nil, err
+	}
+
+	return decision, nil
+}
+
+func (ap *allocationPolicy) review(ctx context.Context, review *Review) (*Decision, error) {
+	body, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ap.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ap.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ap.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review IP %s with allocation policy: %w", review.IP, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the decision of allocation policy: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to review IP %s with allocation policy, status code %d: %s", review.IP, resp.StatusCode, string(respBody))
+	}
+
+	var decision Decision
+	if err := json.Unmarshal(respBody, &decision); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the decision of allocation policy: %w", err)
+	}
+
+	return &decision, nil
+}
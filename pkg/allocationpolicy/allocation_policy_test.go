@@ -0,0 +1,117 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package allocationpolicy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/allocationpolicy"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+var _ = Describe("AllocationPolicy", Label("allocation_policy_test"), func() {
+	var review *allocationpolicy.Review
+
+	BeforeEach(func() {
+		review = &allocationpolicy.Review{
+			Namespace: "default",
+			Pod:       "pod",
+			Interface: "eth0",
+			IPPool:    "pool",
+			IPVersion: constant.IPv4,
+			IP:        "172.18.40.10",
+		}
+	})
+
+	newServer := func(handler http.HandlerFunc) *httptest.Server {
+		server := httptest.NewServer(handler)
+		DeferCleanup(server.Close)
+		return server
+	}
+
+	Describe("NewAllocationPolicy", func() {
+		It("inputs empty URL", func() {
+			policy, err := allocationpolicy.NewAllocationPolicy(allocationpolicy.AllocationPolicyConfig{}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(policy).To(BeNil())
+		})
+
+		It("inputs unknown failure policy", func() {
+			policy, err := allocationpolicy.NewAllocationPolicy(allocationpolicy.AllocationPolicyConfig{
+				URL:           "http://127.0.0.1",
+				FailurePolicy: "Retry",
+			}, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(policy).To(BeNil())
+		})
+	})
+
+	Describe("Review", func() {
+		It("posts the review and returns the decision", func() {
+			server := newServer(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.Method).To(Equal(http.MethodPost))
+
+				var got allocationpolicy.Review
+				Expect(json.NewDecoder(r.Body).Decode(&got)).To(Succeed())
+				Expect(got).To(Equal(*review))
+
+				_ = json.NewEncoder(w).Encode(allocationpolicy.Decision{Allowed: false, Reason: "blocked", SuggestedIP: "172.18.40.11"})
+			})
+
+			policy, err := allocationpolicy.NewAllocationPolicy(allocationpolicy.AllocationPolicyConfig{URL: server.URL}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			decision, err := policy.Review(context.TODO(), review)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decision).To(Equal(&allocationpolicy.Decision{Allowed: false, Reason: "blocked", SuggestedIP: "172.18.40.11"}))
+		})
+
+		It("fails on the error status code with failure policy Fail", func() {
+			server := newServer(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			})
+
+			policy, err := allocationpolicy.NewAllocationPolicy(allocationpolicy.AllocationPolicyConfig{URL: server.URL}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			decision, err := policy.Review(context.TODO(), review)
+			Expect(err).To(HaveOccurred())
+			Expect(decision).To(BeNil())
+		})
+
+		It("allows the allocation on timeout with failure policy Ignore", func() {
+			server := newServer(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+			})
+
+			policy, err := allocationpolicy.NewAllocationPolicy(allocationpolicy.AllocationPolicyConfig{
+				URL:           server.URL,
+				Timeout:       20 * time.Millisecond,
+				FailurePolicy: allocationpolicy.FailurePolicyIgnore,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			decision, err := policy.Review(context.TODO(), review)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decision.Allowed).To(BeTrue())
+		})
+	})
+
+	Describe("ParseFailurePolicy", func() {
+		It("parses the failure policy", func() {
+			Expect(allocationpolicy.ParseFailurePolicy("")).To(Equal(allocationpolicy.FailurePolicyFail))
+			Expect(allocationpolicy.ParseFailurePolicy("Ignore")).To(Equal(allocationpolicy.FailurePolicyIgnore))
+			_, err := allocationpolicy.ParseFailurePolicy("ignore")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,59 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package allocationpolicy
+
+import (
+	"fmt"
+	"time"
+)
+
+// FailurePolicy decides the allocation when the policy webhook fails to
+// answer, e.g. on timeout.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFail fails the allocation, so that the Pod is retried by
+	// kubelet later.
+	FailurePolicyFail FailurePolicy = "Fail"
+	// FailurePolicyIgnore allows the allocation as if there is no policy.
+	FailurePolicyIgnore FailurePolicy = "Ignore"
+)
+
+const defaultTimeout = 3 * time.Second
+
+type AllocationPolicyConfig struct {
+	// URL is the endpoint the candidate allocations are POSTed to.
+	URL string
+
+	// Timeout is the timeout of each review.
+	Timeout time.Duration
+
+	// FailurePolicy is FailurePolicyFail if not specified.
+	FailurePolicy FailurePolicy
+}
+
+func setDefaultsForAllocationPolicyConfig(config AllocationPolicyConfig) AllocationPolicyConfig {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	if config.FailurePolicy == "" {
+		config.FailurePolicy = FailurePolicyFail
+	}
+
+	return config
+}
+
+// ParseFailurePolicy parses the failure policy, and the empty one is
+// FailurePolicyFail.
+func ParseFailurePolicy(s string) (FailurePolicy, error) {
+	switch FailurePolicy(s) {
+	case "", FailurePolicyFail:
+		return FailurePolicyFail, nil
+	case FailurePolicyIgnore:
+		return FailurePolicyIgnore, nil
+	default:
+		return "", fmt.Errorf("unknown failure policy '%s', must be '%s' or '%s'", s, FailurePolicyFail, FailurePolicyIgnore)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package apiauthorizer authorizes the requests to the HTTP API of
+// spiderpool-controller with the Kubernetes RBAC of their callers, so that
+// the API could be exposed to the users without the permission of the CRDs.
+package apiauthorizer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// ResourceAttributes describes the resource which the request accesses, the
+// Spiderpool ones or the Kubernetes ones revealed by the API, e.g. Pods.
+type ResourceAttributes struct {
+	Verb      string
+	Group     string
+	Version   string
+	Resource  string
+	Namespace string
+	Name      string
+}
+
+type APIAuthorizer interface {
+	// Authorize checks whether the caller of the request is allowed to
+	// access the resource. It returns an error matching
+	// constant.ErrUnauthorized if the caller could not be authenticated, or
+	// constant.ErrForbidden if the caller is not allowed.
+	Authorize(req *http.Request, attrs ResourceAttributes) error
+}
+
+type apiAuthorizer struct {
+	client kubernetes.Interface
+}
+
+// NewAPIAuthorizer returns the authorizer which authenticates the bearer
+// token of the request with TokenReview, and checks the permission of the
+// caller, or the user it impersonates, with SubjectAccessReview.
+func NewAPIAuthorizer(client kubernetes.Interface) (APIAuthorizer, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s clientset %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &apiAuthorizer{client: client}, nil
+}
+
+func (a *apiAuthorizer) Authorize(req *http.Request, attrs ResourceAttributes) error {
+	ctx := req.Context()
+
+	user, err := a.authenticate(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	user, err = a.impersonate(ctx, req, user)
+	if err != nil {
+		return err
+	}
+
+	return a.review(ctx, user, &authorizationv1.ResourceAttributes{
+		Verb:      attrs.Verb,
+		Group:     attrs.Group,
+		Version:   attrs.Version,
+		Resource:  attrs.Resource,
+		Namespace: attrs.Namespace,
+		Name:      attrs.Name,
+	})
+}
+
+// authenticate returns the user of the bearer token of the request.
+func (a *apiAuthorizer) authenticate(ctx context.Context, req *http.Request) (*authenticationv1.UserInfo, error) {
+	token, ok := bearerToken(req)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing bearer token", constant.ErrUnauthorized)
+	}
+
+	tr, err := a.client.AuthenticationV1().TokenReviews().Create(
+		ctx,
+		&authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to review the bearer token: %w", err)
+	}
+	if !tr.Status.Authenticated {
+		return nil, fmt.Errorf("%w: %s", constant.ErrUnauthorized, tr.Status.Error)
+	}
+
+	return &tr.Status.User, nil
+}
+
+// impersonate returns the user which the request impersonates with the
+// impersonation headers, after checking that the authenticated user is
+// allowed to impersonate it, the same as kube-apiserver. If there is no
+// impersonation header, the authenticated user is returned.
+func (a *apiAuthorizer) impersonate(ctx context.Context, req *http.Request, user *authenticationv1.UserInfo) (*authenticationv1.UserInfo, error) {
+	username := req.Header.Get(authenticationv1.ImpersonateUserHeader)
+	groups := req.Header.Values(authenticationv1.ImpersonateGroupHeader)
+	uid := req.Header.Get(authenticationv1.ImpersonateUIDHeader)
+	extra := map[string]authenticationv1.ExtraValue{}
+	for key, values := range req.Header {
+		if !strings.HasPrefix(key, authenticationv1.ImpersonateUserExtraHeaderPrefix) {
+			continue
+		}
+		extraKey, err := url.PathUnescape(strings.ToLower(strings.TrimPrefix(key, authenticationv1.ImpersonateUserExtraHeaderPrefix)))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid header %s: %v", constant.ErrForbidden, key, err)
+		}
+		extra[extraKey] = values
+	}
+
+	if username == "" {
+		if len(groups) != 0 || uid != "" || len(extra) != 0 {
+			return nil, fmt.Errorf("%w: impersonating groups, UID or extra without impersonating a user", constant.ErrForbidden)
+		}
+		return user, nil
+	}
+
+	var checks []*authorizationv1.ResourceAttributes
+	if strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		namespace, name, _ := strings.Cut(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+		checks = append(checks, &authorizationv1.ResourceAttributes{Verb: "impersonate", Resource: "serviceaccounts", Namespace: namespace, Name: name})
+	} else {
+		checks = append(checks, &authorizationv1.ResourceAttributes{Verb: "impersonate", Resource: "users", Name: username})
+	}
+	for _, group := range groups {
+		checks = append(checks, &authorizationv1.ResourceAttributes{Verb: "impersonate", Resource: "groups", Name: group})
+	}
+	if uid != "" {
+		checks = append(checks, &authorizationv1.ResourceAttributes{Verb: "impersonate", Group: authenticationv1.GroupName, Resource: "uids", Name: uid})
+	}
+	for key, values := range extra {
+		for _, value := range values {
+			checks = append(checks, &authorizationv1.ResourceAttributes{Verb: "impersonate", Group: authenticationv1.GroupName, Resource: "userextras", Subresource: key, Name: value})
+		}
+	}
+
+	for _, check := range checks {
+		if err := a.review(ctx, user, check); err != nil {
+			return nil, err
+		}
+	}
+
+	return &authenticationv1.UserInfo{
+		Username: username,
+		UID:      uid,
+		Groups:   impersonatedGroups(username, groups),
+		Extra:    extra,
+	}, nil
+}
+
+// review checks the permission of the user with SubjectAccessReview.
+func (a *apiAuthorizer) review(ctx context.Context, user *authenticationv1.UserInfo, attrs *authorizationv1.ResourceAttributes) error {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	sar, err := a.client.AuthorizationV1().SubjectAccessReviews().Create(
+		ctx,
+		&authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: attrs,
+				User:               user.Username,
+				Groups:             user.Groups,
+				UID:                user.UID,
+				Extra:              extra,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to review the access of user %s: %w", user.Username, err)
+	}
+	if !sar.Status.Allowed || sar.Status.Denied {
+		return fmt.Errorf("%w: user %s cannot %s resource %s %s in namespace '%s'", constant.ErrForbidden, user.Username, attrs.Verb, attrs.Resource, attrs.Name, attrs.Namespace)
+	}
+
+	return nil
+}
+
+func bearerToken(req *http.Request) (string, bool) {
+	auth := strings.TrimSpace(req.Header.Get("Authorization"))
+	scheme, token, ok := strings.Cut(auth, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// impersonatedGroups adds the groups which kube-apiserver adds to the
+// impersonated user.
+func impersonatedGroups(username string, groups []string) []string {
+	if strings.HasPrefix(username, serviceAccountUsernamePrefix) && len(groups) == 0 {
+		namespace, _, _ := strings.Cut(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+		groups = append(groups, "system:serviceaccounts", "system:serviceaccounts:"+namespace)
+	}
+	if username != "system:anonymous" {
+		groups = append(groups, "system:authenticated")
+	}
+
+	return groups
+}
@@ -0,0 +1,166 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package apiauthorizer_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/spidernet-io/spiderpool/pkg/apiauthorizer"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+var _ = Describe("APIAuthorizer", Label("api_authorizer_test"), func() {
+	var clientSet *fake.Clientset
+	var authorizer apiauthorizer.APIAuthorizer
+	var attrs apiauthorizer.ResourceAttributes
+
+	// allowed lists the "user verb resource name" which are allowed.
+	var allowed map[string]bool
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+
+	BeforeEach(func() {
+		allowed = map[string]bool{}
+		reviews = nil
+		attrs = apiauthorizer.ResourceAttributes{Verb: "get", Group: constant.SpiderpoolAPIGroup, Resource: "spidersubnets", Name: "subnet"}
+
+		clientSet = fake.NewSimpleClientset()
+		clientSet.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			tr := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+			switch tr.Spec.Token {
+			case "admin-token":
+				tr.Status.Authenticated = true
+				tr.Status.User = authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:authenticated"}}
+			case "error-token":
+				return true, nil, fmt.Errorf("apiserver unavailable")
+			default:
+				tr.Status.Error = "invalid token"
+			}
+			return true, tr, nil
+		})
+		clientSet.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+			reviews = append(reviews, sar.Spec)
+			ra := sar.Spec.ResourceAttributes
+			sar.Status.Allowed = allowed[fmt.Sprintf("%s %s %s %s", sar.Spec.User, ra.Verb, ra.Resource, ra.Name)]
+			return true, sar, nil
+		})
+
+		var err error
+		authorizer, err = apiauthorizer.NewAPIAuthorizer(clientSet)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	newRequest := func(token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/v1/ipam/capacity", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return req
+	}
+
+	It("inputs nil clientset", func() {
+		authorizer, err := apiauthorizer.NewAPIAuthorizer(nil)
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		Expect(authorizer).To(BeNil())
+	})
+
+	It("rejects the request without bearer token", func() {
+		err := authorizer.Authorize(newRequest(""), attrs)
+		Expect(err).To(MatchError(constant.ErrUnauthorized))
+	})
+
+	It("rejects the invalid bearer token", func() {
+		err := authorizer.Authorize(newRequest("invalid-token"), attrs)
+		Expect(err).To(MatchError(constant.ErrUnauthorized))
+	})
+
+	It("fails to review the bearer token", func() {
+		err := authorizer.Authorize(newRequest("error-token"), attrs)
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(constant.ErrUnauthorized))
+		Expect(err).NotTo(MatchError(constant.ErrForbidden))
+	})
+
+	It("allows the permitted user", func() {
+		allowed["admin get spidersubnets subnet"] = true
+
+		err := authorizer.Authorize(newRequest("admin-token"), attrs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews).To(HaveLen(1))
+		Expect(reviews[0].ResourceAttributes.Group).To(Equal(constant.SpiderpoolAPIGroup))
+	})
+
+	It("reviews the namespaced resources of the core group", func() {
+		allowed["admin get pods pod"] = true
+		attrs = apiauthorizer.ResourceAttributes{Verb: "get", Version: "v1", Resource: "pods", Namespace: "default", Name: "pod"}
+
+		err := authorizer.Authorize(newRequest("admin-token"), attrs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews).To(HaveLen(1))
+		Expect(reviews[0].ResourceAttributes.Group).To(BeEmpty())
+		Expect(reviews[0].ResourceAttributes.Namespace).To(Equal("default"))
+	})
+
+	It("forbids the user without permission", func() {
+		err := authorizer.Authorize(newRequest("admin-token"), attrs)
+		Expect(err).To(MatchError(constant.ErrForbidden))
+	})
+
+	It("authorizes the impersonated user", func() {
+		allowed["admin impersonate users alice"] = true
+		allowed["admin impersonate groups network"] = true
+		allowed["alice get spidersubnets subnet"] = true
+
+		req := newRequest("admin-token")
+		req.Header.Set(authenticationv1.ImpersonateUserHeader, "alice")
+		req.Header.Add(authenticationv1.ImpersonateGroupHeader, "network")
+
+		err := authorizer.Authorize(req, attrs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews).To(HaveLen(3))
+		Expect(reviews[2].User).To(Equal("alice"))
+		Expect(reviews[2].Groups).To(ConsistOf("network", "system:authenticated"))
+	})
+
+	It("forbids the impersonation without permission", func() {
+		allowed["alice get spidersubnets subnet"] = true
+
+		req := newRequest("admin-token")
+		req.Header.Set(authenticationv1.ImpersonateUserHeader, "alice")
+
+		err := authorizer.Authorize(req, attrs)
+		Expect(err).To(MatchError(constant.ErrForbidden))
+	})
+
+	It("checks the impersonation of ServiceAccount in its namespace", func() {
+		allowed["admin impersonate serviceaccounts sa"] = true
+		allowed["system:serviceaccount:ns:sa get spidersubnets subnet"] = true
+
+		req := newRequest("admin-token")
+		req.Header.Set(authenticationv1.ImpersonateUserHeader, "system:serviceaccount:ns:sa")
+
+		err := authorizer.Authorize(req, attrs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews[0].ResourceAttributes.Namespace).To(Equal("ns"))
+		Expect(reviews[1].Groups).To(ContainElements("system:serviceaccounts", "system:serviceaccounts:ns"))
+	})
+
+	It("forbids impersonating groups without a user", func() {
+		req := newRequest("admin-token")
+		req.Header.Set(authenticationv1.ImpersonateGroupHeader, "network")
+
+		err := authorizer.Authorize(req, attrs)
+		Expect(err).To(MatchError(constant.ErrForbidden))
+	})
+})
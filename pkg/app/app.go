@@ -0,0 +1,76 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package app assembles the managers of Spiderpool with the same clients and
+// options, so that the tests could set up the managers depending on each
+// other at once.
+package app
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+)
+
+type Config struct {
+	PodManagerConfig    podmanager.PodManagerConfig
+	IPPoolManagerConfig ippoolmanager.IPPoolManagerConfig
+	SubnetManagerConfig subnetmanager.SubnetManagerConfig
+	// EnableSpiderSubnet assembles the SubnetManager, it is nil if false.
+	EnableSpiderSubnet bool
+}
+
+// App is the set of the assembled managers.
+type App struct {
+	PodManager        podmanager.PodManager
+	ReservedIPManager reservedipmanager.ReservedIPManager
+	IPPoolManager     ippoolmanager.IPPoolManager
+	SubnetManager     subnetmanager.SubnetManager
+}
+
+// New assembles the managers with client, apiReader and opts in the order
+// of their dependencies.
+func New(config Config, client client.Client, apiReader client.Reader, scheme *runtime.Scheme, opts ...manageroption.Option) (*App, error) {
+	if scheme == nil {
+		return nil, fmt.Errorf("scheme %w", constant.ErrMissingRequiredParam)
+	}
+
+	podManager, err := podmanager.NewPodManager(config.PodManagerConfig, client, apiReader, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble Pod manager: %w", err)
+	}
+
+	rIPManager, err := reservedipmanager.NewReservedIPManager(client, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble ReservedIP manager: %w", err)
+	}
+
+	ipPoolManager, err := ippoolmanager.NewIPPoolManager(config.IPPoolManagerConfig, client, apiReader, rIPManager, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assemble IPPool manager: %w", err)
+	}
+
+	app := &App{
+		PodManager:        podManager,
+		ReservedIPManager: rIPManager,
+		IPPoolManager:     ipPoolManager,
+	}
+
+	if config.EnableSpiderSubnet {
+		subnetManager, err := subnetmanager.NewSubnetManager(config.SubnetManagerConfig, client, apiReader, ipPoolManager, scheme, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to assemble Subnet manager: %w", err)
+		}
+		app.SubnetManager = subnetManager
+	}
+
+	return app, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package app_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/app"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+)
+
+type countingRecorder struct {
+	cachedReads int
+}
+
+func (r *countingRecorder) RecordRead(_ context.Context, _ string, cached bool) {
+	if cached {
+		r.cachedReads++
+	}
+}
+
+func (r *countingRecorder) RecordConflict(context.Context, string) {}
+
+var _ = Describe("App", Label("app_test"), func() {
+	It("inputs nil scheme", func() {
+		a, err := app.New(app.Config{}, fakeClient, fakeClient, nil)
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		Expect(a).To(BeNil())
+	})
+
+	It("inputs nil client", func() {
+		a, err := app.New(app.Config{}, nil, fakeClient, scheme)
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		Expect(a).To(BeNil())
+	})
+
+	It("assembles the managers without the Subnet manager", func() {
+		a, err := app.New(app.Config{}, fakeClient, fakeClient, scheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a.PodManager).NotTo(BeNil())
+		Expect(a.ReservedIPManager).NotTo(BeNil())
+		Expect(a.IPPoolManager).NotTo(BeNil())
+		Expect(a.SubnetManager).To(BeNil())
+	})
+
+	It("assembles the managers sharing the options", func() {
+		ctx := context.TODO()
+		pool := &spiderpoolv1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-ippool"},
+			Spec: spiderpoolv1.IPPoolSpec{
+				IPVersion: pointer.Int64(constant.IPv4),
+				Subnet:    "172.18.40.0/24",
+			},
+		}
+		err := fakeClient.Create(ctx, pool)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(fakeClient.Delete, ctx, pool)
+
+		recorder := &countingRecorder{}
+		a, err := app.New(
+			app.Config{EnableSpiderSubnet: true},
+			fakeClient,
+			fakeClient,
+			scheme,
+			manageroption.WithCache(fakeClient),
+			manageroption.WithMetrics(recorder),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a.SubnetManager).NotTo(BeNil())
+
+		_, err = a.IPPoolManager.GetIPPoolByName(ctx, pool.Name, constant.UseCache)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.cachedReads).To(Equal(1))
+	})
+})
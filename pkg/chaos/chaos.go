@@ -0,0 +1,159 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chaos injects artificial latency and update conflicts into the
+// writes of IPPools, so that the retries of the IPPool updates can be
+// validated in CI. It is only active in the binaries built with the tag
+// "chaos", e.g. the images built with `make build_image -e CHAOS=1`.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+)
+
+const (
+	// EnvConflictPercent is the percentage of the IPPool writes failed with
+	// an injected conflict.
+	EnvConflictPercent = "SPIDERPOOL_CHAOS_CONFLICT_PERCENT"
+	// EnvLatency is the milliseconds of the latency injected before each
+	// IPPool write.
+	EnvLatency = "SPIDERPOOL_CHAOS_LATENCY"
+)
+
+var errInjectedConflict = errors.New("conflict injected by chaos")
+
+// Config is the chaos injected into the writes of IPPools.
+type Config struct {
+	ConflictPercent int
+	Latency         time.Duration
+}
+
+// IsZero returns true if nothing is injected with the config.
+func (c Config) IsZero() bool {
+	return c.ConflictPercent == 0 && c.Latency == 0
+}
+
+// ConfigFromEnv parses the config from the environment variables.
+func ConfigFromEnv() (Config, error) {
+	var config Config
+
+	if v := os.Getenv(EnvConflictPercent); v != "" {
+		percent, err := strconv.Atoi(v)
+		if err != nil || percent < 0 || percent > 100 {
+			return Config{}, fmt.Errorf("invalid %s '%s', it must be an integer in [0, 100]", EnvConflictPercent, v)
+		}
+		config.ConflictPercent = percent
+	}
+
+	if v := os.Getenv(EnvLatency); v != "" {
+		latency, err := strconv.Atoi(v)
+		if err != nil || latency < 0 {
+			return Config{}, fmt.Errorf("invalid %s '%s', it must be a non-negative integer", EnvLatency, v)
+		}
+		config.Latency = time.Duration(latency) * time.Millisecond
+	}
+
+	return config, nil
+}
+
+type chaosClient struct {
+	client.Client
+	config Config
+
+	randLock lock.Mutex
+	rand     *rand.Rand
+}
+
+// NewClient returns the client injecting latency and conflicts with config
+// into the updates and patches of IPPools, including their status. The other
+// requests are passed through.
+func NewClient(c client.Client, config Config) client.Client {
+	return &chaosClient{
+		Client: c,
+		config: config,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (c *chaosClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.inject(ctx, obj); err != nil {
+		return err
+	}
+
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *chaosClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.inject(ctx, obj); err != nil {
+		return err
+	}
+
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *chaosClient) Status() client.StatusWriter {
+	return &chaosStatusWriter{
+		StatusWriter: c.Client.Status(),
+		chaos:        c,
+	}
+}
+
+// inject waits for the latency and returns the injected conflict, if obj is
+// an IPPool.
+func (c *chaosClient) inject(ctx context.Context, obj client.Object) error {
+	if _, ok := obj.(*spiderpoolv1.SpiderIPPool); !ok {
+		return nil
+	}
+
+	if c.config.Latency > 0 {
+		select {
+		case <-time.After(c.config.Latency):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if c.config.ConflictPercent > 0 {
+		c.randLock.Lock()
+		n := c.rand.Intn(100)
+		c.randLock.Unlock()
+		if n < c.config.ConflictPercent {
+			return apierrors.NewConflict(spiderpoolv1.Resource("spiderippools"), obj.GetName(), errInjectedConflict)
+		}
+	}
+
+	return nil
+}
+
+type chaosStatusWriter struct {
+	client.StatusWriter
+	chaos *chaosClient
+}
+
+func (w *chaosStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.chaos.inject(ctx, obj); err != nil {
+		return err
+	}
+
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func (w *chaosStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := w.chaos.inject(ctx, obj); err != nil {
+		return err
+	}
+
+	return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package chaos_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/chaos"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("Chaos", Label("chaos_test"), func() {
+	var fakeClient client.Client
+	var pool *spiderpoolv1.SpiderIPPool
+	var subnet *spiderpoolv1.SpiderSubnet
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(spiderpoolv1.AddToScheme(scheme)).To(Succeed())
+
+		pool = &spiderpoolv1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+		subnet = &spiderpoolv1.SpiderSubnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet"}}
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool, subnet).Build()
+	})
+
+	Describe("NewClient", func() {
+		It("injects conflicts into the writes of IPPools", func() {
+			c := chaos.NewClient(fakeClient, chaos.Config{ConflictPercent: 100})
+
+			err := c.Status().Update(context.TODO(), pool)
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+			err = c.Update(context.TODO(), pool)
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+			err = c.Patch(context.TODO(), pool, client.MergeFrom(pool.DeepCopy()))
+			Expect(apierrors.IsConflict(err)).To(BeTrue())
+
+			Expect(c.Update(context.TODO(), subnet)).To(Succeed())
+			Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+		})
+
+		It("injects latency into the writes of IPPools", func() {
+			c := chaos.NewClient(fakeClient, chaos.Config{Latency: 50 * time.Millisecond})
+
+			start := time.Now()
+			Expect(c.Status().Update(context.TODO(), pool)).To(Succeed())
+			Expect(time.Since(start)).To(BeNumerically(">=", 50*time.Millisecond))
+		})
+
+		It("stops waiting for the latency when the context is done", func() {
+			c := chaos.NewClient(fakeClient, chaos.Config{Latency: time.Hour})
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			cancel()
+			Expect(c.Update(ctx, pool)).To(MatchError(context.Canceled))
+		})
+	})
+
+	Describe("ConfigFromEnv", func() {
+		It("parses the environment variables", func() {
+			GinkgoT().Setenv(chaos.EnvConflictPercent, "30")
+			GinkgoT().Setenv(chaos.EnvLatency, "200")
+
+			config, err := chaos.ConfigFromEnv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(chaos.Config{ConflictPercent: 30, Latency: 200 * time.Millisecond}))
+			Expect(config.IsZero()).To(BeFalse())
+		})
+
+		It("returns the zero config without environment variables", func() {
+			config, err := chaos.ConfigFromEnv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.IsZero()).To(BeTrue())
+		})
+
+		It("rejects invalid percentages", func() {
+			GinkgoT().Setenv(chaos.EnvConflictPercent, "101")
+
+			_, err := chaos.ConfigFromEnv()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,20 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !chaos
+// +build !chaos
+
+package chaos
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Enabled is true in the binaries built with the tag "chaos".
+const Enabled = false
+
+// WrapClient returns c as is, nothing is injected in the binaries built
+// without the tag "chaos".
+func WrapClient(c client.Client) client.Client {
+	return c
+}
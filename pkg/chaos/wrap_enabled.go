@@ -0,0 +1,34 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build chaos
+// +build chaos
+
+package chaos
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// Enabled is true in the binaries built with the tag "chaos".
+const Enabled = true
+
+// WrapClient returns the client injecting the latency and conflicts
+// configured by the environment variables into the writes of IPPools.
+func WrapClient(c client.Client) client.Client {
+	logger := logutils.Logger.Named("Chaos")
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	if config.IsZero() {
+		return c
+	}
+
+	logger.Sugar().Warnf("Inject latency %s and %d%% conflicts into the writes of IPPools", config.Latency, config.ConflictPercent)
+
+	return NewClient(c, config)
+}
@@ -0,0 +1,366 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cniconfmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	// confFileInfix marks the CNI config files generated by spiderpool-agent,
+	// the other files in the directory are never touched.
+	confFileInfix = "-spiderpool-"
+	confFileExt   = ".conflist"
+
+	maxPriority = 99
+)
+
+// vlanPluginTypes are the main CNI plugins whose master interface is
+// replaced with the VLAN sub-interface of the IPPools.
+var vlanPluginTypes = map[string]bool{
+	"macvlan": true,
+	"ipvlan":  true,
+}
+
+// poolKeys are the keys of the IPAM config of spiderpool naming IPPools.
+var poolKeys = []string{"ipv4_pools", "ipv6_pools", "default_ipv4_ippool", "default_ipv6_ippool"}
+
+// CNIConfManager maintains the CNI config files of the node generated from
+// the NetworkAttachmentDefinitions with the annotation
+// "ipam.spidernet.io/cni-conf-priority". The files are named with the
+// priority as prefix, so that the container runtime loads them in a
+// deterministic order, e.g. the primary overlay network first and then the
+// secondary underlay one.
+//
+// The master interface of the macvlan or ipvlan plugin is replaced with the
+// VLAN sub-interface, e.g. "eth0.100", if the IPPools in its IPAM config are
+// in a VLAN, and a warning event is reported if the parent of the
+// sub-interface is not up on the node.
+type CNIConfManager interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+	// Enqueue triggers the regeneration of the CNI config files.
+	Enqueue()
+}
+
+type cniConfManager struct {
+	config CNIConfManagerConfig
+	client client.Client
+	queue  chan struct{}
+}
+
+func NewCNIConfManager(config CNIConfManagerConfig, client client.Client) (CNIConfManager, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if config.ConfDir == "" {
+		return nil, fmt.Errorf("CNI config directory %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &cniConfManager{
+		config: setDefaultsForCNIConfManagerConfig(config),
+		client: client,
+		queue:  make(chan struct{}, 1),
+	}, nil
+}
+
+// Start regenerates the CNI config files periodically and on demand until
+// the context is done.
+func (m *cniConfManager) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			err := recovery.Call(ctx, "CNI-Conf-Manager", nil, func() error { return m.Reconcile(ctx) })
+			if err != nil {
+				logger.Sugar().Errorf("Failed to generate CNI config files: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-m.queue:
+			}
+		}
+	}()
+}
+
+func (m *cniConfManager) Enqueue() {
+	select {
+	case m.queue <- struct{}{}:
+	default:
+	}
+}
+
+// Reconcile writes the CNI config file of each annotated
+// NetworkAttachmentDefinition, and removes the generated files no longer
+// desired.
+func (m *cniConfManager) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var nadList netv1.NetworkAttachmentDefinitionList
+	if err := m.client.List(ctx, &nadList); err != nil {
+		if !apimeta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to list NetworkAttachmentDefinitions: %w", err)
+		}
+		// Without the CRD, none of the generated files is desired.
+		nadList.Items = nil
+	}
+
+	var poolList spiderpoolv1.SpiderIPPoolList
+	if err := m.client.List(ctx, &poolList); err != nil && !apimeta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to list IPPools: %w", err)
+	}
+	poolVLANs := map[string]types.Vlan{}
+	for _, pool := range poolList.Items {
+		if pool.Spec.Vlan != nil && *pool.Spec.Vlan != 0 {
+			poolVLANs[pool.Name] = *pool.Spec.Vlan
+		}
+	}
+
+	desired := map[string][]byte{}
+	for i := range nadList.Items {
+		nad := &nadList.Items[i]
+		if nad.DeletionTimestamp != nil {
+			continue
+		}
+		if _, ok := nad.Annotations[constant.AnnoCNIConfPriority]; !ok {
+			continue
+		}
+
+		name, data, vlanParents, err := GenerateConfFile(nad, poolVLANs)
+		if err != nil {
+			logger.Sugar().Warnf("Skip NetworkAttachmentDefinition %s/%s: %v", nad.Namespace, nad.Name, err)
+			continue
+		}
+		desired[name] = data
+
+		for _, parent := range vlanParents {
+			if err := checkInterfaceUp(parent); err != nil {
+				logger.Sugar().Warnf("VLAN parent interface of NetworkAttachmentDefinition %s/%s is not ready: %v", nad.Namespace, nad.Name, err)
+				event.EventRecorder.Eventf(nad, corev1.EventTypeWarning, constant.EventReasonVLANParentNotReady,
+					"VLAN parent interface %s is not ready on node %s: %v", parent, m.config.NodeName, err)
+			}
+		}
+	}
+
+	return m.syncConfDir(ctx, desired)
+}
+
+func (m *cniConfManager) syncConfDir(ctx context.Context, desired map[string][]byte) error {
+	logger := logutils.FromContext(ctx)
+
+	entries, err := os.ReadDir(m.config.ConfDir)
+	if err != nil {
+		return fmt.Errorf("failed to read CNI config directory %s: %w", m.config.ConfDir, err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !isGeneratedConfFile(name) {
+			continue
+		}
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.config.ConfDir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove CNI config file %s: %w", name, err)
+		}
+		logger.Sugar().Infof("Remove CNI config file %s", name)
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(m.config.ConfDir, name)
+		if old, err := os.ReadFile(path); err == nil && bytes.Equal(old, desired[name]) {
+			continue
+		}
+		if err := writeFileAtomically(path, desired[name]); err != nil {
+			return fmt.Errorf("failed to write CNI config file %s: %w", name, err)
+		}
+		logger.Sugar().Infof("Write CNI config file %s", name)
+	}
+
+	return nil
+}
+
+// writeFileAtomically writes the file by renaming a temporary file, so that
+// the container runtime never loads a partial config.
+func writeFileAtomically(path string, data []byte) error {
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	return nil
+}
+
+// checkInterfaceUp checks whether the interface exists on the node and is
+// up.
+func checkInterfaceUp(name string) error {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return err
+	}
+	if iface.Flags&net.FlagUp == 0 {
+		return fmt.Errorf("interface %s is down", name)
+	}
+
+	return nil
+}
+
+func isGeneratedConfFile(name string) bool {
+	if len(name) < 3 || name[2] != '-' {
+		return false
+	}
+	if _, err := strconv.Atoi(name[:2]); err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(name[2:], confFileInfix) && strings.HasSuffix(name, confFileExt)
+}
+
+// GenerateConfFile generates the name and the content of the CNI config file
+// of the NetworkAttachmentDefinition. A config of a single plugin is wrapped
+// into a config list. The VLANs of IPPools are rendered with poolVLANs, the
+// VLANs indexed by the names of IPPools, and the parent interfaces of the
+// VLAN sub-interfaces are returned.
+func GenerateConfFile(nad *netv1.NetworkAttachmentDefinition, poolVLANs map[string]types.Vlan) (string, []byte, []string, error) {
+	priority, err := strconv.Atoi(nad.Annotations[constant.AnnoCNIConfPriority])
+	if err != nil || priority < 0 || priority > maxPriority {
+		return "", nil, nil, fmt.Errorf("invalid annotation %s: %q, it must be an integer in [0, %d]", constant.AnnoCNIConfPriority, nad.Annotations[constant.AnnoCNIConfPriority], maxPriority)
+	}
+
+	conf := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(nad.Spec.Config), &conf); err != nil {
+		return "", nil, nil, fmt.Errorf("invalid CNI config: %w", err)
+	}
+
+	if _, ok := conf["plugins"]; !ok {
+		if _, ok := conf["type"]; !ok {
+			return "", nil, nil, fmt.Errorf("invalid CNI config: neither 'plugins' nor 'type' is specified")
+		}
+
+		plugin := conf
+		conf = map[string]interface{}{
+			"plugins": []interface{}{plugin},
+		}
+		for _, key := range []string{"cniVersion", "name"} {
+			if v, ok := plugin[key]; ok {
+				conf[key] = v
+				delete(plugin, key)
+			}
+		}
+	}
+	if _, ok := conf["name"]; !ok {
+		conf["name"] = nad.Name
+	}
+
+	plugins, ok := conf["plugins"].([]interface{})
+	if !ok {
+		return "", nil, nil, fmt.Errorf("invalid CNI config: 'plugins' must be a list")
+	}
+	var vlanParents []string
+	for _, p := range plugins {
+		plugin, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		parent, err := renderVLAN(plugin, poolVLANs)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to render VLAN: %w", err)
+		}
+		if parent != "" {
+			vlanParents = append(vlanParents, parent)
+		}
+	}
+
+	data, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	name := fmt.Sprintf("%02d%s%s-%s%s", priority, confFileInfix, nad.Namespace, nad.Name, confFileExt)
+	return name, data, vlanParents, nil
+}
+
+// renderVLAN replaces the master interface of the macvlan or ipvlan plugin
+// using the IPAM of spiderpool with the VLAN sub-interface of its IPPools,
+// and returns the parent of the sub-interface. It returns "" if none of the
+// IPPools is in a VLAN. The IPPools specified by Pod annotations are out of
+// its sight.
+func renderVLAN(plugin map[string]interface{}, poolVLANs map[string]types.Vlan) (string, error) {
+	pluginType, _ := plugin["type"].(string)
+	if !vlanPluginTypes[pluginType] {
+		return "", nil
+	}
+	ipam, ok := plugin["ipam"].(map[string]interface{})
+	if !ok || ipam["type"] != constant.Spiderpool {
+		return "", nil
+	}
+
+	var vlan types.Vlan
+	for _, key := range poolKeys {
+		pools, _ := ipam[key].([]interface{})
+		for _, p := range pools {
+			poolName, _ := p.(string)
+			v, ok := poolVLANs[poolName]
+			if !ok {
+				continue
+			}
+			if vlan != 0 && vlan != v {
+				return "", fmt.Errorf("IPPools of plugin %s are in different VLANs %d and %d", pluginType, vlan, v)
+			}
+			vlan = v
+		}
+	}
+	if vlan == 0 {
+		return "", nil
+	}
+
+	master, _ := plugin["master"].(string)
+	if master == "" {
+		return "", fmt.Errorf("'master' of plugin %s is required for VLAN %d", pluginType, vlan)
+	}
+	suffix := fmt.Sprintf(".%d", vlan)
+	parent := strings.TrimSuffix(master, suffix)
+	plugin["master"] = parent + suffix
+
+	return parent, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cniconfmanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/cniconfmanager"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("CNIConfManager", Label("cni_conf_manager_test"), func() {
+	newNAD := func(name, priority, config string) *netv1.NetworkAttachmentDefinition {
+		nad := &netv1.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kube-system",
+				Name:      name,
+			},
+			Spec: netv1.NetworkAttachmentDefinitionSpec{Config: config},
+		}
+		if priority != "" {
+			nad.Annotations = map[string]string{constant.AnnoCNIConfPriority: priority}
+		}
+		return nad
+	}
+
+	Describe("New CNIConfManager", func() {
+		It("inputs nil client", func() {
+			manager, err := cniconfmanager.NewCNIConfManager(cniconfmanager.CNIConfManagerConfig{ConfDir: "/etc/cni/net.d"}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+
+		It("inputs empty config directory", func() {
+			manager, err := cniconfmanager.NewCNIConfManager(cniconfmanager.CNIConfManagerConfig{}, fakeClient)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+	})
+
+	Describe("GenerateConfFile", func() {
+		It("wraps the config of a single plugin into a config list", func() {
+			nad := newNAD("macvlan", "10", `{"cniVersion":"0.3.1","name":"macvlan-eth0","type":"macvlan","master":"eth0"}`)
+			name, data, _, err := cniconfmanager.GenerateConfFile(nad, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("10-spiderpool-kube-system-macvlan.conflist"))
+
+			conf := map[string]interface{}{}
+			err = json.Unmarshal(data, &conf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf).To(HaveKeyWithValue("cniVersion", "0.3.1"))
+			Expect(conf).To(HaveKeyWithValue("name", "macvlan-eth0"))
+			Expect(conf["plugins"]).To(ConsistOf(map[string]interface{}{"type": "macvlan", "master": "eth0"}))
+		})
+
+		It("keeps a config list", func() {
+			nad := newNAD("calico", "0", `{"cniVersion":"0.3.1","plugins":[{"type":"calico"},{"type":"portmap"}]}`)
+			name, data, _, err := cniconfmanager.GenerateConfFile(nad, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("00-spiderpool-kube-system-calico.conflist"))
+
+			conf := map[string]interface{}{}
+			err = json.Unmarshal(data, &conf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf).To(HaveKeyWithValue("name", "calico"))
+			Expect(conf["plugins"]).To(HaveLen(2))
+		})
+
+		It("inputs invalid priority", func() {
+			_, _, _, err := cniconfmanager.GenerateConfFile(newNAD("macvlan", "100", `{"type":"macvlan"}`), nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("inputs invalid config", func() {
+			_, _, _, err := cniconfmanager.GenerateConfFile(newNAD("macvlan", "10", `{"master":"eth0"}`), nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("renders the VLAN sub-interface of IPPools as the master interface", func() {
+			nad := newNAD("macvlan", "10", `{"cniVersion":"0.3.1","type":"macvlan","master":"eth0","ipam":{"type":"spiderpool","ipv4_pools":["vlan100-v4"],"ipv6_pools":["vlan100-v6"]}}`)
+			poolVLANs := map[string]types.Vlan{"vlan100-v4": 100, "vlan100-v6": 100}
+			_, data, vlanParents, err := cniconfmanager.GenerateConfFile(nad, poolVLANs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vlanParents).To(Equal([]string{"eth0"}))
+
+			conf := map[string]interface{}{}
+			err = json.Unmarshal(data, &conf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conf["plugins"]).To(ConsistOf(HaveKeyWithValue("master", "eth0.100")))
+		})
+
+		It("keeps the master interface of IPPools out of VLANs", func() {
+			nad := newNAD("ipvlan", "10", `{"cniVersion":"0.3.1","type":"ipvlan","master":"eth0","ipam":{"type":"spiderpool","ipv4_pools":["v4"]}}`)
+			_, data, vlanParents, err := cniconfmanager.GenerateConfFile(nad, map[string]types.Vlan{"vlan100-v4": 100})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vlanParents).To(BeEmpty())
+			Expect(string(data)).To(ContainSubstring(`"master": "eth0"`))
+		})
+
+		It("inputs IPPools in different VLANs", func() {
+			nad := newNAD("macvlan", "10", `{"cniVersion":"0.3.1","type":"macvlan","master":"eth0","ipam":{"type":"spiderpool","ipv4_pools":["vlan100-v4","vlan200-v4"]}}`)
+			_, _, _, err := cniconfmanager.GenerateConfFile(nad, map[string]types.Vlan{"vlan100-v4": 100, "vlan200-v4": 200})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("inputs IPPools in a VLAN without master interface", func() {
+			nad := newNAD("macvlan", "10", `{"cniVersion":"0.3.1","type":"macvlan","ipam":{"type":"spiderpool","ipv4_pools":["vlan100-v4"]}}`)
+			_, _, _, err := cniconfmanager.GenerateConfFile(nad, map[string]types.Vlan{"vlan100-v4": 100})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var ctx context.Context
+		var confDir string
+		var manager cniconfmanager.CNIConfManager
+
+		create := func(nad *netv1.NetworkAttachmentDefinition) {
+			err := fakeClient.Create(ctx, nad)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				err := fakeClient.Delete(ctx, nad)
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			})
+		}
+
+		listConfDir := func() []string {
+			entries, err := os.ReadDir(confDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			return names
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			confDir = GinkgoT().TempDir()
+
+			var err error
+			manager, err = cniconfmanager.NewCNIConfManager(cniconfmanager.CNIConfManagerConfig{ConfDir: confDir}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("writes the config files in the order of priority", func() {
+			create(newNAD("macvlan", "20", `{"cniVersion":"0.3.1","type":"macvlan","master":"eth0"}`))
+			create(newNAD("calico", "10", `{"cniVersion":"0.3.1","plugins":[{"type":"calico"}]}`))
+			create(newNAD("ignored", "", `{"cniVersion":"0.3.1","type":"bridge"}`))
+			create(newNAD("invalid", "30", `invalid`))
+
+			err := manager.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listConfDir()).To(Equal([]string{
+				"10-spiderpool-kube-system-calico.conflist",
+				"20-spiderpool-kube-system-macvlan.conflist",
+			}))
+		})
+
+		It("removes the stale config files only generated by itself", func() {
+			err := os.WriteFile(filepath.Join(confDir, "10-calico.conflist"), []byte("{}"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+			err = os.WriteFile(filepath.Join(confDir, "05-spiderpool-kube-system-stale.conflist"), []byte("{}"), 0o644)
+			Expect(err).NotTo(HaveOccurred())
+
+			nad := newNAD("macvlan", "20", `{"cniVersion":"0.3.1","type":"macvlan","master":"eth0"}`)
+			create(nad)
+
+			err = manager.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listConfDir()).To(Equal([]string{
+				"10-calico.conflist",
+				"20-spiderpool-kube-system-macvlan.conflist",
+			}))
+
+			err = fakeClient.Delete(ctx, nad)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = manager.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listConfDir()).To(Equal([]string{"10-calico.conflist"}))
+		})
+
+		It("reports the VLAN parent interface not ready on the node", func() {
+			recorder := record.NewFakeRecorder(10)
+			event.EventRecorder = recorder
+
+			pool := &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "vlan100-v4"},
+				Spec: spiderpoolv1.IPPoolSpec{
+					Subnet: "172.18.40.0/24",
+					Vlan:   pointer.Int64(100),
+				},
+			}
+			err := fakeClient.Create(ctx, pool)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				Expect(fakeClient.Delete(ctx, pool)).To(Succeed())
+			})
+			create(newNAD("macvlan", "20", `{"cniVersion":"0.3.1","type":"macvlan","master":"spiderpool-nonexistent","ipam":{"type":"spiderpool","ipv4_pools":["vlan100-v4"]}}`))
+
+			err = manager.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := os.ReadFile(filepath.Join(confDir, "20-spiderpool-kube-system-macvlan.conflist"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("spiderpool-nonexistent.100"))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(And(
+				ContainSubstring(constant.EventReasonVLANParentNotReady),
+				ContainSubstring("spiderpool-nonexistent"),
+			))
+		})
+
+		It("regenerates the config file on changes", func() {
+			nad := newNAD("macvlan", "20", `{"cniVersion":"0.3.1","type":"macvlan","master":"eth0"}`)
+			create(nad)
+
+			err := manager.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			nad.Spec.Config = `{"cniVersion":"0.3.1","type":"macvlan","master":"eth1"}`
+			err = fakeClient.Update(ctx, nad)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = manager.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := os.ReadFile(filepath.Join(confDir, "20-spiderpool-kube-system-macvlan.conflist"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring("eth1"))
+		})
+	})
+})
@@ -0,0 +1,34 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cniconfmanager
+
+import (
+	"time"
+)
+
+const (
+	defaultResyncPeriod = time.Minute
+)
+
+type CNIConfManagerConfig struct {
+	// ConfDir is the CNI config directory of the node, e.g. /etc/cni/net.d
+	// mounted into spiderpool-agent.
+	ConfDir string
+
+	// NodeName is the name of the node, reported in the events about the
+	// VLAN interfaces missing on the node.
+	NodeName string
+
+	// ResyncPeriod is the interval of regenerating the CNI config files,
+	// besides the regeneration on the changes of NetworkAttachmentDefinitions.
+	ResyncPeriod time.Duration
+}
+
+func setDefaultsForCNIConfManagerConfig(config CNIConfManagerConfig) CNIConfManagerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	return config
+}
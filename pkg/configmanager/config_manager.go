@@ -0,0 +1,90 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package configmanager
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+type ConfigManager interface {
+	GetSpiderpoolConfiguration(ctx context.Context) (*spiderpoolv1.SpiderpoolConfiguration, error)
+	UpdateComponentStatus(ctx context.Context, component string, generation int64, active spiderpoolv1.SpiderpoolConfigurationSpec) error
+}
+
+type configManager struct {
+	client client.Client
+}
+
+func NewConfigManager(client client.Client) (ConfigManager, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &configManager{
+		client: client,
+	}, nil
+}
+
+// GetSpiderpoolConfiguration gets the singleton SpiderpoolConfiguration. It
+// returns nil without error if the object does not exist or the CRD is not
+// installed, so that components fall back to ConfigMap and env settings.
+func (cm *configManager) GetSpiderpoolConfiguration(ctx context.Context) (*spiderpoolv1.SpiderpoolConfiguration, error) {
+	var config spiderpoolv1.SpiderpoolConfiguration
+	if err := cm.client.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderpoolConfigurationName}, &config); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// UpdateComponentStatus records the configuration that is active on the
+// component in the status of the singleton SpiderpoolConfiguration.
+func (cm *configManager) UpdateComponentStatus(ctx context.Context, component string, generation int64, active spiderpoolv1.SpiderpoolConfigurationSpec) error {
+	if component == "" {
+		return fmt.Errorf("component name %w", constant.ErrMissingRequiredParam)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var config spiderpoolv1.SpiderpoolConfiguration
+		if err := cm.client.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderpoolConfigurationName}, &config); err != nil {
+			return err
+		}
+
+		now := metav1.Now()
+		status := spiderpoolv1.ComponentConfiguration{
+			Name:                component,
+			ObservedGeneration:  generation,
+			LastAppliedTime:     &now,
+			ActiveConfiguration: active,
+		}
+
+		found := false
+		for i := range config.Status.Components {
+			if config.Status.Components[i].Name == component {
+				config.Status.Components[i] = status
+				found = true
+				break
+			}
+		}
+		if !found {
+			config.Status.Components = append(config.Status.Components, status)
+		}
+
+		return cm.client.Status().Update(ctx, &config)
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package configmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("ConfigManager", Label("config_manager_test"), func() {
+	Describe("New ConfigManager", func() {
+		It("inputs nil client", func() {
+			manager, err := configmanager.NewConfigManager(nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+	})
+
+	Describe("Test ConfigManager's method", func() {
+		var ctx context.Context
+		var configT *spiderpoolv1.SpiderpoolConfiguration
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			configT = &spiderpoolv1.SpiderpoolConfiguration{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       constant.SpiderpoolConfigurationKind,
+					APIVersion: spiderpoolv1.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: constant.SpiderpoolConfigurationName,
+				},
+				Spec: spiderpoolv1.SpiderpoolConfigurationSpec{
+					EnableIPv6: pointer.Bool(false),
+				},
+			}
+		})
+
+		AfterEach(func() {
+			_ = fakeClient.Delete(ctx, configT)
+		})
+
+		It("gets nothing if SpiderpoolConfiguration does not exist", func() {
+			config, err := configManager.GetSpiderpoolConfiguration(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(BeNil())
+		})
+
+		It("gets the SpiderpoolConfiguration", func() {
+			err := fakeClient.Create(ctx, configT)
+			Expect(err).NotTo(HaveOccurred())
+
+			config, err := configManager.GetSpiderpoolConfiguration(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Spec).To(Equal(configT.Spec))
+		})
+
+		It("inputs empty component name", func() {
+			err := configManager.UpdateComponentStatus(ctx, "", 1, spiderpoolv1.SpiderpoolConfigurationSpec{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		})
+
+		It("failed to update status due to the non-existent SpiderpoolConfiguration", func() {
+			err := configManager.UpdateComponentStatus(ctx, "spiderpool-controller", 1, spiderpoolv1.SpiderpoolConfigurationSpec{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("records the active configuration of components", func() {
+			err := fakeClient.Create(ctx, configT)
+			Expect(err).NotTo(HaveOccurred())
+
+			active := spiderpoolv1.SpiderpoolConfigurationSpec{
+				EnableIPv4: pointer.Bool(true),
+				EnableIPv6: pointer.Bool(false),
+			}
+			err = configManager.UpdateComponentStatus(ctx, "spiderpool-controller", 1, active)
+			Expect(err).NotTo(HaveOccurred())
+			err = configManager.UpdateComponentStatus(ctx, "spiderpool-agent", 1, active)
+			Expect(err).NotTo(HaveOccurred())
+			err = configManager.UpdateComponentStatus(ctx, "spiderpool-controller", 2, active)
+			Expect(err).NotTo(HaveOccurred())
+
+			config, err := configManager.GetSpiderpoolConfiguration(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Status.Components).To(HaveLen(2))
+			Expect(config.Status.Components[0].Name).To(Equal("spiderpool-controller"))
+			Expect(config.Status.Components[0].ObservedGeneration).To(BeEquivalentTo(2))
+			Expect(config.Status.Components[0].LastAppliedTime).NotTo(BeNil())
+			Expect(config.Status.Components[0].ActiveConfiguration).To(Equal(active))
+			Expect(config.Status.Components[1].Name).To(Equal("spiderpool-agent"))
+		})
+	})
+
+	Describe("ValidateConfigmapKeys", func() {
+		It("accepts all known keys", func() {
+			data := []byte("enableIPv4: true\nenableIPv6: false\nclusterDefaultIPv4IPPool: [default-v4-ippool]\n")
+			Expect(configmanager.ValidateConfigmapKeys(data)).To(Succeed())
+		})
+
+		It("accepts the cluster defaults of the detections", func() {
+			data := []byte("enableGatewayDetection: true\nenableIPConflictDetection: false\n")
+			Expect(configmanager.ValidateConfigmapKeys(data)).To(Succeed())
+		})
+
+		It("rejects misspelled detection keys", func() {
+			data := []byte("enableGatewayDetect: true\n")
+			Expect(configmanager.ValidateConfigmapKeys(data)).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("rejects misspelled keys", func() {
+			data := []byte("enableIPv4: true\nenableIpv6: false\nclusterDefaultIPv4Pool: []\n")
+			err := configmanager.ValidateConfigmapKeys(data)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+			Expect(err.Error()).To(ContainSubstring("clusterDefaultIPv4Pool, enableIpv6"))
+		})
+
+		It("inputs invalid yaml", func() {
+			Expect(configmanager.ValidateConfigmapKeys([]byte("enableIPv4: [true"))).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,176 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package configmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+const defaultFreezeResyncPeriod = 10 * time.Second
+
+// FreezeChecker reports whether Spiderpool is in the maintenance freeze.
+type FreezeChecker interface {
+	// Frozen returns true along with the reason if Spiderpool is frozen.
+	Frozen() (bool, string)
+}
+
+// FreezeMonitor follows 'spec.freeze' of the singleton
+// SpiderpoolConfiguration, so that the freeze takes effect without
+// restarting the components.
+type FreezeMonitor interface {
+	FreezeChecker
+	Start(ctx context.Context)
+	Sync(ctx context.Context) error
+}
+
+type FreezeMonitorConfig struct {
+	// Component is the name of the component reported in the logs and the
+	// events.
+	Component string
+	// ResyncPeriod is how often the SpiderpoolConfiguration is read.
+	ResyncPeriod time.Duration
+	// RecordEvents makes the monitor record the events of the freeze and
+	// the unfreeze on the SpiderpoolConfiguration, it is only enabled for
+	// spiderpool-controller, to avoid an event from each node.
+	RecordEvents bool
+}
+
+func setDefaultsForFreezeMonitorConfig(config FreezeMonitorConfig) FreezeMonitorConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultFreezeResyncPeriod
+	}
+
+	return config
+}
+
+type freezeMonitor struct {
+	config        FreezeMonitorConfig
+	configManager ConfigManager
+
+	lock   sync.RWMutex
+	frozen bool
+	reason string
+}
+
+func NewFreezeMonitor(config FreezeMonitorConfig, configManager ConfigManager) (FreezeMonitor, error) {
+	if configManager == nil {
+		return nil, fmt.Errorf("config manager %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &freezeMonitor{
+		config:        setDefaultsForFreezeMonitorConfig(config),
+		configManager: configManager,
+	}, nil
+}
+
+func (m *freezeMonitor) Frozen() (bool, string) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.frozen, m.reason
+}
+
+// Start syncs the freeze periodically until the context is done.
+func (m *freezeMonitor) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			err := recovery.Call(ctx, "Freeze-Monitor", nil, func() error { return m.Sync(ctx) })
+			if err != nil {
+				logger.Sugar().Errorf("Failed to sync the maintenance freeze: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync reads the freeze from the SpiderpoolConfiguration. Spiderpool is not
+// frozen if the SpiderpoolConfiguration does not exist, but the freeze is
+// kept if it fails to be read.
+func (m *freezeMonitor) Sync(ctx context.Context) error {
+	config, err := m.configManager.GetSpiderpoolConfiguration(ctx)
+	if err != nil {
+		return err
+	}
+
+	frozen, reason := FreezeOf(config)
+
+	m.lock.Lock()
+	changed := frozen != m.frozen || reason != m.reason
+	m.frozen, m.reason = frozen, reason
+	m.lock.Unlock()
+
+	metric.RecordFreeze(frozen)
+	if !changed {
+		return nil
+	}
+
+	logger := logutils.FromContext(ctx)
+	if frozen {
+		logger.Sugar().Warnf("%s is frozen for maintenance: %s", m.config.Component, reason)
+	} else {
+		logger.Sugar().Infof("%s is unfrozen", m.config.Component)
+	}
+
+	if m.config.RecordEvents && config != nil {
+		if frozen {
+			event.EventRecorder.Eventf(config, corev1.EventTypeWarning, constant.EventReasonFreeze,
+				"Spiderpool is frozen, no IP addresses are allocated until it is unfrozen: %s", reason)
+		} else {
+			event.EventRecorder.Event(config, corev1.EventTypeNormal, constant.EventReasonUnfreeze, "Spiderpool is unfrozen")
+		}
+	}
+
+	return nil
+}
+
+// FreezeOf returns whether the SpiderpoolConfiguration freezes Spiderpool,
+// along with the reason.
+func FreezeOf(config *spiderpoolv1.SpiderpoolConfiguration) (bool, string) {
+	if config == nil || config.Spec.Freeze == nil || config.Spec.Freeze.Enabled == nil || !*config.Spec.Freeze.Enabled {
+		return false, ""
+	}
+
+	reason := config.Spec.Freeze.Reason
+	if reason == "" {
+		reason = "no reason given"
+	}
+
+	return true, reason
+}
+
+// CheckFrozen returns an error matching constant.ErrFrozen if Spiderpool is
+// frozen. A nil checker is never frozen.
+func CheckFrozen(checker FreezeChecker) error {
+	if checker == nil {
+		return nil
+	}
+
+	if frozen, reason := checker.Frozen(); frozen {
+		return fmt.Errorf("%w for maintenance: %s", constant.ErrFrozen, reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package configmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("FreezeMonitor", Label("freeze_test"), func() {
+	It("inputs nil config manager", func() {
+		monitor, err := configmanager.NewFreezeMonitor(configmanager.FreezeMonitorConfig{}, nil)
+		Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+		Expect(monitor).To(BeNil())
+	})
+
+	Describe("Sync", func() {
+		var ctx context.Context
+		var configT *spiderpoolv1.SpiderpoolConfiguration
+		var monitor configmanager.FreezeMonitor
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			configT = &spiderpoolv1.SpiderpoolConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: constant.SpiderpoolConfigurationName,
+				},
+			}
+
+			var err error
+			monitor, err = configmanager.NewFreezeMonitor(configmanager.FreezeMonitorConfig{Component: "spiderpool-agent"}, configManager)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			_ = fakeClient.Delete(ctx, configT)
+		})
+
+		It("is not frozen without SpiderpoolConfiguration", func() {
+			Expect(monitor.Sync(ctx)).To(Succeed())
+			frozen, _ := monitor.Frozen()
+			Expect(frozen).To(BeFalse())
+			Expect(configmanager.CheckFrozen(monitor)).To(Succeed())
+		})
+
+		It("follows the freeze of SpiderpoolConfiguration", func() {
+			configT.Spec.Freeze = &spiderpoolv1.FreezeConfiguration{
+				Enabled: pointer.Bool(true),
+				Reason:  "etcd upgrade",
+			}
+			Expect(fakeClient.Create(ctx, configT)).To(Succeed())
+
+			Expect(monitor.Sync(ctx)).To(Succeed())
+			frozen, reason := monitor.Frozen()
+			Expect(frozen).To(BeTrue())
+			Expect(reason).To(Equal("etcd upgrade"))
+			err := configmanager.CheckFrozen(monitor)
+			Expect(err).To(MatchError(constant.ErrFrozen))
+			Expect(err.Error()).To(ContainSubstring("etcd upgrade"))
+
+			configT.Spec.Freeze.Enabled = pointer.Bool(false)
+			Expect(fakeClient.Update(ctx, configT)).To(Succeed())
+
+			Expect(monitor.Sync(ctx)).To(Succeed())
+			frozen, _ = monitor.Frozen()
+			Expect(frozen).To(BeFalse())
+		})
+	})
+
+	Describe("FreezeOf", func() {
+		It("reports a default reason", func() {
+			frozen, reason := configmanager.FreezeOf(&spiderpoolv1.SpiderpoolConfiguration{
+				Spec: spiderpoolv1.SpiderpoolConfigurationSpec{
+					Freeze: &spiderpoolv1.FreezeConfiguration{Enabled: pointer.Bool(true)},
+				},
+			})
+			Expect(frozen).To(BeTrue())
+			Expect(reason).NotTo(BeEmpty())
+		})
+
+		It("is never frozen with nil checker", func() {
+			Expect(configmanager.CheckFrozen(nil)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,65 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package configmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// ConfigmapKeys are all keys recognized in the 'conf.yml' of ConfigMap
+// spiderpool-conf, which is shared by spiderpool-controller and
+// spiderpool-agent.
+var ConfigmapKeys = []string{
+	"ipamUnixSocketPath",
+	"networkMode",
+	"enableIPv4",
+	"enableIPv6",
+	"enableStatefulSet",
+	"enableSpiderSubnet",
+	"clusterDefaultIPv4IPPool",
+	"clusterDefaultIPv6IPPool",
+	"clusterDefaultIPv4Subnet",
+	"clusterDefaultIPv6Subnet",
+	"clusterSubnetDefaultFlexibleIPNumber",
+	"clusterSubnetDefaultReclaimIPPool",
+	"applicationLabelKeys",
+	"metricHistogramBuckets",
+	"enableGatewayDetection",
+	"enableIPConflictDetection",
+	"enablePodAssignedAnnotation",
+}
+
+// ValidateConfigmapKeys checks that every top-level key of the ConfigMap data
+// is recognized, so that a misspelled key is reported instead of being
+// silently ignored.
+func ValidateConfigmapKeys(data []byte) error {
+	var m map[string]interface{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	known := make(map[string]struct{}, len(ConfigmapKeys))
+	for _, k := range ConfigmapKeys {
+		known[k] = struct{}{}
+	}
+
+	var unknown []string
+	for k := range m {
+		if _, ok := known[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown configmap keys %s", constant.ErrWrongInput, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
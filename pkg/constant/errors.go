@@ -5,6 +5,7 @@ package constant
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
@@ -12,8 +13,139 @@ var (
 	ErrNoAvailablePool  = errors.New("no IPPool available")
 	ErrRetriesExhausted = errors.New("exhaust all retries")
 	ErrIPUsedOut        = errors.New("all IP addresses used out")
+	ErrIPConflict       = errors.New("IP address conflict")
+	ErrPoolExhausted    = errors.New("pool exhausted")
+	ErrOwnerNotFound    = errors.New("owner not found")
+	ErrOverlap          = errors.New("overlap")
+	ErrPolicyDenied     = errors.New("denied by allocation policy")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
+	ErrPanic            = errors.New("recovered from panic")
+	ErrFrozen           = errors.New("spiderpool is frozen")
+	ErrOutOfWindow      = errors.New("out of allocation window")
+	ErrLossyMigration   = errors.New("lossy storage version migration")
+	ErrUnsupportedNode  = errors.New("unsupported node")
 )
 
 var ErrMissingRequiredParam = errors.New("must be specified")
 
 var ErrUnknown = errors.New("unknown")
+
+// PoolExhaustedError reports that there are not enough IP addresses left in
+// the IPPools or Subnets. It matches both ErrPoolExhausted and ErrIPUsedOut.
+type PoolExhaustedError struct {
+	// Kind is either SpiderIPPoolKind or SpiderSubnetKind.
+	Kind   string
+	Names  []string
+	Reason string
+}
+
+func (e *PoolExhaustedError) Error() string {
+	msg := fmt.Sprintf("%s: %s %v", ErrPoolExhausted, e.Kind, e.Names)
+	if e.Reason != "" {
+		msg = fmt.Sprintf("%s, %s", msg, e.Reason)
+	}
+
+	return msg
+}
+
+func (e *PoolExhaustedError) Is(target error) bool {
+	return target == ErrPoolExhausted || target == ErrIPUsedOut
+}
+
+// OwnerNotFoundError reports that the owner controller of an object does not
+// exist. It matches ErrOwnerNotFound and unwraps to the underlying error, so
+// that apierrors.IsNotFound also works.
+type OwnerNotFoundError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Err       error
+}
+
+func (e *OwnerNotFoundError) Error() string {
+	name := e.Name
+	if e.Namespace != "" {
+		name = e.Namespace + "/" + e.Name
+	}
+
+	return fmt.Sprintf("%s: %s %s: %v", ErrOwnerNotFound, e.Kind, name, e.Err)
+}
+
+func (e *OwnerNotFoundError) Is(target error) bool {
+	return target == ErrOwnerNotFound
+}
+
+func (e *OwnerNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// OverlapError reports that the CIDR or IP ranges overlap with the ones of
+// another object. It matches ErrOverlap.
+type OverlapError struct {
+	Kind   string
+	Name   string
+	Ranges []string
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("%s with %s %s in %v", ErrOverlap, e.Kind, e.Name, e.Ranges)
+}
+
+func (e *OverlapError) Is(target error) bool {
+	return target == ErrOverlap
+}
+
+// UnsupportedNodeError reports that Spiderpool refuses to allocate IP
+// addresses on the node, as its operating system is not supported. It matches
+// ErrUnsupportedNode.
+type UnsupportedNodeError struct {
+	Node string
+	OS   string
+}
+
+func (e *UnsupportedNodeError) Error() string {
+	return fmt.Sprintf("%s: Node %s runs %s, Spiderpool only allocates IP addresses on Linux nodes", ErrUnsupportedNode, e.Node, e.OS)
+}
+
+func (e *UnsupportedNodeError) Is(target error) bool {
+	return target == ErrUnsupportedNode
+}
+
+// Reasons of the error classes, which are carried in the API responses for
+// the clients to tell the classes apart.
+const (
+	ErrReasonPoolExhausted = "PoolExhausted"
+	ErrReasonOwnerNotFound = "OwnerNotFound"
+	ErrReasonOverlap       = "Overlap"
+)
+
+// ErrReasonOf returns the reason of the class of the error, empty if the
+// error belongs to none of the classes.
+func ErrReasonOf(err error) string {
+	switch {
+	case errors.Is(err, ErrPoolExhausted):
+		return ErrReasonPoolExhausted
+	case errors.Is(err, ErrOwnerNotFound):
+		return ErrReasonOwnerNotFound
+	case errors.Is(err, ErrOverlap):
+		return ErrReasonOverlap
+	default:
+		return ""
+	}
+}
+
+// ErrOfReason returns the error of the class with the reason, nil if the
+// reason is unknown.
+func ErrOfReason(reason string) error {
+	switch reason {
+	case ErrReasonPoolExhausted:
+		return ErrPoolExhausted
+	case ErrReasonOwnerNotFound:
+		return ErrOwnerNotFound
+	case ErrReasonOverlap:
+		return ErrOverlap
+	default:
+		return nil
+	}
+}
@@ -10,6 +10,20 @@ const (
 	IPv6 types.IPVersion = 6
 )
 
+// The modes to assign the IPv6 addresses of IPPools.
+const (
+	IPv6AssignmentModeRandom        = "random"
+	IPv6AssignmentModeEUI64         = "eui64"
+	IPv6AssignmentModeStablePrivacy = "stable-privacy"
+	IPv6AssignmentModeSequential    = "sequential"
+)
+
+// The modes to enforce the reservations of SpiderReservedIPs.
+const (
+	ReservedIPEnforcementHard = "hard"
+	ReservedIPEnforcementSoft = "soft"
+)
+
 const (
 	InvalidIPVersion = types.IPVersion(976)
 	InvalidCIDR      = "invalid CIDR"
@@ -17,6 +17,10 @@ const (
 
 	// For ipam plugin and spiderpool-agent use
 	DefaultIPAMUnixSocketPath = "/var/run/spidernet/spiderpool.sock"
+
+	// DefaultCNIRequestTimeout is the default timeout in seconds of the
+	// requests from ipam plugin to spiderpool-agent.
+	DefaultCNIRequestTimeout = 90
 )
 
 // Log level character string
@@ -38,6 +42,17 @@ const (
 	KindReplicaSet  string = "ReplicaSet"
 	KindJob         string = "Job"
 	KindCronJob     string = "CronJob"
+
+	KindReplicationController string = "ReplicationController"
+	KindDeploymentConfig      string = "DeploymentConfig"
+)
+
+// DeploymentConfigs of OpenShift control their Pods through
+// ReplicationControllers, they are only served by the API servers of
+// OpenShift and OKD.
+const (
+	DeploymentConfigAPIVersion = "apps.openshift.io/v1"
+	DeploymentConfigResource   = "deploymentconfigs"
 )
 
 const (
@@ -51,9 +66,20 @@ const (
 	PodUnknown      types.PodStatus = "Unknown"
 )
 
+// The priority classes of the critical system Pods, which are allowed to
+// take the IP addresses kept by 'spec.minFreeIPs' of IPPools.
+const (
+	PriorityClassSystemClusterCritical = "system-cluster-critical"
+	PriorityClassSystemNodeCritical    = "system-node-critical"
+)
+
 const (
 	AnnotationPre = "ipam.spidernet.io"
 
+	// AnnoPodConfig is a single structured document covering the IPPools,
+	// SpiderSubnets, routes and reclaim policy of the Pod, which takes
+	// precedence over the separate annotations.
+	AnnoPodConfig       = AnnotationPre + "/config"
 	AnnoPodIPPool       = AnnotationPre + "/ippool"
 	AnnoPodIPPools      = AnnotationPre + "/ippools"
 	AnnoPodRoutes       = AnnotationPre + "/routes"
@@ -62,11 +88,85 @@ const (
 	AnnoNSDefautlV4Pool = AnnotationPre + "/default-ipv4-ippool"
 	AnnoNSDefautlV6Pool = AnnotationPre + "/default-ipv6-ippool"
 
+	// AnnoPodHostPortSNAT decides whether the traffic to the hostPorts of
+	// the Pod should be SNATed to the node, so that the replies from its
+	// underlay IP addresses return through the node, "true" by default.
+	AnnoPodHostPortSNAT = AnnotationPre + "/hostport-snat"
+
+	// AnnoPodMACs maps the NICs of the Pod to their MAC addresses, in JSON,
+	// from which the IPv6 addresses of the IPPools in mode eui64 are derived.
+	AnnoPodMACs = AnnotationPre + "/macs"
+
+	// AnnoPodAssigned records the IP addresses assigned to the NICs of the
+	// Pod and their IPPools, in JSON, once the allocation completes.
+	AnnoPodAssigned = AnnotationPre + "/assigned"
+
+	// AnnoPodBandwidth is the rate limits of the Pod in JSON, hinted in the
+	// IPAM results, which override the ones of the IPPools.
+	AnnoPodBandwidth = AnnotationPre + "/bandwidth"
+
+	// AnnoPodBypassAllocationWindow set to "true" on a Pod allocates the IP
+	// addresses of the IPPools out of their allocation windows, e.g. for
+	// emergencies.
+	AnnoPodBypassAllocationWindow = AnnotationPre + "/bypass-allocation-window"
+
+	// AnnoPodDefaultRouteNIC names the NIC of the Pod holding the default
+	// route, the other NICs don't get the default routes of their IPPools.
+	AnnoPodDefaultRouteNIC = AnnotationPre + "/default-route-nic"
+
+	// AnnoDefaultRoutePolicy set on a Pod or an IPPool decides which NIC of
+	// the Pod holds the default route when multiple NICs have gateways, the
+	// one of the Pod takes precedence.
+	AnnoDefaultRoutePolicy     = AnnotationPre + "/default-route-policy"
+	DefaultRoutePolicyAll      = "all"
+	DefaultRoutePolicyFirstNIC = "first-nic"
+	DefaultRoutePolicyUnderlay = "underlay"
+
+	// AnnoPodIPFamilyOrder set on a dual-stack Pod orders the IP addresses of
+	// each NIC in the IPAM results by IP family, the first one is the primary
+	// IP address as the IP families of Services, and the NICs with gateways
+	// of the primary IP family are preferred to hold the default route.
+	AnnoPodIPFamilyOrder   = AnnotationPre + "/ip-family-order"
+	IPFamilyOrderIPv4First = "IPv4First"
+	IPFamilyOrderIPv6First = "IPv6First"
+
+	// IPPool annotation
+	AnnoIPPoolStatefulSetOrdinalIP = AnnotationPre + "/statefulset-ordinal-ip"
+	AnnoIPPoolForceDelete          = AnnotationPre + "/force-delete"
+	// AnnoIPPoolMasterInterface set on an IPPool names the host interface
+	// its Pods are attached to, the IPPool is only usable on the nodes
+	// having the interface.
+	AnnoIPPoolMasterInterface = AnnotationPre + "/master-interface"
+	// AnnoIPPoolDetach set on an auto-created IPPool stops reclaiming and
+	// resizing the IPPool, and detaches it from its application once the
+	// application is gone.
+	AnnoIPPoolDetach = AnnotationPre + "/detach"
+	// AnnoIPPoolPruneExempt set on an auto-created IPPool keeps it from
+	// being pruned after it stays idle beyond the TTL.
+	AnnoIPPoolPruneExempt = AnnotationPre + "/prune-exempt"
+	// AnnoIPPoolPolicyProjection is written by spiderpool-controller with
+	// the normalized projection of the rules of the IPPool in JSON.
+	AnnoIPPoolPolicyProjection = AnnotationPre + "/policy-projection"
+
+	// AnnoReconcile set to AnnoReconcilePaused on an application or its
+	// auto-created IPPool freezes the resizing of the IPPool.
+	AnnoReconcile       = AnnotationPre + "/reconcile"
+	AnnoReconcilePaused = "paused"
+
 	// subnet manager annotation and labels
 	AnnoSpiderSubnet              = AnnotationPre + "/subnet"
 	AnnoSpiderSubnets             = AnnotationPre + "/subnets"
 	AnnoSpiderSubnetPoolIPNumber  = AnnotationPre + "/ippool-ip-number"
 	AnnoSpiderSubnetReclaimIPPool = AnnotationPre + "/ippool-reclaim"
+	AnnoSpiderSubnetBlockSize     = AnnotationPre + "/subnet-block-size"
+
+	// AnnoSubnetBorrowGroup set on SpiderSubnets with the same IP version
+	// and VLAN lets the auto-created IPPools of an exhausted one borrow IP
+	// addresses from the others in the same group.
+	AnnoSubnetBorrowGroup = AnnotationPre + "/borrow-group"
+	// AnnoIPPoolBorrowedFor is the name of the auto-created IPPool which an
+	// IPPool borrowing IP addresses from a sibling SpiderSubnet serves.
+	AnnoIPPoolBorrowedFor = AnnotationPre + "/borrowed-for"
 
 	LabelIPPoolOwnerSpiderSubnet   = AnnotationPre + "/owner-spider-subnet"
 	LabelIPPoolOwnerApplication    = AnnotationPre + "/owner-application"
@@ -76,9 +176,55 @@ const (
 	LabelIPPoolVersionV6           = "IPv6"
 	LabelIPPoolReclaimIPPool       = AnnoSpiderSubnetReclaimIPPool
 	LabelIPPoolInterface           = AnnotationPre + "/interface"
+	// LabelIPPoolBorrowedForSubnet flags the IPPools borrowing IP addresses
+	// for the auto-created IPPools of an exhausted SpiderSubnet.
+	LabelIPPoolBorrowedForSubnet = AnnotationPre + "/borrowed-for-subnet"
+	// LabelIPPoolShardOf is the name of the IPPool which a SpiderIPPoolShard
+	// holds the IP allocation details of.
+	LabelIPPoolShardOf = AnnotationPre + "/ippool-shard-of"
+	// LabelIPPoolPreProvisioned flags the auto-created IPPools created ahead
+	// of their applications, they are adopted by the applications once
+	// created.
+	LabelIPPoolPreProvisioned = AnnotationPre + "/preprovisioned"
+
+	// LabelNodeIPPoolPrefix prefixes the names of the IPPools usable on the
+	// node in its labels, e.g. 'ippool.ipam.spidernet.io/default-v4-ippool'.
+	LabelNodeIPPoolPrefix = "ippool." + AnnotationPre + "/"
+
+	// LabelSubnetTenant is the name of the SpiderTenant which a SpiderSubnet
+	// belongs to.
+	LabelSubnetTenant = AnnotationPre + "/tenant"
+
+	// LabelSubnetDiscoverySource flags the SpiderSubnet drafts created by
+	// spiderpool-agent from the discovery of node interfaces, with the source
+	// of the discovery.
+	LabelSubnetDiscoverySource = AnnotationPre + "/discovery-source"
+	// AnnoSubnetDiscoveredBy is the '<node>/<interface>' where the segment of
+	// a SpiderSubnet draft was discovered.
+	AnnoSubnetDiscoveredBy = AnnotationPre + "/discovered-by"
 
 	LabelSubnetCIDR = AnnotationPre + "/subnet-cidr"
 	LabelIPPoolCIDR = AnnotationPre + "/ippool-cidr"
+
+	// network test labels
+	LabelNetworkTest = AnnotationPre + "/network-test"
+
+	// AnnoCNIConfPriority set on a NetworkAttachmentDefinition makes
+	// spiderpool-agent render its config into the CNI config directory of
+	// each node, ordered by the priority in [0, 99], the lower the first.
+	AnnoCNIConfPriority = AnnotationPre + "/cni-conf-priority"
+
+	// AnnoNetworkAttachmentDefinition set on a SpiderSubnet or an IPPool
+	// binds it to the Multus network '<namespace>/<name>', which is checked
+	// to exist with a compatible CNI type at admission.
+	AnnoNetworkAttachmentDefinition = AnnotationPre + "/network-attachment-definition"
+
+	// AnnoInstalledVersion records the version of spiderpool-controller which
+	// installed the CRD or webhook configuration.
+	AnnoInstalledVersion = AnnotationPre + "/installed-version"
+
+	// Multus annotation
+	AnnoMultusNetworks = "k8s.v1.cni.cncf.io/networks"
 )
 
 const (
@@ -96,6 +242,28 @@ const (
 	SpiderEndpointListKind   = "SpiderEndpointList"
 	SpiderReservedIPListKind = "SpiderReservedIPList"
 	SpiderSubnetListKind     = "SpiderSubnetList"
+
+	SpiderpoolConfigurationKind = "SpiderpoolConfiguration"
+	SpiderNetworkTestKind       = "SpiderNetworkTest"
+	SpiderCoordinatorKind       = "SpiderCoordinator"
+	SpiderTenantKind            = "SpiderTenant"
+	SpiderIPPoolShardKind       = "SpiderIPPoolShard"
+	SpiderWebhookReportKind     = "SpiderWebhookReport"
+	// SpiderpoolConfigurationName is the name of the cluster-wide singleton
+	// SpiderpoolConfiguration read by Spiderpool components.
+	SpiderpoolConfigurationName = "default"
+	// SpiderCoordinatorName is the name of the cluster-wide singleton
+	// SpiderCoordinator reconciled by spiderpool-controller.
+	SpiderCoordinatorName = "default"
+	// SpiderWebhookReportName is the name of the cluster-wide singleton
+	// SpiderWebhookReport aggregating the denials of the webhooks.
+	SpiderWebhookReportName = "default"
+	// SpiderpoolAPIVersionV2beta1 is the version serving the SpiderIPPools
+	// with the range-encoded IP allocation details, converted from v1.
+	SpiderpoolAPIVersionV2beta1 = "v2beta1"
+	// SpiderIPPoolCRDName is the name of the SpiderIPPool CRD, whose versions
+	// are converted by the conversion webhook of spiderpool-controller.
+	SpiderIPPoolCRDName = "spiderippools." + SpiderpoolAPIGroup
 )
 
 const (
@@ -112,6 +280,91 @@ const (
 	EventReasonScaleIPPool  = "ScaleIPPool"
 	EventReasonDeleteIPPool = "DeleteIPPool"
 	EventReasonResyncSubnet = "ResyncSubnet"
+	EventReasonIPConflict   = "IPConflict"
+
+	EventReasonSubnetNotFound = "SubnetNotFound"
+	EventReasonAdoptIPPool    = "AdoptIPPool"
+	EventReasonDetachIPPool   = "DetachIPPool"
+	EventReasonPruneIPPool    = "PruneIPPool"
+	EventReasonBorrowIPs      = "BorrowIPs"
+	EventReasonReturnIPs      = "ReturnIPs"
+
+	EventReasonReleaseEgressIP = "ReleaseEgressIP"
+
+	EventReasonVLANParentNotReady = "VLANParentNotReady"
+
+	EventReasonInvalidNetworkAttachment = "InvalidNetworkAttachment"
+
+	EventReasonSubnetDiscovered = "SubnetDiscovered"
+
+	EventReasonPanic = "Panic"
+
+	EventReasonFreeze   = "Freeze"
+	EventReasonUnfreeze = "Unfreeze"
+
+	EventReasonAllocationDeferred = "AllocationDeferred"
+
+	EventReasonReservedIPExpiring = "ReservedIPExpiring"
+	EventReasonReservedIPExpired  = "ReservedIPExpired"
+	EventReasonSoftReservedIP     = "SoftReservedIP"
+
+	EventReasonSubnetPairMismatch = "SubnetPairMismatch"
+)
+
+// ReasonIPPoolExhausted is the reason of the admission failure of Pods whose
+// candidate IPPools are all exhausted.
+const ReasonIPPoolExhausted = "IPPoolExhausted"
+
+// IPPoolConditionReconcilePaused indicates that the resizing of the
+// auto-created IPPool is paused, with the reason telling whether it is paused
+// by the annotation of the application or the IPPool itself.
+const (
+	IPPoolConditionReconcilePaused = "ReconcilePaused"
+
+	ReasonApplicationPaused = "ApplicationPaused"
+	ReasonIPPoolPaused      = "IPPoolPaused"
+)
+
+// IPPoolConditionExhaustionImminent indicates that the IPPool is forecast to
+// be exhausted within the threshold with its allocation rate.
+const (
+	IPPoolConditionExhaustionImminent = "ExhaustionImminent"
+
+	ReasonAllocationRate = "AllocationRate"
+)
+
+// IPPoolConditionIdle indicates that the auto-created IPPool has no IP
+// addresses allocated while its application is gone or scaled to zero, the
+// last transition time of the condition tells how long it has been idle.
+const (
+	IPPoolConditionIdle = "Idle"
+
+	ReasonApplicationGone = "ApplicationGone"
+	ReasonZeroReplicas    = "ZeroReplicas"
+)
+
+// IPPoolConditionFragmented indicates that the free IP addresses of the
+// auto-created IPPool are split into too many small ranges.
+const (
+	IPPoolConditionFragmented = "Fragmented"
+
+	ReasonFreeRanges = "FreeRanges"
+)
+
+// NodeConditionSpiderpoolUnsupported indicates that Spiderpool does not
+// allocate IP addresses to the Pods on the node, e.g. a Windows node in the
+// mixed-OS cluster.
+const (
+	NodeConditionSpiderpoolUnsupported = "SpiderpoolUnsupported"
+
+	ReasonWindowsNode = "WindowsNode"
 )
 
 const ClusterDefaultInterfaceName = "eth0"
+
+// UseCache and IgnoreCache tell the managers whether to read the object from
+// the cache of the runtime manager or from the API server.
+const (
+	UseCache    = true
+	IgnoreCache = false
+)
@@ -0,0 +1,33 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"time"
+)
+
+const (
+	defaultResyncPeriod  = time.Minute
+	defaultConfigMapName = "spiderpool-coordinator"
+)
+
+type CoordinatorReconcilerConfig struct {
+	ResyncPeriod time.Duration
+	// ConfigMapNamespace and ConfigMapName locate the ConfigMap holding the
+	// network configuration snippet of the coordinator plugin.
+	ConfigMapNamespace string
+	ConfigMapName      string
+}
+
+func setDefaultsForCoordinatorReconcilerConfig(config CoordinatorReconcilerConfig) CoordinatorReconcilerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	if config.ConfigMapName == "" {
+		config.ConfigMapName = defaultConfigMapName
+	}
+
+	return config
+}
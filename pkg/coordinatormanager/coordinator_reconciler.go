@@ -0,0 +1,367 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+const (
+	// ConfigMapKey is the key of the coordinator plugin configuration in the
+	// ConfigMap reconciled from the SpiderCoordinator.
+	ConfigMapKey = "coordinator.json"
+
+	clusterCIDRFlag = "--cluster-cidr"
+	serviceCIDRFlag = "--service-cluster-ip-range"
+)
+
+// CoordinatorReconciler reconciles the SpiderCoordinator into a ConfigMap
+// holding the network configuration snippet of the coordinator plugin. The
+// CIDRs of Pods and Services are detected from the cluster if unset.
+type CoordinatorReconciler interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+}
+
+type coordinatorReconciler struct {
+	config    CoordinatorReconcilerConfig
+	client    client.Client
+	apiReader client.Reader
+	leader    election.SpiderLeaseElector
+}
+
+// NewCoordinatorReconciler creates the reconciler, the static Pods of control
+// plane components are read with apiReader, for they are in host network and
+// may not be cached.
+func NewCoordinatorReconciler(config CoordinatorReconcilerConfig, client client.Client, apiReader client.Reader, leader election.SpiderLeaseElector) (CoordinatorReconciler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &coordinatorReconciler{
+		config:    setDefaultsForCoordinatorReconcilerConfig(config),
+		client:    client,
+		apiReader: apiReader,
+		leader:    leader,
+	}, nil
+}
+
+// Start reconciles the SpiderCoordinator periodically until the context is
+// done. Only the leader of spiderpool-controller reconciles it.
+func (r *coordinatorReconciler) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if r.leader.IsElected() {
+				err := recovery.Call(ctx, "Coordinator-Reconciler", nil, func() error { return r.Reconcile(ctx) })
+				if err != nil {
+					logger.Sugar().Errorf("Failed to reconcile SpiderCoordinator: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Reconcile syncs the SpiderCoordinator named 'default' into the ConfigMap.
+// It does nothing if the SpiderCoordinator does not exist.
+func (r *coordinatorReconciler) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var coordinator spiderpoolv1.SpiderCoordinator
+	if err := r.client.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderCoordinatorName}, &coordinator); err != nil {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get SpiderCoordinator %s: %w", constant.SpiderCoordinatorName, err)
+	}
+	if coordinator.DeletionTimestamp != nil {
+		return nil
+	}
+
+	status := spiderpoolv1.CoordinatorStatus{
+		Phase:              spiderpoolv1.CoordinatorSynced,
+		PodCIDRs:           coordinator.Spec.PodCIDRs,
+		ServiceCIDRs:       coordinator.Spec.ServiceCIDRs,
+		ObservedGeneration: coordinator.Generation,
+	}
+
+	var err error
+	if len(status.PodCIDRs) == 0 {
+		if status.PodCIDRs, err = r.detectPodCIDRs(ctx); err != nil {
+			return r.updateStatus(ctx, &coordinator, notReady(status, err))
+		}
+	}
+	if len(status.ServiceCIDRs) == 0 {
+		if status.ServiceCIDRs, err = r.detectServiceCIDRs(ctx); err != nil {
+			return r.updateStatus(ctx, &coordinator, notReady(status, err))
+		}
+	}
+
+	data, err := GenerateCoordinatorConf(&coordinator, status.PodCIDRs, status.ServiceCIDRs)
+	if err != nil {
+		return r.updateStatus(ctx, &coordinator, notReady(status, err))
+	}
+	if err := r.applyConfigMap(ctx, data); err != nil {
+		return err
+	}
+	status.ConfigMap = r.config.ConfigMapNamespace + "/" + r.config.ConfigMapName
+	logger.Sugar().Debugf("Succeed to sync SpiderCoordinator %s into ConfigMap %s", coordinator.Name, status.ConfigMap)
+
+	return r.updateStatus(ctx, &coordinator, status)
+}
+
+func notReady(status spiderpoolv1.CoordinatorStatus, err error) spiderpoolv1.CoordinatorStatus {
+	status.Phase = spiderpoolv1.CoordinatorNotReady
+	status.Reason = err.Error()
+
+	return status
+}
+
+func (r *coordinatorReconciler) updateStatus(ctx context.Context, coordinator *spiderpoolv1.SpiderCoordinator, status spiderpoolv1.CoordinatorStatus) error {
+	if status.Phase == spiderpoolv1.CoordinatorNotReady {
+		// Keep the ConfigMap synced last time, it's still in effect.
+		status.ConfigMap = coordinator.Status.ConfigMap
+	}
+	if equalStatus(coordinator.Status, status) {
+		return nil
+	}
+
+	coordinator.Status = status
+	if err := r.client.Status().Update(ctx, coordinator); err != nil {
+		return fmt.Errorf("failed to update the status of SpiderCoordinator %s: %w", coordinator.Name, err)
+	}
+
+	return nil
+}
+
+func equalStatus(a, b spiderpoolv1.CoordinatorStatus) bool {
+	return a.Phase == b.Phase &&
+		a.Reason == b.Reason &&
+		a.ConfigMap == b.ConfigMap &&
+		a.ObservedGeneration == b.ObservedGeneration &&
+		strings.Join(a.PodCIDRs, ",") == strings.Join(b.PodCIDRs, ",") &&
+		strings.Join(a.ServiceCIDRs, ",") == strings.Join(b.ServiceCIDRs, ",")
+}
+
+func (r *coordinatorReconciler) applyConfigMap(ctx context.Context, data string) error {
+	var cm corev1.ConfigMap
+	err := r.client.Get(ctx, apitypes.NamespacedName{Namespace: r.config.ConfigMapNamespace, Name: r.config.ConfigMapName}, &cm)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get ConfigMap %s/%s: %w", r.config.ConfigMapNamespace, r.config.ConfigMapName, err)
+		}
+
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.config.ConfigMapNamespace,
+				Name:      r.config.ConfigMapName,
+			},
+			Data: map[string]string{ConfigMapKey: data},
+		}
+		if err := r.client.Create(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s/%s: %w", r.config.ConfigMapNamespace, r.config.ConfigMapName, err)
+		}
+		return nil
+	}
+
+	if cm.Data[ConfigMapKey] == data {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[ConfigMapKey] = data
+	if err := r.client.Update(ctx, &cm); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s/%s: %w", r.config.ConfigMapNamespace, r.config.ConfigMapName, err)
+	}
+
+	return nil
+}
+
+// detectPodCIDRs detects the CIDRs of Pods with the flag '--cluster-cidr' of
+// kube-controller-manager, or the union of the podCIDRs of Nodes.
+func (r *coordinatorReconciler) detectPodCIDRs(ctx context.Context) ([]string, error) {
+	cidrs, err := r.detectFlagFromControlPlane(ctx, clusterCIDRFlag, "kube-controller-manager")
+	if err != nil {
+		return nil, err
+	}
+	if len(cidrs) != 0 {
+		return cidrs, nil
+	}
+
+	var nodeList corev1.NodeList
+	if err := r.client.List(ctx, &nodeList); err != nil {
+		return nil, fmt.Errorf("failed to list Nodes: %w", err)
+	}
+
+	set := map[string]struct{}{}
+	for _, node := range nodeList.Items {
+		podCIDRs := node.Spec.PodCIDRs
+		if len(podCIDRs) == 0 && node.Spec.PodCIDR != "" {
+			podCIDRs = []string{node.Spec.PodCIDR}
+		}
+		for _, cidr := range podCIDRs {
+			set[cidr] = struct{}{}
+		}
+	}
+	for cidr := range set {
+		cidrs = append(cidrs, cidr)
+	}
+	if len(cidrs) == 0 {
+		return nil, fmt.Errorf("failed to detect the CIDRs of Pods, specify them in spec.podCIDRs")
+	}
+	sort.Strings(cidrs)
+
+	return cidrs, nil
+}
+
+// detectServiceCIDRs detects the CIDRs of Services with the flag
+// '--service-cluster-ip-range' of kube-apiserver or kube-controller-manager.
+func (r *coordinatorReconciler) detectServiceCIDRs(ctx context.Context) ([]string, error) {
+	cidrs, err := r.detectFlagFromControlPlane(ctx, serviceCIDRFlag, "kube-apiserver", "kube-controller-manager")
+	if err != nil {
+		return nil, err
+	}
+	if len(cidrs) == 0 {
+		return nil, fmt.Errorf("failed to detect the CIDRs of Services, specify them in spec.serviceCIDRs")
+	}
+
+	return cidrs, nil
+}
+
+// detectFlagFromControlPlane looks up the CIDRs of the flag in the commands
+// of the static Pods of control plane components in kube-system.
+func (r *coordinatorReconciler) detectFlagFromControlPlane(ctx context.Context, flag string, components ...string) ([]string, error) {
+	for _, component := range components {
+		var podList corev1.PodList
+		if err := r.apiReader.List(ctx, &podList,
+			client.InNamespace(metav1.NamespaceSystem),
+			client.MatchingLabels{"component": component},
+		); err != nil {
+			return nil, fmt.Errorf("failed to list %s Pods: %w", component, err)
+		}
+
+		for _, pod := range podList.Items {
+			for _, c := range pod.Spec.Containers {
+				args := append(append([]string{}, c.Command...), c.Args...)
+				if cidrs, ok, err := parseCIDRsFlag(args, flag); ok {
+					return cidrs, err
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// parseCIDRsFlag parses the comma-separated CIDRs of the flag from the
+// arguments.
+func parseCIDRsFlag(args []string, flag string) ([]string, bool, error) {
+	for _, arg := range args {
+		value, found := strings.CutPrefix(arg, flag+"=")
+		if !found {
+			continue
+		}
+
+		var cidrs []string
+		for _, cidr := range strings.Split(value, ",") {
+			cidr = strings.TrimSpace(cidr)
+			if cidr == "" {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return nil, true, fmt.Errorf("invalid CIDR %q of flag %s: %w", cidr, flag, err)
+			}
+			cidrs = append(cidrs, cidr)
+		}
+		return cidrs, true, nil
+	}
+
+	return nil, false, nil
+}
+
+type coordinatorConf struct {
+	Type              string   `json:"type"`
+	Mode              string   `json:"mode"`
+	PodCIDRs          []string `json:"podCIDRs"`
+	ServiceCIDRs      []string `json:"serviceCIDRs"`
+	HijackCustomCIDRs []string `json:"hijackCustomCIDRs,omitempty"`
+	TunePodRoutes     bool     `json:"tunePodRoutes"`
+	HostRuleTable     int64    `json:"hostRuleTable"`
+	HostRPFilter      int64    `json:"hostRPFilter"`
+	HostPort          bool     `json:"hostPort"`
+	// Capabilities asks the runtime for the portMappings of Pods, to
+	// forward their hostPorts.
+	Capabilities map[string]bool `json:"capabilities,omitempty"`
+}
+
+// GenerateCoordinatorConf generates the network configuration snippet of the
+// coordinator plugin, to be appended to the plugin chain of NADs.
+func GenerateCoordinatorConf(coordinator *spiderpoolv1.SpiderCoordinator, podCIDRs, serviceCIDRs []string) (string, error) {
+	for _, cidrs := range [][]string{podCIDRs, serviceCIDRs, coordinator.Spec.HijackCustomCIDRs} {
+		for _, cidr := range cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return "", fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+			}
+		}
+	}
+
+	conf := coordinatorConf{
+		Type:              "coordinator",
+		Mode:              pointer.StringDeref(coordinator.Spec.Mode, "auto"),
+		PodCIDRs:          podCIDRs,
+		ServiceCIDRs:      serviceCIDRs,
+		HijackCustomCIDRs: coordinator.Spec.HijackCustomCIDRs,
+		TunePodRoutes:     pointer.BoolDeref(coordinator.Spec.TunePodRoutes, true),
+		HostRuleTable:     pointer.Int64Deref(coordinator.Spec.HostRuleTable, 500),
+		HostRPFilter:      pointer.Int64Deref(coordinator.Spec.HostRPFilter, 0),
+		HostPort:          pointer.BoolDeref(coordinator.Spec.HostPort, false),
+	}
+	if conf.HostPort {
+		conf.Capabilities = map[string]bool{"portMappings": true}
+	}
+
+	data, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package coordinatormanager_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/coordinatormanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("CoordinatorReconciler", Label("coordinator_reconciler_test"), func() {
+	Describe("New CoordinatorReconciler", func() {
+		It("inputs nil client", func() {
+			reconciler, err := coordinatormanager.NewCoordinatorReconciler(coordinatormanager.CoordinatorReconcilerConfig{}, nil, fakeClient, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+
+		It("inputs nil API reader", func() {
+			reconciler, err := coordinatormanager.NewCoordinatorReconciler(coordinatormanager.CoordinatorReconcilerConfig{}, fakeClient, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			reconciler, err := coordinatormanager.NewCoordinatorReconciler(coordinatormanager.CoordinatorReconcilerConfig{}, fakeClient, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var ctx context.Context
+		var objs []client.Object
+		var coordinatorT *spiderpoolv1.SpiderCoordinator
+		var reconciler coordinatormanager.CoordinatorReconciler
+
+		const cmNamespace = "spiderpool"
+		const cmName = "coordinator"
+
+		create := func(obj client.Object) {
+			err := fakeClient.Create(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			objs = append(objs, obj)
+		}
+
+		getCoordinator := func() *spiderpoolv1.SpiderCoordinator {
+			var coordinator spiderpoolv1.SpiderCoordinator
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderCoordinatorName}, &coordinator)
+			Expect(err).NotTo(HaveOccurred())
+			return &coordinator
+		}
+
+		getConf := func() map[string]interface{} {
+			var cm corev1.ConfigMap
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: cmNamespace, Name: cmName}, &cm)
+			Expect(err).NotTo(HaveOccurred())
+
+			conf := map[string]interface{}{}
+			err = json.Unmarshal([]byte(cm.Data[coordinatormanager.ConfigMapKey]), &conf)
+			Expect(err).NotTo(HaveOccurred())
+			return conf
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			objs = nil
+
+			var err error
+			reconciler, err = coordinatormanager.NewCoordinatorReconciler(
+				coordinatormanager.CoordinatorReconcilerConfig{
+					ConfigMapNamespace: cmNamespace,
+					ConfigMapName:      cmName,
+				},
+				fakeClient,
+				fakeClient,
+				fakeLeader{},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			coordinatorT = &spiderpoolv1.SpiderCoordinator{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: constant.SpiderCoordinatorName,
+				},
+				Spec: spiderpoolv1.CoordinatorSpec{
+					Mode: pointer.String("underlay"),
+				},
+			}
+
+			DeferCleanup(func() {
+				for _, obj := range objs {
+					err := fakeClient.Delete(ctx, obj)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				}
+				err := fakeClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: cmNamespace, Name: cmName}})
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			})
+		})
+
+		It("does nothing without SpiderCoordinator", func() {
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			var cm corev1.ConfigMap
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: cmNamespace, Name: cmName}, &cm)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("syncs the specified CIDRs into the ConfigMap", func() {
+			coordinatorT.Spec.PodCIDRs = []string{"10.244.0.0/16"}
+			coordinatorT.Spec.ServiceCIDRs = []string{"10.96.0.0/12"}
+			coordinatorT.Spec.HijackCustomCIDRs = []string{"169.254.0.0/16"}
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			coordinator := getCoordinator()
+			Expect(coordinator.Status.Phase).To(Equal(spiderpoolv1.CoordinatorSynced))
+			Expect(coordinator.Status.ConfigMap).To(Equal(cmNamespace + "/" + cmName))
+			Expect(coordinator.Status.PodCIDRs).To(Equal([]string{"10.244.0.0/16"}))
+			Expect(coordinator.Status.ServiceCIDRs).To(Equal([]string{"10.96.0.0/12"}))
+
+			conf := getConf()
+			Expect(conf).To(HaveKeyWithValue("type", "coordinator"))
+			Expect(conf).To(HaveKeyWithValue("mode", "underlay"))
+			Expect(conf).To(HaveKeyWithValue("tunePodRoutes", true))
+			Expect(conf).To(HaveKeyWithValue("hostRuleTable", BeNumerically("==", 500)))
+			Expect(conf).To(HaveKeyWithValue("hijackCustomCIDRs", ConsistOf("169.254.0.0/16")))
+			Expect(conf).To(HaveKeyWithValue("hostPort", false))
+			Expect(conf).NotTo(HaveKey("capabilities"))
+		})
+
+		It("asks for the portMappings with hostPort enabled", func() {
+			coordinatorT.Spec.PodCIDRs = []string{"10.244.0.0/16"}
+			coordinatorT.Spec.ServiceCIDRs = []string{"10.96.0.0/12"}
+			coordinatorT.Spec.HostPort = pointer.Bool(true)
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			conf := getConf()
+			Expect(conf).To(HaveKeyWithValue("hostPort", true))
+			Expect(conf).To(HaveKeyWithValue("capabilities", HaveKeyWithValue("portMappings", true)))
+		})
+
+		It("updates the ConfigMap on changes", func() {
+			coordinatorT.Spec.PodCIDRs = []string{"10.244.0.0/16"}
+			coordinatorT.Spec.ServiceCIDRs = []string{"10.96.0.0/12"}
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			coordinator := getCoordinator()
+			coordinator.Spec.HostRuleTable = pointer.Int64(1000)
+			err = fakeClient.Update(ctx, coordinator)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getConf()).To(HaveKeyWithValue("hostRuleTable", BeNumerically("==", 1000)))
+		})
+
+		It("detects the CIDRs from the control plane", func() {
+			create(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceSystem,
+					Name:      "kube-controller-manager-master",
+					Labels:    map[string]string{"component": "kube-controller-manager"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "kube-controller-manager",
+						Command: []string{
+							"kube-controller-manager",
+							"--cluster-cidr=10.244.0.0/16,fd00:10:244::/56",
+							"--service-cluster-ip-range=10.96.0.0/12",
+						},
+					}},
+				},
+			})
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			coordinator := getCoordinator()
+			Expect(coordinator.Status.Phase).To(Equal(spiderpoolv1.CoordinatorSynced))
+			Expect(coordinator.Status.PodCIDRs).To(Equal([]string{"10.244.0.0/16", "fd00:10:244::/56"}))
+			Expect(coordinator.Status.ServiceCIDRs).To(Equal([]string{"10.96.0.0/12"}))
+			Expect(getConf()).To(HaveKeyWithValue("podCIDRs", ConsistOf("10.244.0.0/16", "fd00:10:244::/56")))
+		})
+
+		It("detects the CIDRs of Pods from Nodes", func() {
+			create(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+				Spec:       corev1.NodeSpec{PodCIDRs: []string{"10.244.1.0/24"}},
+			})
+			create(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Spec:       corev1.NodeSpec{PodCIDRs: []string{"10.244.0.0/24"}},
+			})
+			coordinatorT.Spec.ServiceCIDRs = []string{"10.96.0.0/12"}
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getCoordinator().Status.PodCIDRs).To(Equal([]string{"10.244.0.0/24", "10.244.1.0/24"}))
+		})
+
+		It("is not ready if the CIDRs cannot be detected", func() {
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			coordinator := getCoordinator()
+			Expect(coordinator.Status.Phase).To(Equal(spiderpoolv1.CoordinatorNotReady))
+			Expect(coordinator.Status.Reason).To(ContainSubstring("spec.podCIDRs"))
+		})
+
+		It("is not ready with invalid CIDRs", func() {
+			coordinatorT.Spec.PodCIDRs = []string{"10.244.0.0/16"}
+			coordinatorT.Spec.ServiceCIDRs = []string{"10.96.0.0/12"}
+			coordinatorT.Spec.HijackCustomCIDRs = []string{"invalid"}
+			create(coordinatorT)
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			coordinator := getCoordinator()
+			Expect(coordinator.Status.Phase).To(Equal(spiderpoolv1.CoordinatorNotReady))
+			Expect(coordinator.Status.Reason).To(ContainSubstring("invalid"))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package crdmanager
+
+import (
+	"time"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+const (
+	defaultFieldManager     = constant.SpiderpoolController
+	defaultEstablishTimeout = 60 * time.Second
+)
+
+type CRDInstallerConfig struct {
+	// Version is the version of spiderpool-controller recorded on the CRDs.
+	Version string
+	// FieldManager is the field manager of server-side apply.
+	FieldManager     string
+	EstablishTimeout time.Duration
+}
+
+func setDefaultsForCRDInstallerConfig(config CRDInstallerConfig) CRDInstallerConfig {
+	if config.FieldManager == "" {
+		config.FieldManager = defaultFieldManager
+	}
+
+	if config.EstablishTimeout <= 0 {
+		config.EstablishTimeout = defaultEstablishTimeout
+	}
+
+	return config
+}
+
+const (
+	defaultMigrationResyncPeriod = 10 * time.Minute
+	defaultMigrationPageSize     = 500
+)
+
+type StorageVersionMigratorConfig struct {
+	// ResyncPeriod is the period to check whether the CRDs of Spiderpool
+	// have objects stored with the versions other than the storage version.
+	ResyncPeriod time.Duration
+	// PageSize is the number of objects listed per request.
+	PageSize int64
+}
+
+func setDefaultsForStorageVersionMigratorConfig(config StorageVersionMigratorConfig) StorageVersionMigratorConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultMigrationResyncPeriod
+	}
+
+	if config.PageSize <= 0 {
+		config.PageSize = defaultMigrationPageSize
+	}
+
+	return config
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package crdmanager
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// crdFS holds the CRDs of Spiderpool, which are copied from the Helm chart by
+// 'make manifests'.
+//
+//go:embed crds/*.yaml
+var crdFS embed.FS
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// CRDInstaller installs or upgrades the CRDs of Spiderpool with server-side
+// apply, so that spiderpool-controller could be deployed without Helm hooks.
+type CRDInstaller interface {
+	Install(ctx context.Context) error
+}
+
+type crdInstaller struct {
+	config CRDInstallerConfig
+	client client.Client
+}
+
+func NewCRDInstaller(config CRDInstallerConfig, client client.Client) (CRDInstaller, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &crdInstaller{
+		config: setDefaultsForCRDInstallerConfig(config),
+		client: client,
+	}, nil
+}
+
+// Install applies all CRDs of Spiderpool and waits for them to be
+// established. The CRD installed by a newer version of spiderpool-controller
+// is skipped, so that a rolling back or lagging replica never downgrades it.
+func (i *crdInstaller) Install(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	crds, err := CRDs()
+	if err != nil {
+		return err
+	}
+
+	for _, crd := range crds {
+		var existing unstructured.Unstructured
+		existing.SetGroupVersionKind(crdGVK)
+		if err := i.client.Get(ctx, apitypes.NamespacedName{Name: crd.GetName()}, &existing); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get CRD %s: %w", crd.GetName(), err)
+			}
+		} else if installed := existing.GetAnnotations()[constant.AnnoInstalledVersion]; IsNewerVersion(installed, i.config.Version) {
+			logger.Sugar().Infof("CRD %s is installed by newer version %s, skip to apply it with version %s", crd.GetName(), installed, i.config.Version)
+			continue
+		}
+
+		annotations := crd.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[constant.AnnoInstalledVersion] = i.config.Version
+		crd.SetAnnotations(annotations)
+
+		if err := i.client.Patch(ctx, crd, client.Apply, client.FieldOwner(i.config.FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to apply CRD %s: %w", crd.GetName(), err)
+		}
+		logger.Sugar().Infof("Succeed to apply CRD %s with version %s", crd.GetName(), i.config.Version)
+	}
+
+	for _, crd := range crds {
+		if err := i.waitForEstablished(ctx, crd.GetName()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *crdInstaller) waitForEstablished(ctx context.Context, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, i.config.EstablishTimeout)
+	defer cancel()
+
+	err := wait.PollImmediateUntilWithContext(ctx, time.Second, func(ctx context.Context) (bool, error) {
+		var crd unstructured.Unstructured
+		crd.SetGroupVersionKind(crdGVK)
+		if err := i.client.Get(ctx, apitypes.NamespacedName{Name: name}, &crd); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if ok && condition["type"] == "Established" && condition["status"] == "True" {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for CRD %s to be established: %w", name, err)
+	}
+
+	return nil
+}
+
+// CRDs returns the CRDs of Spiderpool embedded in the binary.
+func CRDs() ([]*unstructured.Unstructured, error) {
+	entries, err := crdFS.ReadDir("crds")
+	if err != nil {
+		return nil, err
+	}
+
+	var crds []*unstructured.Unstructured
+	for _, entry := range entries {
+		data, err := crdFS.ReadFile(path.Join("crds", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+		for {
+			obj := &unstructured.Unstructured{}
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, fmt.Errorf("failed to decode CRD file %s: %w", entry.Name(), err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			if obj.GroupVersionKind() != crdGVK {
+				return nil, fmt.Errorf("unexpected object %s in CRD file %s", obj.GroupVersionKind(), entry.Name())
+			}
+
+			// The fields generated by controller-gen are not supposed to be applied.
+			unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+			unstructured.RemoveNestedField(obj.Object, "status")
+			crds = append(crds, obj)
+		}
+	}
+
+	return crds, nil
+}
+
+// IsNewerVersion reports whether the semantic version installed is newer
+// than the current one. The versions which aren't semantic, e.g. the commit
+// of development builds, are never considered newer.
+func IsNewerVersion(installed, current string) bool {
+	if installed == "" || installed == current {
+		return false
+	}
+
+	installedVersion, err := version.ParseSemantic(installed)
+	if err != nil {
+		return false
+	}
+	currentVersion, err := version.ParseSemantic(current)
+	if err != nil {
+		return false
+	}
+
+	return currentVersion.LessThan(installedVersion)
+}
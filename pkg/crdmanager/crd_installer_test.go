@@ -0,0 +1,149 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package crdmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/crdmanager"
+)
+
+// applyClient records the objects applied with server-side apply, which the
+// fake client doesn't support, and creates them as the established ones.
+type applyClient struct {
+	client.Client
+	applied []string
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch != client.Apply {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	c.applied = append(c.applied, obj.GetName())
+
+	u := obj.(*unstructured.Unstructured).DeepCopy()
+	conditions := []interface{}{map[string]interface{}{"type": "Established", "status": "True"}}
+	if err := unstructured.SetNestedSlice(u.Object, conditions, "status", "conditions"); err != nil {
+		return err
+	}
+	if err := c.Client.Delete(ctx, u); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	u.SetResourceVersion("")
+
+	return c.Client.Create(ctx, u)
+}
+
+var _ = Describe("CRDInstaller", Label("crd_installer_test"), func() {
+	Describe("New CRDInstaller", func() {
+		It("inputs nil client", func() {
+			installer, err := crdmanager.NewCRDInstaller(crdmanager.CRDInstallerConfig{}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(installer).To(BeNil())
+		})
+	})
+
+	Describe("CRDs", func() {
+		It("decodes all embedded CRDs", func() {
+			crds, err := crdmanager.CRDs()
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, crd := range crds {
+				names = append(names, crd.GetName())
+				Expect(crd.GetKind()).To(Equal("CustomResourceDefinition"))
+				_, found, _ := unstructured.NestedFieldNoCopy(crd.Object, "status")
+				Expect(found).To(BeFalse())
+			}
+			Expect(names).To(ConsistOf(
+				"spidercoordinators.spiderpool.spidernet.io",
+				"spiderendpoints.spiderpool.spidernet.io",
+				"spiderippools.spiderpool.spidernet.io",
+				"spiderippoolshards.spiderpool.spidernet.io",
+				"spidernetworktests.spiderpool.spidernet.io",
+				"spiderpoolconfigurations.spiderpool.spidernet.io",
+				"spiderreservedips.spiderpool.spidernet.io",
+				"spidersubnets.spiderpool.spidernet.io",
+				"spidertenants.spiderpool.spidernet.io",
+				"spiderwebhookreports.spiderpool.spidernet.io",
+			))
+		})
+	})
+
+	Describe("Install", func() {
+		var c *applyClient
+		var installer crdmanager.CRDInstaller
+
+		BeforeEach(func() {
+			c = &applyClient{
+				Client: fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build(),
+			}
+
+			var err error
+			installer, err = crdmanager.NewCRDInstaller(
+				crdmanager.CRDInstallerConfig{
+					Version:          "v0.4.0",
+					EstablishTimeout: 5 * time.Second,
+				},
+				c,
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies all CRDs with the installed version", func() {
+			ctx := context.TODO()
+			err := installer.Install(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.applied).To(HaveLen(10))
+
+			crds, err := crdmanager.CRDs()
+			Expect(err).NotTo(HaveOccurred())
+			for _, crd := range crds {
+				err := c.Get(ctx, client.ObjectKeyFromObject(crd), crd)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(crd.GetAnnotations()).To(HaveKeyWithValue(constant.AnnoInstalledVersion, "v0.4.0"))
+			}
+		})
+
+		It("skips the CRDs installed by newer version", func() {
+			ctx := context.TODO()
+			crds, err := crdmanager.CRDs()
+			Expect(err).NotTo(HaveOccurred())
+
+			newer := crds[0]
+			newer.SetAnnotations(map[string]string{constant.AnnoInstalledVersion: "v0.5.0"})
+			err = unstructured.SetNestedSlice(newer.Object, []interface{}{map[string]interface{}{"type": "Established", "status": "True"}}, "status", "conditions")
+			Expect(err).NotTo(HaveOccurred())
+			err = c.Create(ctx, newer)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = installer.Install(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.applied).To(HaveLen(9))
+			Expect(c.applied).NotTo(ContainElement(newer.GetName()))
+		})
+	})
+
+	DescribeTable("IsNewerVersion",
+		func(installed, current string, expected bool) {
+			Expect(crdmanager.IsNewerVersion(installed, current)).To(Equal(expected))
+		},
+		Entry("nothing installed", "", "v0.4.0", false),
+		Entry("same version", "v0.4.0", "v0.4.0", false),
+		Entry("older version installed", "v0.3.0", "v0.4.0", false),
+		Entry("newer version installed", "v0.4.1", "v0.4.0", true),
+		Entry("release installed over pre-release", "v0.4.0", "v0.4.0-rc1", true),
+		Entry("development build installed", "3f2a1b9", "v0.4.0", false),
+		Entry("development build running", "v0.4.0", "3f2a1b9", false),
+	)
+})
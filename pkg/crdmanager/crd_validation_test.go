@@ -0,0 +1,205 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package crdmanager_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	celmodel "k8s.io/apiextensions-apiserver/third_party/forked/celopenapi/model"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/spidernet-io/spiderpool/pkg/crdmanager"
+)
+
+// loadCRD returns the embedded CRD in the internal form the API server
+// validates.
+func loadCRD(name string) *apiextensions.CustomResourceDefinition {
+	crds, err := crdmanager.CRDs()
+	Expect(err).NotTo(HaveOccurred())
+
+	for _, crd := range crds {
+		if crd.GetName() != name {
+			continue
+		}
+
+		var v1CRD apiextensionsv1.CustomResourceDefinition
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(crd.Object, &v1CRD)
+		Expect(err).NotTo(HaveOccurred())
+		apiextensionsv1.SetObjectDefaults_CustomResourceDefinition(&v1CRD)
+
+		var internal apiextensions.CustomResourceDefinition
+		err = apiextensionsv1.Convert_v1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(&v1CRD, &internal, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		return &internal
+	}
+
+	Fail("CRD " + name + " not found")
+	return nil
+}
+
+// versionSchema returns the structural schema of the CRD version.
+func versionSchema(crd *apiextensions.CustomResourceDefinition, version string) *structuralschema.Structural {
+	var schema *apiextensions.JSONSchemaProps
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Schema != nil {
+			schema = v.Schema.OpenAPIV3Schema
+		}
+	}
+	// The schema shared by all versions is kept in the top-level validation.
+	if schema == nil && crd.Spec.Validation != nil {
+		schema = crd.Spec.Validation.OpenAPIV3Schema
+	}
+	Expect(schema).NotTo(BeNil())
+
+	structural, err := structuralschema.NewStructural(schema)
+	Expect(err).NotTo(HaveOccurred())
+
+	return structural
+}
+
+// specSchema returns the structural schema of the spec of the CRD version.
+func specSchema(crdName, version string) *structuralschema.Structural {
+	spec := versionSchema(loadCRD(crdName), version).Properties["spec"]
+
+	return &spec
+}
+
+// validateSpec validates the spec with the CEL rules of the CRD version like
+// the API server does.
+func validateSpec(crdName, version string, spec map[string]interface{}) field.ErrorList {
+	structural := specSchema(crdName, version)
+	validator := cel.NewValidator(structural, false, cel.PerCallLimit)
+	Expect(validator).NotTo(BeNil())
+	errs, _ := validator.Validate(context.TODO(), field.NewPath("spec"), structural, spec, nil, cel.RuntimeCELCostBudget)
+
+	return errs
+}
+
+// staticEstimatedCostLimit is the largest estimated cost of a CEL rule the
+// API server accepts in a CRD.
+const staticEstimatedCostLimit = 10000000
+
+// compileRules compiles the CEL rules of the schema and its descendants, and
+// returns the paths of the rules failing to compile or too expensive.
+func compileRules(path string, s *structuralschema.Structural) []string {
+	var failures []string
+	if len(s.XValidations) > 0 {
+		results, err := cel.Compile(s, celmodel.SchemaDeclType(s, false), cel.PerCallLimit)
+		Expect(err).NotTo(HaveOccurred())
+		for i, r := range results {
+			if r.Error != nil || r.MaxCost > staticEstimatedCostLimit {
+				failures = append(failures, fmt.Sprintf("%s[%d]: %v, estimated cost %d", path, i, r.Error, r.MaxCost))
+			}
+		}
+	}
+
+	for name := range s.Properties {
+		property := s.Properties[name]
+		failures = append(failures, compileRules(path+"."+name, &property)...)
+	}
+	if s.Items != nil {
+		failures = append(failures, compileRules(path+"[]", s.Items)...)
+	}
+
+	return failures
+}
+
+var _ = Describe("CRD validation", Label("crd_validation_test"), func() {
+	const (
+		ipPoolCRDName = "spiderippools.spiderpool.spidernet.io"
+		subnetCRDName = "spidersubnets.spiderpool.spidernet.io"
+	)
+
+	It("compiles the CEL rules of all CRDs within the cost limit", func() {
+		crds, err := crdmanager.CRDs()
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, c := range crds {
+			crd := loadCRD(c.GetName())
+			for _, version := range crd.Spec.Versions {
+				Expect(compileRules(version.Name, versionSchema(crd, version.Name))).To(BeEmpty(), crd.Name)
+			}
+		}
+	})
+
+	DescribeTable("validates the spec of SpiderIPPool and SpiderSubnet",
+		func(spec map[string]interface{}, message string) {
+			for _, crdName := range []string{ipPoolCRDName, subnetCRDName} {
+				errs := validateSpec(crdName, "v1", spec)
+				if message == "" {
+					Expect(errs).To(BeEmpty(), crdName)
+					continue
+				}
+				Expect(errs.ToAggregate()).To(MatchError(ContainSubstring(message)), crdName)
+			}
+		},
+		Entry("IPv4 gateway in subnet",
+			map[string]interface{}{
+				"subnet":  "172.18.40.0/24",
+				"gateway": "172.18.40.1",
+				"ips":     []interface{}{"172.18.40.10", "172.18.40.20-172.18.40.30"},
+			},
+			"",
+		),
+		Entry("IPv6 gateway",
+			map[string]interface{}{
+				"subnet":  "abcd:1234::/120",
+				"gateway": "abcd:1234::1",
+				"ips":     []interface{}{"abcd:1234::a", "abcd:1234::14-abcd:1234::1e"},
+			},
+			"",
+		),
+		Entry("IPv4 gateway in a subnet not aligned to octets",
+			map[string]interface{}{
+				"subnet":  "10.6.160.0/19",
+				"gateway": "10.6.191.254",
+			},
+			"",
+		),
+		Entry("IPv4 gateway as the last IP of /32",
+			map[string]interface{}{
+				"subnet":  "172.18.40.1/32",
+				"gateway": "172.18.40.1",
+			},
+			"",
+		),
+		Entry("IPv4 gateway in the network of /0",
+			map[string]interface{}{
+				"subnet":  "0.0.0.0/0",
+				"gateway": "255.255.255.254",
+			},
+			"",
+		),
+		Entry("IPv4 gateway out of subnet",
+			map[string]interface{}{
+				"subnet":  "10.6.160.0/19",
+				"gateway": "10.6.192.1",
+			},
+			"gateway must be in subnet",
+		),
+		Entry("IPv4 gateway out of subnet in the first octet",
+			map[string]interface{}{
+				"subnet":  "10.0.0.0/8",
+				"gateway": "11.0.0.1",
+			},
+			"gateway must be in subnet",
+		),
+		Entry("IPv4 gateway out of subnet in the last octet",
+			map[string]interface{}{
+				"subnet":  "172.18.40.0/25",
+				"gateway": "172.18.40.129",
+			},
+			"gateway must be in subnet",
+		),
+	)
+})
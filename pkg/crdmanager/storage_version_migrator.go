@@ -0,0 +1,264 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package crdmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// StorageVersionMigrator rewrites the objects of the CRDs of Spiderpool
+// stored with the old versions into the storage version, and prunes the
+// 'status.storedVersions' of the CRDs afterwards, so that the old versions
+// could be dropped from the CRDs by the following releases without
+// deploying kube-storage-version-migrator.
+type StorageVersionMigrator interface {
+	Start(ctx context.Context)
+	// Migrate migrates all CRDs of Spiderpool.
+	Migrate(ctx context.Context) error
+	// MigrateCRD migrates the objects of the CRD, it returns whether the
+	// 'status.storedVersions' of the CRD only has the storage version.
+	MigrateCRD(ctx context.Context, name string) (bool, error)
+}
+
+type storageVersionMigrator struct {
+	config StorageVersionMigratorConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+}
+
+func NewStorageVersionMigrator(config StorageVersionMigratorConfig, client client.Client, leader election.SpiderLeaseElector) (StorageVersionMigrator, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &storageVersionMigrator{
+		config: setDefaultsForStorageVersionMigratorConfig(config),
+		client: client,
+		leader: leader,
+	}, nil
+}
+
+// Start migrates the CRDs periodically until the context is done. Only the
+// leader of spiderpool-controller migrates them.
+func (m *storageVersionMigrator) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if m.leader.IsElected() {
+				err := recovery.Call(ctx, "Storage-Version-Migrator", nil, func() error { return m.Migrate(ctx) })
+				if err != nil {
+					logger.Sugar().Errorf("Failed to migrate the storage versions of CRDs: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (m *storageVersionMigrator) Migrate(ctx context.Context) error {
+	crds, err := CRDs()
+	if err != nil {
+		return err
+	}
+
+	for _, crd := range crds {
+		if _, err := m.MigrateCRD(ctx, crd.GetName()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *storageVersionMigrator) MigrateCRD(ctx context.Context, name string) (bool, error) {
+	logger := logutils.FromContext(ctx)
+
+	var crd unstructured.Unstructured
+	crd.SetGroupVersionKind(crdGVK)
+	if err := m.client.Get(ctx, apitypes.NamespacedName{Name: name}, &crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get CRD %s: %w", name, err)
+	}
+
+	storageVersion, err := storageVersionOf(&crd)
+	if err != nil {
+		return false, err
+	}
+	storedVersions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+	if len(storedVersions) == 1 && storedVersions[0] == storageVersion {
+		return true, nil
+	}
+
+	// Without the conversion webhook, the objects stored with the old
+	// versions would be rewritten with the storage version as they are,
+	// and their fields unknown to the storage version would be pruned.
+	if strategy, _, _ := unstructured.NestedString(crd.Object, "spec", "conversion", "strategy"); strategy != "Webhook" && hasSchemaChanged(&crd, storedVersions, storageVersion) {
+		logger.Sugar().Infof("Wait for the conversion webhook of CRD %s to migrate the objects stored with versions %v", name, storedVersions)
+		return false, nil
+	}
+
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	listKind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "listKind")
+	gvk := schema.GroupVersionKind{Group: group, Version: storageVersion, Kind: listKind}
+	logger.Sugar().Infof("Begin to migrate the objects of CRD %s stored with versions %v to %s", name, storedVersions, storageVersion)
+
+	migrated, pending := 0, 0
+	continueToken := ""
+	for {
+		var list unstructured.UnstructuredList
+		list.SetGroupVersionKind(gvk)
+		if err := m.client.List(ctx, &list, client.Limit(m.config.PageSize), client.Continue(continueToken)); err != nil {
+			return false, fmt.Errorf("failed to list the objects of CRD %s: %w", name, err)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			// The webhooks reject the updates of some terminating objects,
+			// they are left to be deleted.
+			if obj.GetDeletionTimestamp() != nil {
+				pending++
+				continue
+			}
+			if err := m.migrateObject(ctx, obj); err != nil {
+				return false, err
+			}
+			migrated++
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			break
+		}
+	}
+
+	if pending > 0 {
+		logger.Sugar().Infof("Migrated %d objects of CRD %s, wait for %d terminating objects to be deleted", migrated, name, pending)
+		return false, nil
+	}
+
+	patch := client.MergeFrom(crd.DeepCopy())
+	if err := unstructured.SetNestedStringSlice(crd.Object, []string{storageVersion}, "status", "storedVersions"); err != nil {
+		return false, err
+	}
+	if err := m.client.Status().Patch(ctx, &crd, patch); err != nil {
+		return false, fmt.Errorf("failed to update the stored versions of CRD %s: %w", name, err)
+	}
+	logger.Sugar().Infof("Succeed to migrate %d objects of CRD %s to %s", migrated, name, storageVersion)
+
+	return true, nil
+}
+
+// migrateObject rewrites the object with the storage version by a no-op
+// update, and verifies that the object survives the round trip.
+func (m *storageVersionMigrator) migrateObject(ctx context.Context, obj *unstructured.Unstructured) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		origin := obj.DeepCopy()
+		if err := m.client.Update(ctx, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			if apierrors.IsConflict(err) {
+				if err := m.client.Get(ctx, client.ObjectKeyFromObject(origin), obj); err != nil {
+					return client.IgnoreNotFound(err)
+				}
+			}
+			return err
+		}
+
+		if !isRoundTripped(origin, obj) {
+			return fmt.Errorf("%w: %s %s changed after being rewritten with the storage version", constant.ErrLossyMigration, origin.GetKind(), origin.GetName())
+		}
+
+		return nil
+	})
+}
+
+// isRoundTripped compares the content of the object before and after being
+// rewritten, except the metadata maintained by the API server.
+func isRoundTripped(origin, rewritten *unstructured.Unstructured) bool {
+	for key, value := range origin.Object {
+		if key == "metadata" {
+			continue
+		}
+		if !equality.Semantic.DeepEqual(value, rewritten.Object[key]) {
+			return false
+		}
+	}
+	for key := range rewritten.Object {
+		if _, ok := origin.Object[key]; !ok && key != "metadata" {
+			return false
+		}
+	}
+
+	return equality.Semantic.DeepEqual(origin.GetLabels(), rewritten.GetLabels()) &&
+		equality.Semantic.DeepEqual(origin.GetAnnotations(), rewritten.GetAnnotations()) &&
+		equality.Semantic.DeepEqual(origin.GetFinalizers(), rewritten.GetFinalizers()) &&
+		equality.Semantic.DeepEqual(origin.GetOwnerReferences(), rewritten.GetOwnerReferences())
+}
+
+func storageVersionOf(crd *unstructured.Unstructured) (string, error) {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if ok && version["storage"] == true {
+			if name, ok := version["name"].(string); ok {
+				return name, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no storage version of CRD %s", crd.GetName())
+}
+
+// hasSchemaChanged reports whether the schema of any stored version still
+// served by the CRD differs from the one of the storage version.
+func hasSchemaChanged(crd *unstructured.Unstructured, storedVersions []string, storageVersion string) bool {
+	schemas := map[string]interface{}{}
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		if version, ok := v.(map[string]interface{}); ok {
+			if name, ok := version["name"].(string); ok {
+				schemas[name] = version["schema"]
+			}
+		}
+	}
+
+	for _, v := range storedVersions {
+		schema, ok := schemas[v]
+		if ok && v != storageVersion && !equality.Semantic.DeepEqual(schema, schemas[storageVersion]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package crdmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/crdmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("StorageVersionMigrator", Label("storage_version_migrator_test"), func() {
+	Describe("New StorageVersionMigrator", func() {
+		It("inputs nil client", func() {
+			migrator, err := crdmanager.NewStorageVersionMigrator(crdmanager.StorageVersionMigratorConfig{}, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(migrator).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			migrator, err := crdmanager.NewStorageVersionMigrator(crdmanager.StorageVersionMigratorConfig{}, fake.NewClientBuilder().Build(), nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(migrator).To(BeNil())
+		})
+	})
+
+	Describe("MigrateCRD", func() {
+		const crdName = "spiderippools.spiderpool.spidernet.io"
+
+		var ctx context.Context
+		var fakeClient client.Client
+		var migrator crdmanager.StorageVersionMigrator
+		var crdT *unstructured.Unstructured
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+
+			scheme := runtime.NewScheme()
+			err := spiderpoolv1.AddToScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = spiderpoolv2beta1.AddToScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				Build()
+
+			migrator, err = crdmanager.NewStorageVersionMigrator(crdmanager.StorageVersionMigratorConfig{PageSize: 1}, fakeClient, fakeLeader{})
+			Expect(err).NotTo(HaveOccurred())
+
+			crds, err := crdmanager.CRDs()
+			Expect(err).NotTo(HaveOccurred())
+			for _, crd := range crds {
+				if crd.GetName() == crdName {
+					crdT = crd
+				}
+			}
+			Expect(crdT).NotTo(BeNil())
+		})
+
+		storedVersions := func() []string {
+			crd := crdT.DeepCopy()
+			err := fakeClient.Get(ctx, client.ObjectKeyFromObject(crd), crd)
+			Expect(err).NotTo(HaveOccurred())
+			versions, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+
+			return versions
+		}
+
+		It("skips the CRD not installed", func() {
+			migrated, err := migrator.MigrateCRD(ctx, crdName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(BeFalse())
+		})
+
+		setConversionWebhook := func() {
+			err := unstructured.SetNestedField(crdT.Object, "Webhook", "spec", "conversion", "strategy")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("skips the CRD only stored with the storage version", func() {
+			err := unstructured.SetNestedStringSlice(crdT.Object, []string{"v2beta1"}, "status", "storedVersions")
+			Expect(err).NotTo(HaveOccurred())
+			err = fakeClient.Create(ctx, crdT)
+			Expect(err).NotTo(HaveOccurred())
+
+			migrated, err := migrator.MigrateCRD(ctx, crdName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(BeTrue())
+		})
+
+		It("waits for the conversion webhook to migrate the objects stored with the old version", func() {
+			err := unstructured.SetNestedStringSlice(crdT.Object, []string{"v1", "v2beta1"}, "status", "storedVersions")
+			Expect(err).NotTo(HaveOccurred())
+			err = fakeClient.Create(ctx, crdT)
+			Expect(err).NotTo(HaveOccurred())
+
+			migrated, err := migrator.MigrateCRD(ctx, crdName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(BeFalse())
+			Expect(storedVersions()).To(Equal([]string{"v1", "v2beta1"}))
+		})
+
+		It("rewrites the objects and prunes the stored versions", func() {
+			setConversionWebhook()
+			err := unstructured.SetNestedStringSlice(crdT.Object, []string{"v1", "v2beta1"}, "status", "storedVersions")
+			Expect(err).NotTo(HaveOccurred())
+			err = fakeClient.Create(ctx, crdT)
+			Expect(err).NotTo(HaveOccurred())
+
+			var pools []*spiderpoolv2beta1.SpiderIPPool
+			for _, name := range []string{"pool1", "pool2"} {
+				pool := &spiderpoolv2beta1.SpiderIPPool{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   name,
+						Labels: map[string]string{"app": name},
+					},
+					Spec: spiderpoolv1.IPPoolSpec{
+						Subnet: "172.18.40.0/24",
+						IPs:    []string{"172.18.40.10"},
+					},
+				}
+				err := fakeClient.Create(ctx, pool)
+				Expect(err).NotTo(HaveOccurred())
+				pools = append(pools, pool)
+			}
+
+			migrated, err := migrator.MigrateCRD(ctx, crdName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(BeTrue())
+			Expect(storedVersions()).To(Equal([]string{"v2beta1"}))
+
+			for _, pool := range pools {
+				var rewritten spiderpoolv2beta1.SpiderIPPool
+				err := fakeClient.Get(ctx, client.ObjectKeyFromObject(pool), &rewritten)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rewritten.ResourceVersion).NotTo(Equal(pool.ResourceVersion))
+				Expect(rewritten.Spec).To(Equal(pool.Spec))
+				Expect(rewritten.Labels).To(Equal(pool.Labels))
+			}
+		})
+
+		It("migrates the objects stored with the version no longer served without the conversion webhook", func() {
+			err := unstructured.SetNestedStringSlice(crdT.Object, []string{"v1beta1", "v2beta1"}, "status", "storedVersions")
+			Expect(err).NotTo(HaveOccurred())
+			err = fakeClient.Create(ctx, crdT)
+			Expect(err).NotTo(HaveOccurred())
+
+			migrated, err := migrator.MigrateCRD(ctx, crdName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(BeTrue())
+			Expect(storedVersions()).To(Equal([]string{"v2beta1"}))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+import (
+	"sort"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// Every feature gate should add a method here following this template:
+	//
+	// // owner: @username
+	// // beta: v0.x
+	// MyFeature featuregate.Feature = "MyFeature"
+
+	// beta: v0.4
+	//
+	// Reserve aligned blocks of SpiderSubnet for auto-created IPPools with
+	// the annotation 'ipam.spidernet.io/subnet-block-size'.
+	SubnetBlockReservation featuregate.Feature = "SubnetBlockReservation"
+
+	// beta: v0.4
+	//
+	// Assign IPs by the ordinal of StatefulSet Pods from the IPPools with the
+	// annotation 'ipam.spidernet.io/statefulset-ordinal-ip'.
+	StatefulSetOrdinalIP featuregate.Feature = "StatefulSetOrdinalIP"
+
+	// alpha: v0.4
+	//
+	// Retain the IP addresses of Pods whose sandboxes are recreated with the
+	// same Pod UID on the same node, e.g. container restarts and in-place
+	// updates, rather than releasing and re-allocating them.
+	SandboxRestartIPRetention featuregate.Feature = "SandboxRestartIPRetention"
+)
+
+// DefaultMutableFeatureGate is a mutable version of DefaultFeatureGate.
+// Only top-level commands/options setup should make use of this.
+var DefaultMutableFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+// DefaultFeatureGate is a shared global FeatureGate. Components should use
+// it to check whether a feature is enabled.
+var DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+
+// defaultSpiderpoolFeatureGates consists of all known Spiderpool-specific
+// feature keys. To add a new feature, define a key for it above and add it
+// here. Alpha features should be disabled by default.
+var defaultSpiderpoolFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	SubnetBlockReservation:    {Default: true, PreRelease: featuregate.Beta},
+	StatefulSetOrdinalIP:      {Default: true, PreRelease: featuregate.Beta},
+	SandboxRestartIPRetention: {Default: false, PreRelease: featuregate.Alpha},
+}
+
+func init() {
+	utilruntime.Must(DefaultMutableFeatureGate.Add(defaultSpiderpoolFeatureGates))
+}
+
+// FeatureGateStatus describes a Spiderpool feature gate and whether it is
+// enabled.
+type FeatureGateStatus struct {
+	Name       string
+	Enabled    bool
+	Default    bool
+	PreRelease string
+}
+
+// ListFeatureGates returns the status of all Spiderpool feature gates of the
+// FeatureGate, sorted by name.
+func ListFeatureGates(fg featuregate.FeatureGate) []FeatureGateStatus {
+	statuses := make([]FeatureGateStatus, 0, len(defaultSpiderpoolFeatureGates))
+	for feature, spec := range defaultSpiderpoolFeatureGates {
+		statuses = append(statuses, FeatureGateStatus{
+			Name:       string(feature),
+			Enabled:    fg.Enabled(feature),
+			Default:    spec.Default,
+			PreRelease: string(spec.PreRelease),
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package features_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/features"
+)
+
+var _ = Describe("Features", Label("features_test"), func() {
+	It("enables the beta features by default", func() {
+		Expect(features.DefaultFeatureGate.Enabled(features.SubnetBlockReservation)).To(BeTrue())
+		Expect(features.DefaultFeatureGate.Enabled(features.StatefulSetOrdinalIP)).To(BeTrue())
+	})
+
+	It("disables the alpha features by default", func() {
+		Expect(features.DefaultFeatureGate.Enabled(features.SandboxRestartIPRetention)).To(BeFalse())
+	})
+
+	It("lists the feature gates sorted by name", func() {
+		gates := features.ListFeatureGates(features.DefaultFeatureGate)
+		Expect(gates).To(Equal([]features.FeatureGateStatus{
+			{Name: string(features.SandboxRestartIPRetention), Enabled: false, Default: false, PreRelease: "ALPHA"},
+			{Name: string(features.StatefulSetOrdinalIP), Enabled: true, Default: true, PreRelease: "BETA"},
+			{Name: string(features.SubnetBlockReservation), Enabled: true, Default: true, PreRelease: "BETA"},
+		}))
+	})
+
+	It("toggles feature gates", func() {
+		fg := features.DefaultMutableFeatureGate.DeepCopy()
+		err := fg.Set(string(features.SubnetBlockReservation) + "=false")
+		Expect(err).NotTo(HaveOccurred())
+
+		gates := features.ListFeatureGates(fg)
+		Expect(gates[2].Name).To(Equal(string(features.SubnetBlockReservation)))
+		Expect(gates[2].Enabled).To(BeFalse())
+		Expect(gates[2].Default).To(BeTrue())
+		Expect(features.DefaultFeatureGate.Enabled(features.SubnetBlockReservation)).To(BeTrue())
+	})
+
+	It("rejects unknown feature gates", func() {
+		fg := features.DefaultMutableFeatureGate.DeepCopy()
+		err := fg.SetFromMap(map[string]bool{"UnknownFeature": true})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -6,14 +6,18 @@ package gcmanager
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
 
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 	"github.com/spidernet-io/spiderpool/pkg/statefulsetmanager"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
@@ -22,7 +26,9 @@ import (
 type GarbageCollectionConfig struct {
 	EnableGCIP                bool
 	EnableGCForTerminatingPod bool
+	EnableGCForEvictedPod     bool
 	EnableStatefulSet         bool
+	EnableKubeletCrossCheck   bool
 
 	ReleaseIPWorkerNum     int
 	GCIPChannelBuffer      int
@@ -33,10 +39,39 @@ type GarbageCollectionConfig struct {
 	GCSignalTimeoutDuration   int
 	GCSignalGapDuration       int
 	AdditionalGraceDelay      int
+
+	// AgentHTTPPort is the port of the HTTP API of spiderpool-agent, which
+	// cross-checks the IP addresses with the kubelet of its node.
+	AgentHTTPPort int
+	// KubeletCrossCheckFailureTimeout is the seconds the cross-check of an
+	// IP address could keep failing before the IP address is released.
+	KubeletCrossCheckFailureTimeout int
+	// RESTConfig carries the ServiceAccount token of spiderpool-controller,
+	// which authenticates the cross-checks to spiderpool-agent.
+	RESTConfig *rest.Config
+
+	// PodLabelSelector and PodFieldSelector scope the Pods watched by the
+	// informer, the same as the cache of the runtime manager.
+	PodLabelSelector string
+	PodFieldSelector string
 }
 
 var logger *zap.Logger
 
+// The reasons of the IPs reclaimed by scanAll, the ones reclaimed for the
+// traced Pods are their statuses.
+const (
+	gcReasonPodNotFound        = "PodNotFound"
+	gcReasonContainerIDChanged = "ContainerIDChanged"
+)
+
+// The reasons of the IPs skipped to reclaim for safety.
+const (
+	gcSkipReasonStatefulSetPod   = "StatefulSetPod"
+	gcSkipReasonIPInUse          = "IPInUse"
+	gcSkipReasonCrossCheckFailed = "CrossCheckFailed"
+)
+
 type GCManager interface {
 	Start(ctx context.Context) error
 
@@ -44,13 +79,17 @@ type GCManager interface {
 
 	TriggerGCAll()
 
+	// QueueDepth returns the number of the timed out Pods waiting for the
+	// workers to reclaim their IPs.
+	QueueDepth() int
+
 	Health()
 }
 
 var _ GCManager = &SpiderGC{}
 
 type SpiderGC struct {
-	k8ClientSet *kubernetes.Clientset
+	k8ClientSet kubernetes.Interface
 	PodDB       PodDBer
 
 	// env configuration
@@ -66,6 +105,14 @@ type SpiderGC struct {
 	stsMgr    statefulsetmanager.StatefulSetManager
 
 	leader election.SpiderLeaseElector
+
+	agentClient ipUsageChecker
+	// crossCheckFailures records since when the cross-check of each IP
+	// allocation has been failing.
+	crossCheckFailures    map[string]time.Time
+	crossCheckFailureLock sync.Mutex
+
+	clock clock.Clock
 }
 
 func NewGCManager(ctx context.Context, clientSet *kubernetes.Clientset, config *GarbageCollectionConfig,
@@ -73,7 +120,8 @@ func NewGCManager(ctx context.Context, clientSet *kubernetes.Clientset, config *
 	ippoolManager ippoolmanager.IPPoolManager,
 	podManager podmanager.PodManager,
 	stsManager statefulsetmanager.StatefulSetManager,
-	spiderControllerLeader election.SpiderLeaseElector) (GCManager, error) {
+	spiderControllerLeader election.SpiderLeaseElector,
+	opts ...manageroption.Option) (GCManager, error) {
 	if clientSet == nil {
 		return nil, fmt.Errorf("k8s ClientSet must be specified")
 	}
@@ -100,6 +148,11 @@ func NewGCManager(ctx context.Context, clientSet *kubernetes.Clientset, config *
 
 	logger = logutils.Logger.Named("IP-GarbageCollection")
 
+	agentClient, err := newAgentClient(config.AgentHTTPPort, config.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	spiderGC := &SpiderGC{
 		k8ClientSet: clientSet,
 		PodDB:       NewPodDBer(config.MaxPodEntryDatabaseCap),
@@ -114,6 +167,11 @@ func NewGCManager(ctx context.Context, clientSet *kubernetes.Clientset, config *
 		stsMgr:    stsManager,
 
 		leader: spiderControllerLeader,
+
+		agentClient:        agentClient,
+		crossCheckFailures: map[string]time.Time{},
+
+		clock: manageroption.New(opts...).Clock,
 	}
 
 	return spiderGC, nil
@@ -151,11 +209,15 @@ func (s *SpiderGC) TriggerGCAll() {
 	logger.Info("trigger gc!")
 	select {
 	case s.gcSignal <- struct{}{}:
-	case <-time.After(time.Duration(s.gcConfig.GCSignalTimeoutDuration) * time.Second):
+	case <-s.clock.After(time.Duration(s.gcConfig.GCSignalTimeoutDuration) * time.Second):
 		logger.Sugar().Errorf("failed to trigger GCAll, gcSignal:len=%d", len(s.gcSignal))
 	}
 }
 
+func (s *SpiderGC) QueueDepth() int {
+	return len(s.gcIPPoolIPSignal)
+}
+
 func (s *SpiderGC) Health() {
 	//TODO (Icarus9913): implement me
 }
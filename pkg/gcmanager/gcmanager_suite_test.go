@@ -0,0 +1,22 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+func TestGCManager(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GCManager Suite", Label("gcmanager", "unitest"))
+}
+
+var _ = BeforeSuite(func() {
+	logger = logutils.Logger.Named("IP-GarbageCollection")
+})
@@ -0,0 +1,214 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	runtimeclient "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	agentOpenAPIClient "github.com/spidernet-io/spiderpool/api/v1/agent/client"
+	"github.com/spidernet-io/spiderpool/api/v1/agent/client/daemonset"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	metrics "github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+const crossCheckTimeout = 10 * time.Second
+
+// errIPInUse is returned by checkIPReleasable if a sandbox known by the
+// kubelet still uses the IP address.
+var errIPInUse = errors.New("IP is still in use")
+
+// ipUsageChecker asks spiderpool-agent on a node about the Pods known by the
+// kubelet of the node which use an IP address.
+type ipUsageChecker interface {
+	PodsUsingIP(ctx context.Context, nodeIP, ip string) ([]string, error)
+}
+
+type agentClient struct {
+	port   int
+	client *http.Client
+}
+
+// newAgentClient authenticates to spiderpool-agent with the bearer token in
+// restConfig, which spiderpool-agent reviews before it asks the kubelet.
+func newAgentClient(port int, restConfig *rest.Config) (ipUsageChecker, error) {
+	if restConfig == nil {
+		return nil, fmt.Errorf("rest config %w", constant.ErrMissingRequiredParam)
+	}
+
+	transportConfig := rest.AnonymousClientConfig(restConfig)
+	transportConfig.BearerToken = restConfig.BearerToken
+	transportConfig.BearerTokenFile = restConfig.BearerTokenFile
+	transportConfig.TLSClientConfig = rest.TLSClientConfig{}
+
+	transport, err := rest.TransportFor(transportConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the transport to spiderpool-agent: %w", err)
+	}
+
+	return &agentClient{
+		port: port,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   crossCheckTimeout,
+		},
+	}, nil
+}
+
+func (c *agentClient) PodsUsingIP(ctx context.Context, nodeIP, ip string) ([]string, error) {
+	host := net.JoinHostPort(nodeIP, strconv.Itoa(c.port))
+	transport := runtimeclient.NewWithClient(host, agentOpenAPIClient.DefaultBasePath, []string{"http"}, c.client)
+	client := agentOpenAPIClient.New(transport, strfmt.Default)
+
+	params := daemonset.NewGetIpamIPInuseParamsWithContext(ctx).
+		WithTimeout(crossCheckTimeout).
+		WithIP(ip)
+	resp, err := client.Daemonset.GetIpamIPInuse(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Payload == nil || resp.Payload.InUse == nil || !*resp.Payload.InUse {
+		return nil, nil
+	}
+
+	return resp.Payload.Pods, nil
+}
+
+// checkIPReleasable asks spiderpool-agent on the node the IP address was
+// allocated on, whether any sandbox known by the kubelet still uses it. The
+// Pod may be removed from kube-apiserver before the kubelet tears down its
+// sandbox, and reclaiming the IP address in the meantime makes it assigned
+// to two live Pods.
+//
+// The IP address is releasable without the check if the node is gone or
+// not ready, since nothing could be asked there and the IP addresses on the
+// dead nodes still have to be reclaimed. For the same reason, it becomes
+// releasable once the check has kept failing for
+// KubeletCrossCheckFailureTimeout, e.g. spiderpool-agent is unreachable.
+func (s *SpiderGC) checkIPReleasable(ctx context.Context, nodeName, ip, containerID string) error {
+	if !s.gcConfig.EnableKubeletCrossCheck || nodeName == "" {
+		return nil
+	}
+
+	log := logutils.FromContext(ctx)
+
+	node, err := s.k8ClientSet.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Sugar().Debugf("Node '%s' not found, skip to cross-check IP '%s'", nodeName, ip)
+			return nil
+		}
+		return fmt.Errorf("failed to get Node '%s' to cross-check IP '%s': %w", nodeName, ip, err)
+	}
+
+	if !isNodeReady(node) {
+		log.Sugar().Debugf("Node '%s' is not ready, skip to cross-check IP '%s'", nodeName, ip)
+		return nil
+	}
+
+	key := nodeName + "/" + ip + "/" + containerID
+	nodeIP := nodeInternalIP(node)
+	if nodeIP == "" {
+		return s.crossCheckFailed(ctx, key, fmt.Errorf("no internal IP of Node '%s' to cross-check IP '%s'", nodeName, ip))
+	}
+
+	pods, err := s.agentClient.PodsUsingIP(ctx, nodeIP, ip)
+	if err != nil {
+		return s.crossCheckFailed(ctx, key, fmt.Errorf("failed to cross-check IP '%s' with spiderpool-agent on Node '%s': %w", ip, nodeName, err))
+	}
+	s.crossCheckSucceeded(key)
+
+	if len(pods) > 0 {
+		return fmt.Errorf("%w, IP '%s' is still used by Pods %v on Node '%s'", errIPInUse, ip, pods, nodeName)
+	}
+
+	return nil
+}
+
+// crossCheckFailed returns the error of the cross-check, until the
+// cross-check of the IP allocation has kept failing for
+// KubeletCrossCheckFailureTimeout, so that an unreachable spiderpool-agent
+// couldn't keep the IP address forever.
+func (s *SpiderGC) crossCheckFailed(ctx context.Context, key string, err error) error {
+	timeout := time.Duration(s.gcConfig.KubeletCrossCheckFailureTimeout) * time.Second
+	now := s.clock.Now()
+
+	s.crossCheckFailureLock.Lock()
+	defer s.crossCheckFailureLock.Unlock()
+
+	since, ok := s.crossCheckFailures[key]
+	if !ok {
+		// An IP allocation is checked again at least every GC interval
+		// until it is released, so the records older than the timeout
+		// plus an interval are left by the allocations released by others.
+		stale := timeout + time.Duration(s.gcConfig.DefaultGCIntervalDuration)*time.Second
+		for k, t := range s.crossCheckFailures {
+			if now.Sub(t) > stale {
+				delete(s.crossCheckFailures, k)
+			}
+		}
+		s.crossCheckFailures[key] = now
+		return err
+	}
+
+	if now.Sub(since) < timeout {
+		return err
+	}
+
+	delete(s.crossCheckFailures, key)
+	logutils.FromContext(ctx).Sugar().Warnf("Cross-check has kept failing since %s, release the IP: %v", since.Format(time.RFC3339), err)
+
+	return nil
+}
+
+func (s *SpiderGC) crossCheckSucceeded(key string) {
+	s.crossCheckFailureLock.Lock()
+	defer s.crossCheckFailureLock.Unlock()
+
+	delete(s.crossCheckFailures, key)
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func nodeInternalIP(node *corev1.Node) string {
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP {
+			return addr.Address
+		}
+	}
+
+	return ""
+}
+
+// recordSkip records the IP address skipped for the error of
+// checkIPReleasable.
+func recordSkip(ctx context.Context, err error) {
+	reason := gcSkipReasonCrossCheckFailed
+	if errors.Is(err, errIPInUse) {
+		reason = gcSkipReasonIPInUse
+	}
+	metrics.RecordIPGCSkip(ctx, reason)
+}
@@ -0,0 +1,202 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+type fakeIPUsageChecker struct {
+	pods  []string
+	err   error
+	calls int
+}
+
+func (f *fakeIPUsageChecker) PodsUsingIP(ctx context.Context, nodeIP, ip string) ([]string, error) {
+	f.calls++
+	return f.pods, f.err
+}
+
+var _ = Describe("KubeletCrossCheck", Label("kubelet_cross_check_test"), func() {
+	var fakeClock *clocktesting.FakeClock
+	var checker *fakeIPUsageChecker
+	var node *corev1.Node
+	var gc *SpiderGC
+
+	BeforeEach(func() {
+		fakeClock = clocktesting.NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		checker = &fakeIPUsageChecker{}
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "172.18.0.2"}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		gc = &SpiderGC{
+			k8ClientSet: fake.NewSimpleClientset(node),
+			gcConfig: &GarbageCollectionConfig{
+				EnableKubeletCrossCheck:         true,
+				KubeletCrossCheckFailureTimeout: 600,
+				DefaultGCIntervalDuration:       600,
+			},
+			agentClient:        checker,
+			crossCheckFailures: map[string]time.Time{},
+			clock:              fakeClock,
+		}
+	})
+
+	It("releases the IP not used on the node", func() {
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).To(Succeed())
+		Expect(checker.calls).To(Equal(1))
+	})
+
+	It("keeps the IP still used on the node", func() {
+		checker.pods = []string{"default/pod"}
+
+		err := gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")
+		Expect(err).To(MatchError(errIPInUse))
+
+		fakeClock.Step(time.Hour)
+		err = gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")
+		Expect(err).To(MatchError(errIPInUse))
+	})
+
+	It("releases the IP on the gone or not ready node without asking", func() {
+		Expect(gc.checkIPReleasable(context.TODO(), "node2", "10.6.0.10", "cid")).To(Succeed())
+
+		node.Status.Conditions[0].Status = corev1.ConditionFalse
+		gc.k8ClientSet = fake.NewSimpleClientset(node)
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).To(Succeed())
+		Expect(checker.calls).To(Equal(0))
+	})
+
+	It("keeps the IP until the cross-check has kept failing for the timeout", func() {
+		checker.err = fmt.Errorf("connection timed out")
+
+		err := gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(errIPInUse))
+
+		fakeClock.Step(599 * time.Second)
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).NotTo(Succeed())
+
+		fakeClock.Step(time.Second)
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).To(Succeed())
+		Expect(gc.crossCheckFailures).To(BeEmpty())
+	})
+
+	It("times the failures of each IP allocation separately", func() {
+		checker.err = fmt.Errorf("connection timed out")
+
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid1")).NotTo(Succeed())
+		fakeClock.Step(600 * time.Second)
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid2")).NotTo(Succeed())
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid1")).To(Succeed())
+	})
+
+	It("restarts the timeout once the cross-check succeeds", func() {
+		checker.err = fmt.Errorf("connection timed out")
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).NotTo(Succeed())
+
+		fakeClock.Step(300 * time.Second)
+		checker.err = nil
+		checker.pods = []string{"default/pod"}
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).To(MatchError(errIPInUse))
+
+		checker.err = fmt.Errorf("connection timed out")
+		checker.pods = nil
+		fakeClock.Step(300 * time.Second)
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).NotTo(Succeed())
+	})
+
+	It("drops the failures of the IP allocations released by others", func() {
+		checker.err = fmt.Errorf("connection timed out")
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid1")).NotTo(Succeed())
+
+		fakeClock.Step(1201 * time.Second)
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.11", "cid2")).NotTo(Succeed())
+		Expect(gc.crossCheckFailures).To(HaveLen(1))
+		Expect(gc.crossCheckFailures).To(HaveKey("node1/10.6.0.11/cid2"))
+	})
+
+	It("skips the cross-check if it is disabled", func() {
+		gc.gcConfig.EnableKubeletCrossCheck = false
+		checker.pods = []string{"default/pod"}
+
+		Expect(gc.checkIPReleasable(context.TODO(), "node1", "10.6.0.10", "cid")).To(Succeed())
+		Expect(checker.calls).To(Equal(0))
+	})
+
+	It("inputs nil rest config", func() {
+		_, err := newAgentClient(5710, nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("asks spiderpool-agent with the bearer token", func() {
+		var token, ip string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token = r.Header.Get("Authorization")
+			ip = r.URL.Query().Get("ip")
+			if r.URL.Path != "/v1/ipam/ip/inuse" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"inUse":true,"pods":["default/pod"]}`))
+		}))
+		DeferCleanup(server.Close)
+
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		agentPort, err := strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := newAgentClient(agentPort, &rest.Config{BearerToken: "controller-token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		pods, err := client.PodsUsingIP(context.TODO(), host, "10.6.0.10")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods).To(Equal([]string{"default/pod"}))
+		Expect(token).To(Equal("Bearer controller-token"))
+		Expect(ip).To(Equal("10.6.0.10"))
+	})
+
+	It("fails if spiderpool-agent rejects the caller", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`"forbidden"`))
+		}))
+		DeferCleanup(server.Close)
+
+		host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+		Expect(err).NotTo(HaveOccurred())
+		agentPort, err := strconv.Atoi(port)
+		Expect(err).NotTo(HaveOccurred())
+
+		client, err := newAgentClient(agentPort, &rest.Config{})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.PodsUsingIP(context.TODO(), host, "10.6.0.10")
+		Expect(err).To(HaveOccurred())
+	})
+})
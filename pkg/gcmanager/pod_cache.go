@@ -149,8 +149,8 @@ func (s *SpiderGC) buildPodEntry(oldPod, currentPod *corev1.Pod, deleted bool) (
 			PodName:             currentPod.Name,
 			Namespace:           currentPod.Namespace,
 			NodeName:            currentPod.Spec.NodeName,
-			EntryUpdateTime:     metav1.Now().UTC(),
-			TracingStartTime:    metav1.Now().UTC(),
+			EntryUpdateTime:     s.clock.Now().UTC(),
+			TracingStartTime:    s.clock.Now().UTC(),
 			TracingGracefulTime: time.Duration(s.gcConfig.AdditionalGraceDelay) * time.Second,
 			PodTracingReason:    constant.PodDeleted,
 		}
@@ -166,6 +166,31 @@ func (s *SpiderGC) buildPodEntry(oldPod, currentPod *corev1.Pod, deleted bool) (
 
 		podStatus, _ := podmanager.CheckPodStatus(currentPod)
 
+		// The containers of an evicted pod are already killed, release its IPs
+		// promptly rather than waiting for its deletion or termination grace
+		// period, since evicted pods could linger for days.
+		if podStatus == constant.PodEvicted && s.gcConfig.EnableGCForEvictedPod {
+			if oldPod != nil {
+				if oldPodStatus, _ := podmanager.CheckPodStatus(oldPod); oldPodStatus == constant.PodEvicted {
+					return nil, nil
+				}
+			}
+
+			podEntry := &PodEntry{
+				PodName:             currentPod.Name,
+				Namespace:           currentPod.Namespace,
+				NodeName:            currentPod.Spec.NodeName,
+				EntryUpdateTime:     s.clock.Now().UTC(),
+				TracingStartTime:    s.evictedTime(currentPod),
+				TracingGracefulTime: time.Duration(s.gcConfig.AdditionalGraceDelay) * time.Second,
+				PodTracingReason:    podStatus,
+			}
+
+			// stop time
+			podEntry.TracingStopTime = podEntry.TracingStartTime.Add(podEntry.TracingGracefulTime)
+			return podEntry, nil
+		}
+
 		var isBuildTerminatingPodEntry, isBuildSucceededOrFailedPodEntry bool
 		switch {
 		case currentPod.DeletionTimestamp != nil && oldPod == nil:
@@ -209,7 +234,7 @@ func (s *SpiderGC) buildPodEntry(oldPod, currentPod *corev1.Pod, deleted bool) (
 				PodName:          currentPod.Name,
 				Namespace:        currentPod.Namespace,
 				NodeName:         currentPod.Spec.NodeName,
-				EntryUpdateTime:  metav1.Now().UTC(),
+				EntryUpdateTime:  s.clock.Now().UTC(),
 				TracingStartTime: currentPod.DeletionTimestamp.Time,
 				PodTracingReason: podStatus,
 			}
@@ -229,7 +254,7 @@ func (s *SpiderGC) buildPodEntry(oldPod, currentPod *corev1.Pod, deleted bool) (
 				PodName:          currentPod.Name,
 				Namespace:        currentPod.Namespace,
 				NodeName:         currentPod.Spec.NodeName,
-				EntryUpdateTime:  metav1.Now().UTC(),
+				EntryUpdateTime:  s.clock.Now().UTC(),
 				PodTracingReason: podStatus,
 			}
 
@@ -252,6 +277,29 @@ func (s *SpiderGC) buildPodEntry(oldPod, currentPod *corev1.Pod, deleted bool) (
 	}
 }
 
+// evictedTime returns when the pod was evicted, that is the latest time its
+// containers terminated or it became not ready. The current time is returned
+// if neither is recorded.
+func (s *SpiderGC) evictedTime(pod *corev1.Pod) time.Time {
+	var t time.Time
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Terminated != nil && t.Before(containerStatus.State.Terminated.FinishedAt.UTC()) {
+			t = containerStatus.State.Terminated.FinishedAt.UTC()
+		}
+	}
+	if !t.IsZero() {
+		return t
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status != corev1.ConditionTrue && !cond.LastTransitionTime.IsZero() {
+			return cond.LastTransitionTime.UTC()
+		}
+	}
+
+	return s.clock.Now().UTC()
+}
+
 // computeSucceededOrFailedPodTerminatingTime will compute terminating start time, stop time and graceful period for 'Succeeded | Failed' phase pod
 func (s *SpiderGC) computeSucceededOrFailedPodTerminatingTime(podYaml *corev1.Pod) (terminatingStartTime, terminatingStopTime time.Time, gracefulTime time.Duration, err error) {
 	// check container numbers
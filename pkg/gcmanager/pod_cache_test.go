@@ -0,0 +1,137 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+var _ = Describe("PodCache", Label("pod_cache_test"), func() {
+	Describe("buildPodEntry", func() {
+		var now time.Time
+		var fakeClock *clocktesting.FakeClock
+		var gc *SpiderGC
+		var pod *corev1.Pod
+
+		newEvictedPod := func(pod *corev1.Pod, finishedAt time.Time) *corev1.Pod {
+			evictedPod := pod.DeepCopy()
+			evictedPod.Status.Phase = corev1.PodFailed
+			evictedPod.Status.Reason = "Evicted"
+			evictedPod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name: "c1",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(finishedAt)},
+				},
+			}}
+
+			return evictedPod
+		}
+
+		BeforeEach(func() {
+			now = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+			fakeClock = clocktesting.NewFakeClock(now)
+			gc = &SpiderGC{
+				gcConfig: &GarbageCollectionConfig{
+					EnableGCForEvictedPod: true,
+					AdditionalGraceDelay:  30,
+				},
+				clock: fakeClock,
+			}
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"},
+				Spec: corev1.PodSpec{
+					NodeName:      "node1",
+					RestartPolicy: corev1.RestartPolicyAlways,
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+		})
+
+		It("traces the evicted Pod within the grace period", func() {
+			evictedPod := newEvictedPod(pod, now.Add(-10*time.Second))
+
+			podEntry, err := gc.buildPodEntry(pod, evictedPod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry).NotTo(BeNil())
+			Expect(podEntry.PodTracingReason).To(Equal(constant.PodEvicted))
+			Expect(podEntry.NodeName).To(Equal("node1"))
+			Expect(podEntry.TracingStartTime).To(Equal(now.Add(-10 * time.Second)))
+			Expect(podEntry.TracingGracefulTime).To(Equal(30 * time.Second))
+			Expect(podEntry.TracingStopTime).To(Equal(now.Add(20 * time.Second)))
+			Expect(fakeClock.Now().After(podEntry.TracingStopTime)).To(BeFalse())
+
+			fakeClock.Step(21 * time.Second)
+			Expect(fakeClock.Now().After(podEntry.TracingStopTime)).To(BeTrue())
+		})
+
+		It("traces the Pod evicted out of the grace period to release its IPs at once", func() {
+			evictedPod := newEvictedPod(pod, now.Add(-time.Hour))
+
+			podEntry, err := gc.buildPodEntry(nil, evictedPod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry).NotTo(BeNil())
+			Expect(podEntry.PodTracingReason).To(Equal(constant.PodEvicted))
+			Expect(podEntry.TracingStopTime).To(Equal(now.Add(-time.Hour + 30*time.Second)))
+			Expect(fakeClock.Now().After(podEntry.TracingStopTime)).To(BeTrue())
+		})
+
+		It("takes the time the evicted Pod became not ready without the terminated containers", func() {
+			evictedPod := newEvictedPod(pod, now)
+			evictedPod.Status.ContainerStatuses = nil
+			evictedPod.Status.Conditions = []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+			}}
+
+			podEntry, err := gc.buildPodEntry(nil, evictedPod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry.TracingStartTime).To(Equal(now.Add(-time.Minute)))
+		})
+
+		It("takes the current time without any record of the eviction", func() {
+			evictedPod := newEvictedPod(pod, now)
+			evictedPod.Status.ContainerStatuses = nil
+
+			podEntry, err := gc.buildPodEntry(nil, evictedPod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry.TracingStartTime).To(Equal(now))
+			Expect(podEntry.TracingStopTime).To(Equal(now.Add(30 * time.Second)))
+		})
+
+		It("does not trace the Pod evicted already", func() {
+			evictedPod := newEvictedPod(pod, now)
+
+			podEntry, err := gc.buildPodEntry(evictedPod, evictedPod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry).To(BeNil())
+		})
+
+		It("does not trace the Pod not evicted", func() {
+			podEntry, err := gc.buildPodEntry(nil, pod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry).To(BeNil())
+		})
+
+		It("waits for the termination grace period of the evicted Pod if it is disabled", func() {
+			gc.gcConfig.EnableGCForEvictedPod = false
+			pod.Spec.TerminationGracePeriodSeconds = pointer.Int64(60)
+			evictedPod := newEvictedPod(pod, now)
+
+			podEntry, err := gc.buildPodEntry(nil, evictedPod, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podEntry.TracingGracefulTime).To(Equal(90 * time.Second))
+			Expect(podEntry.TracingStopTime).To(Equal(now.Add(90 * time.Second)))
+		})
+	})
+})
@@ -5,8 +5,14 @@ package gcmanager
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/informerstatus"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 )
 
 // startPodInformer will set up k8s pod informer in circle
@@ -15,15 +21,21 @@ func (s *SpiderGC) startPodInformer() {
 
 	for {
 		logger.Info("create Pod informer")
-		informerFactory := informers.NewSharedInformerFactory(s.k8ClientSet, 0)
+		informerFactory := s.newPodInformerFactory()
 		stopper := make(chan struct{})
 
 		podInformer := informerFactory.Core().V1().Pods().Informer()
-		podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    s.onPodAdd,
-			UpdateFunc: s.onPodUpdate,
-			DeleteFunc: s.onPodDel,
+		podInformer.AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: isTracedPod,
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    s.onPodAdd,
+				UpdateFunc: s.onPodUpdate,
+				DeleteFunc: s.onPodDel,
+			},
 		})
+		podInformer.AddEventHandler(informerstatus.Register(constant.KindPod, func() int {
+			return len(s.gcIPPoolIPSignal)
+		}).EventHandler())
 		go podInformer.Run(stopper)
 
 		<-stopper
@@ -31,6 +43,28 @@ func (s *SpiderGC) startPodInformer() {
 	}
 }
 
+// newPodInformerFactory returns the informer factory watching the Pods scoped
+// by the label and field selectors of the configuration.
+func (s *SpiderGC) newPodInformerFactory() informers.SharedInformerFactory {
+	return informers.NewSharedInformerFactoryWithOptions(s.k8ClientSet, 0,
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = s.gcConfig.PodLabelSelector
+			options.FieldSelector = s.gcConfig.PodFieldSelector
+		}))
+}
+
+// isTracedPod drops the events of the Pods in host network before any work,
+// they never get IP addresses from Spiderpool. The field is immutable, so a
+// Pod never moves in or out of the filter.
+func isTracedPod(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	pod, ok := obj.(*corev1.Pod)
+	return !ok || !podmanager.IsHostNetworkPod(pod)
+}
+
 // onPodAdd represents Pod informer Add Event
 func (s *SpiderGC) onPodAdd(obj interface{}) {
 	// backup controller could be elected as master
@@ -79,6 +113,14 @@ func (s *SpiderGC) onPodUpdate(oldObj interface{}, newObj interface{}) {
 		return
 	}
 
+	// The completed Pods are already traced on their completion, they are
+	// traced again only once they are deleted.
+	if podmanager.IsCompletedPod(oldPod) && oldPod.Status.Phase == pod.Status.Phase &&
+		oldPod.DeletionTimestamp == nil && pod.DeletionTimestamp == nil &&
+		pointer.Int64Equal(oldPod.Spec.TerminationGracePeriodSeconds, pod.Spec.TerminationGracePeriodSeconds) {
+		return
+	}
+
 	podEntry, err := s.buildPodEntry(oldPod, pod, false)
 	if nil != err {
 		logger.Sugar().Errorf("onPodUpdate: failed to build Pod Entry '%s/%s', error: %v", pod.Namespace, pod.Name, err)
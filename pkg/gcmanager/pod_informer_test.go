@@ -0,0 +1,69 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("PodInformer", Label("pod_informer_test"), func() {
+	var clientSet *fake.Clientset
+	var gc *SpiderGC
+
+	BeforeEach(func() {
+		clientSet = fake.NewSimpleClientset()
+		gc = &SpiderGC{
+			k8ClientSet: clientSet,
+			gcConfig:    &GarbageCollectionConfig{},
+		}
+	})
+
+	// listRestrictions starts the informer of the Pods, and returns the
+	// restrictions of its list request.
+	listRestrictions := func() k8stesting.ListRestrictions {
+		factory := gc.newPodInformerFactory()
+		factory.Core().V1().Pods().Informer()
+
+		stopper := make(chan struct{})
+		DeferCleanup(func() { close(stopper) })
+		factory.Start(stopper)
+		Expect(factory.WaitForCacheSync(stopper)).To(HaveEach(BeTrue()))
+
+		for _, action := range clientSet.Actions() {
+			if list, ok := action.(k8stesting.ListAction); ok && action.GetResource().Resource == "pods" {
+				return list.GetListRestrictions()
+			}
+		}
+		Fail("the Pods are not listed")
+
+		return k8stesting.ListRestrictions{}
+	}
+
+	It("watches all Pods without selectors", func() {
+		restrictions := listRestrictions()
+		Expect(restrictions.Labels.Empty()).To(BeTrue())
+		Expect(restrictions.Fields.Empty()).To(BeTrue())
+	})
+
+	It("watches the Pods not in host network in lite mode", func() {
+		gc.gcConfig.PodFieldSelector = "spec.hostNetwork=false"
+
+		restrictions := listRestrictions()
+		Expect(restrictions.Fields.String()).To(Equal("spec.hostNetwork=false"))
+		Expect(restrictions.Labels.Empty()).To(BeTrue())
+	})
+
+	It("watches the Pods matching both the label and the field selectors", func() {
+		gc.gcConfig.PodLabelSelector = "app=demo"
+		gc.gcConfig.PodFieldSelector = "spec.hostNetwork=false"
+
+		restrictions := listRestrictions()
+		Expect(restrictions.Labels.String()).To(Equal("app=demo"))
+		Expect(restrictions.Fields.String()).To(Equal("spec.hostNetwork=false"))
+	})
+
+})
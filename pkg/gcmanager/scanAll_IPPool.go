@@ -22,7 +22,7 @@ import (
 func (s *SpiderGC) monitorGCSignal(ctx context.Context) {
 	logger.Debug("start to monitor gc signal for CLI or default GC interval")
 
-	timer := time.NewTimer(time.Duration(s.gcConfig.DefaultGCIntervalDuration) * time.Second)
+	timer := s.clock.NewTimer(time.Duration(s.gcConfig.DefaultGCIntervalDuration) * time.Second)
 	defer timer.Stop()
 
 	logger.Debug("initial scan all for cluster firstly")
@@ -30,7 +30,7 @@ func (s *SpiderGC) monitorGCSignal(ctx context.Context) {
 
 	for {
 		select {
-		case <-timer.C:
+		case <-timer.C():
 			select {
 			// In concurrency situation, the backup controller must execute scanAll
 			case <-s.gcSignal:
@@ -48,11 +48,11 @@ func (s *SpiderGC) monitorGCSignal(ctx context.Context) {
 		case <-s.gcSignal:
 			logger.Info("receive CLI GC request, execute scan all right now!")
 			s.executeScanAll(ctx)
-			time.Sleep(time.Duration(s.gcConfig.GCSignalGapDuration) * time.Second)
+			s.clock.Sleep(time.Duration(s.gcConfig.GCSignalGapDuration) * time.Second)
 
 			// discard the concurrent signal
 			select {
-			case <-timer.C:
+			case <-timer.C():
 			default:
 			}
 
@@ -65,9 +65,18 @@ func (s *SpiderGC) monitorGCSignal(ctx context.Context) {
 	}
 }
 
-// executeScanAll scans the whole pod and whole IPPoolList
+// executeScanAll scans the whole pod and whole IPPoolList, the IPPools are
+// listed page by page to bound the memory on large clusters.
 func (s *SpiderGC) executeScanAll(ctx context.Context) {
-	poolList, err := s.ippoolMgr.ListIPPools(ctx)
+	timeRecorder := metrics.NewTimeRecorder()
+	defer func() {
+		metrics.RecordIPGCScanDuration(ctx, timeRecorder.SinceInSeconds())
+	}()
+
+	err := s.ippoolMgr.IterateIPPools(ctx, func(pool *spiderpoolv1.SpiderIPPool) error {
+		s.scanIPPool(ctx, pool)
+		return nil
+	})
 	if apierrors.IsNotFound(err) {
 		logger.Sugar().Warnf("scan all failed, ippoolList not found!")
 		return
@@ -77,111 +86,126 @@ func (s *SpiderGC) executeScanAll(ctx context.Context) {
 		logger.Sugar().Errorf("scan all failed: '%v'", err)
 		return
 	}
+}
 
-	for _, pool := range poolList.Items {
-		logger.Sugar().Debugf("checking IPPool '%s'", pool.Name)
+// scanIPPool checks the IP allocations of the IPPool one by one, and releases
+// the IPs which are leaked.
+func (s *SpiderGC) scanIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) {
+	logger.Sugar().Debugf("checking IPPool '%s'", pool.Name)
 
-		for poolIP, poolIPAllocation := range pool.Status.AllocatedIPs {
-			scanAllLogger := logger.With(zap.String("podNS", poolIPAllocation.Namespace), zap.String("podName", poolIPAllocation.Pod),
-				zap.String("containerID", poolIPAllocation.ContainerID), zap.String("NIC", poolIPAllocation.NIC))
+	for poolIP, poolIPAllocation := range pool.Status.AllocatedIPs {
+		scanAllLogger := logger.With(zap.String("podNS", poolIPAllocation.Namespace), zap.String("podName", poolIPAllocation.Pod),
+			zap.String("containerID", poolIPAllocation.ContainerID), zap.String("NIC", poolIPAllocation.NIC))
 
-			podYaml, err := s.podMgr.GetPodByName(ctx, poolIPAllocation.Namespace, poolIPAllocation.Pod)
-			if err != nil {
-				wrappedLog := scanAllLogger.With(zap.String("gc-reason", "pod not found in k8s but still exists in IPPool allocation"))
-
-				// case: The pod in IPPool's ip-allocationDetail is not exist in k8s
-				if apierrors.IsNotFound(err) {
-					// check StatefulSet pod whether need to clean up its IP and Endpoint or not
-					if s.gcConfig.EnableStatefulSet && poolIPAllocation.OwnerControllerType == constant.KindStatefulSet {
-						isValidStsPod, err := s.stsMgr.IsValidStatefulSetPod(ctx, poolIPAllocation.Namespace, poolIPAllocation.Pod, poolIPAllocation.OwnerControllerType)
-						if nil != err {
-							scanAllLogger.Sugar().Errorf("failed to check StatefulSet pod '%s/%s' IP '%s' should be cleaned or not, error: %v",
-								poolIPAllocation.Namespace, poolIPAllocation.Pod, poolIP, err)
-							continue
-						}
-
-						if isValidStsPod {
-							scanAllLogger.Sugar().Warnf("no deed to release IP '%s' for StatefulSet pod '%s/%s'",
-								poolIP, poolIPAllocation.Namespace, poolIPAllocation.Pod)
-							continue
-						}
-					}
+		podYaml, err := s.podMgr.GetPodByName(ctx, poolIPAllocation.Namespace, poolIPAllocation.Pod, constant.UseCache)
+		if err != nil {
+			wrappedLog := scanAllLogger.With(zap.String("gc-reason", "pod not found in k8s but still exists in IPPool allocation"))
 
-					err = s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation)
+			// case: The pod in IPPool's ip-allocationDetail is not exist in k8s
+			if apierrors.IsNotFound(err) {
+				// check StatefulSet pod whether need to clean up its IP and Endpoint or not
+				if s.gcConfig.EnableStatefulSet && poolIPAllocation.OwnerControllerType == constant.KindStatefulSet {
+					isValidStsPod, err := s.stsMgr.IsValidStatefulSetPod(ctx, poolIPAllocation.Namespace, poolIPAllocation.Pod, poolIPAllocation.OwnerControllerType)
 					if nil != err {
-						wrappedLog.Error(err.Error())
+						scanAllLogger.Sugar().Errorf("failed to check StatefulSet pod '%s/%s' IP '%s' should be cleaned or not, error: %v",
+							poolIPAllocation.Namespace, poolIPAllocation.Pod, poolIP, err)
+						continue
+					}
+
+					if isValidStsPod {
+						metrics.RecordIPGCSkip(ctx, gcSkipReasonStatefulSetPod)
+						scanAllLogger.Sugar().Warnf("no deed to release IP '%s' for StatefulSet pod '%s/%s'",
+							poolIP, poolIPAllocation.Namespace, poolIPAllocation.Pod)
 						continue
 					}
+				}
 
-					// clean up single IP and remove its corresponding SpiderEndpoint successfully, just continue to the next poolIP
+				err = s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), gcReasonPodNotFound, pool.Name, poolIP, poolIPAllocation)
+				if nil != err {
+					wrappedLog.Error(err.Error())
 					continue
 				}
 
-				wrappedLog.Sugar().Errorf("check pod from kubernetes failed with error '%v'", err)
+				// clean up single IP and remove its corresponding SpiderEndpoint successfully, just continue to the next poolIP
 				continue
 			}
 
-			// check pod status phase with its yaml
-			podEntry, err := s.buildPodEntry(nil, podYaml, false)
-			if nil != err {
-				scanAllLogger.Sugar().Errorf("failed to build podEntry '%s/%s' in scanAll, error: %v", poolIPAllocation.Namespace, poolIPAllocation.Pod, err)
-				continue
-			}
+			wrappedLog.Sugar().Errorf("check pod from kubernetes failed with error '%v'", err)
+			continue
+		}
+
+		// check pod status phase with its yaml
+		podEntry, err := s.buildPodEntry(nil, podYaml, false)
+		if nil != err {
+			scanAllLogger.Sugar().Errorf("failed to build podEntry '%s/%s' in scanAll, error: %v", poolIPAllocation.Namespace, poolIPAllocation.Pod, err)
+			continue
+		}
 
-			// case: The pod in IPPool's ip-allocationDetail is also exist in k8s, but the pod is in 'Terminating|Succeeded|Failed' status phase
-			if podEntry != nil {
-				if time.Now().UTC().After(podEntry.TracingStopTime) {
-					wrappedLog := scanAllLogger.With(zap.String("gc-reason", "pod is out of time"))
-					err = s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), pool.Name, poolIP, poolIPAllocation)
+		// case: The pod in IPPool's ip-allocationDetail is also exist in k8s, but the pod is in 'Terminating|Succeeded|Failed' status phase
+		if podEntry != nil {
+			if s.clock.Now().UTC().After(podEntry.TracingStopTime) {
+				wrappedLog := scanAllLogger.With(zap.String("gc-reason", "pod is out of time"))
+				err = s.releaseSingleIPAndRemoveWEPFinalizer(logutils.IntoContext(ctx, wrappedLog), string(podEntry.PodTracingReason), pool.Name, poolIP, poolIPAllocation)
+				if nil != err {
+					wrappedLog.Error(err.Error())
+					continue
+				}
+			} else {
+				// otherwise, flush the PodEntry database and let tracePodWorker to solve it if the current controller is elected master.
+				if s.leader.IsElected() {
+					err = s.PodDB.ApplyPodEntry(podEntry)
 					if nil != err {
-						wrappedLog.Error(err.Error())
+						scanAllLogger.Error(err.Error())
 						continue
 					}
-				} else {
-					// otherwise, flush the PodEntry database and let tracePodWorker to solve it if the current controller is elected master.
-					if s.leader.IsElected() {
-						err = s.PodDB.ApplyPodEntry(podEntry)
-						if nil != err {
-							scanAllLogger.Error(err.Error())
-							continue
-						}
-
-						scanAllLogger.With(zap.String("tracing-reason", string(podEntry.PodTracingReason))).
-							Sugar().Infof("update podEntry '%s/%s' successfully", poolIPAllocation.Namespace, poolIPAllocation.Pod)
-					}
+
+					scanAllLogger.With(zap.String("tracing-reason", string(podEntry.PodTracingReason))).
+						Sugar().Infof("update podEntry '%s/%s' successfully", poolIPAllocation.Namespace, poolIPAllocation.Pod)
 				}
-			} else {
-				endpoint, err := s.wepMgr.GetEndpointByName(ctx, podYaml.Namespace, podYaml.Name)
-				if err != nil {
-					scanAllLogger.Sugar().Errorf("failed to get Endpoint '%s/%s': %v", podYaml.Namespace, podYaml.Name, err)
+			}
+		} else {
+			endpoint, err := s.wepMgr.GetEndpointByName(ctx, podYaml.Namespace, podYaml.Name)
+			if err != nil {
+				scanAllLogger.Sugar().Errorf("failed to get Endpoint '%s/%s': %v", podYaml.Namespace, podYaml.Name, err)
+				continue
+			}
+
+			// case: The pod in IPPool's ip-allocationDetail is also exist in k8s, but the IP corresponding allocation containerID is different with wep current containerID
+			if endpoint.Status.Current != nil && endpoint.Status.Current.ContainerID != poolIPAllocation.ContainerID {
+				wrappedLog := scanAllLogger.With(zap.String("gc-reason", "IPPoolAllocation containerID is different with wep current containerID"))
+				if err := s.checkIPReleasable(logutils.IntoContext(ctx, wrappedLog), poolIPAllocation.Node, poolIP, poolIPAllocation.ContainerID); err != nil {
+					recordSkip(ctx, err)
+					wrappedLog.Sugar().Warnf("skip to release ip '%s': %v", poolIP, err)
 					continue
 				}
 
-				// case: The pod in IPPool's ip-allocationDetail is also exist in k8s, but the IP corresponding allocation containerID is different with wep current containerID
-				if endpoint.Status.Current != nil && endpoint.Status.Current.ContainerID != poolIPAllocation.ContainerID {
-					wrappedLog := scanAllLogger.With(zap.String("gc-reason", "IPPoolAllocation containerID is different with wep current containerID"))
-					// release IP but no need to remove wep finalizer
-					err = s.ippoolMgr.ReleaseIP(ctx, pool.Name, []types.IPAndCID{{
-						IP:          poolIP,
-						ContainerID: poolIPAllocation.ContainerID},
-					})
-					if nil != err {
-						wrappedLog.Sugar().Errorf("failed to release ip '%s', error: '%v'", poolIP, err)
-						continue
-					}
-
-					wrappedLog.Sugar().Infof("release ip '%s' successfully!", poolIP)
+				// release IP but no need to remove wep finalizer
+				err = s.ippoolMgr.ReleaseIP(ctx, pool.Name, []types.IPAndCID{{
+					IP:          poolIP,
+					ContainerID: poolIPAllocation.ContainerID},
+				})
+				if nil != err {
+					wrappedLog.Sugar().Errorf("failed to release ip '%s', error: '%v'", poolIP, err)
+					continue
 				}
+
+				metrics.RecordIPGCReclaim(ctx, gcReasonContainerIDChanged, 1)
+				wrappedLog.Sugar().Infof("release ip '%s' successfully!", poolIP)
 			}
 		}
-		logger.Sugar().Debugf("task checking IPPool '%s' is completed", pool.Name)
 	}
+	logger.Sugar().Debugf("task checking IPPool '%s' is completed", pool.Name)
 }
 
 // releaseSingleIPAndRemoveWEPFinalizer serves for handleTerminatingPod to gc singleIP and remove wep finalizer
-func (s *SpiderGC) releaseSingleIPAndRemoveWEPFinalizer(ctx context.Context, poolName, poolIP string, poolIPAllocation spiderpoolv1.PoolIPAllocation) error {
+func (s *SpiderGC) releaseSingleIPAndRemoveWEPFinalizer(ctx context.Context, reason, poolName, poolIP string, poolIPAllocation spiderpoolv1.PoolIPAllocation) error {
 	log := logutils.FromContext(ctx)
 
+	if err := s.checkIPReleasable(ctx, poolIPAllocation.Node, poolIP, poolIPAllocation.ContainerID); err != nil {
+		recordSkip(ctx, err)
+		return fmt.Errorf("skip to release IP '%s': %w", poolIP, err)
+	}
+
 	singleIP := []types.IPAndCID{{IP: poolIP, ContainerID: poolIPAllocation.ContainerID}}
 	err := s.ippoolMgr.ReleaseIP(ctx, poolName, singleIP)
 	if nil != err {
@@ -190,6 +214,7 @@ func (s *SpiderGC) releaseSingleIPAndRemoveWEPFinalizer(ctx context.Context, poo
 	}
 
 	metrics.IPGCTotalCounts.Add(ctx, 1)
+	metrics.RecordIPGCReclaim(ctx, reason, 1)
 	log.Sugar().Infof("release ip '%s' successfully", poolIP)
 
 	err = s.wepMgr.RemoveFinalizer(ctx, poolIPAllocation.Namespace, poolIPAllocation.Pod)
@@ -11,7 +11,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	metrics "github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/types"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
@@ -26,12 +28,13 @@ func (s *SpiderGC) tracePodWorker(ctx context.Context) {
 			return
 		default:
 			podEntryList := s.PodDB.ListAllPodEntries()
+			metrics.RecordIPGCCandidates(len(podEntryList), len(s.gcIPPoolIPSignal))
 			for _, podEntry := range podEntryList {
 				podCache := podEntry
 				s.handlePodEntryForTracingTimeOut(&podCache)
 			}
 
-			time.Sleep(time.Duration(s.gcConfig.TracePodGapDuration) * time.Second)
+			s.clock.Sleep(time.Duration(s.gcConfig.TracePodGapDuration) * time.Second)
 		}
 	}
 }
@@ -42,7 +45,7 @@ func (s *SpiderGC) handlePodEntryForTracingTimeOut(podEntry *PodEntry) {
 		logger.Sugar().Warnf("unknown podEntry: %+v", podEntry)
 		return
 	} else {
-		if time.Now().UTC().After(podEntry.TracingStopTime) {
+		if s.clock.Now().UTC().After(podEntry.TracingStopTime) {
 			logger.With(zap.Any("podEntry tracing-reason", podEntry.PodTracingReason)).
 				Sugar().Infof("pod '%s/%s' is out of time, begin to gc IP", podEntry.Namespace, podEntry.PodName)
 		} else {
@@ -56,7 +59,7 @@ func (s *SpiderGC) handlePodEntryForTracingTimeOut(podEntry *PodEntry) {
 		logger.Sugar().Debugf("sending signal to gc pod '%s/%s' IP", podEntry.Namespace, podEntry.PodName)
 		s.PodDB.DeletePodEntry(podEntry.Namespace, podEntry.PodName)
 
-	case <-time.After(time.Duration(s.gcConfig.GCSignalTimeoutDuration) * time.Second):
+	case <-s.clock.After(time.Duration(s.gcConfig.GCSignalTimeoutDuration) * time.Second):
 		logger.Sugar().Errorf("failed to gc IP, gcSignal:len=%d, event:'%s/%s' will be dropped", len(s.gcSignal), podEntry.Namespace, podEntry.PodName)
 	}
 }
@@ -83,8 +86,29 @@ func (s *SpiderGC) releaseIPPoolIPExecutor(ctx context.Context, workerIndex int)
 			// we need to gather the pod corresponding SpiderEndpoint to get the used history IPs.
 			podUsedIPs := workloadendpointmanager.ListAllHistoricalIPs(endpoint)
 
+			nodeName := podCache.NodeName
+			if nodeName == "" && endpoint.Status.Current != nil && endpoint.Status.Current.Node != nil {
+				nodeName = *endpoint.Status.Current.Node
+			}
+
 			// release pod used history IPs
-			for poolName, ips := range podUsedIPs {
+			skipped := false
+			for poolName, allIPs := range podUsedIPs {
+				var ips []types.IPAndCID
+				for _, ip := range allIPs {
+					if err := s.checkIPReleasable(logutils.IntoContext(ctx, loggerReleaseIP), nodeName, ip.IP, ip.ContainerID); err != nil {
+						loggerReleaseIP.Sugar().Warnf("skip to release ip '%s' of pod '%s/%s': %v",
+							ip.IP, podCache.Namespace, podCache.PodName, err)
+						recordSkip(ctx, err)
+						skipped = true
+						continue
+					}
+					ips = append(ips, ip)
+				}
+				if len(ips) == 0 {
+					continue
+				}
+
 				loggerReleaseIP.Sugar().Infof("pod '%s/%s used IPs '%+v' from pool '%s', begin to release",
 					podCache.Namespace, podCache.PodName, ips, poolName)
 
@@ -98,6 +122,13 @@ func (s *SpiderGC) releaseIPPoolIPExecutor(ctx context.Context, workerIndex int)
 
 				// metric
 				metrics.IPGCTotalCounts.Add(ctx, 1)
+				metrics.RecordIPGCReclaim(ctx, string(podCache.PodTracingReason), len(ips))
+			}
+
+			// keep the SpiderEndpoint and its finalizer, the skipped IPs will
+			// be released by the following scanAll.
+			if skipped {
+				continue
 			}
 
 			loggerReleaseIP.Sugar().Infof("release IPPoolIP task '%+v' successfully", *podCache)
@@ -0,0 +1,85 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package gcmanager
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+var _ = Describe("TracePodWorker", Label("tracepod_worker_test"), func() {
+	var fakeClock *clocktesting.FakeClock
+	var gc *SpiderGC
+	var podEntry *PodEntry
+
+	BeforeEach(func() {
+		fakeClock = clocktesting.NewFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		gc = &SpiderGC{
+			PodDB: NewPodDBer(10),
+			gcConfig: &GarbageCollectionConfig{
+				GCSignalTimeoutDuration: 5,
+			},
+			gcSignal:         make(chan struct{}, 1),
+			gcIPPoolIPSignal: make(chan *PodEntry, 1),
+			clock:            fakeClock,
+		}
+
+		podEntry = &PodEntry{
+			PodName:             "pod",
+			Namespace:           "default",
+			TracingStartTime:    fakeClock.Now(),
+			TracingGracefulTime: 30 * time.Second,
+			TracingStopTime:     fakeClock.Now().Add(30 * time.Second),
+			PodTracingReason:    constant.PodTerminating,
+		}
+		Expect(gc.PodDB.ApplyPodEntry(podEntry)).To(Succeed())
+	})
+
+	It("waits for the tracing stop time to release the IP addresses", func() {
+		gc.handlePodEntryForTracingTimeOut(podEntry)
+		Expect(gc.gcIPPoolIPSignal).To(BeEmpty())
+		Expect(gc.PodDB.ListAllPodEntries()).To(HaveLen(1))
+
+		fakeClock.Step(31 * time.Second)
+		gc.handlePodEntryForTracingTimeOut(podEntry)
+		Expect(gc.gcIPPoolIPSignal).To(Receive(Equal(podEntry)))
+		Expect(gc.PodDB.ListAllPodEntries()).To(BeEmpty())
+	})
+
+	It("keeps the pod entry if the signal times out", func() {
+		gc.gcIPPoolIPSignal <- &PodEntry{}
+		fakeClock.Step(31 * time.Second)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			gc.handlePodEntryForTracingTimeOut(podEntry)
+		}()
+
+		Eventually(fakeClock.HasWaiters).Should(BeTrue())
+		Consistently(done).ShouldNot(BeClosed())
+
+		fakeClock.Step(5 * time.Second)
+		Eventually(done).Should(BeClosed())
+		Expect(gc.PodDB.ListAllPodEntries()).To(HaveLen(1))
+	})
+
+	It("reports the timed out pods waiting for the workers", func() {
+		Expect(gc.QueueDepth()).To(Equal(0))
+
+		fakeClock.Step(31 * time.Second)
+		gc.handlePodEntryForTracingTimeOut(podEntry)
+		Expect(gc.QueueDepth()).To(Equal(1))
+	})
+
+	It("traces the evicted pod from now if it has no termination recorded", func() {
+		Expect(gc.evictedTime(&corev1.Pod{})).To(Equal(fakeClock.Now().UTC()))
+	})
+})
@@ -0,0 +1,182 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package informerstatus
+
+import (
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+// Status is the snapshot of an informer.
+type Status struct {
+	Name string
+	// QueueDepth is the number of events waiting in the work queue behind the informer.
+	QueueDepth int
+	// EventLag is the time between the latest write of an object and the
+	// informer event delivering it, for the latest event.
+	EventLag time.Duration
+	// LastEventTime is the time the latest event was delivered, zero if none.
+	LastEventTime time.Time
+}
+
+// Tracker records the events delivered by an informer.
+type Tracker struct {
+	name string
+
+	lock          lock.RWMutex
+	queueDepth    func() int
+	startTime     time.Time
+	eventLag      time.Duration
+	lastEventTime time.Time
+}
+
+var (
+	trackersLock lock.RWMutex
+	trackers     = map[string]*Tracker{}
+)
+
+// Register returns the Tracker of the informer called name, it is called
+// each time the informer is created. The queueDepth function reports the
+// depth of the work queue fed by the informer.
+func Register(name string, queueDepth func() int) *Tracker {
+	trackersLock.Lock()
+	defer trackersLock.Unlock()
+
+	t, ok := trackers[name]
+	if !ok {
+		t = &Tracker{name: name}
+		trackers[name] = t
+	}
+
+	t.lock.Lock()
+	t.queueDepth = queueDepth
+	t.startTime = time.Now()
+	t.lock.Unlock()
+
+	return t
+}
+
+// EventHandler returns the handler to add to the informer, in addition to
+// the ones of its controller.
+func (t *Tracker) EventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			// The initial list replays objects written before the informer
+			// starts, their age is not a lag.
+			writeTime, ok := lastWriteTime(obj)
+			t.lock.RLock()
+			startTime := t.startTime
+			t.lock.RUnlock()
+			if ok && writeTime.Before(startTime) {
+				t.observe(time.Time{})
+				return
+			}
+			t.observe(writeTime)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldAccessor, oldErr := meta.Accessor(oldObj)
+			newAccessor, newErr := meta.Accessor(newObj)
+			if oldErr == nil && newErr == nil && oldAccessor.GetResourceVersion() == newAccessor.GetResourceVersion() {
+				// resync
+				return
+			}
+			writeTime, _ := lastWriteTime(newObj)
+			t.observe(writeTime)
+		},
+		DeleteFunc: func(obj interface{}) {
+			// The deletion of an object doesn't always write it.
+			t.observe(time.Time{})
+		},
+	}
+}
+
+// observe records an event of an object last written at writeTime, the lag
+// is left unchanged if writeTime is zero.
+func (t *Tracker) observe(writeTime time.Time) {
+	now := time.Now()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.lastEventTime = now
+	if writeTime.IsZero() {
+		return
+	}
+
+	t.eventLag = now.Sub(writeTime)
+	if t.eventLag < 0 {
+		t.eventLag = 0
+	}
+}
+
+// Status returns the snapshot of the informer.
+func (t *Tracker) Status() Status {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	s := Status{
+		Name:          t.name,
+		EventLag:      t.eventLag,
+		LastEventTime: t.lastEventTime,
+	}
+	if t.queueDepth != nil {
+		s.QueueDepth = t.queueDepth()
+	}
+
+	return s
+}
+
+// List returns the snapshots of all registered informers, sorted by name.
+func List() []Status {
+	trackersLock.RLock()
+	defer trackersLock.RUnlock()
+
+	list := make([]Status, 0, len(trackers))
+	for _, t := range trackers {
+		list = append(list, t.Status())
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+// RecordMetrics reports the lag and queue depth of all registered informers.
+func RecordMetrics() {
+	for _, s := range List() {
+		attr := attribute.String("informer", s.Name)
+		metric.InformerEventLagSeconds.Record(s.Name, int64(s.EventLag.Seconds()), attr)
+		metric.InformerQueueDepth.Record(s.Name, int64(s.QueueDepth), attr)
+	}
+}
+
+// lastWriteTime returns the latest time obj was written, which is the latest
+// time of its managed fields, or its creation time.
+func lastWriteTime(obj interface{}) (time.Time, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	writeTime := accessor.GetCreationTimestamp().Time
+	for _, f := range accessor.GetManagedFields() {
+		if f.Time != nil && f.Time.After(writeTime) {
+			writeTime = f.Time.Time
+		}
+	}
+
+	return writeTime, !writeTime.IsZero()
+}
@@ -0,0 +1,16 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package informerstatus_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInformerStatus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "InformerStatus Suite", Label("informerstatus", "unitest"))
+}
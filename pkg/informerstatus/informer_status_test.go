@@ -0,0 +1,74 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package informerstatus_test
+
+import (
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/informerstatus"
+)
+
+var _ = Describe("InformerStatus", Label("informer_status_test"), func() {
+	newPod := func(resourceVersion string, writeTime time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "pod",
+				ResourceVersion:   resourceVersion,
+				CreationTimestamp: metav1.NewTime(writeTime.Add(-time.Hour)),
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					{Manager: "kubelet", Time: &metav1.Time{Time: writeTime}},
+				},
+			},
+		}
+	}
+
+	It("returns the same tracker for the recreated informer", func() {
+		t1 := informerstatus.Register("same", func() int { return 1 })
+		t2 := informerstatus.Register("same", func() int { return 2 })
+		Expect(t2).To(BeIdenticalTo(t1))
+		Expect(t2.Status().QueueDepth).To(Equal(2))
+	})
+
+	It("ignores the lag of objects written before the informer starts", func() {
+		tracker := informerstatus.Register("initial-list", nil)
+		tracker.EventHandler().OnAdd(newPod("1", time.Now().Add(-time.Hour)))
+
+		status := tracker.Status()
+		Expect(status.LastEventTime).NotTo(BeZero())
+		Expect(status.EventLag).To(BeZero())
+	})
+
+	It("records the lag of updates", func() {
+		tracker := informerstatus.Register("update", func() int { return 3 })
+		handler := tracker.EventHandler()
+
+		handler.OnUpdate(newPod("1", time.Now()), newPod("1", time.Now().Add(-time.Minute)))
+		Expect(tracker.Status().LastEventTime).To(BeZero())
+
+		handler.OnUpdate(newPod("1", time.Now()), newPod("2", time.Now().Add(-time.Minute)))
+		status := tracker.Status()
+		Expect(status.Name).To(Equal("update"))
+		Expect(status.QueueDepth).To(Equal(3))
+		Expect(status.EventLag).To(BeNumerically(">=", time.Minute))
+		Expect(status.LastEventTime).NotTo(BeZero())
+	})
+
+	It("lists the informers sorted by name", func() {
+		informerstatus.Register("list-b", nil)
+		informerstatus.Register("list-a", nil)
+
+		var names []string
+		for _, s := range informerstatus.List() {
+			names = append(names, s.Name)
+		}
+		Expect(names).To(ContainElements("list-a", "list-b"))
+		Expect(sort.StringsAreSorted(names)).To(BeTrue())
+	})
+})
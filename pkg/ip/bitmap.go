@@ -0,0 +1,148 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip
+
+import (
+	"math/bits"
+	"net"
+	"net/netip"
+)
+
+// AllocationBitmap tracks the allocation state of a fixed group of IP
+// addresses, e.g. the total IP addresses of an IPPool, with one bit per
+// IP address in ascending order.
+//
+// AllocationBitmap is not safe for concurrent use.
+type AllocationBitmap struct {
+	ips       []net.IP
+	index     map[netip.Addr]int
+	words     []uint64
+	allocated int
+}
+
+// NewAllocationBitmap creates an AllocationBitmap of the distinct IP
+// addresses, all of them are free initially.
+func NewAllocationBitmap(ips []net.IP) *AllocationBitmap {
+	sorted := NewIPSet(ips...).List(true)
+	b := &AllocationBitmap{
+		ips:   sorted,
+		index: make(map[netip.Addr]int, len(sorted)),
+		words: make([]uint64, (len(sorted)+63)/64),
+	}
+	for i, ip := range sorted {
+		addr, _ := toAddr(ip)
+		b.index[addr] = i
+	}
+
+	return b
+}
+
+// Allocate marks the IP address allocated, it reports false if the IP
+// address is out of the bitmap or has already been allocated.
+func (b *AllocationBitmap) Allocate(ip net.IP) bool {
+	i, ok := b.indexOf(ip)
+	if !ok || b.isSet(i) {
+		return false
+	}
+
+	b.words[i/64] |= 1 << (i % 64)
+	b.allocated++
+
+	return true
+}
+
+// Release marks the IP address free, it reports false if the IP address
+// is out of the bitmap or is not allocated.
+func (b *AllocationBitmap) Release(ip net.IP) bool {
+	i, ok := b.indexOf(ip)
+	if !ok || !b.isSet(i) {
+		return false
+	}
+
+	b.words[i/64] &^= 1 << (i % 64)
+	b.allocated--
+
+	return true
+}
+
+// IsAllocated reports whether the IP address is allocated.
+func (b *AllocationBitmap) IsAllocated(ip net.IP) bool {
+	i, ok := b.indexOf(ip)
+	return ok && b.isSet(i)
+}
+
+// Contains reports whether the IP address is tracked by the bitmap.
+func (b *AllocationBitmap) Contains(ip net.IP) bool {
+	_, ok := b.indexOf(ip)
+	return ok
+}
+
+// Len returns the number of the IP addresses tracked by the bitmap.
+func (b *AllocationBitmap) Len() int {
+	return len(b.ips)
+}
+
+// AllocatedCount returns the number of the allocated IP addresses.
+func (b *AllocationBitmap) AllocatedCount() int {
+	return b.allocated
+}
+
+// FreeCount returns the number of the free IP addresses.
+func (b *AllocationBitmap) FreeCount() int {
+	return len(b.ips) - b.allocated
+}
+
+// FirstFree returns the lowest free IP address, or nil if there is none.
+func (b *AllocationBitmap) FirstFree() net.IP {
+	for w, word := range b.words {
+		if word == ^uint64(0) {
+			continue
+		}
+
+		i := w*64 + bits.TrailingZeros64(^word)
+		if i < len(b.ips) {
+			return b.ips[i]
+		}
+	}
+
+	return nil
+}
+
+// FreeIPs returns the free IP addresses in ascending order.
+func (b *AllocationBitmap) FreeIPs() []net.IP {
+	ips := make([]net.IP, 0, b.FreeCount())
+	for i, ip := range b.ips {
+		if !b.isSet(i) {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+// AllocatedIPs returns the allocated IP addresses in ascending order.
+func (b *AllocationBitmap) AllocatedIPs() []net.IP {
+	ips := make([]net.IP, 0, b.allocated)
+	for i, ip := range b.ips {
+		if b.isSet(i) {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+func (b *AllocationBitmap) indexOf(ip net.IP) (int, bool) {
+	addr, ok := toAddr(ip)
+	if !ok {
+		return 0, false
+	}
+
+	i, ok := b.index[addr]
+	return i, ok
+}
+
+func (b *AllocationBitmap) isSet(i int) bool {
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
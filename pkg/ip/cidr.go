@@ -5,7 +5,9 @@ package ip
 
 import (
 	"fmt"
+	"math/big"
 	"net"
+	"strings"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/types"
@@ -102,3 +104,84 @@ func IsIPv6CIDR(subnet string) bool {
 
 	return ip.To4() == nil
 }
+
+// ConvertIPsToCIDRs converts the IP address slices of the specified IP
+// version into the fewest sorted CIDRs which cover exactly these IP
+// addresses, like "172.18.40.0/30" for 172.18.40.0-172.18.40.3.
+func ConvertIPsToCIDRs(version types.IPVersion, ips []net.IP) ([]string, error) {
+	ipRanges, err := ConvertIPsToIPRanges(version, ips)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := 32
+	if version == constant.IPv6 {
+		bits = 128
+	}
+
+	var cidrs []string
+	for _, r := range ipRanges {
+		startStr, endStr, found := strings.Cut(r, "-")
+		if !found {
+			endStr = startStr
+		}
+		start := ipToInt(net.ParseIP(startStr))
+		end := ipToInt(net.ParseIP(endStr))
+
+		for start.Cmp(end) <= 0 {
+			// The largest block aligned on the start address which
+			// doesn't exceed the end of the range.
+			size := int(start.TrailingZeroBits())
+			if start.Sign() == 0 {
+				size = bits
+			}
+			for ; size > 0; size-- {
+				last := new(big.Int).Lsh(big.NewInt(1), uint(size))
+				last.Add(last, start).Sub(last, big.NewInt(1))
+				if last.Cmp(end) <= 0 {
+					break
+				}
+			}
+
+			ip := net.IP(start.FillBytes(make([]byte, bits/8)))
+			cidrs = append(cidrs, fmt.Sprintf("%s/%d", ip, bits-size))
+			start.Add(start, new(big.Int).Lsh(big.NewInt(1), uint(size)))
+		}
+	}
+
+	return cidrs, nil
+}
+
+// MaxSplitCIDRBits limits the sub-prefixes SplitCIDR splits a subnet into to
+// 2^16.
+const MaxSplitCIDRBits = 16
+
+// SplitCIDR splits the subnet of the specified IP version into the sorted
+// sub-prefixes of the prefix length, like "172.18.40.0/28" and
+// "172.18.40.16/28" for "172.18.40.0/27" with the prefix length 28.
+func SplitCIDR(version types.IPVersion, subnet string, prefixLength int) ([]*net.IPNet, error) {
+	ipNet, err := ParseCIDR(version, subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if prefixLength < ones || prefixLength > bits {
+		return nil, fmt.Errorf("%w, prefix length %d is out of the range [%d, %d] of subnet '%s'", ErrInvalidCIDRFormat, prefixLength, ones, bits, subnet)
+	}
+	if prefixLength-ones > MaxSplitCIDRBits {
+		return nil, fmt.Errorf("%w, subnet '%s' is split into more than 2^%d prefixes of length %d", ErrInvalidCIDRFormat, subnet, MaxSplitCIDRBits, prefixLength)
+	}
+
+	start := ipToInt(ipNet.IP)
+	step := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixLength))
+	prefixes := make([]*net.IPNet, 0, 1<<(prefixLength-ones))
+	for i := 0; i < 1<<(prefixLength-ones); i++ {
+		ip := make(net.IP, bits/8)
+		start.FillBytes(ip)
+		prefixes = append(prefixes, &net.IPNet{IP: ip, Mask: net.CIDRMask(prefixLength, bits)})
+		start.Add(start, step)
+	}
+
+	return prefixes, nil
+}
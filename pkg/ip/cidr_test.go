@@ -183,4 +183,85 @@ var _ = Describe("CIDR", Label("cidr_test"), func() {
 			Expect(spiderpoolip.IsIPv6CIDR("abcd:1234::/120")).To(BeTrue())
 		})
 	})
+
+	Describe("Test ConvertIPsToCIDRs", func() {
+		When("Verifying", func() {
+			It("inputs invalid IP version", func() {
+				cidrs, err := spiderpoolip.ConvertIPsToCIDRs(constant.InvalidIPVersion, nil)
+				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPVersion))
+				Expect(cidrs).To(BeNil())
+			})
+
+			It("inputs IP addresses of the other IP version", func() {
+				cidrs, err := spiderpoolip.ConvertIPsToCIDRs(constant.IPv4, []net.IP{net.ParseIP("abcd:1234::1")})
+				Expect(err).To(MatchError(spiderpoolip.ErrInvalidIP))
+				Expect(cidrs).To(BeNil())
+			})
+		})
+
+		It("converts IPv4 addresses to CIDRs", func() {
+			ips, err := spiderpoolip.ParseIPRanges(constant.IPv4, []string{
+				"172.18.40.1-172.18.40.10",
+				"172.18.40.12",
+				"172.18.40.16-172.18.40.31",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cidrs, err := spiderpoolip.ConvertIPsToCIDRs(constant.IPv4, ips)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrs).To(Equal([]string{
+				"172.18.40.1/32",
+				"172.18.40.2/31",
+				"172.18.40.4/30",
+				"172.18.40.8/31",
+				"172.18.40.10/32",
+				"172.18.40.12/32",
+				"172.18.40.16/28",
+			}))
+		})
+
+		It("converts IPv6 addresses to CIDRs", func() {
+			ips, err := spiderpoolip.ParseIPRanges(constant.IPv6, []string{"abcd:1234::-abcd:1234::ff", "abcd:1234::101"})
+			Expect(err).NotTo(HaveOccurred())
+
+			cidrs, err := spiderpoolip.ConvertIPsToCIDRs(constant.IPv6, ips)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cidrs).To(Equal([]string{"abcd:1234::/120", "abcd:1234::101/128"}))
+		})
+	})
+	Describe("Test SplitCIDR", func() {
+		It("inputs invalid subnet", func() {
+			prefixes, err := spiderpoolip.SplitCIDR(constant.IPv4, constant.InvalidCIDR, 28)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(prefixes).To(BeNil())
+		})
+
+		It("inputs the prefix length shorter than the subnet", func() {
+			prefixes, err := spiderpoolip.SplitCIDR(constant.IPv4, "172.18.40.0/27", 26)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(prefixes).To(BeNil())
+		})
+
+		It("splits the subnet into too many prefixes", func() {
+			prefixes, err := spiderpoolip.SplitCIDR(constant.IPv6, "abcd:1234::/32", 64)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(prefixes).To(BeNil())
+		})
+
+		It("splits IPv4 subnet", func() {
+			prefixes, err := spiderpoolip.SplitCIDR(constant.IPv4, "172.18.40.0/27", 28)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefixes).To(HaveLen(2))
+			Expect(prefixes[0].String()).To(Equal("172.18.40.0/28"))
+			Expect(prefixes[1].String()).To(Equal("172.18.40.16/28"))
+		})
+
+		It("splits IPv6 subnet", func() {
+			prefixes, err := spiderpoolip.SplitCIDR(constant.IPv6, "::/62", 64)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefixes).To(HaveLen(4))
+			Expect(prefixes[0].String()).To(Equal("::/64"))
+			Expect(prefixes[3].String()).To(Equal("0:0:0:3::/64"))
+		})
+	})
 })
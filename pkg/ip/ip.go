@@ -4,11 +4,9 @@
 package ip
 
 import (
-	"bytes"
 	"fmt"
 	"math/big"
 	"net"
-	"sort"
 
 	"github.com/asaskevich/govalidator"
 
@@ -83,31 +81,7 @@ func IsIP(version types.IPVersion, s string) error {
 //
 // If sorted is true, the result set of IP addresses will be sorted.
 func IPsDiffSet(ips1, ips2 []net.IP, sorted bool) []net.IP {
-	var ips []net.IP
-	marks := make(map[string]bool)
-	for _, ip := range ips1 {
-		if ip != nil {
-			marks[ip.String()] = true
-		}
-	}
-
-	for _, ip := range ips2 {
-		if ip != nil {
-			delete(marks, ip.String())
-		}
-	}
-
-	for k := range marks {
-		ips = append(ips, net.ParseIP(k))
-	}
-
-	if sorted {
-		sort.Slice(ips, func(i, j int) bool {
-			return bytes.Compare(ips[i].To16(), ips[j].To16()) < 0
-		})
-	}
-
-	return ips
+	return NewIPSet(ips1...).Difference(NewIPSet(ips2...)).List(sorted)
 }
 
 // IPsUnionSet calculates the union set of two IP address slices.
@@ -116,26 +90,7 @@ func IPsDiffSet(ips1, ips2 []net.IP, sorted bool) []net.IP {
 //
 // If sorted is true, the result set of IP addresses will be sorted.
 func IPsUnionSet(ips1, ips2 []net.IP, sorted bool) []net.IP {
-	var ips []net.IP
-	marks := make(map[string]bool)
-	ips1 = append(ips1, ips2...)
-	for _, ip := range ips1 {
-		if ip != nil {
-			marks[ip.String()] = true
-		}
-	}
-
-	for k := range marks {
-		ips = append(ips, net.ParseIP(k))
-	}
-
-	if sorted {
-		sort.Slice(ips, func(i, j int) bool {
-			return bytes.Compare(ips[i].To16(), ips[j].To16()) < 0
-		})
-	}
-
-	return ips
+	return NewIPSet(ips1...).Union(NewIPSet(ips2...)).List(sorted)
 }
 
 // IPsIntersectionSet calculates the intersection set of two IP address
@@ -144,32 +99,7 @@ func IPsUnionSet(ips1, ips2 []net.IP, sorted bool) []net.IP {
 //
 // If sorted is true, the result set of IP addresses will be sorted.
 func IPsIntersectionSet(ips1, ips2 []net.IP, sorted bool) []net.IP {
-	var ips []net.IP
-	set := make(map[string]bool)
-	for _, ip := range ips1 {
-		if ip != nil {
-			set[ip.String()] = true
-		}
-	}
-
-	marks := make(map[string]bool)
-	for _, ip := range ips2 {
-		if ip != nil && set[ip.String()] {
-			marks[ip.String()] = true
-		}
-	}
-
-	for k := range marks {
-		ips = append(ips, net.ParseIP(k))
-	}
-
-	if sorted {
-		sort.Slice(ips, func(i, j int) bool {
-			return bytes.Compare(ips[i].To16(), ips[j].To16()) < 0
-		})
-	}
-
-	return ips
+	return NewIPSet(ips1...).Intersection(NewIPSet(ips2...)).List(sorted)
 }
 
 // NextIP returns the next IP address.
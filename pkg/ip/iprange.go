@@ -4,10 +4,9 @@
 package ip
 
 import (
-	"bytes"
 	"fmt"
+	"math/big"
 	"net"
-	"sort"
 	"strings"
 
 	"github.com/asaskevich/govalidator"
@@ -70,6 +69,22 @@ func ParseIPRange(version types.IPVersion, ipRange string) ([]net.IP, error) {
 	return ips, nil
 }
 
+// IPRangeSize returns the number of IP addresses in the IP range of the
+// specified IP version, without parsing it as an IP address slices.
+func IPRangeSize(version types.IPVersion, ipRange string) (*big.Int, error) {
+	if err := IsIPRange(version, ipRange); err != nil {
+		return nil, err
+	}
+
+	start, end, found := strings.Cut(ipRange, "-")
+	if !found {
+		return big.NewInt(1), nil
+	}
+
+	size := big.NewInt(0).Sub(ipToInt(net.ParseIP(end)), ipToInt(net.ParseIP(start)))
+	return size.Add(size, big.NewInt(1)), nil
+}
+
 // ConvertIPsToIPRanges converts the IP address slices of the specified
 // IP version into a group of distinct, sorted and merged IP ranges.
 func ConvertIPsToIPRanges(version types.IPVersion, ips []net.IP) ([]string, error) {
@@ -77,23 +92,13 @@ func ConvertIPsToIPRanges(version types.IPVersion, ips []net.IP) ([]string, erro
 		return nil, err
 	}
 
-	set := map[string]struct{}{}
 	for _, ip := range ips {
 		if (version == constant.IPv4 && ip.To4() == nil) ||
 			(version == constant.IPv6 && ip.To4() != nil) {
 			return nil, fmt.Errorf("%wv%d IP '%s'", ErrInvalidIP, version, ip.String())
 		}
-		set[ip.String()] = struct{}{}
 	}
-
-	ips = ips[0:0]
-	for v := range set {
-		ips = append(ips, net.ParseIP(v))
-	}
-
-	sort.Slice(ips, func(i, j int) bool {
-		return bytes.Compare(ips[i].To16(), ips[j].To16()) < 0
-	})
+	ips = NewIPSet(ips...).List(true)
 
 	var ipRanges []string
 	var start, end int
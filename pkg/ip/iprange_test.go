@@ -168,6 +168,28 @@ var _ = Describe("IP range", Label("ip_range_test"), func() {
 		})
 	})
 
+	Describe("Test IPRangeSize", func() {
+		It("inputs invalid IP ranges", func() {
+			size, err := spiderpoolip.IPRangeSize(constant.IPv4, constant.InvalidIPRange)
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidIPRangeFormat))
+			Expect(size).To(BeNil())
+		})
+
+		It("counts the IP addresses of IP ranges", func() {
+			size, err := spiderpoolip.IPRangeSize(constant.IPv4, "172.18.40.10")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size.Int64()).To(Equal(int64(1)))
+
+			size, err = spiderpoolip.IPRangeSize(constant.IPv4, "172.18.40.1-172.18.41.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size.Int64()).To(Equal(int64(256)))
+
+			size, err = spiderpoolip.IPRangeSize(constant.IPv6, "::-ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size.BitLen()).To(Equal(129))
+		})
+	})
+
 	Describe("Test ConvertIPsToIPRanges", func() {
 		When("Verifying", func() {
 			It("inputs invalid IP version", func() {
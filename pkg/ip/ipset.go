@@ -0,0 +1,182 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip
+
+import (
+	"net"
+	"net/netip"
+	"sort"
+)
+
+// set is a generic set of comparable elements, the set operations of
+// IPSet are built on it.
+type set[T comparable] map[T]struct{}
+
+func (s set[T]) insert(items ...T) {
+	for _, item := range items {
+		s[item] = struct{}{}
+	}
+}
+
+func (s set[T]) has(item T) bool {
+	_, ok := s[item]
+	return ok
+}
+
+func union[T comparable](s1, s2 set[T]) set[T] {
+	result := make(set[T], len(s1)+len(s2))
+	for item := range s1 {
+		result[item] = struct{}{}
+	}
+	for item := range s2 {
+		result[item] = struct{}{}
+	}
+
+	return result
+}
+
+func difference[T comparable](s1, s2 set[T]) set[T] {
+	result := make(set[T], len(s1))
+	for item := range s1 {
+		if !s2.has(item) {
+			result[item] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+func intersection[T comparable](s1, s2 set[T]) set[T] {
+	// Walk the smaller one.
+	if len(s1) > len(s2) {
+		s1, s2 = s2, s1
+	}
+
+	result := make(set[T], len(s1))
+	for item := range s1 {
+		if s2.has(item) {
+			result[item] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// IPSet is a set of distinct IP addresses. IPv4 addresses are identical
+// to their IPv4-mapped IPv6 forms, the same as net.IP.Equal reports. The
+// zero value is not usable, create it with NewIPSet.
+//
+// IPSet is not safe for concurrent use.
+type IPSet struct {
+	addrs set[netip.Addr]
+}
+
+// NewIPSet creates an IPSet with the IP addresses, nil ones are ignored.
+func NewIPSet(ips ...net.IP) *IPSet {
+	s := &IPSet{addrs: make(set[netip.Addr], len(ips))}
+	s.Insert(ips...)
+
+	return s
+}
+
+// Insert adds the IP addresses to the set, nil ones are ignored.
+func (s *IPSet) Insert(ips ...net.IP) {
+	for _, ip := range ips {
+		if addr, ok := toAddr(ip); ok {
+			s.addrs.insert(addr)
+		}
+	}
+}
+
+// Delete removes the IP addresses from the set.
+func (s *IPSet) Delete(ips ...net.IP) {
+	for _, ip := range ips {
+		if addr, ok := toAddr(ip); ok {
+			delete(s.addrs, addr)
+		}
+	}
+}
+
+// Contains reports whether the IP address is in the set.
+func (s *IPSet) Contains(ip net.IP) bool {
+	addr, ok := toAddr(ip)
+	if !ok {
+		return false
+	}
+
+	return s.addrs.has(addr)
+}
+
+// Len returns the number of the IP addresses in the set.
+func (s *IPSet) Len() int {
+	return len(s.addrs)
+}
+
+// Union returns a new set of the IP addresses in either s or other.
+func (s *IPSet) Union(other *IPSet) *IPSet {
+	return &IPSet{addrs: union(s.addrs, other.addrs)}
+}
+
+// Difference returns a new set of the IP addresses in s but not in other.
+func (s *IPSet) Difference(other *IPSet) *IPSet {
+	return &IPSet{addrs: difference(s.addrs, other.addrs)}
+}
+
+// Intersection returns a new set of the IP addresses in both s and other.
+func (s *IPSet) Intersection(other *IPSet) *IPSet {
+	return &IPSet{addrs: intersection(s.addrs, other.addrs)}
+}
+
+// Range calls f sequentially for each IP address in the set, in no
+// particular order. If f returns false, Range stops the iteration.
+func (s *IPSet) Range(f func(ip net.IP) bool) {
+	for addr := range s.addrs {
+		if !f(toIP(addr)) {
+			return
+		}
+	}
+}
+
+// List returns the IP addresses in the set in their 16-byte forms, or nil
+// if the set is empty. If sorted is true, the IP addresses are sorted in
+// ascending order.
+func (s *IPSet) List(sorted bool) []net.IP {
+	if len(s.addrs) == 0 {
+		return nil
+	}
+
+	addrs := make([]netip.Addr, 0, len(s.addrs))
+	for addr := range s.addrs {
+		addrs = append(addrs, addr)
+	}
+	if sorted {
+		sort.Slice(addrs, func(i, j int) bool {
+			return addrs[i].Less(addrs[j])
+		})
+	}
+
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, toIP(addr))
+	}
+
+	return ips
+}
+
+// toAddr converts net.IP to netip.Addr, the IPv4-mapped IPv6 addresses
+// are unmapped so as to be identical with the IPv4 ones.
+func toAddr(ip net.IP) (netip.Addr, bool) {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return netip.Addr{}, false
+	}
+
+	return addr.Unmap(), true
+}
+
+// toIP converts netip.Addr to net.IP in 16-byte form, the same as the one
+// returned by net.ParseIP.
+func toIP(addr netip.Addr) net.IP {
+	return net.IP(addr.AsSlice()).To16()
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip_test
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+)
+
+var _ = Describe("IPSet", Label("ipset_test"), func() {
+	var set1, set2 *spiderpoolip.IPSet
+
+	BeforeEach(func() {
+		set1 = spiderpoolip.NewIPSet(
+			net.IPv4(172, 18, 40, 1),
+			net.IPv4(172, 18, 40, 2),
+		)
+		set2 = spiderpoolip.NewIPSet(
+			net.IPv4(172, 18, 40, 2),
+			net.IPv4(172, 18, 40, 3),
+		)
+	})
+
+	It("treats the IPv4 address and its IPv4-mapped IPv6 form as identical", func() {
+		set := spiderpoolip.NewIPSet(net.ParseIP("172.18.40.1").To4(), net.IPv4(172, 18, 40, 1), nil)
+		Expect(set.Len()).To(Equal(1))
+		Expect(set.Contains(net.ParseIP("172.18.40.1").To4())).To(BeTrue())
+		Expect(set.Contains(nil)).To(BeFalse())
+	})
+
+	It("inserts and deletes IP addresses", func() {
+		set1.Insert(net.IPv4(172, 18, 40, 10))
+		Expect(set1.Contains(net.IPv4(172, 18, 40, 10))).To(BeTrue())
+
+		set1.Delete(net.IPv4(172, 18, 40, 1), net.IPv4(172, 18, 40, 10))
+		Expect(set1.List(true)).To(Equal([]net.IP{net.IPv4(172, 18, 40, 2)}))
+	})
+
+	It("calculates the union set", func() {
+		Expect(set1.Union(set2).List(true)).To(Equal([]net.IP{
+			net.IPv4(172, 18, 40, 1),
+			net.IPv4(172, 18, 40, 2),
+			net.IPv4(172, 18, 40, 3),
+		}))
+		Expect(set1.Len()).To(Equal(2))
+	})
+
+	It("calculates the difference set", func() {
+		Expect(set1.Difference(set2).List(true)).To(Equal([]net.IP{net.IPv4(172, 18, 40, 1)}))
+	})
+
+	It("calculates the intersection set", func() {
+		Expect(set1.Intersection(set2).List(true)).To(Equal([]net.IP{net.IPv4(172, 18, 40, 2)}))
+		Expect(set2.Intersection(set1).List(true)).To(Equal([]net.IP{net.IPv4(172, 18, 40, 2)}))
+	})
+
+	It("lists nil for the empty set", func() {
+		Expect(spiderpoolip.NewIPSet().List(true)).To(BeNil())
+	})
+
+	It("ranges over the IP addresses until stopped", func() {
+		var count int
+		set1.Union(set2).Range(func(ip net.IP) bool {
+			count++
+			return count < 2
+		})
+		Expect(count).To(Equal(2))
+	})
+
+	It("assembles the total IP addresses as IPSet", func() {
+		set, err := spiderpoolip.AssembleTotalIPSet(constant.IPv4, []string{"172.18.40.1-172.18.40.3"}, []string{"172.18.40.2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.List(true)).To(Equal([]net.IP{
+			net.IPv4(172, 18, 40, 1),
+			net.IPv4(172, 18, 40, 3),
+		}))
+	})
+})
+
+var _ = Describe("AllocationBitmap", Label("ipset_test"), func() {
+	var bitmap *spiderpoolip.AllocationBitmap
+
+	BeforeEach(func() {
+		ips, err := spiderpoolip.ParseIPRange(constant.IPv4, "172.18.40.1-172.18.40.100")
+		Expect(err).NotTo(HaveOccurred())
+		bitmap = spiderpoolip.NewAllocationBitmap(ips)
+	})
+
+	It("allocates and releases IP addresses", func() {
+		Expect(bitmap.Len()).To(Equal(100))
+		Expect(bitmap.Allocate(net.IPv4(172, 18, 40, 70))).To(BeTrue())
+		Expect(bitmap.Allocate(net.IPv4(172, 18, 40, 70))).To(BeFalse())
+		Expect(bitmap.Allocate(net.IPv4(172, 18, 40, 200))).To(BeFalse())
+		Expect(bitmap.IsAllocated(net.IPv4(172, 18, 40, 70))).To(BeTrue())
+		Expect(bitmap.AllocatedCount()).To(Equal(1))
+		Expect(bitmap.FreeCount()).To(Equal(99))
+		Expect(bitmap.AllocatedIPs()).To(Equal([]net.IP{net.IPv4(172, 18, 40, 70)}))
+
+		Expect(bitmap.Release(net.IPv4(172, 18, 40, 70))).To(BeTrue())
+		Expect(bitmap.Release(net.IPv4(172, 18, 40, 70))).To(BeFalse())
+		Expect(bitmap.FreeCount()).To(Equal(100))
+	})
+
+	It("finds the lowest free IP address across words", func() {
+		for i := 1; i <= 65; i++ {
+			Expect(bitmap.Allocate(net.IPv4(172, 18, 40, byte(i)))).To(BeTrue())
+		}
+		Expect(bitmap.FirstFree()).To(Equal(net.IPv4(172, 18, 40, 66)))
+
+		for i := 66; i <= 100; i++ {
+			bitmap.Allocate(net.IPv4(172, 18, 40, byte(i)))
+		}
+		Expect(bitmap.FirstFree()).To(BeNil())
+		Expect(bitmap.FreeIPs()).To(BeEmpty())
+	})
+})
+
+func benchmarkIPs(b *testing.B, ipRange string) []net.IP {
+	ips, err := spiderpoolip.ParseIPRange(constant.IPv4, ipRange)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return ips
+}
+
+func BenchmarkIPsDiffSet(b *testing.B) {
+	ips1 := benchmarkIPs(b, "10.0.0.1-10.0.255.254")
+	ips2 := benchmarkIPs(b, "10.0.128.1-10.0.255.254")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		spiderpoolip.IPsDiffSet(ips1, ips2, true)
+	}
+}
+
+func BenchmarkIPSetIntersection(b *testing.B) {
+	set1 := spiderpoolip.NewIPSet(benchmarkIPs(b, "10.0.0.1-10.0.255.254")...)
+	set2 := spiderpoolip.NewIPSet(benchmarkIPs(b, "10.0.128.1-10.0.255.254")...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set1.Intersection(set2)
+	}
+}
+
+func BenchmarkAllocationBitmapFirstFree(b *testing.B) {
+	ips := benchmarkIPs(b, "10.0.0.1-10.0.255.254")
+	bitmap := spiderpoolip.NewAllocationBitmap(ips)
+	for _, ip := range ips[:len(ips)-1] {
+		bitmap.Allocate(ip)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bitmap.FirstFree()
+	}
+}
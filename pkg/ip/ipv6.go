@@ -0,0 +1,73 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// EUI64IP returns the IPv6 address of the subnet whose interface identifier
+// is derived from the 48-bit MAC address in the modified EUI-64 format, as
+// described in RFC 4291. The prefix length of the subnet must be at most 64.
+func EUI64IP(subnet, mac string) (net.IP, error) {
+	ipNet, err := ParseCIDR(constant.IPv6, subnet)
+	if err != nil {
+		return nil, err
+	}
+	if ones, _ := ipNet.Mask.Size(); ones > 64 {
+		return nil, fmt.Errorf("%w, the prefix length of IPv6 subnet '%s' must be at most 64 for EUI-64", ErrInvalidCIDRFormat, subnet)
+	}
+
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != 6 {
+		return nil, fmt.Errorf("%w '%s', must be a 48-bit MAC address", ErrInvalidMACFormat, mac)
+	}
+
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, ipNet.IP.To16()[:8])
+	ip[8] = hw[0] ^ 0x02
+	ip[9] = hw[1]
+	ip[10] = hw[2]
+	ip[11] = 0xff
+	ip[12] = 0xfe
+	ip[13] = hw[3]
+	ip[14] = hw[4]
+	ip[15] = hw[5]
+
+	return ip, nil
+}
+
+// StableHashIP picks an IP address from the candidates with the hash of the
+// key and the counter, so that the same key always gets the same IP address
+// as long as the candidates don't change. The counter is increased to pick
+// another one if the former is unavailable, like the DAD counter of RFC 7217.
+// It returns nil if there is no candidate.
+func StableHashIP(ips []net.IP, key string, counter int) net.IP {
+	if len(ips) == 0 {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(key + "/" + strconv.Itoa(counter)))
+	index := binary.BigEndian.Uint64(sum[:8]) % uint64(len(ips))
+
+	return ips[index]
+}
+
+// IsEUI64IP reports whether the interface identifier of the IPv6 address is
+// in the modified EUI-64 format, i.e. it may be derived from a MAC address by
+// SLAAC, as described in RFC 4862.
+func IsEUI64IP(ip net.IP) bool {
+	if ip.To4() != nil {
+		return false
+	}
+	ip = ip.To16()
+
+	return ip != nil && ip[11] == 0xff && ip[12] == 0xfe
+}
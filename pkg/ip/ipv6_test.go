@@ -0,0 +1,97 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ip_test
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+)
+
+var _ = Describe("IPv6", Label("ipv6_test"), func() {
+	Describe("Test EUI64IP", func() {
+		It("inputs invalid subnet", func() {
+			ip, err := spiderpoolip.EUI64IP(constant.InvalidCIDR, "00:50:56:aa:bb:cc")
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(ip).To(BeNil())
+		})
+
+		It("inputs the subnet with too long prefix", func() {
+			ip, err := spiderpoolip.EUI64IP("fd00:40::/120", "00:50:56:aa:bb:cc")
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidCIDRFormat))
+			Expect(ip).To(BeNil())
+		})
+
+		It("inputs invalid MAC address", func() {
+			ip, err := spiderpoolip.EUI64IP("fd00:40::/64", "invalid")
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidMACFormat))
+			Expect(ip).To(BeNil())
+		})
+
+		It("inputs 64-bit MAC address", func() {
+			ip, err := spiderpoolip.EUI64IP("fd00:40::/64", "00:50:56:ff:fe:aa:bb:cc")
+			Expect(err).To(MatchError(spiderpoolip.ErrInvalidMACFormat))
+			Expect(ip).To(BeNil())
+		})
+
+		It("derives the IPv6 address from MAC address", func() {
+			ip, err := spiderpoolip.EUI64IP("fd00:40::/64", "00:50:56:aa:bb:cc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.String()).To(Equal("fd00:40::250:56ff:feaa:bbcc"))
+		})
+
+		It("flips the universal/local bit", func() {
+			ip, err := spiderpoolip.EUI64IP("fd00:40::/48", "02:50:56:aa:bb:cc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ip.String()).To(Equal("fd00:40::50:56ff:feaa:bbcc"))
+		})
+	})
+
+	Describe("Test StableHashIP", func() {
+		ips := []net.IP{
+			net.ParseIP("fd00:40::1"),
+			net.ParseIP("fd00:40::2"),
+			net.ParseIP("fd00:40::3"),
+			net.ParseIP("fd00:40::4"),
+		}
+
+		It("returns nil without candidates", func() {
+			Expect(spiderpoolip.StableHashIP(nil, "default/pod", 0)).To(BeNil())
+		})
+
+		It("returns the same IP address with the same key", func() {
+			ip := spiderpoolip.StableHashIP(ips, "default/pod", 0)
+			Expect(ip).NotTo(BeNil())
+			Expect(ips).To(ContainElement(ip))
+			Expect(spiderpoolip.StableHashIP(ips, "default/pod", 0)).To(Equal(ip))
+		})
+
+		It("returns different IP addresses with the counter", func() {
+			picked := map[string]struct{}{}
+			for i := 0; i < 16; i++ {
+				picked[spiderpoolip.StableHashIP(ips, "default/pod", i).String()] = struct{}{}
+			}
+			Expect(len(picked)).To(BeNumerically(">", 1))
+		})
+	})
+	Describe("Test IsEUI64IP", func() {
+		It("inputs IPv4 address", func() {
+			Expect(spiderpoolip.IsEUI64IP(net.ParseIP("172.18.40.10"))).To(BeFalse())
+		})
+
+		It("inputs the IPv6 address derived from MAC address", func() {
+			ip, err := spiderpoolip.EUI64IP("fd00:40::/64", "00:50:56:aa:bb:cc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spiderpoolip.IsEUI64IP(ip)).To(BeTrue())
+		})
+
+		It("inputs the static IPv6 address", func() {
+			Expect(spiderpoolip.IsEUI64IP(net.ParseIP("fd00:40::10"))).To(BeFalse())
+		})
+	})
+})
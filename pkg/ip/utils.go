@@ -10,7 +10,20 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/types"
 )
 
+// AssembleTotalIPs returns the IP addresses of the IP ranges except the
+// excluded ones, in no particular order.
 func AssembleTotalIPs(ipVersion types.IPVersion, ipRanges, excludedIPRanges []string) ([]net.IP, error) {
+	set, err := AssembleTotalIPSet(ipVersion, ipRanges, excludedIPRanges)
+	if nil != err {
+		return nil, err
+	}
+
+	return set.List(false), nil
+}
+
+// AssembleTotalIPSet is the same as AssembleTotalIPs, but returns the IP
+// addresses as an IPSet for the further set operations.
+func AssembleTotalIPSet(ipVersion types.IPVersion, ipRanges, excludedIPRanges []string) (*IPSet, error) {
 	ips, err := ParseIPRanges(ipVersion, ipRanges)
 	if nil != err {
 		return nil, err
@@ -19,9 +32,11 @@ func AssembleTotalIPs(ipVersion types.IPVersion, ipRanges, excludedIPRanges []st
 	if nil != err {
 		return nil, err
 	}
-	totalIPs := IPsDiffSet(ips, excludeIPs, false)
 
-	return totalIPs, nil
+	set := NewIPSet(ips...)
+	set.Delete(excludeIPs...)
+
+	return set, nil
 }
 
 func CIDRToLabelValue(ipVersion types.IPVersion, subnet string) (string, error) {
@@ -0,0 +1,78 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+// mergeBandwidth merges the rate limits of the IPPool into the ones of the
+// IPAM result, e.g. the ones of both the IPv4 and IPv6 IPPools of a NIC.
+// The lower limit wins.
+func mergeBandwidth(bandwidth *models.Bandwidth, poolBandwidth *spiderpoolv1.Bandwidth) *models.Bandwidth {
+	if bandwidth == nil {
+		bandwidth = &models.Bandwidth{}
+	}
+
+	mergeLimit(&bandwidth.IngressRate, poolBandwidth.IngressRate)
+	mergeLimit(&bandwidth.IngressBurst, poolBandwidth.IngressBurst)
+	mergeLimit(&bandwidth.EgressRate, poolBandwidth.EgressRate)
+	mergeLimit(&bandwidth.EgressBurst, poolBandwidth.EgressBurst)
+
+	return bandwidth
+}
+
+func mergeLimit(limit *int64, q *resource.Quantity) {
+	if q == nil {
+		return
+	}
+	if v := q.Value(); *limit == 0 || v < *limit {
+		*limit = v
+	}
+}
+
+// applyPodBandwidth overrides the rate limits of the IPPools with the Pod
+// annotation "ipam.spidernet.io/bandwidth", and the bursts default to the
+// rates.
+func applyPodBandwidth(pod *corev1.Pod, addResp *models.IpamAddResponse) error {
+	if value, ok := pod.Annotations[constant.AnnoPodBandwidth]; ok {
+		podBandwidth, err := annotation.ParsePodBandwidth(value)
+		if err != nil {
+			return err
+		}
+
+		if addResp.Bandwidth == nil {
+			addResp.Bandwidth = &models.Bandwidth{}
+		}
+		for limit, q := range map[*int64]*resource.Quantity{
+			&addResp.Bandwidth.IngressRate:  podBandwidth.IngressRate,
+			&addResp.Bandwidth.IngressBurst: podBandwidth.IngressBurst,
+			&addResp.Bandwidth.EgressRate:   podBandwidth.EgressRate,
+			&addResp.Bandwidth.EgressBurst:  podBandwidth.EgressBurst,
+		} {
+			if q != nil {
+				*limit = q.Value()
+			}
+		}
+	}
+
+	b := addResp.Bandwidth
+	if b == nil {
+		return nil
+	}
+	if b.IngressBurst == 0 {
+		b.IngressBurst = b.IngressRate
+	}
+	if b.EgressBurst == 0 {
+		b.EgressBurst = b.EgressRate
+	}
+
+	return nil
+}
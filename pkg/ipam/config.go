@@ -10,6 +10,7 @@ import (
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/limiter"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
@@ -24,9 +25,38 @@ type IPAMConfig struct {
 	EnableSpiderSubnet bool
 	EnableStatefulSet  bool
 
+	// EnableGatewayDetection and EnableIPConflictDetection are the cluster
+	// defaults of the detections by CNI plugins, IPPools could override them.
+	EnableGatewayDetection    bool
+	EnableIPConflictDetection bool
+
+	// EnablePodAssignedAnnotation makes the IP addresses assigned to the Pods
+	// recorded in their annotation "ipam.spidernet.io/assigned", with an extra
+	// write of each Pod.
+	EnablePodAssignedAnnotation bool
+
 	OperationRetries     int
 	OperationGapDuration time.Duration
 	LimiterConfig        limiter.LimiterConfig
+
+	// StageTimeouts bounds the stages of the allocations and releases, so
+	// that a stuck stage fails before the deadline of the CNI request.
+	StageTimeouts StageTimeouts
+
+	// Freeze is optional, new IP addresses are not allocated while it
+	// reports Spiderpool frozen, but the existing allocations of the Pods
+	// are still retrieved and released.
+	Freeze configmanager.FreezeChecker
+
+	// InterfaceExists is optional, it reports whether the host interface
+	// exists on the node where the IP addresses are allocated. The IPPools
+	// whose master interfaces don't exist are filtered out, rather than
+	// failing the CNI plugins later.
+	InterfaceExists func(name string) bool
+
+	// NodeName is the node where the IP addresses are allocated, the IP
+	// allocations of the node are synced from the Endpoints with it.
+	NodeName string
 }
 
 func setDefaultsForIPAMConfig(config IPAMConfig) IPAMConfig {
@@ -19,21 +19,30 @@ func convertIPDetailsToIPConfigsAndAllRoutes(details []spiderpoolv1.IPAllocation
 	var routes []*models.Route
 	for _, d := range details {
 		nic := d.NIC
+		// The default route is elected to another NIC.
+		defaultRoute := d.DefaultRoute == nil || *d.DefaultRoute
 
 		if d.IPv4 != nil {
 			version := constant.IPv4
 			var ipv4Gateway string
 			if d.IPv4Gateway != nil {
 				ipv4Gateway = *d.IPv4Gateway
-				routes = append(routes, genDefaultRoute(nic, ipv4Gateway))
+				if defaultRoute {
+					routes = append(routes, genDefaultRoute(nic, ipv4Gateway))
+				}
+			}
+			var ipv4DelegatedPrefix string
+			if d.IPv4DelegatedPrefix != nil {
+				ipv4DelegatedPrefix = *d.IPv4DelegatedPrefix
 			}
 			ips = append(ips, &models.IPConfig{
-				Address: d.IPv4,
-				Gateway: ipv4Gateway,
-				IPPool:  *d.IPv4Pool,
-				Nic:     &nic,
-				Version: &version,
-				Vlan:    *d.Vlan,
+				Address:         d.IPv4,
+				Gateway:         ipv4Gateway,
+				IPPool:          *d.IPv4Pool,
+				Nic:             &nic,
+				Version:         &version,
+				Vlan:            *d.Vlan,
+				DelegatedPrefix: ipv4DelegatedPrefix,
 			})
 		}
 
@@ -42,15 +51,22 @@ func convertIPDetailsToIPConfigsAndAllRoutes(details []spiderpoolv1.IPAllocation
 			var ipv6Gateway string
 			if d.IPv6Gateway != nil {
 				ipv6Gateway = *d.IPv6Gateway
-				routes = append(routes, genDefaultRoute(nic, ipv6Gateway))
+				if defaultRoute {
+					routes = append(routes, genDefaultRoute(nic, ipv6Gateway))
+				}
+			}
+			var ipv6DelegatedPrefix string
+			if d.IPv6DelegatedPrefix != nil {
+				ipv6DelegatedPrefix = *d.IPv6DelegatedPrefix
 			}
 			ips = append(ips, &models.IPConfig{
-				Address: d.IPv6,
-				Gateway: ipv6Gateway,
-				IPPool:  *d.IPv6Pool,
-				Nic:     &nic,
-				Version: &version,
-				Vlan:    *d.Vlan,
+				Address:         d.IPv6,
+				Gateway:         ipv6Gateway,
+				IPPool:          *d.IPv6Pool,
+				Nic:             &nic,
+				Version:         &version,
+				Vlan:            *d.Vlan,
+				DelegatedPrefix: ipv6DelegatedPrefix,
 			})
 		}
 
@@ -67,7 +83,7 @@ func convertResultsToIPConfigsAndAllRoutes(results []*AllocationResult) ([]*mode
 		ips = append(ips, r.IP)
 		routes = append(routes, r.Routes...)
 
-		if r.CleanGateway {
+		if r.CleanGateway || r.DefaultRoute != nil && !*r.DefaultRoute {
 			continue
 		}
 
@@ -116,17 +132,24 @@ func convertResultsToIPDetails(results []*AllocationResult) []spiderpoolv1.IPAll
 			}
 		}
 		routes := convertOAIRoutesToSpecRoutes(r.Routes)
+		var delegatedPrefix *string
+		if r.IP.DelegatedPrefix != "" {
+			delegatedPrefix = new(string)
+			*delegatedPrefix = r.IP.DelegatedPrefix
+		}
 		if d, ok := nicToDetail[*r.IP.Nic]; ok {
 			if *r.IP.Version == constant.IPv4 {
 				d.IPv4 = r.IP.Address
 				d.IPv4Pool = &r.IP.IPPool
 				d.IPv4Gateway = gateway
+				d.IPv4DelegatedPrefix = delegatedPrefix
 				d.CleanGateway = cleanGateway
 				d.Routes = append(d.Routes, routes...)
 			} else {
 				d.IPv6 = r.IP.Address
 				d.IPv6Pool = &r.IP.IPPool
 				d.IPv6Gateway = gateway
+				d.IPv6DelegatedPrefix = delegatedPrefix
 				d.CleanGateway = cleanGateway
 				d.Routes = append(d.Routes, routes...)
 			}
@@ -135,23 +158,27 @@ func convertResultsToIPDetails(results []*AllocationResult) []spiderpoolv1.IPAll
 
 		if *r.IP.Version == constant.IPv4 {
 			nicToDetail[*r.IP.Nic] = &spiderpoolv1.IPAllocationDetail{
-				NIC:          *r.IP.Nic,
-				IPv4:         r.IP.Address,
-				IPv4Pool:     &r.IP.IPPool,
-				Vlan:         &r.IP.Vlan,
-				IPv4Gateway:  gateway,
-				CleanGateway: cleanGateway,
-				Routes:       routes,
+				NIC:                 *r.IP.Nic,
+				IPv4:                r.IP.Address,
+				IPv4Pool:            &r.IP.IPPool,
+				Vlan:                &r.IP.Vlan,
+				IPv4Gateway:         gateway,
+				CleanGateway:        cleanGateway,
+				DefaultRoute:        r.DefaultRoute,
+				Routes:              routes,
+				IPv4DelegatedPrefix: delegatedPrefix,
 			}
 		} else {
 			nicToDetail[*r.IP.Nic] = &spiderpoolv1.IPAllocationDetail{
-				NIC:          *r.IP.Nic,
-				IPv6:         r.IP.Address,
-				IPv6Pool:     &r.IP.IPPool,
-				Vlan:         &r.IP.Vlan,
-				IPv6Gateway:  gateway,
-				CleanGateway: cleanGateway,
-				Routes:       routes,
+				NIC:                 *r.IP.Nic,
+				IPv6:                r.IP.Address,
+				IPv6Pool:            &r.IP.IPPool,
+				Vlan:                &r.IP.Vlan,
+				IPv6Gateway:         gateway,
+				CleanGateway:        cleanGateway,
+				DefaultRoute:        r.DefaultRoute,
+				Routes:              routes,
+				IPv6DelegatedPrefix: delegatedPrefix,
 			}
 		}
 	}
@@ -167,10 +194,11 @@ func convertResultsToIPDetails(results []*AllocationResult) []spiderpoolv1.IPAll
 func convertAnnoPodRoutesToOAIRoutes(annoPodRoutes types.AnnoPodRoutesValue) []*models.Route {
 	var routes []*models.Route
 	for _, r := range annoPodRoutes {
+		nic := r.Interface
 		dst := r.Dst
 		gw := r.Gw
 		routes = append(routes, &models.Route{
-			IfName: new(string),
+			IfName: &nic,
 			Dst:    &dst,
 			Gw:     &gw,
 		})
@@ -227,3 +255,38 @@ func GroupIPDetails(containerID, nodeName string, details []spiderpoolv1.IPAlloc
 
 	return pics
 }
+
+// mergeDNS merges the DNS of the IPPool into the one of the IPAM result,
+// e.g. the DNS of both the IPv4 and IPv6 IPPools of a NIC. The domain of the
+// first IPPool setting it takes precedence, and the duplicated entries are
+// dropped.
+func mergeDNS(dns *models.DNS, poolDNS *spiderpoolv1.DNS) *models.DNS {
+	if dns == nil {
+		dns = &models.DNS{}
+	}
+
+	appendUnique := func(dst []string, src []string) []string {
+		for _, s := range src {
+			duplicated := false
+			for _, d := range dst {
+				if d == s {
+					duplicated = true
+					break
+				}
+			}
+			if !duplicated {
+				dst = append(dst, s)
+			}
+		}
+		return dst
+	}
+
+	if dns.Domain == "" {
+		dns.Domain = poolDNS.Domain
+	}
+	dns.Nameservers = appendUnique(dns.Nameservers, poolDNS.Nameservers)
+	dns.Search = appendUnique(dns.Search, poolDNS.Search)
+	dns.Options = appendUnique(dns.Options, poolDNS.Options)
+
+	return dns
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+// defaultRoutePolicy is how the NIC holding the default route of the Pod is
+// elected, parsed from the annotations of the Pod.
+type defaultRoutePolicy struct {
+	// NIC is the NIC specified explicitly, it takes precedence over Policy.
+	NIC string
+	// Policy is empty if the Pod doesn't specify it, then the one of the
+	// IPPools is used.
+	Policy string
+	// FamilyOrder prefers the NICs with gateways of the primary IP family
+	// when electing by Policy.
+	FamilyOrder string
+}
+
+func getDefaultRoutePolicy(pod *corev1.Pod) (*defaultRoutePolicy, error) {
+	policy := &defaultRoutePolicy{
+		NIC:    pod.Annotations[constant.AnnoPodDefaultRouteNIC],
+		Policy: pod.Annotations[constant.AnnoDefaultRoutePolicy],
+	}
+
+	if v, ok := pod.Annotations[constant.AnnoPodDefaultRouteNIC]; ok && v == "" {
+		return nil, fmt.Errorf("%w, Pod annotation '%s' is empty", constant.ErrWrongInput, constant.AnnoPodDefaultRouteNIC)
+	}
+	if policy.Policy != "" && !annotation.IsDefaultRoutePolicy(policy.Policy) {
+		return nil, fmt.Errorf("%w, invalid Pod annotation '%s: %s'", constant.ErrWrongInput, constant.AnnoDefaultRoutePolicy, policy.Policy)
+	}
+
+	familyOrder, err := getIPFamilyOrder(pod)
+	if err != nil {
+		return nil, err
+	}
+	policy.FamilyOrder = familyOrder
+
+	return policy, nil
+}
+
+// electDefaultRoute elects the NIC holding the default route among the NICs
+// allocated together, in the order they are requested, and marks the
+// results. The default routes of the other NICs are dropped. Nothing is
+// marked with the policy 'all', where every NIC with a gateway holds the
+// default route as before.
+//
+// The explicit NIC of the Pod wins. Otherwise the policy of the Pod, or the
+// one of the IPPool of the first NIC specifying it, is applied: 'first-nic'
+// elects the first NIC with a gateway, and 'underlay' elects the first one
+// allocated from an underlay IPPool, falling back to 'first-nic'. With the
+// IP family order of the Pod, the NICs with gateways of the primary IP family
+// are tried before the others.
+func electDefaultRoute(tt ToBeAllocateds, policy *defaultRoutePolicy, results []*AllocationResult) {
+	nicToResults := map[string][]*AllocationResult{}
+	for _, r := range results {
+		nicToResults[*r.IP.Nic] = append(nicToResults[*r.IP.Nic], r)
+	}
+
+	primary := primaryIPVersion(policy.FamilyOrder)
+	var candidates, primaryCandidates []string
+	underlay := map[string]bool{}
+	poolPolicy := ""
+	for _, t := range tt {
+		hasGateway, hasPrimaryGateway := false, false
+		for _, r := range nicToResults[t.NIC] {
+			if r.IP.Gateway != "" && !r.CleanGateway {
+				hasGateway = true
+				if *r.IP.Version == primary {
+					hasPrimaryGateway = true
+				}
+			}
+			if r.Underlay {
+				underlay[t.NIC] = true
+			}
+			if poolPolicy == "" {
+				poolPolicy = r.DefaultRoutePolicy
+			}
+		}
+		if hasPrimaryGateway {
+			primaryCandidates = append(primaryCandidates, t.NIC)
+		} else if hasGateway {
+			candidates = append(candidates, t.NIC)
+		}
+	}
+	candidates = append(primaryCandidates, candidates...)
+
+	elected := ""
+	switch {
+	case policy.NIC != "":
+		elected = policy.NIC
+	default:
+		p := policy.Policy
+		if p == "" {
+			p = poolPolicy
+		}
+
+		switch p {
+		case constant.DefaultRoutePolicyFirstNIC, constant.DefaultRoutePolicyUnderlay:
+			if len(candidates) == 0 {
+				return
+			}
+			elected = candidates[0]
+			if p == constant.DefaultRoutePolicyUnderlay {
+				for _, nic := range candidates {
+					if underlay[nic] {
+						elected = nic
+						break
+					}
+				}
+			}
+		default:
+			return
+		}
+	}
+
+	for nic, rs := range nicToResults {
+		isElected := nic == elected
+		for _, r := range rs {
+			r.DefaultRoute = &isElected
+		}
+	}
+}
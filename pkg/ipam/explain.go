@@ -0,0 +1,382 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
+	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+	"github.com/spidernet-io/spiderpool/pkg/singletons"
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	subnetmanagercontrollers "github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+// The rules of IPAM which select the candidate IPPools, in the order of
+// precedence.
+const (
+	PoolSourcePodSubnetAnnotation       = "Pod SpiderSubnet annotation"
+	PoolSourceAppSubnetAnnotation       = "application SpiderSubnet annotation"
+	PoolSourceNamespaceSubnetAnnotation = "Namespace SpiderSubnet annotation"
+	PoolSourcePodIPPoolsAnnotation      = "Pod annotation " + constant.AnnoPodIPPools
+	PoolSourcePodIPPoolAnnotation       = "Pod annotation " + constant.AnnoPodIPPool
+	PoolSourceNamespaceDefault          = "Namespace default IPPools"
+	PoolSourceNamespaceDefaultIPPool    = "IPPools declared default of the Namespace"
+	PoolSourceClusterDefaultSubnet      = "cluster default SpiderSubnet"
+	PoolSourceClusterDefault            = "cluster default IPPools"
+)
+
+// PoolDecision explains why a candidate IPPool is accepted or rejected for
+// the NIC of the Pod.
+type PoolDecision struct {
+	NIC       string
+	IPVersion types.IPVersion
+	// IPPool is empty if the auto-created IPPool of SpiderSubnet has not
+	// been created for the application yet.
+	IPPool   string
+	Accepted bool
+	Reason   string
+}
+
+// PoolExplanation is the replay of the candidate IPPool selection of a Pod.
+type PoolExplanation struct {
+	// Source is the rule which selects the candidate IPPools.
+	Source    string
+	Decisions []PoolDecision
+}
+
+type PoolExplainer interface {
+	// Explain replays the selection of candidate IPPools for the NIC of the
+	// Pod without allocating any IP address, nor creating or scaling any
+	// auto-created IPPool. The IPPools and SpiderSubnets specified in the
+	// CNI network configuration are invisible here.
+	Explain(ctx context.Context, pod *corev1.Pod, nic string) (*PoolExplanation, error)
+}
+
+type poolExplainer struct {
+	i *ipam
+}
+
+func NewPoolExplainer(
+	config IPAMConfig,
+	ipPoolManager ippoolmanager.IPPoolManager,
+	nodeManager nodemanager.NodeManager,
+	nsManager namespacemanager.NamespaceManager,
+	podManager podmanager.PodManager,
+	subnetManager subnetmanager.SubnetManager,
+) (PoolExplainer, error) {
+	if ipPoolManager == nil {
+		return nil, fmt.Errorf("ippool manager %w", constant.ErrMissingRequiredParam)
+	}
+	if nodeManager == nil {
+		return nil, fmt.Errorf("node manager %w", constant.ErrMissingRequiredParam)
+	}
+	if nsManager == nil {
+		return nil, fmt.Errorf("namespace manager %w", constant.ErrMissingRequiredParam)
+	}
+	if podManager == nil {
+		return nil, fmt.Errorf("pod manager %w", constant.ErrMissingRequiredParam)
+	}
+	if config.EnableSpiderSubnet && subnetManager == nil {
+		return nil, fmt.Errorf("subnet manager %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &poolExplainer{
+		i: &ipam{
+			config:        setDefaultsForIPAMConfig(config),
+			ipPoolManager: ipPoolManager,
+			nodeManager:   nodeManager,
+			nsManager:     nsManager,
+			podManager:    podManager,
+			subnetManager: subnetManager,
+		},
+	}, nil
+}
+
+func (e *poolExplainer) Explain(ctx context.Context, pod *corev1.Pod, nic string) (*PoolExplanation, error) {
+	if _, ok := pod.Annotations[constant.AnnoPodConfig]; ok {
+		annotations, err := annotation.ConvertPodConfig(pod.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		pod = pod.DeepCopy()
+		pod.Annotations = annotations
+	}
+
+	source, tt, decisions, err := e.getPoolCandidates(ctx, pod, nic)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &PoolExplanation{
+		Source:    source,
+		Decisions: decisions,
+	}
+	for _, t := range tt {
+		for _, c := range t.PoolCandidates {
+			explanation.Decisions = append(explanation.Decisions, e.explainPoolCandidate(ctx, t.NIC, c, pod)...)
+		}
+	}
+
+	return explanation, nil
+}
+
+// getPoolCandidates follows the precedence of getPoolCandidates of IPAM. The
+// auto-created IPPools of SpiderSubnet are only looked up, the ones not
+// created yet are returned as rejected decisions.
+func (e *poolExplainer) getPoolCandidates(ctx context.Context, pod *corev1.Pod, nic string) (string, ToBeAllocateds, []PoolDecision, error) {
+	if e.i.config.EnableSpiderSubnet {
+		ns, err := e.i.nsManager.GetNamespaceByName(ctx, pod.Namespace)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		podController, err := e.i.podManager.GetPodTopController(ctx, pod)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to get the top controller of Pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		subnetAnnoConfig, err := subnetmanagercontrollers.GetSubnetAnnoConfig(pod.Annotations, appAnnotations(podController), ns.Annotations, logutils.FromContext(ctx))
+		if err != nil {
+			return "", nil, nil, err
+		}
+
+		if !subnetmanagercontrollers.IsDefaultIPPoolMode(subnetAnnoConfig) {
+			source := PoolSourceNamespaceSubnetAnnotation
+			if hasSubnetAnnotation(pod.Annotations) {
+				source = PoolSourcePodSubnetAnnotation
+			} else if podController.Kind != constant.KindPod && hasSubnetAnnotation(appAnnotations(podController)) {
+				source = PoolSourceAppSubnetAnnotation
+			}
+
+			var subnetItem types.AnnoSubnetItem
+			if len(subnetAnnoConfig.MultipleSubnets) != 0 {
+				for _, item := range subnetAnnoConfig.MultipleSubnets {
+					if item.Interface == nic {
+						subnetItem = item
+						break
+					}
+				}
+			} else if subnetAnnoConfig.SingleSubnet != nil {
+				subnetItem = *subnetAnnoConfig.SingleSubnet
+			}
+
+			var v4Subnet, v6Subnet string
+			if len(subnetItem.IPv4) != 0 {
+				v4Subnet = subnetItem.IPv4[0]
+			}
+			if len(subnetItem.IPv6) != 0 {
+				v6Subnet = subnetItem.IPv6[0]
+			}
+			t, decisions, err := e.getPoolFromSubnet(ctx, podController, nic, v4Subnet, v6Subnet)
+			if err != nil {
+				return "", nil, nil, err
+			}
+			return source, ToBeAllocateds{t}, decisions, nil
+		}
+	}
+
+	if anno, ok := pod.Annotations[constant.AnnoPodIPPools]; ok {
+		tt, err := getPoolFromPodAnnoPools(ctx, anno, nic)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return PoolSourcePodIPPoolsAnnotation, tt, nil, nil
+	}
+
+	if anno, ok := pod.Annotations[constant.AnnoPodIPPool]; ok {
+		t, err := getPoolFromPodAnnoPool(ctx, anno, nic, false)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return PoolSourcePodIPPoolAnnotation, ToBeAllocateds{t}, nil, nil
+	}
+
+	t, err := e.i.getPoolFromNS(ctx, pod.Namespace, nic, false)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if t != nil {
+		return PoolSourceNamespaceDefault, ToBeAllocateds{t}, nil, nil
+	}
+
+	t, err = e.i.getPoolFromDefaultIPPools(ctx, pod.Namespace, nic, false)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if t != nil {
+		return PoolSourceNamespaceDefaultIPPool, ToBeAllocateds{t}, nil, nil
+	}
+
+	if e.i.config.EnableSpiderSubnet {
+		var v4Subnet, v6Subnet string
+		if len(singletons.ClusterDefaultPool.ClusterDefaultIPv4Subnet) != 0 {
+			v4Subnet = singletons.ClusterDefaultPool.ClusterDefaultIPv4Subnet[0]
+		}
+		if len(singletons.ClusterDefaultPool.ClusterDefaultIPv6Subnet) != 0 {
+			v6Subnet = singletons.ClusterDefaultPool.ClusterDefaultIPv6Subnet[0]
+		}
+		if (!e.i.config.EnableIPv4 || v4Subnet != "") && (!e.i.config.EnableIPv6 || v6Subnet != "") {
+			podController, err := e.i.podManager.GetPodTopController(ctx, pod)
+			if err != nil {
+				return "", nil, nil, fmt.Errorf("failed to get the top controller of Pod %s/%s: %w", pod.Namespace, pod.Name, err)
+			}
+			t, decisions, err := e.getPoolFromSubnet(ctx, podController, nic, v4Subnet, v6Subnet)
+			if err != nil {
+				return "", nil, nil, err
+			}
+			return PoolSourceClusterDefaultSubnet, ToBeAllocateds{t}, decisions, nil
+		}
+	}
+
+	t, err = e.i.getClusterDefaultPool(ctx, nic, false)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return PoolSourceClusterDefault, ToBeAllocateds{t}, nil, nil
+}
+
+// getPoolFromSubnet looks up the auto-created IPPools of the SpiderSubnets
+// for the application of the Pod, with the IPPools borrowing IP addresses
+// for them.
+func (e *poolExplainer) getPoolFromSubnet(ctx context.Context, podController types.PodTopController, nic, v4Subnet, v6Subnet string) (*ToBeAllocated, []PoolDecision, error) {
+	t := &ToBeAllocated{NIC: nic}
+	var decisions []PoolDecision
+	for _, s := range []struct {
+		enabled   bool
+		ipVersion types.IPVersion
+		label     string
+		subnet    string
+	}{
+		{e.i.config.EnableIPv4, constant.IPv4, constant.LabelIPPoolVersionV4, v4Subnet},
+		{e.i.config.EnableIPv6, constant.IPv6, constant.LabelIPPoolVersionV6, v6Subnet},
+	} {
+		if !s.enabled {
+			continue
+		}
+		if s.subnet == "" {
+			decisions = append(decisions, PoolDecision{
+				NIC:       nic,
+				IPVersion: s.ipVersion,
+				Reason:    fmt.Sprintf("IPv%d is enabled, but no IPv%d SpiderSubnet is specified", s.ipVersion, s.ipVersion),
+			})
+			continue
+		}
+
+		matchLabels := client.MatchingLabels{
+			constant.LabelIPPoolOwnerApplicationUID: string(podController.UID),
+			constant.LabelIPPoolOwnerSpiderSubnet:   s.subnet,
+			constant.LabelIPPoolOwnerApplication:    subnetmanagercontrollers.AppLabelValue(podController.Kind, podController.Namespace, podController.Name),
+			constant.LabelIPPoolVersion:             s.label,
+			constant.LabelIPPoolInterface:           nic,
+		}
+		poolList, err := e.i.ipPoolManager.ListIPPools(ctx, matchLabels)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get IPPoolList with labels '%v', error: %w", matchLabels, err)
+		}
+		if len(poolList.Items) != 1 {
+			reason := fmt.Sprintf("no auto-created IPPool of SpiderSubnet %s for %s %s/%s yet", s.subnet, podController.Kind, podController.Namespace, podController.Name)
+			if len(poolList.Items) > 1 {
+				reason = fmt.Sprintf("multiple auto-created IPPools of SpiderSubnet %s for %s %s/%s", s.subnet, podController.Kind, podController.Namespace, podController.Name)
+			}
+			decisions = append(decisions, PoolDecision{
+				NIC:       nic,
+				IPVersion: s.ipVersion,
+				Reason:    reason,
+			})
+			continue
+		}
+
+		c, err := e.i.subnetPoolCandidate(ctx, s.ipVersion, poolList.Items[0].DeepCopy())
+		if err != nil {
+			return nil, nil, err
+		}
+		t.PoolCandidates = append(t.PoolCandidates, c)
+	}
+
+	return t, decisions, nil
+}
+
+// explainPoolCandidate replays precheckPoolCandidates and filterPoolCandidates
+// of IPAM on each IPPool of the candidate. The Node affinity of IPPools is
+// not checked for the Pod which has not been scheduled.
+func (e *poolExplainer) explainPoolCandidate(ctx context.Context, nic string, c *PoolCandidate, pod *corev1.Pod) []PoolDecision {
+	decisions := make([]PoolDecision, 0, len(c.Pools))
+	seen := map[string]struct{}{}
+	for _, poolName := range c.Pools {
+		d := PoolDecision{
+			NIC:       nic,
+			IPVersion: c.IPVersion,
+			IPPool:    poolName,
+		}
+
+		if _, ok := seen[poolName]; ok {
+			d.Reason = fmt.Sprintf("duplicate candidate IPPool %s", poolName)
+			decisions = append(decisions, d)
+			continue
+		}
+		seen[poolName] = struct{}{}
+
+		if (c.IPVersion == constant.IPv4 && !e.i.config.EnableIPv4) || (c.IPVersion == constant.IPv6 && !e.i.config.EnableIPv6) {
+			d.Reason = fmt.Sprintf("IPv%d is disabled", c.IPVersion)
+			decisions = append(decisions, d)
+			continue
+		}
+
+		ipPool, ok := c.PToIPPool[poolName]
+		if !ok {
+			var err error
+			ipPool, err = e.i.ipPoolManager.GetIPPoolByName(ctx, poolName, constant.UseCache)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					d.Reason = fmt.Sprintf("IPPool %s not found", poolName)
+				} else {
+					d.Reason = fmt.Sprintf("failed to get IPPool %s: %v", poolName, err)
+				}
+				decisions = append(decisions, d)
+				continue
+			}
+		}
+
+		d.Reason = "matched"
+		var unchecked []string
+		if ipPool.Spec.NodeAffinity != nil && pod.Spec.NodeName == "" {
+			ipPool = ipPool.DeepCopy()
+			ipPool.Spec.NodeAffinity = nil
+			unchecked = append(unchecked, "the Node affinity unchecked for the Pod not scheduled yet")
+		}
+		if _, ok := ipPool.Annotations[constant.AnnoIPPoolMasterInterface]; ok && e.i.config.InterfaceExists == nil {
+			unchecked = append(unchecked, "the master interface unchecked out of the Node")
+		}
+		if len(unchecked) != 0 {
+			d.Reason = "matched, except " + strings.Join(unchecked, " and ")
+		}
+		if err := e.i.selectByPod(ctx, c.IPVersion, ipPool, pod); err != nil {
+			d.Reason = err.Error()
+		} else {
+			d.Accepted = true
+		}
+		decisions = append(decisions, d)
+	}
+
+	return decisions
+}
+
+func hasSubnetAnnotation(annotations map[string]string) bool {
+	_, single := annotations[constant.AnnoSpiderSubnet]
+	_, multiple := annotations[constant.AnnoSpiderSubnets]
+
+	return single || multiple
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+// getIPFamilyOrder returns the IP family order of the Pod, empty if the Pod
+// doesn't specify it.
+func getIPFamilyOrder(pod *corev1.Pod) (string, error) {
+	order, ok := pod.Annotations[constant.AnnoPodIPFamilyOrder]
+	if ok && !annotation.IsIPFamilyOrder(order) {
+		return "", fmt.Errorf("%w, invalid Pod annotation '%s: %s'", constant.ErrWrongInput, constant.AnnoPodIPFamilyOrder, order)
+	}
+
+	return order, nil
+}
+
+// primaryIPVersion returns the IP version coming first in the IP family
+// order, 0 if there is no order.
+func primaryIPVersion(order string) int64 {
+	switch order {
+	case constant.IPFamilyOrderIPv4First:
+		return constant.IPv4
+	case constant.IPFamilyOrderIPv6First:
+		return constant.IPv6
+	default:
+		return 0
+	}
+}
+
+// sortIPsByFamily sorts the IP addresses of each NIC by the IP family order,
+// the NICs keep their order. Kubernetes takes the first IP address of the
+// results as the primary IP address of the Pod, like the IP families of
+// dual-stack Services.
+func sortIPsByFamily(ips []*models.IPConfig, order string) {
+	primary := primaryIPVersion(order)
+	if primary == 0 {
+		return
+	}
+
+	var nics []string
+	primaryIPs := map[string][]*models.IPConfig{}
+	otherIPs := map[string][]*models.IPConfig{}
+	for _, ip := range ips {
+		nic := *ip.Nic
+		if _, ok := primaryIPs[nic]; !ok {
+			nics = append(nics, nic)
+			primaryIPs[nic] = nil
+		}
+		if *ip.Version == primary {
+			primaryIPs[nic] = append(primaryIPs[nic], ip)
+		} else {
+			otherIPs[nic] = append(otherIPs[nic], ip)
+		}
+	}
+
+	sorted := make([]*models.IPConfig, 0, len(ips))
+	for _, nic := range nics {
+		sorted = append(sorted, primaryIPs[nic]...)
+		sorted = append(sorted, otherIPs[nic]...)
+	}
+	copy(ips, sorted)
+}
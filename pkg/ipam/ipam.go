@@ -5,6 +5,7 @@ package ipam
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -17,10 +18,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/allocationjournal"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/limiter"
@@ -33,6 +39,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
 	subnetmanagercontrollers "github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
 	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
 )
 
@@ -40,6 +47,9 @@ type IPAM interface {
 	Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*models.IpamAddResponse, error)
 	Release(ctx context.Context, delArgs *models.IpamDelArgs) error
 	Start(ctx context.Context) error
+	// ListAllocations lists the IP addresses allocated to the Pods on the
+	// node, without querying the API server.
+	ListAllocations(ctx context.Context) (models.IPAllocations, error)
 }
 
 type ipam struct {
@@ -54,7 +64,13 @@ type ipam struct {
 	stsManager      statefulsetmanager.StatefulSetManager
 	subnetManager   subnetmanager.SubnetManager
 
+	// journal is optional, it records the allocations in flight to repair
+	// them after the restart.
+	journal allocationjournal.Journal
+
 	rollbacks sync.Map
+
+	nodeAllocations *nodeAllocations
 }
 
 func NewIPAM(
@@ -66,6 +82,7 @@ func NewIPAM(
 	podManager podmanager.PodManager,
 	stsManager statefulsetmanager.StatefulSetManager,
 	subnetManager subnetmanager.SubnetManager,
+	journal allocationjournal.Journal,
 ) (IPAM, error) {
 	if ipPoolManager == nil {
 		return nil, fmt.Errorf("ippool manager %w", constant.ErrMissingRequiredParam)
@@ -99,7 +116,9 @@ func NewIPAM(
 		podManager:      podManager,
 		stsManager:      stsManager,
 		subnetManager:   subnetManager,
+		journal:         journal,
 		rollbacks:       sync.Map{},
+		nodeAllocations: newNodeAllocations(),
 	}, nil
 }
 
@@ -107,9 +126,12 @@ func (i *ipam) Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*mode
 	logger := logutils.FromContext(ctx)
 	logger.Info("Start to allocate")
 
-	pod, err := i.podManager.GetPodByName(ctx, *addArgs.PodNamespace, *addArgs.PodName)
+	pod, err := i.podManager.GetPodByName(ctx, *addArgs.PodNamespace, *addArgs.PodName, constant.UseCache)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get Pod %s/%s: %v", *addArgs.PodNamespace, *addArgs.PodName, err)
+		return nil, fmt.Errorf("failed to get Pod %s/%s: %w", *addArgs.PodNamespace, *addArgs.PodName, err)
+	}
+	if podmanager.IsHostNetworkPod(pod) {
+		return nil, fmt.Errorf("Pod %s/%s runs in host network, no IP address is allocated to it", pod.Namespace, pod.Name)
 	}
 	podStatus, allocatable := podmanager.CheckPodStatus(pod)
 	if !allocatable {
@@ -117,15 +139,103 @@ func (i *ipam) Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*mode
 	}
 	logger.Sugar().Debugf("Get Pod with status %s", podStatus)
 
+	if err := i.checkNodeOS(ctx, pod.Spec.NodeName); err != nil {
+		return nil, err
+	}
+	if _, ok := pod.Annotations[constant.AnnoPodConfig]; ok {
+		annotations, err := annotation.ConvertPodConfig(pod.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		pod = pod.DeepCopy()
+		pod.Annotations = annotations
+	}
+	familyOrder, err := getIPFamilyOrder(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	addResp, err := i.allocate(ctx, addArgs, pod)
+	if err != nil {
+		i.endAllocation(ctx, *addArgs.ContainerID, false)
+		return nil, err
+	}
+	sortIPsByFamily(addResp.Ips, familyOrder)
+
+	if err := i.applyIPPoolSettings(ctx, *addArgs.IfName, addResp); err != nil {
+		i.endAllocation(ctx, *addArgs.ContainerID, false)
+		return nil, err
+	}
+
+	addResp.HostPorts, err = getHostPortMappings(pod, *addArgs.IfName, addResp.Ips)
+	if err != nil {
+		i.endAllocation(ctx, *addArgs.ContainerID, false)
+		return nil, err
+	}
+
+	if err := applyPodBandwidth(pod, addResp); err != nil {
+		i.endAllocation(ctx, *addArgs.ContainerID, false)
+		return nil, err
+	}
+	i.endAllocation(ctx, *addArgs.ContainerID, true)
+	i.nodeAllocations.record(pod.Namespace, pod.Name, *addArgs.ContainerID, *addArgs.IfName, addResp.Ips)
+
+	if i.config.EnablePodAssignedAnnotation {
+		// The annotation is informative, the allocation doesn't fail with it.
+		if err := i.annotateAssignedIPs(ctx, pod, addResp.Ips); err != nil {
+			logger.Sugar().Warnf("Failed to annotate the assigned IP addresses to the Pod: %v", err)
+		}
+	}
+
+	return addResp, nil
+}
+
+// checkNodeOS refuses the allocation on the Windows node, whose Pods are
+// broken by the Linux specific results. The allocation goes on if the node
+// could not be got, it is checked again by the following allocations.
+func (i *ipam) checkNodeOS(ctx context.Context, nodeName string) error {
+	logger := logutils.FromContext(ctx)
+
+	node, err := i.nodeManager.GetNodeByName(ctx, nodeName)
+	if err != nil {
+		logger.Sugar().Warnf("Failed to get Node %s to check its operating system: %v", nodeName, err)
+		return nil
+	}
+	if nodemanager.IsWindowsNode(node) {
+		return &constant.UnsupportedNodeError{Node: nodeName, OS: nodemanager.NodeOS(node)}
+	}
+
+	return nil
+}
+
+// annotateAssignedIPs records the IP addresses assigned to the NICs of the
+// Pod and their IPPools in the annotation of the Pod, unless it's up to date.
+func (i *ipam) annotateAssignedIPs(ctx context.Context, pod *corev1.Pod, ips []*models.IPConfig) error {
+	value, err := genAssignedAnnotation(ips)
+	if err != nil {
+		return err
+	}
+	if pod.Annotations[constant.AnnoPodAssigned] == value {
+		return nil
+	}
+
+	return i.podManager.MergeAnnotations(ctx, pod.Namespace, pod.Name, map[string]string{
+		constant.AnnoPodAssigned: value,
+	})
+}
+
+func (i *ipam) allocate(ctx context.Context, addArgs *models.IpamAddArgs, pod *corev1.Pod) (*models.IpamAddResponse, error) {
+	logger := logutils.FromContext(ctx)
+
 	podTopController, err := i.podManager.GetPodTopController(ctx, pod)
 	if nil != err {
-		return nil, fmt.Errorf("failed to get the top controller of the Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return nil, fmt.Errorf("failed to get the top controller of the Pod %s/%s: %w", pod.Namespace, pod.Name, err)
 	}
 	logger.Sugar().Debugf("%s %s/%s is the top controller of the Pod", podTopController.Kind, podTopController.Namespace, podTopController.Name)
 
 	endpoint, err := i.endpointManager.GetEndpointByName(ctx, pod.Namespace, pod.Name)
 	if client.IgnoreNotFound(err) != nil {
-		return nil, fmt.Errorf("failed to get Endpoint %s/%s: %v", pod.Namespace, pod.Name, err)
+		return nil, fmt.Errorf("failed to get Endpoint %s/%s: %w", pod.Namespace, pod.Name, err)
 	}
 
 	if endpoint == nil {
@@ -152,6 +262,21 @@ func (i *ipam) Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*mode
 		if addResp != nil {
 			return addResp, nil
 		}
+
+		if features.DefaultFeatureGate.Enabled(features.SandboxRestartIPRetention) {
+			logger.Debug("Try to retrieve the IP allocation of the restarted sandbox")
+			addResp, err := i.retrieveRestartedSandboxIPAllocation(ctx, *addArgs.ContainerID, *addArgs.IfName, pod, endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve the IP allocation of the restarted sandbox: %w", err)
+			}
+			if addResp != nil {
+				return addResp, nil
+			}
+		}
+	}
+
+	if err := configmanager.CheckFrozen(i.config.Freeze); err != nil {
+		return nil, err
 	}
 
 	logger.Info("Allocate IP addresses in standard mode")
@@ -163,6 +288,45 @@ func (i *ipam) Allocate(ctx context.Context, addArgs *models.IpamAddArgs) (*mode
 	return addResp, nil
 }
 
+// applyIPPoolSettings sets whether the CNI plugins should detect the gateway
+// and the IP conflict of each IP address, with the settings of its IPPool,
+// which override the cluster defaults. The DNS and the rate limits of the
+// IPPools of the NIC being set up are merged into the result as well.
+func (i *ipam) applyIPPoolSettings(ctx context.Context, ifName string, addResp *models.IpamAddResponse) error {
+	for _, ip := range addResp.Ips {
+		ip.EnableGatewayDetection = i.config.EnableGatewayDetection
+		ip.EnableIPConflictDetection = i.config.EnableIPConflictDetection
+
+		if ip.IPPool == "" {
+			continue
+		}
+		pool, err := i.ipPoolManager.GetIPPoolByName(ctx, ip.IPPool, constant.UseCache)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get IPPool %s: %w", ip.IPPool, err)
+		}
+
+		if pool.Spec.EnableGatewayDetection != nil {
+			ip.EnableGatewayDetection = *pool.Spec.EnableGatewayDetection
+		}
+		if pool.Spec.EnableIPConflictDetection != nil {
+			ip.EnableIPConflictDetection = *pool.Spec.EnableIPConflictDetection
+		}
+		ip.AcceptRA = ippoolmanager.IsSLAACCoexistenceIPPool(pool)
+		ip.DisableSNAT = ippoolmanager.IsSNATDisabledIPPool(pool)
+		if ip.Nic != nil && *ip.Nic == ifName && pool.Spec.DNS != nil {
+			addResp.DNS = mergeDNS(addResp.DNS, pool.Spec.DNS)
+		}
+		if ip.Nic != nil && *ip.Nic == ifName && pool.Spec.Bandwidth != nil {
+			addResp.Bandwidth = mergeBandwidth(addResp.Bandwidth, pool.Spec.Bandwidth)
+		}
+	}
+
+	return nil
+}
+
 func (i *ipam) retrieveStsIPAllocation(ctx context.Context, containerID string, pod *corev1.Pod, endpoint *spiderpoolv1.SpiderEndpoint) (*models.IpamAddResponse, error) {
 	logger := logutils.FromContext(ctx)
 
@@ -218,7 +382,7 @@ func (i *ipam) reallocateIPPoolIPRecords(ctx context.Context, containerID, nodeN
 	pics := GroupIPDetails(containerID, nodeName, endpoint.Status.Current.IPs)
 	tickets := pics.Pools()
 	if err := i.ipamLimiter.AcquireTicket(ctx, tickets...); err != nil {
-		return fmt.Errorf("failed to queue correctly: %v", err)
+		return fmt.Errorf("failed to queue correctly: %w", err)
 	}
 	defer i.ipamLimiter.ReleaseTicket(ctx, tickets...)
 
@@ -272,6 +436,45 @@ func (i *ipam) retrieveMultiNICIPAllocation(ctx context.Context, containerID, ni
 	return addResp, nil
 }
 
+// retrieveRestartedSandboxIPAllocation retrieves the IP allocation of the Pod
+// whose sandbox is recreated with the same Pod UID on the same node, and
+// hands the IP addresses over to the new container ID.
+func (i *ipam) retrieveRestartedSandboxIPAllocation(ctx context.Context, containerID, nic string, pod *corev1.Pod, endpoint *spiderpoolv1.SpiderEndpoint) (*models.IpamAddResponse, error) {
+	logger := logutils.FromContext(ctx)
+
+	allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(containerID, nic, pod, endpoint)
+	if allocation == nil {
+		logger.Debug("No IP allocation of the restarted sandbox retrieved")
+		return nil, nil
+	}
+
+	// The last allocation failed, re-allocate in standard mode.
+	for _, d := range allocation.IPs {
+		if i.config.EnableIPv4 && d.IPv4 == nil ||
+			i.config.EnableIPv6 && d.IPv6 == nil {
+			logger.Sugar().Warnf("The restarted sandbox has legacy failure allocation %+v, try to re-allocate", d)
+			return nil, nil
+		}
+	}
+
+	if err := i.reallocateIPPoolIPRecords(ctx, containerID, pod.Spec.NodeName, endpoint); err != nil {
+		return nil, err
+	}
+
+	if err := i.endpointManager.ReallocateCurrentIPAllocation(ctx, containerID, pod.Spec.NodeName, endpoint); err != nil {
+		return nil, fmt.Errorf("failed to update the current IP allocation of the restarted sandbox: %w", err)
+	}
+
+	ips, routes := convertIPDetailsToIPConfigsAndAllRoutes(endpoint.Status.Current.IPs)
+	addResp := &models.IpamAddResponse{
+		Ips:    ips,
+		Routes: routes,
+	}
+	logger.Sugar().Infof("Succeed to retrieve the IP allocation of the restarted sandbox: %+v", *addResp)
+
+	return addResp, nil
+}
+
 func (i *ipam) allocateInStandardMode(ctx context.Context, addArgs *models.IpamAddArgs, pod *corev1.Pod, endpoint *spiderpoolv1.SpiderEndpoint, podController types.PodTopController) (*models.IpamAddResponse, error) {
 	logger := logutils.FromContext(ctx)
 
@@ -281,27 +484,44 @@ func (i *ipam) allocateInStandardMode(ctx context.Context, addArgs *models.IpamA
 		return nil, err
 	}
 
-	logger.Debug("Generate IPPool candidates")
-	toBeAllocatedSet, err := i.genToBeAllocatedSet(ctx, addArgs, pod, podController)
+	logger.Debug("Parse default route policy")
+	routePolicy, err := getDefaultRoutePolicy(pod)
 	if err != nil {
 		return nil, err
 	}
 
+	logger.Debug("Generate IPPool candidates")
+	var toBeAllocatedSet ToBeAllocateds
+	if err := i.runStage(ctx, stagePoolSelection, func(ctx context.Context) error {
+		toBeAllocatedSet, err = i.genToBeAllocatedSet(ctx, addArgs, pod, podController)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := i.beginAllocation(ctx, *addArgs.ContainerID, *addArgs.IfName, pod, toBeAllocatedSet); err != nil {
+		return nil, err
+	}
+
 	// TODO(iiiceoo): Comment why containerID should be written first.
 	if endpoint == nil {
 		logger.Sugar().Infof("First sandbox of Pod is being created, mark the IP allocation")
-		endpoint, err = i.endpointManager.MarkIPAllocation(ctx, *addArgs.ContainerID, pod, podController)
-		if err != nil {
-			return nil, fmt.Errorf("failed to mark IP allocation: %v", err)
+		if err := i.runStage(ctx, stageEndpointUpdate, func(ctx context.Context) error {
+			endpoint, err = i.endpointManager.MarkIPAllocation(ctx, *addArgs.ContainerID, pod, podController)
+			return err
+		}); err != nil {
+			return nil, fmt.Errorf("failed to mark IP allocation: %w", err)
 		}
 	} else {
 		logger.Sugar().Infof("Sandbox has changed, remarking the IP allocation with the new container ID")
-		if err := i.endpointManager.ReMarkIPAllocation(ctx, *addArgs.ContainerID, endpoint, pod); err != nil {
-			return nil, fmt.Errorf("failed to remark IP allocation: %v", err)
+		if err := i.runStage(ctx, stageEndpointUpdate, func(ctx context.Context) error {
+			return i.endpointManager.ReMarkIPAllocation(ctx, *addArgs.ContainerID, endpoint, pod)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to remark IP allocation: %w", err)
 		}
 	}
 
-	results, err := i.allocateForAllNICs(ctx, toBeAllocatedSet, *addArgs.ContainerID, customRoutes, endpoint, pod, podController)
+	results, err := i.allocateForAllNICs(ctx, toBeAllocatedSet, *addArgs.ContainerID, customRoutes, routePolicy, endpoint, pod, podController)
 	if err != nil {
 		if len(results) != 0 {
 			logger.Sugar().Warnf("Failed to allocate IP addresses for all NICs, record incomplete IP allocation results for rollback: %+v", results)
@@ -351,26 +571,34 @@ func (i *ipam) genToBeAllocatedSet(ctx context.Context, addArgs *models.IpamAddA
 	return preliminary, nil
 }
 
-func (i *ipam) allocateForAllNICs(ctx context.Context, tt ToBeAllocateds, containerID string, customRoutes []*models.Route, endpoint *spiderpoolv1.SpiderEndpoint, pod *corev1.Pod, podController types.PodTopController) ([]*AllocationResult, error) {
+func (i *ipam) allocateForAllNICs(ctx context.Context, tt ToBeAllocateds, containerID string, customRoutes []*models.Route, routePolicy *defaultRoutePolicy, endpoint *spiderpoolv1.SpiderEndpoint, pod *corev1.Pod, podController types.PodTopController) ([]*AllocationResult, error) {
 	logger := logutils.FromContext(ctx)
 
 	logger.Sugar().Debugf("Concurrently allocate IP addresses from all IPPool candidates")
-	results, err := i.allocateIPsFromAllCandidates(ctx, tt, containerID, pod, podController)
-	if err != nil {
+	var results []*AllocationResult
+	if err := i.runStage(ctx, stageIPAllocation, func(ctx context.Context) (err error) {
+		results, err = i.allocateIPsFromAllCandidates(ctx, tt, containerID, pod, podController)
+		return err
+	}); err != nil {
 		return results, err
 	}
 
 	logger.Sugar().Debugf("Group custom routes by IP allocation results")
 	if err := groupCustomRoutes(ctx, customRoutes, results); err != nil {
-		return results, fmt.Errorf("failed to group custom routes %+v: %v", customRoutes, err)
+		return results, fmt.Errorf("failed to group custom routes %+v: %w", customRoutes, err)
 	}
 
+	logger.Sugar().Debugf("Elect the NIC holding the default route")
+	electDefaultRoute(tt, routePolicy, results)
+
 	logger.Sugar().Debugf("Patch IP allocation detail to Endpoint %s/%s", endpoint.Namespace, endpoint.Name)
-	if err = i.endpointManager.PatchIPAllocation(ctx, &spiderpoolv1.PodIPAllocation{
-		ContainerID: containerID,
-		IPs:         convertResultsToIPDetails(results),
-	}, endpoint); err != nil {
-		return results, fmt.Errorf("failed to patch IP allocation detail to Endpoint %s/%s: %v", endpoint.Namespace, endpoint.Name, err)
+	if err := i.runStage(ctx, stageEndpointUpdate, func(ctx context.Context) error {
+		return i.endpointManager.PatchIPAllocation(ctx, &spiderpoolv1.PodIPAllocation{
+			ContainerID: containerID,
+			IPs:         convertResultsToIPDetails(results),
+		}, endpoint)
+	}); err != nil {
+		return results, fmt.Errorf("failed to patch IP allocation detail to Endpoint %s/%s: %w", endpoint.Namespace, endpoint.Name, err)
 	}
 
 	return results, nil
@@ -381,7 +609,7 @@ func (i *ipam) allocateIPsFromAllCandidates(ctx context.Context, tt ToBeAllocate
 
 	tickets := tt.Pools()
 	if err := i.ipamLimiter.AcquireTicket(ctx, tickets...); err != nil {
-		return nil, fmt.Errorf("failed to queue correctly: %v", err)
+		return nil, fmt.Errorf("failed to queue correctly: %w", err)
 	}
 	defer i.ipamLimiter.ReleaseTicket(ctx, tickets...)
 
@@ -448,9 +676,11 @@ func (i *ipam) allocateIPFromCandidate(ctx context.Context, c *PoolCandidate, ni
 		}
 
 		result = &AllocationResult{
-			IP:           ip,
-			CleanGateway: cleanGateway,
-			Routes:       convertSpecRoutesToOAIRoutes(nic, c.PToIPPool[pool].Spec.Routes),
+			IP:                 ip,
+			CleanGateway:       cleanGateway,
+			Routes:             convertSpecRoutesToOAIRoutes(nic, c.PToIPPool[pool].Spec.Routes),
+			DefaultRoutePolicy: c.PToIPPool[pool].Annotations[constant.AnnoDefaultRoutePolicy],
+			Underlay:           ippoolmanager.IsUnderlayIPPool(c.PToIPPool[pool]),
 		}
 		logger.Sugar().Infof("Allocate IPv%d IP %s to NIC %s from IPPool %s", c.IPVersion, *result.IP.Address, nic, pool)
 		break
@@ -469,7 +699,7 @@ func (i *ipam) getPoolCandidates(ctx context.Context, addArgs *models.IpamAddArg
 	if i.config.EnableSpiderSubnet {
 		fromSubnet, err := i.getPoolFromSubnetAnno(ctx, pod, *addArgs.IfName, addArgs.CleanGateway, podController)
 		if nil != err {
-			return nil, fmt.Errorf("failed to get IPPool candidates from Subnet: %v", err)
+			return nil, fmt.Errorf("failed to get IPPool candidates from Subnet: %w", err)
 		}
 		if fromSubnet != nil {
 			return ToBeAllocateds{fromSubnet}, nil
@@ -490,18 +720,6 @@ func (i *ipam) getPoolCandidates(ctx context.Context, addArgs *models.IpamAddArg
 		return ToBeAllocateds{t}, nil
 	}
 
-	// If feature SpiderSubnet is enabled, select IPPool candidates through the cluster
-	// default Subnet defined in Configmap spiderpool-conf.
-	if i.config.EnableSpiderSubnet {
-		fromClusterDefaultSubnet, err := i.getPoolFromClusterDefaultSubnet(ctx, pod, *addArgs.IfName, addArgs.CleanGateway, podController)
-		if nil != err {
-			return nil, err
-		}
-		if fromClusterDefaultSubnet != nil {
-			return ToBeAllocateds{fromClusterDefaultSubnet}, nil
-		}
-	}
-
 	// Select IPPool candidates through the Namespace annotations
 	// "ipam.spidernet.io/defaultv4ippool" and "ipam.spidernet.io/defaultv6ippool".
 	t, err := i.getPoolFromNS(ctx, pod.Namespace, *addArgs.IfName, addArgs.CleanGateway)
@@ -512,13 +730,45 @@ func (i *ipam) getPoolCandidates(ctx context.Context, addArgs *models.IpamAddArg
 		return ToBeAllocateds{t}, nil
 	}
 
+	// Select IPPool candidates through the IPPools which declare themselves
+	// the default of the Namespace in 'spec.namespaceDefault'.
+	t, err = i.getPoolFromDefaultIPPools(ctx, pod.Namespace, *addArgs.IfName, addArgs.CleanGateway)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		return ToBeAllocateds{t}, nil
+	}
+
+	// Select IPPool candidates through the SpiderSubnets of CNI network configuration.
+	if addArgs.DefaultIPV4Subnet != "" || addArgs.DefaultIPV6Subnet != "" {
+		fromNetConfSubnet, err := i.getPoolFromNetConfSubnet(ctx, addArgs, pod, podController)
+		if nil != err {
+			return nil, fmt.Errorf("failed to get IPPool candidates from the Subnet of CNI network configuration: %w", err)
+		}
+		return ToBeAllocateds{fromNetConfSubnet}, nil
+	}
+
 	// Select IPPool candidates through CNI network configuration.
 	if t := getPoolFromNetConf(ctx, *addArgs.IfName, addArgs.DefaultIPV4IPPool, addArgs.DefaultIPV6IPPool, addArgs.CleanGateway); t != nil {
 		return ToBeAllocateds{t}, nil
 	}
 
-	// Select IPPool candidates through Configmap spiderpool-conf.
-	t, err = i.config.getClusterDefaultPool(ctx, *addArgs.IfName, addArgs.CleanGateway)
+	// If feature SpiderSubnet is enabled, select IPPool candidates through the cluster
+	// default Subnet defined in Configmap spiderpool-conf.
+	if i.config.EnableSpiderSubnet {
+		fromClusterDefaultSubnet, err := i.getPoolFromClusterDefaultSubnet(ctx, pod, *addArgs.IfName, addArgs.CleanGateway, podController)
+		if nil != err {
+			return nil, err
+		}
+		if fromClusterDefaultSubnet != nil {
+			return ToBeAllocateds{fromClusterDefaultSubnet}, nil
+		}
+	}
+
+	// Select IPPool candidates through Configmap spiderpool-conf, followed by
+	// the IPPools which declare themselves the default of the cluster.
+	t, err = i.getClusterDefaultPool(ctx, *addArgs.IfName, addArgs.CleanGateway)
 	if err != nil {
 		return nil, err
 	}
@@ -529,8 +779,13 @@ func (i *ipam) getPoolCandidates(ctx context.Context, addArgs *models.IpamAddArg
 func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic string, cleanGateway bool, podController types.PodTopController) (*ToBeAllocated, error) {
 	logger := logutils.FromContext(ctx)
 
-	// get SpiderSubnet configuration from pod annotation
-	subnetAnnoConfig, err := subnetmanagercontrollers.GetSubnetAnnoConfig(pod.Annotations, logger)
+	ns, err := i.nsManager.GetNamespaceByName(ctx, pod.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// get SpiderSubnet configuration from pod annotation, with the defaults of its application and Namespace
+	subnetAnnoConfig, err := subnetmanagercontrollers.GetSubnetAnnoConfig(pod.Annotations, appAnnotations(podController), ns.Annotations, logger)
 	if nil != err {
 		return nil, err
 	}
@@ -582,7 +837,7 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 		for j := 1; j <= i.config.OperationRetries; j++ {
 			poolList, err := i.ipPoolManager.ListIPPools(ctx, matchLabels)
 			if nil != err {
-				return nil, false, fmt.Errorf("failed to get IPPoolList with labels '%v', error: %v", matchLabels, err)
+				return nil, false, fmt.Errorf("failed to get IPPoolList with labels '%v', error: %w", matchLabels, err)
 			}
 
 			// validation
@@ -594,7 +849,9 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 
 				logger.Sugar().Errorf("fetch SubnetIPPool %d times: no '%s' IPPool retrieved from SpiderSubnet '%s' with matchLabel '%v', wait for a second and get a retry",
 					j, matchLabels[constant.LabelIPPoolVersion], subnetName, matchLabels)
-				time.Sleep(i.config.OperationGapDuration)
+				if err := i.waitOperationGap(ctx); err != nil {
+					return nil, false, err
+				}
 				continue
 			} else if len(poolList.Items) == 1 {
 				pool = poolList.Items[0].DeepCopy()
@@ -604,18 +861,22 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 					logger.Sugar().Debugf("found SpiderSubnet '%s' IPPool '%s' and check it whether need to be scaled", subnetName, pool.Name)
 					enableScaled, err := i.subnetManager.CheckScaleIPPool(ctx, pool, subnetName, poolIPNum)
 					if nil != err {
-						return nil, false, fmt.Errorf("failed to check IPPool %s whether need to be scaled: %v", pool.Name, err)
+						return nil, false, fmt.Errorf("failed to check IPPool %s whether need to be scaled: %w", pool.Name, err)
 					}
 					if enableScaled {
 						// wait for a while and let ippool informer to scale the IPPool's IPs
-						time.Sleep(i.config.OperationGapDuration)
+						if err := i.waitOperationGap(ctx); err != nil {
+							return nil, false, err
+						}
 					}
 				}
 
 				// we fetched Auto-created IPPool but it doesn't have any IPs, just wait for a while and let the IPPool informer to allocate IPs for it
 				if len(pool.Spec.IPs) == 0 {
 					logger.Sugar().Errorf("fetch SubnetIPPool %d times: retrieved IPPool '%s' but no IPs, wait for a second and get a retry", j, pool.Name)
-					time.Sleep(i.config.OperationGapDuration)
+					if err := i.waitOperationGap(ctx); err != nil {
+						return nil, false, err
+					}
 					continue
 				}
 			} else {
@@ -637,16 +898,19 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 	var errV4, errV6 error
 	var wg sync.WaitGroup
 
-	// get pod annotation "ipam.spidernet.io/reclaim-ippool"
-	reclaimIPPool, err := subnetmanagercontrollers.ShouldReclaimIPPool(pod.Annotations)
-	if nil != err {
-		return nil, err
-	}
+	// annotation "ipam.spidernet.io/ippool-reclaim", inherited from the application and Namespace
+	reclaimIPPool := subnetAnnoConfig.ReclaimIPPool
 	// we don't support reclaim IPPool for third party controller application
 	if podController.Kind == constant.KindUnknown {
 		reclaimIPPool = false
 	}
 
+	// get pod annotation "ipam.spidernet.io/subnet-block-size"
+	blockPrefixLength, err := subnetmanagercontrollers.GetSubnetBlockSize(pod.Annotations)
+	if nil != err {
+		return nil, err
+	}
+
 	// if enableIPv4 is off and get the specified SpiderSubnet IPv4 name, just filter it out
 	if i.config.EnableIPv4 && len(subnetItem.IPv4) != 0 {
 		wg.Add(1)
@@ -665,14 +929,14 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 			}
 
 			if shouldCreateV4Pool {
-				v4Pool, err := i.subnetManager.AllocateEmptyIPPool(ctx, subnetItem.IPv4[0], podController, podSelector, poolIPNum, constant.IPv4, reclaimIPPool, nic)
+				v4Pool, err := i.subnetManager.AllocateEmptyIPPool(ctx, subnetItem.IPv4[0], podController, podSelector, poolIPNum, constant.IPv4, reclaimIPPool, nic, blockPrefixLength)
 				if nil != err {
 					errV4 = err
 					return
 				}
 				v4PoolCandidate = v4Pool
 				// wait for a second to make the spiderpool-controller allocates IP for the IPPool from SpiderSubnet
-				time.Sleep(i.config.OperationGapDuration)
+				errV4 = i.waitOperationGap(ctx)
 			}
 		}()
 	}
@@ -695,14 +959,14 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 			}
 
 			if shouldCreateV6Pool {
-				v6Pool, err := i.subnetManager.AllocateEmptyIPPool(ctx, subnetItem.IPv6[0], podController, podSelector, poolIPNum, constant.IPv6, reclaimIPPool, nic)
+				v6Pool, err := i.subnetManager.AllocateEmptyIPPool(ctx, subnetItem.IPv6[0], podController, podSelector, poolIPNum, constant.IPv6, reclaimIPPool, nic, blockPrefixLength)
 				if nil != err {
 					errV6 = err
 					return
 				}
 				v6PoolCandidate = v6Pool
 				// wait for a second to make the spiderpool-controller allocates IP for the IPPool from SpiderSubnet
-				time.Sleep(i.config.OperationGapDuration)
+				errV6 = i.waitOperationGap(ctx)
 			}
 		}()
 	}
@@ -715,25 +979,102 @@ func (i *ipam) getPoolFromSubnetAnno(ctx context.Context, pod *corev1.Pod, nic s
 
 	if v4PoolCandidate != nil {
 		logger.Sugar().Debugf("add IPv4 subnet IPPool '%s' to PoolCandidates", v4PoolCandidate.Name)
-		result.PoolCandidates = append(result.PoolCandidates, &PoolCandidate{
-			IPVersion: constant.IPv4,
-			Pools:     []string{v4PoolCandidate.Name},
-			PToIPPool: PoolNameToIPPool{v4PoolCandidate.Name: v4PoolCandidate},
-		})
+		c, err := i.subnetPoolCandidate(ctx, constant.IPv4, v4PoolCandidate)
+		if nil != err {
+			return nil, err
+		}
+		result.PoolCandidates = append(result.PoolCandidates, c)
 	}
 	if v6PoolCandidate != nil {
 		logger.Sugar().Debugf("add IPv6 subnet IPPool '%s' to PoolCandidates", v6PoolCandidate.Name)
-		result.PoolCandidates = append(result.PoolCandidates, &PoolCandidate{
-			IPVersion: constant.IPv6,
-			Pools:     []string{v6PoolCandidate.Name},
-			PToIPPool: PoolNameToIPPool{v6PoolCandidate.Name: v6PoolCandidate},
-		})
+		c, err := i.subnetPoolCandidate(ctx, constant.IPv6, v6PoolCandidate)
+		if nil != err {
+			return nil, err
+		}
+		result.PoolCandidates = append(result.PoolCandidates, c)
 	}
 
 	return result, nil
 }
 
+// subnetPoolCandidate returns the candidate of the auto-created IPPool,
+// followed by the IPPools borrowing IP addresses for it from the sibling
+// SpiderSubnets of its exhausted SpiderSubnet.
+func (i *ipam) subnetPoolCandidate(ctx context.Context, ipVersion types.IPVersion, pool *spiderpoolv1.SpiderIPPool) (*PoolCandidate, error) {
+	c := &PoolCandidate{
+		IPVersion: ipVersion,
+		Pools:     []string{pool.Name},
+		PToIPPool: PoolNameToIPPool{pool.Name: pool},
+	}
+
+	matchLabels := client.MatchingLabels{
+		constant.LabelIPPoolBorrowedForSubnet: pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet],
+	}
+	for _, key := range []string{
+		constant.LabelIPPoolOwnerApplicationUID,
+		constant.LabelIPPoolOwnerApplication,
+		constant.LabelIPPoolVersion,
+		constant.LabelIPPoolInterface,
+	} {
+		matchLabels[key] = pool.Labels[key]
+	}
+	poolList, err := i.ipPoolManager.ListIPPools(ctx, matchLabels)
+	if nil != err {
+		return nil, fmt.Errorf("failed to get borrowed IPPoolList with labels '%v', error: %w", matchLabels, err)
+	}
+
+	for j := range poolList.Items {
+		borrowed := poolList.Items[j].DeepCopy()
+		if borrowed.DeletionTimestamp != nil || len(borrowed.Spec.IPs) == 0 {
+			continue
+		}
+		logutils.FromContext(ctx).Sugar().Debugf("add IPv%d IPPool '%s' borrowed for IPPool '%s' to PoolCandidates", ipVersion, borrowed.Name, pool.Name)
+		c.Pools = append(c.Pools, borrowed.Name)
+		c.PToIPPool[borrowed.Name] = borrowed
+	}
+
+	return c, nil
+}
+
 func (i *ipam) getPoolFromClusterDefaultSubnet(ctx context.Context, pod *corev1.Pod, nic string, cleanGateway bool, podController types.PodTopController) (*ToBeAllocated, error) {
+	var clusterDefaultV4Subnet, clusterDefaultV6Subnet string
+
+	if len(singletons.ClusterDefaultPool.ClusterDefaultIPv4Subnet) != 0 {
+		clusterDefaultV4Subnet = singletons.ClusterDefaultPool.ClusterDefaultIPv4Subnet[0]
+	}
+	if len(singletons.ClusterDefaultPool.ClusterDefaultIPv6Subnet) != 0 {
+		clusterDefaultV6Subnet = singletons.ClusterDefaultPool.ClusterDefaultIPv6Subnet[0]
+	}
+	// no cluster default subnet specified
+	if (i.config.EnableIPv4 && clusterDefaultV4Subnet == "") || (i.config.EnableIPv6 && clusterDefaultV6Subnet == "") {
+		return nil, nil
+	}
+
+	return i.getPoolFromSubnet(ctx, pod, nic, cleanGateway, podController, clusterDefaultV4Subnet, clusterDefaultV6Subnet)
+}
+
+// getPoolFromNetConfSubnet serves for the SpiderSubnets specified in the CNI network configuration
+// with 'ipv4_subnet' and 'ipv6_subnet'.
+func (i *ipam) getPoolFromNetConfSubnet(ctx context.Context, addArgs *models.IpamAddArgs, pod *corev1.Pod, podController types.PodTopController) (*ToBeAllocated, error) {
+	if !i.config.EnableSpiderSubnet {
+		return nil, fmt.Errorf("%w: feature SpiderSubnet is disabled", constant.ErrWrongInput)
+	}
+	if i.config.EnableIPv4 && addArgs.DefaultIPV4Subnet == "" {
+		return nil, fmt.Errorf("%w: the CNI network configuration doesn't specify IPv4 SpiderSubnet", constant.ErrWrongInput)
+	}
+	if i.config.EnableIPv6 && addArgs.DefaultIPV6Subnet == "" {
+		return nil, fmt.Errorf("%w: the CNI network configuration doesn't specify IPv6 SpiderSubnet", constant.ErrWrongInput)
+	}
+
+	logger := logutils.FromContext(ctx)
+	logger.Sugar().Infof("Use SpiderSubnets '%s' and '%s' from CNI network configuration", addArgs.DefaultIPV4Subnet, addArgs.DefaultIPV6Subnet)
+
+	return i.getPoolFromSubnet(ctx, pod, *addArgs.IfName, addArgs.CleanGateway, podController, addArgs.DefaultIPV4Subnet, addArgs.DefaultIPV6Subnet)
+}
+
+// getPoolFromSubnet finds or applies the auto-created IPPools of the given SpiderSubnets for the application.
+func (i *ipam) getPoolFromSubnet(ctx context.Context, pod *corev1.Pod, nic string, cleanGateway bool, podController types.PodTopController,
+	v4Subnet, v6Subnet string) (*ToBeAllocated, error) {
 	log := logutils.FromContext(ctx)
 
 	poolIPNum, podSelector, err := getAutoPoolIPNumberAndSelector(pod, podController)
@@ -741,27 +1082,43 @@ func (i *ipam) getPoolFromClusterDefaultSubnet(ctx context.Context, pod *corev1.
 		return nil, err
 	}
 
-	// get pod annotation "ipam.spidernet.io/reclaim-ippool"
-	reclaimIPPool, err := subnetmanagercontrollers.ShouldReclaimIPPool(pod.Annotations)
+	// get annotation "ipam.spidernet.io/ippool-reclaim" of the pod, its application or Namespace
+	ns, err := i.nsManager.GetNamespaceByName(ctx, pod.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	appAnno, err := annotation.ConvertPodConfig(appAnnotations(podController))
+	if err != nil {
+		return nil, err
+	}
+	reclaimIPPool, err := subnetmanagercontrollers.ShouldReclaimIPPool(pod.Annotations, appAnno, ns.Annotations)
+	if nil != err {
+		return nil, err
+	}
+
+	// get pod annotation "ipam.spidernet.io/subnet-block-size"
+	blockPrefixLength, err := subnetmanagercontrollers.GetSubnetBlockSize(pod.Annotations)
 	if nil != err {
 		return nil, err
 	}
 
 	var v4Pool, v6Pool *spiderpoolv1.SpiderIPPool
 	for j := 0; j <= i.config.OperationRetries; j++ {
-		v4Pool, v6Pool, err = i.findOrApplyClusterSubnetDefaultIPPool(ctx, podController, podSelector, nic, poolIPNum, reclaimIPPool)
+		v4Pool, v6Pool, err = i.findOrApplySubnetIPPool(ctx, podController, podSelector, nic, v4Subnet, v6Subnet, poolIPNum, reclaimIPPool, blockPrefixLength)
 		if nil != err {
 			if j == i.config.OperationRetries {
-				return nil, fmt.Errorf("exhaust all retries to find or apply auto-created IPPool: %v", err)
+				return nil, fmt.Errorf("exhaust all retries to find or apply auto-created IPPool: %w", err)
 			}
 			log.Sugar().Errorf("failed to find or apply auto-created IPPool with %d times: %v", j, err)
-			time.Sleep(i.config.OperationGapDuration)
+			if err := i.waitOperationGap(ctx); err != nil {
+				return nil, err
+			}
 			continue
 		}
 		break
 	}
 
-	// no cluster default subnets
+	// no auto-created IPPools
 	if v4Pool == nil && v6Pool == nil {
 		return nil, nil
 	}
@@ -772,55 +1129,42 @@ func (i *ipam) getPoolFromClusterDefaultSubnet(ctx context.Context, pod *corev1.
 	}
 
 	if v4Pool != nil {
-		result.PoolCandidates = append(result.PoolCandidates, &PoolCandidate{
-			IPVersion: constant.IPv4,
-			Pools:     []string{v4Pool.Name},
-			PToIPPool: PoolNameToIPPool{v4Pool.Name: v4Pool},
-		})
+		c, err := i.subnetPoolCandidate(ctx, constant.IPv4, v4Pool)
+		if nil != err {
+			return nil, err
+		}
+		result.PoolCandidates = append(result.PoolCandidates, c)
 	}
 
 	if v6Pool != nil {
-		result.PoolCandidates = append(result.PoolCandidates, &PoolCandidate{
-			IPVersion: constant.IPv6,
-			Pools:     []string{v6Pool.Name},
-			PToIPPool: PoolNameToIPPool{v6Pool.Name: v6Pool},
-		})
+		c, err := i.subnetPoolCandidate(ctx, constant.IPv6, v6Pool)
+		if nil != err {
+			return nil, err
+		}
+		result.PoolCandidates = append(result.PoolCandidates, c)
 	}
 
 	return result, nil
 }
 
-// findOrApplyClusterSubnetDefaultIPPool serves for cluster default subnet usage.
+// findOrApplySubnetIPPool serves for cluster default subnet and CNI network configuration subnet usage.
 // This will create auto-created IPPool or update auto-created IPPool desired IP number
-func (i *ipam) findOrApplyClusterSubnetDefaultIPPool(ctx context.Context, podController types.PodTopController, podSelector *metav1.LabelSelector,
-	ifName string, poolIPNum int, reclaimIPPool bool) (v4Pool, v6Pool *spiderpoolv1.SpiderIPPool, err error) {
+func (i *ipam) findOrApplySubnetIPPool(ctx context.Context, podController types.PodTopController, podSelector *metav1.LabelSelector,
+	ifName, v4Subnet, v6Subnet string, poolIPNum int, reclaimIPPool bool, blockPrefixLength int) (v4Pool, v6Pool *spiderpoolv1.SpiderIPPool, err error) {
 	log := logutils.FromContext(ctx)
 
-	var clusterDefaultV4Subnet, clusterDefaultV6Subnet string
-
-	if len(singletons.ClusterDefaultPool.ClusterDefaultIPv4Subnet) != 0 {
-		clusterDefaultV4Subnet = singletons.ClusterDefaultPool.ClusterDefaultIPv4Subnet[0]
-	}
-	if len(singletons.ClusterDefaultPool.ClusterDefaultIPv6Subnet) != 0 {
-		clusterDefaultV6Subnet = singletons.ClusterDefaultPool.ClusterDefaultIPv6Subnet[0]
-	}
-	// no cluster default subnet specified
-	if (i.config.EnableIPv4 && clusterDefaultV4Subnet == "") || (i.config.EnableIPv6 && clusterDefaultV6Subnet == "") {
-		return nil, nil, nil
-	}
-
 	fn := func(poolList *spiderpoolv1.SpiderIPPoolList, subnetName string, ipVersion types.IPVersion, matchLabel client.MatchingLabels) (*spiderpoolv1.SpiderIPPool, error) {
 		if poolList == nil || len(poolList.Items) == 0 {
-			log.Sugar().Debugf("there's no 'IPv%d' IPPoolList retrieved from cluster default SpiderSubent '%s' with matchLabel '%v'",
+			log.Sugar().Debugf("there's no 'IPv%d' IPPoolList retrieved from SpiderSubent '%s' with matchLabel '%v'",
 				ipVersion, subnetName, matchLabel)
-			pool, err := i.subnetManager.AllocateEmptyIPPool(ctx, subnetName, podController, podSelector, poolIPNum, ipVersion, reclaimIPPool, ifName)
+			pool, err := i.subnetManager.AllocateEmptyIPPool(ctx, subnetName, podController, podSelector, poolIPNum, ipVersion, reclaimIPPool, ifName, blockPrefixLength)
 			if nil != err {
 				return nil, err
 			}
 			return pool, nil
 		} else if len(poolList.Items) == 1 {
 			pool := poolList.Items[0].DeepCopy()
-			log.Sugar().Debugf("found cluster default SpiderSubnet '%s' IPPool '%s' with matchLabel '%v', check it whether need to be scaled",
+			log.Sugar().Debugf("found SpiderSubnet '%s' IPPool '%s' with matchLabel '%v', check it whether need to be scaled",
 				subnetName, pool.Name, matchLabel)
 			enableScaled, err := i.subnetManager.CheckScaleIPPool(ctx, pool, subnetName, poolIPNum)
 			if nil != err {
@@ -828,7 +1172,9 @@ func (i *ipam) findOrApplyClusterSubnetDefaultIPPool(ctx context.Context, podCon
 			}
 			if enableScaled {
 				// wait for a while and let ippool informer to scale the IPPool's IPs
-				time.Sleep(i.config.OperationGapDuration)
+				if err := i.waitOperationGap(ctx); err != nil {
+					return nil, err
+				}
 			}
 			return pool, nil
 		} else {
@@ -840,47 +1186,47 @@ func (i *ipam) findOrApplyClusterSubnetDefaultIPPool(ctx context.Context, podCon
 	var errV4, errV6 error
 	var wg sync.WaitGroup
 
-	if i.config.EnableIPv4 && clusterDefaultV4Subnet != "" {
+	if i.config.EnableIPv4 && v4Subnet != "" {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
 			matchLabels := client.MatchingLabels{
 				constant.LabelIPPoolOwnerApplicationUID: string(podController.UID),
-				constant.LabelIPPoolOwnerSpiderSubnet:   clusterDefaultV4Subnet,
+				constant.LabelIPPoolOwnerSpiderSubnet:   v4Subnet,
 				constant.LabelIPPoolOwnerApplication:    subnetmanagercontrollers.AppLabelValue(podController.Kind, podController.Namespace, podController.Name),
 				constant.LabelIPPoolVersion:             constant.LabelIPPoolVersionV4,
 				constant.LabelIPPoolInterface:           ifName,
 			}
 			v4PoolList, err := i.ipPoolManager.ListIPPools(ctx, matchLabels)
 			if nil != err {
-				errV4 = fmt.Errorf("failed to get IPv4 IPPoolList with labels '%v', error: %v", matchLabels, err)
+				errV4 = fmt.Errorf("failed to get IPv4 IPPoolList with labels '%v', error: %w", matchLabels, err)
 				return
 			}
 
-			v4Pool, errV4 = fn(v4PoolList.DeepCopy(), clusterDefaultV4Subnet, constant.IPv4, matchLabels)
+			v4Pool, errV4 = fn(v4PoolList.DeepCopy(), v4Subnet, constant.IPv4, matchLabels)
 		}()
 	}
 
-	if i.config.EnableIPv6 && clusterDefaultV6Subnet != "" {
+	if i.config.EnableIPv6 && v6Subnet != "" {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 
 			matchLabels := client.MatchingLabels{
 				constant.LabelIPPoolOwnerApplicationUID: string(podController.UID),
-				constant.LabelIPPoolOwnerSpiderSubnet:   clusterDefaultV6Subnet,
+				constant.LabelIPPoolOwnerSpiderSubnet:   v6Subnet,
 				constant.LabelIPPoolOwnerApplication:    subnetmanagercontrollers.AppLabelValue(podController.Kind, podController.Namespace, podController.Name),
 				constant.LabelIPPoolVersion:             constant.LabelIPPoolVersionV6,
 				constant.LabelIPPoolInterface:           ifName,
 			}
 			v6PoolList, err := i.ipPoolManager.ListIPPools(ctx, matchLabels)
 			if nil != err {
-				errV6 = fmt.Errorf("failed to get IPv6 IPPoolList with labels '%v', error: %v", matchLabels, err)
+				errV6 = fmt.Errorf("failed to get IPv6 IPPoolList with labels '%v', error: %w", matchLabels, err)
 				return
 			}
 
-			v6Pool, errV6 = fn(v6PoolList.DeepCopy(), clusterDefaultV6Subnet, constant.IPv6, matchLabels)
+			v6Pool, errV6 = fn(v6PoolList.DeepCopy(), v6Subnet, constant.IPv6, matchLabels)
 		}()
 	}
 
@@ -930,6 +1276,103 @@ func (i *ipam) getPoolFromNS(ctx context.Context, namespace, nic string, cleanGa
 	return t, nil
 }
 
+// getPoolFromDefaultIPPools selects the IPPools which declare themselves the
+// default of namespace, or of the whole cluster if namespace is empty, sorted
+// by their names in each IP version.
+func (i *ipam) getPoolFromDefaultIPPools(ctx context.Context, namespace, nic string, cleanGateway bool) (*ToBeAllocated, error) {
+	ipPools, err := i.ipPoolManager.ListDefaultIPPools(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the default IPPools: %w", err)
+	}
+
+	var v4Pools, v6Pools []string
+	for _, pool := range ipPools {
+		if pool.Spec.IPVersion == nil {
+			continue
+		}
+		switch *pool.Spec.IPVersion {
+		case constant.IPv4:
+			v4Pools = append(v4Pools, pool.Name)
+		case constant.IPv6:
+			v6Pools = append(v6Pools, pool.Name)
+		}
+	}
+
+	if len(v4Pools) == 0 && len(v6Pools) == 0 {
+		return nil, nil
+	}
+
+	logger := logutils.FromContext(ctx)
+	if namespace != "" {
+		logger.Sugar().Infof("Use the default IPPools of Namespace %s", namespace)
+	}
+
+	t := &ToBeAllocated{
+		NIC:          nic,
+		CleanGateway: cleanGateway,
+	}
+	if len(v4Pools) != 0 {
+		t.PoolCandidates = append(t.PoolCandidates, &PoolCandidate{
+			IPVersion: constant.IPv4,
+			Pools:     v4Pools,
+		})
+	}
+	if len(v6Pools) != 0 {
+		t.PoolCandidates = append(t.PoolCandidates, &PoolCandidate{
+			IPVersion: constant.IPv6,
+			Pools:     v6Pools,
+		})
+	}
+
+	return t, nil
+}
+
+// getClusterDefaultPool selects the cluster default IPPools of Configmap
+// spiderpool-conf, and then the IPPools which declare themselves the default
+// of the cluster, as the lower-priority candidates of each IP version.
+func (i *ipam) getClusterDefaultPool(ctx context.Context, nic string, cleanGateway bool) (*ToBeAllocated, error) {
+	fromIPPools, err := i.getPoolFromDefaultIPPools(ctx, "", nic, cleanGateway)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := i.config.getClusterDefaultPool(ctx, nic, cleanGateway)
+	if err != nil {
+		if fromIPPools != nil && errors.Is(err, constant.ErrNoAvailablePool) {
+			logutils.FromContext(ctx).Info("Use the default IPPools of the cluster")
+			return fromIPPools, nil
+		}
+		return nil, err
+	}
+	if fromIPPools == nil {
+		return t, nil
+	}
+
+	for _, extra := range fromIPPools.PoolCandidates {
+		merged := false
+		for _, c := range t.PoolCandidates {
+			if c.IPVersion != extra.IPVersion {
+				continue
+			}
+			// Copy the Pools, which are shared with the configuration.
+			pools := append([]string{}, c.Pools...)
+			existing := sets.NewString(c.Pools...)
+			for _, pool := range extra.Pools {
+				if !existing.Has(pool) {
+					pools = append(pools, pool)
+				}
+			}
+			c.Pools = pools
+			merged = true
+		}
+		if !merged {
+			t.PoolCandidates = append(t.PoolCandidates, extra)
+		}
+	}
+
+	return t, nil
+}
+
 func (i *ipam) precheckPoolCandidates(ctx context.Context, tt ToBeAllocateds) error {
 	logger := logutils.FromContext(ctx)
 
@@ -947,9 +1390,9 @@ func (i *ipam) precheckPoolCandidates(ctx context.Context, tt ToBeAllocateds) er
 				}
 
 				logger.Sugar().Debugf("Get original candidate IPPool %s", pool)
-				ipPool, err := i.ipPoolManager.GetIPPoolByName(ctx, pool)
+				ipPool, err := i.ipPoolManager.GetIPPoolByName(ctx, pool, constant.UseCache)
 				if err != nil {
-					return fmt.Errorf("failed to get original candidate IPPool %s: %v", pool, err)
+					return fmt.Errorf("failed to get original candidate IPPool %s: %w", pool, err)
 				}
 				ptp[pool] = ipPool
 			}
@@ -972,6 +1415,9 @@ func (i *ipam) filterPoolCandidates(ctx context.Context, tt ToBeAllocateds, pod
 				if err := i.selectByPod(ctx, c.IPVersion, c.PToIPPool[pool], pod); err != nil {
 					logger.Sugar().Warnf("IPPool %s is filtered by Pod: %v", pool, err)
 					errs = append(errs, err)
+					if errors.Is(err, constant.ErrOutOfWindow) {
+						event.EventRecorder.Eventf(pod, corev1.EventTypeWarning, constant.EventReasonAllocationDeferred, err.Error())
+					}
 
 					delete(c.PToIPPool, pool)
 					c.Pools = append((c.Pools)[:j], (c.Pools)[j+1:]...)
@@ -1003,7 +1449,7 @@ func (i *ipam) selectByPod(ctx context.Context, version types.IPVersion, ipPool
 
 	if ipPool.Status.TotalIPCount != nil && ipPool.Status.AllocatedIPCount != nil {
 		if *ipPool.Status.TotalIPCount-*ipPool.Status.AllocatedIPCount == 0 {
-			return constant.ErrIPUsedOut
+			return &constant.PoolExhaustedError{Kind: constant.SpiderIPPoolKind, Names: []string{ipPool.Name}}
 		}
 	}
 
@@ -1021,6 +1467,18 @@ func (i *ipam) selectByPod(ctx context.Context, version types.IPVersion, ipPool
 		}
 	}
 
+	if err := checkAllocationWindow(ctx, ipPool, pod); err != nil {
+		return err
+	}
+
+	// The VLAN sub-interface is created by the CNI plugins on demand, so the
+	// master interface is enough.
+	if master, ok := ipPool.Annotations[constant.AnnoIPPoolMasterInterface]; ok && i.config.InterfaceExists != nil {
+		if !i.config.InterfaceExists(master) {
+			return fmt.Errorf("master interface %s of IPPool %s not found on Node %s", master, ipPool.Name, pod.Spec.NodeName)
+		}
+	}
+
 	if ipPool.Spec.NamespaceAffinity != nil {
 		namespace, err := i.nsManager.GetNamespaceByName(ctx, pod.Namespace)
 		if err != nil {
@@ -1075,22 +1533,46 @@ func (i *ipam) Release(ctx context.Context, delArgs *models.IpamDelArgs) error {
 	logger := logutils.FromContext(ctx)
 	logger.Info("Start to release")
 
-	endpoint, err := i.endpointManager.GetEndpointByName(ctx, *delArgs.PodNamespace, *delArgs.PodName)
+	err := i.runStage(ctx, stageRelease, func(ctx context.Context) error {
+		return i.releaseByPod(ctx, *delArgs.PodNamespace, *delArgs.PodName, *delArgs.ContainerID, *delArgs.IfName)
+	})
+	if err == nil || !i.deferRelease(ctx, err) {
+		return err
+	}
+
+	if err := i.journal.DeferRelease(allocationjournal.Entry{
+		ContainerID: *delArgs.ContainerID,
+		Namespace:   *delArgs.PodNamespace,
+		Pod:         *delArgs.PodName,
+		NIC:         *delArgs.IfName,
+	}); err != nil {
+		return fmt.Errorf("failed to journal the deferred release: %w", err)
+	}
+	logger.Sugar().Warnf("API server is unavailable, acknowledge the release and defer it: %v", err)
+
+	return nil
+}
+
+func (i *ipam) releaseByPod(ctx context.Context, podNamespace, podName, containerID, nic string) error {
+	logger := logutils.FromContext(ctx)
+
+	endpoint, err := i.endpointManager.GetEndpointByName(ctx, podNamespace, podName)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Endpoin does not exist, ignoring release")
+			i.nodeAllocations.forget(containerID)
 			return nil
 		}
-		return fmt.Errorf("failed to get Endpoint %s/%s: %v", *delArgs.PodNamespace, *delArgs.PodName, err)
+		return fmt.Errorf("failed to get Endpoint %s/%s: %w", podNamespace, podName, err)
 	}
 
-	if err := i.releaseForAllNICs(ctx, *delArgs.ContainerID, *delArgs.IfName, endpoint); err != nil {
+	if err := i.releaseForAllNICs(ctx, containerID, nic, endpoint); err != nil {
 		return err
 	}
 
 	if i.config.EnableSpiderSubnet && endpoint.Status.OwnerControllerType == constant.KindPod {
 		logger.Info("try to check whether need to delete dead orphan pod's auto-created IPPool")
-		err := i.deleteDeadOrphanPodAutoIPPool(ctx, *delArgs.PodNamespace, *delArgs.PodName, *delArgs.IfName)
+		err := i.deleteDeadOrphanPodAutoIPPool(ctx, podNamespace, podName, nic)
 		if nil != err {
 			logger.Sugar().Errorf("failed to delete dead orphan pod auto-created IPPool: %v", err)
 		}
@@ -1108,9 +1590,11 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, containerID, nic string, e
 		logger.Sugar().Infof("Roll back IP allocation details: %+v", details)
 
 		if err := i.release(ctx, containerID, details); err != nil {
-			return fmt.Errorf("failed to roll back the allocated IP addresses: %v", err)
+			return fmt.Errorf("failed to roll back the allocated IP addresses: %w", err)
 		}
 		i.removeRollback(containerID)
+		i.endAllocation(ctx, containerID, false)
+		i.nodeAllocations.forget(containerID)
 		logger.Info("Succeed to roll back")
 
 		return nil
@@ -1119,6 +1603,7 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, containerID, nic string, e
 	allocation := workloadendpointmanager.RetrieveIPAllocation(containerID, nic, endpoint)
 	if allocation == nil {
 		logger.Info("Nothing retrieved for releasing")
+		i.nodeAllocations.forget(containerID)
 		return nil
 	}
 
@@ -1135,6 +1620,17 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, containerID, nic string, e
 		}
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.SandboxRestartIPRetention) {
+		restarting, err := i.isSandboxRestarting(ctx, endpoint.Namespace, endpoint.Name, allocation)
+		if err != nil {
+			return err
+		}
+		if restarting {
+			logger.Info("The sandbox of Pod is restarting, retain the IP allocation")
+			return nil
+		}
+	}
+
 	logger.Sugar().Infof("Release IP allocation details: %+v", allocation.IPs)
 	if err := i.release(ctx, allocation.ContainerID, allocation.IPs); err != nil {
 		return err
@@ -1142,8 +1638,9 @@ func (i *ipam) releaseForAllNICs(ctx context.Context, containerID, nic string, e
 
 	logger.Info("Clear the current IP allocation")
 	if err := i.endpointManager.ClearCurrentIPAllocation(ctx, containerID, endpoint); err != nil {
-		return fmt.Errorf("failed to clear current IP allocation: %v", err)
+		return fmt.Errorf("failed to clear current IP allocation: %w", err)
 	}
+	i.nodeAllocations.forget(containerID)
 
 	logger.Info("Succeed to release")
 
@@ -1181,6 +1678,29 @@ func (i *ipam) shouldReleaseStatefulSet(ctx context.Context, podNamespace, podNa
 	return false, nil
 }
 
+// isSandboxRestarting checks whether the sandbox of the Pod is torn down to be
+// recreated, e.g. on container restarts or in-place updates, rather than the
+// Pod is terminated. It is only true if the Pod with the UID of the IP
+// allocation still exists on the same node and could still allocate IP
+// addresses.
+func (i *ipam) isSandboxRestarting(ctx context.Context, podNamespace, podName string, allocation *spiderpoolv1.PodIPAllocation) (bool, error) {
+	if allocation.UID == "" || allocation.Node == nil {
+		return false, nil
+	}
+
+	pod, err := i.podManager.GetPodByName(ctx, podNamespace, podName, constant.UseCache)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	if string(pod.UID) != allocation.UID || pod.Spec.NodeName != *allocation.Node {
+		return false, nil
+	}
+
+	_, allocatable := podmanager.CheckPodStatus(pod)
+	return allocatable, nil
+}
+
 func (i *ipam) release(ctx context.Context, containerID string, details []spiderpoolv1.IPAllocationDetail) error {
 	if len(details) == 0 {
 		return nil
@@ -1190,7 +1710,7 @@ func (i *ipam) release(ctx context.Context, containerID string, details []spider
 	pics := GroupIPDetails(containerID, "", details)
 	tickets := pics.Pools()
 	if err := i.ipamLimiter.AcquireTicket(ctx, tickets...); err != nil {
-		return fmt.Errorf("failed to queue correctly: %v", err)
+		return fmt.Errorf("failed to queue correctly: %w", err)
 	}
 	defer i.ipamLimiter.ReleaseTicket(ctx, tickets...)
 
@@ -1230,13 +1750,13 @@ func (i *ipam) deleteDeadOrphanPodAutoIPPool(ctx context.Context, podNS, podName
 	log := logutils.FromContext(ctx)
 
 	isAlive := true
-	pod, err := i.podManager.GetPodByName(ctx, podNS, podName)
+	pod, err := i.podManager.GetPodByName(ctx, podNS, podName, constant.UseCache)
 	if nil != err {
 		if apierrors.IsNotFound(err) {
 			isAlive = false
 			log.Debug("pod is already deleted, try to delete its auto-created IPPool")
 		} else {
-			return fmt.Errorf("failed to get pod: %v", err)
+			return fmt.Errorf("failed to get pod: %w", err)
 		}
 	} else {
 		if pod.DeletionTimestamp != nil {
@@ -1285,5 +1805,31 @@ func (i *ipam) getRollback(containerID string) []*AllocationResult {
 }
 
 func (i *ipam) Start(ctx context.Context) error {
+	if i.journal != nil {
+		go i.repairInFlightAllocations(ctx)
+		go i.reconcileDeferredReleases(ctx)
+	}
+	go i.syncNodeAllocations(ctx)
+
 	return i.ipamLimiter.Start(ctx)
 }
+
+// checkAllocationWindow checks that the IPPool is in its allocation window,
+// unless the Pod bypasses it.
+func checkAllocationWindow(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool, pod *corev1.Pod) error {
+	in, err := ippoolmanager.InAllocationWindow(ipPool.Spec.AllocationWindow, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid allocation window of IPPool %s: %w", ipPool.Name, err)
+	}
+	if in {
+		return nil
+	}
+
+	if pod.Annotations[constant.AnnoPodBypassAllocationWindow] == constant.True {
+		logger := logutils.FromContext(ctx)
+		logger.Sugar().Warnf("Bypass the allocation window of IPPool %s", ipPool.Name)
+		return nil
+	}
+
+	return fmt.Errorf("%w, IPPool %s only allocates IP addresses in the schedules %v", constant.ErrOutOfWindow, ipPool.Name, ipPool.Spec.AllocationWindow.Schedules)
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// fakeIPPoolManager gets the preset IPPools, and fails with the preset error.
+type fakeIPPoolManager struct {
+	ippoolmanager.IPPoolManager
+
+	pools map[string]*spiderpoolv1.SpiderIPPool
+	err   error
+}
+
+func (f *fakeIPPoolManager) GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv1.SpiderIPPool, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	pool, ok := f.pools[poolName]
+	if !ok {
+		return nil, apierrors.NewNotFound(spiderpoolv1.Resource("spiderippools"), poolName)
+	}
+
+	return pool, nil
+}
+
+var _ = Describe("IPAM", Label("ipam_test"), func() {
+	Describe("Filter the candidate IPPools by the master interface", func() {
+		var i *ipam
+		var pod *corev1.Pod
+
+		newIPPool := func(name, master string) *spiderpoolv1.SpiderIPPool {
+			ipPool := &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					Disable:   pointer.Bool(false),
+				},
+			}
+			if master != "" {
+				ipPool.Annotations = map[string]string{constant.AnnoIPPoolMasterInterface: master}
+			}
+
+			return ipPool
+		}
+
+		BeforeEach(func() {
+			i = &ipam{
+				config: IPAMConfig{
+					InterfaceExists: func(name string) bool {
+						return name == "eth0"
+					},
+				},
+			}
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"},
+				Spec:       corev1.PodSpec{NodeName: "node1"},
+			}
+		})
+
+		It("selects the IPPool whose master interface exists on the Node", func() {
+			err := i.selectByPod(context.TODO(), constant.IPv4, newIPPool("pool", "eth0"), pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the IPPool whose master interface is missing on the Node", func() {
+			err := i.selectByPod(context.TODO(), constant.IPv4, newIPPool("pool", "eth1"), pod)
+			Expect(err).To(MatchError("master interface eth1 of IPPool pool not found on Node node1"))
+		})
+
+		It("selects the IPPool without master interface", func() {
+			err := i.selectByPod(context.TODO(), constant.IPv4, newIPPool("pool", ""), pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not check the master interface without the checker", func() {
+			i.config.InterfaceExists = nil
+
+			err := i.selectByPod(context.TODO(), constant.IPv4, newIPPool("pool", "eth1"), pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the rejection reason when all candidate IPPools are filtered out", func() {
+			tt := ToBeAllocateds{
+				{
+					NIC: "eth0",
+					PoolCandidates: []*PoolCandidate{{
+						IPVersion: constant.IPv4,
+						Pools:     []string{"pool1", "pool2"},
+						PToIPPool: PoolNameToIPPool{
+							"pool1": newIPPool("pool1", "eth1"),
+							"pool2": newIPPool("pool2", "eth2"),
+						},
+					}},
+				},
+			}
+
+			err := i.filterPoolCandidates(context.TODO(), tt, pod)
+			Expect(err).To(MatchError(constant.ErrNoAvailablePool))
+			Expect(err).To(MatchError(ContainSubstring("master interface eth1 of IPPool pool1 not found on Node node1")))
+			Expect(err).To(MatchError(ContainSubstring("master interface eth2 of IPPool pool2 not found on Node node1")))
+		})
+
+		It("keeps the IPPools whose master interface exists", func() {
+			tt := ToBeAllocateds{
+				{
+					NIC: "eth0",
+					PoolCandidates: []*PoolCandidate{{
+						IPVersion: constant.IPv4,
+						Pools:     []string{"pool1", "pool2", "pool3"},
+						PToIPPool: PoolNameToIPPool{
+							"pool1": newIPPool("pool1", "eth1"),
+							"pool2": newIPPool("pool2", "eth0"),
+							"pool3": newIPPool("pool3", ""),
+						},
+					}},
+				},
+			}
+
+			err := i.filterPoolCandidates(context.TODO(), tt, pod)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tt[0].PoolCandidates[0].Pools).To(Equal([]string{"pool2", "pool3"}))
+			Expect(tt[0].PoolCandidates[0].PToIPPool).NotTo(HaveKey("pool1"))
+		})
+	})
+
+	Describe("Apply the detection settings of the IPPools", func() {
+		var i *ipam
+		var ipPoolManager *fakeIPPoolManager
+
+		newIPConfig := func(pool string) *models.IPConfig {
+			return &models.IPConfig{
+				Address: pointer.String("172.18.40.10/24"),
+				IPPool:  pool,
+				Nic:     pointer.String("eth0"),
+				Version: pointer.Int64(constant.IPv4),
+			}
+		}
+
+		BeforeEach(func() {
+			ipPoolManager = &fakeIPPoolManager{pools: map[string]*spiderpoolv1.SpiderIPPool{}}
+			i = &ipam{
+				config: IPAMConfig{
+					EnableGatewayDetection:    true,
+					EnableIPConflictDetection: false,
+				},
+				ipPoolManager: ipPoolManager,
+			}
+		})
+
+		It("takes the cluster defaults without the settings of the IPPool", func() {
+			ipPoolManager.pools["pool"] = &spiderpoolv1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			addResp := &models.IpamAddResponse{Ips: []*models.IPConfig{newIPConfig("pool"), newIPConfig("")}}
+
+			Expect(i.applyIPPoolSettings(context.TODO(), "eth0", addResp)).To(Succeed())
+			for _, ip := range addResp.Ips {
+				Expect(ip.EnableGatewayDetection).To(BeTrue())
+				Expect(ip.EnableIPConflictDetection).To(BeFalse())
+			}
+		})
+
+		It("overrides the cluster defaults with the settings of the IPPool", func() {
+			ipPoolManager.pools["pool"] = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec: spiderpoolv1.IPPoolSpec{
+					EnableGatewayDetection:    pointer.Bool(false),
+					EnableIPConflictDetection: pointer.Bool(true),
+				},
+			}
+			addResp := &models.IpamAddResponse{Ips: []*models.IPConfig{newIPConfig("pool")}}
+
+			Expect(i.applyIPPoolSettings(context.TODO(), "eth0", addResp)).To(Succeed())
+			Expect(addResp.Ips[0].EnableGatewayDetection).To(BeFalse())
+			Expect(addResp.Ips[0].EnableIPConflictDetection).To(BeTrue())
+		})
+
+		It("takes the cluster defaults for the IPPool not found", func() {
+			addResp := &models.IpamAddResponse{Ips: []*models.IPConfig{newIPConfig("gone")}}
+
+			Expect(i.applyIPPoolSettings(context.TODO(), "eth0", addResp)).To(Succeed())
+			Expect(addResp.Ips[0].EnableGatewayDetection).To(BeTrue())
+			Expect(addResp.Ips[0].EnableIPConflictDetection).To(BeFalse())
+		})
+
+		It("fails to get the IPPool", func() {
+			ipPoolManager.err = fmt.Errorf("the server is currently unable to handle the request")
+			addResp := &models.IpamAddResponse{Ips: []*models.IPConfig{newIPConfig("pool")}}
+
+			err := i.applyIPPoolSettings(context.TODO(), "eth0", addResp)
+			Expect(err).To(MatchError(ContainSubstring("failed to get IPPool pool")))
+		})
+	})
+})
@@ -0,0 +1,252 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/allocationjournal"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// journalRepairInterval is the interval to retry repairing the allocations in
+// flight, e.g. until the informer caches are synced after the restart, and to
+// reconcile the deferred releases.
+const journalRepairInterval = 5 * time.Second
+
+// beginAllocation records the allocation in the journal before any IP address
+// is written to the IPPools.
+func (i *ipam) beginAllocation(ctx context.Context, containerID, nic string, pod *corev1.Pod, tt ToBeAllocateds) error {
+	if i.journal == nil {
+		return nil
+	}
+
+	if err := i.journal.Begin(allocationjournal.Entry{
+		ContainerID: containerID,
+		Namespace:   pod.Namespace,
+		Pod:         pod.Name,
+		NIC:         nic,
+		IPPools:     tt.Pools(),
+	}); err != nil {
+		return fmt.Errorf("failed to journal the allocation: %w", err)
+	}
+
+	return nil
+}
+
+// endAllocation ends the allocation in the journal once it is returned to the
+// CNI plugin, or it has failed. The failed allocation with the IP addresses to
+// roll back stays in flight until they are released, so that they could be
+// repaired if the agent restarts before that.
+func (i *ipam) endAllocation(ctx context.Context, containerID string, committed bool) {
+	if i.journal == nil {
+		return
+	}
+
+	logger := logutils.FromContext(ctx)
+	if committed {
+		if err := i.journal.Commit(containerID); err != nil {
+			logger.Sugar().Warnf("Failed to commit the allocation in journal: %v", err)
+		}
+		return
+	}
+
+	if len(i.getRollback(containerID)) != 0 {
+		return
+	}
+	if err := i.journal.Abort(containerID); err != nil {
+		logger.Sugar().Warnf("Failed to abort the allocation in journal: %v", err)
+	}
+}
+
+// repairInFlightAllocations repairs the allocations left in flight by the
+// last run of the agent, until all of them are repaired.
+func (i *ipam) repairInFlightAllocations(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	entries := i.journal.InFlight()
+	if len(entries) == 0 {
+		return
+	}
+	logger.Sugar().Infof("Repair %d IP allocations in flight before the restart", len(entries))
+
+	_ = wait.PollImmediateUntilWithContext(ctx, journalRepairInterval, func(ctx context.Context) (bool, error) {
+		var errs []error
+		var remaining []allocationjournal.Entry
+		for _, e := range entries {
+			if err := i.repairAllocation(ctx, e); err != nil {
+				errs = append(errs, err)
+				remaining = append(remaining, e)
+				continue
+			}
+			if err := i.journal.Abort(e.ContainerID); err != nil {
+				errs = append(errs, err)
+				remaining = append(remaining, e)
+			}
+		}
+
+		// Only the allocations of the last run are repaired, not the ones
+		// in flight now.
+		entries = remaining
+		if len(errs) != 0 {
+			logger.Sugar().Warnf("Failed to repair the IP allocations in flight, retry later: %v", utilerrors.NewAggregate(errs))
+			return false, nil
+		}
+		logger.Info("Succeed to repair the IP allocations in flight")
+
+		return true, nil
+	})
+}
+
+// repairAllocation compares the IP addresses which the IPPools allocated to
+// the container with the ones the Endpoint records, the latter are the ones
+// returned to the CNI plugin. The IP addresses only allocated in the IPPools
+// are released. If the Endpoint records any IP address not allocated in its
+// IPPool, the whole allocation is released to be allocated again.
+func (i *ipam) repairAllocation(ctx context.Context, e allocationjournal.Entry) error {
+	logger := logutils.FromContext(ctx)
+
+	endpoint, err := i.endpointManager.GetEndpointByName(ctx, e.Namespace, e.Pod)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get Endpoint %s/%s: %w", e.Namespace, e.Pod, err)
+	}
+
+	recorded := PoolNameToIPAndCIDs{}
+	if endpoint != nil && endpoint.Status.Current != nil && endpoint.Status.Current.ContainerID == e.ContainerID {
+		recorded = GroupIPDetails(e.ContainerID, "", endpoint.Status.Current.IPs)
+	}
+
+	allocated := PoolNameToIPAndCIDs{}
+	for _, poolName := range e.IPPools {
+		if _, ok := allocated[poolName]; ok {
+			continue
+		}
+
+		pool, err := i.ipPoolManager.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get IPPool %s: %w", poolName, err)
+		}
+
+		allocated[poolName] = nil
+		for ip, a := range pool.Status.AllocatedIPs {
+			if a.ContainerID == e.ContainerID {
+				allocated[poolName] = append(allocated[poolName], types.IPAndCID{IP: ip, ContainerID: e.ContainerID})
+			}
+		}
+	}
+
+	complete := len(recorded) != 0
+	for poolName, ics := range recorded {
+		for _, ic := range ics {
+			if !containsIP(allocated[poolName], ic.IP) {
+				complete = false
+			}
+		}
+	}
+
+	leaked := PoolNameToIPAndCIDs{}
+	for poolName, ics := range allocated {
+		for _, ic := range ics {
+			if !complete || !containsIP(recorded[poolName], ic.IP) {
+				leaked[poolName] = append(leaked[poolName], ic)
+			}
+		}
+	}
+
+	for poolName, ics := range leaked {
+		logger.Sugar().Infof("Release IP addresses %+v leaked by the allocation of container %s from IPPool %s", ics, e.ContainerID, poolName)
+		if err := i.ipPoolManager.ReleaseIP(ctx, poolName, ics); err != nil {
+			return fmt.Errorf("failed to release IP addresses %+v from IPPool %s: %w", ics, poolName, err)
+		}
+	}
+
+	if !complete && len(recorded) != 0 {
+		logger.Sugar().Infof("Clear the incomplete IP allocation of container %s from Endpoint %s/%s", e.ContainerID, e.Namespace, e.Pod)
+		if err := i.endpointManager.ClearCurrentIPAllocation(ctx, e.ContainerID, endpoint); err != nil {
+			return fmt.Errorf("failed to clear the current IP allocation of Endpoint %s/%s: %w", e.Namespace, e.Pod, err)
+		}
+		i.nodeAllocations.forget(e.ContainerID)
+	}
+
+	return nil
+}
+
+// deferRelease checks whether the failed release should be acknowledged to
+// the CNI plugin and deferred, so that kubelet is not blocked from tearing
+// down the Pod while kube-apiserver is unavailable. The release is done later
+// from the local journal, and the informer caches still serve the reads.
+func (i *ipam) deferRelease(ctx context.Context, err error) bool {
+	return i.journal != nil && ctx.Err() == nil && isAPIServerUnavailable(err)
+}
+
+// reconcileDeferredReleases does the deferred releases in the background, once
+// kube-apiserver is available again.
+func (i *ipam) reconcileDeferredReleases(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for _, e := range i.journal.PendingReleases() {
+			if err := i.releaseByPod(ctx, e.Namespace, e.Pod, e.ContainerID, e.NIC); err != nil {
+				logger.Sugar().Debugf("Failed to do the deferred release of container %s of Pod %s/%s, retry later: %v", e.ContainerID, e.Namespace, e.Pod, err)
+				continue
+			}
+			if err := i.journal.Released(e.ContainerID); err != nil {
+				logger.Sugar().Warnf("Failed to end the deferred release in journal: %v", err)
+				continue
+			}
+			logger.Sugar().Infof("Succeed to do the deferred release of container %s of Pod %s/%s", e.ContainerID, e.Namespace, e.Pod)
+		}
+	}, journalRepairInterval)
+}
+
+// isAPIServerUnavailable checks whether the error is caused by kube-apiserver
+// being unreachable or unable to serve, rather than by the request itself.
+func isAPIServerUnavailable(err error) bool {
+	var agg utilerrors.Aggregate
+	if errors.As(err, &agg) {
+		for _, e := range agg.Errors() {
+			if isAPIServerUnavailable(e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func containsIP(ics []types.IPAndCID, ip string) bool {
+	for _, ic := range ics {
+		if ic.IP == ip {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,192 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// nodeAllocationsSyncInterval is the interval to retry seeding the IP
+// allocations of the node from the Endpoints.
+const nodeAllocationsSyncInterval = 5 * time.Second
+
+// nodeAllocation is the IP addresses allocated to a NIC of the container.
+type nodeAllocation struct {
+	namespace string
+	pod       string
+	ips       []*models.IPConfig
+}
+
+// nodeAllocations records the IP addresses allocated by the agent to the Pods
+// on the node, so that they could be listed without querying the API server.
+// They are seeded from the Endpoints of the node once the agent starts, and
+// maintained by the allocations and releases afterwards.
+type nodeAllocations struct {
+	lock   sync.RWMutex
+	synced bool
+	// containerID -> NIC -> allocation
+	containers map[string]map[string]*nodeAllocation
+}
+
+func newNodeAllocations() *nodeAllocations {
+	return &nodeAllocations{
+		containers: map[string]map[string]*nodeAllocation{},
+	}
+}
+
+// record records the IP addresses allocated to the NIC of the container. The
+// ones of the other containers of the Pod are dropped, as only one sandbox of
+// the Pod runs at the same time.
+func (n *nodeAllocations) record(namespace, pod, containerID, nic string, ips []*models.IPConfig) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for id, nics := range n.containers {
+		if id == containerID {
+			continue
+		}
+		for _, a := range nics {
+			if a.namespace == namespace && a.pod == pod {
+				delete(n.containers, id)
+				break
+			}
+		}
+	}
+
+	n.add(namespace, pod, containerID, nic, ips)
+}
+
+func (n *nodeAllocations) add(namespace, pod, containerID, nic string, ips []*models.IPConfig) {
+	nics, ok := n.containers[containerID]
+	if !ok {
+		nics = map[string]*nodeAllocation{}
+		n.containers[containerID] = nics
+	}
+	nics[nic] = &nodeAllocation{namespace: namespace, pod: pod, ips: ips}
+}
+
+// forget drops the IP addresses allocated to all NICs of the container.
+func (n *nodeAllocations) forget(containerID string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.containers, containerID)
+}
+
+// seed records the IP addresses of the containers listed from the Endpoints,
+// except the ones already recorded by the allocations since the start.
+func (n *nodeAllocations) seed(namespace, pod, containerID string, ips []*models.IPConfig) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.containers[containerID]; ok {
+		return
+	}
+
+	nicIPs := map[string][]*models.IPConfig{}
+	for _, ip := range ips {
+		nicIPs[*ip.Nic] = append(nicIPs[*ip.Nic], ip)
+	}
+	for nic, ips := range nicIPs {
+		n.add(namespace, pod, containerID, nic, ips)
+	}
+}
+
+func (n *nodeAllocations) markSynced() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.synced = true
+}
+
+// list returns the recorded IP addresses sorted by the Pods, the NICs and
+// the IP versions.
+func (n *nodeAllocations) list() (models.IPAllocations, error) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	if !n.synced {
+		return nil, fmt.Errorf("IP allocations of the node are not synced yet")
+	}
+
+	allocations := models.IPAllocations{}
+	for containerID, nics := range n.containers {
+		containerID := containerID
+		for nic, a := range nics {
+			nic, a := nic, a
+			for _, ip := range a.ips {
+				allocations = append(allocations, &models.IPAllocation{
+					PodNamespace: &a.namespace,
+					PodName:      &a.pod,
+					ContainerID:  &containerID,
+					Nic:          &nic,
+					Version:      ip.Version,
+					Address:      ip.Address,
+					IPPool:       ip.IPPool,
+					Vlan:         ip.Vlan,
+				})
+			}
+		}
+	}
+
+	sort.Slice(allocations, func(i, j int) bool {
+		a, b := allocations[i], allocations[j]
+		if *a.PodNamespace != *b.PodNamespace {
+			return *a.PodNamespace < *b.PodNamespace
+		}
+		if *a.PodName != *b.PodName {
+			return *a.PodName < *b.PodName
+		}
+		if *a.Nic != *b.Nic {
+			return *a.Nic < *b.Nic
+		}
+		return *a.Version < *b.Version
+	})
+
+	return allocations, nil
+}
+
+// ListAllocations lists the IP addresses allocated to the Pods on the node.
+func (i *ipam) ListAllocations(ctx context.Context) (models.IPAllocations, error) {
+	return i.nodeAllocations.list()
+}
+
+// syncNodeAllocations seeds the IP allocations of the node from the
+// Endpoints, until it succeeds.
+func (i *ipam) syncNodeAllocations(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	_ = wait.PollImmediateUntilWithContext(ctx, nodeAllocationsSyncInterval, func(ctx context.Context) (bool, error) {
+		endpointList, err := i.endpointManager.ListEndpoints(ctx)
+		if err != nil {
+			logger.Sugar().Warnf("Failed to list Endpoints to sync the IP allocations of the node, retry later: %v", err)
+			return false, nil
+		}
+
+		count := 0
+		for _, endpoint := range endpointList.Items {
+			current := endpoint.Status.Current
+			if current == nil || current.Node == nil || *current.Node != i.config.NodeName {
+				continue
+			}
+
+			ips, _ := convertIPDetailsToIPConfigsAndAllRoutes(current.IPs)
+			i.nodeAllocations.seed(endpoint.Namespace, endpoint.Name, current.ContainerID, ips)
+			count++
+		}
+		i.nodeAllocations.markSynced()
+		logger.Sugar().Infof("Succeed to sync the IP allocations of %d Pods on the node", count)
+
+		return true, nil
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+// The stages of the IPAM pipeline which could be bounded by the timeouts.
+const (
+	stagePoolSelection  = "pool_selection"
+	stageIPAllocation   = "ip_allocation"
+	stageEndpointUpdate = "endpoint_update"
+	stageRelease        = "release"
+)
+
+// StageTimeouts bounds each stage of the IPAM pipeline, besides the deadline
+// of the CNI request. Zero means the stage is only bounded by the latter.
+type StageTimeouts struct {
+	// PoolSelection bounds the selection of the IPPool candidates, including
+	// waiting for the auto-created IPPools of SpiderSubnets.
+	PoolSelection time.Duration
+	// IPAllocation bounds queuing for the IPPools and allocating the IP
+	// addresses from them.
+	IPAllocation time.Duration
+	// EndpointUpdate bounds each update of the Endpoint of the Pod.
+	EndpointUpdate time.Duration
+	// Release bounds the release of the IP addresses of the Pod.
+	Release time.Duration
+}
+
+func (t StageTimeouts) of(stage string) time.Duration {
+	switch stage {
+	case stagePoolSelection:
+		return t.PoolSelection
+	case stageIPAllocation:
+		return t.IPAllocation
+	case stageEndpointUpdate:
+		return t.EndpointUpdate
+	case stageRelease:
+		return t.Release
+	}
+
+	return 0
+}
+
+// runStage runs the stage bounded by its timeout. If the stage fails once its
+// context is done, because either the stage timed out or the CNI request was
+// given up, the stage is recorded into the metric and the error wraps the
+// error of the context.
+func (i *ipam) runStage(ctx context.Context, stage string, fn func(ctx context.Context) error) error {
+	if timeout := i.config.StageTimeouts.of(stage); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := fn(ctx)
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	logger := logutils.FromContext(ctx)
+	logger.Sugar().Warnf("IPAM stage %s is aborted: %v", stage, ctx.Err())
+	metric.RecordIPAMDeadlineExceeded(ctx, stage)
+
+	return fmt.Errorf("IPAM stage %s is aborted by %w: %w", stage, ctx.Err(), err)
+}
+
+// waitOperationGap waits for the operation gap between the retries, unless
+// the context is done.
+func (i *ipam) waitOperationGap(ctx context.Context) error {
+	timer := time.NewTimer(i.config.OperationGapDuration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
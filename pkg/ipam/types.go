@@ -90,4 +90,12 @@ type AllocationResult struct {
 	IP           *models.IPConfig
 	Routes       []*models.Route
 	CleanGateway bool
+
+	// DefaultRoutePolicy and Underlay come from the IPPool, to elect the
+	// NIC holding the default route.
+	DefaultRoutePolicy string
+	Underlay           bool
+	// DefaultRoute is set once the NIC holding the default route is
+	// elected, the default route is dropped if it's false.
+	DefaultRoute *bool
 }
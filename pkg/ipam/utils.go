@@ -8,9 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
+	"strconv"
+	"strings"
 
-	appsv1 "k8s.io/api/apps/v1"
-	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -156,6 +157,10 @@ func getCustomRoutes(pod *corev1.Pod) ([]*models.Route, error) {
 	return convertAnnoPodRoutesToOAIRoutes(annoPodRoutes), nil
 }
 
+// groupCustomRoutes injects the custom routes to the NICs of the IP
+// allocation results. A route scoped to an interface only goes to that NIC,
+// and the gateway of every route must be reachable within the subnet of the
+// IP address allocated to its NIC.
 func groupCustomRoutes(ctx context.Context, customRoutes []*models.Route, results []*AllocationResult) error {
 	if len(customRoutes) == 0 {
 		return nil
@@ -169,6 +174,10 @@ func groupCustomRoutes(ctx context.Context, customRoutes []*models.Route, result
 
 		for i := 0; i < len(customRoutes); i++ {
 			route := customRoutes[i]
+			if *route.IfName != "" && *route.IfName != *res.IP.Nic {
+				continue
+			}
+
 			if ipNet.Contains(net.ParseIP(*route.Gw)) {
 				route.IfName = res.IP.Nic
 				res.Routes = append(res.Routes, route)
@@ -180,52 +189,87 @@ func groupCustomRoutes(ctx context.Context, customRoutes []*models.Route, result
 	}
 
 	if len(customRoutes) != 0 {
-		logger := logutils.FromContext(ctx)
-		logger.Sugar().Warnf("Invalid custom routes: %+v", customRoutes)
+		var unreachable []string
+		for _, route := range customRoutes {
+			if *route.IfName != "" {
+				unreachable = append(unreachable, fmt.Sprintf("'interface: %s, dst: %s, gw: %s'", *route.IfName, *route.Dst, *route.Gw))
+			} else {
+				unreachable = append(unreachable, fmt.Sprintf("'dst: %s, gw: %s'", *route.Dst, *route.Gw))
+			}
+		}
+
+		return fmt.Errorf("%w, gateways of custom routes %s are unreachable within the subnets of the allocated IP addresses", constant.ErrWrongInput, strings.Join(unreachable, ", "))
 	}
 
 	return nil
 }
 
+// getHostPortMappings returns the hostPorts of the Pod forwarded to the IP
+// addresses of the NIC being set up, as the hints for the CNI plugins in the
+// chain, since the portmap plugin assumes the overlay IP address of the Pod.
+func getHostPortMappings(pod *corev1.Pod, ifName string, ips []*models.IPConfig) ([]*models.HostPortMapping, error) {
+	snat := true
+	if anno, ok := pod.Annotations[constant.AnnoPodHostPortSNAT]; ok {
+		var err error
+		snat, err = strconv.ParseBool(anno)
+		if err != nil {
+			return nil, fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodHostPortSNAT, err)
+		}
+	}
+
+	var mappings []*models.HostPortMapping
+	for _, c := range pod.Spec.Containers {
+		for _, port := range c.Ports {
+			if port.HostPort == 0 {
+				continue
+			}
+
+			protocol := corev1.ProtocolTCP
+			if port.Protocol != "" {
+				protocol = port.Protocol
+			}
+
+			for _, ip := range ips {
+				if ip.Nic == nil || *ip.Nic != ifName || ip.Address == nil {
+					continue
+				}
+
+				podIP, _, err := net.ParseCIDR(*ip.Address)
+				if err != nil {
+					return nil, err
+				}
+				if hostIP := net.ParseIP(port.HostIP); hostIP != nil && (hostIP.To4() == nil) != (podIP.To4() == nil) {
+					continue
+				}
+
+				mappings = append(mappings, &models.HostPortMapping{
+					HostPort:      int64(port.HostPort),
+					ContainerPort: int64(port.ContainerPort),
+					Protocol:      strings.ToLower(string(protocol)),
+					HostIP:        port.HostIP,
+					PodIP:         podIP.String(),
+					Snat:          snat,
+				})
+			}
+		}
+	}
+
+	return mappings, nil
+}
+
 // getAutoPoolIPNumberAndSelector calculates the auto-created IPPool IP number with the given params pod and pod top controller.
 // If it's an orphan pod, it will return 1.
 func getAutoPoolIPNumberAndSelector(pod *corev1.Pod, podController types.PodTopController) (int, *metav1.LabelSelector, error) {
-	var appReplicas int
-	var podSelector *metav1.LabelSelector
-	var isThirdPartyController bool
-
-	switch podController.Kind {
 	// orphan pod
-	case constant.KindPod:
+	if podController.Kind == constant.KindPod {
 		return 1, &metav1.LabelSelector{MatchLabels: pod.Labels}, nil
-	case constant.KindDeployment:
-		deployment := podController.APP.(*appsv1.Deployment)
-		appReplicas = subnetmanagercontrollers.GetAppReplicas(deployment.Spec.Replicas)
-		podSelector = deployment.Spec.Selector
-	case constant.KindReplicaSet:
-		replicaSet := podController.APP.(*appsv1.ReplicaSet)
-		podSelector = replicaSet.Spec.Selector
-		appReplicas = subnetmanagercontrollers.GetAppReplicas(replicaSet.Spec.Replicas)
-	case constant.KindStatefulSet:
-		statefulSet := podController.APP.(*appsv1.StatefulSet)
-		appReplicas = subnetmanagercontrollers.GetAppReplicas(statefulSet.Spec.Replicas)
-		podSelector = statefulSet.Spec.Selector
-	case constant.KindDaemonSet:
-		daemonSet := podController.APP.(*appsv1.DaemonSet)
-		appReplicas = int(daemonSet.Status.DesiredNumberScheduled)
-		podSelector = daemonSet.Spec.Selector
-	case constant.KindJob:
-		job := podController.APP.(*batchv1.Job)
-		appReplicas = subnetmanagercontrollers.CalculateJobPodNum(job.Spec.Parallelism, job.Spec.Completions)
-		podSelector = job.Spec.Selector
-	case constant.KindCronJob:
-		cronJob := podController.APP.(*batchv1.CronJob)
-		appReplicas = subnetmanagercontrollers.CalculateJobPodNum(cronJob.Spec.JobTemplate.Spec.Parallelism, cronJob.Spec.JobTemplate.Spec.Completions)
-		podSelector = cronJob.Spec.JobTemplate.Spec.Selector
-	default:
-		isThirdPartyController = true
 	}
 
+	// the replicas of the third party controller are known only if its
+	// scale subresource is got
+	appReplicas, replicasKnown := podController.Replicas()
+	podSelector := podController.Selector()
+
 	var flexibleIPNum int
 	poolIPNumStr, ok := pod.Annotations[constant.AnnoSpiderSubnetPoolIPNumber]
 	if ok {
@@ -247,8 +291,8 @@ func getAutoPoolIPNumberAndSelector(pod *corev1.Pod, podController types.PodTopC
 		// flexible IP Number
 		flexibleIPNum = ipNum
 	} else {
-		// third party controller only supports fixed auto-created IPPool IP number
-		if isThirdPartyController {
+		// third party controller without the scale subresource only supports fixed auto-created IPPool IP number
+		if !replicasKnown {
 			return -1, nil, fmt.Errorf("%s/%s/%s only supports fixed auto-created IPPool IP Number", podController.Kind, podController.Namespace, podController.Name)
 		}
 
@@ -261,3 +305,53 @@ func getAutoPoolIPNumberAndSelector(pod *corev1.Pod, podController types.PodTopC
 
 	return poolIPNum, podSelector, nil
 }
+
+// genAssignedAnnotation generates the value of the Pod annotation
+// "ipam.spidernet.io/assigned" with the IP addresses assigned to the Pod.
+func genAssignedAnnotation(ips []*models.IPConfig) (string, error) {
+	var value types.AnnoPodAssignedValue
+	items := map[string]int{}
+	for _, ip := range ips {
+		if ip.Nic == nil || ip.Address == nil || ip.Version == nil {
+			continue
+		}
+
+		index, ok := items[*ip.Nic]
+		if !ok {
+			index = len(value)
+			items[*ip.Nic] = index
+			value = append(value, types.AnnoAssignedItem{NIC: *ip.Nic})
+		}
+
+		if *ip.Version == constant.IPv4 {
+			value[index].IPv4 = *ip.Address
+			value[index].IPv4IPPool = ip.IPPool
+		} else {
+			value[index].IPv6 = *ip.Address
+			value[index].IPv6IPPool = ip.IPPool
+		}
+		if ip.Vlan != 0 {
+			value[index].Vlan = ip.Vlan
+		}
+	}
+	sort.Slice(value, func(i, j int) bool {
+		return value[i].NIC < value[j].NIC
+	})
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// appAnnotations returns the annotations of the application object of the
+// Pod, nil for the third party controller.
+func appAnnotations(podController types.PodTopController) map[string]string {
+	if podController.APP == nil {
+		return nil
+	}
+
+	return podController.APP.GetAnnotations()
+}
@@ -0,0 +1,44 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipconflictmonitor
+
+import (
+	"time"
+)
+
+const (
+	defaultResyncPeriod   = 30 * time.Second
+	defaultReportInterval = 5 * time.Minute
+)
+
+type IPConflictMonitorConfig struct {
+	// Interfaces are the underlay interfaces of the node on which ARP and
+	// NDP Neighbor Advertisement packets are listened to.
+	Interfaces []string
+
+	// NodeName is the name of the node where spiderpool-agent runs. Only the
+	// IP addresses allocated to the Pods on this node are monitored, so that
+	// each conflict is reported once in the cluster.
+	NodeName string
+
+	// ResyncPeriod is the interval of syncing the allocated IP addresses
+	// from IPPools.
+	ResyncPeriod time.Duration
+
+	// ReportInterval is the minimum interval of reporting the same conflict
+	// repeatedly.
+	ReportInterval time.Duration
+}
+
+func setDefaultsForIPConflictMonitorConfig(config IPConflictMonitorConfig) IPConflictMonitorConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	if config.ReportInterval <= 0 {
+		config.ReportInterval = defaultReportInterval
+	}
+
+	return config
+}
@@ -0,0 +1,280 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipconflictmonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/utils/pager"
+)
+
+// IPConflictMonitor passively listens to the ARP packets and NDP Neighbor
+// Advertisements on the underlay interfaces of the node. Once a MAC address
+// other than the one of the Pod claims an IP address allocated to the Pod, it
+// reports the conflict with a Warning event of the Pod and a metric.
+//
+// The MAC address of the Pod is taken from the network status annotation of
+// the Pod, the IP addresses of the Pods without it are not monitored.
+type IPConflictMonitor interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+	Observe(ctx context.Context, iface string, frame []byte)
+}
+
+type ipOwner struct {
+	containerID string
+	mac         string
+	pod         *corev1.Pod
+	// reported records the last time each conflicting MAC address is reported.
+	reported map[string]time.Time
+}
+
+type ipConflictMonitor struct {
+	config IPConflictMonitorConfig
+	client client.Client
+
+	lock   lock.Mutex
+	owners map[string]*ipOwner
+}
+
+func NewIPConflictMonitor(config IPConflictMonitorConfig, client client.Client) (IPConflictMonitor, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if config.NodeName == "" {
+		return nil, fmt.Errorf("node name %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &ipConflictMonitor{
+		config: setDefaultsForIPConflictMonitorConfig(config),
+		client: client,
+		owners: map[string]*ipOwner{},
+	}, nil
+}
+
+// Start syncs the allocated IP addresses periodically, and listens on each
+// interface until the context is done. The listener is restarted after a
+// resync period if it fails, for example the interface does not exist yet.
+func (m *ipConflictMonitor) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			err := recovery.Call(ctx, "IP-Conflict-Monitor", nil, func() error { return m.Reconcile(ctx) })
+			if err != nil {
+				logger.Sugar().Errorf("Failed to sync allocated IP addresses: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	for _, iface := range m.config.Interfaces {
+		go func(iface string) {
+			for {
+				logger.Sugar().Infof("Begin to monitor IP conflicts on interface %s", iface)
+				err := listen(ctx, iface, func(frame []byte) {
+					m.Observe(ctx, iface, frame)
+				})
+				if err != nil {
+					logger.Sugar().Errorf("Failed to monitor IP conflicts on interface %s: %v", iface, err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(m.config.ResyncPeriod):
+				}
+			}
+		}(iface)
+	}
+}
+
+// Reconcile syncs the IP addresses allocated to the Pods on the node from
+// IPPools, and the MAC addresses of the Pods claiming them.
+func (m *ipConflictMonitor) Reconcile(ctx context.Context) error {
+	allocations := map[string]spiderpoolv1.PoolIPAllocation{}
+	err := pager.ListPages(
+		ctx,
+		m.client,
+		pager.Config{},
+		func() client.ObjectList { return &spiderpoolv1.SpiderIPPoolList{} },
+		func(list client.ObjectList) error {
+			for _, pool := range list.(*spiderpoolv1.SpiderIPPoolList).Items {
+				pool := pool
+				if err := ippoolmanager.AssembleIPPoolShards(ctx, m.client, nil, &pool); err != nil {
+					return err
+				}
+				for ip, a := range pool.Status.AllocatedIPs {
+					if a.Node != m.config.NodeName {
+						continue
+					}
+					parsed := net.ParseIP(ip)
+					if parsed == nil {
+						continue
+					}
+					allocations[parsed.String()] = a
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list IPPools: %w", err)
+	}
+
+	m.lock.Lock()
+	current := m.owners
+	m.lock.Unlock()
+
+	logger := logutils.FromContext(ctx)
+	owners := map[string]*ipOwner{}
+	for ip, a := range allocations {
+		if owner, ok := current[ip]; ok && owner.containerID == a.ContainerID {
+			owners[ip] = owner
+			continue
+		}
+
+		owner, err := m.getIPOwner(ctx, ip, a)
+		if err != nil {
+			logger.Sugar().Warnf("Failed to get the MAC address of Pod %s/%s claiming IP %s: %v", a.Namespace, a.Pod, ip, err)
+			continue
+		}
+		if owner != nil {
+			owners[ip] = owner
+		}
+	}
+
+	m.lock.Lock()
+	m.owners = owners
+	m.lock.Unlock()
+
+	return nil
+}
+
+// getIPOwner gets the Pod the IP address is allocated to, and takes the MAC
+// address of its interface with the IP address from its network status
+// annotation. It returns nil if the MAC address is not reported yet.
+func (m *ipConflictMonitor) getIPOwner(ctx context.Context, ip string, a spiderpoolv1.PoolIPAllocation) (*ipOwner, error) {
+	var pod corev1.Pod
+	if err := m.client.Get(ctx, apitypes.NamespacedName{Namespace: a.Namespace, Name: a.Pod}, &pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	anno, ok := pod.Annotations[netv1.NetworkStatusAnnot]
+	if !ok {
+		return nil, nil
+	}
+
+	var statuses []netv1.NetworkStatus
+	if err := json.Unmarshal([]byte(anno), &statuses); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", netv1.NetworkStatusAnnot, err)
+	}
+
+	for _, status := range statuses {
+		if status.Mac == "" {
+			continue
+		}
+		for _, statusIP := range status.IPs {
+			// Tolerate the IP addresses in CIDR notation.
+			if i := strings.Index(statusIP, "/"); i >= 0 {
+				statusIP = statusIP[:i]
+			}
+			if !net.ParseIP(ip).Equal(net.ParseIP(statusIP)) {
+				continue
+			}
+
+			mac, err := net.ParseMAC(status.Mac)
+			if err != nil {
+				return nil, fmt.Errorf("invalid MAC address %s of interface %s: %w", status.Mac, status.Interface, err)
+			}
+
+			return &ipOwner{
+				containerID: a.ContainerID,
+				mac:         mac.String(),
+				pod: &corev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       constant.KindPod,
+						APIVersion: corev1.SchemeGroupVersion.String(),
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: pod.Namespace,
+						Name:      pod.Name,
+						UID:       pod.UID,
+					},
+				},
+				reported: map[string]time.Time{},
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Observe checks whether the Ethernet frame received on the interface claims
+// an IP address allocated to a Pod on the node with another MAC address.
+func (m *ipConflictMonitor) Observe(ctx context.Context, iface string, frame []byte) {
+	claim, ok := parseFrame(frame)
+	if !ok {
+		return
+	}
+
+	ip := claim.IP.String()
+	mac := claim.MAC.String()
+
+	m.lock.Lock()
+	owner, ok := m.owners[ip]
+	if !ok || owner.mac == mac {
+		m.lock.Unlock()
+		return
+	}
+
+	if last, ok := owner.reported[mac]; ok && time.Since(last) < m.config.ReportInterval {
+		m.lock.Unlock()
+		return
+	}
+	owner.reported[mac] = time.Now()
+	m.lock.Unlock()
+
+	logger := logutils.FromContext(ctx)
+	logger.Sugar().Warnf("IP %s of Pod %s/%s is claimed by MAC %s on interface %s, the MAC of the Pod is %s",
+		ip, owner.pod.Namespace, owner.pod.Name, mac, iface, owner.mac)
+
+	metric.IPConflictCounts.Add(ctx, 1, attribute.String("interface", iface))
+
+	event.EventRecorder.Eventf(owner.pod, corev1.EventTypeWarning, constant.EventReasonIPConflict,
+		"IP %s is claimed by MAC %s on interface %s of node %s, but the MAC of the Pod is %s",
+		ip, mac, iface, m.config.NodeName, owner.mac)
+}
@@ -0,0 +1,248 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipconflictmonitor_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/ipconflictmonitor"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+const (
+	nodeName = "node1"
+	podMAC   = "02:00:00:00:00:01"
+	rogueMAC = "02:00:00:00:00:99"
+)
+
+// objectRecorder records the objects of the events besides the messages.
+type objectRecorder struct {
+	*record.FakeRecorder
+	objects []metav1.Object
+}
+
+func (r *objectRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if o, ok := object.(metav1.Object); ok {
+		r.objects = append(r.objects, o)
+	}
+	r.FakeRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func networkStatus(mac string, ips ...string) string {
+	statuses := []netv1.NetworkStatus{{Name: "macvlan", Interface: "eth0", IPs: ips, Mac: mac}}
+	data, _ := json.Marshal(statuses)
+
+	return string(data)
+}
+
+func arpFrame(op uint16, mac, ip string) []byte {
+	hw, _ := net.ParseMAC(mac)
+	frame := make([]byte, 42)
+	copy(frame[0:6], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	copy(frame[6:12], hw)
+	binary.BigEndian.PutUint16(frame[12:14], 0x0806)
+	binary.BigEndian.PutUint16(frame[14:16], 1)
+	binary.BigEndian.PutUint16(frame[16:18], 0x0800)
+	frame[18] = 6
+	frame[19] = 4
+	binary.BigEndian.PutUint16(frame[20:22], op)
+	copy(frame[22:28], hw)
+	copy(frame[28:32], net.ParseIP(ip).To4())
+
+	return frame
+}
+
+func neighborAdvertFrame(srcMAC, targetMAC, ip string) []byte {
+	src, _ := net.ParseMAC(srcMAC)
+	frame := make([]byte, 14+40+24)
+	copy(frame[0:6], []byte{0x33, 0x33, 0x00, 0x00, 0x00, 0x01})
+	copy(frame[6:12], src)
+	binary.BigEndian.PutUint16(frame[12:14], 0x86dd)
+	frame[14] = 0x60
+	frame[20] = 58
+	frame[21] = 255
+	frame[54] = 136
+	copy(frame[62:78], net.ParseIP(ip).To16())
+	if targetMAC != "" {
+		target, _ := net.ParseMAC(targetMAC)
+		frame = append(frame, 2, 1)
+		frame = append(frame, target...)
+	}
+
+	return frame
+}
+
+var _ = Describe("IPConflictMonitor", Label("ip_conflict_monitor_test"), func() {
+	Describe("New IPConflictMonitor", func() {
+		It("inputs nil client", func() {
+			monitor, err := ipconflictmonitor.NewIPConflictMonitor(ipconflictmonitor.IPConflictMonitorConfig{NodeName: nodeName}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(monitor).To(BeNil())
+		})
+
+		It("inputs empty node name", func() {
+			monitor, err := ipconflictmonitor.NewIPConflictMonitor(ipconflictmonitor.IPConflictMonitorConfig{}, fakeClient)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(monitor).To(BeNil())
+		})
+	})
+
+	Describe("Observe", func() {
+		var ctx context.Context
+		var recorder *objectRecorder
+		var pod *corev1.Pod
+		var pool *spiderpoolv1.SpiderIPPool
+		var monitor ipconflictmonitor.IPConflictMonitor
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			recorder = &objectRecorder{FakeRecorder: record.NewFakeRecorder(10)}
+			event.EventRecorder = recorder
+
+			pod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "pod1",
+					UID:       "uid1",
+					Annotations: map[string]string{
+						netv1.NetworkStatusAnnot: networkStatus(podMAC, "172.18.40.10/24", "2001:db8::10"),
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pod)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(fakeClient.Delete(ctx, pod)).To(Succeed())
+			})
+
+			pool = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Status: spiderpoolv1.IPPoolStatus{
+					AllocatedIPs: spiderpoolv1.PoolIPAllocations{
+						"172.18.40.10": {ContainerID: "c1", NIC: "eth0", Node: nodeName, Namespace: "default", Pod: "pod1"},
+						"172.18.40.11": {ContainerID: "c2", NIC: "eth0", Node: "node2", Namespace: "default", Pod: "pod2"},
+						"172.18.40.13": {ContainerID: "c4", NIC: "eth0", Node: nodeName, Namespace: "default", Pod: "pod4"},
+						"2001:db8::10": {ContainerID: "c1", NIC: "eth0", Node: nodeName, Namespace: "default", Pod: "pod1"},
+					},
+				},
+			}
+			Expect(fakeClient.Create(ctx, pool)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(fakeClient.Delete(ctx, pool)).To(Succeed())
+			})
+
+			var err error
+			monitor, err = ipconflictmonitor.NewIPConflictMonitor(ipconflictmonitor.IPConflictMonitorConfig{NodeName: nodeName}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(monitor.Reconcile(ctx)).To(Succeed())
+		})
+
+		It("reports an IPv4 conflict once within the report interval", func() {
+			monitor.Observe(ctx, "eth1", arpFrame(1, podMAC, "172.18.40.10"))
+			monitor.Observe(ctx, "eth1", arpFrame(2, podMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(BeEmpty())
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(And(
+				ContainSubstring(constant.EventReasonIPConflict),
+				ContainSubstring(rogueMAC),
+				ContainSubstring(podMAC),
+			))
+			Expect(recorder.objects).To(HaveLen(1))
+			Expect(recorder.objects[0].GetUID()).To(Equal(pod.UID))
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("reports the conflict claimed before the Pod", func() {
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(ContainSubstring(podMAC))
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, podMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("reports an IPv6 conflict", func() {
+			monitor.Observe(ctx, "eth1", neighborAdvertFrame(podMAC, "", "2001:db8::10"))
+			monitor.Observe(ctx, "eth1", neighborAdvertFrame(podMAC, rogueMAC, "2001:db8::10"))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(ContainSubstring(rogueMAC))
+		})
+
+		It("ignores the IP addresses not allocated to the Pods on the node", func() {
+			monitor.Observe(ctx, "eth1", arpFrame(2, podMAC, "172.18.40.11"))
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.11"))
+			monitor.Observe(ctx, "eth1", arpFrame(2, podMAC, "172.18.40.12"))
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.12"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("ignores the IP addresses of the Pods not found", func() {
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.13"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("ignores the IP addresses of the Pods without network status", func() {
+			pod.Annotations = nil
+			Expect(fakeClient.Update(ctx, pod)).To(Succeed())
+
+			allocation := pool.Status.AllocatedIPs["172.18.40.10"]
+			allocation.ContainerID = "c3"
+			pool.Status.AllocatedIPs["172.18.40.10"] = allocation
+			Expect(fakeClient.Update(ctx, pool)).To(Succeed())
+			Expect(monitor.Reconcile(ctx)).To(Succeed())
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("ignores ARP probes and other frames", func() {
+			monitor.Observe(ctx, "eth1", arpFrame(2, podMAC, "172.18.40.10"))
+			monitor.Observe(ctx, "eth1", arpFrame(1, rogueMAC, "0.0.0.0"))
+			monitor.Observe(ctx, "eth1", []byte{0x01, 0x02})
+
+			frame := arpFrame(2, rogueMAC, "172.18.40.10")
+			binary.BigEndian.PutUint16(frame[12:14], 0x0800)
+			monitor.Observe(ctx, "eth1", frame)
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("takes the MAC address again once the IP address is reallocated", func() {
+			pod.Annotations[netv1.NetworkStatusAnnot] = networkStatus(rogueMAC, "172.18.40.10")
+			Expect(fakeClient.Update(ctx, pod)).To(Succeed())
+			Expect(monitor.Reconcile(ctx)).To(Succeed())
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(HaveLen(1))
+			<-recorder.Events
+
+			allocation := pool.Status.AllocatedIPs["172.18.40.10"]
+			allocation.ContainerID = "c3"
+			pool.Status.AllocatedIPs["172.18.40.10"] = allocation
+			Expect(fakeClient.Update(ctx, pool)).To(Succeed())
+			Expect(monitor.Reconcile(ctx)).To(Succeed())
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, rogueMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(BeEmpty())
+
+			monitor.Observe(ctx, "eth1", arpFrame(2, podMAC, "172.18.40.10"))
+			Expect(recorder.Events).To(HaveLen(1))
+		})
+	})
+})
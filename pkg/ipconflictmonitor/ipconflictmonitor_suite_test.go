@@ -0,0 +1,44 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipconflictmonitor_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+var scheme *runtime.Scheme
+var fakeClient client.Client
+
+func TestIPConflictMonitor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "IPConflictMonitor Suite", Label("ipconflictmonitor", "unitest"))
+}
+
+var _ = BeforeSuite(func() {
+	scheme = runtime.NewScheme()
+	err := corev1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+	err = spiderpoolv1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	fakeClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	_, err = metric.InitMetricController(context.TODO(), "spiderpool-agent-test", false)
+	Expect(err).NotTo(HaveOccurred())
+	err = metric.InitSpiderpoolAgentMetrics(context.TODO())
+	Expect(err).NotTo(HaveOccurred())
+})
@@ -0,0 +1,120 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ipconflictmonitor
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+const (
+	etherTypeARP  = 0x0806
+	etherTypeIPv6 = 0x86dd
+
+	ethernetHeaderLen = 14
+	arpPacketLen      = 28
+	ipv6HeaderLen     = 40
+	// ICMPv6 type, code, checksum, flags and target address
+	neighborAdvertLen = 24
+
+	ipProtocolICMPv6       = 58
+	icmpv6NeighborAdvert   = 136
+	ndpOptTargetLinkLayer  = 2
+	arpHardwareTypeEther   = 1
+	arpOperationRequest    = 1
+	arpOperationReply      = 2
+	hardwareAddrLen        = 6
+	ipv4AddrLen            = 4
+	ipv6AddrLen            = 16
+	ipv6NextHeaderOffset   = ethernetHeaderLen + 6
+	icmpv6TypeOffset       = ethernetHeaderLen + ipv6HeaderLen
+	neighborAdvertTargetAt = icmpv6TypeOffset + 8
+)
+
+// ipClaim means that the host with the MAC address claims the IP address,
+// which is announced by an ARP packet or an NDP Neighbor Advertisement.
+type ipClaim struct {
+	IP  net.IP
+	MAC net.HardwareAddr
+}
+
+// parseFrame parses the IP address claimed in the Ethernet frame. It returns
+// false if the frame is neither an ARP packet nor an NDP Neighbor
+// Advertisement, or it does not claim any IP address.
+func parseFrame(frame []byte) (ipClaim, bool) {
+	if len(frame) < ethernetHeaderLen {
+		return ipClaim{}, false
+	}
+
+	switch binary.BigEndian.Uint16(frame[12:14]) {
+	case etherTypeARP:
+		return parseARP(frame[ethernetHeaderLen:])
+	case etherTypeIPv6:
+		return parseNeighborAdvert(frame)
+	default:
+		return ipClaim{}, false
+	}
+}
+
+// parseARP parses the sender addresses of an ARP request or reply. ARP
+// probes, whose sender IP address is unspecified, claim nothing.
+func parseARP(packet []byte) (ipClaim, bool) {
+	if len(packet) < arpPacketLen {
+		return ipClaim{}, false
+	}
+
+	if binary.BigEndian.Uint16(packet[0:2]) != arpHardwareTypeEther ||
+		binary.BigEndian.Uint16(packet[2:4]) != 0x0800 ||
+		packet[4] != hardwareAddrLen || packet[5] != ipv4AddrLen {
+		return ipClaim{}, false
+	}
+
+	op := binary.BigEndian.Uint16(packet[6:8])
+	if op != arpOperationRequest && op != arpOperationReply {
+		return ipClaim{}, false
+	}
+
+	ip := net.IP(append([]byte{}, packet[14:18]...))
+	if ip.IsUnspecified() {
+		return ipClaim{}, false
+	}
+
+	return ipClaim{
+		IP:  ip,
+		MAC: net.HardwareAddr(append([]byte{}, packet[8:14]...)),
+	}, true
+}
+
+// parseNeighborAdvert parses the target address of an NDP Neighbor
+// Advertisement. The MAC address is taken from the target link-layer address
+// option, or the source address of the Ethernet frame if the option is absent.
+func parseNeighborAdvert(frame []byte) (ipClaim, bool) {
+	if len(frame) < icmpv6TypeOffset+neighborAdvertLen {
+		return ipClaim{}, false
+	}
+
+	if frame[ipv6NextHeaderOffset] != ipProtocolICMPv6 || frame[icmpv6TypeOffset] != icmpv6NeighborAdvert {
+		return ipClaim{}, false
+	}
+
+	claim := ipClaim{
+		IP:  net.IP(append([]byte{}, frame[neighborAdvertTargetAt:neighborAdvertTargetAt+ipv6AddrLen]...)),
+		MAC: net.HardwareAddr(append([]byte{}, frame[6:12]...)),
+	}
+
+	options := frame[icmpv6TypeOffset+neighborAdvertLen:]
+	for len(options) >= 2 {
+		optLen := int(options[1]) * 8
+		if optLen == 0 || optLen > len(options) {
+			break
+		}
+		if options[0] == ndpOptTargetLinkLayer && optLen >= 2+hardwareAddrLen {
+			claim.MAC = net.HardwareAddr(append([]byte{}, options[2:2+hardwareAddrLen]...))
+			break
+		}
+		options = options[optLen:]
+	}
+
+	return claim, true
+}
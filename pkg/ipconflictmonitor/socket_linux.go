@@ -0,0 +1,91 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package ipconflictmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	readTimeout = time.Second
+	maxFrameLen = 1518
+)
+
+// claimFilter is a classic BPF program that only accepts ARP packets and
+// ICMPv6 Neighbor Advertisements without IPv6 extension headers.
+var claimFilter = []unix.SockFilter{
+	{Code: unix.BPF_LD | unix.BPF_H | unix.BPF_ABS, K: 12},
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 5, Jf: 0, K: etherTypeARP},
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 0, Jf: 5, K: etherTypeIPv6},
+	{Code: unix.BPF_LD | unix.BPF_B | unix.BPF_ABS, K: ipv6NextHeaderOffset},
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 0, Jf: 3, K: ipProtocolICMPv6},
+	{Code: unix.BPF_LD | unix.BPF_B | unix.BPF_ABS, K: icmpv6TypeOffset},
+	{Code: unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, Jt: 0, Jf: 1, K: icmpv6NeighborAdvert},
+	{Code: unix.BPF_RET | unix.BPF_K, K: maxFrameLen},
+	{Code: unix.BPF_RET | unix.BPF_K, K: 0},
+}
+
+// listen receives the ARP packets and NDP Neighbor Advertisements on the
+// interface with a packet socket, and passes them to the handler until the
+// context is done.
+func listen(ctx context.Context, iface string, handler func(frame []byte)) error {
+	link, err := net.InterfaceByName(iface)
+	if err != nil {
+		return fmt.Errorf("failed to get interface %s: %w", iface, err)
+	}
+
+	protocol := htons(unix.ETH_P_ALL)
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(protocol))
+	if err != nil {
+		return fmt.Errorf("failed to create packet socket: %w", err)
+	}
+	defer unix.Close(fd)
+
+	prog := unix.SockFprog{
+		Len:    uint16(len(claimFilter)),
+		Filter: &claimFilter[0],
+	}
+	if err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &prog); err != nil {
+		return fmt.Errorf("failed to attach BPF filter: %w", err)
+	}
+
+	tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return fmt.Errorf("failed to set read timeout: %w", err)
+	}
+
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: protocol, Ifindex: link.Index}); err != nil {
+		return fmt.Errorf("failed to bind packet socket to interface %s: %w", iface, err)
+	}
+
+	buf := make([]byte, maxFrameLen)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return fmt.Errorf("failed to receive packets on interface %s: %w", iface, err)
+		}
+		handler(buf[:n])
+	}
+}
+
+func htons(i uint16) uint16 {
+	return (i<<8)&0xff00 | i>>8
+}
@@ -0,0 +1,15 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package ipconflictmonitor
+
+import (
+	"context"
+	"errors"
+)
+
+func listen(ctx context.Context, iface string, handler func(frame []byte)) error {
+	return errors.New("IP conflict monitor is only supported on linux")
+}
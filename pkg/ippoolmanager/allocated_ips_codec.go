@@ -0,0 +1,143 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// allocationFields is the count of fields in the tuple of an IP allocation.
+const allocationFields = 7
+
+// EncodeIPAllocations encodes the IP allocation details by IP range, which
+// is how SpiderIPPool v2beta1 stores them.
+func EncodeIPAllocations(allocations spiderpoolv1.PoolIPAllocations) (*spiderpoolv1.RangeEncodedIPAllocations, error) {
+	if allocations == nil {
+		return nil, nil
+	}
+
+	var v4IPs, v6IPs []net.IP
+	for ipStr := range allocations {
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return nil, fmt.Errorf("%w '%s' in allocated IPs", spiderpoolip.ErrInvalidIP, ipStr)
+		}
+		if ip.To4() != nil {
+			v4IPs = append(v4IPs, ip)
+		} else {
+			v6IPs = append(v6IPs, ip)
+		}
+	}
+
+	out := &spiderpoolv1.RangeEncodedIPAllocations{}
+	index := map[string]int{}
+	for _, e := range []struct {
+		version int64
+		ips     []net.IP
+	}{
+		{version: constant.IPv4, ips: v4IPs},
+		{version: constant.IPv6, ips: v6IPs},
+	} {
+		if len(e.ips) == 0 {
+			continue
+		}
+		ranges, err := spiderpoolip.ConvertIPsToIPRanges(e.version, e.ips)
+		if err != nil {
+			return nil, err
+		}
+		out.IPs = append(out.IPs, ranges...)
+
+		// The allocations follow the sorted IPs, in the same order as the
+		// merged IP ranges.
+		for _, ip := range spiderpoolip.NewIPSet(e.ips...).List(true) {
+			a := allocations[ip.String()]
+			fields := []string{a.ContainerID, a.NIC, a.Node, a.Namespace, a.Pod, a.OwnerControllerType, a.OwnerControllerName}
+			tuple := make([]int, 0, allocationFields)
+			for _, f := range fields {
+				i, ok := index[f]
+				if !ok {
+					i = len(out.Strings)
+					index[f] = i
+					out.Strings = append(out.Strings, f)
+				}
+				tuple = append(tuple, i)
+			}
+			out.Allocations = append(out.Allocations, tuple)
+		}
+	}
+
+	return out, nil
+}
+
+// DecodeIPAllocations decodes the range-encoded IP allocation details.
+// The sizes of the IP ranges are checked against the count of allocations
+// before the IP ranges are expanded.
+func DecodeIPAllocations(encoded *spiderpoolv1.RangeEncodedIPAllocations) (spiderpoolv1.PoolIPAllocations, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+
+	total := big.NewInt(0)
+	for _, ipRange := range encoded.IPs {
+		size, err := spiderpoolip.IPRangeSize(ipRangeVersion(ipRange), ipRange)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, size)
+	}
+	if total.Cmp(big.NewInt(int64(len(encoded.Allocations)))) != 0 {
+		return nil, fmt.Errorf("%w, %s IPs in IP ranges mismatch %d allocations", constant.ErrWrongInput, total, len(encoded.Allocations))
+	}
+
+	out := make(spiderpoolv1.PoolIPAllocations, len(encoded.Allocations))
+	next := 0
+	for _, ipRange := range encoded.IPs {
+		ips, err := spiderpoolip.ParseIPRange(ipRangeVersion(ipRange), ipRange)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			tuple := encoded.Allocations[next]
+			next++
+			if len(tuple) != allocationFields {
+				return nil, fmt.Errorf("%w, invalid allocation of IP '%s': %v", constant.ErrWrongInput, ip, tuple)
+			}
+
+			fields := make([]string, 0, allocationFields)
+			for _, i := range tuple {
+				if i < 0 || i >= len(encoded.Strings) {
+					return nil, fmt.Errorf("%w, invalid allocation of IP '%s': string index %d out of range", constant.ErrWrongInput, ip, i)
+				}
+				fields = append(fields, encoded.Strings[i])
+			}
+			out[ip.String()] = spiderpoolv1.PoolIPAllocation{
+				ContainerID:         fields[0],
+				NIC:                 fields[1],
+				Node:                fields[2],
+				Namespace:           fields[3],
+				Pod:                 fields[4],
+				OwnerControllerType: fields[5],
+				OwnerControllerName: fields[6],
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func ipRangeVersion(ipRange string) int64 {
+	if strings.Contains(ipRange, ":") {
+		return constant.IPv6
+	}
+
+	return constant.IPv4
+}
@@ -0,0 +1,224 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/crdmanager"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	spiderpoolv2beta1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v2beta1"
+)
+
+// genAllocatedIPs allocates the IPs of the IP range to the Pods of a
+// Deployment scheduled on 10 Nodes.
+func genAllocatedIPs(version int64, ipRange string) spiderpoolv1.PoolIPAllocations {
+	ips, err := spiderpoolip.ParseIPRange(version, ipRange)
+	Expect(err).NotTo(HaveOccurred())
+
+	allocatedIPs := spiderpoolv1.PoolIPAllocations{}
+	for i, ip := range ips {
+		allocatedIPs[ip.String()] = spiderpoolv1.PoolIPAllocation{
+			ContainerID:         fmt.Sprintf("%064x", i),
+			NIC:                 "eth0",
+			Node:                fmt.Sprintf("worker-%d", i%10),
+			Namespace:           "default",
+			Pod:                 fmt.Sprintf("demo-deploy-6b8b4c8c45-%05d", i),
+			OwnerControllerType: constant.KindDeployment,
+			OwnerControllerName: "demo-deploy",
+		}
+	}
+
+	return allocatedIPs
+}
+
+var _ = Describe("AllocatedIPs storage", Label("allocated_ips_storage_test"), func() {
+	It("converts SpiderIPPool between v1 and v2beta1", func() {
+		scheme := runtime.NewScheme()
+		Expect(spiderpoolv1.AddToScheme(scheme)).To(Succeed())
+		Expect(spiderpoolv2beta1.AddToScheme(scheme)).To(Succeed())
+
+		convertible, err := conversion.IsConvertible(scheme, &spiderpoolv1.SpiderIPPool{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(convertible).To(BeTrue())
+
+		allocatedIPs := genAllocatedIPs(constant.IPv4, "172.18.40.1-172.18.40.10")
+		for ip, allocation := range genAllocatedIPs(constant.IPv6, "abcd:1234::1-abcd:1234::a") {
+			allocatedIPs[ip] = allocation
+		}
+		delete(allocatedIPs, "172.18.40.5")
+
+		hub := &spiderpoolv1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+			Spec:       spiderpoolv1.IPPoolSpec{Subnet: "172.18.40.0/24"},
+			Status: spiderpoolv1.IPPoolStatus{
+				AllocatedIPs:     allocatedIPs,
+				AllocatedIPCount: pointer.Int64(int64(len(allocatedIPs))),
+			},
+		}
+
+		var pool spiderpoolv2beta1.SpiderIPPool
+		Expect(pool.ConvertFrom(hub)).To(Succeed())
+		Expect(pool.Name).To(Equal(hub.Name))
+		Expect(pool.Spec).To(Equal(hub.Spec))
+		Expect(pool.Status.AllocatedIPCount).To(Equal(hub.Status.AllocatedIPCount))
+		Expect(pool.Status.AllocatedIPs.IPs).To(Equal([]string{
+			"172.18.40.1-172.18.40.4",
+			"172.18.40.6-172.18.40.10",
+			"abcd:1234::1-abcd:1234::a",
+		}))
+
+		var converted spiderpoolv1.SpiderIPPool
+		Expect(pool.ConvertTo(&converted)).To(Succeed())
+		Expect(converted).To(Equal(*hub))
+	})
+
+	It("keeps the IPPool without allocated IPs as before", func() {
+		encoded, err := ippoolmanager.EncodeIPAllocations(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encoded).To(BeNil())
+
+		decoded, err := ippoolmanager.DecodeIPAllocations(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(BeNil())
+
+		data, err := json.Marshal(spiderpoolv2beta1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("allocatedIPs"))
+	})
+
+	It("stores the IPPool with v2beta1 within the request size limit of etcd", func() {
+		crds, err := crdmanager.CRDs()
+		Expect(err).NotTo(HaveOccurred())
+
+		var storageVersion string
+		for _, crd := range crds {
+			if crd.GetName() != "spiderippools."+constant.SpiderpoolAPIGroup {
+				continue
+			}
+			versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+			for _, v := range versions {
+				if version := v.(map[string]interface{}); version["storage"] == true {
+					storageVersion = version["name"].(string)
+				}
+			}
+		}
+		Expect(storageVersion).To(Equal(spiderpoolv2beta1.GroupVersion.Version))
+
+		// A fully allocated IPPool of /19.
+		allocatedIPs := genAllocatedIPs(constant.IPv4, "172.18.0.1-172.18.31.254")
+		hub := &spiderpoolv1.SpiderIPPool{
+			TypeMeta:   metav1.TypeMeta{APIVersion: spiderpoolv1.GroupVersion.String(), Kind: constant.SpiderIPPoolKind},
+			ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+			Spec:       spiderpoolv1.IPPoolSpec{Subnet: "172.18.0.0/19", IPs: []string{"172.18.0.1-172.18.31.254"}},
+			Status: spiderpoolv1.IPPoolStatus{
+				AllocatedIPs:     allocatedIPs,
+				AllocatedIPCount: pointer.Int64(int64(len(allocatedIPs))),
+			},
+		}
+
+		// The API server converts the object to the storage version and
+		// stores it as JSON.
+		stored := &spiderpoolv2beta1.SpiderIPPool{}
+		Expect(stored.ConvertFrom(hub)).To(Succeed())
+		stored.TypeMeta = metav1.TypeMeta{APIVersion: spiderpoolv2beta1.GroupVersion.String(), Kind: constant.SpiderIPPoolKind}
+		storedData, err := json.Marshal(stored)
+		Expect(err).NotTo(HaveOccurred())
+		hubData, err := json.Marshal(hub)
+		Expect(err).NotTo(HaveOccurred())
+
+		const etcdMaxRequestBytes = 1572864
+		Expect(len(hubData)).To(BeNumerically(">", etcdMaxRequestBytes))
+		Expect(len(storedData)).To(BeNumerically("<", etcdMaxRequestBytes*3/4))
+	})
+
+	It("fails to encode the invalid IP", func() {
+		_, err := ippoolmanager.EncodeIPAllocations(spiderpoolv1.PoolIPAllocations{"invalid": {}})
+		Expect(err).To(MatchError(spiderpoolip.ErrInvalidIP))
+	})
+
+	DescribeTable("fails to decode the corrupted allocated IPs",
+		func(encoded spiderpoolv1.RangeEncodedIPAllocations) {
+			_, err := ippoolmanager.DecodeIPAllocations(&encoded)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("more IPs than allocations", spiderpoolv1.RangeEncodedIPAllocations{
+			IPs:         []string{"172.18.40.1-172.18.40.2"},
+			Strings:     []string{"a"},
+			Allocations: [][]int{{0, 0, 0, 0, 0, 0, 0}},
+		}),
+		Entry("more allocations than IPs", spiderpoolv1.RangeEncodedIPAllocations{
+			IPs:         []string{"172.18.40.1"},
+			Strings:     []string{"a"},
+			Allocations: [][]int{{0, 0, 0, 0, 0, 0, 0}, {0, 0, 0, 0, 0, 0, 0}},
+		}),
+		Entry("short tuple", spiderpoolv1.RangeEncodedIPAllocations{
+			IPs:         []string{"172.18.40.1"},
+			Strings:     []string{"a"},
+			Allocations: [][]int{{0, 0}},
+		}),
+		Entry("string index out of range", spiderpoolv1.RangeEncodedIPAllocations{
+			IPs:         []string{"172.18.40.1"},
+			Strings:     []string{"a"},
+			Allocations: [][]int{{0, 0, 0, 0, 0, 0, 1}},
+		}),
+		Entry("invalid IP range", spiderpoolv1.RangeEncodedIPAllocations{
+			IPs:         []string{"172.18.40.x"},
+			Strings:     []string{"a"},
+			Allocations: [][]int{{0, 0, 0, 0, 0, 0, 0}},
+		}),
+	)
+
+	It("rejects the huge IP range before expanding it", func() {
+		encoded := spiderpoolv1.RangeEncodedIPAllocations{
+			IPs:         []string{"abcd::1-abcd::ffff:ffff:ffff:ffff"},
+			Strings:     []string{"a"},
+			Allocations: [][]int{{0, 0, 0, 0, 0, 0, 0}},
+		}
+
+		_, err := ippoolmanager.DecodeIPAllocations(&encoded)
+		Expect(err).To(MatchError(constant.ErrWrongInput))
+	})
+
+	// Size regression: the range-encoded allocated IPs of a large IPPool
+	// must stay much smaller than the verbose map.
+	DescribeTable("keeps the range-encoded allocated IPs of large IPPools small",
+		func(version int64, ipRange string, maxBytesPerIP int) {
+			allocatedIPs := genAllocatedIPs(version, ipRange)
+
+			rangeEncoded, err := ippoolmanager.EncodeIPAllocations(allocatedIPs)
+			Expect(err).NotTo(HaveOccurred())
+			encoded, err := json.Marshal(rangeEncoded)
+			Expect(err).NotTo(HaveOccurred())
+			verbose, err := json.Marshal(allocatedIPs)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(len(encoded)).To(BeNumerically("<", len(verbose)*6/10))
+			Expect(len(encoded)).To(BeNumerically("<=", len(allocatedIPs)*maxBytesPerIP))
+		},
+		Entry("IPv4", constant.IPv4, "172.18.0.1-172.18.15.254", 128),
+		Entry("IPv6", constant.IPv6, "abcd:1234::1-abcd:1234::fff", 128),
+	)
+
+	It("encodes the IPs without allocations as empty tuples of strings", func() {
+		encoded, err := ippoolmanager.EncodeIPAllocations(spiderpoolv1.PoolIPAllocations{"172.18.40.1": {}})
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := json.Marshal(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"ips":["172.18.40.1"],"strings":[""],"allocations":[[0,0,0,0,0,0,0]]}`))
+	})
+})
@@ -0,0 +1,155 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// The images of Spiderpool may have no time zone database.
+	_ "time/tzdata"
+
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// cronField is the bounds of a field of cron expressions.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// cronSchedule is a parsed cron expression, each field is the bitset of the
+// values matched.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar follow cron to match either of the day of month
+	// and the day of week if both are restricted.
+	domStar, dowStar bool
+}
+
+// parseCronSchedule parses the cron expression 'minute hour day-of-month
+// month day-of-week', each field is a comma-separated list of '*', 'a',
+// 'a-b', with an optional step '/n'. Sunday is either 0 or 7.
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expect %d fields 'minute hour day-of-month month day-of-week', but got %d", len(cronFields), len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, f := range fields {
+		b, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	// Sunday is 0.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	return &cronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rng, step := item, 1
+		if r, s, found := strings.Cut(item, "/"); found {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step '%s' of %s", s, field.name)
+			}
+			rng, step = r, n
+		}
+
+		start, end := field.min, field.max
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = strconv.Atoi(first); err != nil {
+				return 0, fmt.Errorf("invalid %s '%s'", field.name, item)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(last); err != nil {
+					return 0, fmt.Errorf("invalid %s '%s'", field.name, item)
+				}
+			}
+		}
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("%s '%s' out of range [%d, %d]", field.name, item, field.min, field.max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func (s *cronSchedule) matches(t time.Time) bool {
+	if s.minute&(1<<t.Minute()) == 0 || s.hour&(1<<t.Hour()) == 0 || s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	domMatched := s.dom&(1<<t.Day()) != 0
+	dowMatched := s.dow&(1<<int(t.Weekday())) != 0
+	if !s.domStar && !s.dowStar {
+		return domMatched || dowMatched
+	}
+
+	return domMatched && dowMatched
+}
+
+// InAllocationWindow returns whether the time is in the allocation window.
+// A nil allocation window is always open.
+func InAllocationWindow(window *spiderpoolv1.AllocationWindow, t time.Time) (bool, error) {
+	if window == nil {
+		return true, nil
+	}
+
+	loc := time.UTC
+	if window.TimeZone != nil && *window.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(*window.TimeZone); err != nil {
+			return false, fmt.Errorf("invalid time zone '%s': %w", *window.TimeZone, err)
+		}
+	}
+	t = t.In(loc)
+
+	in := false
+	for _, expr := range window.Schedules {
+		schedule, err := parseCronSchedule(expr)
+		if err != nil {
+			return false, fmt.Errorf("invalid schedule '%s': %w", expr, err)
+		}
+		if schedule.matches(t) {
+			in = true
+		}
+	}
+
+	return in, nil
+}
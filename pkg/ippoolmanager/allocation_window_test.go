@@ -0,0 +1,65 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("InAllocationWindow", Label("allocation_window_test"), func() {
+	// Monday, 2022-10-03 10:30 UTC.
+	monday := time.Date(2022, 10, 3, 10, 30, 0, 0, time.UTC)
+
+	It("is always open without the allocation window", func() {
+		in, err := ippoolmanager.InAllocationWindow(nil, monday)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(in).To(BeTrue())
+	})
+
+	DescribeTable("matches the schedules",
+		func(schedules []string, t time.Time, expected bool) {
+			in, err := ippoolmanager.InAllocationWindow(&spiderpoolv1.AllocationWindow{Schedules: schedules}, t)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(in).To(Equal(expected))
+		},
+		Entry("out of business hours on weekdays", []string{"* 0-8,18-23 * * 1-5"}, monday, false),
+		Entry("in the evening", []string{"* 0-8,18-23 * * 1-5"}, monday.Add(8*time.Hour), true),
+		Entry("any of the schedules", []string{"* 0-8,18-23 * * 1-5", "* * * * 0,6"}, monday.AddDate(0, 0, 6), true),
+		Entry("Sunday as 7", []string{"* * * * 7"}, monday.AddDate(0, 0, 6), true),
+		Entry("steps of minutes", []string{"*/15 * * * *"}, monday, true),
+		Entry("steps of minutes unmatched", []string{"*/20 * * * *"}, monday, false),
+		Entry("either day of month or day of week", []string{"* * 1 * 1"}, monday, true),
+		Entry("the month", []string{"* * * 1-9 *"}, monday, false),
+	)
+
+	It("follows the time zone", func() {
+		window := &spiderpoolv1.AllocationWindow{
+			Schedules: []string{"* 18 * * *"},
+			TimeZone:  pointer.String("Asia/Shanghai"),
+		}
+
+		in, err := ippoolmanager.InAllocationWindow(window, monday)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(in).To(BeTrue())
+	})
+
+	DescribeTable("fails with the invalid allocation window",
+		func(window *spiderpoolv1.AllocationWindow) {
+			_, err := ippoolmanager.InAllocationWindow(window, monday)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", &spiderpoolv1.AllocationWindow{Schedules: []string{"* * * *"}}),
+		Entry("out of range", &spiderpoolv1.AllocationWindow{Schedules: []string{"* 24 * * *"}}),
+		Entry("reversed range", &spiderpoolv1.AllocationWindow{Schedules: []string{"* 18-8 * * *"}}),
+		Entry("invalid step", &spiderpoolv1.AllocationWindow{Schedules: []string{"*/0 * * * *"}}),
+		Entry("unknown time zone", &spiderpoolv1.AllocationWindow{Schedules: []string{"* * * * *"}, TimeZone: pointer.String("Mars/Olympus")}),
+	)
+})
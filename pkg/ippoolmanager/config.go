@@ -10,9 +10,18 @@ const (
 )
 
 type IPPoolManagerConfig struct {
-	MaxConflictRetries    int
-	ConflictRetryUnitTime time.Duration
-	MaxAllocatedIPs       *int
+	MaxAllocatedIPs *int
+	// ListPageSize and ListPageInterval control the pagination of
+	// IterateIPPools.
+	ListPageSize     int64
+	ListPageInterval time.Duration
+	// EnableGapFilling makes the IPPools with the condition Fragmented
+	// allocate the IP addresses of their smallest free ranges first.
+	EnableGapFilling bool
+	// StatusShardSize is the size in bytes of the IP allocation details in
+	// the status of an IPPool, beyond which they are moved into a
+	// SpiderIPPoolShard, 0 disables the sharding.
+	StatusShardSize int
 }
 
 func setDefaultsForIPPoolManagerConfig(config IPPoolManagerConfig) IPPoolManagerConfig {
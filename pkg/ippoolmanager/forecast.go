@@ -0,0 +1,109 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"time"
+
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+)
+
+const (
+	// maxUsageSamples limits the samples kept for each IPPool, the oldest
+	// one is dropped once reached.
+	maxUsageSamples = 256
+	// forecastResyncPeriod is the period to sample all IPPools, besides
+	// sampling on their changes.
+	forecastResyncPeriod = time.Minute
+)
+
+type usageSample struct {
+	time time.Time
+	used int64
+}
+
+// UsageForecaster tracks the used IP addresses of IPPools over a sliding
+// window, and forecasts when the IPPools will be exhausted with the
+// allocation rate in the window.
+type UsageForecaster struct {
+	window time.Duration
+
+	lock    lock.Mutex
+	samples map[string][]usageSample
+}
+
+func NewUsageForecaster(window time.Duration) *UsageForecaster {
+	return &UsageForecaster{
+		window:  window,
+		samples: map[string][]usageSample{},
+	}
+}
+
+// Observe records the number of used IP addresses of the IPPool at the time.
+// The samples out of the window are dropped, and the sample not changing the
+// usage is only kept as the latest one.
+func (f *UsageForecaster) Observe(poolName string, now time.Time, used int64) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	samples := f.samples[poolName]
+	start := 0
+	for start < len(samples) && now.Sub(samples[start].time) > f.window {
+		start++
+	}
+	samples = samples[start:]
+
+	// Keep the first sample of a steady usage, so that the rate covers the
+	// whole period without allocation.
+	if n := len(samples); n >= 2 && samples[n-1].used == used && samples[n-2].used == used {
+		samples[n-1].time = now
+	} else {
+		if len(samples) >= maxUsageSamples {
+			samples = samples[1:]
+		}
+		samples = append(samples, usageSample{time: now, used: used})
+	}
+
+	f.samples[poolName] = samples
+}
+
+// Rate returns the allocation rate of the IPPool in IP addresses per second
+// over the window. The rate is only available with at least two samples.
+func (f *UsageForecaster) Rate(poolName string) (float64, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	samples := f.samples[poolName]
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	first, last := samples[0], samples[len(samples)-1]
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+
+	return float64(last.used-first.used) / elapsed, true
+}
+
+// ExhaustionETA returns how long it will take to allocate all the headroom
+// of the IPPool with its allocation rate. It is unavailable if the usage of
+// the IPPool isn't growing.
+func (f *UsageForecaster) ExhaustionETA(poolName string, headroom int64) (time.Duration, bool) {
+	rate, ok := f.Rate(poolName)
+	if !ok || rate <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(float64(headroom) / rate * float64(time.Second)), true
+}
+
+// Forget drops the samples of the IPPool.
+func (f *UsageForecaster) Forget(poolName string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	delete(f.samples, poolName)
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+)
+
+var _ = Describe("UsageForecaster", Label("forecast_test"), func() {
+	var forecaster *ippoolmanager.UsageForecaster
+	var start time.Time
+
+	BeforeEach(func() {
+		forecaster = ippoolmanager.NewUsageForecaster(time.Hour)
+		start = time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	})
+
+	It("needs at least two samples", func() {
+		_, ok := forecaster.Rate("pool")
+		Expect(ok).To(BeFalse())
+
+		forecaster.Observe("pool", start, 10)
+		_, ok = forecaster.Rate("pool")
+		Expect(ok).To(BeFalse())
+		_, ok = forecaster.ExhaustionETA("pool", 100)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("forecasts the exhaustion with the allocation rate", func() {
+		forecaster.Observe("pool", start, 10)
+		forecaster.Observe("pool", start.Add(10*time.Second), 15)
+		forecaster.Observe("pool", start.Add(20*time.Second), 20)
+
+		rate, ok := forecaster.Rate("pool")
+		Expect(ok).To(BeTrue())
+		Expect(rate).To(BeNumerically("~", 0.5))
+
+		eta, ok := forecaster.ExhaustionETA("pool", 30)
+		Expect(ok).To(BeTrue())
+		Expect(eta).To(Equal(time.Minute))
+	})
+
+	It("has no ETA if the usage isn't growing", func() {
+		forecaster.Observe("pool", start, 20)
+		forecaster.Observe("pool", start.Add(time.Minute), 10)
+
+		rate, ok := forecaster.Rate("pool")
+		Expect(ok).To(BeTrue())
+		Expect(rate).To(BeNumerically("<", 0))
+		_, ok = forecaster.ExhaustionETA("pool", 100)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("spreads the growth over the steady usage", func() {
+		forecaster.Observe("pool", start, 0)
+		forecaster.Observe("pool", start.Add(time.Minute), 60)
+		forecaster.Observe("pool", start.Add(2*time.Minute), 60)
+		forecaster.Observe("pool", start.Add(3*time.Minute), 60)
+
+		rate, ok := forecaster.Rate("pool")
+		Expect(ok).To(BeTrue())
+		Expect(rate).To(BeNumerically("~", 60.0/180))
+	})
+
+	It("drops the samples out of the window", func() {
+		forecaster.Observe("pool", start, 0)
+		forecaster.Observe("pool", start.Add(30*time.Minute), 100)
+		forecaster.Observe("pool", start.Add(90*time.Minute), 160)
+
+		rate, ok := forecaster.Rate("pool")
+		Expect(ok).To(BeTrue())
+		Expect(rate).To(BeNumerically("~", 60.0/3600))
+	})
+
+	It("forgets the IPPool", func() {
+		forecaster.Observe("pool", start, 0)
+		forecaster.Observe("pool", start.Add(time.Minute), 10)
+		forecaster.Observe("other", start, 0)
+		forecaster.Observe("other", start.Add(time.Minute), 10)
+
+		forecaster.Forget("pool")
+		_, ok := forecaster.Rate("pool")
+		Expect(ok).To(BeFalse())
+		_, ok = forecaster.Rate("other")
+		Expect(ok).To(BeTrue())
+	})
+})
@@ -9,26 +9,34 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
 	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/informerstatus"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	crdclientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
@@ -36,7 +44,9 @@ import (
 	informers "github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions/spiderpool.spidernet.io/v1"
 	listers "github.com/spidernet-io/spiderpool/pkg/k8s/client/listers/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	subnetmanagercontrollers "github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
 	"github.com/spidernet-io/spiderpool/pkg/types"
@@ -44,11 +54,29 @@ import (
 
 var informerLogger *zap.Logger
 
+// pruneResyncPeriod is the period to enqueue the empty auto-created IPPools,
+// so that the IPPools whose applications are gone or scaled to zero without
+// any change of themselves are pruned in time.
+const pruneResyncPeriod = time.Minute
+
+// egressIPResyncPeriod is the period to enqueue the IPPools with IP addresses
+// reserved for egress gateway objects, so that the reservations of the
+// deleted objects are released in time.
+const egressIPResyncPeriod = time.Minute
+
 type IPPoolController struct {
 	IPPoolControllerConfig
 
 	client     client.Client
 	rIPManager reservedipmanager.ReservedIPManager
+	forecaster *UsageForecaster
+	clock      clock.Clock
+	freeze     configmanager.FreezeChecker
+
+	// blockedDeletions records the Pods blocking the deletion of each
+	// terminating IPPool last reported, so that the warning is only emitted
+	// once they change rather than on every resync.
+	blockedDeletions sync.Map
 
 	poolLister    listers.SpiderIPPoolLister
 	poolSynced    cache.InformerSynced
@@ -76,15 +104,54 @@ type IPPoolControllerConfig struct {
 	MaxWorkqueueLength            int
 	WorkQueueRequeueDelayDuration time.Duration
 	WorkQueueMaxRetries           int
+	// TopConsumerMetricCount is the number of the applications consuming the
+	// most IP addresses reported for each IPPool, zero disables the metric.
+	TopConsumerMetricCount int
+	// ExhaustionForecastWindow is the sliding window of the allocation rate
+	// used to forecast the exhaustion of IPPools, zero disables the forecast.
+	ExhaustionForecastWindow time.Duration
+	// ExhaustionETAThreshold is the ETA below which the condition
+	// ExhaustionImminent is set on IPPools, zero disables the condition.
+	ExhaustionETAThreshold time.Duration
+	// AutoExpansionThreshold is the utilization percentage at which the
+	// auto-created IPPools are expanded from their SpiderSubnets, zero
+	// disables the expansion.
+	AutoExpansionThreshold int
+	// AutoExpansionStep is the number of IP addresses expanded at a time.
+	AutoExpansionStep int
+	// AutoExpansionMaxIPs bounds the IP addresses expanded for each
+	// auto-created IPPool.
+	AutoExpansionMaxIPs int
+	// EnablePolicyProjection makes the controller keep the annotation
+	// "ipam.spidernet.io/policy-projection" of IPPools up to date.
+	EnablePolicyProjection bool
+	// AutoPoolPruneTTL is how long the auto-created IPPools stay idle before
+	// they are deleted, even if they are not to be reclaimed, zero disables
+	// the pruning.
+	AutoPoolPruneTTL time.Duration
+	// FragmentationRangeThreshold is the number of the ranges which the free
+	// IP addresses of the auto-created IPPools are split into, at which the
+	// condition Fragmented is set, zero disables the condition.
+	FragmentationRangeThreshold int
+	// StatusShardSize is the size in bytes of the IP allocation details in
+	// the status of an IPPool, beyond which they are moved into a
+	// SpiderIPPoolShard, 0 disables the sharding.
+	StatusShardSize int
 }
 
-func NewIPPoolController(poolControllerConfig IPPoolControllerConfig, client client.Client, rIPManager reservedipmanager.ReservedIPManager) *IPPoolController {
+func NewIPPoolController(poolControllerConfig IPPoolControllerConfig, client client.Client, rIPManager reservedipmanager.ReservedIPManager, opts ...manageroption.Option) *IPPoolController {
 	informerLogger = logutils.Logger.Named("SpiderIPPool-Informer")
 
+	options := manageroption.New(opts...)
 	c := &IPPoolController{
 		IPPoolControllerConfig: poolControllerConfig,
 		client:                 client,
 		rIPManager:             rIPManager,
+		clock:                  options.Clock,
+		freeze:                 options.Freeze,
+	}
+	if poolControllerConfig.ExhaustionForecastWindow > 0 {
+		c.forecaster = NewUsageForecaster(poolControllerConfig.ExhaustionForecastWindow)
 	}
 
 	return c
@@ -159,8 +226,11 @@ func (ic *IPPoolController) addEventHandlers(poolInformer informers.SpiderIPPool
 	poolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    ic.onIPPoolAdd,
 		UpdateFunc: ic.onIPPoolUpdate,
-		DeleteFunc: nil,
+		DeleteFunc: ic.onIPPoolDelete,
 	})
+	poolInformer.Informer().AddEventHandler(informerstatus.Register(constant.SpiderIPPoolKind, func() int {
+		return ic.normalPoolWorkQueue.Len() + ic.v4AutoPoolWorkQueue.Len() + ic.v6AutoPoolWorkQueue.Len()
+	}).EventHandler())
 
 	// for auto-created IPPool processing
 	if ic.EnableSpiderSubnet {
@@ -219,6 +289,10 @@ func (ic *IPPoolController) enqueueIPPool(pool *spiderpoolv1.SpiderIPPool) {
 // onAllIPPoolAdd represents SpiderIPPool informer Add Event
 func (ic *IPPoolController) onIPPoolAdd(obj interface{}) {
 	pool := obj.(*spiderpoolv1.SpiderIPPool)
+	recordIPPoolHeadroom(pool)
+	recordIPPoolInfo(pool)
+	ic.recordIPPoolTopConsumers(pool)
+	ic.recordIPPoolExhaustionETA(pool)
 
 	err := ic.updateSpiderIPPool(nil, pool, informerLogger.With(zap.String("onIPPoolAdd", pool.Name)))
 	if nil != err {
@@ -230,6 +304,10 @@ func (ic *IPPoolController) onIPPoolAdd(obj interface{}) {
 func (ic *IPPoolController) onIPPoolUpdate(oldObj interface{}, newObj interface{}) {
 	oldPool := oldObj.(*spiderpoolv1.SpiderIPPool)
 	newPool := newObj.(*spiderpoolv1.SpiderIPPool)
+	recordIPPoolHeadroom(newPool)
+	recordIPPoolInfo(newPool)
+	ic.recordIPPoolTopConsumers(newPool)
+	ic.recordIPPoolExhaustionETA(newPool)
 
 	err := ic.updateSpiderIPPool(oldPool, newPool, informerLogger.With(zap.String("onIPPoolUpdate", newPool.Name)))
 	if nil != err {
@@ -237,6 +315,162 @@ func (ic *IPPoolController) onIPPoolUpdate(oldObj interface{}, newObj interface{
 	}
 }
 
+// onIPPoolDelete represents SpiderIPPool informer Delete Event
+func (ic *IPPoolController) onIPPoolDelete(obj interface{}) {
+	var pool *spiderpoolv1.SpiderIPPool
+	switch t := obj.(type) {
+	case *spiderpoolv1.SpiderIPPool:
+		pool = t
+	case cache.DeletedFinalStateUnknown:
+		p, ok := t.Obj.(*spiderpoolv1.SpiderIPPool)
+		if !ok {
+			return
+		}
+		pool = p
+	default:
+		return
+	}
+
+	ic.blockedDeletions.Delete(pool.Name)
+	metric.IPPoolHeadroom.Delete(pool.Name)
+	metric.IPPoolInfo.Delete(pool.Name)
+	metric.IPPoolExhaustionETASeconds.Delete(pool.Name)
+	if ic.forecaster != nil {
+		ic.forecaster.Forget(pool.Name)
+	}
+	for rank := 0; rank < ic.TopConsumerMetricCount; rank++ {
+		metric.IPPoolTopConsumerIPCounts.Delete(topConsumerMetricKey(pool.Name, rank))
+	}
+}
+
+// recordIPPoolHeadroom reports the number of IP addresses that can still be
+// allocated from the IPPool.
+func recordIPPoolHeadroom(pool *spiderpoolv1.SpiderIPPool) {
+	headroom, ok := IPPoolHeadroom(pool)
+	if !ok {
+		return
+	}
+
+	metric.IPPoolHeadroom.Record(pool.Name, headroom, attribute.String("ippool", pool.Name))
+}
+
+// recordIPPoolInfo reports the inventory of the IPPool in the labels of the
+// info metric, so that dashboards could join the other metrics of the IPPool
+// with its topology.
+func recordIPPoolInfo(pool *spiderpoolv1.SpiderIPPool) {
+	metric.IPPoolInfo.Record(pool.Name, 1,
+		attribute.String("ippool", pool.Name),
+		attribute.Int64("ip_version", pointer.Int64Deref(pool.Spec.IPVersion, 0)),
+		attribute.String("cidr", pool.Spec.Subnet),
+		attribute.Int64("vlan", pointer.Int64Deref(pool.Spec.Vlan, 0)),
+		attribute.String("gateway", pointer.StringDeref(pool.Spec.Gateway, "")),
+		attribute.Bool("default", IsDefaultIPPool(pool)),
+		attribute.String("subnet", pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]),
+		attribute.String("application", pool.Labels[constant.LabelIPPoolOwnerApplication]),
+	)
+}
+
+// recordIPPoolTopConsumers reports the applications consuming the most IP
+// addresses of the IPPool, ranked series which no longer have a consumer
+// are dropped.
+func (ic *IPPoolController) recordIPPoolTopConsumers(pool *spiderpoolv1.SpiderIPPool) {
+	if ic.TopConsumerMetricCount <= 0 {
+		return
+	}
+
+	consumers := TopIPConsumers(ic.TopConsumerMetricCount, pool)
+	for rank := 0; rank < ic.TopConsumerMetricCount; rank++ {
+		key := topConsumerMetricKey(pool.Name, rank)
+		if rank >= len(consumers) {
+			metric.IPPoolTopConsumerIPCounts.Delete(key)
+			continue
+		}
+
+		consumer := consumers[rank]
+		metric.IPPoolTopConsumerIPCounts.Record(key, int64(consumer.IPCount),
+			attribute.String("ippool", pool.Name),
+			attribute.String("subnet", pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]),
+			attribute.String("namespace", consumer.Namespace),
+			attribute.String("kind", consumer.Kind),
+			attribute.String("name", consumer.Name),
+		)
+	}
+}
+
+func topConsumerMetricKey(poolName string, rank int) string {
+	return fmt.Sprintf("%s/%d", poolName, rank)
+}
+
+// recordIPPoolExhaustionETA samples the used IP addresses of the IPPool and
+// reports how long it will take to exhaust the IPPool with its allocation
+// rate. The ETA is dropped once the usage of the IPPool stops growing.
+func (ic *IPPoolController) recordIPPoolExhaustionETA(pool *spiderpoolv1.SpiderIPPool) {
+	if ic.forecaster == nil || pool.DeletionTimestamp != nil {
+		return
+	}
+
+	headroom, ok := IPPoolHeadroom(pool)
+	if !ok {
+		return
+	}
+	ic.forecaster.Observe(pool.Name, ic.clock.Now(), int64(len(usedIPsOfIPPool(pool))))
+
+	eta, ok := ic.forecaster.ExhaustionETA(pool.Name, headroom)
+	if !ok {
+		metric.IPPoolExhaustionETASeconds.Delete(pool.Name)
+		return
+	}
+	metric.IPPoolExhaustionETASeconds.Record(pool.Name, int64(eta.Seconds()), attribute.String("ippool", pool.Name))
+}
+
+// resyncExhaustionForecast samples all IPPools periodically, so that the ETA
+// of the IPPools without any allocation any more grows rather than staying
+// with the last burst, and enqueues the IPPools whose condition
+// ExhaustionImminent is outdated.
+func (ic *IPPoolController) resyncExhaustionForecast() {
+	pools, err := ic.poolLister.List(labels.Everything())
+	if err != nil {
+		informerLogger.Sugar().Errorf("failed to list IPPools to forecast their exhaustion: %v", err)
+		return
+	}
+
+	for _, pool := range pools {
+		ic.recordIPPoolExhaustionETA(pool)
+		if pool.DeletionTimestamp == nil && ic.exhaustionConditionOutdated(pool) {
+			ic.enqueueIPPool(pool)
+		}
+	}
+}
+
+// isExhaustionImminent reports whether the IPPool is forecast to be exhausted
+// within the threshold, with the ETA.
+func (ic *IPPoolController) isExhaustionImminent(pool *spiderpoolv1.SpiderIPPool) (bool, time.Duration) {
+	if ic.forecaster == nil || ic.ExhaustionETAThreshold <= 0 {
+		return false, 0
+	}
+
+	headroom, ok := IPPoolHeadroom(pool)
+	if !ok {
+		return false, 0
+	}
+
+	eta, ok := ic.forecaster.ExhaustionETA(pool.Name, headroom)
+	if !ok {
+		return false, 0
+	}
+
+	return eta < ic.ExhaustionETAThreshold, eta
+}
+
+// exhaustionConditionOutdated reports whether the condition ExhaustionImminent
+// of the IPPool doesn't match its forecast.
+func (ic *IPPoolController) exhaustionConditionOutdated(pool *spiderpoolv1.SpiderIPPool) bool {
+	imminent, _ := ic.isExhaustionImminent(pool)
+	cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionExhaustionImminent)
+
+	return imminent != (cond != nil && cond.Status == metav1.ConditionTrue)
+}
+
 // updateSpiderIPPool serves for SpiderIPPool Informer event hooks,
 // it will check whether the SpiderIPPool status AllocatedIPCount/TotalIPCount needs to be initialized
 // and enqueue them.
@@ -251,6 +485,9 @@ func (ic *IPPoolController) updateSpiderIPPool(oldIPPool, currentIPPool *spiderp
 	needCalculate := false
 	if currentIPPool.Status.TotalIPCount == nil || currentIPPool.Status.AllocatedIPCount == nil {
 		needCalculate = true
+	} else if ic.EnablePolicyProjection && !IsPolicyProjectionUpToDate(currentIPPool) {
+		// case: the policy projection of SpiderIPPool is outdated
+		needCalculate = true
 	} else {
 		if oldIPPool == nil {
 			needCalculate = false
@@ -264,6 +501,18 @@ func (ic *IPPoolController) updateSpiderIPPool(oldIPPool, currentIPPool *spiderp
 				// case: SpiderIPPool spec ExcludeIPs changed
 				needCalculate = true
 
+			case IsReconcilePaused(oldIPPool.Annotations) != IsReconcilePaused(currentIPPool.Annotations):
+				// case: the resizing of SpiderIPPool is paused or resumed
+				needCalculate = true
+
+			case IsDetachRequested(oldIPPool.Annotations) != IsDetachRequested(currentIPPool.Annotations):
+				// case: SpiderIPPool is requested to be detached from its application
+				needCalculate = true
+
+			case ic.exhaustionConditionOutdated(currentIPPool):
+				// case: SpiderIPPool is forecast to be exhausted soon, or not any more
+				needCalculate = true
+
 			default:
 				needCalculate = false
 			}
@@ -278,7 +527,10 @@ func (ic *IPPoolController) updateSpiderIPPool(oldIPPool, currentIPPool *spiderp
 
 	// If the auto-created IPPool's current IP number is not equal with the desired IP number, we'll try to scale it.
 	// If its allocated IPs are empty, we will check whether the IPPool should be deleted or not.
-	if ShouldScaleIPPool(currentIPPool) || len(currentIPPool.Status.AllocatedIPs) == 0 {
+	// If its utilization pressure changes, we will expand or retract it.
+	// If it borrows IPs from a sibling SpiderSubnet, we will check whether to return them.
+	if ShouldScaleIPPool(currentIPPool) || len(currentIPPool.Status.AllocatedIPs) == 0 || ic.shouldAutoExpandIPPool(currentIPPool) ||
+		IsBorrowedIPPool(currentIPPool) {
 		log.Debug("try to add IPPool to IPPool workqueue to scale or delete itself")
 		ic.enqueueIPPool(currentIPPool)
 	}
@@ -315,6 +567,16 @@ func (ic *IPPoolController) Run(stopCh <-chan struct{}) error {
 		go wait.Until(ic.runV6AutoPoolWorker, 1*time.Second, stopCh)
 	}
 
+	if ic.forecaster != nil {
+		go wait.Until(ic.resyncExhaustionForecast, forecastResyncPeriod, stopCh)
+	}
+
+	if ic.EnableSpiderSubnet && ic.AutoPoolPruneTTL > 0 {
+		go wait.Until(ic.resyncIdleAutoIPPools, pruneResyncPeriod, stopCh)
+	}
+
+	go wait.Until(ic.resyncEgressIPPools, egressIPResyncPeriod, stopCh)
+
 	informerLogger.Info("IPPool controller workers started")
 
 	<-stopCh
@@ -372,6 +634,14 @@ func (ic *IPPoolController) processNextWorkItem(workQueue workqueue.RateLimiting
 			return nil
 		}
 
+		// The reconciliation is paused during the maintenance freeze.
+		if err := configmanager.CheckFrozen(ic.freeze); err != nil {
+			log.Sugar().Debugf("Postpone syncing '%s': %v", poolName, err)
+			workQueue.Forget(obj)
+			workQueue.AddAfter(poolName, ic.WorkQueueRequeueDelayDuration)
+			return nil
+		}
+
 		pool, err := ic.poolLister.Get(poolName)
 		if nil != err {
 			// The IPPool resource may no longer exist, in which case we stop
@@ -386,12 +656,20 @@ func (ic *IPPoolController) processNextWorkItem(workQueue workqueue.RateLimiting
 			return fmt.Errorf("error syncing '%s': %s, requeuing", poolName, err.Error())
 		}
 
-		err = fn(context.TODO(), pool.DeepCopy())
+		pool = pool.DeepCopy()
+		if err := AssembleIPPoolShards(context.TODO(), ic.client, nil, pool); err != nil {
+			workQueue.AddRateLimited(poolName)
+			return fmt.Errorf("error syncing '%s': %s, requeuing", poolName, err.Error())
+		}
+
+		err = recovery.Call(logutils.IntoContext(context.TODO(), log), "IPPool-Informer", pool, func() error {
+			return fn(context.TODO(), pool)
+		})
 		if nil != err {
 			// discard some wrong input items
 			if errors.Is(err, constant.ErrWrongInput) {
 				workQueue.Forget(obj)
-				return fmt.Errorf("failed to process IPPool '%s', error: %v, discarding it", pool.Name, err)
+				return fmt.Errorf("failed to process IPPool '%s', error: %w, discarding it", pool.Name, err)
 			}
 
 			if apierrors.IsConflict(err) {
@@ -475,6 +753,18 @@ func (ic *IPPoolController) scaleIPPoolIfNeeded(ctx context.Context, pool *spide
 
 		ipsFromSubnet, err := ic.generateIPsFromSubnetWhenScaleUpIP(logutils.IntoContext(ctx, informerLogger), subnetName, pool, cursor)
 		if nil != err {
+			var exhaustedErr *constant.PoolExhaustedError
+			if errors.As(err, &exhaustedErr) && !IsBorrowedIPPool(pool) {
+				borrowed, borrowErr := ic.borrowIPs(ctx, pool, subnetName)
+				if nil != borrowErr {
+					return fmt.Errorf("failed to borrow IPs for IPPool '%s' from the siblings of SpiderSubnet '%s': %w", pool.Name, subnetName, borrowErr)
+				}
+				if borrowed {
+					// the IPPool will be expanded on the next update of its SpiderSubnet
+					informerLogger.Sugar().Warnf("IPPool '%s' borrows IPs from the siblings of exhausted SpiderSubnet '%s'", pool.Name, subnetName)
+					return nil
+				}
+			}
 			return fmt.Errorf("failed to generate IPs from subnet '%s', error: %w", subnetName, err)
 		}
 
@@ -487,12 +777,8 @@ func (ic *IPPoolController) scaleIPPoolIfNeeded(ctx context.Context, pool *spide
 	} else {
 		// shrink: free IP number >= return IP Num
 		// when it needs to scale down IP, enough IP is released to make sure it scale down successfully
-		if totalIPCount-len(pool.Status.AllocatedIPs) >= totalIPCount-desiredIPNum {
-			var allocatedIPRanges []string
-			for tmpIP := range pool.Status.AllocatedIPs {
-				allocatedIPRanges = append(allocatedIPRanges, tmpIP)
-			}
-
+		allocatedIPRanges := usedIPsOfIPPool(pool)
+		if totalIPCount-len(allocatedIPRanges) >= totalIPCount-desiredIPNum {
 			allocatedIPs, err := spiderpoolip.ParseIPRanges(*pool.Spec.IPVersion, allocatedIPRanges)
 			if nil != err {
 				return fmt.Errorf("%w: failed to parse IP ranges '%v', error: %v", constant.ErrWrongInput, allocatedIPRanges, err)
@@ -516,6 +802,65 @@ func (ic *IPPoolController) scaleIPPoolIfNeeded(ctx context.Context, pool *spide
 	return nil
 }
 
+// shouldAutoExpandIPPool checks whether the auto-created IPPool should be
+// expanded or retracted on its utilization pressure.
+func (ic *IPPoolController) shouldAutoExpandIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	if !ic.EnableSpiderSubnet || !IsAutoCreatedIPPool(pool) || IsBorrowedIPPool(pool) || pool.DeletionTimestamp != nil || ShouldScaleIPPool(pool) {
+		return false
+	}
+
+	expanded := AutoExpandedIPCount(pool, ic.AutoExpansionThreshold, ic.AutoExpansionStep, ic.AutoExpansionMaxIPs)
+	return expanded != pointer.Int64Deref(pool.Status.AutoExpandedIPCount, 0)
+}
+
+// autoExpandIPPool updates the status AutoDesiredIPCount of the auto-created
+// IPPool with the IP addresses expanded or retracted on its utilization
+// pressure, the expansion is bounded by the free IPs of the SpiderSubnet.
+// It returns true if the IPPool status is updated.
+func (ic *IPPoolController) autoExpandIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (bool, error) {
+	if !ic.shouldAutoExpandIPPool(pool) {
+		return false, nil
+	}
+
+	oldExpanded := pointer.Int64Deref(pool.Status.AutoExpandedIPCount, 0)
+	expanded := AutoExpandedIPCount(pool, ic.AutoExpansionThreshold, ic.AutoExpansionStep, ic.AutoExpansionMaxIPs)
+	if expanded > oldExpanded {
+		subnetName := pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]
+		subnet, err := ic.subnetsLister.Get(subnetName)
+		if nil != err {
+			return false, fmt.Errorf("failed to get SpiderSubnet '%s' to expand IPPool '%s': %w", subnetName, pool.Name, err)
+		}
+
+		freeIPCount := pointer.Int64Deref(subnet.Status.TotalIPCount, 0) - pointer.Int64Deref(subnet.Status.AllocatedIPCount, 0)
+		if expanded-oldExpanded > freeIPCount {
+			expanded = oldExpanded + freeIPCount
+		}
+		if expanded <= oldExpanded {
+			informerLogger.Sugar().Debugf("no free IPs left in SpiderSubnet '%s' to expand IPPool '%s'", subnetName, pool.Name)
+			return false, nil
+		}
+	}
+
+	*pool.Status.AutoDesiredIPCount += expanded - oldExpanded
+	if expanded == 0 {
+		pool.Status.AutoExpandedIPCount = nil
+	} else {
+		pool.Status.AutoExpandedIPCount = pointer.Int64(expanded)
+	}
+
+	err := ic.updateIPPoolStatus(ctx, pool)
+	if nil != err {
+		return false, err
+	}
+
+	informerLogger.Sugar().Infof("update IPPool '%s' status AutoDesiredIPCount to '%d' with '%d' IPs expanded on its utilization",
+		pool.Name, *pool.Status.AutoDesiredIPCount, expanded)
+	event.EventRecorder.Eventf(pool, corev1.EventTypeNormal, constant.EventReasonScaleIPPool,
+		"Change the IP addresses expanded on the utilization from %d to %d", oldExpanded, expanded)
+
+	return true, nil
+}
+
 // cleanAutoIPPoolLegacy checks whether the given IPPool should be deleted or not, and the return params can show the IPPool is deleted or not
 func (ic *IPPoolController) cleanAutoIPPoolLegacy(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (isCleaned bool, err error) {
 	if pool.DeletionTimestamp != nil {
@@ -533,56 +878,13 @@ func (ic *IPPoolController) cleanAutoIPPoolLegacy(ctx context.Context, pool *spi
 			return false, nil
 		}
 
-		// unpack the IPPool corresponding application type,namespace and name
-		appLabelValue := poolLabels[constant.LabelIPPoolOwnerApplication]
-		kind, ns, name, found := subnetmanagercontrollers.ParseAppLabelValue(appLabelValue)
-		if !found {
-			return false, fmt.Errorf("%w: invalid IPPool label '%s' value '%s'", constant.ErrWrongInput, constant.LabelIPPoolOwnerApplication, appLabelValue)
-		}
-
-		var object client.Object
-		switch kind {
-		case constant.KindDeployment:
-			object = &appsv1.Deployment{}
-		case constant.KindReplicaSet:
-			object = &appsv1.ReplicaSet{}
-		case constant.KindDaemonSet:
-			object = &appsv1.DaemonSet{}
-		case constant.KindStatefulSet:
-			object = &appsv1.StatefulSet{}
-		case constant.KindJob:
-			object = &batchv1.Job{}
-		case constant.KindCronJob:
-			object = &batchv1.CronJob{}
-		default:
-			// pod and other controllers will clean up legacy ippools in IPAM
-			return false, nil
-		}
-
-		enableDelete := false
-
-		// check the IPPool's corresponding application whether is existed or not
-		informerLogger.Sugar().Debugf("try to get auto-created IPPool '%s' corresponding application '%s/%s/%s'", pool.Name, kind, ns, name)
-		err = ic.client.Get(ctx, apitypes.NamespacedName{Namespace: ns, Name: name}, object)
+		enableDelete, err := ic.isApplicationGone(ctx, pool)
 		if nil != err {
-			// if the application is no longer exist, we should delete the IPPool
-			if apierrors.IsNotFound(err) {
-				informerLogger.Sugar().Warnf("auto-created IPPool '%s' corresponding application '%s/%s/%s' is no longer exist, try to gc IPPool",
-					pool.Name, kind, ns, name)
-				enableDelete = true
-			} else {
-				return false, err
-			}
-		} else {
-			// mismatch application UID
-			if string(object.GetUID()) != poolLabels[constant.LabelIPPoolOwnerApplicationUID] {
-				enableDelete = true
-				informerLogger.Sugar().Warnf("auto-created IPPool '%v' mismatches application '%s/%s/%s' UID '%s', try to gc IPPool",
-					pool, kind, ns, name, object.GetUID())
-			}
+			return false, err
 		}
 
 		if enableDelete {
+			informerLogger.Sugar().Infof("try to gc auto-created IPPool '%s'", pool.Name)
 			err := ic.client.Delete(ctx, pool)
 			if client.IgnoreNotFound(err) != nil {
 				return true, err
@@ -594,16 +896,348 @@ func (ic *IPPoolController) cleanAutoIPPoolLegacy(ctx context.Context, pool *spi
 	return false, nil
 }
 
+// pruneIdleAutoIPPool tracks whether the auto-created IPPool is idle with the
+// condition Idle, and deletes it once it has been idle beyond the TTL, whether
+// it is to be reclaimed or not. The return params show the IPPool is deleted
+// or not.
+func (ic *IPPoolController) pruneIdleAutoIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (isPruned bool, err error) {
+	if ic.AutoPoolPruneTTL <= 0 || pool.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	idle, reason := false, ""
+	if len(pool.Status.AllocatedIPs) == 0 && !IsPruneExempt(pool.Annotations) {
+		idle, reason, err = ic.isApplicationIdle(ctx, pool)
+		if nil != err {
+			return false, err
+		}
+	}
+
+	if SetIdle(pool, idle, reason) {
+		err := ic.updateIPPoolStatus(ctx, pool)
+		if nil != err {
+			return false, err
+		}
+		informerLogger.Sugar().Debugf("update IPPool '%s' condition %s to %v", pool.Name, constant.IPPoolConditionIdle, idle)
+	}
+
+	idleDuration, ok := IdleDuration(pool, ic.clock.Now())
+	if !ok || idleDuration < ic.AutoPoolPruneTTL {
+		return false, nil
+	}
+
+	informerLogger.Sugar().Infof("try to prune auto-created IPPool '%s' idle for %s", pool.Name, idleDuration.Round(time.Second))
+	err = ic.client.Delete(ctx, pool)
+	if client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+	event.EventRecorder.Eventf(pool, corev1.EventTypeNormal, constant.EventReasonPruneIPPool,
+		"Pruned the IPPool idle for %s", idleDuration.Round(time.Second))
+
+	return true, nil
+}
+
+// isApplicationIdle checks whether the application of the auto-created
+// IPPool is gone or scaled to zero, with the reason.
+func (ic *IPPoolController) isApplicationIdle(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (bool, string, error) {
+	appGone, err := ic.isApplicationGone(ctx, pool)
+	if nil != err {
+		return false, "", err
+	}
+	if appGone {
+		return true, constant.ReasonApplicationGone, nil
+	}
+
+	kind, ns, name, _ := subnetmanagercontrollers.ParseAppLabelValue(pool.Labels[constant.LabelIPPoolOwnerApplication])
+	key := apitypes.NamespacedName{Namespace: ns, Name: name}
+
+	var object client.Object
+	switch kind {
+	case constant.KindDeployment, constant.KindReplicaSet, constant.KindStatefulSet,
+		constant.KindReplicationController, constant.KindDeploymentConfig:
+		object = newApplicationObject(kind)
+	default:
+		return false, "", nil
+	}
+	if err := ic.client.Get(ctx, key, object); err != nil {
+		return false, "", client.IgnoreNotFound(err)
+	}
+
+	// the replicas default to 1 if unset
+	if replicas, _ := (types.PodTopController{Kind: kind, APP: object}).Replicas(); replicas == 0 {
+		return true, constant.ReasonZeroReplicas, nil
+	}
+
+	return false, "", nil
+}
+
+// newApplicationObject returns an empty object of the application kind whose
+// IPPools are created by the SpiderSubnet controller, nil for the others.
+func newApplicationObject(kind string) client.Object {
+	switch kind {
+	case constant.KindDeployment:
+		return &appsv1.Deployment{}
+	case constant.KindReplicaSet:
+		return &appsv1.ReplicaSet{}
+	case constant.KindDaemonSet:
+		return &appsv1.DaemonSet{}
+	case constant.KindStatefulSet:
+		return &appsv1.StatefulSet{}
+	case constant.KindJob:
+		return &batchv1.Job{}
+	case constant.KindCronJob:
+		return &batchv1.CronJob{}
+	case constant.KindReplicationController:
+		return &corev1.ReplicationController{}
+	case constant.KindDeploymentConfig:
+		deploymentConfig := &unstructured.Unstructured{}
+		deploymentConfig.SetAPIVersion(constant.DeploymentConfigAPIVersion)
+		deploymentConfig.SetKind(constant.KindDeploymentConfig)
+		return deploymentConfig
+	}
+
+	return nil
+}
+
+// resyncIdleAutoIPPools enqueues the empty auto-created IPPools periodically
+// to check whether they are idle or should be pruned.
+func (ic *IPPoolController) resyncIdleAutoIPPools() {
+	pools, err := ic.poolLister.List(labels.Everything())
+	if err != nil {
+		informerLogger.Sugar().Errorf("failed to list IPPools to prune the idle ones: %v", err)
+		return
+	}
+
+	for _, pool := range pools {
+		if pool.DeletionTimestamp == nil && IsAutoCreatedIPPool(pool) && len(pool.Status.AllocatedIPs) == 0 && len(pool.Status.Shards) == 0 {
+			ic.enqueueIPPool(pool)
+		}
+	}
+}
+
+// resyncEgressIPPools enqueues the IPPools with IP addresses reserved for
+// egress gateway objects periodically to release the orphan reservations.
+func (ic *IPPoolController) resyncEgressIPPools() {
+	pools, err := ic.poolLister.List(labels.Everything())
+	if err != nil {
+		informerLogger.Sugar().Errorf("failed to list IPPools to release the orphan egress IPs: %v", err)
+		return
+	}
+
+	for _, pool := range pools {
+		if len(pool.Status.EgressIPs) != 0 {
+			ic.enqueueIPPool(pool)
+		}
+	}
+}
+
+// releaseOrphanEgressIPs releases the IP addresses of the IPPool reserved for
+// the egress gateway objects no longer existed or mismatching the UIDs of the
+// reservations. The reservations whose objects could not be checked, e.g. the
+// controller is not allowed to get them, are kept.
+func (ic *IPPoolController) releaseOrphanEgressIPs(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) error {
+	var orphans []string
+	for ip, reservation := range pool.Status.EgressIPs {
+		gone, err := ic.isEgressObjectGone(ctx, reservation)
+		if nil != err {
+			informerLogger.Sugar().Warnf("failed to check %s %s/%s reserving IP '%s' of SpiderIPPool '%s': %v",
+				reservation.Kind, reservation.Namespace, reservation.Name, ip, pool.Name, err)
+			continue
+		}
+		if gone {
+			orphans = append(orphans, ip)
+		}
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	released := make(map[string]spiderpoolv1.EgressIPReservation, len(orphans))
+	for _, ip := range orphans {
+		released[ip] = pool.Status.EgressIPs[ip]
+		delete(pool.Status.EgressIPs, ip)
+	}
+	err := ic.updateIPPoolStatus(ctx, pool)
+	if nil != err {
+		return err
+	}
+
+	for ip, reservation := range released {
+		informerLogger.Sugar().Infof("release IP '%s' of SpiderIPPool '%s' reserved for %s %s/%s no longer existed",
+			ip, pool.Name, reservation.Kind, reservation.Namespace, reservation.Name)
+		event.EventRecorder.Eventf(pool, corev1.EventTypeNormal, constant.EventReasonReleaseEgressIP,
+			"Released IP %s reserved for %s %s/%s no longer existed", ip, reservation.Kind, reservation.Namespace, reservation.Name)
+	}
+
+	return nil
+}
+
+// isEgressObjectGone checks whether the egress gateway object of the
+// reservation is no longer existed, its kind is no longer served, or it
+// mismatches the UID of the reservation.
+func (ic *IPPoolController) isEgressObjectGone(ctx context.Context, reservation spiderpoolv1.EgressIPReservation) (bool, error) {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(reservation.APIVersion)
+	object.SetKind(reservation.Kind)
+
+	err := ic.client.Get(ctx, apitypes.NamespacedName{Namespace: reservation.Namespace, Name: reservation.Name}, object)
+	if nil != err {
+		if apierrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return reservation.UID != "" && string(object.GetUID()) != reservation.UID, nil
+}
+
+// isApplicationGone checks whether the application of the auto-created IPPool
+// is no longer existed or mismatches the IPPool with its UID. The IPPools of
+// Pods and other controllers are never reported, they are cleaned up in IPAM.
+// The pre-provisioned IPPools are never reported either, as they are waiting
+// for their applications to be created.
+func (ic *IPPoolController) isApplicationGone(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (bool, error) {
+	poolLabels := pool.GetLabels()
+	if poolLabels[constant.LabelIPPoolPreProvisioned] == constant.True {
+		return false, nil
+	}
+
+	// unpack the IPPool corresponding application type,namespace and name
+	appLabelValue := poolLabels[constant.LabelIPPoolOwnerApplication]
+	kind, ns, name, found := subnetmanagercontrollers.ParseAppLabelValue(appLabelValue)
+	if !found {
+		return false, fmt.Errorf("%w: invalid IPPool label '%s' value '%s'", constant.ErrWrongInput, constant.LabelIPPoolOwnerApplication, appLabelValue)
+	}
+
+	object := newApplicationObject(kind)
+	if object == nil {
+		// pod and other controllers will clean up legacy ippools in IPAM
+		return false, nil
+	}
+
+	// check the IPPool's corresponding application whether is existed or not
+	informerLogger.Sugar().Debugf("try to get auto-created IPPool '%s' corresponding application '%s/%s/%s'", pool.Name, kind, ns, name)
+	err := ic.client.Get(ctx, apitypes.NamespacedName{Namespace: ns, Name: name}, object)
+	if nil != err {
+		if apierrors.IsNotFound(err) {
+			informerLogger.Sugar().Warnf("auto-created IPPool '%s' corresponding application '%s/%s/%s' is no longer exist",
+				pool.Name, kind, ns, name)
+			return true, nil
+		}
+		return false, err
+	}
+
+	// mismatch application UID
+	if string(object.GetUID()) != poolLabels[constant.LabelIPPoolOwnerApplicationUID] {
+		informerLogger.Sugar().Warnf("auto-created IPPool '%s' mismatches application '%s/%s/%s' UID '%s'",
+			pool.Name, kind, ns, name, object.GetUID())
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// detachAutoIPPool detaches the auto-created IPPool with the annotation
+// "ipam.spidernet.io/detach" from its application. The IPPool is not
+// reclaimed and not resized any more once the annotation is set, and it
+// becomes a normal IPPool after the application is gone. It returns true if
+// the IPPool is updated.
+func (ic *IPPoolController) detachAutoIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (bool, error) {
+	if pool.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	appGone, err := ic.isApplicationGone(ctx, pool)
+	if nil != err {
+		return false, err
+	}
+
+	if !DetachAutoIPPool(pool, appGone) {
+		return false, nil
+	}
+
+	err = ic.client.Update(ctx, pool)
+	if nil != err {
+		return false, err
+	}
+
+	if !appGone {
+		informerLogger.Sugar().Infof("stop reclaiming IPPool '%s' to be detached from its application", pool.Name)
+		event.EventRecorder.Eventf(pool, corev1.EventTypeNormal, constant.EventReasonDetachIPPool,
+			"Stop reclaiming the IPPool, it will be detached once its application is gone")
+		return true, nil
+	}
+
+	// the auto-created IPPool status is meaningless for the normal IPPool
+	pool.Status.AutoDesiredIPCount = nil
+	pool.Status.AutoExpandedIPCount = nil
+	apimeta.RemoveStatusCondition(&pool.Status.Conditions, constant.IPPoolConditionReconcilePaused)
+	err = ic.updateIPPoolStatus(ctx, pool)
+	if nil != err {
+		return false, err
+	}
+
+	informerLogger.Sugar().Infof("detach IPPool '%s' from its application", pool.Name)
+	event.EventRecorder.Eventf(pool, corev1.EventTypeNormal, constant.EventReasonDetachIPPool,
+		"Detached the IPPool from its application, it is a normal IPPool now")
+
+	return true, nil
+}
+
 func (ic *IPPoolController) handleIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) error {
 	// checkout the Auto-created IPPools whether need to scale or clean up legacies
-	if ic.EnableSpiderSubnet && IsAutoCreatedIPPool(pool) {
+	if ic.EnableSpiderSubnet && IsAutoCreatedIPPool(pool) && IsDetachRequested(pool.Annotations) {
+		detached, err := ic.detachAutoIPPool(ctx, pool)
+		if nil != err {
+			if apierrors.IsConflict(err) {
+				metric.IPPoolInformerConflictCounts.Add(ctx, 1)
+			}
+			return err
+		}
+		if detached {
+			// the IPPool will be synced on its update event
+			return nil
+		}
+	} else if ic.EnableSpiderSubnet && IsAutoCreatedIPPool(pool) {
 		isCleaned, err := ic.cleanAutoIPPoolLegacy(ctx, pool)
 		if nil != err {
 			return err
 		}
-
-		// there's no need to scale the IPPool if the IPPool is terminating.
 		if !isCleaned {
+			isCleaned, err = ic.pruneIdleAutoIPPool(ctx, pool)
+			if nil != err {
+				if apierrors.IsConflict(err) {
+					metric.IPPoolInformerConflictCounts.Add(ctx, 1)
+				}
+				return err
+			}
+		}
+
+		// there's no need to scale the IPPool if the IPPool is terminating or
+		// its resizing is paused.
+		if IsReconcilePaused(pool.Annotations) {
+			informerLogger.Sugar().Debugf("the resizing of IPPool '%s' is paused", pool.Name)
+		} else if !isCleaned {
+			if IsBorrowedIPPool(pool) {
+				returned, err := ic.returnBorrowedIPs(ctx, pool)
+				if nil != err {
+					return err
+				}
+				if returned {
+					// the IPPool will be scaled on its update event
+					return nil
+				}
+			}
+
+			expanded, err := ic.autoExpandIPPool(ctx, pool)
+			if nil != err {
+				return err
+			}
+			if expanded {
+				// the IPPool will be scaled on its update event
+				return nil
+			}
+
 			err = ic.scaleIPPoolIfNeeded(ctx, pool)
 			if nil != err {
 				if apierrors.IsConflict(err) {
@@ -611,6 +1245,19 @@ func (ic *IPPoolController) handleIPPool(ctx context.Context, pool *spiderpoolv1
 				}
 				return err
 			}
+			if !IsBorrowedIPPool(pool) {
+				ic.enqueueBorrowedIPPool(pool)
+			}
+		}
+	}
+
+	if ic.EnablePolicyProjection {
+		err := ic.syncPolicyProjection(ctx, pool)
+		if nil != err {
+			if apierrors.IsConflict(err) {
+				metric.IPPoolInformerConflictCounts.Add(ctx, 1)
+			}
+			return err
 		}
 	}
 
@@ -626,12 +1273,46 @@ func (ic *IPPoolController) handleIPPool(ctx context.Context, pool *spiderpoolv1
 	return nil
 }
 
+// syncPolicyProjection updates the annotation "ipam.spidernet.io/policy-projection"
+// of the IPPool if it is outdated, the IPPool is updated in place.
+func (ic *IPPoolController) syncPolicyProjection(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) error {
+	if pool.DeletionTimestamp != nil {
+		return nil
+	}
+
+	projection, err := ProjectPolicy(pool)
+	if nil != err {
+		return fmt.Errorf("%w: failed to project the policy of SpiderIPPool '%s', error: %v", constant.ErrWrongInput, pool.Name, err)
+	}
+	if pool.Annotations[constant.AnnoIPPoolPolicyProjection] == projection {
+		return nil
+	}
+
+	if pool.Annotations == nil {
+		pool.Annotations = map[string]string{}
+	}
+	pool.Annotations[constant.AnnoIPPoolPolicyProjection] = projection
+	err = ic.client.Update(ctx, pool)
+	if nil != err {
+		return err
+	}
+
+	informerLogger.Sugar().Debugf("update SpiderIPPool '%s' policy projection to '%s'", pool.Name, projection)
+	return nil
+}
+
 // syncHandleAllIPPool will calculate and update the provided SpiderIPPool status AllocatedIPCount or TotalIPCount.
 // And it will also remove finalizer once the IPPool is dying and no longer being used.
 func (ic *IPPoolController) syncHandleAllIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) error {
+	if len(pool.Status.EgressIPs) != 0 {
+		if err := ic.releaseOrphanEgressIPs(ctx, pool); err != nil {
+			return fmt.Errorf("failed to release the orphan egress IPs of SpiderIPPool '%s': %w", pool.Name, err)
+		}
+	}
+
 	if pool.DeletionTimestamp != nil {
 		// remove finalizer to delete the dying IPPool when the IPPool is no longer being used
-		if len(pool.Status.AllocatedIPs) == 0 {
+		if len(pool.Status.AllocatedIPs) == 0 && len(pool.Status.EgressIPs) == 0 {
 			err := ic.removeFinalizer(ctx, pool)
 			if nil != err {
 				if apierrors.IsNotFound(err) {
@@ -642,8 +1323,20 @@ func (ic *IPPoolController) syncHandleAllIPPool(ctx context.Context, pool *spide
 			}
 
 			informerLogger.Sugar().Infof("remove SpiderIPPool '%s' finalizer successfully", pool.Name)
+		} else if pods := blockingPodsOfIPPool(pool); pods != "" {
+			if reported, ok := ic.blockedDeletions.Load(pool.Name); !ok || reported.(string) != pods {
+				ic.blockedDeletions.Store(pool.Name, pods)
+				event.EventRecorder.Eventf(pool, corev1.EventTypeWarning, constant.EventReasonDeleteIPPool,
+					"Waiting for IP addresses to be released by Pods %s", pods)
+			}
 		}
 	} else {
+		if ic.StatusShardSize > 0 || len(pool.Status.Shards) != 0 {
+			if err := pruneOrphanIPPoolShards(ctx, ic.client, pool, time.Now()); err != nil {
+				return err
+			}
+		}
+
 		needUpdate := false
 
 		// initial the original data
@@ -653,18 +1346,33 @@ func (ic *IPPoolController) syncHandleAllIPPool(ctx context.Context, pool *spide
 			informerLogger.Sugar().Infof("initial SpiderIPPool '%s' status AllocatedIPCount to 0", pool.Name)
 		}
 
-		totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+		totalIPCount, err := totalIPCountOfIPPool(pool)
 		if nil != err {
 			return fmt.Errorf("%w: failed to calculate SpiderIPPool '%s' total IP count, error: %v", constant.ErrWrongInput, pool.Name, err)
 		}
 
-		if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount != int64(len(totalIPs)) {
+		if pool.Status.TotalIPCount == nil || *pool.Status.TotalIPCount != totalIPCount {
+			needUpdate = true
+			pool.Status.TotalIPCount = pointer.Int64(totalIPCount)
+		}
+
+		if IsAutoCreatedIPPool(pool) && SetReconcilePaused(pool, IsReconcilePaused(pool.Annotations), constant.ReasonIPPoolPaused) {
 			needUpdate = true
-			pool.Status.TotalIPCount = pointer.Int64(int64(len(totalIPs)))
+			informerLogger.Sugar().Infof("the resizing of SpiderIPPool '%s' is paused: %t", pool.Name, IsReconcilePaused(pool.Annotations))
+		}
+
+		if imminent, eta := ic.isExhaustionImminent(pool); SetExhaustionImminent(pool, imminent, eta) {
+			needUpdate = true
+			informerLogger.Sugar().Infof("SpiderIPPool '%s' is forecast to be exhausted within %s: %t", pool.Name, ic.ExhaustionETAThreshold, imminent)
+		}
+
+		if fragmented, ranges, free := ic.isFragmented(pool); SetFragmented(pool, fragmented, ranges, free) {
+			needUpdate = true
+			informerLogger.Sugar().Infof("SpiderIPPool '%s' is fragmented into %d free ranges: %t", pool.Name, ranges, fragmented)
 		}
 
 		if needUpdate {
-			err = ic.client.Status().Update(ctx, pool)
+			err = ic.updateIPPoolStatus(ctx, pool)
 			if nil != err {
 				return err
 			}
@@ -675,6 +1383,29 @@ func (ic *IPPoolController) syncHandleAllIPPool(ctx context.Context, pool *spide
 	return nil
 }
 
+// updateIPPoolStatus updates the status of the IPPool assembled with its
+// SpiderIPPoolShards.
+func (ic *IPPoolController) updateIPPoolStatus(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) error {
+	return updateIPPoolStatus(ctx, ic.client, ic.client, nil, pool, ic.StatusShardSize)
+}
+
+// isFragmented reports whether the free IP addresses of the auto-created
+// IPPool are split into at least FragmentationRangeThreshold ranges, along
+// with the number of the ranges and the free IP addresses.
+func (ic *IPPoolController) isFragmented(pool *spiderpoolv1.SpiderIPPool) (bool, int, int) {
+	if ic.FragmentationRangeThreshold <= 0 || !IsAutoCreatedIPPool(pool) || IsPrefixDelegationIPPool(pool) {
+		return false, 0, 0
+	}
+
+	ranges, free, err := FreeIPRangeCount(pool)
+	if err != nil {
+		informerLogger.Sugar().Warnf("failed to count the free IP ranges of SpiderIPPool '%s': %v", pool.Name, err)
+		return IsFragmented(pool), 0, 0
+	}
+
+	return ranges >= ic.FragmentationRangeThreshold, ranges, free
+}
+
 // syncSubnetIPPools will enqueue all SpiderSubnet object corresponding IPPools name into workQueue
 func (ic *IPPoolController) syncSubnetIPPools(obj interface{}) {
 	subnet := obj.(*spiderpoolv1.SpiderSubnet)
@@ -693,7 +1424,8 @@ func (ic *IPPoolController) syncSubnetIPPools(obj interface{}) {
 	for _, pool := range ipPools {
 		// If the auto-created IPPool's current IP number is not equal with the desired IP number, we'll try to scale it.
 		// If its allocated IPs are empty, we will check whether the IPPool should be deleted or not.
-		if ShouldScaleIPPool(pool) || len(pool.Status.AllocatedIPs) == 0 {
+		// If it waits for the free IPs of the SpiderSubnet to be expanded, we will expand it.
+		if ShouldScaleIPPool(pool) || len(pool.Status.AllocatedIPs) == 0 || ic.shouldAutoExpandIPPool(pool) {
 			informerLogger.Sugar().Debugf("try to add IPPool %s to resync with SpiderSubnet %s", pool.Name, subnet.Name)
 			ic.enqueueIPPool(pool)
 		}
@@ -726,7 +1458,7 @@ func (ic *IPPoolController) scaleIPPoolWithIPs(ctx context.Context, pool *spider
 	// filter out exclude IPs.
 	currentIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
 	if nil != err {
-		return fmt.Errorf("failed to assemble Total IP addresses: %v", err)
+		return fmt.Errorf("failed to assemble Total IP addresses: %w", err)
 	}
 
 	if len(currentIPs) == desiredIPNum {
@@ -738,7 +1470,7 @@ func (ic *IPPoolController) scaleIPPoolWithIPs(ctx context.Context, pool *spider
 		tmpIPs := append(pool.Spec.IPs, ipRanges...)
 		sortedIPRanges, err := spiderpoolip.MergeIPRanges(*pool.Spec.IPVersion, tmpIPs)
 		if nil != err {
-			return fmt.Errorf("failed to merge IP ranges '%v', error: %v", pool.Spec.IPs, err)
+			return fmt.Errorf("failed to merge IP ranges '%v', error: %w", pool.Spec.IPs, err)
 		}
 
 		log = log.With(zap.String("ScaleUpIP", fmt.Sprintf("add IPs '%v'", ipRanges)))
@@ -747,18 +1479,18 @@ func (ic *IPPoolController) scaleIPPoolWithIPs(ctx context.Context, pool *spider
 	} else {
 		discardedIPs, err := spiderpoolip.ParseIPRanges(*pool.Spec.IPVersion, ipRanges)
 		if nil != err {
-			return fmt.Errorf("failed to parse IP ranges '%v', error: %v", ipRanges, err)
+			return fmt.Errorf("failed to parse IP ranges '%v', error: %w", ipRanges, err)
 		}
 
 		// the original IPPool.Spec.IPs
 		totalIPs, err := spiderpoolip.ParseIPRanges(*pool.Spec.IPVersion, pool.Spec.IPs)
 		if nil != err {
-			return fmt.Errorf("failed to parse IP ranges '%v', error: %v", pool.Spec.IPs, err)
+			return fmt.Errorf("failed to parse IP ranges '%v', error: %w", pool.Spec.IPs, err)
 		}
 
 		sortedIPRanges, err := spiderpoolip.ConvertIPsToIPRanges(*pool.Spec.IPVersion, spiderpoolip.IPsDiffSet(totalIPs, discardedIPs, false))
 		if nil != err {
-			return fmt.Errorf("failed to convert IPs '%v' to IP ranges, error: %v", ipRanges, err)
+			return fmt.Errorf("failed to convert IPs '%v' to IP ranges, error: %w", ipRanges, err)
 		}
 
 		log = log.With(zap.String("ScaleDownIP", fmt.Sprintf("discard IPs '%v'", ipRanges)))
@@ -842,7 +1574,7 @@ func (ic *IPPoolController) generateIPsFromSubnetWhenScaleUpIP(ctx context.Conte
 
 	freeIPs, err := subnetmanagercontrollers.GenSubnetFreeIPs(&subnet)
 	if nil != err {
-		return nil, fmt.Errorf("failed to generate SpiderSubnet '%s' free IPs, error: %v", subnetName, err)
+		return nil, fmt.Errorf("failed to generate SpiderSubnet '%s' free IPs, error: %w", subnetName, err)
 	}
 
 	// filter reserved IPs
@@ -856,17 +1588,45 @@ func (ic *IPPoolController) generateIPsFromSubnetWhenScaleUpIP(ctx context.Conte
 		freeIPs = spiderpoolip.IPsDiffSet(freeIPs, reservedIPs, true)
 	}
 
+	// keep the headroom of the SpiderSubnet
+	minFreeIPs := pointer.Int64Deref(subnet.Spec.MinFreeIPs, 0)
+	if minFreeIPs > 0 && int64(len(freeIPs)-ipNum) < minFreeIPs {
+		return nil, &constant.PoolExhaustedError{
+			Kind:   constant.SpiderSubnetKind,
+			Names:  []string{subnetName},
+			Reason: fmt.Sprintf("required '%d' IPs would leave fewer than 'spec.minFreeIPs' '%d' of the '%d' FreeIPs", ipNum, minFreeIPs, len(freeIPs)),
+		}
+	}
+
 	if len(pool.Spec.ExcludeIPs) != 0 {
 		excludeIPs, err := spiderpoolip.ParseIPRanges(ipVersion, pool.Spec.ExcludeIPs)
 		if nil != err {
-			return nil, fmt.Errorf("failed to parse exclude IP ranges '%v', error: %v", pool.Spec.ExcludeIPs, err)
+			return nil, fmt.Errorf("failed to parse exclude IP ranges '%v', error: %w", pool.Spec.ExcludeIPs, err)
 		}
 		freeIPs = spiderpoolip.IPsDiffSet(freeIPs, excludeIPs, true)
 	}
 
+	// filter the IPs of the blocks reserved for the other applications
+	blockIPs, err := subnetmanagercontrollers.GenSubnetReservedBlockIPs(&subnet, pool.Name)
+	if nil != err {
+		return nil, fmt.Errorf("failed to generate SpiderSubnet '%s' reserved block IPs, error: %w", subnetName, err)
+	}
+	if len(blockIPs) != 0 {
+		freeIPs = spiderpoolip.IPsDiffSet(freeIPs, blockIPs, true)
+	}
+
+	freeIPs, err = ic.preferSubnetBlockIPs(ctx, &subnet, pool, freeIPs, cursor)
+	if nil != err {
+		return nil, err
+	}
+
 	// check the filtered subnet free IP number is enough or not
 	if len(freeIPs) < ipNum {
-		return nil, fmt.Errorf("insufficient subnet FreeIPs, required '%d' but only left '%d'", ipNum, len(freeIPs))
+		return nil, &constant.PoolExhaustedError{
+			Kind:   constant.SpiderSubnetKind,
+			Names:  []string{subnetName},
+			Reason: fmt.Sprintf("insufficient subnet FreeIPs, required '%d' but only left '%d'", ipNum, len(freeIPs)),
+		}
 	}
 
 	allocateIPs := make([]net.IP, 0, ipNum)
@@ -890,3 +1650,60 @@ func (ic *IPPoolController) generateIPsFromSubnetWhenScaleUpIP(ctx context.Conte
 
 	return allocateIPRange, nil
 }
+
+// preferSubnetBlockIPs reserves a contiguous block of the SpiderSubnet for the auto-created IPPool with the annotation
+// "ipam.spidernet.io/subnet-block-size", and sorts the free IPs to make the IPs of the block to be allocated first.
+func (ic *IPPoolController) preferSubnetBlockIPs(ctx context.Context, subnet *spiderpoolv1.SpiderSubnet, pool *spiderpoolv1.SpiderIPPool,
+	freeIPs []net.IP, cursor bool) ([]net.IP, error) {
+	log := logutils.FromContext(ctx)
+
+	prefixLength, err := subnetmanagercontrollers.GetSubnetBlockSize(pool.Annotations)
+	if nil != err {
+		return nil, err
+	}
+	if prefixLength == 0 {
+		return freeIPs, nil
+	}
+
+	block, ok := subnet.Status.ReservedBlocks[pool.Name]
+	if !ok {
+		block, err = subnetmanagercontrollers.SelectSubnetBlock(subnet, pool.Name, prefixLength)
+		if nil != err {
+			return nil, fmt.Errorf("failed to select block '/%d' from SpiderSubnet '%s', error: %w", prefixLength, subnet.Name, err)
+		}
+		if len(block) == 0 {
+			log.Sugar().Warnf("no free block '/%d' left in SpiderSubnet '%s', generate IPs for IPPool '%s' without block", prefixLength, subnet.Name, pool.Name)
+			return freeIPs, nil
+		}
+
+		if subnet.Status.ReservedBlocks == nil {
+			subnet.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{}
+		}
+		subnet.Status.ReservedBlocks[pool.Name] = block
+		if err := ic.client.Status().Update(ctx, subnet); nil != err {
+			return nil, fmt.Errorf("failed to reserve block '%s' of SpiderSubnet '%s' for IPPool '%s': %w", block, subnet.Name, pool.Name, err)
+		}
+		log.Sugar().Infof("reserved block '%s' of SpiderSubnet '%s' for IPPool '%s'", block, subnet.Name, pool.Name)
+	}
+
+	_, blockNet, err := net.ParseCIDR(block)
+	if nil != err {
+		return nil, fmt.Errorf("failed to parse SpiderSubnet '%s' reserved block '%s', error: %w", subnet.Name, block, err)
+	}
+
+	var blockIPs, otherIPs []net.IP
+	for _, ip := range freeIPs {
+		if blockNet.Contains(ip) {
+			blockIPs = append(blockIPs, ip)
+		} else {
+			otherIPs = append(otherIPs, ip)
+		}
+	}
+
+	// the IPs are picked from the head of the free IPs with cursor, otherwise from the tail
+	if cursor {
+		return append(blockIPs, otherIPs...), nil
+	}
+
+	return append(otherIPs, blockIPs...), nil
+}
@@ -0,0 +1,360 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+var _ = Describe("IPPoolController", Label("ippool_informer_test"), func() {
+	Describe("release the orphan egress IPs", func() {
+		const egressAPIVersion = "egressgateway.spidernet.io/v1beta1"
+
+		var ctx context.Context
+		var c client.Client
+		var ic *IPPoolController
+		var poolT *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+
+			scheme := runtime.NewScheme()
+			Expect(spiderpoolv1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).Build()
+			ic = NewIPPoolController(IPPoolControllerConfig{}, c, nil)
+
+			origRecorder := event.EventRecorder
+			event.EventRecorder = record.NewFakeRecorder(event.FakeRecorderBufferSize)
+			DeferCleanup(func() {
+				event.EventRecorder = origRecorder
+			})
+
+			poolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "egress-ippool",
+					Finalizers: []string{constant.SpiderFinalizer},
+				},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.10-172.18.40.11"},
+				},
+				Status: spiderpoolv1.IPPoolStatus{
+					EgressIPs: spiderpoolv1.PoolEgressIPReservations{
+						"172.18.40.10": spiderpoolv1.EgressIPReservation{
+							APIVersion: egressAPIVersion,
+							Kind:       "EgressGateway",
+							Name:       "orphan",
+						},
+					},
+				},
+			}
+		})
+
+		createEgressObject := func(name, uid string) {
+			object := &unstructured.Unstructured{}
+			object.SetAPIVersion(egressAPIVersion)
+			object.SetKind("EgressGateway")
+			object.SetName(name)
+			object.SetUID(apitypes.UID(uid))
+			Expect(c.Create(ctx, object)).To(Succeed())
+		}
+
+		getPool := func() (*spiderpoolv1.SpiderIPPool, error) {
+			var pool spiderpoolv1.SpiderIPPool
+			err := c.Get(ctx, apitypes.NamespacedName{Name: poolT.Name}, &pool)
+
+			return &pool, err
+		}
+
+		It("deletes the IPPool with the IP reserved for the object no longer existed", func() {
+			Expect(c.Create(ctx, poolT)).To(Succeed())
+			Expect(c.Delete(ctx, poolT)).To(Succeed())
+
+			pool, err := getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.DeletionTimestamp).NotTo(BeNil())
+
+			Expect(ic.syncHandleAllIPPool(ctx, pool)).To(Succeed())
+
+			_, err = getPool()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("keeps the terminating IPPool with the IP reserved for the existing object", func() {
+			createEgressObject("orphan", "")
+			Expect(c.Create(ctx, poolT)).To(Succeed())
+			Expect(c.Delete(ctx, poolT)).To(Succeed())
+
+			pool, err := getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ic.syncHandleAllIPPool(ctx, pool)).To(Succeed())
+
+			pool, err = getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Status.EgressIPs).To(HaveKey("172.18.40.10"))
+			Expect(pool.Finalizers).To(ContainElement(constant.SpiderFinalizer))
+		})
+
+		It("releases the IP reserved for the object mismatching the UID", func() {
+			createEgressObject("orphan", "new-uid")
+			reservation := poolT.Status.EgressIPs["172.18.40.10"]
+			reservation.UID = "old-uid"
+			poolT.Status.EgressIPs["172.18.40.10"] = reservation
+			Expect(c.Create(ctx, poolT)).To(Succeed())
+
+			pool, err := getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ic.syncHandleAllIPPool(ctx, pool)).To(Succeed())
+
+			pool, err = getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Status.EgressIPs).To(BeEmpty())
+		})
+
+		It("releases only the IPs reserved for the objects no longer existed", func() {
+			createEgressObject("existing", "existing-uid")
+			poolT.Status.EgressIPs["172.18.40.11"] = spiderpoolv1.EgressIPReservation{
+				APIVersion: egressAPIVersion,
+				Kind:       "EgressGateway",
+				Name:       "existing",
+				UID:        "existing-uid",
+			}
+			Expect(c.Create(ctx, poolT)).To(Succeed())
+
+			pool, err := getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ic.syncHandleAllIPPool(ctx, pool)).To(Succeed())
+
+			pool, err = getPool()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Status.EgressIPs).To(HaveLen(1))
+			Expect(pool.Status.EgressIPs).To(HaveKey("172.18.40.11"))
+		})
+	})
+
+	Describe("prefer the IPs of the reserved block", func() {
+		var ctx context.Context
+		var c client.Client
+		var ic *IPPoolController
+		var subnetT *spiderpoolv1.SpiderSubnet
+		var poolT *spiderpoolv1.SpiderIPPool
+		var freeIPs []net.IP
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+
+			scheme := runtime.NewScheme()
+			Expect(spiderpoolv1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).Build()
+			ic = NewIPPoolController(IPPoolControllerConfig{}, c, nil)
+
+			subnetT = &spiderpoolv1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+				Spec: spiderpoolv1.SubnetSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.1-172.18.40.40"},
+				},
+			}
+			Expect(c.Create(ctx, subnetT)).To(Succeed())
+
+			poolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "auto-pool",
+					Annotations: map[string]string{constant.AnnoSpiderSubnetBlockSize: "/29"},
+				},
+			}
+
+			freeIPs = nil
+			for i := 1; i <= 20; i++ {
+				freeIPs = append(freeIPs, net.IPv4(172, 18, 40, byte(i)).To4())
+			}
+		})
+
+		ipStrings := func(ips []net.IP) []string {
+			var s []string
+			for _, ip := range ips {
+				s = append(s, ip.String())
+			}
+
+			return s
+		}
+
+		It("keeps the free IPs without the block annotation", func() {
+			poolT.Annotations = nil
+
+			ips, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ips).To(Equal(freeIPs))
+		})
+
+		It("fails with the invalid block annotation", func() {
+			poolT.Annotations[constant.AnnoSpiderSubnetBlockSize] = "invalid"
+
+			_, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, true)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("reserves a block and picks its IPs first", func() {
+			subnetT.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{"other": "172.18.40.0/29"}
+
+			ips, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ipStrings(ips[:8])).To(Equal([]string{
+				"172.18.40.8", "172.18.40.9", "172.18.40.10", "172.18.40.11",
+				"172.18.40.12", "172.18.40.13", "172.18.40.14", "172.18.40.15",
+			}))
+			Expect(ips).To(HaveLen(len(freeIPs)))
+
+			var subnet spiderpoolv1.SpiderSubnet
+			Expect(c.Get(ctx, apitypes.NamespacedName{Name: subnetT.Name}, &subnet)).To(Succeed())
+			Expect(subnet.Status.ReservedBlocks).To(HaveKeyWithValue(poolT.Name, "172.18.40.8/29"))
+		})
+
+		It("picks the IPs of the reserved block last from the tail", func() {
+			subnetT.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{poolT.Name: "172.18.40.0/29"}
+
+			ips, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ipStrings(ips[len(ips)-7:])).To(Equal([]string{
+				"172.18.40.1", "172.18.40.2", "172.18.40.3", "172.18.40.4",
+				"172.18.40.5", "172.18.40.6", "172.18.40.7",
+			}))
+		})
+
+		It("keeps the free IPs if there is no block left", func() {
+			subnetT.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{"other": "172.18.40.0/26"}
+
+			ips, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ips).To(Equal(freeIPs))
+		})
+
+		It("fails with the invalid reserved block", func() {
+			subnetT.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{poolT.Name: "invalid"}
+
+			_, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, true)
+			Expect(err).To(MatchError(ContainSubstring("invalid")))
+		})
+
+		It("fails to reserve the block of the SpiderSubnet not found", func() {
+			Expect(c.Delete(ctx, subnetT)).To(Succeed())
+
+			_, err := ic.preferSubnetBlockIPs(ctx, subnetT, poolT, freeIPs, true)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("record the info metric of IPPools", Ordered, func() {
+		var metricHandler http.Handler
+		var ic *IPPoolController
+		var poolT *spiderpoolv1.SpiderIPPool
+
+		BeforeAll(func() {
+			var err error
+			metricHandler, err = metric.InitMetricController(context.TODO(), "spiderpool-controller-test", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metric.InitSpiderpoolControllerMetrics(context.TODO())).To(Succeed())
+		})
+
+		BeforeEach(func() {
+			ic = &IPPoolController{}
+			poolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "info-pool",
+					Labels: map[string]string{
+						constant.LabelIPPoolOwnerSpiderSubnet: "subnet",
+						constant.LabelIPPoolOwnerApplication:  "apps_v1_Deployment_default_app",
+					},
+				},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					Gateway:   pointer.String("172.18.40.1"),
+					Vlan:      pointer.Int64(100),
+					Default:   pointer.Bool(true),
+				},
+			}
+			DeferCleanup(ic.onIPPoolDelete, poolT)
+		})
+
+		scrape := func() string {
+			rr := httptest.NewRecorder()
+			metricHandler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+
+			return rr.Body.String()
+		}
+
+		It("carries the inventory of the IPPool in the labels", func() {
+			recordIPPoolInfo(poolT)
+
+			Expect(scrape()).To(MatchRegexp(`ippool_info\{application="apps_v1_Deployment_default_app",cidr="172\.18\.40\.0/24",default="true",gateway="172\.18\.40\.1",ip_version="4",ippool="info-pool",[^}]*subnet="subnet",vlan="100"\} 1`))
+		})
+
+		It("carries the empty owners and the defaults of the optional fields", func() {
+			poolT.Labels = nil
+			poolT.Spec.Gateway = nil
+			poolT.Spec.Vlan = nil
+			poolT.Spec.Default = nil
+			recordIPPoolInfo(poolT)
+
+			Expect(scrape()).To(MatchRegexp(`ippool_info\{application="",cidr="172\.18\.40\.0/24",default="false",gateway="",ip_version="4",ippool="info-pool",[^}]*subnet="",vlan="0"\} 1`))
+		})
+
+		It("replaces the series once the IPPool changes", func() {
+			recordIPPoolInfo(poolT)
+			poolT.Spec.Vlan = pointer.Int64(200)
+			recordIPPoolInfo(poolT)
+
+			output := scrape()
+			Expect(output).To(MatchRegexp(`ippool_info\{[^}]*ippool="info-pool",[^}]*vlan="200"\} 1`))
+			Expect(output).NotTo(MatchRegexp(`ippool_info\{[^}]*vlan="100"`))
+		})
+
+		It("deletes the series of the deleted IPPool", func() {
+			recordIPPoolInfo(poolT)
+
+			ic.onIPPoolDelete(poolT)
+			Expect(scrape()).NotTo(MatchRegexp(`ippool_info\{[^}]*ippool="info-pool"`))
+		})
+
+		It("deletes the series of the IPPool in a tombstone", func() {
+			recordIPPoolInfo(poolT)
+
+			ic.onIPPoolDelete(cache.DeletedFinalStateUnknown{Key: poolT.Name, Obj: poolT})
+			Expect(scrape()).NotTo(MatchRegexp(`ippool_info\{[^}]*ippool="info-pool"`))
+		})
+
+		It("ignores the unknown objects in a tombstone", func() {
+			recordIPPoolInfo(poolT)
+
+			ic.onIPPoolDelete(cache.DeletedFinalStateUnknown{Key: poolT.Name, Obj: &spiderpoolv1.SpiderSubnet{}})
+			ic.onIPPoolDelete("info-pool")
+			Expect(scrape()).To(MatchRegexp(`ippool_info\{[^}]*ippool="info-pool"`))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"sort"
 	"time"
 
 	"go.uber.org/zap"
@@ -17,54 +18,144 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/allocationpolicy"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/pager"
 )
 
 type IPPoolManager interface {
-	GetIPPoolByName(ctx context.Context, poolName string) (*spiderpoolv1.SpiderIPPool, error)
+	GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv1.SpiderIPPool, error)
 	ListIPPools(ctx context.Context, opts ...client.ListOption) (*spiderpoolv1.SpiderIPPoolList, error)
+	IterateIPPools(ctx context.Context, fn func(pool *spiderpoolv1.SpiderIPPool) error, opts ...client.ListOption) error
 	AllocateIP(ctx context.Context, poolName, containerID, nic string, pod *corev1.Pod, podController types.PodTopController) (*models.IPConfig, error)
 	ReleaseIP(ctx context.Context, poolName string, ipAndCIDs []types.IPAndCID) error
 	UpdateAllocatedIPs(ctx context.Context, poolName string, ipAndCIDs []types.IPAndCID) error
 	DeleteAllIPPools(ctx context.Context, pool *spiderpoolv1.SpiderIPPool, opts ...client.DeleteAllOfOption) error
 	UpdateDesiredIPNumber(ctx context.Context, pool *spiderpoolv1.SpiderIPPool, ipNum int) error
+	ReserveEgressIP(ctx context.Context, poolName string, egress spiderpoolv1.EgressIPReservation) (string, error)
+	ReleaseEgressIP(ctx context.Context, poolName string, egress spiderpoolv1.EgressIPReservation) error
+	ListDefaultIPPools(ctx context.Context, namespace string) ([]spiderpoolv1.SpiderIPPool, error)
 }
 
+// defaultNamespaceIndex is the field index of the default IPPools by the
+// Namespaces which they are the default of, the IPPools which are the default
+// of the whole cluster are indexed by clusterDefaultIndexValue.
+const (
+	defaultNamespaceIndex    = "spec.namespaceDefault"
+	clusterDefaultIndexValue = "*"
+)
+
 type ipPoolManager struct {
 	config     IPPoolManagerConfig
 	client     client.Client
+	apiReader  client.Reader
 	rIPManager reservedipmanager.ReservedIPManager
+	options    manageroption.Options
 }
 
-func NewIPPoolManager(config IPPoolManagerConfig, client client.Client, rIPManager reservedipmanager.ReservedIPManager) (IPPoolManager, error) {
+func NewIPPoolManager(config IPPoolManagerConfig, client client.Client, apiReader client.Reader, rIPManager reservedipmanager.ReservedIPManager, opts ...manageroption.Option) (IPPoolManager, error) {
 	if client == nil {
 		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
 	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
 	if rIPManager == nil {
 		return nil, fmt.Errorf("reserved-IP manager %w", constant.ErrMissingRequiredParam)
 	}
 
+	options := manageroption.New(opts...)
+	if options.Indexer != nil {
+		if err := options.Indexer.IndexField(context.Background(), &spiderpoolv1.SpiderIPPool{}, defaultNamespaceIndex, indexByDefaultNamespace); err != nil {
+			return nil, fmt.Errorf("failed to index SpiderIPPools by %s: %w", defaultNamespaceIndex, err)
+		}
+	}
+
 	return &ipPoolManager{
 		config:     setDefaultsForIPPoolManagerConfig(config),
 		client:     client,
+		apiReader:  apiReader,
 		rIPManager: rIPManager,
+		options:    options,
 	}, nil
 }
 
-func (im *ipPoolManager) GetIPPoolByName(ctx context.Context, poolName string) (*spiderpoolv1.SpiderIPPool, error) {
+func indexByDefaultNamespace(obj client.Object) []string {
+	ipPool := obj.(*spiderpoolv1.SpiderIPPool)
+	if !IsDefaultIPPool(ipPool) {
+		return nil
+	}
+	if len(ipPool.Spec.NamespaceDefault) == 0 {
+		return []string{clusterDefaultIndexValue}
+	}
+
+	return ipPool.Spec.NamespaceDefault
+}
+
+// GetIPPoolByName gets the IPPool from the cache if cached and the cache is
+// configured, otherwise from the API server. The IPPool not found in the
+// cache is got from the API server again, as the cache may lag behind.
+func (im *ipPoolManager) GetIPPoolByName(ctx context.Context, poolName string, cached bool) (*spiderpoolv1.SpiderIPPool, error) {
+	fromCache := cached == constant.UseCache && im.options.Cache != nil
+	reader := im.apiReader
+	if fromCache {
+		reader = im.options.Cache
+	}
+
 	var ipPool spiderpoolv1.SpiderIPPool
-	if err := im.client.Get(ctx, apitypes.NamespacedName{Name: poolName}, &ipPool); err != nil {
+	err := reader.Get(ctx, apitypes.NamespacedName{Name: poolName}, &ipPool)
+	im.options.Metrics.RecordRead(ctx, constant.SpiderIPPoolKind, fromCache)
+	if fromCache && apierrors.IsNotFound(err) {
+		err = im.apiReader.Get(ctx, apitypes.NamespacedName{Name: poolName}, &ipPool)
+		im.options.Metrics.RecordRead(ctx, constant.SpiderIPPoolKind, false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := im.assembleShards(ctx, &ipPool, fromCache); err != nil {
 		return nil, err
 	}
 
 	return &ipPool, nil
 }
 
+// assembleShards merges the IP allocation details held by the
+// SpiderIPPoolShards of the IPPool into its status, the shards are read from
+// the cache if cached and the cache is configured.
+func (im *ipPoolManager) assembleShards(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool, cached bool) error {
+	if cached && im.options.Cache != nil {
+		return AssembleIPPoolShards(ctx, im.options.Cache, im.apiReader, ipPool)
+	}
+
+	return AssembleIPPoolShards(ctx, im.apiReader, nil, ipPool)
+}
+
+// updateIPPoolStatus updates the status of the IPPool assembled with its
+// SpiderIPPoolShards, with the IP allocation details split into the shards
+// once they exceed StatusShardSize.
+func (im *ipPoolManager) updateIPPoolStatus(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool) error {
+	reader := im.apiReader
+	if im.options.Cache != nil {
+		// The shards are immutable, the cached ones are always up to date.
+		reader = im.options.Cache
+	}
+
+	return updateIPPoolStatus(ctx, im.client, reader, im.apiReader, ipPool, im.config.StatusShardSize)
+}
+
+// ListIPPools lists the IPPools as they are stored, the IP allocation
+// details held by their SpiderIPPoolShards are not assembled, so that they
+// could be updated as they are.
 func (im *ipPoolManager) ListIPPools(ctx context.Context, opts ...client.ListOption) (*spiderpoolv1.SpiderIPPoolList, error) {
 	var ipPoolList spiderpoolv1.SpiderIPPoolList
 	if err := im.client.List(ctx, &ipPoolList, opts...); err != nil {
@@ -74,26 +165,126 @@ func (im *ipPoolManager) ListIPPools(ctx context.Context, opts ...client.ListOpt
 	return &ipPoolList, nil
 }
 
+// ListDefaultIPPools lists the IPPools which are the default of namespace, or
+// of the whole cluster if namespace is empty, sorted by their names. The
+// terminating IPPools are skipped.
+func (im *ipPoolManager) ListDefaultIPPools(ctx context.Context, namespace string) ([]spiderpoolv1.SpiderIPPool, error) {
+	value := namespace
+	if value == "" {
+		value = clusterDefaultIndexValue
+	}
+
+	var opts []client.ListOption
+	if im.options.Indexer != nil {
+		opts = append(opts, client.MatchingFields{defaultNamespaceIndex: value})
+	}
+	ipPoolList, err := im.ListIPPools(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ipPools []spiderpoolv1.SpiderIPPool
+	for _, pool := range ipPoolList.Items {
+		if pool.DeletionTimestamp == nil && IsDefaultIPPoolOf(&pool, namespace) {
+			ipPools = append(ipPools, pool)
+		}
+	}
+	sort.Slice(ipPools, func(i, j int) bool {
+		return ipPools[i].Name < ipPools[j].Name
+	})
+
+	return ipPools, nil
+}
+
+// IterateIPPools lists the IPPools page by page and calls fn with each of
+// them assembled with their SpiderIPPoolShards, until all of them are
+// iterated or fn returns an error.
+func (im *ipPoolManager) IterateIPPools(ctx context.Context, fn func(pool *spiderpoolv1.SpiderIPPool) error, opts ...client.ListOption) error {
+	return pager.ListPages(
+		ctx,
+		im.client,
+		pager.Config{PageSize: im.config.ListPageSize, PageInterval: im.config.ListPageInterval},
+		func() client.ObjectList { return &spiderpoolv1.SpiderIPPoolList{} },
+		func(list client.ObjectList) error {
+			poolList := list.(*spiderpoolv1.SpiderIPPoolList)
+			for i := range poolList.Items {
+				if err := im.assembleShards(ctx, &poolList.Items[i], constant.UseCache); err != nil {
+					return err
+				}
+				if err := fn(&poolList.Items[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		opts...,
+	)
+}
+
 func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, containerID, nic string, pod *corev1.Pod, podController types.PodTopController) (*models.IPConfig, error) {
 	logger := logutils.FromContext(ctx)
 
+	if err := configmanager.CheckFrozen(im.options.Freeze); err != nil {
+		return nil, err
+	}
+
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	var ipConfig *models.IPConfig
-	for i := 0; i <= im.config.MaxConflictRetries; i++ {
+	for i := 0; i <= im.options.RetryPolicy.MaxConflictRetries; i++ {
+		// Don't write the IPPool anymore once the allocation is given up.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		logger := logger.With(zap.Int("times", i+1))
 		logger.Sugar().Debugf("Re-get IPPool %s for IP allocation", poolName)
 
-		ipPool, err := im.GetIPPoolByName(ctx, poolName)
+		// Only the first attempt reads the cache, the stale IPPool fails the
+		// update with conflict and the retries read the API server.
+		cached := i == 0
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, cached)
 		if err != nil {
 			return nil, err
 		}
 
-		logger.Debug("Generate a random IP address")
-		allocatedIP, err := im.genRandomIP(ctx, ipPool)
+		if err := checkIPPoolMinFreeIPs(ipPool, pod); err != nil {
+			if cached && i < im.options.RetryPolicy.MaxConflictRetries {
+				logger.Sugar().Debugf("Failed to check the headroom of the cached IPPool, retry with the latest one: %v", err)
+				continue
+			}
+			return nil, err
+		}
+
+		var allocatedIP net.IP
+		var delegatedPrefix *net.IPNet
+		if IsPrefixDelegationIPPool(ipPool) {
+			logger.Sugar().Debugf("Delegate a prefix of length %d", *ipPool.Spec.DelegatedPrefixLength)
+			allocatedIP, delegatedPrefix, err = im.genDelegatedPrefix(ctx, ipPool)
+		} else if ordinal, ok := statefulSetOrdinalOfPod(ipPool, pod, podController); ok {
+			logger.Sugar().Debugf("Generate the IP address of StatefulSet ordinal %d", ordinal)
+			allocatedIP, err = im.genOrdinalIP(ctx, ipPool, ordinal)
+		} else if mode := ipv6AssignmentModeOf(ipPool); mode != constant.IPv6AssignmentModeRandom {
+			logger.Sugar().Debugf("Generate an IPv6 address in mode %s", mode)
+			allocatedIP, err = im.genIPv6IP(ctx, ipPool, mode, nic, pod)
+		} else {
+			logger.Debug("Generate a random IP address")
+			allocatedIP, err = im.genRandomIP(ctx, ipPool)
+		}
 		if err != nil {
+			if cached && i < im.options.RetryPolicy.MaxConflictRetries {
+				logger.Sugar().Debugf("Failed to generate IP address with the cached IPPool, retry with the latest one: %v", err)
+				continue
+			}
 			return nil, err
 		}
 
+		if im.options.AllocationPolicy != nil {
+			allocatedIP, err = im.reviewIP(ctx, ipPool, allocatedIP, nic, pod, podController)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if ipPool.Status.AllocatedIPs == nil {
 			ipPool.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{}
 		}
@@ -110,6 +301,12 @@ func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, containerID,
 
 		ip := allocatedIP.String()
 		ipPool.Status.AllocatedIPs[ip] = allocation
+		if delegatedPrefix != nil {
+			if ipPool.Status.DelegatedPrefixes == nil {
+				ipPool.Status.DelegatedPrefixes = spiderpoolv1.PoolPrefixDelegations{}
+			}
+			ipPool.Status.DelegatedPrefixes[delegatedPrefix.String()] = ip
+		}
 
 		if ipPool.Status.AllocatedIPCount == nil {
 			ipPool.Status.AllocatedIPCount = new(int64)
@@ -117,69 +314,314 @@ func (im *ipPoolManager) AllocateIP(ctx context.Context, poolName, containerID,
 
 		*ipPool.Status.AllocatedIPCount++
 		if *ipPool.Status.AllocatedIPCount > int64(*im.config.MaxAllocatedIPs) {
-			return nil, fmt.Errorf("%w, threshold of IP allocations(<=%d) for IPPool %s exceeded", constant.ErrIPUsedOut, im.config.MaxAllocatedIPs, ipPool.Name)
+			return nil, &constant.PoolExhaustedError{
+				Kind:   constant.SpiderIPPoolKind,
+				Names:  []string{ipPool.Name},
+				Reason: fmt.Sprintf("threshold of IP allocations(<=%d) exceeded", im.config.MaxAllocatedIPs),
+			}
 		}
 
 		logger.Sugar().Debugf("Try to update the allocation status of IPPool %s with random IP %s", ipPool.Name, ip)
-		if err := im.client.Status().Update(ctx, ipPool); err != nil {
+		if err := im.updateIPPoolStatus(ctx, ipPool); err != nil {
 			if !apierrors.IsConflict(err) {
 				return nil, err
 			}
-			if i == im.config.MaxConflictRetries {
-				return nil, fmt.Errorf("%w (%d times), failed to allocate IP from IPPool %s", constant.ErrRetriesExhausted, im.config.MaxConflictRetries, ipPool.Name)
+			im.options.Metrics.RecordConflict(ctx, constant.SpiderIPPoolKind)
+			if i == im.options.RetryPolicy.MaxConflictRetries {
+				return nil, fmt.Errorf("%w (%d times), failed to allocate IP from IPPool %s", constant.ErrRetriesExhausted, im.options.RetryPolicy.MaxConflictRetries, ipPool.Name)
 			}
 
-			interval := time.Duration(r.Intn(1<<(i+1))) * im.config.ConflictRetryUnitTime
+			interval := time.Duration(r.Intn(1<<(i+1))) * im.options.RetryPolicy.ConflictRetryUnitTime
 			logger.Sugar().Debugf("An conflict occurred when updating the status of the IPPool %s, it will be retried in %s", ipPool.Name, interval)
 
-			time.Sleep(interval)
+			im.options.Clock.Sleep(interval)
 			continue
 		}
 
 		ipConfig = genResIPConfig(allocatedIP, nic, ipPool)
+		if delegatedPrefix != nil {
+			ipConfig.DelegatedPrefix = delegatedPrefix.String()
+		}
+		im.warnSoftReservedIP(ctx, ipPool, allocatedIP, pod)
 		break
 	}
 
 	return ipConfig, nil
 }
 
+// warnSoftReservedIP warns about the allocation of the IP address reserved
+// by a SpiderReservedIP in mode 'soft', with the events on the Pod and the
+// SpiderReservedIP and a metric. It never fails the allocation.
+func (im *ipPoolManager) warnSoftReservedIP(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool, ip net.IP, pod *corev1.Pod) {
+	logger := logutils.FromContext(ctx)
+
+	rIP, err := im.rIPManager.MatchSoftReservedIP(ctx, *ipPool.Spec.IPVersion, ip)
+	if err != nil {
+		logger.Sugar().Warnf("Failed to check whether IP address %s is reserved in mode soft: %v", ip, err)
+		return
+	}
+	if rIP == nil {
+		return
+	}
+
+	logger.Sugar().Warnf("Allocate IP address %s of IPPool %s reserved by SpiderReservedIP %s in mode soft", ip, ipPool.Name, rIP.Name)
+	metric.RecordSoftReservedIPAllocation(ctx, rIP.Name, ipPool.Name)
+	event.EventRecorder.Eventf(pod, corev1.EventTypeWarning, constant.EventReasonSoftReservedIP,
+		"IP address %s of IPPool %s is reserved by SpiderReservedIP %s in mode soft", ip, ipPool.Name, rIP.Name)
+	event.EventRecorder.Eventf(rIP, corev1.EventTypeWarning, constant.EventReasonSoftReservedIP,
+		"IP address %s is allocated to Pod %s/%s from IPPool %s", ip, pod.Namespace, pod.Name, ipPool.Name)
+}
+
+// reviewIP asks the allocation policy whether the IP address could be
+// allocated to the Pod. It returns the IP address suggested by the policy
+// instead if the IP address is denied, as long as the suggested one is
+// available in the IPPool.
+func (im *ipPoolManager) reviewIP(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool, ip net.IP, nic string, pod *corev1.Pod, podController types.PodTopController) (net.IP, error) {
+	logger := logutils.FromContext(ctx)
+
+	decision, err := im.options.AllocationPolicy.Review(ctx, &allocationpolicy.Review{
+		Namespace:           pod.Namespace,
+		Pod:                 pod.Name,
+		PodUID:              string(pod.UID),
+		Node:                pod.Spec.NodeName,
+		OwnerControllerType: podController.Kind,
+		OwnerControllerName: podController.Name,
+		Interface:           nic,
+		IPPool:              ipPool.Name,
+		IPVersion:           *ipPool.Spec.IPVersion,
+		IP:                  ip.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decision.Allowed {
+		return ip, nil
+	}
+
+	if decision.SuggestedIP == "" {
+		return nil, fmt.Errorf("%w, IP address %s of IPPool %s: %s", constant.ErrPolicyDenied, ip, ipPool.Name, decision.Reason)
+	}
+
+	suggestedIP := net.ParseIP(decision.SuggestedIP)
+	if suggestedIP == nil {
+		return nil, fmt.Errorf("%w, IP address %s of IPPool %s, and the suggested IP address '%s' is invalid", constant.ErrPolicyDenied, ip, ipPool.Name, decision.SuggestedIP)
+	}
+
+	availableIPs, err := im.availableIPs(ctx, ipPool)
+	if err != nil {
+		return nil, err
+	}
+	for _, availableIP := range availableIPs {
+		if availableIP.Equal(suggestedIP) {
+			logger.Sugar().Infof("Allocation policy denies IP address %s of IPPool %s, use the suggested IP address %s: %s", ip, ipPool.Name, suggestedIP, decision.Reason)
+			return suggestedIP, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w, IP address %s of IPPool %s, and the suggested IP address %s is unavailable", constant.ErrPolicyDenied, ip, ipPool.Name, suggestedIP)
+}
+
 func (im *ipPoolManager) genRandomIP(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool) (net.IP, error) {
+	availableIPs, err := im.availableIPs(ctx, ipPool)
+	if err != nil {
+		return nil, err
+	}
+	if len(availableIPs) == 0 {
+		return nil, &constant.PoolExhaustedError{Kind: constant.SpiderIPPoolKind, Names: []string{ipPool.Name}}
+	}
+
+	// The head of the IPPool is kept for the ordinals of StatefulSet, so other
+	// Pods are allocated from the tail to avoid taking their IP addresses.
+	if IsStatefulSetOrdinalIPPool(ipPool) {
+		return availableIPs[len(availableIPs)-1], nil
+	}
+
+	if im.config.EnableGapFilling && IsFragmented(ipPool) {
+		return gapFillingIP(spiderpoolip.IPsDiffSet(availableIPs, nil, true)), nil
+	}
+
+	return availableIPs[0], nil
+}
+
+// genIPv6IP generates the IPv6 address in the assignment mode of the
+// IPPool. The mode stable-privacy falls back to sequential if the stable
+// IP addresses of the Pod are all unavailable.
+func (im *ipPoolManager) genIPv6IP(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool, mode, nic string, pod *corev1.Pod) (net.IP, error) {
+	availableIPs, err := im.availableIPs(ctx, ipPool)
+	if err != nil {
+		return nil, err
+	}
+	if len(availableIPs) == 0 {
+		return nil, &constant.PoolExhaustedError{Kind: constant.SpiderIPPoolKind, Names: []string{ipPool.Name}}
+	}
+	availableIPs = spiderpoolip.IPsDiffSet(availableIPs, nil, true)
+
+	switch mode {
+	case constant.IPv6AssignmentModeEUI64:
+		mac, err := macOfPodNIC(pod, nic)
+		if err != nil {
+			return nil, err
+		}
+		ip, err := spiderpoolip.EUI64IP(ipPool.Spec.Subnet, mac)
+		if err != nil {
+			return nil, err
+		}
+		if !containsIP(availableIPs, ip) {
+			return nil, fmt.Errorf("%w, EUI-64 IP address %s of MAC %s is unavailable in IPPool %s", constant.ErrIPConflict, ip, mac, ipPool.Name)
+		}
+		return ip, nil
+	case constant.IPv6AssignmentModeStablePrivacy:
+		totalIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+		if err != nil {
+			return nil, err
+		}
+		totalIPs = spiderpoolip.IPsDiffSet(totalIPs, nil, true)
+
+		key := pod.Namespace + "/" + pod.Name + "/" + nic
+		for counter := 0; counter < maxStableHashAttempts; counter++ {
+			if ip := spiderpoolip.StableHashIP(totalIPs, key, counter); containsIP(availableIPs, ip) {
+				return ip, nil
+			}
+		}
+		return availableIPs[0], nil
+	default:
+		return availableIPs[0], nil
+	}
+}
+
+// availableIPs returns the IP addresses of the IPPool which are neither
+// reserved nor used, in ascending order if the IPPool is for the ordinals of
+// StatefulSet. The IPPool in SLAAC coexistence mode leaves the addresses in
+// the modified EUI-64 format to SLAAC.
+func (im *ipPoolManager) availableIPs(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool) ([]net.IP, error) {
 	reservedIPs, err := im.rIPManager.AssembleReservedIPs(ctx, *ipPool.Spec.IPVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	var used []string
-	for ip := range ipPool.Status.AllocatedIPs {
-		used = append(used, ip)
+	usedIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, usedIPsOfIPPool(ipPool))
+	if err != nil {
+		return nil, err
 	}
-	usedIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, used)
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
 	if err != nil {
 		return nil, err
 	}
 
+	availableIPs := spiderpoolip.IPsDiffSet(totalIPs, append(reservedIPs, usedIPs...), IsStatefulSetOrdinalIPPool(ipPool))
+	if IsSLAACCoexistenceIPPool(ipPool) {
+		staticIPs := make([]net.IP, 0, len(availableIPs))
+		for _, ip := range availableIPs {
+			if !spiderpoolip.IsEUI64IP(ip) {
+				staticIPs = append(staticIPs, ip)
+			}
+		}
+		availableIPs = staticIPs
+	}
+
+	return availableIPs, nil
+}
+
+// genDelegatedPrefix returns the lowest sub-prefix of the subnet which is
+// neither delegated nor contains any excluded, reserved or used IP address,
+// along with the first IP address of it for the Pod interface.
+func (im *ipPoolManager) genDelegatedPrefix(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool) (net.IP, *net.IPNet, error) {
+	prefixes, err := spiderpoolip.SplitCIDR(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, int(*ipPool.Spec.DelegatedPrefixLength))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reservedIPs, err := im.rIPManager.AssembleReservedIPs(ctx, *ipPool.Spec.IPVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	excludeIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, ipPool.Spec.ExcludeIPs)
+	if err != nil {
+		return nil, nil, err
+	}
+	usedIPs, err := spiderpoolip.ParseIPRanges(*ipPool.Spec.IPVersion, usedIPsOfIPPool(ipPool))
+	if err != nil {
+		return nil, nil, err
+	}
+	unavailableIPs := append(append(reservedIPs, excludeIPs...), usedIPs...)
+
+	// The delegations whose IP addresses have been released are stale.
+	delegated := map[string]bool{}
+	for prefix, ip := range ipPool.Status.DelegatedPrefixes {
+		if _, ok := ipPool.Status.AllocatedIPs[ip]; ok {
+			delegated[prefix] = true
+		}
+	}
+
+Prefixes:
+	for _, prefix := range prefixes {
+		if delegated[prefix.String()] {
+			continue
+		}
+		for _, ip := range unavailableIPs {
+			if prefix.Contains(ip) {
+				continue Prefixes
+			}
+		}
+
+		return spiderpoolip.NextIP(prefix.IP), prefix, nil
+	}
+
+	return nil, nil, &constant.PoolExhaustedError{
+		Kind:   constant.SpiderIPPoolKind,
+		Names:  []string{ipPool.Name},
+		Reason: fmt.Sprintf("no prefix of length %d available", *ipPool.Spec.DelegatedPrefixLength),
+	}
+}
+
+// genOrdinalIP returns the nth IP address of the IPPool for the StatefulSet
+// Pod with ordinal n.
+func (im *ipPoolManager) genOrdinalIP(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool, ordinal int) (net.IP, error) {
 	totalIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
 	if err != nil {
 		return nil, err
 	}
 
-	availableIPs := spiderpoolip.IPsDiffSet(totalIPs, append(reservedIPs, usedIPs...), false)
-	if len(availableIPs) == 0 {
-		return nil, constant.ErrIPUsedOut
+	totalIPs = spiderpoolip.IPsDiffSet(totalIPs, nil, true)
+	if ordinal >= len(totalIPs) {
+		return nil, &constant.PoolExhaustedError{
+			Kind:   constant.SpiderIPPoolKind,
+			Names:  []string{ipPool.Name},
+			Reason: fmt.Sprintf("StatefulSet ordinal %d exceeds the %d IP addresses", ordinal, len(totalIPs)),
+		}
 	}
+	ip := totalIPs[ordinal]
 
-	return availableIPs[0], nil
+	reservedIPs, err := im.rIPManager.AssembleReservedIPs(ctx, *ipPool.Spec.IPVersion)
+	if err != nil {
+		return nil, err
+	}
+	for _, reservedIP := range reservedIPs {
+		if reservedIP.Equal(ip) {
+			return nil, fmt.Errorf("%w, IP address %s of StatefulSet ordinal %d is reserved", constant.ErrIPConflict, ip, ordinal)
+		}
+	}
+
+	if allocation, ok := ipPool.Status.AllocatedIPs[ip.String()]; ok {
+		return nil, fmt.Errorf("%w, IP address %s of StatefulSet ordinal %d is allocated to Pod %s/%s", constant.ErrIPConflict, ip, ordinal, allocation.Namespace, allocation.Pod)
+	}
+	if reservation, ok := ipPool.Status.EgressIPs[ip.String()]; ok {
+		return nil, fmt.Errorf("%w, IP address %s of StatefulSet ordinal %d is reserved for %s %s", constant.ErrIPConflict, ip, ordinal, reservation.Kind, reservation.Name)
+	}
+
+	return ip, nil
 }
 
 func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndCIDs []types.IPAndCID) error {
 	logger := logutils.FromContext(ctx)
 
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for i := 0; i <= im.config.MaxConflictRetries; i++ {
+	for i := 0; i <= im.options.RetryPolicy.MaxConflictRetries; i++ {
 		logger := logger.With(zap.Int("times", i+1))
 		logger.Sugar().Debugf("Re-get IPPool %s for IP release", poolName)
 
-		ipPool, err := im.GetIPPoolByName(ctx, poolName)
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
 		if err != nil {
 			return err
 		}
@@ -197,6 +639,11 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndCI
 				if record.ContainerID == cur.ContainerID {
 					delete(ipPool.Status.AllocatedIPs, cur.IP)
 					*ipPool.Status.AllocatedIPCount--
+					for prefix, ip := range ipPool.Status.DelegatedPrefixes {
+						if ip == cur.IP {
+							delete(ipPool.Status.DelegatedPrefixes, prefix)
+						}
+					}
 					release = true
 				}
 			}
@@ -207,18 +654,19 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndCI
 		}
 
 		logger.Sugar().Debugf("Try to clean the allocation status of IPPool %s with IP addresses %+v", ipPool.Name, ipAndCIDs)
-		if err := im.client.Status().Update(ctx, ipPool); err != nil {
+		if err := im.updateIPPoolStatus(ctx, ipPool); err != nil {
 			if !apierrors.IsConflict(err) {
 				return err
 			}
-			if i == im.config.MaxConflictRetries {
-				return fmt.Errorf("%w (%d times), failed to release IP addresses %+v from IPPool %s", constant.ErrRetriesExhausted, im.config.MaxConflictRetries, ipAndCIDs, poolName)
+			im.options.Metrics.RecordConflict(ctx, constant.SpiderIPPoolKind)
+			if i == im.options.RetryPolicy.MaxConflictRetries {
+				return fmt.Errorf("%w (%d times), failed to release IP addresses %+v from IPPool %s", constant.ErrRetriesExhausted, im.options.RetryPolicy.MaxConflictRetries, ipAndCIDs, poolName)
 			}
 
-			interval := time.Duration(r.Intn(1<<(i+1))) * im.config.ConflictRetryUnitTime
+			interval := time.Duration(r.Intn(1<<(i+1))) * im.options.RetryPolicy.ConflictRetryUnitTime
 			logger.Sugar().Debugf("An conflict occurred when releasing form the IPPool %s, it will be retried in %s", ipPool.Name, interval)
 
-			time.Sleep(interval)
+			im.options.Clock.Sleep(interval)
 			continue
 		}
 		break
@@ -229,8 +677,8 @@ func (im *ipPoolManager) ReleaseIP(ctx context.Context, poolName string, ipAndCI
 
 func (im *ipPoolManager) UpdateAllocatedIPs(ctx context.Context, poolName string, ipAndCIDs []types.IPAndCID) error {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	for i := 0; i <= im.config.MaxConflictRetries; i++ {
-		ipPool, err := im.GetIPPoolByName(ctx, poolName)
+	for i := 0; i <= im.options.RetryPolicy.MaxConflictRetries; i++ {
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
 		if err != nil {
 			return err
 		}
@@ -252,15 +700,16 @@ func (im *ipPoolManager) UpdateAllocatedIPs(ctx context.Context, poolName string
 			return nil
 		}
 
-		if err := im.client.Status().Update(ctx, ipPool); err != nil {
+		if err := im.updateIPPoolStatus(ctx, ipPool); err != nil {
 			if !apierrors.IsConflict(err) {
 				return err
 			}
-			if i == im.config.MaxConflictRetries {
-				return fmt.Errorf("%w (%d times), failed to re-allocate the IP addresses %+v from IPPool %s", constant.ErrRetriesExhausted, im.config.MaxConflictRetries, ipAndCIDs, poolName)
+			im.options.Metrics.RecordConflict(ctx, constant.SpiderIPPoolKind)
+			if i == im.options.RetryPolicy.MaxConflictRetries {
+				return fmt.Errorf("%w (%d times), failed to re-allocate the IP addresses %+v from IPPool %s", constant.ErrRetriesExhausted, im.options.RetryPolicy.MaxConflictRetries, ipAndCIDs, poolName)
 			}
 
-			time.Sleep(time.Duration(r.Intn(1<<(i+1))) * im.config.ConflictRetryUnitTime)
+			im.options.Clock.Sleep(time.Duration(r.Intn(1<<(i+1))) * im.options.RetryPolicy.ConflictRetryUnitTime)
 			continue
 		}
 		break
@@ -276,7 +725,7 @@ func (im *ipPoolManager) CreateIPPool(ctx context.Context, pool *spiderpoolv1.Sp
 			return nil
 		}
 
-		return fmt.Errorf("failed to create IPPool '%s', error: %v", pool.Name, err)
+		return fmt.Errorf("failed to create IPPool '%s', error: %w", pool.Name, err)
 	}
 
 	return nil
@@ -303,7 +752,127 @@ func (im *ipPoolManager) UpdateDesiredIPNumber(ctx context.Context, pool *spider
 	*pool.Status.AutoDesiredIPCount = int64(ipNum)
 	err := im.client.Status().Update(ctx, pool)
 	if nil != err {
-		return fmt.Errorf("failed to update IPPool '%s' auto desired IP count to %d : %v", pool.Name, ipNum, err)
+		return fmt.Errorf("failed to update IPPool '%s' auto desired IP count to %d : %w", pool.Name, ipNum, err)
+	}
+
+	return nil
+}
+
+// ReserveEgressIP reserves an IP address of the IPPool for the given egress
+// gateway object, the reserved IP address will no longer be allocated to any
+// Pod. If the egress gateway object has already held an IP address of the
+// IPPool, the IP address will be returned directly.
+func (im *ipPoolManager) ReserveEgressIP(ctx context.Context, poolName string, egress spiderpoolv1.EgressIPReservation) (string, error) {
+	logger := logutils.FromContext(ctx)
+
+	if err := configmanager.CheckFrozen(im.options.Freeze); err != nil {
+		return "", err
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var reservedIP string
+	for i := 0; i <= im.options.RetryPolicy.MaxConflictRetries; i++ {
+		logger := logger.With(zap.Int("times", i+1))
+		logger.Sugar().Debugf("Re-get IPPool %s for egress IP reservation", poolName)
+
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			return "", err
+		}
+
+		if ipPool.DeletionTimestamp != nil {
+			return "", fmt.Errorf("%w, terminating IPPool %s", constant.ErrWrongInput, poolName)
+		}
+
+		for ip, reservation := range ipPool.Status.EgressIPs {
+			if reservation == egress {
+				logger.Sugar().Debugf("IP %s of IPPool %s has already been reserved for %s %s/%s", ip, poolName, egress.Kind, egress.Namespace, egress.Name)
+				return ip, nil
+			}
+		}
+
+		ip, err := im.genRandomIP(ctx, ipPool)
+		if err != nil {
+			return "", err
+		}
+
+		if ipPool.Status.EgressIPs == nil {
+			ipPool.Status.EgressIPs = spiderpoolv1.PoolEgressIPReservations{}
+		}
+		ipPool.Status.EgressIPs[ip.String()] = egress
+
+		logger.Sugar().Debugf("Try to reserve IP %s of IPPool %s for %s %s/%s", ip, poolName, egress.Kind, egress.Namespace, egress.Name)
+		if err := im.updateIPPoolStatus(ctx, ipPool); err != nil {
+			if !apierrors.IsConflict(err) {
+				return "", err
+			}
+			im.options.Metrics.RecordConflict(ctx, constant.SpiderIPPoolKind)
+			if i == im.options.RetryPolicy.MaxConflictRetries {
+				return "", fmt.Errorf("%w (%d times), failed to reserve egress IP from IPPool %s", constant.ErrRetriesExhausted, im.options.RetryPolicy.MaxConflictRetries, poolName)
+			}
+
+			interval := time.Duration(r.Intn(1<<(i+1))) * im.options.RetryPolicy.ConflictRetryUnitTime
+			logger.Sugar().Debugf("An conflict occurred when reserving egress IP from the IPPool %s, it will be retried in %s", poolName, interval)
+
+			im.options.Clock.Sleep(interval)
+			continue
+		}
+
+		reservedIP = ip.String()
+		break
+	}
+
+	return reservedIP, nil
+}
+
+// ReleaseEgressIP releases all IP addresses of the IPPool reserved for the
+// given egress gateway object, regardless of their UIDs if the UID of the
+// given one is empty.
+func (im *ipPoolManager) ReleaseEgressIP(ctx context.Context, poolName string, egress spiderpoolv1.EgressIPReservation) error {
+	logger := logutils.FromContext(ctx)
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i <= im.options.RetryPolicy.MaxConflictRetries; i++ {
+		logger := logger.With(zap.Int("times", i+1))
+		logger.Sugar().Debugf("Re-get IPPool %s for egress IP release", poolName)
+
+		ipPool, err := im.GetIPPoolByName(ctx, poolName, constant.IgnoreCache)
+		if err != nil {
+			return err
+		}
+
+		release := false
+		for ip, reservation := range ipPool.Status.EgressIPs {
+			if egress.UID == "" {
+				reservation.UID = ""
+			}
+			if reservation == egress {
+				delete(ipPool.Status.EgressIPs, ip)
+				release = true
+			}
+		}
+
+		if !release {
+			return nil
+		}
+
+		logger.Sugar().Debugf("Try to release the egress IP of IPPool %s reserved for %s %s/%s", poolName, egress.Kind, egress.Namespace, egress.Name)
+		if err := im.updateIPPoolStatus(ctx, ipPool); err != nil {
+			if !apierrors.IsConflict(err) {
+				return err
+			}
+			im.options.Metrics.RecordConflict(ctx, constant.SpiderIPPoolKind)
+			if i == im.options.RetryPolicy.MaxConflictRetries {
+				return fmt.Errorf("%w (%d times), failed to release egress IP from IPPool %s", constant.ErrRetriesExhausted, im.options.RetryPolicy.MaxConflictRetries, poolName)
+			}
+
+			interval := time.Duration(r.Intn(1<<(i+1))) * im.options.RetryPolicy.ConflictRetryUnitTime
+			logger.Sugar().Debugf("An conflict occurred when releasing egress IP from the IPPool %s, it will be retried in %s", poolName, interval)
+
+			im.options.Clock.Sleep(interval)
+			continue
+		}
+		break
 	}
 
 	return nil
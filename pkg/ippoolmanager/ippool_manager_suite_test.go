@@ -6,6 +6,7 @@ package ippoolmanager_test
 import (
 	"testing"
 
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -18,6 +19,7 @@ import (
 
 var scheme *runtime.Scheme
 var fakeClient client.Client
+var ipPoolManager ippoolmanager.IPPoolManager
 var ipPoolWebhook *ippoolmanager.IPPoolWebhook
 
 func TestIPPoolManager(t *testing.T) {
@@ -29,11 +31,21 @@ var _ = BeforeSuite(func() {
 	scheme = runtime.NewScheme()
 	err := spiderpoolv1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
+	err = netv1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
 
 	fakeClient = fake.NewClientBuilder().
 		WithScheme(scheme).
 		Build()
 
+	ipPoolManager, err = ippoolmanager.NewIPPoolManager(
+		ippoolmanager.IPPoolManagerConfig{},
+		fakeClient,
+		fakeClient,
+		&fakeReservedIPManager{},
+	)
+	Expect(err).NotTo(HaveOccurred())
+
 	ipPoolWebhook = &ippoolmanager.IPPoolWebhook{
 		Client:             fakeClient,
 		Scheme:             scheme,
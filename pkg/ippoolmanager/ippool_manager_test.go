@@ -0,0 +1,967 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
+	"github.com/spidernet-io/spiderpool/pkg/allocationpolicy"
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/features"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// fakeReservedIPManager avoids the field index on the fake client, which
+// the real ReservedIPManager depends on.
+type fakeReservedIPManager struct {
+	reservedIPs []net.IP
+	softRIP     *spiderpoolv1.SpiderReservedIP
+}
+
+func (f *fakeReservedIPManager) GetReservedIPByName(ctx context.Context, rIPName string) (*spiderpoolv1.SpiderReservedIP, error) {
+	return nil, constant.ErrUnknown
+}
+
+func (f *fakeReservedIPManager) ListReservedIPs(ctx context.Context, opts ...client.ListOption) (*spiderpoolv1.SpiderReservedIPList, error) {
+	return nil, constant.ErrUnknown
+}
+
+func (f *fakeReservedIPManager) AssembleReservedIPs(ctx context.Context, version types.IPVersion) ([]net.IP, error) {
+	return f.reservedIPs, nil
+}
+
+func (f *fakeReservedIPManager) MatchSoftReservedIP(ctx context.Context, version types.IPVersion, ip net.IP) (*spiderpoolv1.SpiderReservedIP, error) {
+	return f.softRIP, nil
+}
+
+// fakeAllocationPolicy records the reviewed IP addresses and answers with
+// the decision.
+type fakeAllocationPolicy struct {
+	decision *allocationpolicy.Decision
+	reviewed []string
+}
+
+func (f *fakeAllocationPolicy) Review(ctx context.Context, review *allocationpolicy.Review) (*allocationpolicy.Decision, error) {
+	f.reviewed = append(f.reviewed, review.IP)
+	return f.decision, nil
+}
+
+var _ = Describe("IPPoolManager", Label("ippool_manager_test"), func() {
+	Describe("New IPPoolManager", func() {
+		It("inputs nil client", func() {
+			manager, err := ippoolmanager.NewIPPoolManager(ippoolmanager.IPPoolManagerConfig{}, nil, fakeClient, &fakeReservedIPManager{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+
+		It("inputs nil reserved-IP manager", func() {
+			manager, err := ippoolmanager.NewIPPoolManager(ippoolmanager.IPPoolManagerConfig{}, fakeClient, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+	})
+
+	Describe("Test IPPoolManager's method", func() {
+		var count uint64
+		var ipPoolName string
+		var ipPoolT *spiderpoolv1.SpiderIPPool
+		var egressT spiderpoolv1.EgressIPReservation
+
+		BeforeEach(func() {
+			atomic.AddUint64(&count, 1)
+			ipPoolName = fmt.Sprintf("ippool-%v", count)
+			ipPoolT = &spiderpoolv1.SpiderIPPool{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       constant.SpiderIPPoolKind,
+					APIVersion: fmt.Sprintf("%s/%s", constant.SpiderpoolAPIGroup, constant.SpiderpoolAPIVersionV1),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: ipPoolName,
+				},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.10-172.18.40.11"},
+				},
+			}
+
+			egressT = spiderpoolv1.EgressIPReservation{
+				APIVersion: "egressgateway.spidernet.io/v1beta1",
+				Kind:       "EgressGateway",
+				Name:       fmt.Sprintf("egress-%v", count),
+				UID:        string(uuid.NewUUID()),
+			}
+		})
+
+		AfterEach(func() {
+			policy := metav1.DeletePropagationForeground
+			deleteOption := &client.DeleteOptions{
+				GracePeriodSeconds: pointer.Int64(0),
+				PropagationPolicy:  &policy,
+			}
+
+			ctx := context.TODO()
+			err := fakeClient.Delete(ctx, ipPoolT, deleteOption)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+
+		Describe("IterateIPPools", func() {
+			It("iterates the IPPools", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				found := false
+				err = ipPoolManager.IterateIPPools(ctx, func(pool *spiderpoolv1.SpiderIPPool) error {
+					if pool.Name == ipPoolName {
+						found = true
+					}
+					return nil
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("stops once fn returns an error", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ipPoolManager.IterateIPPools(ctx, func(pool *spiderpoolv1.SpiderIPPool) error {
+					return constant.ErrUnknown
+				})
+				Expect(err).To(MatchError(constant.ErrUnknown))
+			})
+		})
+
+		Describe("ListDefaultIPPools", func() {
+			It("lists the default IPPools of the Namespace", func() {
+				ipPoolT.Spec.Default = pointer.Bool(true)
+				ipPoolT.Spec.NamespaceDefault = []string{"ns1"}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPools, err := ipPoolManager.ListDefaultIPPools(ctx, "ns1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPools).To(HaveLen(1))
+				Expect(ipPools[0].Name).To(Equal(ipPoolName))
+
+				ipPools, err = ipPoolManager.ListDefaultIPPools(ctx, "ns2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPools).To(BeEmpty())
+
+				ipPools, err = ipPoolManager.ListDefaultIPPools(ctx, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPools).To(BeEmpty())
+			})
+
+			It("lists the default IPPools of the cluster", func() {
+				ipPoolT.Spec.Default = pointer.Bool(true)
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPools, err := ipPoolManager.ListDefaultIPPools(ctx, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPools).To(HaveLen(1))
+				Expect(ipPools[0].Name).To(Equal(ipPoolName))
+
+				ipPools, err = ipPoolManager.ListDefaultIPPools(ctx, "ns1")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPools).To(BeEmpty())
+			})
+		})
+
+		Describe("ReserveEgressIP", func() {
+			It("reserves egress IP from non-existent IPPool", func() {
+				ctx := context.TODO()
+				ip, err := ipPoolManager.ReserveEgressIP(ctx, ipPoolName, egressT)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(ip).To(BeEmpty())
+			})
+
+			It("reserves egress IP that is excluded from allocated IP addresses", func() {
+				ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+					"172.18.40.10": spiderpoolv1.PoolIPAllocation{
+						ContainerID: "container",
+						NIC:         "eth0",
+						Node:        "node",
+						Namespace:   "default",
+						Pod:         "pod",
+					},
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ip, err := ipPoolManager.ReserveEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ip).To(Equal("172.18.40.11"))
+
+				ipPool, err := ipPoolManager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.EgressIPs).To(HaveKeyWithValue(ip, egressT))
+			})
+
+			It("reserves egress IP repeatedly", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ip1, err := ipPoolManager.ReserveEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ip2, err := ipPoolManager.ReserveEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ip2).To(Equal(ip1))
+			})
+
+			It("runs out of IP addresses", func() {
+				ipPoolT.Spec.IPs = []string{"172.18.40.10"}
+				ipPoolT.Status.EgressIPs = spiderpoolv1.PoolEgressIPReservations{
+					"172.18.40.10": spiderpoolv1.EgressIPReservation{
+						APIVersion: "egressgateway.spidernet.io/v1beta1",
+						Kind:       "EgressGateway",
+						Name:       "other",
+					},
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ip, err := ipPoolManager.ReserveEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).To(MatchError(constant.ErrIPUsedOut))
+				Expect(err).To(MatchError(constant.ErrPoolExhausted))
+				Expect(ip).To(BeEmpty())
+			})
+		})
+
+		Describe("AllocateIP by StatefulSet ordinal", func() {
+			var stsController types.PodTopController
+
+			newPod := func(name string) *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+			}
+
+			BeforeEach(func() {
+				ipPoolT.Annotations = map[string]string{constant.AnnoIPPoolStatefulSetOrdinalIP: constant.True}
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				ipPoolT.Spec.IPs = []string{"172.18.40.10-172.18.40.13"}
+
+				stsController = types.PodTopController{
+					Kind:      constant.KindStatefulSet,
+					Namespace: "default",
+					Name:      "sts",
+				}
+			})
+
+			It("allocates the nth IP address to the Pod with ordinal n", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", newPod("sts-2"), stsController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("172.18.40.12/24"))
+			})
+
+			It("allocates IP address from the tail to the other Pods", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				deployController := types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", newPod("deploy-abc"), deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("172.18.40.13/24"))
+			})
+
+			It("fails when the ordinal exceeds the IPPool size", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", newPod("sts-4"), stsController)
+				Expect(err).To(MatchError(constant.ErrIPUsedOut))
+				Expect(err).To(MatchError(constant.ErrPoolExhausted))
+				Expect(ipConfig).To(BeNil())
+			})
+
+			It("fails when the IP address of the ordinal is allocated to another Pod", func() {
+				ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+					"172.18.40.11": spiderpoolv1.PoolIPAllocation{
+						ContainerID: "other",
+						NIC:         "eth0",
+						Node:        "node",
+						Namespace:   "default",
+						Pod:         "other",
+					},
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", newPod("sts-1"), stsController)
+				Expect(err).To(MatchError(constant.ErrIPConflict))
+				Expect(ipConfig).To(BeNil())
+			})
+
+			It("ignores the ordinal when the feature gate is disabled", func() {
+				err := features.DefaultMutableFeatureGate.SetFromMap(map[string]bool{string(features.StatefulSetOrdinalIP): false})
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(func() {
+					err := features.DefaultMutableFeatureGate.SetFromMap(map[string]bool{string(features.StatefulSetOrdinalIP): true})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				ctx := context.TODO()
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", newPod("sts-4"), stsController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipConfig.Address).NotTo(BeNil())
+			})
+		})
+
+		Describe("AllocateIP of soft reserved IP address", func() {
+			It("allocates the IP address and warns with an event", func() {
+				softRIP := &spiderpoolv1.SpiderReservedIP{
+					ObjectMeta: metav1.ObjectMeta{Name: "legacy"},
+					Spec: spiderpoolv1.ReservedIPSpec{
+						IPVersion:   pointer.Int64(constant.IPv4),
+						IPs:         []string{"172.18.40.10-172.18.40.11"},
+						Enforcement: pointer.String(constant.ReservedIPEnforcementSoft),
+					},
+				}
+				manager, err := ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{},
+					fakeClient,
+					fakeClient,
+					&fakeReservedIPManager{softRIP: softRIP},
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				ctx := context.TODO()
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				recorder := record.NewFakeRecorder(2)
+				event.EventRecorder = recorder
+				DeferCleanup(func() {
+					event.EventRecorder = record.NewFakeRecorder(event.FakeRecorderBufferSize)
+				})
+
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy-abc",
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController := types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipConfig.Address).NotTo(BeNil())
+				Expect(recorder.Events).To(Receive(ContainSubstring(constant.EventReasonSoftReservedIP)))
+				Expect(recorder.Events).To(Receive(ContainSubstring("deploy-abc")))
+			})
+		})
+
+		Describe("AllocateIP with allocation policy", func() {
+			var policy *fakeAllocationPolicy
+			var manager ippoolmanager.IPPoolManager
+			var pod *corev1.Pod
+			var deployController types.PodTopController
+
+			BeforeEach(func() {
+				policy = &fakeAllocationPolicy{}
+
+				var err error
+				manager, err = ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{},
+					fakeClient,
+					fakeClient,
+					&fakeReservedIPManager{},
+					manageroption.WithAllocationPolicy(policy),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				ipPoolT.Spec.IPs = []string{"172.18.40.10-172.18.40.12"}
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy-abc",
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController = types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+
+				ctx := context.TODO()
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("allocates the IP address allowed by the policy", func() {
+				policy.decision = &allocationpolicy.Decision{Allowed: true}
+
+				ctx := context.TODO()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policy.reviewed).To(HaveLen(1))
+				Expect(*ipConfig.Address).To(Equal(policy.reviewed[0] + "/24"))
+			})
+
+			It("fails when the policy denies the IP address", func() {
+				policy.decision = &allocationpolicy.Decision{Allowed: false, Reason: "blocked"}
+
+				ctx := context.TODO()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).To(MatchError(constant.ErrPolicyDenied))
+				Expect(err.Error()).To(ContainSubstring("blocked"))
+				Expect(ipConfig).To(BeNil())
+			})
+
+			It("allocates the IP address suggested by the policy", func() {
+				policy.decision = &allocationpolicy.Decision{Allowed: false, SuggestedIP: "172.18.40.12"}
+
+				ctx := context.TODO()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("172.18.40.12/24"))
+
+				var pool spiderpoolv1.SpiderIPPool
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(ipPoolT), &pool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pool.Status.AllocatedIPs).To(HaveKey("172.18.40.12"))
+			})
+
+			It("fails when the suggested IP address is unavailable", func() {
+				policy.decision = &allocationpolicy.Decision{Allowed: false, SuggestedIP: "172.18.40.20"}
+
+				ctx := context.TODO()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).To(MatchError(constant.ErrPolicyDenied))
+				Expect(ipConfig).To(BeNil())
+			})
+		})
+
+		Describe("AllocateIP with gap filling", func() {
+			var manager ippoolmanager.IPPoolManager
+			var pod *corev1.Pod
+			var deployController types.PodTopController
+
+			BeforeEach(func() {
+				var err error
+				manager, err = ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{EnableGapFilling: true},
+					fakeClient,
+					fakeClient,
+					&fakeReservedIPManager{},
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				ipPoolT.Spec.IPs = []string{"172.18.40.10-172.18.40.20"}
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy-abc",
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController = types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+
+				ctx := context.TODO()
+				err = fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+					"172.18.40.12": {},
+					"172.18.40.15": {},
+					"172.18.40.17": {},
+				}
+				ipPoolT.Status.AllocatedIPCount = pointer.Int64(3)
+				ippoolmanager.SetFragmented(ipPoolT, true, 4, 8)
+				err = fakeClient.Status().Update(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("allocates the IP address of the smallest free range", func() {
+				ctx := context.TODO()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("172.18.40.16/24"))
+			})
+
+			It("gives up the allocation once the context is done", func() {
+				ctx, cancel := context.WithCancel(context.TODO())
+				cancel()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(ipConfig).To(BeNil())
+
+				var ipPool spiderpoolv1.SpiderIPPool
+				err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(ipPoolT), &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipPool.Status.AllocatedIPCount).To(Equal(int64(3)))
+			})
+		})
+
+		Describe("AllocateIP with minimum free IP addresses", func() {
+			var manager ippoolmanager.IPPoolManager
+			var pod *corev1.Pod
+			var deployController types.PodTopController
+
+			BeforeEach(func() {
+				var err error
+				manager, err = ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{},
+					fakeClient,
+					fakeClient,
+					&fakeReservedIPManager{},
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				ipPoolT.Spec.IPs = []string{"172.18.40.10-172.18.40.12"}
+				ipPoolT.Spec.MinFreeIPs = pointer.Int64(2)
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy-abc",
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController = types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+
+				err = fakeClient.Create(context.TODO(), ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps the headroom from the non-critical Pods", func() {
+				ctx := context.TODO()
+				_, err := manager.AllocateIP(ctx, ipPoolName, "container-1", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = manager.AllocateIP(ctx, ipPoolName, "container-2", "eth0", pod, deployController)
+				Expect(err).To(MatchError(constant.ErrPoolExhausted))
+				Expect(err.Error()).To(ContainSubstring("spec.minFreeIPs"))
+			})
+
+			It("allocates the headroom to the critical Pods", func() {
+				ctx := context.TODO()
+				pod.Spec.PriorityClassName = constant.PriorityClassSystemNodeCritical
+				for _, containerID := range []string{"container-1", "container-2", "container-3"} {
+					_, err := manager.AllocateIP(ctx, ipPoolName, containerID, "eth0", pod, deployController)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+		})
+
+		Describe("AllocateIP and ReleaseIP with status shards", func() {
+			var manager ippoolmanager.IPPoolManager
+			var pod *corev1.Pod
+			var deployController types.PodTopController
+
+			listShards := func() []spiderpoolv1.SpiderIPPoolShard {
+				var shardList spiderpoolv1.SpiderIPPoolShardList
+				err := fakeClient.List(context.TODO(), &shardList, client.MatchingLabels{constant.LabelIPPoolShardOf: ipPoolName})
+				Expect(err).NotTo(HaveOccurred())
+				return shardList.Items
+			}
+
+			BeforeEach(func() {
+				var err error
+				manager, err = ippoolmanager.NewIPPoolManager(
+					ippoolmanager.IPPoolManagerConfig{StatusShardSize: 1},
+					fakeClient,
+					fakeClient,
+					&fakeReservedIPManager{},
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deploy-abc",
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController = types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+
+				err = fakeClient.Create(context.TODO(), ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				for _, shard := range listShards() {
+					shard := shard
+					err := fakeClient.Delete(context.TODO(), &shard)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				}
+			})
+
+			It("moves the allocation details beyond the size into shards and assembles them", func() {
+				ctx := context.TODO()
+				ipConfig1, err := manager.AllocateIP(ctx, ipPoolName, "container-1", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				ipConfig2, err := manager.AllocateIP(ctx, ipPoolName, "container-2", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+
+				var stored spiderpoolv1.SpiderIPPool
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(ipPoolT), &stored)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Status.AllocatedIPs).To(BeEmpty())
+				Expect(stored.Status.Shards).To(HaveLen(2))
+				Expect(listShards()).To(HaveLen(2))
+				Expect(*stored.Status.AllocatedIPCount).To(Equal(int64(2)))
+
+				ipPool, err := manager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				ip1, _, _ := net.ParseCIDR(*ipConfig1.Address)
+				ip2, _, _ := net.ParseCIDR(*ipConfig2.Address)
+				Expect(ipPool.Status.AllocatedIPs).To(HaveKey(ip1.String()))
+				Expect(ipPool.Status.AllocatedIPs).To(HaveKey(ip2.String()))
+				Expect(ipPool.Status.AllocatedIPs[ip1.String()].ContainerID).To(Equal("container-1"))
+
+				err = manager.ReleaseIP(ctx, ipPoolName, []types.IPAndCID{{IP: ip1.String(), ContainerID: "container-1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPool, err = manager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.AllocatedIPs).To(HaveLen(1))
+				Expect(ipPool.Status.AllocatedIPs).To(HaveKey(ip2.String()))
+				Expect(ipPool.Status.Shards).To(HaveLen(1))
+				Expect(listShards()).To(HaveLen(1))
+			})
+
+			It("keeps the unchanged shards once the sharding is disabled", func() {
+				ctx := context.TODO()
+				ipConfig, err := manager.AllocateIP(ctx, ipPoolName, "container-1", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = manager.AllocateIP(ctx, ipPoolName, "container-2", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+
+				manager, err = ippoolmanager.NewIPPoolManager(ippoolmanager.IPPoolManagerConfig{}, fakeClient, fakeClient, &fakeReservedIPManager{})
+				Expect(err).NotTo(HaveOccurred())
+				ip, _, _ := net.ParseCIDR(*ipConfig.Address)
+				err = manager.ReleaseIP(ctx, ipPoolName, []types.IPAndCID{{IP: ip.String(), ContainerID: "container-1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var stored spiderpoolv1.SpiderIPPool
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(ipPoolT), &stored)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored.Status.Shards).To(HaveLen(1))
+				Expect(stored.Status.AllocatedIPs).To(BeEmpty())
+
+				// The shard of container-2 is unchanged and kept.
+				ipPool, err := manager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.AllocatedIPs).To(HaveLen(1))
+			})
+		})
+
+		Describe("AllocateIP in IPv6 assignment mode", func() {
+			var pod *corev1.Pod
+			var deployController types.PodTopController
+
+			BeforeEach(func() {
+				ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+				ipPoolT.Spec.Subnet = "fd00:40::/64"
+				ipPoolT.Spec.IPs = []string{"fd00:40::10-fd00:40::13", "fd00:40::250:56ff:feaa:bbcc"}
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "deploy-abc",
+						Namespace:   "default",
+						Annotations: map[string]string{constant.AnnoPodMACs: `{"eth0": "00:50:56:aa:bb:cc"}`},
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController = types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "deploy",
+				}
+			})
+
+			allocate := func(mode string, nic string) (*models.IPConfig, error) {
+				ipPoolT.Spec.IPv6AssignmentMode = pointer.String(mode)
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				return ipPoolManager.AllocateIP(ctx, ipPoolName, "container", nic, pod, deployController)
+			}
+
+			It("allocates the lowest IP address in mode sequential", func() {
+				ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+					"fd00:40::10": spiderpoolv1.PoolIPAllocation{
+						ContainerID: "other",
+						NIC:         "eth0",
+						Node:        "node",
+						Namespace:   "default",
+						Pod:         "other",
+					},
+				}
+
+				ipConfig, err := allocate(constant.IPv6AssignmentModeSequential, "eth0")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("fd00:40::11/64"))
+			})
+
+			It("allocates the IP address derived from MAC address in mode eui64", func() {
+				ipConfig, err := allocate(constant.IPv6AssignmentModeEUI64, "eth0")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("fd00:40::250:56ff:feaa:bbcc/64"))
+			})
+
+			It("leaves the IP address in EUI-64 format to SLAAC", func() {
+				ipPoolT.Spec.IPs = []string{"fd00:40::250:56ff:feaa:bbcc", "fd00:40::300:0:0:10"}
+				ipPoolT.Spec.SLAACCoexistence = pointer.Bool(true)
+
+				ipConfig, err := allocate(constant.IPv6AssignmentModeSequential, "eth0")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("fd00:40::300:0:0:10/64"))
+			})
+
+			It("fails without the MAC address of the NIC in mode eui64", func() {
+				ipConfig, err := allocate(constant.IPv6AssignmentModeEUI64, "net1")
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				Expect(ipConfig).To(BeNil())
+			})
+
+			It("fails when the derived IP address is not in the IPPool in mode eui64", func() {
+				pod.Annotations[constant.AnnoPodMACs] = `{"eth0": "00:50:56:aa:bb:dd"}`
+
+				ipConfig, err := allocate(constant.IPv6AssignmentModeEUI64, "eth0")
+				Expect(err).To(MatchError(constant.ErrIPConflict))
+				Expect(ipConfig).To(BeNil())
+			})
+
+			It("allocates the same IP address to the same Pod in mode stable-privacy", func() {
+				ipConfig, err := allocate(constant.IPv6AssignmentModeStablePrivacy, "eth0")
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx := context.TODO()
+				ip, _, err := net.ParseCIDR(*ipConfig.Address)
+				Expect(err).NotTo(HaveOccurred())
+				err = ipPoolManager.ReleaseIP(ctx, ipPoolName, []types.IPAndCID{{IP: ip.String(), ContainerID: "container"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				again, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*again.Address).To(Equal(*ipConfig.Address))
+			})
+		})
+
+		Describe("AllocateIP with prefix delegation", func() {
+			var pod *corev1.Pod
+			var deployController types.PodTopController
+
+			BeforeEach(func() {
+				ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				ipPoolT.Spec.Subnet = "172.18.40.0/26"
+				ipPoolT.Spec.IPs = nil
+				ipPoolT.Spec.ExcludeIPs = []string{"172.18.40.20"}
+				ipPoolT.Spec.Vlan = pointer.Int64(0)
+				ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(28)
+
+				pod = &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "router-abc",
+						Namespace: "default",
+					},
+					Spec: corev1.PodSpec{NodeName: "node"},
+				}
+				deployController = types.PodTopController{
+					Kind:      constant.KindDeployment,
+					Namespace: "default",
+					Name:      "router",
+				}
+			})
+
+			It("delegates the lowest available prefix", func() {
+				ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+					"172.18.40.1": spiderpoolv1.PoolIPAllocation{
+						ContainerID: "other",
+						NIC:         "eth0",
+						Node:        "node",
+						Namespace:   "default",
+						Pod:         "other",
+					},
+				}
+				ipPoolT.Status.DelegatedPrefixes = spiderpoolv1.PoolPrefixDelegations{
+					"172.18.40.0/28": "172.18.40.1",
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*ipConfig.Address).To(Equal("172.18.40.33/26"))
+				Expect(ipConfig.DelegatedPrefix).To(Equal("172.18.40.32/28"))
+
+				var ipPool spiderpoolv1.SpiderIPPool
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(ipPoolT), &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.DelegatedPrefixes).To(HaveKeyWithValue("172.18.40.32/28", "172.18.40.33"))
+			})
+
+			It("releases the delegated prefix with the IP address", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipConfig.DelegatedPrefix).To(Equal("172.18.40.0/28"))
+
+				err = ipPoolManager.ReleaseIP(ctx, ipPoolName, []types.IPAndCID{{IP: "172.18.40.1", ContainerID: "container"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				var ipPool spiderpoolv1.SpiderIPPool
+				err = fakeClient.Get(ctx, client.ObjectKeyFromObject(ipPoolT), &ipPool)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.DelegatedPrefixes).To(BeEmpty())
+			})
+
+			It("runs out of prefixes", func() {
+				ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(26)
+				ipPoolT.Spec.ExcludeIPs = []string{"172.18.40.63"}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipConfig, err := ipPoolManager.AllocateIP(ctx, ipPoolName, "container", "eth0", pod, deployController)
+				Expect(err).To(MatchError(constant.ErrPoolExhausted))
+				Expect(ipConfig).To(BeNil())
+			})
+		})
+
+		Describe("ReleaseEgressIP", func() {
+			It("releases egress IP from non-existent IPPool", func() {
+				ctx := context.TODO()
+				err := ipPoolManager.ReleaseEgressIP(ctx, ipPoolName, egressT)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("releases the reserved egress IP", func() {
+				ipPoolT.Status.EgressIPs = spiderpoolv1.PoolEgressIPReservations{
+					"172.18.40.10": egressT,
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ipPoolManager.ReleaseEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPool, err := ipPoolManager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.EgressIPs).To(BeEmpty())
+			})
+
+			It("releases the reserved egress IP regardless of the UID", func() {
+				ipPoolT.Status.EgressIPs = spiderpoolv1.PoolEgressIPReservations{
+					"172.18.40.10": egressT,
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				egressT.UID = ""
+				err = ipPoolManager.ReleaseEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPool, err := ipPoolManager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.EgressIPs).To(BeEmpty())
+			})
+
+			It("keeps the egress IP reserved for the object with another UID", func() {
+				ipPoolT.Status.EgressIPs = spiderpoolv1.PoolEgressIPReservations{
+					"172.18.40.10": egressT,
+				}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, ipPoolT)
+				Expect(err).NotTo(HaveOccurred())
+
+				egressT.UID = string(uuid.NewUUID())
+				err = ipPoolManager.ReleaseEgressIP(ctx, ipPoolName, egressT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ipPool, err := ipPoolManager.GetIPPoolByName(ctx, ipPoolName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ipPool.Status.EgressIPs).To(HaveKey("172.18.40.10"))
+			})
+		})
+	})
+})
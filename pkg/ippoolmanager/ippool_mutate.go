@@ -51,7 +51,7 @@ func (iw *IPPoolWebhook) mutateIPPool(ctx context.Context, ipPool *spiderpoolv1.
 
 	cidr, err := spiderpoolip.CIDRToLabelValue(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet)
 	if err != nil {
-		return fmt.Errorf("failed to parse 'spec.subnet' %s as a valid label value: %v", ipPool.Spec.Subnet, err)
+		return fmt.Errorf("failed to parse 'spec.subnet' %s as a valid label value: %w", ipPool.Spec.Subnet, err)
 	}
 
 	if v, ok := ipPool.Labels[constant.LabelIPPoolCIDR]; !ok || v != cidr {
@@ -64,14 +64,14 @@ func (iw *IPPoolWebhook) mutateIPPool(ctx context.Context, ipPool *spiderpoolv1.
 
 	if iw.EnableSpiderSubnet {
 		if err := iw.setControllerSubnet(ctx, ipPool); err != nil {
-			return apierrors.NewInternalError(fmt.Errorf("failed to set the reference of the controller Subnet: %v", err))
+			return apierrors.NewInternalError(fmt.Errorf("failed to set the reference of the controller Subnet: %w", err))
 		}
 	}
 
 	if len(ipPool.Spec.IPs) > 1 {
 		mergedIPs, err := spiderpoolip.MergeIPRanges(*ipPool.Spec.IPVersion, ipPool.Spec.IPs)
 		if err != nil {
-			return fmt.Errorf("failed to merge 'spec.ips': %v", err)
+			return fmt.Errorf("failed to merge 'spec.ips': %w", err)
 		}
 
 		ipPool.Spec.IPs = mergedIPs
@@ -81,7 +81,7 @@ func (iw *IPPoolWebhook) mutateIPPool(ctx context.Context, ipPool *spiderpoolv1.
 	if len(ipPool.Spec.ExcludeIPs) > 1 {
 		mergedExcludeIPs, err := spiderpoolip.MergeIPRanges(*ipPool.Spec.IPVersion, ipPool.Spec.ExcludeIPs)
 		if err != nil {
-			return fmt.Errorf("failed to merge 'spec.excludeIPs': %v", err)
+			return fmt.Errorf("failed to merge 'spec.excludeIPs': %w", err)
 		}
 
 		ipPool.Spec.ExcludeIPs = mergedExcludeIPs
@@ -102,7 +102,7 @@ func (iw *IPPoolWebhook) setControllerSubnet(ctx context.Context, ipPool *spider
 
 	cidr, err := spiderpoolip.CIDRToLabelValue(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet)
 	if err != nil {
-		return fmt.Errorf("failed to parse CIDR %s as a valid label value: %v", ipPool.Spec.Subnet, err)
+		return fmt.Errorf("failed to parse CIDR %s as a valid label value: %w", ipPool.Spec.Subnet, err)
 	}
 
 	var subnetList spiderpoolv1.SpiderSubnetList
@@ -111,7 +111,7 @@ func (iw *IPPoolWebhook) setControllerSubnet(ctx context.Context, ipPool *spider
 		&subnetList,
 		client.MatchingLabels{constant.LabelSubnetCIDR: cidr},
 	); err != nil {
-		return fmt.Errorf("failed to list Subnets: %v", err)
+		return fmt.Errorf("failed to list Subnets: %w", err)
 	}
 
 	if len(subnetList.Items) == 0 {
@@ -121,7 +121,7 @@ func (iw *IPPoolWebhook) setControllerSubnet(ctx context.Context, ipPool *spider
 	subnet := subnetList.Items[0]
 	if !metav1.IsControlledBy(ipPool, &subnet) {
 		if err := ctrl.SetControllerReference(&subnet, ipPool, iw.Scheme); err != nil {
-			return fmt.Errorf("failed to set owner reference: %v", err)
+			return fmt.Errorf("failed to set owner reference: %w", err)
 		}
 		logger.Sugar().Infof("Set owner reference as Subnet %s", subnet.Name)
 	}
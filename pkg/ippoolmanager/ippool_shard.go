@@ -0,0 +1,236 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// orphanShardGracePeriod is how long a SpiderIPPoolShard not referenced by
+// its IPPool is kept, as it may be created for an update of the IPPool in
+// progress.
+const orphanShardGracePeriod = 5 * time.Minute
+
+// AssembleIPPoolShards merges the IP allocation details held by the
+// SpiderIPPoolShards of the IPPool into its 'status.allocatedIPs'. The
+// shards not found in reader, e.g. a lagging cache, are read from
+// fallback if it is not nil.
+func AssembleIPPoolShards(ctx context.Context, reader, fallback client.Reader, pool *spiderpoolv1.SpiderIPPool) error {
+	if len(pool.Status.Shards) == 0 {
+		return nil
+	}
+
+	if pool.Status.AllocatedIPs == nil {
+		pool.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{}
+	}
+	for _, name := range pool.Status.Shards {
+		shard, err := getIPPoolShard(ctx, reader, fallback, name)
+		if err != nil {
+			return fmt.Errorf("failed to get SpiderIPPoolShard %s of IPPool %s: %w", name, pool.Name, err)
+		}
+		for ip, allocation := range shard.AllocatedIPs {
+			pool.Status.AllocatedIPs[ip] = allocation
+		}
+	}
+
+	return nil
+}
+
+func getIPPoolShard(ctx context.Context, reader, fallback client.Reader, name string) (*spiderpoolv1.SpiderIPPoolShard, error) {
+	var shard spiderpoolv1.SpiderIPPoolShard
+	err := reader.Get(ctx, apitypes.NamespacedName{Name: name}, &shard)
+	if fallback != nil && apierrors.IsNotFound(err) {
+		err = fallback.Get(ctx, apitypes.NamespacedName{Name: name}, &shard)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &shard, nil
+}
+
+// updateIPPoolStatus updates the status of the IPPool assembled with its
+// SpiderIPPoolShards. The IP allocation details are split back into the
+// shards they come from, and the changed shards are replaced by new ones
+// as the shards are immutable. The ones left in the status are moved into
+// a new shard once they exceed shardSize bytes, and the small shards are
+// merged back into the status, all of them if shardSize is not positive.
+//
+// The update of the IPPool commits the change, the new shards are deleted
+// if it fails, and the replaced ones are deleted once it succeeds.
+func updateIPPoolStatus(ctx context.Context, c client.Client, reader, fallback client.Reader, pool *spiderpoolv1.SpiderIPPool, shardSize int) error {
+	if len(pool.Status.Shards) == 0 && (shardSize <= 0 || allocationsSize(pool.Status.AllocatedIPs) <= shardSize) {
+		return c.Status().Update(ctx, pool)
+	}
+
+	logger := logutils.FromContext(ctx)
+
+	assembled := pool.Status.AllocatedIPs
+	inline := make(spiderpoolv1.PoolIPAllocations, len(assembled))
+	for ip, allocation := range assembled {
+		inline[ip] = allocation
+	}
+
+	var shards, created, replaced []string
+	deleteShards := func(names []string) {
+		for _, name := range names {
+			shard := &spiderpoolv1.SpiderIPPoolShard{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			if err := c.Delete(ctx, shard); client.IgnoreNotFound(err) != nil {
+				logger.Sugar().Warnf("Failed to delete SpiderIPPoolShard %s of IPPool %s, leave it to be cleaned up: %v", name, pool.Name, err)
+			}
+		}
+	}
+
+	for _, name := range pool.Status.Shards {
+		shard, err := getIPPoolShard(ctx, reader, fallback, name)
+		if err != nil {
+			deleteShards(created)
+			return fmt.Errorf("failed to get SpiderIPPoolShard %s of IPPool %s: %w", name, pool.Name, err)
+		}
+
+		records := spiderpoolv1.PoolIPAllocations{}
+		changed := false
+		for ip, allocation := range shard.AllocatedIPs {
+			current, ok := inline[ip]
+			if !ok {
+				changed = true
+				continue
+			}
+			delete(inline, ip)
+			if !reflect.DeepEqual(current, allocation) {
+				changed = true
+			}
+			records[ip] = current
+		}
+		if !changed {
+			shards = append(shards, name)
+			continue
+		}
+
+		replaced = append(replaced, name)
+		if len(records) == 0 {
+			continue
+		}
+
+		merged := make(spiderpoolv1.PoolIPAllocations, len(inline)+len(records))
+		for ip, allocation := range inline {
+			merged[ip] = allocation
+		}
+		for ip, allocation := range records {
+			merged[ip] = allocation
+		}
+		if shardSize <= 0 || allocationsSize(merged) <= shardSize {
+			inline = merged
+			continue
+		}
+
+		newName, err := createIPPoolShard(ctx, c, pool, records)
+		if err != nil {
+			deleteShards(created)
+			return err
+		}
+		created = append(created, newName)
+		shards = append(shards, newName)
+	}
+
+	if shardSize > 0 && allocationsSize(inline) > shardSize {
+		newName, err := createIPPoolShard(ctx, c, pool, inline)
+		if err != nil {
+			deleteShards(created)
+			return err
+		}
+		created = append(created, newName)
+		shards = append(shards, newName)
+		inline = spiderpoolv1.PoolIPAllocations{}
+	}
+
+	pool.Status.AllocatedIPs = inline
+	pool.Status.Shards = shards
+	if err := c.Status().Update(ctx, pool); err != nil {
+		pool.Status.AllocatedIPs = assembled
+		deleteShards(created)
+		return err
+	}
+	pool.Status.AllocatedIPs = assembled
+	deleteShards(replaced)
+
+	return nil
+}
+
+func createIPPoolShard(ctx context.Context, c client.Client, pool *spiderpoolv1.SpiderIPPool, allocations spiderpoolv1.PoolIPAllocations) (string, error) {
+	shard := &spiderpoolv1.SpiderIPPoolShard{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pool.Name + "-",
+			Labels:       map[string]string{constant.LabelIPPoolShardOf: pool.Name},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         spiderpoolv1.GroupVersion.String(),
+				Kind:               constant.SpiderIPPoolKind,
+				Name:               pool.Name,
+				UID:                pool.UID,
+				BlockOwnerDeletion: pointer.Bool(true),
+			}},
+		},
+		IPPool:       pool.Name,
+		AllocatedIPs: allocations,
+	}
+	if err := c.Create(ctx, shard); err != nil {
+		return "", fmt.Errorf("failed to create SpiderIPPoolShard of IPPool %s: %w", pool.Name, err)
+	}
+
+	return shard.Name, nil
+}
+
+// allocationsSize returns the size of the encoded IP allocation details.
+func allocationsSize(allocations spiderpoolv1.PoolIPAllocations) int {
+	if len(allocations) == 0 {
+		return 0
+	}
+
+	data, err := json.Marshal(allocations)
+	if err != nil {
+		return 0
+	}
+
+	return len(data)
+}
+
+// pruneOrphanIPPoolShards deletes the SpiderIPPoolShards of the IPPool which
+// are no longer referenced by it, left by the interrupted updates.
+func pruneOrphanIPPoolShards(ctx context.Context, c client.Client, pool *spiderpoolv1.SpiderIPPool, now time.Time) error {
+	var shardList spiderpoolv1.SpiderIPPoolShardList
+	if err := c.List(ctx, &shardList, client.MatchingLabels{constant.LabelIPPoolShardOf: pool.Name}); err != nil {
+		return fmt.Errorf("failed to list SpiderIPPoolShards of IPPool %s: %w", pool.Name, err)
+	}
+
+	referenced := make(map[string]bool, len(pool.Status.Shards))
+	for _, name := range pool.Status.Shards {
+		referenced[name] = true
+	}
+	for i := range shardList.Items {
+		shard := &shardList.Items[i]
+		if referenced[shard.Name] || shard.IPPool != pool.Name || now.Sub(shard.CreationTimestamp.Time) < orphanShardGracePeriod {
+			continue
+		}
+		if err := c.Delete(ctx, shard); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete orphan SpiderIPPoolShard %s of IPPool %s: %w", shard.Name, pool.Name, err)
+		}
+		logutils.FromContext(ctx).Sugar().Infof("Delete orphan SpiderIPPoolShard %s of IPPool %s", shard.Name, pool.Name)
+	}
+
+	return nil
+}
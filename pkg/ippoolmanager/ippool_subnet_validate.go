@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 )
@@ -28,6 +31,9 @@ func (iw *IPPoolWebhook) validateCreateIPPoolWhileEnableSpiderSubnet(ctx context
 		if err := validateSubnetTotalIPsContainsIPPoolTotalIPs(subnet, ipPool); err != nil {
 			return field.ErrorList{err}
 		}
+		if err := validateSubnetMinFreeIPs(subnet, nil, ipPool); err != nil {
+			return field.ErrorList{err}
+		}
 	}
 
 	return nil
@@ -46,7 +52,9 @@ func (iw *IPPoolWebhook) validateUpdateIPPoolWhileEnableSpiderSubnet(ctx context
 		if err := validateSubnetTotalIPsContainsIPPoolTotalIPs(subnet, newIPPool); err != nil {
 			return field.ErrorList{err}
 		}
-
+		if err := validateSubnetMinFreeIPs(subnet, oldIPPool, newIPPool); err != nil {
+			return field.ErrorList{err}
+		}
 	}
 
 	return nil
@@ -63,7 +71,10 @@ func (iw *IPPoolWebhook) validateSubnetControllerExist(ctx context.Context, ipPo
 
 	var subnet spiderpoolv1.SpiderSubnet
 	if err := iw.Client.Get(ctx, apitypes.NamespacedName{Name: owner.Name}, &subnet); err != nil {
-		return nil, field.InternalError(subnetField, fmt.Errorf("failed to get controller Subnet %s: %v", owner.Name, err))
+		if apierrors.IsNotFound(err) {
+			return nil, FieldErrorOf(subnetField, owner.Name, &constant.OwnerNotFoundError{Kind: constant.SpiderSubnetKind, Name: owner.Name, Err: err})
+		}
+		return nil, field.InternalError(subnetField, fmt.Errorf("failed to get controller Subnet %s: %w", owner.Name, err))
 	}
 
 	if subnet.DeletionTimestamp != nil {
@@ -79,11 +90,11 @@ func (iw *IPPoolWebhook) validateSubnetControllerExist(ctx context.Context, ipPo
 func validateSubnetTotalIPsContainsIPPoolTotalIPs(subnet *spiderpoolv1.SpiderSubnet, ipPool *spiderpoolv1.SpiderIPPool) *field.Error {
 	poolTotalIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
 	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %v", ipPool.Name, err))
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %w", ipPool.Name, err))
 	}
 	subnetTotalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
 	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %v", subnet.Name, err))
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %w", subnet.Name, err))
 	}
 
 	outIPs := spiderpoolip.IPsDiffSet(poolTotalIPs, subnetTotalIPs, false)
@@ -97,3 +108,54 @@ func validateSubnetTotalIPsContainsIPPoolTotalIPs(subnet *spiderpoolv1.SpiderSub
 
 	return nil
 }
+
+// validateSubnetMinFreeIPs rejects the scale-up of the IPPool if it would
+// leave fewer free IP addresses in the controller Subnet than its
+// 'spec.minFreeIPs'. The IPPools not scaled up are always allowed, even if
+// the headroom has already been violated.
+func validateSubnetMinFreeIPs(subnet *spiderpoolv1.SpiderSubnet, oldIPPool, newIPPool *spiderpoolv1.SpiderIPPool) *field.Error {
+	minFreeIPs := pointer.Int64Deref(subnet.Spec.MinFreeIPs, 0)
+	if minFreeIPs == 0 {
+		return nil
+	}
+
+	newTotalIPs, err := spiderpoolip.AssembleTotalIPs(*newIPPool.Spec.IPVersion, newIPPool.Spec.IPs, newIPPool.Spec.ExcludeIPs)
+	if err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %w", newIPPool.Name, err))
+	}
+	if oldIPPool != nil {
+		oldTotalIPs, err := spiderpoolip.AssembleTotalIPs(*oldIPPool.Spec.IPVersion, oldIPPool.Spec.IPs, oldIPPool.Spec.ExcludeIPs)
+		if err != nil {
+			return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %w", oldIPPool.Name, err))
+		}
+		if len(newTotalIPs) <= len(oldTotalIPs) {
+			return nil
+		}
+	}
+
+	var used []string
+	for poolName, allocation := range subnet.Status.ControlledIPPools {
+		if poolName != newIPPool.Name {
+			used = append(used, allocation.IPs...)
+		}
+	}
+	usedIPs, err := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, used)
+	if err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to parse the IP addresses controlled by Subnet %s: %w", subnet.Name, err))
+	}
+	subnetTotalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+	if err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %w", subnet.Name, err))
+	}
+
+	freeIPs := spiderpoolip.IPsDiffSet(subnetTotalIPs, usedIPs, false)
+	freeIPs = spiderpoolip.IPsDiffSet(freeIPs, newTotalIPs, false)
+	if int64(len(freeIPs)) < minFreeIPs {
+		return field.Forbidden(
+			ipsField,
+			fmt.Sprintf("only %d free IP addresses would be left in controller Subnet %s, which keeps 'spec.minFreeIPs' %d", len(freeIPs), subnet.Name, minFreeIPs),
+		)
+	}
+
+	return nil
+}
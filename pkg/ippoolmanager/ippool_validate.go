@@ -5,16 +5,24 @@ package ippoolmanager
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
 )
 
 var (
@@ -24,6 +32,18 @@ var (
 	excludeIPsField *field.Path = field.NewPath("spec").Child("excludeIPs")
 	gatewayField    *field.Path = field.NewPath("spec").Child("gateway")
 	routesField     *field.Path = field.NewPath("spec").Child("routes")
+	dnsField        *field.Path = field.NewPath("spec").Child("dns")
+	bandwidthField  *field.Path = field.NewPath("spec").Child("bandwidth")
+
+	allocationWindowField *field.Path = field.NewPath("spec").Child("allocationWindow")
+
+	ipv6AssignmentModeField    *field.Path = field.NewPath("spec").Child("ipv6AssignmentMode")
+	slaacCoexistenceField      *field.Path = field.NewPath("spec").Child("slaacCoexistence")
+	disableSNATField           *field.Path = field.NewPath("spec").Child("disableSNAT")
+	delegatedPrefixLengthField *field.Path = field.NewPath("spec").Child("delegatedPrefixLength")
+	namespaceDefaultField      *field.Path = field.NewPath("spec").Child("namespaceDefault")
+
+	annotationsField *field.Path = field.NewPath("metadata").Child("annotations")
 )
 
 func (iw *IPPoolWebhook) validateCreateIPPool(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool) field.ErrorList {
@@ -39,6 +59,12 @@ func (iw *IPPoolWebhook) validateCreateIPPool(ctx context.Context, ipPool *spide
 	if err := iw.validateIPPoolSpec(ctx, ipPool); err != nil {
 		errs = append(errs, err)
 	}
+	if err := validateIPPoolAnnotations(ipPool); err != nil {
+		errs = append(errs, err)
+	}
+	if err := ValidateNetworkAttachment(ctx, iw.Client, ipPool, iw.NetworkAttachmentWarningOnly); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -60,10 +86,29 @@ func (iw *IPPoolWebhook) validateUpdateIPPool(ctx context.Context, oldIPPool, ne
 		return field.ErrorList{err}
 	}
 
+	// The IP addresses in use may be held by the SpiderIPPoolShards.
+	inUse := newIPPool
+	if len(newIPPool.Status.Shards) != 0 {
+		inUse = newIPPool.DeepCopy()
+		if err := AssembleIPPoolShards(ctx, iw.Client, nil, inUse); err != nil {
+			return field.ErrorList{field.InternalError(ipsField, err)}
+		}
+	}
+
 	var errs field.ErrorList
-	if err := validateIPPoolIPInUse(newIPPool); err != nil {
+	if err := validateIPPoolIPInUse(inUse); err != nil {
+		errs = append(errs, err)
+	}
+	if err := validateIPPoolAnnotations(newIPPool); err != nil {
 		errs = append(errs, err)
 	}
+	// The NetworkAttachmentDefinition is only checked once it is changed, so
+	// that the IPPool could still be updated after it is removed.
+	if newIPPool.Annotations[constant.AnnoNetworkAttachmentDefinition] != oldIPPool.Annotations[constant.AnnoNetworkAttachmentDefinition] {
+		if err := ValidateNetworkAttachment(ctx, iw.Client, newIPPool, iw.NetworkAttachmentWarningOnly); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -88,6 +133,13 @@ func validateIPPoolShouldNotBeChanged(oldIPPool, newIPPool *spiderpoolv1.SpiderI
 		)
 	}
 
+	if pointer.Int32Deref(newIPPool.Spec.DelegatedPrefixLength, 0) != pointer.Int32Deref(oldIPPool.Spec.DelegatedPrefixLength, 0) {
+		return field.Forbidden(
+			delegatedPrefixLengthField,
+			"is not changeable",
+		)
+	}
+
 	return nil
 }
 
@@ -99,22 +151,111 @@ func (iw *IPPoolWebhook) validateIPPoolSpec(ctx context.Context, ipPool *spiderp
 		return err
 	}
 
-	return validateIPPoolRoutes(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.Routes)
+	if err := validateIPPoolRoutes(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.Routes); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolDNS(ipPool.Spec.DNS); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolBandwidth(ipPool.Spec.Bandwidth); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolAllocationWindow(ipPool.Spec.AllocationWindow); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolIPv6AssignmentMode(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.IPv6AssignmentMode); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolSLAACCoexistence(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.IPv6AssignmentMode, ipPool.Spec.SLAACCoexistence); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolDelegatedPrefixLength(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, ipPool.Spec.IPs, ipPool.Spec.DelegatedPrefixLength); err != nil {
+		return err
+	}
+
+	if err := validateIPPoolDisableSNAT(ipPool); err != nil {
+		return err
+	}
+
+	return iw.validateIPPoolDefault(ctx, ipPool)
+}
+
+// validateDeleteIPPool prevents the IPPool whose IP addresses are still allocated
+// to Pods from being deleted, unless it has the annotation "ipam.spidernet.io/force-delete".
+// The auto-created IPPools are skipped, whose lifecycle is managed by Spiderpool.
+func validateDeleteIPPool(ipPool *spiderpoolv1.SpiderIPPool) error {
+	if ipPool.DeletionTimestamp != nil || IsAutoCreatedIPPool(ipPool) {
+		return nil
+	}
+
+	if force, err := strconv.ParseBool(ipPool.Annotations[constant.AnnoIPPoolForceDelete]); err == nil && force {
+		return nil
+	}
+
+	pods := blockingPodsOfIPPool(ipPool)
+	if pods == "" {
+		return nil
+	}
+
+	return fmt.Errorf("IP addresses of IPPool %s are still allocated to Pods %s, set annotation '%s: \"true\"' to delete it forcibly",
+		ipPool.Name, pods, constant.AnnoIPPoolForceDelete)
+}
+
+func validateIPPoolAnnotations(ipPool *spiderpoolv1.SpiderIPPool) *field.Error {
+	for _, key := range []string{constant.AnnoIPPoolStatefulSetOrdinalIP, constant.AnnoIPPoolForceDelete, constant.AnnoIPPoolDetach} {
+		if v, ok := ipPool.Annotations[key]; ok {
+			if _, err := strconv.ParseBool(v); err != nil {
+				return field.Invalid(
+					annotationsField.Key(key),
+					v,
+					"must be a boolean",
+				)
+			}
+		}
+	}
+
+	// The name of a Linux network interface is at most 15 characters.
+	if v, ok := ipPool.Annotations[constant.AnnoIPPoolMasterInterface]; ok {
+		if v == "" || len(v) > 15 || strings.ContainsAny(v, "/ \t\n") {
+			return field.Invalid(
+				annotationsField.Key(constant.AnnoIPPoolMasterInterface),
+				v,
+				"must be a valid interface name",
+			)
+		}
+	}
+
+	if v, ok := ipPool.Annotations[constant.AnnoDefaultRoutePolicy]; ok && !annotation.IsDefaultRoutePolicy(v) {
+		return field.NotSupported(
+			annotationsField.Key(constant.AnnoDefaultRoutePolicy),
+			v,
+			[]string{constant.DefaultRoutePolicyAll, constant.DefaultRoutePolicyFirstNIC, constant.DefaultRoutePolicyUnderlay},
+		)
+	}
+
+	return nil
 }
 
 func validateIPPoolIPInUse(ipPool *spiderpoolv1.SpiderIPPool) *field.Error {
-	totalIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
-	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %v", ipPool.Name, err))
+	// The IP addresses of the IPPool delegating prefixes are carved from the
+	// subnet, which is not changeable.
+	if IsPrefixDelegationIPPool(ipPool) {
+		return nil
 	}
 
-	totalIPsMap := map[string]bool{}
-	for _, ip := range totalIPs {
-		totalIPsMap[ip.String()] = true
+	totalIPs, err := spiderpoolip.AssembleTotalIPSet(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+	if err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %w", ipPool.Name, err))
 	}
 
 	for ip, allocation := range ipPool.Status.AllocatedIPs {
-		if _, ok := totalIPsMap[ip]; !ok {
+		if !totalIPs.Contains(net.ParseIP(ip)) {
 			return field.Forbidden(
 				ipsField,
 				fmt.Sprintf("remove an IP address %s that is being used by Pod %s/%s, total IP addresses of an IPPool are jointly determined by 'spec.ips' and 'spec.excludeIPs'", ip, allocation.Namespace, allocation.Pod),
@@ -122,6 +263,15 @@ func validateIPPoolIPInUse(ipPool *spiderpoolv1.SpiderIPPool) *field.Error {
 		}
 	}
 
+	for ip, reservation := range ipPool.Status.EgressIPs {
+		if !totalIPs.Contains(net.ParseIP(ip)) {
+			return field.Forbidden(
+				ipsField,
+				fmt.Sprintf("remove an IP address %s that is reserved for %s %s/%s, total IP addresses of an IPPool are jointly determined by 'spec.ips' and 'spec.excludeIPs'", ip, reservation.Kind, reservation.Namespace, reservation.Name),
+			)
+		}
+	}
+
 	return nil
 }
 
@@ -177,7 +327,7 @@ func (iw *IPPoolWebhook) validateIPPoolCIDR(ctx context.Context, ipPool *spiderp
 	// TODO(iiiceoo): Use label selector.
 	var ipPoolList spiderpoolv1.SpiderIPPoolList
 	if err := iw.Client.List(ctx, &ipPoolList); err != nil {
-		return field.InternalError(subnetField, fmt.Errorf("failed to list IPPools: %v", err))
+		return field.InternalError(subnetField, fmt.Errorf("failed to list IPPools: %w", err))
 	}
 
 	for _, pool := range ipPoolList.Items {
@@ -192,14 +342,14 @@ func (iw *IPPoolWebhook) validateIPPoolCIDR(ctx context.Context, ipPool *spiderp
 
 			overlap, err := spiderpoolip.IsCIDROverlap(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet, pool.Spec.Subnet)
 			if err != nil {
-				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps: %v", err))
+				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps: %w", err))
 			}
 
 			if overlap {
-				return field.Invalid(
+				return FieldErrorOf(
 					subnetField,
 					ipPool.Spec.Subnet,
-					fmt.Sprintf("overlap with IPPool %s which 'spec.subnet' is %s", pool.Name, pool.Spec.Subnet),
+					&constant.OverlapError{Kind: constant.SpiderIPPoolKind, Name: pool.Name, Ranges: []string{pool.Spec.Subnet}},
 				)
 			}
 		}
@@ -218,7 +368,7 @@ func (iw *IPPoolWebhook) validateIPPoolAvailableIPs(ctx context.Context, ipPool
 
 	cidr, err := spiderpoolip.CIDRToLabelValue(*ipPool.Spec.IPVersion, ipPool.Spec.Subnet)
 	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to parse CIDR %s as a valid label value: %v", ipPool.Spec.Subnet, err))
+		return field.InternalError(ipsField, fmt.Errorf("failed to parse CIDR %s as a valid label value: %w", ipPool.Spec.Subnet, err))
 	}
 
 	// TODO(iiiceoo): The list in validateIPPoolCIDR should be reused.
@@ -228,27 +378,36 @@ func (iw *IPPoolWebhook) validateIPPoolAvailableIPs(ctx context.Context, ipPool
 		&ipPoolList,
 		client.MatchingLabels{constant.LabelIPPoolCIDR: cidr},
 	); err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to list IPPools: %v", err))
+		return field.InternalError(ipsField, fmt.Errorf("failed to list IPPools: %w", err))
 	}
 
-	newIPs, err := spiderpoolip.AssembleTotalIPs(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
+	newIPs, err := spiderpoolip.AssembleTotalIPSet(*ipPool.Spec.IPVersion, ipPool.Spec.IPs, ipPool.Spec.ExcludeIPs)
 	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %v", ipPool.Name, err))
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the IPPool %s: %w", ipPool.Name, err))
 	}
 
 	for _, pool := range ipPoolList.Items {
+		if pool.Name != ipPool.Name && (IsPrefixDelegationIPPool(ipPool) || pool.Spec.DelegatedPrefixLength != nil) {
+			return field.Forbidden(
+				subnetField,
+				fmt.Sprintf("share the subnet with IPPool %s, the IPPool delegating prefixes takes the whole subnet", pool.Name),
+			)
+		}
+
 		if pool.Name != ipPool.Name {
-			existIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+			existIPs, err := spiderpoolip.AssembleTotalIPSet(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
 			if err != nil {
-				return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the existing IPPool %s: %v", pool.Name, err))
+				return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the existing IPPool %s: %w", pool.Name, err))
 			}
 
-			overlapIPs := spiderpoolip.IPsIntersectionSet(newIPs, existIPs, false)
-			if len(overlapIPs) > 0 {
-				overlapRanges, _ := spiderpoolip.ConvertIPsToIPRanges(*pool.Spec.IPVersion, overlapIPs)
-				return field.Forbidden(
+			overlapIPs := newIPs.Intersection(existIPs)
+			if overlapIPs.Len() > 0 {
+				overlapRanges, _ := spiderpoolip.ConvertIPsToIPRanges(*pool.Spec.IPVersion, overlapIPs.List(false))
+				return FieldErrorOf(
 					ipsField,
-					fmt.Sprintf("overlap with IPPool %s in IP ranges %v, total IP addresses of an IPPool are jointly determined by 'spec.ips' and 'spec.excludeIPs'", pool.Name, overlapRanges),
+					ipPool.Spec.IPs,
+					fmt.Errorf("%w, total IP addresses of an IPPool are jointly determined by 'spec.ips' and 'spec.excludeIPs'",
+						&constant.OverlapError{Kind: constant.SpiderIPPoolKind, Name: pool.Name, Ranges: overlapRanges}),
 				)
 			}
 		}
@@ -303,6 +462,126 @@ func validateIPPoolRoutes(version types.IPVersion, subnet string, routes []spide
 	return nil
 }
 
+// validateIPPoolDNS checks the DNS settings returned in the CNI result,
+// the nameservers may be in either IP family.
+func validateIPPoolDNS(dns *spiderpoolv1.DNS) *field.Error {
+	if dns == nil {
+		return nil
+	}
+
+	for i, ns := range dns.Nameservers {
+		if net.ParseIP(ns) == nil {
+			return field.Invalid(
+				dnsField.Child("nameservers").Index(i),
+				ns,
+				"must be a valid IP address",
+			)
+		}
+	}
+
+	if dns.Domain != "" {
+		if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(dns.Domain, ".")); len(errs) > 0 {
+			return field.Invalid(
+				dnsField.Child("domain"),
+				dns.Domain,
+				strings.Join(errs, "; "),
+			)
+		}
+	}
+
+	for i, search := range dns.Search {
+		if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(search, ".")); len(errs) > 0 {
+			return field.Invalid(
+				dnsField.Child("search").Index(i),
+				search,
+				strings.Join(errs, "; "),
+			)
+		}
+	}
+
+	for i, option := range dns.Options {
+		if option == "" || strings.ContainsAny(option, " \t\n") {
+			return field.Invalid(
+				dnsField.Child("options").Index(i),
+				option,
+				"must be a non-empty string without whitespaces",
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateIPPoolBandwidth checks the rate limits hinted in the CNI result,
+// all of them must be positive if specified.
+func validateIPPoolBandwidth(bandwidth *spiderpoolv1.Bandwidth) *field.Error {
+	if bandwidth == nil {
+		return nil
+	}
+
+	limits := []struct {
+		name string
+		q    *resource.Quantity
+	}{
+		{"ingressRate", bandwidth.IngressRate},
+		{"ingressBurst", bandwidth.IngressBurst},
+		{"egressRate", bandwidth.EgressRate},
+		{"egressBurst", bandwidth.EgressBurst},
+	}
+	for _, l := range limits {
+		if q := l.q; q != nil && q.Sign() <= 0 {
+			return field.Invalid(
+				bandwidthField.Child(l.name),
+				q.String(),
+				"must be positive",
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateIPPoolAllocationWindow checks that the schedules of the allocation
+// window are valid cron expressions, and the time zone is known.
+func validateIPPoolAllocationWindow(window *spiderpoolv1.AllocationWindow) *field.Error {
+	if window == nil {
+		return nil
+	}
+
+	if len(window.Schedules) == 0 {
+		return field.Required(allocationWindowField.Child("schedules"), "at least one schedule is required")
+	}
+	for i, expr := range window.Schedules {
+		if _, err := parseCronSchedule(expr); err != nil {
+			return field.Invalid(allocationWindowField.Child("schedules").Index(i), expr, err.Error())
+		}
+	}
+
+	if window.TimeZone != nil && *window.TimeZone != "" {
+		if _, err := time.LoadLocation(*window.TimeZone); err != nil {
+			return field.Invalid(allocationWindowField.Child("timeZone"), *window.TimeZone, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// FieldErrorOf maps the class of the error to the type of the field error,
+// so that the clients of the webhooks are able to tell the classes apart by
+// the causes of the admission response.
+func FieldErrorOf(fieldPath *field.Path, value interface{}, err error) *field.Error {
+	switch {
+	case errors.Is(err, constant.ErrOverlap), errors.Is(err, constant.ErrWrongInput):
+		return field.Invalid(fieldPath, value, err.Error())
+	case errors.Is(err, constant.ErrOwnerNotFound):
+		return &field.Error{Type: field.ErrorTypeNotFound, Field: fieldPath.String(), BadValue: value, Detail: err.Error()}
+	case errors.Is(err, constant.ErrPoolExhausted):
+		return field.Forbidden(fieldPath, err.Error())
+	default:
+		return field.InternalError(fieldPath, err)
+	}
+}
+
 func ValidateContainsIPRange(fieldPath *field.Path, version types.IPVersion, subnet string, ipRange string) *field.Error {
 	contains, err := spiderpoolip.ContainsIPRange(version, subnet, ipRange)
 	if err != nil {
@@ -344,3 +623,209 @@ func ValidateContainsIP(fieldPath *field.Path, version types.IPVersion, subnet s
 
 	return nil
 }
+
+// validateIPPoolIPv6AssignmentMode checks that only the IPv6 IPPools are in
+// the IPv6 assignment modes other than random, and that the IPPools in mode
+// eui64 have room for the interface identifiers of 64 bits.
+func validateIPPoolIPv6AssignmentMode(version types.IPVersion, subnet string, mode *string) *field.Error {
+	if mode == nil {
+		return nil
+	}
+
+	switch *mode {
+	case constant.IPv6AssignmentModeRandom:
+		return nil
+	case constant.IPv6AssignmentModeEUI64, constant.IPv6AssignmentModeStablePrivacy, constant.IPv6AssignmentModeSequential:
+	default:
+		return field.NotSupported(
+			ipv6AssignmentModeField,
+			*mode,
+			[]string{constant.IPv6AssignmentModeRandom, constant.IPv6AssignmentModeEUI64, constant.IPv6AssignmentModeStablePrivacy, constant.IPv6AssignmentModeSequential},
+		)
+	}
+
+	if version != constant.IPv6 {
+		return field.Invalid(
+			ipv6AssignmentModeField,
+			*mode,
+			"only the IPv6 IPPool could be in the mode",
+		)
+	}
+
+	if *mode == constant.IPv6AssignmentModeEUI64 {
+		ipNet, err := spiderpoolip.ParseCIDR(version, subnet)
+		if err != nil {
+			return field.Invalid(subnetField, subnet, err.Error())
+		}
+		if ones, _ := ipNet.Mask.Size(); ones > 64 {
+			return field.Invalid(
+				ipv6AssignmentModeField,
+				*mode,
+				fmt.Sprintf("the prefix length of subnet %s must be at most 64", subnet),
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateIPPoolSLAACCoexistence checks that only the IPv6 IPPools with the
+// /64 prefix, which SLAAC works on, coexist with SLAAC, and that they don't
+// assign the addresses in the modified EUI-64 format.
+func validateIPPoolSLAACCoexistence(version types.IPVersion, subnet string, mode *string, coexistence *bool) *field.Error {
+	if coexistence == nil || !*coexistence {
+		return nil
+	}
+
+	if version != constant.IPv6 {
+		return field.Invalid(
+			slaacCoexistenceField,
+			*coexistence,
+			"only the IPv6 IPPool could coexist with SLAAC",
+		)
+	}
+
+	ipNet, err := spiderpoolip.ParseCIDR(version, subnet)
+	if err != nil {
+		return field.Invalid(subnetField, subnet, err.Error())
+	}
+	if ones, _ := ipNet.Mask.Size(); ones != 64 {
+		return field.Invalid(
+			slaacCoexistenceField,
+			*coexistence,
+			fmt.Sprintf("the prefix length of subnet %s must be 64", subnet),
+		)
+	}
+
+	if mode != nil && *mode == constant.IPv6AssignmentModeEUI64 {
+		return field.Invalid(
+			slaacCoexistenceField,
+			*coexistence,
+			fmt.Sprintf("conflicts with IPv6 assignment mode %s", constant.IPv6AssignmentModeEUI64),
+		)
+	}
+
+	return nil
+}
+
+// validateIPPoolDelegatedPrefixLength checks that the IPPool delegating
+// prefixes carves them from the subnet rather than 'spec.ips', and that each
+// prefix has an IP address for the Pod interface besides the network address.
+func validateIPPoolDelegatedPrefixLength(version types.IPVersion, subnet string, ips []string, length *int32) *field.Error {
+	if length == nil {
+		return nil
+	}
+
+	if len(ips) != 0 {
+		return field.Invalid(
+			delegatedPrefixLengthField,
+			*length,
+			"conflicts with 'spec.ips', the prefixes are carved from 'spec.subnet'",
+		)
+	}
+
+	if _, err := spiderpoolip.SplitCIDR(version, subnet, int(*length)); err != nil {
+		return field.Invalid(delegatedPrefixLengthField, *length, err.Error())
+	}
+
+	bits := 32
+	if version == constant.IPv6 {
+		bits = 128
+	}
+	if int(*length) > bits-2 {
+		return field.Invalid(
+			delegatedPrefixLengthField,
+			*length,
+			fmt.Sprintf("must be at most %d", bits-2),
+		)
+	}
+
+	return nil
+}
+
+// validateIPPoolDefault makes sure that a Namespace, or the whole cluster,
+// only has one default IPPool of each IP version, so that the default IPPool
+// of a Pod is never ambiguous.
+func (iw *IPPoolWebhook) validateIPPoolDefault(ctx context.Context, ipPool *spiderpoolv1.SpiderIPPool) *field.Error {
+	if !IsDefaultIPPool(ipPool) {
+		if len(ipPool.Spec.NamespaceDefault) != 0 {
+			return field.Invalid(
+				namespaceDefaultField,
+				ipPool.Spec.NamespaceDefault,
+				"requires 'spec.default' to be true",
+			)
+		}
+		return nil
+	}
+
+	namespaces := make(map[string]struct{}, len(ipPool.Spec.NamespaceDefault))
+	for _, ns := range ipPool.Spec.NamespaceDefault {
+		if errs := validation.IsDNS1123Label(ns); len(errs) != 0 {
+			return field.Invalid(
+				namespaceDefaultField,
+				ns,
+				strings.Join(errs, "; "),
+			)
+		}
+		if _, ok := namespaces[ns]; ok {
+			return field.Duplicate(namespaceDefaultField, ns)
+		}
+		namespaces[ns] = struct{}{}
+	}
+
+	var ipPoolList spiderpoolv1.SpiderIPPoolList
+	if err := iw.Client.List(ctx, &ipPoolList); err != nil {
+		return field.InternalError(namespaceDefaultField, fmt.Errorf("failed to list IPPools: %w", err))
+	}
+
+	for _, pool := range ipPoolList.Items {
+		if pool.Name == ipPool.Name || pool.DeletionTimestamp != nil || !IsDefaultIPPool(&pool) {
+			continue
+		}
+		if pool.Spec.IPVersion == nil || *pool.Spec.IPVersion != *ipPool.Spec.IPVersion {
+			continue
+		}
+
+		if len(ipPool.Spec.NamespaceDefault) == 0 {
+			if len(pool.Spec.NamespaceDefault) == 0 {
+				return field.Invalid(
+					namespaceDefaultField,
+					ipPool.Spec.NamespaceDefault,
+					fmt.Sprintf("IPPool %s is already the IPv%d default of the cluster", pool.Name, *ipPool.Spec.IPVersion),
+				)
+			}
+			continue
+		}
+
+		for _, ns := range pool.Spec.NamespaceDefault {
+			if _, ok := namespaces[ns]; ok {
+				return field.Invalid(
+					namespaceDefaultField,
+					ipPool.Spec.NamespaceDefault,
+					fmt.Sprintf("IPPool %s is already the IPv%d default of Namespace %s", pool.Name, *ipPool.Spec.IPVersion, ns),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateIPPoolDisableSNAT checks that SNAT is only disabled on the underlay
+// IPPools, whose IP addresses are routable out of the cluster, while the
+// egress traffic from the overlay ones is dropped without SNAT.
+func validateIPPoolDisableSNAT(ipPool *spiderpoolv1.SpiderIPPool) *field.Error {
+	if ipPool.Spec.DisableSNAT == nil || !*ipPool.Spec.DisableSNAT {
+		return nil
+	}
+
+	if !IsUnderlayIPPool(ipPool) {
+		return field.Invalid(
+			disableSNATField,
+			*ipPool.Spec.DisableSNAT,
+			fmt.Sprintf("only the underlay IPPool, with a non-zero spec.vlan or the annotation '%s', could disable SNAT", constant.AnnoIPPoolMasterInterface),
+		)
+	}
+
+	return nil
+}
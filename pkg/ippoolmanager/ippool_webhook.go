@@ -19,6 +19,8 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
 )
 
 var WebhookLogger *zap.Logger
@@ -30,6 +32,14 @@ type IPPoolWebhook struct {
 	EnableIPv4         bool
 	EnableIPv6         bool
 	EnableSpiderSubnet bool
+
+	// NetworkAttachmentWarningOnly reports the IPPool bound to an invalid
+	// NetworkAttachmentDefinition with a warning event instead of rejecting it.
+	NetworkAttachmentWarningOnly bool
+
+	// Reporter aggregates the denials of the webhook into the
+	// SpiderWebhookReport, it is optional.
+	Reporter webhookmanager.WebhookReporter
 }
 
 func (iw *IPPoolWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -39,8 +49,8 @@ func (iw *IPPoolWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&spiderpoolv1.SpiderIPPool{}).
-		WithDefaulter(iw).
-		WithValidator(iw).
+		WithDefaulter(recovery.Defaulter("IPPool-Webhook", iw)).
+		WithValidator(recovery.Validator("IPPool-Webhook", webhookmanager.DenialValidator(constant.SpiderIPPoolKind, iw, iw.Reporter))).
 		Complete()
 }
 
@@ -125,5 +135,30 @@ func (iw *IPPoolWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runt
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
 func (iw *IPPoolWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	ipPool := obj.(*spiderpoolv1.SpiderIPPool)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("IPPoolName", ipPool.Name),
+		zap.String("Operation", "DELETE"),
+	)
+	logger.Sugar().Debugf("Request IPPool: %+v", *ipPool)
+
+	if len(ipPool.Status.Shards) != 0 {
+		ipPool = ipPool.DeepCopy()
+		if err := AssembleIPPoolShards(ctx, iw.Client, nil, ipPool); err != nil {
+			logger.Sugar().Errorf("Failed to assemble IPPool: %v", err)
+			return apierrors.NewInternalError(err)
+		}
+	}
+
+	if err := validateDeleteIPPool(ipPool); err != nil {
+		logger.Sugar().Errorf("Failed to delete IPPool: %v", err)
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: constant.SpiderpoolAPIGroup, Resource: "spiderippools"},
+			ipPool.Name,
+			err,
+		)
+	}
+
 	return nil
 }
@@ -9,11 +9,14 @@ import (
 	"sync/atomic"
 
 	"github.com/agiledragon/gomonkey/v2"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -832,6 +835,524 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				})
 			})
 
+			When("Validating 'metadata.annotations'", func() {
+				It("inputs invalid StatefulSet ordinal IP annotation", func() {
+					ipPoolT.Annotations = map[string]string{constant.AnnoIPPoolStatefulSetOrdinalIP: "yes-please"}
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(constant.AnnoIPPoolStatefulSetOrdinalIP))
+				})
+
+				It("inputs invalid detach annotation", func() {
+					ipPoolT.Annotations = map[string]string{constant.AnnoIPPoolDetach: "later"}
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(constant.AnnoIPPoolDetach))
+				})
+
+				It("inputs invalid master interface annotation", func() {
+					ipPoolT.Annotations = map[string]string{constant.AnnoIPPoolMasterInterface: "interface-name-too-long"}
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(constant.AnnoIPPoolMasterInterface))
+				})
+
+				It("inputs invalid default route policy annotation", func() {
+					ipPoolT.Annotations = map[string]string{constant.AnnoDefaultRoutePolicy: "last-nic"}
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(constant.AnnoDefaultRoutePolicy))
+				})
+			})
+
+			When("Validating 'spec.dns'", func() {
+				BeforeEach(func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+				})
+
+				It("inputs invalid nameserver", func() {
+					ipPoolT.Spec.DNS = &spiderpoolv1.DNS{
+						Nameservers: []string{"10.6.0.53", constant.InvalidIP},
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.dns.nameservers[1]"))
+				})
+
+				It("inputs invalid domain", func() {
+					ipPoolT.Spec.DNS = &spiderpoolv1.DNS{
+						Domain: "Invalid_Domain",
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.dns.domain"))
+				})
+
+				It("inputs invalid search domain", func() {
+					ipPoolT.Spec.DNS = &spiderpoolv1.DNS{
+						Search: []string{"underlay.example.com.", "-invalid"},
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.dns.search[1]"))
+				})
+
+				It("inputs invalid option", func() {
+					ipPoolT.Spec.DNS = &spiderpoolv1.DNS{
+						Options: []string{"ndots:2", "timeout: 1"},
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.dns.options[1]"))
+				})
+			})
+
+			When("Validating 'spec.bandwidth'", func() {
+				It("inputs non-positive rate", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					egressRate := resource.MustParse("0")
+					ipPoolT.Spec.Bandwidth = &spiderpoolv1.Bandwidth{
+						EgressRate: &egressRate,
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.bandwidth.egressRate"))
+				})
+			})
+
+			When("Validating 'spec.allocationWindow'", func() {
+				It("inputs invalid schedule", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.AllocationWindow = &spiderpoolv1.AllocationWindow{
+						Schedules: []string{"* 0-8,18-23 * * 1-5", "* 0-24 * * *"},
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.allocationWindow.schedules[1]"))
+				})
+
+				It("inputs unknown time zone", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.AllocationWindow = &spiderpoolv1.AllocationWindow{
+						Schedules: []string{"* 0-8,18-23 * * 1-5"},
+						TimeZone:  pointer.String("Mars/Olympus"),
+					}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.allocationWindow.timeZone"))
+				})
+			})
+
+			When("Validating the bound NetworkAttachmentDefinition", func() {
+				var nadT *netv1.NetworkAttachmentDefinition
+
+				BeforeEach(func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+
+					nadT = &netv1.NetworkAttachmentDefinition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "macvlan-ens192",
+							Namespace: "kube-system",
+						},
+					}
+				})
+
+				AfterEach(func() {
+					ipPoolWebhook.NetworkAttachmentWarningOnly = false
+					err := fakeClient.Delete(context.TODO(), nadT)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				})
+
+				It("binds to the NetworkAttachmentDefinition in invalid form", func() {
+					ipPoolT.SetAnnotations(map[string]string{constant.AnnoNetworkAttachmentDefinition: "macvlan-ens192"})
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(constant.AnnoNetworkAttachmentDefinition))
+				})
+
+				It("binds to the NetworkAttachmentDefinition that does not exist", func() {
+					ipPoolT.SetAnnotations(map[string]string{constant.AnnoNetworkAttachmentDefinition: "kube-system/macvlan-ens192"})
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("Not found"))
+				})
+
+				It("binds to the NetworkAttachmentDefinition of incompatible CNI type", func() {
+					nadT.Spec.Config = `{"cniVersion":"0.3.1","name":"bridge","plugins":[{"type":"bridge"},{"type":"coordinator"}]}`
+					ctx := context.TODO()
+					err := fakeClient.Create(ctx, nadT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.SetAnnotations(map[string]string{constant.AnnoNetworkAttachmentDefinition: "kube-system/macvlan-ens192"})
+					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("bridge"))
+				})
+
+				It("binds to the NetworkAttachmentDefinition of macvlan", func() {
+					nadT.Spec.Config = `{"cniVersion":"0.3.1","name":"macvlan-ens192","plugins":[{"type":"macvlan","master":"ens192"},{"type":"coordinator"}]}`
+					ctx := context.TODO()
+					err := fakeClient.Create(ctx, nadT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.SetAnnotations(map[string]string{constant.AnnoNetworkAttachmentDefinition: "kube-system/macvlan-ens192"})
+					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("admits the NetworkAttachmentDefinition that does not exist in warning-only mode", func() {
+					ipPoolWebhook.NetworkAttachmentWarningOnly = true
+					ipPoolT.SetAnnotations(map[string]string{constant.AnnoNetworkAttachmentDefinition: "kube-system/macvlan-ens192"})
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("Validating 'spec.ipv6AssignmentMode'", func() {
+				It("sets the mode of IPv4 IPPool", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.IPv6AssignmentMode = pointer.String(constant.IPv6AssignmentModeSequential)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.ipv6AssignmentMode"))
+				})
+
+				It("sets mode eui64 with the prefix longer than 64", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/120"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::a")
+					ipPoolT.Spec.IPv6AssignmentMode = pointer.String(constant.IPv6AssignmentModeEUI64)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.ipv6AssignmentMode"))
+				})
+
+				It("sets unknown mode", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/120"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::a")
+					ipPoolT.Spec.IPv6AssignmentMode = pointer.String("unknown")
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.ipv6AssignmentMode"))
+				})
+
+				It("sets mode stable-privacy of IPv6 IPPool", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/120"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::a")
+					ipPoolT.Spec.IPv6AssignmentMode = pointer.String(constant.IPv6AssignmentModeStablePrivacy)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("Validating 'spec.delegatedPrefixLength'", func() {
+				It("sets the prefix length with 'spec.ips'", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(28)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.delegatedPrefixLength"))
+				})
+
+				It("sets the prefix length shorter than the subnet", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(16)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.delegatedPrefixLength"))
+				})
+
+				It("sets the prefix length without room for the Pod IP address", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(31)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.delegatedPrefixLength"))
+				})
+
+				It("shares the subnet with existing IPPool", func() {
+					ipVersion := constant.IPv6
+					subnet := "abcd:1234::/56"
+					cidr, err := spiderpoolip.CIDRToLabelValue(ipVersion, subnet)
+					Expect(err).NotTo(HaveOccurred())
+
+					existIPPoolT.Labels[constant.LabelIPPoolCIDR] = cidr
+					existIPPoolT.Spec.IPVersion = pointer.Int64(ipVersion)
+					existIPPoolT.Spec.Subnet = subnet
+					existIPPoolT.Spec.IPs = append(existIPPoolT.Spec.IPs, "abcd:1234::a")
+
+					ctx := context.TODO()
+					err = fakeClient.Create(ctx, existIPPoolT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.IPVersion = pointer.Int64(ipVersion)
+					ipPoolT.Spec.Subnet = subnet
+					ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(64)
+
+					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.subnet"))
+				})
+
+				It("delegates IPv6 prefixes", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/56"
+					ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(64)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("Validating 'spec.slaacCoexistence'", func() {
+				It("sets the coexistence of IPv4 IPPool", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.SLAACCoexistence = pointer.Bool(true)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.slaacCoexistence"))
+				})
+
+				It("sets the coexistence with the prefix other than 64", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/120"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::a")
+					ipPoolT.Spec.SLAACCoexistence = pointer.Bool(true)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.slaacCoexistence"))
+				})
+
+				It("sets the coexistence in mode eui64", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/64"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::a")
+					ipPoolT.Spec.IPv6AssignmentMode = pointer.String(constant.IPv6AssignmentModeEUI64)
+					ipPoolT.Spec.SLAACCoexistence = pointer.Bool(true)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.slaacCoexistence"))
+				})
+
+				It("sets the coexistence of IPv6 IPPool", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					ipPoolT.Spec.Subnet = "abcd:1234::/64"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "abcd:1234::a")
+					ipPoolT.Spec.SLAACCoexistence = pointer.Bool(true)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("Validating 'spec.disableSNAT'", func() {
+				BeforeEach(func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.10")
+					ipPoolT.Spec.DisableSNAT = pointer.Bool(true)
+				})
+
+				It("disables SNAT of the overlay IPPool", func() {
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.disableSNAT"))
+				})
+
+				It("disables SNAT of the IPPool in a VLAN", func() {
+					ipPoolT.Spec.Vlan = pointer.Int64(100)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("disables SNAT of the IPPool with a master interface", func() {
+					ipPoolT.SetAnnotations(map[string]string{constant.AnnoIPPoolMasterInterface: "eth1"})
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("Validating 'spec.namespaceDefault'", func() {
+				It("sets 'spec.namespaceDefault' without 'spec.default'", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.NamespaceDefault = []string{"ns1"}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.namespaceDefault"))
+				})
+
+				It("inputs invalid Namespace", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.Default = pointer.Bool(true)
+					ipPoolT.Spec.NamespaceDefault = []string{"Invalid_NS"}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.namespaceDefault"))
+				})
+
+				It("inputs duplicate Namespaces", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.Default = pointer.Bool(true)
+					ipPoolT.Spec.NamespaceDefault = []string{"ns1", "ns1"}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("spec.namespaceDefault"))
+				})
+
+				It("conflicts with the default IPPool of the same Namespace", func() {
+					existIPPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					existIPPoolT.Spec.Subnet = "172.18.41.0/24"
+					existIPPoolT.Spec.Default = pointer.Bool(true)
+					existIPPoolT.Spec.NamespaceDefault = []string{"ns1", "ns2"}
+
+					ctx := context.TODO()
+					err := fakeClient.Create(ctx, existIPPoolT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.Default = pointer.Bool(true)
+					ipPoolT.Spec.NamespaceDefault = []string{"ns2"}
+
+					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(existIPPoolName))
+				})
+
+				It("conflicts with the default IPPool of the cluster", func() {
+					existIPPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					existIPPoolT.Spec.Subnet = "172.18.41.0/24"
+					existIPPoolT.Spec.Default = pointer.Bool(true)
+
+					ctx := context.TODO()
+					err := fakeClient.Create(ctx, existIPPoolT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.Default = pointer.Bool(true)
+
+					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(existIPPoolName))
+				})
+
+				It("shares the Namespace with the default IPPool of another IP version", func() {
+					existIPPoolT.Spec.IPVersion = pointer.Int64(constant.IPv6)
+					existIPPoolT.Spec.Subnet = "abcd:1234::/120"
+					existIPPoolT.Spec.Default = pointer.Bool(true)
+					existIPPoolT.Spec.NamespaceDefault = []string{"ns1"}
+
+					ctx := context.TODO()
+					err := fakeClient.Create(ctx, existIPPoolT)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.Default = pointer.Bool(true)
+					ipPoolT.Spec.NamespaceDefault = []string{"ns1"}
+
+					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			When("Validating 'spec.routes'", func() {
 				It("inputs invalid destination", func() {
 					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
@@ -943,6 +1464,9 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 					ctx := context.TODO()
 					err = ipPoolWebhook.ValidateCreate(ctx, ipPoolT)
 					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.(apierrors.APIStatus).Status().Details.Causes).To(ContainElement(
+						HaveField("Type", metav1.CauseType(field.ErrorTypeNotFound)),
+					))
 				})
 
 				It("sets owner reference to a terminating Subnet", func() {
@@ -1188,6 +1712,21 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				})
 			})
 
+			When("Validating 'spec.delegatedPrefixLength'", func() {
+				It("changes 'spec.delegatedPrefixLength'", func() {
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.DelegatedPrefixLength = pointer.Int32(28)
+
+					newIPPoolT := ipPoolT.DeepCopy()
+					newIPPoolT.Spec.DelegatedPrefixLength = pointer.Int32(27)
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateUpdate(ctx, ipPoolT, newIPPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				})
+			})
+
 			When("Validating 'spec.ips'", func() {
 				It("appends invalid IP range to 'spec.ips'", func() {
 					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
@@ -1556,6 +2095,44 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				})
 			})
 
+			When("Validating the minimum free IP addresses of the controller Subnet", func() {
+				BeforeEach(func() {
+					ipPoolWebhook.EnableSpiderSubnet = true
+
+					subnetT.SetUID(uuid.NewUUID())
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.1-172.18.40.4")
+					subnetT.Spec.MinFreeIPs = pointer.Int64(2)
+
+					err := fakeClient.Create(context.TODO(), subnetT)
+					Expect(err).NotTo(HaveOccurred())
+
+					err = controllerutil.SetControllerReference(subnetT, ipPoolT, scheme)
+					Expect(err).NotTo(HaveOccurred())
+
+					ipPoolT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					ipPoolT.Spec.Subnet = "172.18.40.0/24"
+					ipPoolT.Spec.IPs = append(ipPoolT.Spec.IPs, "172.18.40.1")
+				})
+
+				It("scales up within the headroom", func() {
+					newIPPoolT := ipPoolT.DeepCopy()
+					newIPPoolT.Spec.IPs = []string{"172.18.40.1-172.18.40.2"}
+
+					err := ipPoolWebhook.ValidateUpdate(context.TODO(), ipPoolT, newIPPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("scales up beyond the headroom", func() {
+					newIPPoolT := ipPoolT.DeepCopy()
+					newIPPoolT.Spec.IPs = []string{"172.18.40.1-172.18.40.3"}
+
+					err := ipPoolWebhook.ValidateUpdate(context.TODO(), ipPoolT, newIPPoolT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				})
+			})
+
 			It("deletes IPPool", func() {
 				controllerutil.AddFinalizer(ipPoolT, constant.SpiderFinalizer)
 				now := metav1.Now()
@@ -1668,6 +2245,43 @@ var _ = Describe("IPPoolWebhook", Label("ippool_webhook_test"), func() {
 				err := ipPoolWebhook.ValidateDelete(ctx, ipPoolT)
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			When("IP addresses are in use", func() {
+				BeforeEach(func() {
+					ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+						"172.18.40.10": spiderpoolv1.PoolIPAllocation{
+							ContainerID: "container",
+							NIC:         "eth0",
+							Node:        "node",
+							Namespace:   "default",
+							Pod:         "pod",
+						},
+					}
+				})
+
+				It("rejects deleting the IPPool and lists the blocking Pods", func() {
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateDelete(ctx, ipPoolT)
+					Expect(apierrors.IsForbidden(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring("default/pod"))
+				})
+
+				It("deletes the IPPool forcibly", func() {
+					ipPoolT.Annotations = map[string]string{constant.AnnoIPPoolForceDelete: constant.True}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateDelete(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the auto-created IPPool", func() {
+					ipPoolT.Labels = map[string]string{constant.LabelIPPoolOwnerApplication: "Deployment_default_demo"}
+
+					ctx := context.TODO()
+					err := ipPoolWebhook.ValidateDelete(ctx, ipPoolT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
 		})
 	})
 })
@@ -0,0 +1,112 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// NetworkAttachmentPluginTypes are the main CNI plugins of the Multus
+// networks which SpiderSubnets and IPPools could be bound to.
+var NetworkAttachmentPluginTypes = []string{"macvlan", "ipvlan", "sriov"}
+
+// ValidateNetworkAttachment checks that the NetworkAttachmentDefinition the
+// SpiderSubnet or IPPool is bound to with the annotation
+// "ipam.spidernet.io/network-attachment-definition" exists, and its main
+// CNI plugin is one of NetworkAttachmentPluginTypes.
+//
+// With warningOnly, the failure is reported as a warning event of the object
+// rather than rejecting it, for the GitOps flows which may apply the
+// NetworkAttachmentDefinition after the object.
+func ValidateNetworkAttachment(ctx context.Context, c client.Reader, obj client.Object, warningOnly bool) *field.Error {
+	value, ok := obj.GetAnnotations()[constant.AnnoNetworkAttachmentDefinition]
+	if !ok {
+		return nil
+	}
+
+	err := validateNetworkAttachment(ctx, c, value)
+	if err == nil || !warningOnly {
+		return err
+	}
+
+	logger := logutils.FromContext(ctx)
+	logger.Sugar().Warnf("Admit with invalid NetworkAttachmentDefinition: %v", err)
+	event.EventRecorder.Eventf(obj, corev1.EventTypeWarning, constant.EventReasonInvalidNetworkAttachment, err.Error())
+
+	return nil
+}
+
+func validateNetworkAttachment(ctx context.Context, c client.Reader, value string) *field.Error {
+	fieldPath := annotationsField.Key(constant.AnnoNetworkAttachmentDefinition)
+
+	namespace, name, found := strings.Cut(value, "/")
+	if !found || namespace == "" || name == "" {
+		return field.Invalid(fieldPath, value, "must be in the form of '<namespace>/<name>'")
+	}
+
+	var nad netv1.NetworkAttachmentDefinition
+	if err := c.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: name}, &nad); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(fieldPath, value)
+		}
+		if apimeta.IsNoMatchError(err) {
+			return field.Invalid(fieldPath, value, "the CRD of NetworkAttachmentDefinition is not installed")
+		}
+
+		return field.InternalError(fieldPath, err)
+	}
+
+	// An empty config refers to the CNI config file of the same name on
+	// each node, which is out of sight.
+	if nad.Spec.Config == "" {
+		return nil
+	}
+
+	pluginType, err := mainPluginType(nad.Spec.Config)
+	if err != nil {
+		return field.Invalid(fieldPath, value, err.Error())
+	}
+	for _, t := range NetworkAttachmentPluginTypes {
+		if pluginType == t {
+			return nil
+		}
+	}
+
+	return field.Invalid(fieldPath, value, fmt.Sprintf("the CNI type '%s' is not one of %v", pluginType, NetworkAttachmentPluginTypes))
+}
+
+// mainPluginType returns the type of the first plugin of the CNI config or
+// config list.
+func mainPluginType(config string) (string, error) {
+	var conf struct {
+		Type    string `json:"type"`
+		Plugins []struct {
+			Type string `json:"type"`
+		} `json:"plugins"`
+	}
+	if err := json.Unmarshal([]byte(config), &conf); err != nil {
+		return "", fmt.Errorf("invalid CNI config: %w", err)
+	}
+
+	if len(conf.Plugins) != 0 {
+		return conf.Plugins[0].Type, nil
+	}
+
+	return conf.Type, nil
+}
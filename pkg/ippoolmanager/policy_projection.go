@@ -0,0 +1,123 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"encoding/json"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// PolicyProjection is the normalized projection of the rules of an IPPool,
+// written to the annotation "ipam.spidernet.io/policy-projection" of the
+// IPPool, so that policy engines like Gatekeeper could validate the Pods
+// against the IPPools they use without interpreting the full spec.
+type PolicyProjection struct {
+	IPVersion int64  `json:"ipVersion"`
+	Subnet    string `json:"subnet"`
+	Vlan      int64  `json:"vlan"`
+	Disabled  bool   `json:"disabled"`
+	// TotalIPCount is the number of the IP addresses the IPPool could
+	// allocate, jointly determined by spec.ips and spec.excludeIPs.
+	TotalIPCount int64 `json:"totalIPCount"`
+	// OwnerSubnet is the SpiderSubnet controlling the IPPool.
+	OwnerSubnet string `json:"ownerSubnet,omitempty"`
+	// OwnerApplication is the application of the auto-created IPPool, in the
+	// format of "{appKind}_{appNS}_{appName}".
+	OwnerApplication string `json:"ownerApplication,omitempty"`
+	// The selectors are the requirements that must all be met, the empty
+	// selector matches everything.
+	PodSelector       []SelectorRequirement `json:"podSelector,omitempty"`
+	NamespaceSelector []SelectorRequirement `json:"namespaceSelector,omitempty"`
+	NodeSelector      []SelectorRequirement `json:"nodeSelector,omitempty"`
+}
+
+// SelectorRequirement is a requirement of a label selector, the matchLabels
+// are projected to the requirements with the operator In.
+type SelectorRequirement struct {
+	Key      string   `json:"key"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// ProjectPolicy returns the policy projection of the IPPool in JSON, which is
+// stable as long as the rules of the IPPool don't change.
+func ProjectPolicy(pool *spiderpoolv1.SpiderIPPool) (string, error) {
+	ipVersion := pointer.Int64Deref(pool.Spec.IPVersion, constant.IPv4)
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(ipVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if err != nil {
+		return "", err
+	}
+
+	projection := PolicyProjection{
+		IPVersion:         ipVersion,
+		Subnet:            pool.Spec.Subnet,
+		Vlan:              pointer.Int64Deref(pool.Spec.Vlan, 0),
+		Disabled:          pointer.BoolDeref(pool.Spec.Disable, false),
+		TotalIPCount:      int64(len(totalIPs)),
+		OwnerSubnet:       pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet],
+		OwnerApplication:  pool.Labels[constant.LabelIPPoolOwnerApplication],
+		PodSelector:       projectSelector(pool.Spec.PodAffinity),
+		NamespaceSelector: projectSelector(pool.Spec.NamespaceAffinity),
+		NodeSelector:      projectSelector(pool.Spec.NodeAffinity),
+	}
+
+	b, err := json.Marshal(projection)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// IsPolicyProjectionUpToDate checks whether the annotation
+// "ipam.spidernet.io/policy-projection" of the IPPool is up to date.
+func IsPolicyProjectionUpToDate(pool *spiderpoolv1.SpiderIPPool) bool {
+	projection, err := ProjectPolicy(pool)
+	if err != nil {
+		// the invalid IPPool is not projected
+		return true
+	}
+
+	return pool.Annotations[constant.AnnoIPPoolPolicyProjection] == projection
+}
+
+func projectSelector(selector *metav1.LabelSelector) []SelectorRequirement {
+	if selector == nil {
+		return nil
+	}
+
+	var requirements []SelectorRequirement
+	for key, value := range selector.MatchLabels {
+		requirements = append(requirements, SelectorRequirement{
+			Key:      key,
+			Operator: string(metav1.LabelSelectorOpIn),
+			Values:   []string{value},
+		})
+	}
+	for _, expr := range selector.MatchExpressions {
+		values := append([]string(nil), expr.Values...)
+		sort.Strings(values)
+		requirements = append(requirements, SelectorRequirement{
+			Key:      expr.Key,
+			Operator: string(expr.Operator),
+			Values:   values,
+		})
+	}
+
+	sort.SliceStable(requirements, func(i, j int) bool {
+		if requirements[i].Key != requirements[j].Key {
+			return requirements[i].Key < requirements[j].Key
+		}
+		return requirements[i].Operator < requirements[j].Operator
+	})
+
+	return requirements
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("PolicyProjection", Label("policy_projection_test"), func() {
+	var pool *spiderpoolv1.SpiderIPPool
+
+	BeforeEach(func() {
+		pool = &spiderpoolv1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pool",
+				Labels: map[string]string{
+					constant.LabelIPPoolOwnerSpiderSubnet: "subnet",
+				},
+			},
+			Spec: spiderpoolv1.IPPoolSpec{
+				IPVersion:  pointer.Int64(constant.IPv4),
+				Subnet:     "172.18.40.0/24",
+				IPs:        []string{"172.18.40.10-172.18.40.19"},
+				ExcludeIPs: []string{"172.18.40.19"},
+				Vlan:       pointer.Int64(100),
+				NamespaceAffinity: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "env",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"test", "dev"},
+					}},
+				},
+			},
+		}
+	})
+
+	It("projects the rules of the IPPool", func() {
+		projection, err := ippoolmanager.ProjectPolicy(pool)
+		Expect(err).NotTo(HaveOccurred())
+
+		var got ippoolmanager.PolicyProjection
+		Expect(json.Unmarshal([]byte(projection), &got)).To(Succeed())
+		Expect(got).To(Equal(ippoolmanager.PolicyProjection{
+			IPVersion:    constant.IPv4,
+			Subnet:       "172.18.40.0/24",
+			Vlan:         100,
+			TotalIPCount: 9,
+			OwnerSubnet:  "subnet",
+			NamespaceSelector: []ippoolmanager.SelectorRequirement{
+				{Key: "env", Operator: "In", Values: []string{"dev", "test"}},
+				{Key: "team", Operator: "In", Values: []string{"a"}},
+			},
+		}))
+	})
+
+	It("omits the empty selectors", func() {
+		pool.Spec.NamespaceAffinity = nil
+
+		projection, err := ippoolmanager.ProjectPolicy(pool)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(projection).NotTo(ContainSubstring("Selector"))
+	})
+
+	It("checks whether the projection is up to date", func() {
+		Expect(ippoolmanager.IsPolicyProjectionUpToDate(pool)).To(BeFalse())
+
+		projection, err := ippoolmanager.ProjectPolicy(pool)
+		Expect(err).NotTo(HaveOccurred())
+		pool.Annotations = map[string]string{constant.AnnoIPPoolPolicyProjection: projection}
+		Expect(ippoolmanager.IsPolicyProjectionUpToDate(pool)).To(BeTrue())
+
+		pool.Spec.Disable = pointer.Bool(true)
+		Expect(ippoolmanager.IsPolicyProjectionUpToDate(pool)).To(BeFalse())
+	})
+})
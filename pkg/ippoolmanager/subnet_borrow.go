@@ -0,0 +1,243 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// BorrowedIPPoolName returns the name of the IPPool borrowing IP addresses
+// from a sibling SpiderSubnet for the auto-created IPPool.
+func BorrowedIPPoolName(poolName string) string {
+	return poolName + "-borrowed"
+}
+
+// IsBorrowedIPPool checks whether the IPPool borrows IP addresses from a
+// sibling SpiderSubnet for an auto-created IPPool.
+func IsBorrowedIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	_, ok := pool.GetLabels()[constant.LabelIPPoolBorrowedForSubnet]
+	return ok
+}
+
+// autoIPPoolDeficit returns the number of IP addresses the auto-created
+// IPPool still lacks for its desired IP number.
+func autoIPPoolDeficit(pool *spiderpoolv1.SpiderIPPool) int {
+	if pool.Status.AutoDesiredIPCount == nil || pool.Spec.IPVersion == nil {
+		return 0
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if err != nil {
+		return 0
+	}
+
+	deficit := int(*pool.Status.AutoDesiredIPCount) - len(totalIPs)
+	if deficit < 0 {
+		return 0
+	}
+
+	return deficit
+}
+
+// SiblingSubnets returns the SpiderSubnets in the same borrow group as the
+// given one with the same IP version and VLAN, ordered by their free IP
+// addresses, the most the first.
+func SiblingSubnets(subnet *spiderpoolv1.SpiderSubnet, subnets []*spiderpoolv1.SpiderSubnet) []*spiderpoolv1.SpiderSubnet {
+	group := subnet.Annotations[constant.AnnoSubnetBorrowGroup]
+	if group == "" {
+		return nil
+	}
+
+	var siblings []*spiderpoolv1.SpiderSubnet
+	for _, s := range subnets {
+		if s.Name == subnet.Name || s.DeletionTimestamp != nil || s.Annotations[constant.AnnoSubnetBorrowGroup] != group {
+			continue
+		}
+		if pointer.Int64Deref(s.Spec.IPVersion, 0) != pointer.Int64Deref(subnet.Spec.IPVersion, 0) ||
+			pointer.Int64Deref(s.Spec.Vlan, 0) != pointer.Int64Deref(subnet.Spec.Vlan, 0) {
+			continue
+		}
+		siblings = append(siblings, s)
+	}
+
+	freeIPCount := func(s *spiderpoolv1.SpiderSubnet) int64 {
+		return pointer.Int64Deref(s.Status.TotalIPCount, 0) - pointer.Int64Deref(s.Status.AllocatedIPCount, 0)
+	}
+	sort.SliceStable(siblings, func(i, j int) bool {
+		if freeIPCount(siblings[i]) != freeIPCount(siblings[j]) {
+			return freeIPCount(siblings[i]) > freeIPCount(siblings[j])
+		}
+		return siblings[i].Name < siblings[j].Name
+	})
+
+	return siblings
+}
+
+// borrowIPs lets the auto-created IPPool of the exhausted SpiderSubnet borrow
+// the IP addresses it lacks from a sibling SpiderSubnet in the same borrow
+// group, with an auto-created IPPool of the sibling flagged as borrowed and
+// tried by IPAM after the IPPool. It returns false if there's no sibling
+// SpiderSubnet with enough free IP addresses.
+func (ic *IPPoolController) borrowIPs(ctx context.Context, pool *spiderpoolv1.SpiderIPPool, subnetName string) (bool, error) {
+	deficit := autoIPPoolDeficit(pool)
+	if deficit == 0 {
+		return false, nil
+	}
+
+	borrowed, err := ic.poolLister.Get(BorrowedIPPoolName(pool.Name))
+	if err == nil {
+		borrowed = borrowed.DeepCopy()
+		if err := AssembleIPPoolShards(ctx, ic.client, nil, borrowed); err != nil {
+			return false, err
+		}
+
+		// keep borrowing from the same sibling, the IP addresses in use are never returned
+		desired := int64(deficit)
+		if used := int64(len(usedIPsOfIPPool(borrowed))); used > desired {
+			desired = used
+		}
+		if pointer.Int64Deref(borrowed.Status.AutoDesiredIPCount, -1) == desired {
+			return true, nil
+		}
+
+		borrowed.Status.AutoDesiredIPCount = pointer.Int64(desired)
+		if err := ic.updateIPPoolStatus(ctx, borrowed); err != nil {
+			return false, err
+		}
+		informerLogger.Sugar().Infof("update borrowed IPPool '%s' status AutoDesiredIPCount to '%d' for IPPool '%s'", borrowed.Name, desired, pool.Name)
+		return true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	subnet, err := ic.subnetsLister.Get(subnetName)
+	if err != nil {
+		return false, err
+	}
+	subnets, err := ic.subnetsLister.List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+
+	var sibling *spiderpoolv1.SpiderSubnet
+	for _, s := range SiblingSubnets(subnet, subnets) {
+		if pointer.Int64Deref(s.Status.TotalIPCount, 0)-pointer.Int64Deref(s.Status.AllocatedIPCount, 0) >= int64(deficit) {
+			sibling = s
+			break
+		}
+	}
+	if sibling == nil {
+		return false, nil
+	}
+
+	borrowed = &spiderpoolv1.SpiderIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        BorrowedIPPoolName(pool.Name),
+			Labels:      map[string]string{},
+			Annotations: map[string]string{constant.AnnoIPPoolBorrowedFor: pool.Name},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(sibling, spiderpoolv1.SchemeGroupVersion.WithKind(constant.SpiderSubnetKind)),
+			},
+		},
+		Spec: spiderpoolv1.IPPoolSpec{
+			IPVersion:   pool.Spec.IPVersion,
+			Subnet:      sibling.Spec.Subnet,
+			Gateway:     sibling.Spec.Gateway,
+			Vlan:        sibling.Spec.Vlan,
+			Routes:      sibling.Spec.Routes,
+			PodAffinity: pool.Spec.PodAffinity,
+		},
+	}
+	for k, v := range pool.Labels {
+		borrowed.Labels[k] = v
+	}
+	borrowed.Labels[constant.LabelIPPoolOwnerSpiderSubnet] = sibling.Name
+	borrowed.Labels[constant.LabelIPPoolBorrowedForSubnet] = subnetName
+
+	informerLogger.Sugar().Infof("try to borrow '%d' IPs from SpiderSubnet '%s' for IPPool '%s'", deficit, sibling.Name, pool.Name)
+	if err := ic.client.Create(ctx, borrowed); err != nil {
+		return false, err
+	}
+	borrowed.Status.AutoDesiredIPCount = pointer.Int64(int64(deficit))
+	if err := ic.client.Status().Update(ctx, borrowed); err != nil {
+		return false, err
+	}
+
+	event.EventRecorder.Eventf(pool, corev1.EventTypeWarning, constant.EventReasonBorrowIPs,
+		"SpiderSubnet %s is exhausted, borrowed %d IP addresses from SpiderSubnet %s with IPPool %s", subnetName, deficit, sibling.Name, borrowed.Name)
+
+	return true, nil
+}
+
+// returnBorrowedIPs returns the IP addresses of the borrowed IPPool to its
+// SpiderSubnet once the auto-created IPPool it serves no longer lacks IP
+// addresses, or is gone. The IP addresses in use are kept until they are
+// released, and the borrowed IPPool is deleted once all of them are
+// returned. It returns true if the IPPool is updated or deleted.
+func (ic *IPPoolController) returnBorrowedIPs(ctx context.Context, pool *spiderpoolv1.SpiderIPPool) (bool, error) {
+	if pool.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	primary, err := ic.poolLister.Get(pool.Annotations[constant.AnnoIPPoolBorrowedFor])
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if err == nil && primary.DeletionTimestamp == nil && autoIPPoolDeficit(primary) > 0 {
+		return false, nil
+	}
+
+	used := int64(len(usedIPsOfIPPool(pool)))
+	if used == 0 {
+		informerLogger.Sugar().Infof("try to delete borrowed IPPool '%s' with all IPs returned", pool.Name)
+		if err := ic.client.Delete(ctx, pool); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+		if primary != nil {
+			event.EventRecorder.Eventf(primary, corev1.EventTypeNormal, constant.EventReasonReturnIPs,
+				"Returned all the IP addresses borrowed from SpiderSubnet %s with IPPool %s", pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet], pool.Name)
+		}
+		return true, nil
+	}
+
+	if pointer.Int64Deref(pool.Status.AutoDesiredIPCount, -1) == used {
+		return false, nil
+	}
+
+	pool.Status.AutoDesiredIPCount = pointer.Int64(used)
+	if err := ic.updateIPPoolStatus(ctx, pool); err != nil {
+		return false, err
+	}
+	informerLogger.Sugar().Infof("return the free IPs of borrowed IPPool '%s', keep '%d' IPs in use", pool.Name, used)
+
+	return true, nil
+}
+
+// enqueueBorrowedIPPool enqueues the IPPool borrowing IP addresses for the
+// auto-created IPPool if any, to check whether they can be returned.
+func (ic *IPPoolController) enqueueBorrowedIPPool(pool *spiderpoolv1.SpiderIPPool) {
+	borrowed, err := ic.poolLister.Get(BorrowedIPPoolName(pool.Name))
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			informerLogger.Sugar().Errorf("failed to get the borrowed IPPool of IPPool '%s': %v", pool.Name, err)
+		}
+		return
+	}
+
+	ic.enqueueIPPool(borrowed)
+}
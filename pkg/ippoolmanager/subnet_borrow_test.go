@@ -0,0 +1,73 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("SubnetBorrow", Label("subnet_borrow_test"), func() {
+	newSubnet := func(name, group string, vlan, total, allocated int64) *spiderpoolv1.SpiderSubnet {
+		subnet := &spiderpoolv1.SpiderSubnet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: spiderpoolv1.SubnetSpec{
+				IPVersion: pointer.Int64(constant.IPv4),
+				Vlan:      pointer.Int64(vlan),
+			},
+			Status: spiderpoolv1.SubnetStatus{
+				TotalIPCount:     pointer.Int64(total),
+				AllocatedIPCount: pointer.Int64(allocated),
+			},
+		}
+		if group != "" {
+			subnet.Annotations = map[string]string{constant.AnnoSubnetBorrowGroup: group}
+		}
+		return subnet
+	}
+
+	Describe("IsBorrowedIPPool", func() {
+		It("checks the borrowed label", func() {
+			pool := &spiderpoolv1.SpiderIPPool{}
+			Expect(ippoolmanager.IsBorrowedIPPool(pool)).To(BeFalse())
+
+			pool.Labels = map[string]string{constant.LabelIPPoolBorrowedForSubnet: "subnet"}
+			Expect(ippoolmanager.IsBorrowedIPPool(pool)).To(BeTrue())
+		})
+	})
+
+	Describe("SiblingSubnets", func() {
+		It("returns nothing for the SpiderSubnet out of any borrow group", func() {
+			subnet := newSubnet("subnet", "", 0, 10, 10)
+			Expect(ippoolmanager.SiblingSubnets(subnet, []*spiderpoolv1.SpiderSubnet{
+				subnet,
+				newSubnet("other", "", 0, 10, 0),
+			})).To(BeEmpty())
+		})
+
+		It("returns the SpiderSubnets of the same group, IP version and VLAN, the most free first", func() {
+			subnet := newSubnet("subnet", "rack1", 10, 10, 10)
+			sibling1 := newSubnet("sibling1", "rack1", 10, 10, 8)
+			sibling2 := newSubnet("sibling2", "rack1", 10, 10, 5)
+			sibling3 := newSubnet("sibling3", "rack1", 10, 10, 8)
+			otherGroup := newSubnet("other-group", "rack2", 10, 10, 0)
+			otherVLAN := newSubnet("other-vlan", "rack1", 20, 10, 0)
+			otherVersion := newSubnet("other-version", "rack1", 10, 10, 0)
+			otherVersion.Spec.IPVersion = pointer.Int64(constant.IPv6)
+			terminating := newSubnet("terminating", "rack1", 10, 10, 0)
+			terminating.DeletionTimestamp = &metav1.Time{}
+
+			siblings := ippoolmanager.SiblingSubnets(subnet, []*spiderpoolv1.SpiderSubnet{
+				subnet, sibling3, sibling1, otherGroup, otherVLAN, otherVersion, terminating, sibling2,
+			})
+			Expect(siblings).To(Equal([]*spiderpoolv1.SpiderSubnet{sibling2, sibling1, sibling3}))
+		})
+	})
+})
@@ -4,12 +4,25 @@
 package ippoolmanager
 
 import (
+	"encoding/json"
+	"fmt"
 	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	"github.com/spidernet-io/spiderpool/api/v1/agent/models"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
 )
 
 func genResIPConfig(allocateIP net.IP, nic string, ipPool *spiderpoolv1.SpiderIPPool) *models.IPConfig {
@@ -50,3 +63,605 @@ func IsAutoCreatedIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
 	_, ok := poolLabels[constant.LabelIPPoolOwnerApplication]
 	return ok
 }
+
+// IsDefaultIPPool reports whether the IPPool is a default one, of the whole
+// cluster or of the Namespaces in 'spec.namespaceDefault'.
+func IsDefaultIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	return pointer.BoolDeref(pool.Spec.Default, false)
+}
+
+// IsDefaultIPPoolOf reports whether the IPPool is the default of namespace,
+// or of the whole cluster if namespace is empty.
+func IsDefaultIPPoolOf(pool *spiderpoolv1.SpiderIPPool, namespace string) bool {
+	if !IsDefaultIPPool(pool) {
+		return false
+	}
+	if namespace == "" {
+		return len(pool.Spec.NamespaceDefault) == 0
+	}
+
+	for _, ns := range pool.Spec.NamespaceDefault {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsDetachRequested checks whether the auto-created IPPool is requested to be
+// detached from its application by the annotation "ipam.spidernet.io/detach".
+func IsDetachRequested(annotations map[string]string) bool {
+	detach, err := strconv.ParseBool(annotations[constant.AnnoIPPoolDetach])
+	return err == nil && detach
+}
+
+// IsPruneExempt checks whether the auto-created IPPool is exempted from
+// being pruned by the annotation "ipam.spidernet.io/prune-exempt".
+func IsPruneExempt(annotations map[string]string) bool {
+	exempt, err := strconv.ParseBool(annotations[constant.AnnoIPPoolPruneExempt])
+	return err == nil && exempt
+}
+
+// DetachAutoIPPool removes the reclaim label of the auto-created IPPool, and
+// removes the labels binding it to its application if the application is
+// gone, so that it becomes a normal IPPool. It reports whether the labels
+// changed.
+func DetachAutoIPPool(pool *spiderpoolv1.SpiderIPPool, appGone bool) bool {
+	keys := []string{constant.LabelIPPoolReclaimIPPool}
+	if appGone {
+		keys = append(keys,
+			constant.LabelIPPoolOwnerApplication,
+			constant.LabelIPPoolOwnerApplicationUID,
+			constant.LabelIPPoolInterface,
+		)
+	}
+
+	changed := false
+	for _, key := range keys {
+		if _, ok := pool.Labels[key]; ok {
+			delete(pool.Labels, key)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// IsReconcilePaused checks whether the resizing of auto-created IPPools is
+// paused by the annotation "ipam.spidernet.io/reconcile: paused" of the
+// application or the IPPool.
+func IsReconcilePaused(annotations map[string]string) bool {
+	return annotations[constant.AnnoReconcile] == constant.AnnoReconcilePaused
+}
+
+// SetReconcilePaused sets or removes the condition ReconcilePaused of the
+// IPPool with the reason, and reports whether the status changed. The
+// condition set with other reasons is not removed, so that the application
+// and the IPPool could pause the IPPool independently.
+func SetReconcilePaused(pool *spiderpoolv1.SpiderIPPool, paused bool, reason string) bool {
+	cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionReconcilePaused)
+	if !paused {
+		if cond == nil || cond.Reason != reason {
+			return false
+		}
+		apimeta.RemoveStatusCondition(&pool.Status.Conditions, constant.IPPoolConditionReconcilePaused)
+		return true
+	}
+
+	if cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == reason {
+		return false
+	}
+
+	apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+		Type:               constant.IPPoolConditionReconcilePaused,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pool.Generation,
+		Reason:             reason,
+		Message:            fmt.Sprintf("the resizing is paused by the annotation %s: %s", constant.AnnoReconcile, constant.AnnoReconcilePaused),
+	})
+
+	return true
+}
+
+// SetExhaustionImminent sets or removes the condition ExhaustionImminent of
+// the IPPool, and reports whether the status changed. The message is only
+// written when the condition is set, so that the changing ETA doesn't update
+// the status all the time.
+func SetExhaustionImminent(pool *spiderpoolv1.SpiderIPPool, imminent bool, eta time.Duration) bool {
+	cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionExhaustionImminent)
+	if !imminent {
+		if cond == nil {
+			return false
+		}
+		apimeta.RemoveStatusCondition(&pool.Status.Conditions, constant.IPPoolConditionExhaustionImminent)
+		return true
+	}
+
+	if cond != nil && cond.Status == metav1.ConditionTrue {
+		return false
+	}
+
+	apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+		Type:               constant.IPPoolConditionExhaustionImminent,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pool.Generation,
+		Reason:             constant.ReasonAllocationRate,
+		Message:            fmt.Sprintf("the IPPool is forecast to be exhausted in %s with its allocation rate", eta.Round(time.Second)),
+	})
+
+	return true
+}
+
+// SetIdle sets or removes the condition Idle of the IPPool with the reason,
+// and reports whether the status changed. The last transition time of the
+// condition is kept while the IPPool stays idle, even if the reason changes.
+func SetIdle(pool *spiderpoolv1.SpiderIPPool, idle bool, reason string) bool {
+	cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionIdle)
+	if !idle {
+		if cond == nil {
+			return false
+		}
+		apimeta.RemoveStatusCondition(&pool.Status.Conditions, constant.IPPoolConditionIdle)
+		return true
+	}
+
+	if cond != nil && cond.Status == metav1.ConditionTrue && cond.Reason == reason {
+		return false
+	}
+
+	apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+		Type:               constant.IPPoolConditionIdle,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pool.Generation,
+		Reason:             reason,
+		Message:            "the IPPool has no IP addresses allocated and its application is gone or scaled to zero",
+	})
+
+	return true
+}
+
+// IdleDuration returns how long the IPPool has been idle at the time now,
+// and false if it is not idle.
+func IdleDuration(pool *spiderpoolv1.SpiderIPPool, now time.Time) (time.Duration, bool) {
+	cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionIdle)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return 0, false
+	}
+
+	return now.Sub(cond.LastTransitionTime.Time), true
+}
+
+// AutoExpandedIPCount returns the number of IP addresses the auto-created
+// IPPool should be expanded with on its utilization pressure. The expansion
+// grows by step once the utilization percentage of the IPPool reaches the
+// threshold, bounded by max, and it is retracted by step as long as the
+// utilization stays below the threshold without it.
+func AutoExpandedIPCount(pool *spiderpoolv1.SpiderIPPool, threshold, step, max int) int64 {
+	expanded := pointer.Int64Deref(pool.Status.AutoExpandedIPCount, 0)
+	if pool.Status.AutoDesiredIPCount == nil {
+		return expanded
+	}
+
+	used := int64(len(usedIPsOfIPPool(pool)))
+	if threshold <= 0 || step <= 0 || max <= 0 || used == 0 {
+		return 0
+	}
+
+	desired := *pool.Status.AutoDesiredIPCount
+	if used*100 >= int64(threshold)*desired {
+		expanded += int64(step)
+		if expanded > int64(max) {
+			expanded = int64(max)
+		}
+		return expanded
+	}
+
+	base := desired - expanded
+	for expanded > 0 {
+		retracted := expanded - int64(step)
+		if retracted < 0 {
+			retracted = 0
+		}
+		if used*100 >= int64(threshold)*(base+retracted) {
+			break
+		}
+		expanded = retracted
+	}
+
+	return expanded
+}
+
+// FreeIPRangeCount returns the number of the contiguous ranges which the free
+// IP addresses of the IPPool are split into, and the number of the free IP
+// addresses. The reserved IP addresses are not taken into account.
+func FreeIPRangeCount(pool *spiderpoolv1.SpiderIPPool) (int, int, error) {
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if err != nil {
+		return 0, 0, err
+	}
+	usedIPs, err := spiderpoolip.ParseIPRanges(*pool.Spec.IPVersion, usedIPsOfIPPool(pool))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	freeIPs := spiderpoolip.IPsDiffSet(totalIPs, usedIPs, true)
+	ranges := 0
+	for i, ip := range freeIPs {
+		if i == 0 || !spiderpoolip.NextIP(freeIPs[i-1]).Equal(ip) {
+			ranges++
+		}
+	}
+
+	return ranges, len(freeIPs), nil
+}
+
+// SetFragmented sets or removes the condition Fragmented of the IPPool, and
+// reports whether the status changed. Like the condition ExhaustionImminent,
+// the message is only written when the condition is set.
+func SetFragmented(pool *spiderpoolv1.SpiderIPPool, fragmented bool, ranges, free int) bool {
+	cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionFragmented)
+	if !fragmented {
+		if cond == nil {
+			return false
+		}
+		apimeta.RemoveStatusCondition(&pool.Status.Conditions, constant.IPPoolConditionFragmented)
+		return true
+	}
+
+	if cond != nil && cond.Status == metav1.ConditionTrue {
+		return false
+	}
+
+	apimeta.SetStatusCondition(&pool.Status.Conditions, metav1.Condition{
+		Type:               constant.IPPoolConditionFragmented,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: pool.Generation,
+		Reason:             constant.ReasonFreeRanges,
+		Message:            fmt.Sprintf("the %d free IP addresses are split into %d ranges", free, ranges),
+	})
+
+	return true
+}
+
+// IsFragmented reports whether the IPPool has the condition Fragmented.
+func IsFragmented(pool *spiderpoolv1.SpiderIPPool) bool {
+	return apimeta.IsStatusConditionTrue(pool.Status.Conditions, constant.IPPoolConditionFragmented)
+}
+
+// gapFillingIP returns the lowest IP address of the smallest contiguous range
+// of the sorted available IP addresses, so that the allocation closes the
+// gaps between the allocated IP addresses rather than splitting a big range.
+func gapFillingIP(availableIPs []net.IP) net.IP {
+	var best net.IP
+	bestSize := 0
+	start, size := 0, 0
+	for i := range availableIPs {
+		if i == 0 || !spiderpoolip.NextIP(availableIPs[i-1]).Equal(availableIPs[i]) {
+			start, size = i, 0
+		}
+		size++
+		if i == len(availableIPs)-1 || !spiderpoolip.NextIP(availableIPs[i]).Equal(availableIPs[i+1]) {
+			if best == nil || size < bestSize {
+				best, bestSize = availableIPs[start], size
+			}
+		}
+	}
+
+	return best
+}
+
+// totalIPCountOfIPPool returns the number of IP addresses of the IPPool, or
+// the number of sub-prefixes if the IPPool delegates prefixes, as each Pod
+// takes one IP address of a sub-prefix.
+func totalIPCountOfIPPool(pool *spiderpoolv1.SpiderIPPool) (int64, error) {
+	if IsPrefixDelegationIPPool(pool) {
+		prefixes, err := spiderpoolip.SplitCIDR(*pool.Spec.IPVersion, pool.Spec.Subnet, int(*pool.Spec.DelegatedPrefixLength))
+		if err != nil {
+			return 0, err
+		}
+		return int64(len(prefixes)), nil
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(totalIPs)), nil
+}
+
+// usedIPsOfIPPool returns the IP addresses of the IPPool which are allocated
+// to Pods or reserved for egress gateway objects.
+func usedIPsOfIPPool(pool *spiderpoolv1.SpiderIPPool) []string {
+	used := make([]string, 0, len(pool.Status.AllocatedIPs)+len(pool.Status.EgressIPs))
+	for ip := range pool.Status.AllocatedIPs {
+		used = append(used, ip)
+	}
+	for ip := range pool.Status.EgressIPs {
+		used = append(used, ip)
+	}
+
+	return used
+}
+
+// IPPoolHeadroom returns the number of IP addresses that can still be
+// allocated from the IPPool. The second return value is false if the IPPool
+// status has not been calculated yet.
+func IPPoolHeadroom(pool *spiderpoolv1.SpiderIPPool) (int64, bool) {
+	if pool.Status.TotalIPCount == nil {
+		return 0, false
+	}
+
+	headroom := *pool.Status.TotalIPCount - int64(len(usedIPsOfIPPool(pool)))
+	if headroom < 0 {
+		headroom = 0
+	}
+
+	return headroom, true
+}
+
+// IPPoolHeadroomOfPod returns the headroom of the IPPool available to the
+// Pod, the IP addresses kept by 'spec.minFreeIPs' are only available to
+// the critical Pods.
+func IPPoolHeadroomOfPod(pool *spiderpoolv1.SpiderIPPool, pod *corev1.Pod) (int64, bool) {
+	headroom, ok := IPPoolHeadroom(pool)
+	if !ok || IsCriticalPod(pod) {
+		return headroom, ok
+	}
+
+	headroom -= pointer.Int64Deref(pool.Spec.MinFreeIPs, 0)
+	if headroom < 0 {
+		headroom = 0
+	}
+
+	return headroom, true
+}
+
+// IsCriticalPod checks whether the Pod is a critical system one, which is
+// allowed to take the IP addresses kept by 'spec.minFreeIPs' of IPPools.
+func IsCriticalPod(pod *corev1.Pod) bool {
+	if pod == nil {
+		return false
+	}
+
+	return pod.Spec.PriorityClassName == constant.PriorityClassSystemClusterCritical ||
+		pod.Spec.PriorityClassName == constant.PriorityClassSystemNodeCritical
+}
+
+// checkIPPoolMinFreeIPs returns a PoolExhaustedError if the allocation for
+// the Pod would leave fewer free IP addresses in the IPPool than its
+// 'spec.minFreeIPs'.
+func checkIPPoolMinFreeIPs(pool *spiderpoolv1.SpiderIPPool, pod *corev1.Pod) error {
+	minFreeIPs := pointer.Int64Deref(pool.Spec.MinFreeIPs, 0)
+	if minFreeIPs == 0 || IsCriticalPod(pod) {
+		return nil
+	}
+
+	total, err := totalIPCountOfIPPool(pool)
+	if err != nil {
+		return err
+	}
+
+	free := total - int64(len(usedIPsOfIPPool(pool)))
+	if free > minFreeIPs {
+		return nil
+	}
+
+	return &constant.PoolExhaustedError{
+		Kind:   constant.SpiderIPPoolKind,
+		Names:  []string{pool.Name},
+		Reason: fmt.Sprintf("the %d free IP addresses left are kept for critical Pods by 'spec.minFreeIPs' %d", free, minFreeIPs),
+	}
+}
+
+// TopIPConsumers aggregates the allocated IP addresses of the IPPools by the
+// applications owning the Pods, and returns the n applications consuming the
+// most IP addresses in descending order. A non-positive n returns all.
+func TopIPConsumers(n int, pools ...*spiderpoolv1.SpiderIPPool) []types.IPConsumer {
+	counts := map[types.IPConsumer]int{}
+	for _, pool := range pools {
+		for _, allocation := range pool.Status.AllocatedIPs {
+			consumer := types.IPConsumer{
+				Namespace: allocation.Namespace,
+				Kind:      allocation.OwnerControllerType,
+				Name:      allocation.OwnerControllerName,
+			}
+			if consumer.Kind == "" {
+				consumer.Kind = constant.KindPod
+				consumer.Name = allocation.Pod
+			}
+			counts[consumer]++
+		}
+	}
+
+	consumers := make([]types.IPConsumer, 0, len(counts))
+	for consumer, count := range counts {
+		consumer.IPCount = count
+		consumers = append(consumers, consumer)
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		a, b := consumers[i], consumers[j]
+		if a.IPCount != b.IPCount {
+			return a.IPCount > b.IPCount
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	if n > 0 && len(consumers) > n {
+		consumers = consumers[:n]
+	}
+
+	return consumers
+}
+
+// IsStatefulSetOrdinalIPPool checks whether the IPPool assigns the nth IP
+// address to the StatefulSet Pod with ordinal n, which is enabled by the
+// annotation "ipam.spidernet.io/statefulset-ordinal-ip" and the feature gate
+// StatefulSetOrdinalIP.
+func IsStatefulSetOrdinalIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	if !features.DefaultFeatureGate.Enabled(features.StatefulSetOrdinalIP) {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(pool.GetAnnotations()[constant.AnnoIPPoolStatefulSetOrdinalIP])
+	return err == nil && enabled
+}
+
+// statefulSetOrdinalOfPod returns the ordinal of the StatefulSet Pod if the
+// IPPool assigns IP addresses by ordinal.
+func statefulSetOrdinalOfPod(pool *spiderpoolv1.SpiderIPPool, pod *corev1.Pod, podController types.PodTopController) (int, bool) {
+	if podController.Kind != constant.KindStatefulSet || !IsStatefulSetOrdinalIPPool(pool) {
+		return 0, false
+	}
+
+	suffix, found := strings.CutPrefix(pod.Name, podController.Name+"-")
+	if !found {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+
+	return ordinal, true
+}
+
+// FixedIPOfPod returns the IP address the IPPool allocates to the Pod if it
+// is known before the allocation, which is the nth IP address of the IPPool
+// assigning IP addresses by StatefulSet ordinal, or the only IP address of
+// the IPPool. Whether the IP address is free is left to the allocation.
+func FixedIPOfPod(pool *spiderpoolv1.SpiderIPPool, pod *corev1.Pod, podController types.PodTopController) (net.IP, bool) {
+	if pool.Spec.IPVersion == nil || IsPrefixDelegationIPPool(pool) {
+		return nil, false
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*pool.Spec.IPVersion, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if err != nil {
+		return nil, false
+	}
+	totalIPs = spiderpoolip.IPsDiffSet(totalIPs, nil, true)
+
+	if ordinal, ok := statefulSetOrdinalOfPod(pool, pod, podController); ok {
+		if ordinal >= len(totalIPs) {
+			return nil, false
+		}
+		return totalIPs[ordinal], true
+	}
+
+	if len(totalIPs) != 1 || ipv6AssignmentModeOf(pool) != constant.IPv6AssignmentModeRandom {
+		return nil, false
+	}
+
+	return totalIPs[0], true
+}
+
+// IsUnderlayIPPool reports whether the IPPool attaches its Pods to the
+// underlay network, through a master interface of the nodes or a VLAN.
+func IsUnderlayIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	if _, ok := pool.Annotations[constant.AnnoIPPoolMasterInterface]; ok {
+		return true
+	}
+
+	return pool.Spec.Vlan != nil && *pool.Spec.Vlan != 0
+}
+
+// IsSNATDisabledIPPool checks whether the egress traffic from the IP
+// addresses of the underlay IPPool is not SNATed, which is enabled by
+// 'spec.disableSNAT'.
+func IsSNATDisabledIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	return pool.Spec.DisableSNAT != nil && *pool.Spec.DisableSNAT && IsUnderlayIPPool(pool)
+}
+
+// IsSLAACCoexistenceIPPool checks whether the IPv6 IPPool coexists with the
+// SLAAC of routers, which is enabled by 'spec.slaacCoexistence'.
+func IsSLAACCoexistenceIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	if pool.Spec.IPVersion == nil || *pool.Spec.IPVersion != constant.IPv6 {
+		return false
+	}
+
+	return pool.Spec.SLAACCoexistence != nil && *pool.Spec.SLAACCoexistence
+}
+
+// IsPrefixDelegationIPPool checks whether the IPPool delegates a whole
+// sub-prefix to each Pod, which is enabled by 'spec.delegatedPrefixLength'.
+func IsPrefixDelegationIPPool(pool *spiderpoolv1.SpiderIPPool) bool {
+	return pool.Spec.DelegatedPrefixLength != nil
+}
+
+// maxStableHashAttempts limits the IP addresses tried for a Pod in the IPv6
+// assignment mode stable-privacy.
+const maxStableHashAttempts = 16
+
+// ipv6AssignmentModeOf returns the IPv6 assignment mode of the IPPool, the
+// IPv4 IPPools are always in mode random.
+func ipv6AssignmentModeOf(pool *spiderpoolv1.SpiderIPPool) string {
+	if pool.Spec.IPVersion == nil || *pool.Spec.IPVersion != constant.IPv6 || pool.Spec.IPv6AssignmentMode == nil {
+		return constant.IPv6AssignmentModeRandom
+	}
+
+	return *pool.Spec.IPv6AssignmentMode
+}
+
+// macOfPodNIC returns the MAC address of the NIC in the Pod annotation
+// "ipam.spidernet.io/macs".
+func macOfPodNIC(pod *corev1.Pod, nic string) (string, error) {
+	anno, ok := pod.Annotations[constant.AnnoPodMACs]
+	if !ok {
+		return "", fmt.Errorf("%w, Pod annotation '%s' is required for the IPPools in IPv6 assignment mode %s", constant.ErrWrongInput, constant.AnnoPodMACs, constant.IPv6AssignmentModeEUI64)
+	}
+
+	var macs types.AnnoPodMACsValue
+	if err := json.Unmarshal([]byte(anno), &macs); err != nil {
+		return "", fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodMACs, err)
+	}
+	mac, ok := macs[nic]
+	if !ok {
+		return "", fmt.Errorf("%w, no MAC address of interface %s in Pod annotation '%s'", constant.ErrWrongInput, nic, constant.AnnoPodMACs)
+	}
+
+	return mac, nil
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, e := range ips {
+		if e.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maxListedPods limits the number of Pods listed in messages.
+const maxListedPods = 10
+
+// blockingPodsOfIPPool returns a description of the Pods which are still
+// allocated IP addresses from the IPPool, it is empty if there is no one.
+func blockingPodsOfIPPool(pool *spiderpoolv1.SpiderIPPool) string {
+	podSet := map[string]struct{}{}
+	for _, allocation := range pool.Status.AllocatedIPs {
+		podSet[allocation.Namespace+"/"+allocation.Pod] = struct{}{}
+	}
+	if len(podSet) == 0 {
+		return ""
+	}
+
+	pods := make([]string, 0, len(podSet))
+	for pod := range podSet {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+
+	if len(pods) > maxListedPods {
+		return fmt.Sprintf("%s and %d more", strings.Join(pods[:maxListedPods], ", "), len(pods)-maxListedPods)
+	}
+
+	return strings.Join(pods, ", ")
+}
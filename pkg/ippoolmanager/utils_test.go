@@ -0,0 +1,398 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package ippoolmanager_test
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("IPPoolManager utils", Label("ippool_utils_test"), func() {
+	Describe("IsReconcilePaused", func() {
+		It("checks the reconcile annotation", func() {
+			Expect(ippoolmanager.IsReconcilePaused(nil)).To(BeFalse())
+			Expect(ippoolmanager.IsReconcilePaused(map[string]string{constant.AnnoReconcile: "running"})).To(BeFalse())
+			Expect(ippoolmanager.IsReconcilePaused(map[string]string{constant.AnnoReconcile: constant.AnnoReconcilePaused})).To(BeTrue())
+		})
+	})
+
+	Describe("IsDetachRequested", func() {
+		It("checks the detach annotation", func() {
+			Expect(ippoolmanager.IsDetachRequested(nil)).To(BeFalse())
+			Expect(ippoolmanager.IsDetachRequested(map[string]string{constant.AnnoIPPoolDetach: "no"})).To(BeFalse())
+			Expect(ippoolmanager.IsDetachRequested(map[string]string{constant.AnnoIPPoolDetach: constant.False})).To(BeFalse())
+			Expect(ippoolmanager.IsDetachRequested(map[string]string{constant.AnnoIPPoolDetach: constant.True})).To(BeTrue())
+		})
+	})
+
+	Describe("IsPruneExempt", func() {
+		It("checks the prune-exempt annotation", func() {
+			Expect(ippoolmanager.IsPruneExempt(nil)).To(BeFalse())
+			Expect(ippoolmanager.IsPruneExempt(map[string]string{constant.AnnoIPPoolPruneExempt: "no"})).To(BeFalse())
+			Expect(ippoolmanager.IsPruneExempt(map[string]string{constant.AnnoIPPoolPruneExempt: constant.False})).To(BeFalse())
+			Expect(ippoolmanager.IsPruneExempt(map[string]string{constant.AnnoIPPoolPruneExempt: constant.True})).To(BeTrue())
+		})
+	})
+
+	Describe("DetachAutoIPPool", func() {
+		var pool *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			pool = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "auto-deployment-default-demo-v4-eth0-1a2b3c4d5e6f",
+					Labels: map[string]string{
+						constant.LabelIPPoolOwnerSpiderSubnet:   "subnet",
+						constant.LabelIPPoolOwnerApplication:    "Deployment_default_demo",
+						constant.LabelIPPoolOwnerApplicationUID: "1a2b3c4d5e6f",
+						constant.LabelIPPoolInterface:           "eth0",
+						constant.LabelIPPoolReclaimIPPool:       constant.True,
+					},
+				},
+			}
+		})
+
+		It("stops reclaiming the IPPool while the application exists", func() {
+			Expect(ippoolmanager.DetachAutoIPPool(pool, false)).To(BeTrue())
+			Expect(pool.Labels).NotTo(HaveKey(constant.LabelIPPoolReclaimIPPool))
+			Expect(ippoolmanager.IsAutoCreatedIPPool(pool)).To(BeTrue())
+
+			Expect(ippoolmanager.DetachAutoIPPool(pool, false)).To(BeFalse())
+		})
+
+		It("makes the IPPool normal once the application is gone", func() {
+			Expect(ippoolmanager.DetachAutoIPPool(pool, true)).To(BeTrue())
+			Expect(pool.Labels).To(Equal(map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: "subnet"}))
+			Expect(ippoolmanager.IsAutoCreatedIPPool(pool)).To(BeFalse())
+
+			Expect(ippoolmanager.DetachAutoIPPool(pool, true)).To(BeFalse())
+		})
+	})
+
+	Describe("SetReconcilePaused", func() {
+		var pool *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			pool = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 2},
+			}
+		})
+
+		It("sets and removes the condition", func() {
+			Expect(ippoolmanager.SetReconcilePaused(pool, false, constant.ReasonIPPoolPaused)).To(BeFalse())
+
+			Expect(ippoolmanager.SetReconcilePaused(pool, true, constant.ReasonIPPoolPaused)).To(BeTrue())
+			cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionReconcilePaused)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(constant.ReasonIPPoolPaused))
+			Expect(cond.ObservedGeneration).To(Equal(int64(2)))
+
+			Expect(ippoolmanager.SetReconcilePaused(pool, true, constant.ReasonIPPoolPaused)).To(BeFalse())
+
+			Expect(ippoolmanager.SetReconcilePaused(pool, false, constant.ReasonIPPoolPaused)).To(BeTrue())
+			Expect(pool.Status.Conditions).To(BeEmpty())
+		})
+
+		It("keeps the condition set with another reason", func() {
+			Expect(ippoolmanager.SetReconcilePaused(pool, true, constant.ReasonApplicationPaused)).To(BeTrue())
+			Expect(ippoolmanager.SetReconcilePaused(pool, false, constant.ReasonIPPoolPaused)).To(BeFalse())
+			Expect(apimeta.IsStatusConditionTrue(pool.Status.Conditions, constant.IPPoolConditionReconcilePaused)).To(BeTrue())
+		})
+	})
+
+	Describe("SetExhaustionImminent", func() {
+		It("sets and removes the condition", func() {
+			pool := &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 3},
+			}
+			Expect(ippoolmanager.SetExhaustionImminent(pool, false, 0)).To(BeFalse())
+
+			Expect(ippoolmanager.SetExhaustionImminent(pool, true, 90*time.Second)).To(BeTrue())
+			cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionExhaustionImminent)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(constant.ReasonAllocationRate))
+			Expect(cond.Message).To(ContainSubstring("1m30s"))
+			Expect(cond.ObservedGeneration).To(Equal(int64(3)))
+
+			Expect(ippoolmanager.SetExhaustionImminent(pool, true, 60*time.Second)).To(BeFalse())
+			Expect(apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionExhaustionImminent).Message).To(ContainSubstring("1m30s"))
+
+			Expect(ippoolmanager.SetExhaustionImminent(pool, false, 0)).To(BeTrue())
+			Expect(pool.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	Describe("FreeIPRangeCount", func() {
+		It("counts the contiguous ranges of the free IP addresses", func() {
+			pool := &spiderpoolv1.SpiderIPPool{
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion:  pointer.Int64(constant.IPv4),
+					Subnet:     "172.18.40.0/24",
+					IPs:        []string{"172.18.40.1-172.18.40.10"},
+					ExcludeIPs: []string{"172.18.40.10"},
+				},
+				Status: spiderpoolv1.IPPoolStatus{
+					AllocatedIPs: spiderpoolv1.PoolIPAllocations{
+						"172.18.40.3": {},
+						"172.18.40.6": {},
+					},
+				},
+			}
+
+			ranges, free, err := ippoolmanager.FreeIPRangeCount(pool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ranges).To(Equal(3))
+			Expect(free).To(Equal(7))
+		})
+	})
+
+	Describe("SetFragmented", func() {
+		It("sets and removes the condition", func() {
+			pool := &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 2},
+			}
+			Expect(ippoolmanager.SetFragmented(pool, false, 0, 0)).To(BeFalse())
+			Expect(ippoolmanager.IsFragmented(pool)).To(BeFalse())
+
+			Expect(ippoolmanager.SetFragmented(pool, true, 9, 12)).To(BeTrue())
+			cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionFragmented)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal(constant.ReasonFreeRanges))
+			Expect(cond.Message).To(ContainSubstring("9 ranges"))
+			Expect(ippoolmanager.IsFragmented(pool)).To(BeTrue())
+
+			Expect(ippoolmanager.SetFragmented(pool, true, 10, 11)).To(BeFalse())
+
+			Expect(ippoolmanager.SetFragmented(pool, false, 0, 0)).To(BeTrue())
+			Expect(pool.Status.Conditions).To(BeEmpty())
+		})
+	})
+
+	Describe("SetIdle", func() {
+		var pool *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			pool = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Generation: 4},
+			}
+		})
+
+		It("sets and removes the condition", func() {
+			Expect(ippoolmanager.SetIdle(pool, false, "")).To(BeFalse())
+			_, ok := ippoolmanager.IdleDuration(pool, time.Now())
+			Expect(ok).To(BeFalse())
+
+			Expect(ippoolmanager.SetIdle(pool, true, constant.ReasonZeroReplicas)).To(BeTrue())
+			cond := apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionIdle)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(constant.ReasonZeroReplicas))
+			Expect(cond.ObservedGeneration).To(Equal(int64(4)))
+
+			Expect(ippoolmanager.SetIdle(pool, true, constant.ReasonZeroReplicas)).To(BeFalse())
+
+			Expect(ippoolmanager.SetIdle(pool, false, "")).To(BeTrue())
+			Expect(pool.Status.Conditions).To(BeEmpty())
+		})
+
+		It("keeps the idle time across the reasons", func() {
+			since := metav1.NewTime(time.Now().Add(-time.Hour))
+			pool.Status.Conditions = []metav1.Condition{{
+				Type:               constant.IPPoolConditionIdle,
+				Status:             metav1.ConditionTrue,
+				Reason:             constant.ReasonZeroReplicas,
+				LastTransitionTime: since,
+			}}
+
+			Expect(ippoolmanager.SetIdle(pool, true, constant.ReasonApplicationGone)).To(BeTrue())
+			Expect(apimeta.FindStatusCondition(pool.Status.Conditions, constant.IPPoolConditionIdle).Reason).To(Equal(constant.ReasonApplicationGone))
+
+			idleDuration, ok := ippoolmanager.IdleDuration(pool, since.Add(90*time.Minute))
+			Expect(ok).To(BeTrue())
+			Expect(idleDuration).To(Equal(90 * time.Minute))
+		})
+	})
+
+	Describe("AutoExpandedIPCount", func() {
+		newPool := func(used int, desired, expanded int64) *spiderpoolv1.SpiderIPPool {
+			pool := &spiderpoolv1.SpiderIPPool{
+				Status: spiderpoolv1.IPPoolStatus{
+					AllocatedIPs:       spiderpoolv1.PoolIPAllocations{},
+					AutoDesiredIPCount: pointer.Int64(desired),
+				},
+			}
+			if expanded > 0 {
+				pool.Status.AutoExpandedIPCount = pointer.Int64(expanded)
+			}
+			for i := 0; i < used; i++ {
+				pool.Status.AllocatedIPs[fmt.Sprintf("172.18.40.%d", i+1)] = spiderpoolv1.PoolIPAllocation{Namespace: "default", Pod: fmt.Sprintf("pod-%d", i)}
+			}
+			return pool
+		}
+
+		It("is disabled without the threshold", func() {
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(10, 10, 0), 0, 5, 50)).To(BeZero())
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(10, 15, 5), 0, 5, 50)).To(BeZero())
+		})
+
+		It("keeps the IPPool without desired IP count", func() {
+			pool := newPool(10, 10, 5)
+			pool.Status.AutoDesiredIPCount = nil
+			Expect(ippoolmanager.AutoExpandedIPCount(pool, 80, 5, 50)).To(Equal(int64(5)))
+		})
+
+		It("expands the IPPool by step on the pressure", func() {
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(7, 10, 0), 80, 5, 50)).To(BeZero())
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(8, 10, 0), 80, 5, 50)).To(Equal(int64(5)))
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(12, 15, 5), 80, 5, 50)).To(Equal(int64(10)))
+		})
+
+		It("bounds the expansion with the max", func() {
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(16, 18, 8), 80, 5, 10)).To(Equal(int64(10)))
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(19, 20, 10), 80, 5, 10)).To(Equal(int64(10)))
+		})
+
+		It("retracts the expansion by step once the pressure is gone", func() {
+			// 11 used IPs are below 80% of 15 IPs, but not below 80% of 10 IPs
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(11, 20, 10), 80, 5, 50)).To(Equal(int64(5)))
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(3, 20, 10), 80, 5, 50)).To(BeZero())
+			Expect(ippoolmanager.AutoExpandedIPCount(newPool(0, 20, 10), 80, 5, 50)).To(BeZero())
+		})
+	})
+
+	Describe("TopIPConsumers", func() {
+		var pool, otherPool *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			deploy := spiderpoolv1.PoolIPAllocation{Namespace: "default", OwnerControllerType: constant.KindDeployment, OwnerControllerName: "deploy"}
+			sts := spiderpoolv1.PoolIPAllocation{Namespace: "default", OwnerControllerType: constant.KindStatefulSet, OwnerControllerName: "sts"}
+
+			pool = &spiderpoolv1.SpiderIPPool{
+				Status: spiderpoolv1.IPPoolStatus{
+					AllocatedIPs: spiderpoolv1.PoolIPAllocations{
+						"172.18.40.1": deploy,
+						"172.18.40.2": deploy,
+						"172.18.40.3": sts,
+						"172.18.40.4": {Namespace: "default", Pod: "orphan", OwnerControllerType: constant.KindPod, OwnerControllerName: "orphan"},
+					},
+				},
+			}
+			otherPool = &spiderpoolv1.SpiderIPPool{
+				Status: spiderpoolv1.IPPoolStatus{
+					AllocatedIPs: spiderpoolv1.PoolIPAllocations{
+						"172.18.41.1": sts,
+						"172.18.41.2": sts,
+						"172.18.41.3": {Namespace: "default", Pod: "legacy"},
+					},
+				},
+			}
+		})
+
+		It("returns the applications consuming the most IPs of the IPPool", func() {
+			consumers := ippoolmanager.TopIPConsumers(2, pool)
+			Expect(consumers).To(HaveLen(2))
+			Expect(consumers[0]).To(Equal(types.IPConsumer{Namespace: "default", Kind: constant.KindDeployment, Name: "deploy", IPCount: 2}))
+			Expect(consumers[1]).To(Equal(types.IPConsumer{Namespace: "default", Kind: constant.KindPod, Name: "orphan", IPCount: 1}))
+		})
+
+		It("aggregates the IPs over the IPPools", func() {
+			consumers := ippoolmanager.TopIPConsumers(0, pool, otherPool)
+			Expect(consumers).To(Equal([]types.IPConsumer{
+				{Namespace: "default", Kind: constant.KindStatefulSet, Name: "sts", IPCount: 3},
+				{Namespace: "default", Kind: constant.KindDeployment, Name: "deploy", IPCount: 2},
+				{Namespace: "default", Kind: constant.KindPod, Name: "legacy", IPCount: 1},
+				{Namespace: "default", Kind: constant.KindPod, Name: "orphan", IPCount: 1},
+			}))
+		})
+
+		It("returns nothing for the IPPool without allocated IPs", func() {
+			Expect(ippoolmanager.TopIPConsumers(5, &spiderpoolv1.SpiderIPPool{})).To(BeEmpty())
+		})
+	})
+
+	Describe("FixedIPOfPod", func() {
+		var pool *spiderpoolv1.SpiderIPPool
+		var pod *corev1.Pod
+		var sts types.PodTopController
+
+		BeforeEach(func() {
+			pool = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion:  pointer.Int64(constant.IPv4),
+					Subnet:     "172.18.40.0/24",
+					IPs:        []string{"172.18.40.10-172.18.40.12"},
+					ExcludeIPs: []string{"172.18.40.10"},
+				},
+			}
+			pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sts-1"}}
+			sts = types.PodTopController{Kind: constant.KindStatefulSet, Namespace: "default", Name: "sts"}
+		})
+
+		It("returns the IP address of the StatefulSet ordinal", func() {
+			pool.Annotations = map[string]string{constant.AnnoIPPoolStatefulSetOrdinalIP: constant.True}
+
+			ip, ok := ippoolmanager.FixedIPOfPod(pool, pod, sts)
+			Expect(ok).To(BeTrue())
+			Expect(ip).To(Equal(net.ParseIP("172.18.40.12")))
+
+			pod.Name = "sts-2"
+			_, ok = ippoolmanager.FixedIPOfPod(pool, pod, sts)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("returns the only IP address of the IPPool", func() {
+			_, ok := ippoolmanager.FixedIPOfPod(pool, pod, sts)
+			Expect(ok).To(BeFalse())
+
+			pool.Spec.IPs = []string{"172.18.40.10", "172.18.40.11"}
+			ip, ok := ippoolmanager.FixedIPOfPod(pool, pod, types.PodTopController{Kind: constant.KindPod, Namespace: "default", Name: "sts-1"})
+			Expect(ok).To(BeTrue())
+			Expect(ip).To(Equal(net.ParseIP("172.18.40.11")))
+		})
+
+		It("returns nothing for the IPPool delegating prefixes", func() {
+			pool.Spec.IPs = []string{"172.18.40.11"}
+			pool.Spec.DelegatedPrefixLength = pointer.Int32(28)
+
+			_, ok := ippoolmanager.FixedIPOfPod(pool, pod, sts)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("FieldErrorOf", func() {
+		fieldPath := field.NewPath("spec").Child("subnet")
+
+		It("maps the error classes to the types of field errors", func() {
+			overlapErr := &constant.OverlapError{Kind: constant.SpiderIPPoolKind, Name: "pool", Ranges: []string{"172.18.40.0/24"}}
+			Expect(ippoolmanager.FieldErrorOf(fieldPath, "172.18.40.0/25", overlapErr).Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(ippoolmanager.FieldErrorOf(fieldPath, "172.18.40.0/25", fmt.Errorf("%w: bad", constant.ErrWrongInput)).Type).To(Equal(field.ErrorTypeInvalid))
+
+			ownerErr := &constant.OwnerNotFoundError{Kind: constant.SpiderSubnetKind, Name: "subnet", Err: fmt.Errorf("not found")}
+			fieldErr := ippoolmanager.FieldErrorOf(fieldPath, "subnet", ownerErr)
+			Expect(fieldErr.Type).To(Equal(field.ErrorTypeNotFound))
+			Expect(fieldErr.Detail).To(Equal(ownerErr.Error()))
+
+			exhaustedErr := &constant.PoolExhaustedError{Kind: constant.SpiderSubnetKind, Names: []string{"subnet"}}
+			Expect(ippoolmanager.FieldErrorOf(fieldPath, "subnet", exhaustedErr).Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(ippoolmanager.FieldErrorOf(fieldPath, "subnet", fmt.Errorf("boom")).Type).To(Equal(field.ErrorTypeInternal))
+		})
+	})
+})
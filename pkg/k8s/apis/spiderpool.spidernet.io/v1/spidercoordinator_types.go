@@ -0,0 +1,122 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CoordinatorSpec defines the cluster-wide defaults of the coordinator plugin.
+type CoordinatorSpec struct {
+	// Mode is the tune mode of the coordinator plugin. The mode 'auto'
+	// detects 'underlay' or 'overlay' by the NICs of Pods.
+	// +kubebuilder:default=auto
+	// +kubebuilder:validation:Enum=auto;underlay;overlay;disabled
+	// +kubebuilder:validation:Optional
+	Mode *string `json:"mode,omitempty"`
+
+	// PodCIDRs are the CIDRs of Pods to be hijacked. They are detected from
+	// the cluster if unset.
+	// +kubebuilder:validation:Optional
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
+
+	// ServiceCIDRs are the CIDRs of Services to be hijacked. They are
+	// detected from the cluster if unset.
+	// +kubebuilder:validation:Optional
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// HijackCustomCIDRs are the additional CIDRs to be hijacked.
+	// +kubebuilder:validation:Optional
+	HijackCustomCIDRs []string `json:"hijackCustomCIDRs,omitempty"`
+
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	TunePodRoutes *bool `json:"tunePodRoutes,omitempty"`
+
+	// HostRuleTable is the ID of the host route table for the policy
+	// routing of Pods.
+	// +kubebuilder:default=500
+	// +kubebuilder:validation:Maximum=4294967295
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	HostRuleTable *int64 `json:"hostRuleTable,omitempty"`
+
+	// HostRPFilter is the value of the sysctl rp_filter set on the host.
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Maximum=2
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	HostRPFilter *int64 `json:"hostRPFilter,omitempty"`
+
+	// HostPort makes the coordinator plugin forward the hostPorts of Pods
+	// to their underlay IP addresses, SNATed as hinted by the IPAM results,
+	// since the portmap plugin assumes the overlay IP addresses of Pods.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	HostPort *bool `json:"hostPort,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Synced;NotReady
+type CoordinatorPhase string
+
+const (
+	CoordinatorSynced   CoordinatorPhase = "Synced"
+	CoordinatorNotReady CoordinatorPhase = "NotReady"
+)
+
+// CoordinatorStatus defines the observed state of SpiderCoordinator.
+type CoordinatorStatus struct {
+	// +kubebuilder:validation:Optional
+	Phase CoordinatorPhase `json:"phase,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// PodCIDRs are the effective CIDRs of Pods, specified or detected.
+	// +kubebuilder:validation:Optional
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
+
+	// ServiceCIDRs are the effective CIDRs of Services, specified or
+	// detected.
+	// +kubebuilder:validation:Optional
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// ConfigMap is the '<namespace>/<name>' of the ConfigMap holding the
+	// network configuration snippet of the coordinator plugin.
+	// +kubebuilder:validation:Optional
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spidercoordinators",scope="Cluster",shortName={scc},singular="spidercoordinator"
+// +kubebuilder:printcolumn:JSONPath=".spec.mode",description="mode",name="MODE",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",description="phase",name="PHASE",type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SpiderCoordinator is the Schema for the spidercoordinators API.
+type SpiderCoordinator struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CoordinatorSpec   `json:"spec,omitempty"`
+	Status CoordinatorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderCoordinatorList contains a list of SpiderCoordinator.
+type SpiderCoordinatorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderCoordinator `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderCoordinator{}, &SpiderCoordinatorList{})
+}
@@ -26,6 +26,10 @@ type PodIPAllocation struct {
 	// +kubebuilder:validation:Required
 	ContainerID string `json:"containerID"`
 
+	// UID is the UID of the Pod the IP addresses are allocated to.
+	// +kubebuilder:validation:Optional
+	UID string `json:"uid,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Node *string `json:"node,omitempty"`
 
@@ -67,8 +71,27 @@ type IPAllocationDetail struct {
 	// +kubebuilder:validation:Optional
 	CleanGateway *bool `json:"cleanGateway,omitempty"`
 
+	// DefaultRoute reports whether the interface holds the default route of
+	// the Pod, elected by the default route policy. It is unset if every
+	// interface with a gateway holds the default route.
+	// +kubebuilder:validation:Optional
+	DefaultRoute *bool `json:"defaultRoute,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Routes []Route `json:"routes,omitempty"`
+
+	// SLAACIPv6 records the IPv6 addresses derived from router advertisements
+	// on the interface, for the IPPools coexisting with SLAAC.
+	// +kubebuilder:validation:Optional
+	SLAACIPv6 []string `json:"slaacIPv6,omitempty"`
+
+	// IPv4DelegatedPrefix is the IPv4 sub-prefix delegated to the interface.
+	// +kubebuilder:validation:Optional
+	IPv4DelegatedPrefix *string `json:"ipv4DelegatedPrefix,omitempty"`
+
+	// IPv6DelegatedPrefix is the IPv6 sub-prefix delegated to the interface.
+	// +kubebuilder:validation:Optional
+	IPv6DelegatedPrefix *string `json:"ipv6DelegatedPrefix,omitempty"`
 }
 
 // +kubebuilder:resource:categories={spiderpool},path="spiderendpoints",scope="Namespaced",shortName={se},singular="spiderendpoint"
@@ -0,0 +1,8 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+// Hub marks v1 as the hub version of SpiderIPPool, which the other versions
+// are converted to and from.
+func (*SpiderIPPool) Hub() {}
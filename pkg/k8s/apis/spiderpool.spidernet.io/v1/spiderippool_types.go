@@ -4,16 +4,23 @@
 package v1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // IPPoolSpec defines the desired state of SpiderIPPool.
+// +kubebuilder:validation:XValidation:rule="!has(self.ipVersion) || (self.ipVersion == 6 ? self.subnet.matches(':') : !self.subnet.matches(':'))",message="ipVersion must match the IP family of subnet"
+// +kubebuilder:validation:XValidation:rule="!has(self.gateway) || (self.subnet.matches(':') ? self.gateway.matches(':') : !self.gateway.matches(':'))",message="gateway must be in the same IP family as subnet"
+// +kubebuilder:validation:XValidation:rule="!has(self.gateway) || self.subnet.matches(':') || self.gateway.matches(':') || [0, 1, 2, 3].all(i, int(self.gateway.split('.')[i]) / [256, 128, 64, 32, 16, 8, 4, 2, 1][(int(self.subnet.split('/')[1]) < 8 * i ? 0 : (int(self.subnet.split('/')[1]) > 8 * i + 8 ? 8 : int(self.subnet.split('/')[1]) - 8 * i))] - int(self.subnet.split('/')[0].split('.')[i]) / [256, 128, 64, 32, 16, 8, 4, 2, 1][(int(self.subnet.split('/')[1]) < 8 * i ? 0 : (int(self.subnet.split('/')[1]) > 8 * i + 8 ? 8 : int(self.subnet.split('/')[1]) - 8 * i))] == 0)",message="gateway must be in subnet"
+// +kubebuilder:validation:XValidation:rule="!has(self.routes) || self.routes.all(r, self.subnet.matches(':') ? r.dst.matches(':') : !r.dst.matches(':'))",message="routes must be in the same IP family as subnet"
 type IPPoolSpec struct {
 	// +kubebuilder:validation:Enum=4;6
 	// +kubebuilder:validation:Optional
 	IPVersion *int64 `json:"ipVersion,omitempty"`
 
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=49
+	// +kubebuilder:validation:XValidation:rule="self.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}/(3[0-2]|[12]?[0-9])|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*/(12[0-8]|1[01][0-9]|[1-9]?[0-9]))$')",message="subnet must be a valid CIDR"
 	Subnet string `json:"subnet"`
 
 	// +kubebuilder:validation:Optional
@@ -27,6 +34,8 @@ type IPPoolSpec struct {
 	ExcludeIPs []string `json:"excludeIPs,omitempty"`
 
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=45
+	// +kubebuilder:validation:XValidation:rule="self.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*)$')",message="gateway must be a valid IP address"
 	Gateway *string `json:"gateway,omitempty"`
 
 	// +kubebuilder:default=0
@@ -36,6 +45,10 @@ type IPPoolSpec struct {
 	Vlan *int64 `json:"vlan,omitempty"`
 
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=1024
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.dst.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}/(3[0-2]|[12]?[0-9])|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*/(12[0-8]|1[01][0-9]|[1-9]?[0-9]))$'))",message="dst of routes must be a valid CIDR"
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.gw.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*)$'))",message="gw of routes must be a valid IP address"
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.dst.matches(':') ? r.gw.matches(':') : !r.gw.matches(':'))",message="dst and gw of routes must be in the same IP family"
 	Routes []Route `json:"routes,omitempty"`
 
 	// +kubebuilder:validation:Optional
@@ -46,13 +59,146 @@ type IPPoolSpec struct {
 
 	// +kubebuilder:validation:Optional
 	NodeAffinity *metav1.LabelSelector `json:"nodeAffinity,omitempty"`
+
+	// Default makes the IPPool a default one, which is selected for the Pods
+	// whose IPPools are not specified otherwise. It is the default of the
+	// Namespaces in NamespaceDefault, or of the whole cluster if none.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	Default *bool `json:"default,omitempty"`
+
+	// NamespaceDefault lists the Namespaces which the IPPool is the default
+	// of, it requires Default. A Namespace could only have one default
+	// IPPool of each IP version.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=1024
+	NamespaceDefault []string `json:"namespaceDefault,omitempty"`
+
+	// EnableGatewayDetection overrides the cluster default of whether CNI
+	// plugins detect the reachability of the gateway.
+	// +kubebuilder:validation:Optional
+	EnableGatewayDetection *bool `json:"enableGatewayDetection,omitempty"`
+
+	// EnableIPConflictDetection overrides the cluster default of whether CNI
+	// plugins detect the conflict of the allocated IP addresses.
+	// +kubebuilder:validation:Optional
+	EnableIPConflictDetection *bool `json:"enableIPConflictDetection,omitempty"`
+
+	// DNS is returned in the CNI result for the Pods using the IPPool,
+	// e.g. the resolvers of the underlay network.
+	// +kubebuilder:validation:Optional
+	DNS *DNS `json:"dns,omitempty"`
+
+	// IPv6AssignmentMode decides how the IPv6 addresses are assigned. The
+	// mode 'eui64' derives them from the MAC addresses of Pods, the mode
+	// 'stable-privacy' from the stable hashes of Pods, and the mode
+	// 'sequential' assigns the lowest available ones.
+	// +kubebuilder:default=random
+	// +kubebuilder:validation:Enum=random;eui64;stable-privacy;sequential
+	// +kubebuilder:validation:Optional
+	IPv6AssignmentMode *string `json:"ipv6AssignmentMode,omitempty"`
+
+	// SLAACCoexistence makes Spiderpool only assign the static addresses of
+	// the /64 prefix that routers also advertise, so that Pods could keep
+	// their addresses derived from router advertisements without conflicts.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	SLAACCoexistence *bool `json:"slaacCoexistence,omitempty"`
+
+	// DelegatedPrefixLength makes the IPPool delegate a whole sub-prefix of
+	// the length, carved from the subnet, to each Pod instead of a single IP
+	// address, e.g. for the router or VM workloads.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=126
+	// +kubebuilder:validation:Optional
+	DelegatedPrefixLength *int32 `json:"delegatedPrefixLength,omitempty"`
+
+	// MinFreeIPs is the headroom of free IP addresses kept for the critical
+	// system Pods, i.e. the Pods of priority class 'system-cluster-critical'
+	// or 'system-node-critical'. Other Pods fail to be allocated once the
+	// free IP addresses of the IPPool drop to it.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MinFreeIPs *int64 `json:"minFreeIPs,omitempty"`
+
+	// Bandwidth is the rate limits hinted in the IPAM results for the Pods
+	// using the IPPool, which are applied by the bandwidth plugin or the
+	// coordinator plugin.
+	// +kubebuilder:validation:Optional
+	Bandwidth *Bandwidth `json:"bandwidth,omitempty"`
+
+	// AllocationWindow restricts the IP allocation from the IPPool to the
+	// approved change windows. The Pods are deferred out of the windows,
+	// unless annotated with "ipam.spidernet.io/bypass-allocation-window".
+	// +kubebuilder:validation:Optional
+	AllocationWindow *AllocationWindow `json:"allocationWindow,omitempty"`
+
+	// DisableSNAT hints the coordinator plugin in the IPAM results not to
+	// SNAT the egress traffic from the IP addresses of the underlay IPPool,
+	// which are routable, so that the firewalls could match the Pods.
+	// +kubebuilder:default=false
+	// +kubebuilder:validation:Optional
+	DisableSNAT *bool `json:"disableSNAT,omitempty"`
+}
+
+// AllocationWindow is the minutes when IP addresses could be allocated.
+type AllocationWindow struct {
+	// Schedules are the cron expressions 'minute hour day-of-month month
+	// day-of-week' of the minutes in the window, e.g. '* 0-8,18-23 * * 1-5'
+	// for the minutes out of business hours on weekdays. A minute matching
+	// any of them is in the window.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Schedules []string `json:"schedules"`
+
+	// TimeZone is the IANA time zone of the schedules, e.g. 'Asia/Shanghai',
+	// defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// Bandwidth is the rate limits of the traffic of a Pod, the rates are in
+// bits per second and the bursts are in bits, e.g. "100M".
+type Bandwidth struct {
+	// +kubebuilder:validation:Optional
+	IngressRate *resource.Quantity `json:"ingressRate,omitempty"`
+
+	// IngressBurst defaults to IngressRate.
+	// +kubebuilder:validation:Optional
+	IngressBurst *resource.Quantity `json:"ingressBurst,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EgressRate *resource.Quantity `json:"egressRate,omitempty"`
+
+	// EgressBurst defaults to EgressRate.
+	// +kubebuilder:validation:Optional
+	EgressBurst *resource.Quantity `json:"egressBurst,omitempty"`
+}
+
+type DNS struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=3
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	Domain string `json:"domain,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=32
+	Search []string `json:"search,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Options []string `json:"options,omitempty"`
 }
 
 type Route struct {
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=49
 	Dst string `json:"dst"`
 
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=45
 	Gw string `json:"gw"`
 }
 
@@ -61,6 +207,12 @@ type IPPoolStatus struct {
 	// +kubebuilder:validation:Optional
 	AllocatedIPs PoolIPAllocations `json:"allocatedIPs,omitempty"`
 
+	// Shards are the names of the SpiderIPPoolShards holding the rest of the
+	// IP allocation details, once they would exceed the size limit of the
+	// status.
+	// +kubebuilder:validation:Optional
+	Shards []string `json:"shards,omitempty"`
+
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	TotalIPCount *int64 `json:"totalIPCount,omitempty"`
@@ -72,6 +224,26 @@ type IPPoolStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	AutoDesiredIPCount *int64 `json:"autoDesiredIPCount,omitempty"`
+
+	// AutoExpandedIPCount is the number of IP addresses the auto-created
+	// IPPool is expanded with on the utilization pressure, which is
+	// included in the AutoDesiredIPCount.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AutoExpandedIPCount *int64 `json:"autoExpandedIPCount,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EgressIPs PoolEgressIPReservations `json:"egressIPs,omitempty"`
+
+	// DelegatedPrefixes is the sub-prefixes delegated to Pods by the IPPool
+	// with 'spec.delegatedPrefixLength'.
+	// +kubebuilder:validation:Optional
+	DelegatedPrefixes PoolPrefixDelegations `json:"delegatedPrefixes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // PoolIPAllocations is a map of IP allocation details indexed by IP address.
@@ -100,6 +272,55 @@ type PoolIPAllocation struct {
 	OwnerControllerName string `json:"ownerControllerName"`
 }
 
+// RangeEncodedIPAllocations is the IP allocation details encoded by IP
+// range, which SpiderIPPool v2beta1 stores instead of the PoolIPAllocations.
+// The IPs are the sorted and merged IP ranges, IPv4 ones first, and the
+// allocation details of each IP are in the same order as a tuple of indexes
+// into the table of distinct strings, because most of them, like node,
+// namespace and owner controller, are shared by many IPs.
+type RangeEncodedIPAllocations struct {
+	// +kubebuilder:validation:Optional
+	IPs []string `json:"ips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Strings []string `json:"strings,omitempty"`
+
+	// Allocations are the tuples of the indexes of containerID, interface,
+	// node, namespace, pod, ownerControllerType and ownerControllerName.
+	// +kubebuilder:validation:Optional
+	Allocations [][]int `json:"allocations,omitempty"`
+}
+
+// PoolPrefixDelegations is a map of the sub-prefixes delegated to Pods
+// indexed by prefix, whose values are the IP addresses of the Pods recorded
+// in the AllocatedIPs.
+type PoolPrefixDelegations map[string]string
+
+// PoolEgressIPReservations is a map of the IP addresses reserved for egress
+// gateway objects indexed by IP address. These IP addresses are excluded from
+// Pod IP allocation.
+type PoolEgressIPReservations map[string]EgressIPReservation
+
+// EgressIPReservation is the egress gateway object which an IP address is
+// reserved for. The reservation is released once the object is gone, or its
+// UID mismatches.
+type EgressIPReservation struct {
+	// +kubebuilder:validation:Required
+	APIVersion string `json:"apiVersion"`
+
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	UID string `json:"uid,omitempty"`
+}
+
 // +kubebuilder:resource:categories={spiderpool},path="spiderippools",scope="Cluster",shortName={sp},singular="spiderippool"
 // +kubebuilder:printcolumn:JSONPath=".spec.ipVersion",description="ipVersion",name="VERSION",type=string
 // +kubebuilder:printcolumn:JSONPath=".spec.subnet",description="subnet",name="SUBNET",type=string
@@ -0,0 +1,48 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:resource:categories={spiderpool},path="spiderippoolshards",scope="Cluster",shortName={sps},singular="spiderippoolshard"
+// +kubebuilder:printcolumn:JSONPath=".ipPool",description="ipPool",name="IPPOOL",type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+
+// SpiderIPPoolShard holds a part of the IP allocation records of a
+// SpiderIPPool, whose status would otherwise exceed the size limit of
+// objects. It is immutable, and referenced by 'status.shards' of the
+// SpiderIPPool.
+type SpiderIPPoolShard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// IPPool is the name of the SpiderIPPool the shard belongs to.
+	// +kubebuilder:validation:Required
+	IPPool string `json:"ipPool"`
+
+	// AllocatedIPs is the IP allocation details, encoded the same way as
+	// the ones in the status of the SpiderIPPool.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	AllocatedIPs PoolIPAllocations `json:"allocatedIPs,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderIPPoolShardList contains a list of SpiderIPPoolShard.
+type SpiderIPPoolShardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderIPPoolShard `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderIPPoolShard{}, &SpiderIPPoolShardList{})
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SpiderNetworkTestSpec defines the desired state of SpiderNetworkTest.
+type SpiderNetworkTestSpec struct {
+	// +kubebuilder:validation:Optional
+	IPv4IPPools []string `json:"ipv4IPPools,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	IPv6IPPools []string `json:"ipv6IPPools,omitempty"`
+
+	// NetworkAttachmentDefinition is the Multus network, in the form of
+	// '<namespace>/<name>' or '<name>', which probe Pods attach to.
+	// +kubebuilder:validation:Optional
+	NetworkAttachmentDefinition *string `json:"networkAttachmentDefinition,omitempty"`
+
+	// Interface is the interface of probe Pods to be tested. It defaults
+	// to 'net1' if networkAttachmentDefinition is specified, otherwise 'eth0'.
+	// +kubebuilder:validation:Optional
+	Interface *string `json:"interface,omitempty"`
+
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Maximum=20
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Targets are additional IP addresses whose L3 reachability is checked.
+	// +kubebuilder:validation:Optional
+	Targets []string `json:"targets,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Image *string `json:"image,omitempty"`
+
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type NetworkTestPhase string
+
+const (
+	NetworkTestPending   NetworkTestPhase = "Pending"
+	NetworkTestRunning   NetworkTestPhase = "Running"
+	NetworkTestSucceeded NetworkTestPhase = "Succeeded"
+	NetworkTestFailed    NetworkTestPhase = "Failed"
+)
+
+// SpiderNetworkTestStatus defines the observed state of SpiderNetworkTest.
+type SpiderNetworkTestStatus struct {
+	// +kubebuilder:validation:Optional
+	Phase NetworkTestPhase `json:"phase,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Results []NetworkTestProbeResult `json:"results,omitempty"`
+}
+
+// NetworkTestProbeResult is the result reported by a probe Pod.
+type NetworkTestProbeResult struct {
+	// +kubebuilder:validation:Required
+	Pod string `json:"pod"`
+
+	// +kubebuilder:validation:Optional
+	Node string `json:"node,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	IPs []string `json:"ips,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Checks []NetworkTestCheck `json:"checks,omitempty"`
+}
+
+// NetworkTestCheck is the result of a single check of a probe Pod.
+type NetworkTestCheck struct {
+	// Name is one of 'duplicate-address', 'gateway-arp', 'gateway-ping' and 'target-ping'.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	Target string `json:"target,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Passed bool `json:"passed"`
+
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spidernetworktests",scope="Namespaced",shortName={snt},singular="spidernetworktest"
+// +kubebuilder:printcolumn:JSONPath=".status.phase",description="phase",name="PHASE",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.message",description="message",name="MESSAGE",type=string
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SpiderNetworkTest is the Schema for the spidernetworktests API.
+type SpiderNetworkTest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SpiderNetworkTestSpec   `json:"spec,omitempty"`
+	Status SpiderNetworkTestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderNetworkTestList contains a list of SpiderNetworkTest.
+type SpiderNetworkTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderNetworkTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderNetworkTest{}, &SpiderNetworkTestList{})
+}
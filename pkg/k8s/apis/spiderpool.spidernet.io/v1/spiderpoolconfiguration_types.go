@@ -0,0 +1,156 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SpiderpoolConfigurationSpec defines the desired configuration of Spiderpool components.
+// The specified fields override the same settings of ConfigMap spiderpool-conf and the
+// environment variables of the components.
+type SpiderpoolConfigurationSpec struct {
+	// +kubebuilder:validation:Optional
+	EnableIPv4 *bool `json:"enableIPv4,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EnableIPv6 *bool `json:"enableIPv6,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EnableStatefulSet *bool `json:"enableStatefulSet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EnableSpiderSubnet *bool `json:"enableSpiderSubnet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ClusterDefaultIPv4IPPool []string `json:"clusterDefaultIPv4IPPool,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ClusterDefaultIPv6IPPool []string `json:"clusterDefaultIPv6IPPool,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ClusterDefaultIPv4Subnet []string `json:"clusterDefaultIPv4Subnet,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ClusterDefaultIPv6Subnet []string `json:"clusterDefaultIPv6Subnet,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	ClusterSubnetDefaultFlexibleIPNumber *int64 `json:"clusterSubnetDefaultFlexibleIPNumber,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	GC *GCConfiguration `json:"gc,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Retry *RetryConfiguration `json:"retry,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Freeze puts Spiderpool into the maintenance freeze, it takes effect
+	// without restarting the components.
+	// +kubebuilder:validation:Optional
+	Freeze *FreezeConfiguration `json:"freeze,omitempty"`
+}
+
+// FreezeConfiguration defines the maintenance freeze of Spiderpool. While it
+// is enabled, no IP addresses are allocated and the controllers of IPPools
+// and Subnets are paused, but the IP addresses are still released.
+type FreezeConfiguration struct {
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Reason is reported in the errors of the refused allocations.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// GCConfiguration defines the IP garbage collection settings of spiderpool-controller.
+type GCConfiguration struct {
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	TerminatingPodEnabled *bool `json:"terminatingPodEnabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EvictedPodEnabled *bool `json:"evictedPodEnabled,omitempty"`
+
+	// KubeletCrossCheckEnabled makes spiderpool-controller confirm with the
+	// kubelet that no sandbox still uses an IP address before it is released.
+	// +kubebuilder:validation:Optional
+	KubeletCrossCheckEnabled *bool `json:"kubeletCrossCheckEnabled,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AdditionalGraceDelaySeconds *int64 `json:"additionalGraceDelaySeconds,omitempty"`
+}
+
+// RetryConfiguration defines the retry budgets of Spiderpool components.
+type RetryConfiguration struct {
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	UpdateCRMaxRetries *int64 `json:"updateCRMaxRetries,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	UpdateCRRetryUnitTimeMilliseconds *int64 `json:"updateCRRetryUnitTimeMilliseconds,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	WorkQueueMaxRetries *int64 `json:"workQueueMaxRetries,omitempty"`
+}
+
+// SpiderpoolConfigurationStatus defines the observed state of SpiderpoolConfiguration.
+type SpiderpoolConfigurationStatus struct {
+	// +kubebuilder:validation:Optional
+	Components []ComponentConfiguration `json:"components,omitempty"`
+}
+
+// ComponentConfiguration shows the configuration that is active on a Spiderpool component.
+type ComponentConfiguration struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ActiveConfiguration SpiderpoolConfigurationSpec `json:"activeConfiguration,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spiderpoolconfigurations",scope="Cluster",shortName={spc},singular="spiderpoolconfiguration"
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SpiderpoolConfiguration is the Schema for the spiderpoolconfigurations API.
+type SpiderpoolConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SpiderpoolConfigurationSpec   `json:"spec,omitempty"`
+	Status SpiderpoolConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderpoolConfigurationList contains a list of SpiderpoolConfiguration.
+type SpiderpoolConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderpoolConfiguration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderpoolConfiguration{}, &SpiderpoolConfigurationList{})
+}
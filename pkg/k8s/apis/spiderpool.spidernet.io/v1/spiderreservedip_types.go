@@ -15,10 +15,32 @@ type ReservedIPSpec struct {
 
 	// +kubebuilder:validation:Optional
 	IPs []string `json:"ips,omitempty"`
+
+	// ExpiresAt is when the reservation ends, the SpiderReservedIP is
+	// deleted by then and its IP addresses are released back. It is set from
+	// 'spec.ttl' if not specified.
+	// +kubebuilder:validation:Optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// TTL is how long the IP addresses are reserved since the creation of
+	// the SpiderReservedIP, e.g. "72h".
+	// +kubebuilder:validation:Optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// Enforcement decides how the reservation is enforced. The IP addresses
+	// are never allocated in mode 'hard', while they are still allocatable
+	// in mode 'soft' but each allocation of them is warned with an event and
+	// a metric, to measure the impact before the hard cutover.
+	// +kubebuilder:default=hard
+	// +kubebuilder:validation:Enum=hard;soft
+	// +kubebuilder:validation:Optional
+	Enforcement *string `json:"enforcement,omitempty"`
 }
 
 // +kubebuilder:resource:categories={spiderpool},path="spiderreservedips",scope="Cluster",shortName={sr},singular="spiderreservedip"
 // +kubebuilder:printcolumn:JSONPath=".spec.ipVersion",description="ipVersion",name="VERSION",type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.expiresAt",description="expiresAt",name="EXPIRES",type=date
+// +kubebuilder:printcolumn:JSONPath=".spec.enforcement",description="enforcement",name="ENFORCEMENT",type=string
 // +kubebuilder:object:root=true
 
 // SpiderReservedIP is the Schema for the spiderreservedips API.
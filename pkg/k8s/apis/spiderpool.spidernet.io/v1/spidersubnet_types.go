@@ -8,12 +8,18 @@ import (
 )
 
 // SubnetSpec defines the desired state of SpiderSubnet.
+// +kubebuilder:validation:XValidation:rule="!has(self.ipVersion) || (self.ipVersion == 6 ? self.subnet.matches(':') : !self.subnet.matches(':'))",message="ipVersion must match the IP family of subnet"
+// +kubebuilder:validation:XValidation:rule="!has(self.gateway) || (self.subnet.matches(':') ? self.gateway.matches(':') : !self.gateway.matches(':'))",message="gateway must be in the same IP family as subnet"
+// +kubebuilder:validation:XValidation:rule="!has(self.gateway) || self.subnet.matches(':') || self.gateway.matches(':') || [0, 1, 2, 3].all(i, int(self.gateway.split('.')[i]) / [256, 128, 64, 32, 16, 8, 4, 2, 1][(int(self.subnet.split('/')[1]) < 8 * i ? 0 : (int(self.subnet.split('/')[1]) > 8 * i + 8 ? 8 : int(self.subnet.split('/')[1]) - 8 * i))] - int(self.subnet.split('/')[0].split('.')[i]) / [256, 128, 64, 32, 16, 8, 4, 2, 1][(int(self.subnet.split('/')[1]) < 8 * i ? 0 : (int(self.subnet.split('/')[1]) > 8 * i + 8 ? 8 : int(self.subnet.split('/')[1]) - 8 * i))] == 0)",message="gateway must be in subnet"
+// +kubebuilder:validation:XValidation:rule="!has(self.routes) || self.routes.all(r, self.subnet.matches(':') ? r.dst.matches(':') : !r.dst.matches(':'))",message="routes must be in the same IP family as subnet"
 type SubnetSpec struct {
 	// +kubebuilder:validation:Enum=4;6
 	// +kubebuilder:validation:Optional
 	IPVersion *int64 `json:"ipVersion,omitempty"`
 
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=49
+	// +kubebuilder:validation:XValidation:rule="self.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}/(3[0-2]|[12]?[0-9])|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*/(12[0-8]|1[01][0-9]|[1-9]?[0-9]))$')",message="subnet must be a valid CIDR"
 	Subnet string `json:"subnet"`
 
 	// +kubebuilder:validation:Optional
@@ -23,6 +29,8 @@ type SubnetSpec struct {
 	ExcludeIPs []string `json:"excludeIPs,omitempty"`
 
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=45
+	// +kubebuilder:validation:XValidation:rule="self.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*)$')",message="gateway must be a valid IP address"
 	Gateway *string `json:"gateway,omitempty"`
 
 	// +kubebuilder:default=0
@@ -32,7 +40,19 @@ type SubnetSpec struct {
 	Vlan *int64 `json:"vlan,omitempty"`
 
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=1024
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.dst.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}/(3[0-2]|[12]?[0-9])|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*/(12[0-8]|1[01][0-9]|[1-9]?[0-9]))$'))",message="dst of routes must be a valid CIDR"
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.gw.matches('^((25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])([.](25[0-5]|2[0-4][0-9]|1[0-9][0-9]|[1-9]?[0-9])){3}|[0-9a-fA-F:.]*:[0-9a-fA-F:.]*)$'))",message="gw of routes must be a valid IP address"
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.dst.matches(':') ? r.gw.matches(':') : !r.gw.matches(':'))",message="dst and gw of routes must be in the same IP family"
 	Routes []Route `json:"routes,omitempty"`
+
+	// MinFreeIPs is the headroom of free IP addresses kept in the
+	// SpiderSubnet, e.g. for the IPPools of the critical system workloads
+	// created later. The IPPools controlled by the SpiderSubnet are not
+	// scaled up if it would leave fewer free IP addresses than it.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MinFreeIPs *int64 `json:"minFreeIPs,omitempty"`
 }
 
 // SubnetStatus defines the observed state of SpiderSubnet.
@@ -47,6 +67,9 @@ type SubnetStatus struct {
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Optional
 	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ReservedBlocks PoolReservedBlocks `json:"reservedBlocks,omitempty"`
 }
 
 // PoolIPPreAllocations is a map of pool IP pre-allocation details indexed by pool name.
@@ -57,6 +80,9 @@ type PoolIPPreAllocation struct {
 	IPs []string `json:"ips"`
 }
 
+// PoolReservedBlocks is a map of the contiguous CIDR blocks reserved for auto-created IPPools indexed by pool name.
+type PoolReservedBlocks map[string]string
+
 // +kubebuilder:resource:categories={spiderpool},path="spidersubnets",scope="Cluster",shortName={ss},singular="spidersubnet"
 // +kubebuilder:printcolumn:JSONPath=".spec.ipVersion",description="ipVersion",name="VERSION",type=string
 // +kubebuilder:printcolumn:JSONPath=".spec.subnet",description="subnet",name="SUBNET",type=string
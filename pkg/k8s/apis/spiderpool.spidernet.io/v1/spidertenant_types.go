@@ -0,0 +1,68 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantSpec defines the IP quota of a tenant. The SpiderSubnets join the
+// tenant with the label 'ipam.spidernet.io/tenant'.
+type TenantSpec struct {
+	// MaxIPCount is the max number of IP addresses of all the SpiderSubnets
+	// of the tenant, unlimited if unset.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	MaxIPCount *int64 `json:"maxIPCount,omitempty"`
+}
+
+// TenantStatus defines the observed state of SpiderTenant.
+type TenantStatus struct {
+	// Subnets are the names of the SpiderSubnets of the tenant.
+	// +kubebuilder:validation:Optional
+	Subnets []string `json:"subnets,omitempty"`
+
+	// TotalIPCount is the number of IP addresses of all the SpiderSubnets
+	// of the tenant.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	TotalIPCount *int64 `json:"totalIPCount,omitempty"`
+
+	// AllocatedIPCount is the number of IP addresses allocated to the
+	// IPPools from all the SpiderSubnets of the tenant.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spidertenants",scope="Cluster",shortName={st},singular="spidertenant"
+// +kubebuilder:printcolumn:JSONPath=".spec.maxIPCount",description="maxIPCount",name="MAX-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.totalIPCount",description="totalIPCount",name="TOTAL-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.allocatedIPCount",description="allocatedIPCount",name="ALLOCATED-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SpiderTenant is the Schema for the spidertenants API.
+type SpiderTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantSpec   `json:"spec,omitempty"`
+	Status TenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderTenantList contains a list of SpiderTenant.
+type SpiderTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderTenant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderTenant{}, &SpiderTenantList{})
+}
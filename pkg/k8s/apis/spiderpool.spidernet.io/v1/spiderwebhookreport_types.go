@@ -0,0 +1,76 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WebhookReportStatus defines the observed state of SpiderWebhookReport.
+type WebhookReportStatus struct {
+	// Denials are the requests denied by the webhooks, aggregated by the
+	// kind, the operation and the reason.
+	// +kubebuilder:validation:Optional
+	Denials []WebhookDenial `json:"denials,omitempty"`
+
+	// TotalDenials is the sum of the counts of all denials.
+	// +kubebuilder:validation:Optional
+	TotalDenials int64 `json:"totalDenials,omitempty"`
+}
+
+// WebhookDenial is the count of the requests denied by the webhooks for
+// the same reason.
+type WebhookDenial struct {
+	// Kind is the kind of the denied object, SpiderIPPool or SpiderSubnet.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// +kubebuilder:validation:Enum=CREATE;UPDATE;DELETE
+	// +kubebuilder:validation:Required
+	Operation string `json:"operation"`
+
+	// Reason is the kind of the misconfiguration, e.g. 'overlap',
+	// 'headroom', 'gateway' or 'terminating'.
+	// +kubebuilder:validation:Required
+	Reason string `json:"reason"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Required
+	Count int64 `json:"count"`
+
+	// LastMessage is the message of the latest denial.
+	// +kubebuilder:validation:Optional
+	LastMessage string `json:"lastMessage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	LastTime *metav1.Time `json:"lastTime,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spiderwebhookreports",scope="Cluster",shortName={swr},singular="spiderwebhookreport"
+// +kubebuilder:printcolumn:JSONPath=".status.totalDenials",description="totalDenials",name="TOTAL-DENIALS",type=integer
+// +kubebuilder:printcolumn:JSONPath=".metadata.creationTimestamp",description="age",name="AGE",type=date
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// SpiderWebhookReport is the Schema for the spiderwebhookreports API.
+type SpiderWebhookReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status WebhookReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderWebhookReportList contains a list of SpiderWebhookReport.
+type SpiderWebhookReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderWebhookReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderWebhookReport{}, &SpiderWebhookReportList{})
+}
@@ -43,6 +43,15 @@ func (in *IPPoolSpec) String() string {
 		`PodAffinity:` + fmt.Sprintf("%v", in.PodAffinity) + `,`,
 		`NamespaceAffinity:` + fmt.Sprintf("%v", in.NamespaceAffinity) + `,`,
 		`NodeAffinity:` + fmt.Sprintf("%v", in.NodeAffinity) + `,`,
+		`EnableGatewayDetection:` + stringutil.ValueToStringGenerated(in.EnableGatewayDetection) + `,`,
+		`EnableIPConflictDetection:` + stringutil.ValueToStringGenerated(in.EnableIPConflictDetection) + `,`,
+		`DNS:` + stringutil.ValueToStringGenerated(in.DNS) + `,`,
+		`IPv6AssignmentMode:` + stringutil.ValueToStringGenerated(in.IPv6AssignmentMode) + `,`,
+		`SLAACCoexistence:` + stringutil.ValueToStringGenerated(in.SLAACCoexistence) + `,`,
+		`DelegatedPrefixLength:` + stringutil.ValueToStringGenerated(in.DelegatedPrefixLength) + `,`,
+		`Bandwidth:` + stringutil.ValueToStringGenerated(in.Bandwidth) + `,`,
+		`AllocationWindow:` + stringutil.ValueToStringGenerated(in.AllocationWindow) + `,`,
+		`DisableSNAT:` + stringutil.ValueToStringGenerated(in.DisableSNAT) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -59,6 +68,9 @@ func (in *IPPoolStatus) String() string {
 		`TotalIPCount:` + stringutil.ValueToStringGenerated(in.TotalIPCount) + `,`,
 		`AllocatedIPCount:` + stringutil.ValueToStringGenerated(in.AllocatedIPCount) + `,`,
 		`AutoDesiredIPCount:` + stringutil.ValueToStringGenerated(in.AutoDesiredIPCount) + `,`,
+		`AutoExpandedIPCount:` + stringutil.ValueToStringGenerated(in.AutoExpandedIPCount) + `,`,
+		`EgressIPs:` + fmt.Sprintf("%+v", in.EgressIPs) + `,`,
+		`DelegatedPrefixes:` + fmt.Sprintf("%+v", in.DelegatedPrefixes) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -114,6 +126,7 @@ func (in *PodIPAllocation) String() string {
 
 	s := strings.Join([]string{`&PodIPAllocation{`,
 		`ContainerID:` + fmt.Sprintf("%+v", in.ContainerID) + `,`,
+		`UID:` + fmt.Sprintf("%+v", in.UID) + `,`,
 		`Node:` + stringutil.ValueToStringGenerated(in.Node) + `,`,
 		`IPs:` + repeatedStringForIPs + `,`,
 		`CreationTime:` + fmt.Sprintf("%v", in.CreationTime) + `,`,
@@ -138,7 +151,11 @@ func (in *IPAllocationDetail) String() string {
 		`IPv4Gateway:` + stringutil.ValueToStringGenerated(in.IPv4Gateway) + `,`,
 		`IPv6Gateway:` + stringutil.ValueToStringGenerated(in.IPv6Gateway) + `,`,
 		`CleanGateway:` + stringutil.ValueToStringGenerated(in.CleanGateway) + `,`,
+		`DefaultRoute:` + stringutil.ValueToStringGenerated(in.DefaultRoute) + `,`,
 		`Routes:` + fmt.Sprintf("%+v", in.Routes) + `,`,
+		`SLAACIPv6:` + fmt.Sprintf("%v", in.SLAACIPv6) + `,`,
+		`IPv4DelegatedPrefix:` + stringutil.ValueToStringGenerated(in.IPv4DelegatedPrefix) + `,`,
+		`IPv6DelegatedPrefix:` + stringutil.ValueToStringGenerated(in.IPv6DelegatedPrefix) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -216,6 +233,7 @@ func (in *SubnetStatus) String() string {
 		`ControlledIPPools:` + fmt.Sprintf("%v", in.ControlledIPPools) + `,`,
 		`TotalIPCount:` + stringutil.ValueToStringGenerated(in.TotalIPCount) + `,`,
 		`AllocatedIPCount:` + stringutil.ValueToStringGenerated(in.AllocatedIPCount) + `,`,
+		`ReservedBlocks:` + fmt.Sprintf("%v", in.ReservedBlocks) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -13,6 +13,276 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllocationWindow) DeepCopyInto(out *AllocationWindow) {
+	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllocationWindow.
+func (in *AllocationWindow) DeepCopy() *AllocationWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(AllocationWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bandwidth) DeepCopyInto(out *Bandwidth) {
+	*out = *in
+	if in.IngressRate != nil {
+		in, out := &in.IngressRate, &out.IngressRate
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IngressBurst != nil {
+		in, out := &in.IngressBurst, &out.IngressBurst
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EgressRate != nil {
+		in, out := &in.EgressRate, &out.EgressRate
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EgressBurst != nil {
+		in, out := &in.EgressBurst, &out.EgressBurst
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bandwidth.
+func (in *Bandwidth) DeepCopy() *Bandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(Bandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentConfiguration) DeepCopyInto(out *ComponentConfiguration) {
+	*out = *in
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	in.ActiveConfiguration.DeepCopyInto(&out.ActiveConfiguration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfiguration.
+func (in *ComponentConfiguration) DeepCopy() *ComponentConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorSpec) DeepCopyInto(out *CoordinatorSpec) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceCIDRs != nil {
+		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HijackCustomCIDRs != nil {
+		in, out := &in.HijackCustomCIDRs, &out.HijackCustomCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TunePodRoutes != nil {
+		in, out := &in.TunePodRoutes, &out.TunePodRoutes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostRuleTable != nil {
+		in, out := &in.HostRuleTable, &out.HostRuleTable
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HostRPFilter != nil {
+		in, out := &in.HostRPFilter, &out.HostRPFilter
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HostPort != nil {
+		in, out := &in.HostPort, &out.HostPort
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorSpec.
+func (in *CoordinatorSpec) DeepCopy() *CoordinatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoordinatorStatus) DeepCopyInto(out *CoordinatorStatus) {
+	*out = *in
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceCIDRs != nil {
+		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoordinatorStatus.
+func (in *CoordinatorStatus) DeepCopy() *CoordinatorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CoordinatorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNS) DeepCopyInto(out *DNS) {
+	*out = *in
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
+func (in *DNS) DeepCopy() *DNS {
+	if in == nil {
+		return nil
+	}
+	out := new(DNS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPReservation) DeepCopyInto(out *EgressIPReservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPReservation.
+func (in *EgressIPReservation) DeepCopy() *EgressIPReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeConfiguration) DeepCopyInto(out *FreezeConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeConfiguration.
+func (in *FreezeConfiguration) DeepCopy() *FreezeConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCConfiguration) DeepCopyInto(out *GCConfiguration) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TerminatingPodEnabled != nil {
+		in, out := &in.TerminatingPodEnabled, &out.TerminatingPodEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EvictedPodEnabled != nil {
+		in, out := &in.EvictedPodEnabled, &out.EvictedPodEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KubeletCrossCheckEnabled != nil {
+		in, out := &in.KubeletCrossCheckEnabled, &out.KubeletCrossCheckEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AdditionalGraceDelaySeconds != nil {
+		in, out := &in.AdditionalGraceDelaySeconds, &out.AdditionalGraceDelaySeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCConfiguration.
+func (in *GCConfiguration) DeepCopy() *GCConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GCConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPAllocationDetail) DeepCopyInto(out *IPAllocationDetail) {
 	*out = *in
@@ -56,11 +326,31 @@ func (in *IPAllocationDetail) DeepCopyInto(out *IPAllocationDetail) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DefaultRoute != nil {
+		in, out := &in.DefaultRoute, &out.DefaultRoute
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Routes != nil {
 		in, out := &in.Routes, &out.Routes
 		*out = make([]Route, len(*in))
 		copy(*out, *in)
 	}
+	if in.SLAACIPv6 != nil {
+		in, out := &in.SLAACIPv6, &out.SLAACIPv6
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPv4DelegatedPrefix != nil {
+		in, out := &in.IPv4DelegatedPrefix, &out.IPv4DelegatedPrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6DelegatedPrefix != nil {
+		in, out := &in.IPv6DelegatedPrefix, &out.IPv6DelegatedPrefix
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAllocationDetail.
@@ -126,6 +416,66 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NamespaceDefault != nil {
+		in, out := &in.NamespaceDefault, &out.NamespaceDefault
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableGatewayDetection != nil {
+		in, out := &in.EnableGatewayDetection, &out.EnableGatewayDetection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableIPConflictDetection != nil {
+		in, out := &in.EnableIPConflictDetection, &out.EnableIPConflictDetection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPv6AssignmentMode != nil {
+		in, out := &in.IPv6AssignmentMode, &out.IPv6AssignmentMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.SLAACCoexistence != nil {
+		in, out := &in.SLAACCoexistence, &out.SLAACCoexistence
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DelegatedPrefixLength != nil {
+		in, out := &in.DelegatedPrefixLength, &out.DelegatedPrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinFreeIPs != nil {
+		in, out := &in.MinFreeIPs, &out.MinFreeIPs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(Bandwidth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllocationWindow != nil {
+		in, out := &in.AllocationWindow, &out.AllocationWindow
+		*out = new(AllocationWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisableSNAT != nil {
+		in, out := &in.DisableSNAT, &out.DisableSNAT
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
@@ -148,6 +498,11 @@ func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TotalIPCount != nil {
 		in, out := &in.TotalIPCount, &out.TotalIPCount
 		*out = new(int64)
@@ -163,6 +518,32 @@ func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.AutoExpandedIPCount != nil {
+		in, out := &in.AutoExpandedIPCount, &out.AutoExpandedIPCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EgressIPs != nil {
+		in, out := &in.EgressIPs, &out.EgressIPs
+		*out = make(PoolEgressIPReservations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DelegatedPrefixes != nil {
+		in, out := &in.DelegatedPrefixes, &out.DelegatedPrefixes
+		*out = make(PoolPrefixDelegations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
@@ -176,44 +557,105 @@ func (in *IPPoolStatus) DeepCopy() *IPPoolStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodIPAllocation) DeepCopyInto(out *PodIPAllocation) {
+func (in *NetworkTestCheck) DeepCopyInto(out *NetworkTestCheck) {
 	*out = *in
-	if in.Node != nil {
-		in, out := &in.Node, &out.Node
-		*out = new(string)
-		**out = **in
-	}
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
-		*out = make([]IPAllocationDetail, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CreationTime != nil {
-		in, out := &in.CreationTime, &out.CreationTime
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIPAllocation.
-func (in *PodIPAllocation) DeepCopy() *PodIPAllocation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTestCheck.
+func (in *NetworkTestCheck) DeepCopy() *NetworkTestCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(PodIPAllocation)
+	out := new(NetworkTestCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolIPAllocation) DeepCopyInto(out *PoolIPAllocation) {
+func (in *NetworkTestProbeResult) DeepCopyInto(out *NetworkTestProbeResult) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPAllocation.
-func (in *PoolIPAllocation) DeepCopy() *PoolIPAllocation {
-	if in == nil {
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]NetworkTestCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTestProbeResult.
+func (in *NetworkTestProbeResult) DeepCopy() *NetworkTestProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTestProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodIPAllocation) DeepCopyInto(out *PodIPAllocation) {
+	*out = *in
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]IPAllocationDetail, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIPAllocation.
+func (in *PodIPAllocation) DeepCopy() *PodIPAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(PodIPAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PoolEgressIPReservations) DeepCopyInto(out *PoolEgressIPReservations) {
+	{
+		in := &in
+		*out = make(PoolEgressIPReservations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolEgressIPReservations.
+func (in PoolEgressIPReservations) DeepCopy() PoolEgressIPReservations {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolEgressIPReservations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolIPAllocation) DeepCopyInto(out *PoolIPAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPAllocation.
+func (in *PoolIPAllocation) DeepCopy() *PoolIPAllocation {
+	if in == nil {
 		return nil
 	}
 	out := new(PoolIPAllocation)
@@ -273,76 +715,648 @@ func (in PoolIPPreAllocations) DeepCopyInto(out *PoolIPPreAllocations) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPPreAllocations.
-func (in PoolIPPreAllocations) DeepCopy() PoolIPPreAllocations {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolIPPreAllocations.
+func (in PoolIPPreAllocations) DeepCopy() PoolIPPreAllocations {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolIPPreAllocations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PoolPrefixDelegations) DeepCopyInto(out *PoolPrefixDelegations) {
+	{
+		in := &in
+		*out = make(PoolPrefixDelegations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolPrefixDelegations.
+func (in PoolPrefixDelegations) DeepCopy() PoolPrefixDelegations {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolPrefixDelegations)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in PoolReservedBlocks) DeepCopyInto(out *PoolReservedBlocks) {
+	{
+		in := &in
+		*out = make(PoolReservedBlocks, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolReservedBlocks.
+func (in PoolReservedBlocks) DeepCopy() PoolReservedBlocks {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolReservedBlocks)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RangeEncodedIPAllocations) DeepCopyInto(out *RangeEncodedIPAllocations) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Strings != nil {
+		in, out := &in.Strings, &out.Strings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([][]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RangeEncodedIPAllocations.
+func (in *RangeEncodedIPAllocations) DeepCopy() *RangeEncodedIPAllocations {
+	if in == nil {
+		return nil
+	}
+	out := new(RangeEncodedIPAllocations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservedIPSpec) DeepCopyInto(out *ReservedIPSpec) {
+	*out = *in
+	if in.IPVersion != nil {
+		in, out := &in.IPVersion, &out.IPVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Enforcement != nil {
+		in, out := &in.Enforcement, &out.Enforcement
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedIPSpec.
+func (in *ReservedIPSpec) DeepCopy() *ReservedIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservedIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryConfiguration) DeepCopyInto(out *RetryConfiguration) {
+	*out = *in
+	if in.UpdateCRMaxRetries != nil {
+		in, out := &in.UpdateCRMaxRetries, &out.UpdateCRMaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UpdateCRRetryUnitTimeMilliseconds != nil {
+		in, out := &in.UpdateCRRetryUnitTimeMilliseconds, &out.UpdateCRRetryUnitTimeMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.WorkQueueMaxRetries != nil {
+		in, out := &in.WorkQueueMaxRetries, &out.WorkQueueMaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryConfiguration.
+func (in *RetryConfiguration) DeepCopy() *RetryConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderCoordinator) DeepCopyInto(out *SpiderCoordinator) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderCoordinator.
+func (in *SpiderCoordinator) DeepCopy() *SpiderCoordinator {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderCoordinator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderCoordinator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderCoordinatorList) DeepCopyInto(out *SpiderCoordinatorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderCoordinator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderCoordinatorList.
+func (in *SpiderCoordinatorList) DeepCopy() *SpiderCoordinatorList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderCoordinatorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderCoordinatorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderEndpoint) DeepCopyInto(out *SpiderEndpoint) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderEndpoint.
+func (in *SpiderEndpoint) DeepCopy() *SpiderEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderEndpoint) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderEndpointList) DeepCopyInto(out *SpiderEndpointList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderEndpointList.
+func (in *SpiderEndpointList) DeepCopy() *SpiderEndpointList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderEndpointList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderEndpointList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderIPPool) DeepCopyInto(out *SpiderIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPPool.
+func (in *SpiderIPPool) DeepCopy() *SpiderIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderIPPoolList) DeepCopyInto(out *SpiderIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPPoolList.
+func (in *SpiderIPPoolList) DeepCopy() *SpiderIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderIPPoolShard) DeepCopyInto(out *SpiderIPPoolShard) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.AllocatedIPs != nil {
+		in, out := &in.AllocatedIPs, &out.AllocatedIPs
+		*out = make(PoolIPAllocations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPPoolShard.
+func (in *SpiderIPPoolShard) DeepCopy() *SpiderIPPoolShard {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderIPPoolShard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderIPPoolShard) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderIPPoolShardList) DeepCopyInto(out *SpiderIPPoolShardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderIPPoolShard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPPoolShardList.
+func (in *SpiderIPPoolShardList) DeepCopy() *SpiderIPPoolShardList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderIPPoolShardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderIPPoolShardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderNetworkTest) DeepCopyInto(out *SpiderNetworkTest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderNetworkTest.
+func (in *SpiderNetworkTest) DeepCopy() *SpiderNetworkTest {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderNetworkTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderNetworkTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderNetworkTestList) DeepCopyInto(out *SpiderNetworkTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderNetworkTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderNetworkTestList.
+func (in *SpiderNetworkTestList) DeepCopy() *SpiderNetworkTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderNetworkTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderNetworkTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderNetworkTestSpec) DeepCopyInto(out *SpiderNetworkTestSpec) {
+	*out = *in
+	if in.IPv4IPPools != nil {
+		in, out := &in.IPv4IPPools, &out.IPv4IPPools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPv6IPPools != nil {
+		in, out := &in.IPv6IPPools, &out.IPv6IPPools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkAttachmentDefinition != nil {
+		in, out := &in.NetworkAttachmentDefinition, &out.NetworkAttachmentDefinition
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(string)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderNetworkTestSpec.
+func (in *SpiderNetworkTestSpec) DeepCopy() *SpiderNetworkTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderNetworkTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderNetworkTestStatus) DeepCopyInto(out *SpiderNetworkTestStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]NetworkTestProbeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderNetworkTestStatus.
+func (in *SpiderNetworkTestStatus) DeepCopy() *SpiderNetworkTestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolIPPreAllocations)
+	out := new(SpiderNetworkTestStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReservedIPSpec) DeepCopyInto(out *ReservedIPSpec) {
+func (in *SpiderReservedIP) DeepCopyInto(out *SpiderReservedIP) {
 	*out = *in
-	if in.IPVersion != nil {
-		in, out := &in.IPVersion, &out.IPVersion
-		*out = new(int64)
-		**out = **in
-	}
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservedIPSpec.
-func (in *ReservedIPSpec) DeepCopy() *ReservedIPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderReservedIP.
+func (in *SpiderReservedIP) DeepCopy() *SpiderReservedIP {
 	if in == nil {
 		return nil
 	}
-	out := new(ReservedIPSpec)
+	out := new(SpiderReservedIP)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderReservedIP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Route) DeepCopyInto(out *Route) {
+func (in *SpiderReservedIPList) DeepCopyInto(out *SpiderReservedIPList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpiderReservedIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
-func (in *Route) DeepCopy() *Route {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderReservedIPList.
+func (in *SpiderReservedIPList) DeepCopy() *SpiderReservedIPList {
 	if in == nil {
 		return nil
 	}
-	out := new(Route)
+	out := new(SpiderReservedIPList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpiderReservedIPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderEndpoint) DeepCopyInto(out *SpiderEndpoint) {
+func (in *SpiderSubnet) DeepCopyInto(out *SpiderSubnet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderEndpoint.
-func (in *SpiderEndpoint) DeepCopy() *SpiderEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderSubnet.
+func (in *SpiderSubnet) DeepCopy() *SpiderSubnet {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderEndpoint)
+	out := new(SpiderSubnet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderEndpoint) DeepCopyObject() runtime.Object {
+func (in *SpiderSubnet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -350,31 +1364,31 @@ func (in *SpiderEndpoint) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderEndpointList) DeepCopyInto(out *SpiderEndpointList) {
+func (in *SpiderSubnetList) DeepCopyInto(out *SpiderSubnetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SpiderEndpoint, len(*in))
+		*out = make([]SpiderSubnet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderEndpointList.
-func (in *SpiderEndpointList) DeepCopy() *SpiderEndpointList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderSubnetList.
+func (in *SpiderSubnetList) DeepCopy() *SpiderSubnetList {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderEndpointList)
+	out := new(SpiderSubnetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderEndpointList) DeepCopyObject() runtime.Object {
+func (in *SpiderSubnetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -382,7 +1396,7 @@ func (in *SpiderEndpointList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderIPPool) DeepCopyInto(out *SpiderIPPool) {
+func (in *SpiderTenant) DeepCopyInto(out *SpiderTenant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -390,18 +1404,18 @@ func (in *SpiderIPPool) DeepCopyInto(out *SpiderIPPool) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPPool.
-func (in *SpiderIPPool) DeepCopy() *SpiderIPPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderTenant.
+func (in *SpiderTenant) DeepCopy() *SpiderTenant {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderIPPool)
+	out := new(SpiderTenant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderIPPool) DeepCopyObject() runtime.Object {
+func (in *SpiderTenant) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -409,31 +1423,31 @@ func (in *SpiderIPPool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderIPPoolList) DeepCopyInto(out *SpiderIPPoolList) {
+func (in *SpiderTenantList) DeepCopyInto(out *SpiderTenantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SpiderIPPool, len(*in))
+		*out = make([]SpiderTenant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderIPPoolList.
-func (in *SpiderIPPoolList) DeepCopy() *SpiderIPPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderTenantList.
+func (in *SpiderTenantList) DeepCopy() *SpiderTenantList {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderIPPoolList)
+	out := new(SpiderTenantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderIPPoolList) DeepCopyObject() runtime.Object {
+func (in *SpiderTenantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -441,25 +1455,25 @@ func (in *SpiderIPPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderReservedIP) DeepCopyInto(out *SpiderReservedIP) {
+func (in *SpiderWebhookReport) DeepCopyInto(out *SpiderWebhookReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderReservedIP.
-func (in *SpiderReservedIP) DeepCopy() *SpiderReservedIP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderWebhookReport.
+func (in *SpiderWebhookReport) DeepCopy() *SpiderWebhookReport {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderReservedIP)
+	out := new(SpiderWebhookReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderReservedIP) DeepCopyObject() runtime.Object {
+func (in *SpiderWebhookReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -467,31 +1481,31 @@ func (in *SpiderReservedIP) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderReservedIPList) DeepCopyInto(out *SpiderReservedIPList) {
+func (in *SpiderWebhookReportList) DeepCopyInto(out *SpiderWebhookReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SpiderReservedIP, len(*in))
+		*out = make([]SpiderWebhookReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderReservedIPList.
-func (in *SpiderReservedIPList) DeepCopy() *SpiderReservedIPList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderWebhookReportList.
+func (in *SpiderWebhookReportList) DeepCopy() *SpiderWebhookReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderReservedIPList)
+	out := new(SpiderWebhookReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderReservedIPList) DeepCopyObject() runtime.Object {
+func (in *SpiderWebhookReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -499,7 +1513,7 @@ func (in *SpiderReservedIPList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderSubnet) DeepCopyInto(out *SpiderSubnet) {
+func (in *SpiderpoolConfiguration) DeepCopyInto(out *SpiderpoolConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -507,18 +1521,18 @@ func (in *SpiderSubnet) DeepCopyInto(out *SpiderSubnet) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderSubnet.
-func (in *SpiderSubnet) DeepCopy() *SpiderSubnet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderpoolConfiguration.
+func (in *SpiderpoolConfiguration) DeepCopy() *SpiderpoolConfiguration {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderSubnet)
+	out := new(SpiderpoolConfiguration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderSubnet) DeepCopyObject() runtime.Object {
+func (in *SpiderpoolConfiguration) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -526,37 +1540,141 @@ func (in *SpiderSubnet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SpiderSubnetList) DeepCopyInto(out *SpiderSubnetList) {
+func (in *SpiderpoolConfigurationList) DeepCopyInto(out *SpiderpoolConfigurationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SpiderSubnet, len(*in))
+		*out = make([]SpiderpoolConfiguration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderSubnetList.
-func (in *SpiderSubnetList) DeepCopy() *SpiderSubnetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderpoolConfigurationList.
+func (in *SpiderpoolConfigurationList) DeepCopy() *SpiderpoolConfigurationList {
 	if in == nil {
 		return nil
 	}
-	out := new(SpiderSubnetList)
+	out := new(SpiderpoolConfigurationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SpiderSubnetList) DeepCopyObject() runtime.Object {
+func (in *SpiderpoolConfigurationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderpoolConfigurationSpec) DeepCopyInto(out *SpiderpoolConfigurationSpec) {
+	*out = *in
+	if in.EnableIPv4 != nil {
+		in, out := &in.EnableIPv4, &out.EnableIPv4
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableIPv6 != nil {
+		in, out := &in.EnableIPv6, &out.EnableIPv6
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableStatefulSet != nil {
+		in, out := &in.EnableStatefulSet, &out.EnableStatefulSet
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableSpiderSubnet != nil {
+		in, out := &in.EnableSpiderSubnet, &out.EnableSpiderSubnet
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClusterDefaultIPv4IPPool != nil {
+		in, out := &in.ClusterDefaultIPv4IPPool, &out.ClusterDefaultIPv4IPPool
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDefaultIPv6IPPool != nil {
+		in, out := &in.ClusterDefaultIPv6IPPool, &out.ClusterDefaultIPv6IPPool
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDefaultIPv4Subnet != nil {
+		in, out := &in.ClusterDefaultIPv4Subnet, &out.ClusterDefaultIPv4Subnet
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDefaultIPv6Subnet != nil {
+		in, out := &in.ClusterDefaultIPv6Subnet, &out.ClusterDefaultIPv6Subnet
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterSubnetDefaultFlexibleIPNumber != nil {
+		in, out := &in.ClusterSubnetDefaultFlexibleIPNumber, &out.ClusterSubnetDefaultFlexibleIPNumber
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GC != nil {
+		in, out := &in.GC, &out.GC
+		*out = new(GCConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Freeze != nil {
+		in, out := &in.Freeze, &out.Freeze
+		*out = new(FreezeConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderpoolConfigurationSpec.
+func (in *SpiderpoolConfigurationSpec) DeepCopy() *SpiderpoolConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderpoolConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpiderpoolConfigurationStatus) DeepCopyInto(out *SpiderpoolConfigurationStatus) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpiderpoolConfigurationStatus.
+func (in *SpiderpoolConfigurationStatus) DeepCopy() *SpiderpoolConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SpiderpoolConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 	*out = *in
@@ -590,6 +1708,11 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = make([]Route, len(*in))
 		copy(*out, *in)
 	}
+	if in.MinFreeIPs != nil {
+		in, out := &in.MinFreeIPs, &out.MinFreeIPs
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
@@ -622,6 +1745,13 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ReservedBlocks != nil {
+		in, out := &in.ReservedBlocks, &out.ReservedBlocks
+		*out = make(PoolReservedBlocks, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
@@ -634,6 +1764,97 @@ func (in *SubnetStatus) DeepCopy() *SubnetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantSpec) DeepCopyInto(out *TenantSpec) {
+	*out = *in
+	if in.MaxIPCount != nil {
+		in, out := &in.MaxIPCount, &out.MaxIPCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSpec.
+func (in *TenantSpec) DeepCopy() *TenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantStatus) DeepCopyInto(out *TenantStatus) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TotalIPCount != nil {
+		in, out := &in.TotalIPCount, &out.TotalIPCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllocatedIPCount != nil {
+		in, out := &in.AllocatedIPCount, &out.AllocatedIPCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantStatus.
+func (in *TenantStatus) DeepCopy() *TenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDenial) DeepCopyInto(out *WebhookDenial) {
+	*out = *in
+	if in.LastTime != nil {
+		in, out := &in.LastTime, &out.LastTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDenial.
+func (in *WebhookDenial) DeepCopy() *WebhookDenial {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDenial)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookReportStatus) DeepCopyInto(out *WebhookReportStatus) {
+	*out = *in
+	if in.Denials != nil {
+		in, out := &in.Denials, &out.Denials
+		*out = make([]WebhookDenial, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookReportStatus.
+func (in *WebhookReportStatus) DeepCopy() *WebhookReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadEndpointStatus) DeepCopyInto(out *WorkloadEndpointStatus) {
 	*out = *in
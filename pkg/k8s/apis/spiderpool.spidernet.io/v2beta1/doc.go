@@ -0,0 +1,6 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v2beta1 is the v2beta1 version of the API.
+// +groupName=spiderpool.spidernet.io
+package v2beta1
@@ -0,0 +1,65 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v2beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// ConvertTo converts the SpiderIPPool to the hub version v1.
+func (in *SpiderIPPool) ConvertTo(hub conversion.Hub) error {
+	dst := hub.(*spiderpoolv1.SpiderIPPool)
+
+	allocatedIPs, err := ippoolmanager.DecodeIPAllocations(in.Status.AllocatedIPs)
+	if err != nil {
+		return fmt.Errorf("failed to decode the allocated IPs of IPPool %s: %w", in.Name, err)
+	}
+
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec = *in.Spec.DeepCopy()
+	dst.Status = spiderpoolv1.IPPoolStatus{
+		AllocatedIPs:        allocatedIPs,
+		Shards:              in.Status.Shards,
+		TotalIPCount:        in.Status.TotalIPCount,
+		AllocatedIPCount:    in.Status.AllocatedIPCount,
+		AutoDesiredIPCount:  in.Status.AutoDesiredIPCount,
+		AutoExpandedIPCount: in.Status.AutoExpandedIPCount,
+		EgressIPs:           in.Status.EgressIPs,
+		DelegatedPrefixes:   in.Status.DelegatedPrefixes,
+		Conditions:          in.Status.Conditions,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the SpiderIPPool from the hub version v1.
+func (in *SpiderIPPool) ConvertFrom(hub conversion.Hub) error {
+	src := hub.(*spiderpoolv1.SpiderIPPool)
+
+	allocatedIPs, err := ippoolmanager.EncodeIPAllocations(src.Status.AllocatedIPs)
+	if err != nil {
+		return fmt.Errorf("failed to encode the allocated IPs of IPPool %s: %w", src.Name, err)
+	}
+
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec = *src.Spec.DeepCopy()
+	in.Status = IPPoolStatus{
+		AllocatedIPs:        allocatedIPs,
+		Shards:              src.Status.Shards,
+		TotalIPCount:        src.Status.TotalIPCount,
+		AllocatedIPCount:    src.Status.AllocatedIPCount,
+		AutoDesiredIPCount:  src.Status.AutoDesiredIPCount,
+		AutoExpandedIPCount: src.Status.AutoExpandedIPCount,
+		EgressIPs:           src.Status.EgressIPs,
+		DelegatedPrefixes:   src.Status.DelegatedPrefixes,
+		Conditions:          src.Status.Conditions,
+	}
+
+	return nil
+}
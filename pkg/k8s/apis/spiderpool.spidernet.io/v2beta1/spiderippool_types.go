@@ -0,0 +1,88 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package v2beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// IPPoolStatus defines the observed state of SpiderIPPool.
+type IPPoolStatus struct {
+	// AllocatedIPs is the range-encoded IP allocation details.
+	// +kubebuilder:validation:Optional
+	AllocatedIPs *spiderpoolv1.RangeEncodedIPAllocations `json:"allocatedIPs,omitempty"`
+
+	// Shards are the names of the SpiderIPPoolShards holding the rest of the
+	// IP allocation details, once they would exceed the size limit of the
+	// status.
+	// +kubebuilder:validation:Optional
+	Shards []string `json:"shards,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	TotalIPCount *int64 `json:"totalIPCount,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AllocatedIPCount *int64 `json:"allocatedIPCount,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AutoDesiredIPCount *int64 `json:"autoDesiredIPCount,omitempty"`
+
+	// AutoExpandedIPCount is the number of IP addresses the auto-created
+	// IPPool is expanded with on the utilization pressure, which is
+	// included in the AutoDesiredIPCount.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	AutoExpandedIPCount *int64 `json:"autoExpandedIPCount,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	EgressIPs spiderpoolv1.PoolEgressIPReservations `json:"egressIPs,omitempty"`
+
+	// DelegatedPrefixes is the sub-prefixes delegated to Pods by the IPPool
+	// with 'spec.delegatedPrefixLength'.
+	// +kubebuilder:validation:Optional
+	DelegatedPrefixes spiderpoolv1.PoolPrefixDelegations `json:"delegatedPrefixes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:resource:categories={spiderpool},path="spiderippools",scope="Cluster",shortName={sp},singular="spiderippool"
+// +kubebuilder:printcolumn:JSONPath=".spec.ipVersion",description="ipVersion",name="VERSION",type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.subnet",description="subnet",name="SUBNET",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.allocatedIPCount",description="allocatedIPCount",name="ALLOCATED-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".status.totalIPCount",description="totalIPCount",name="TOTAL-IP-COUNT",type=integer
+// +kubebuilder:printcolumn:JSONPath=".spec.disable",description="disable",name="DISABLE",type=boolean
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// SpiderIPPool is the Schema for the spiderippools API.
+type SpiderIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   spiderpoolv1.IPPoolSpec `json:"spec,omitempty"`
+	Status IPPoolStatus            `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpiderIPPoolList contains a list of SpiderIPPool.
+type SpiderIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SpiderIPPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpiderIPPool{}, &SpiderIPPoolList{})
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeletclient
+
+import (
+	"time"
+)
+
+const (
+	defaultAddress = "https://127.0.0.1:10250"
+	defaultTimeout = 5 * time.Second
+)
+
+type KubeletClientConfig struct {
+	// Address is the address of the kubelet API of the node, which is
+	// reachable from spiderpool-agent with host network.
+	Address string
+
+	// ServerName is the name which the serving certificate of the kubelet
+	// is verified for, usually the name of the node. The host of Address is
+	// used if it is empty.
+	ServerName string
+
+	// CAFile is the CA certificate which the serving certificate of the
+	// kubelet is verified with. The CA of the cluster is used if it is
+	// empty, which signs the serving certificates requested by the kubelets
+	// with serverTLSBootstrap.
+	CAFile string
+
+	// Timeout is the timeout of each request to the kubelet.
+	Timeout time.Duration
+}
+
+func setDefaultsForKubeletClientConfig(config KubeletClientConfig) KubeletClientConfig {
+	if config.Address == "" {
+		config.Address = defaultAddress
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return config
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeletclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// KubeletClient queries the kubelet of the node about the Pods it runs. The
+// kubelet still knows the Pods whose sandboxes are not torn down yet, even
+// if they have been removed from kube-apiserver.
+type KubeletClient interface {
+	ListPods(ctx context.Context) ([]corev1.Pod, error)
+	// PodsUsingIP returns the '<namespace>/<name>' of the Pods using the IP
+	// address on the node.
+	PodsUsingIP(ctx context.Context, ip string) ([]string, error)
+}
+
+type kubeletClient struct {
+	config KubeletClientConfig
+	client *http.Client
+}
+
+// NewKubeletClient authenticates to the kubelet with the bearer token in
+// restConfig, usually the ServiceAccount token of spiderpool-agent, and
+// verifies the serving certificate of the kubelet with config.CAFile, or
+// the CA in restConfig.
+func NewKubeletClient(config KubeletClientConfig, restConfig *rest.Config) (KubeletClient, error) {
+	if restConfig == nil {
+		return nil, fmt.Errorf("rest config %w", constant.ErrMissingRequiredParam)
+	}
+
+	config = setDefaultsForKubeletClientConfig(config)
+	transportConfig := rest.AnonymousClientConfig(restConfig)
+	transportConfig.BearerToken = restConfig.BearerToken
+	transportConfig.BearerTokenFile = restConfig.BearerTokenFile
+	transportConfig.TLSClientConfig = rest.TLSClientConfig{
+		ServerName: config.ServerName,
+		CAFile:     restConfig.CAFile,
+		CAData:     restConfig.CAData,
+	}
+	if config.CAFile != "" {
+		transportConfig.TLSClientConfig.CAFile = config.CAFile
+		transportConfig.TLSClientConfig.CAData = nil
+	}
+
+	transport, err := rest.TransportFor(transportConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the transport to kubelet: %w", err)
+	}
+
+	return &kubeletClient{
+		config: config,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+		},
+	}, nil
+}
+
+func (kc *kubeletClient) ListPods(ctx context.Context) ([]corev1.Pod, error) {
+	url := strings.TrimSuffix(kc.config.Address, "/") + "/pods"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := kc.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Pods from kubelet: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Pods from kubelet: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list Pods from kubelet, status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	var podList corev1.PodList
+	if err := json.Unmarshal(body, &podList); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Pods from kubelet: %w", err)
+	}
+
+	return podList.Items, nil
+}
+
+func (kc *kubeletClient) PodsUsingIP(ctx context.Context, ip string) ([]string, error) {
+	if net.ParseIP(ip) == nil {
+		return nil, fmt.Errorf("%w: invalid IP address '%s'", constant.ErrWrongInput, ip)
+	}
+
+	pods, err := kc.ListPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for i := range pods {
+		if PodUsesIP(&pods[i], ip) {
+			result = append(result, pods[i].Namespace+"/"+pods[i].Name)
+		}
+	}
+
+	return result, nil
+}
+
+// PodUsesIP checks whether the IP address is assigned to any NIC of the
+// Pod, including the secondary ones reported in the network status
+// annotation of Multus. The Pods in host network and the terminated ones,
+// whose sandboxes have been torn down, are never using it.
+func PodUsesIP(pod *corev1.Pod, ip string) bool {
+	target := net.ParseIP(ip)
+	if target == nil || pod.Spec.HostNetwork {
+		return false
+	}
+
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	for _, podIP := range pod.Status.PodIPs {
+		if target.Equal(net.ParseIP(podIP.IP)) {
+			return true
+		}
+	}
+	if target.Equal(net.ParseIP(pod.Status.PodIP)) {
+		return true
+	}
+
+	anno, ok := pod.Annotations[netv1.NetworkStatusAnnot]
+	if !ok {
+		return false
+	}
+
+	var statuses []netv1.NetworkStatus
+	if err := json.Unmarshal([]byte(anno), &statuses); err != nil {
+		return false
+	}
+	for _, status := range statuses {
+		for _, statusIP := range status.IPs {
+			// Tolerate the IP addresses in CIDR notation.
+			if i := strings.Index(statusIP, "/"); i >= 0 {
+				statusIP = statusIP[:i]
+			}
+			if target.Equal(net.ParseIP(statusIP)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeletclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/kubeletclient"
+)
+
+var _ = Describe("KubeletClient", Label("kubelet_client_test"), func() {
+	newPod := func(name string, ips ...string) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for _, ip := range ips {
+			pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+		}
+		return pod
+	}
+
+	Describe("PodUsesIP", func() {
+		It("matches the IP addresses in Pod status", func() {
+			pod := newPod("pod", "172.18.40.10", "abcd:1234::10")
+			Expect(kubeletclient.PodUsesIP(&pod, "172.18.40.10")).To(BeTrue())
+			Expect(kubeletclient.PodUsesIP(&pod, "abcd:1234:0::10")).To(BeTrue())
+			Expect(kubeletclient.PodUsesIP(&pod, "172.18.40.11")).To(BeFalse())
+		})
+
+		It("matches the IP addresses of secondary NICs", func() {
+			pod := newPod("pod", "172.18.40.10")
+			statuses := []netv1.NetworkStatus{{Name: "macvlan", Interface: "net1", IPs: []string{"10.6.0.10/16"}}}
+			data, err := json.Marshal(statuses)
+			Expect(err).NotTo(HaveOccurred())
+			pod.Annotations = map[string]string{netv1.NetworkStatusAnnot: string(data)}
+
+			Expect(kubeletclient.PodUsesIP(&pod, "10.6.0.10")).To(BeTrue())
+			Expect(kubeletclient.PodUsesIP(&pod, "10.6.0.11")).To(BeFalse())
+		})
+
+		It("ignores the terminated Pods and Pods in host network", func() {
+			pod := newPod("pod", "172.18.40.10")
+			pod.Status.Phase = corev1.PodSucceeded
+			Expect(kubeletclient.PodUsesIP(&pod, "172.18.40.10")).To(BeFalse())
+
+			pod = newPod("pod", "172.18.40.10")
+			pod.Spec.HostNetwork = true
+			Expect(kubeletclient.PodUsesIP(&pod, "172.18.40.10")).To(BeFalse())
+		})
+
+		It("inputs invalid IP address", func() {
+			pod := newPod("pod", "172.18.40.10")
+			Expect(kubeletclient.PodUsesIP(&pod, "invalid")).To(BeFalse())
+		})
+	})
+
+	Describe("PodsUsingIP", func() {
+		var server *httptest.Server
+		var config kubeletclient.KubeletClientConfig
+		var restConfig *rest.Config
+		var token string
+
+		BeforeEach(func() {
+			token = ""
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				token = r.Header.Get("Authorization")
+				if r.URL.Path != "/pods" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				podList := corev1.PodList{Items: []corev1.Pod{
+					newPod("pod1", "172.18.40.10"),
+					newPod("pod2", "172.18.40.11"),
+				}}
+				_ = json.NewEncoder(w).Encode(podList)
+			}))
+			DeferCleanup(server.Close)
+
+			caFile := filepath.Join(GinkgoT().TempDir(), "kubelet-ca.crt")
+			caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			Expect(os.WriteFile(caFile, caData, 0o600)).To(Succeed())
+
+			config = kubeletclient.KubeletClientConfig{
+				Address:    server.URL,
+				ServerName: "example.com",
+				CAFile:     caFile,
+			}
+			restConfig = &rest.Config{BearerToken: "agent-token"}
+		})
+
+		It("inputs nil rest config", func() {
+			kc, err := kubeletclient.NewKubeletClient(config, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(kc).To(BeNil())
+		})
+
+		It("lists the Pods using the IP address with the bearer token", func() {
+			kc, err := kubeletclient.NewKubeletClient(config, restConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			pods, err := kc.PodsUsingIP(context.TODO(), "172.18.40.11")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pods).To(Equal([]string{"default/pod2"}))
+			Expect(token).To(Equal("Bearer agent-token"))
+
+			pods, err = kc.PodsUsingIP(context.TODO(), "172.18.40.12")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pods).To(BeEmpty())
+		})
+
+		It("verifies the serving certificate with the CA of the cluster by default", func() {
+			restConfig.CAFile = config.CAFile
+			config.CAFile = ""
+
+			kc, err := kubeletclient.NewKubeletClient(config, restConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = kc.PodsUsingIP(context.TODO(), "172.18.40.10")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the serving certificate signed by an unknown CA", func() {
+			config.CAFile = ""
+
+			kc, err := kubeletclient.NewKubeletClient(config, restConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = kc.PodsUsingIP(context.TODO(), "172.18.40.10")
+			Expect(err).To(HaveOccurred())
+			Expect(token).To(BeEmpty())
+		})
+
+		It("rejects the serving certificate issued for another name", func() {
+			config.ServerName = "node1"
+
+			kc, err := kubeletclient.NewKubeletClient(config, restConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = kc.PodsUsingIP(context.TODO(), "172.18.40.10")
+			Expect(err).To(HaveOccurred())
+			Expect(token).To(BeEmpty())
+		})
+
+		It("inputs invalid IP address", func() {
+			kc, err := kubeletclient.NewKubeletClient(config, restConfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = kc.PodsUsingIP(context.TODO(), "invalid")
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("fails to reach kubelet", func() {
+			kc, err := kubeletclient.NewKubeletClient(config, restConfig)
+			Expect(err).NotTo(HaveOccurred())
+			server.Close()
+
+			_, err = kc.PodsUsingIP(context.TODO(), "172.18.40.10")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -61,15 +61,21 @@ func (q *queue) AcquireTicket(ctx context.Context, tickets ...string) error {
 	logger := logutils.FromContext(ctx)
 	logger.Sugar().Debugf("Waiting in queue with expect tickets: %v", tickets)
 
-	// TODO(iiiceoo): When ctx times out or is canceled, AcquireTicket should
-	// not still be blocked.
-
 	e, err := q.queueUp(tickets...)
 	if err != nil {
 		return err
 	}
 
-	<-e.notifyCheckin
+	select {
+	case <-e.notifyCheckin:
+	case <-ctx.Done():
+		// The tickets may be granted while leaving the queue, return them
+		// back as the queuer won't work anymore.
+		if !q.leave(e) {
+			q.ReleaseTicket(ctx, e.wantedTickets...)
+		}
+		return fmt.Errorf("failed to wait in queue for tickets %v: %w", tickets, ctx.Err())
+	}
 	logger.Debug("Succeed to acquire tickets")
 
 	return nil
@@ -106,6 +112,22 @@ func (q *queue) queueUp(tickets ...string) (*e, error) {
 	return e, nil
 }
 
+// leave removes the queuer from the queue, it returns false if the tickets
+// have been granted to the queuer.
+func (q *queue) leave(e *e) bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for i := range q.elements {
+		if q.elements[i] == e {
+			q.elements = append(q.elements[:i], q.elements[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
 func (q *queue) ReleaseTicket(ctx context.Context, tickets ...string) {
 	logger := logutils.FromContext(ctx)
 	logger.Debug("Work has been completed, try to release tickets")
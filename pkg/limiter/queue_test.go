@@ -123,6 +123,22 @@ var _ = Describe("Limiter", Label("queue_test"), func() {
 				}
 				wg.Wait()
 			})
+
+			It("gives up waiting in queue once the context is done", func() {
+				ctx := context.TODO()
+				err := queue.AcquireTicket(ctx, "pool")
+				Expect(err).NotTo(HaveOccurred())
+
+				timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+				defer timeoutCancel()
+				err = queue.AcquireTicket(timeoutCtx, "pool")
+				Expect(err).To(MatchError(context.DeadlineExceeded))
+				queue.ReleaseTicket(ctx, "pool")
+
+				err = queue.AcquireTicket(ctx, "pool")
+				Expect(err).NotTo(HaveOccurred())
+				queue.ReleaseTicket(ctx, "pool")
+			})
 		})
 
 		Context("Concurrency", func() {
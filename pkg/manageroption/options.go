@@ -0,0 +1,160 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package manageroption provides the functional options shared by the
+// constructors of the managers, so that the optional dependencies, e.g. the
+// cache and the metrics, are injected into all managers in the same way.
+package manageroption
+
+import (
+	"context"
+	"time"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/allocationpolicy"
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
+)
+
+// Options are the optional dependencies of a manager, the managers ignore
+// the ones they don't use.
+type Options struct {
+	// Cache is the reader of the reads asked to go through the cache. The
+	// reads go to API server if it is nil.
+	Cache client.Reader
+	// Metrics records the reads and the update conflicts of the managers.
+	Metrics MetricsRecorder
+	// RetryPolicy controls the retries of the updates failed with conflict.
+	RetryPolicy RetryPolicy
+	// Indexer registers the field indexes the managers list objects with.
+	// The indexes are not registered if it is nil, e.g. in the tests with
+	// the fake client.
+	Indexer client.FieldIndexer
+	// AllocationPolicy reviews the IP addresses before they are allocated.
+	// The allocations are not reviewed if it is nil.
+	AllocationPolicy allocationpolicy.AllocationPolicy
+	// Freeze tells whether Spiderpool is in the maintenance freeze, in which
+	// no IP addresses are allocated and the controllers are paused. Spiderpool
+	// is never frozen if it is nil.
+	Freeze configmanager.FreezeChecker
+	// Clock is the source of time of the timing logic, e.g. the retry
+	// backoffs and the garbage collection of IP addresses. The tests inject
+	// a fake clock to step the time.
+	Clock clock.Clock
+	// Scales gets the scale subresources of the third party controllers, so
+	// that their replicas and selectors are known. They are unknown if it is
+	// nil.
+	Scales ScaleGetter
+}
+
+type RetryPolicy struct {
+	MaxConflictRetries    int
+	ConflictRetryUnitTime time.Duration
+}
+
+// MetricsRecorder records the metrics of the operations of the managers.
+type MetricsRecorder interface {
+	// RecordRead records a read of the resource, from the cache or from API
+	// server.
+	RecordRead(ctx context.Context, resource string, cached bool)
+	// RecordConflict records an update of the resource failed with conflict.
+	RecordConflict(ctx context.Context, resource string)
+}
+
+// ScaleGetter gets the scale subresource of the object.
+type ScaleGetter interface {
+	GetScale(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*autoscalingv1.Scale, error)
+}
+
+type Option func(*Options)
+
+// New returns the options applied with opts in order.
+func New(opts ...Option) Options {
+	options := Options{
+		Metrics: noopMetricsRecorder{},
+		Clock:   clock.RealClock{},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithCache makes the managers read the objects through reader when they are
+// asked to read with cache, e.g. the client of the runtime manager.
+func WithCache(reader client.Reader) Option {
+	return func(o *Options) {
+		o.Cache = reader
+	}
+}
+
+// WithMetrics makes the managers record their metrics with recorder.
+func WithMetrics(recorder MetricsRecorder) Option {
+	return func(o *Options) {
+		if recorder != nil {
+			o.Metrics = recorder
+		}
+	}
+}
+
+// WithRetryPolicy makes the managers retry the updates failed with conflict
+// at most maxRetries times, with random backoff in units of unitTime.
+func WithRetryPolicy(maxRetries int, unitTime time.Duration) Option {
+	return func(o *Options) {
+		o.RetryPolicy = RetryPolicy{
+			MaxConflictRetries:    maxRetries,
+			ConflictRetryUnitTime: unitTime,
+		}
+	}
+}
+
+// WithIndexer makes the managers register their field indexes with indexer,
+// it must be the indexer of the cache which is not started yet.
+func WithIndexer(indexer client.FieldIndexer) Option {
+	return func(o *Options) {
+		o.Indexer = indexer
+	}
+}
+
+// WithAllocationPolicy makes the managers review the IP addresses with policy
+// before they are allocated.
+func WithAllocationPolicy(policy allocationpolicy.AllocationPolicy) Option {
+	return func(o *Options) {
+		o.AllocationPolicy = policy
+	}
+}
+
+// WithFreeze makes the managers refuse to allocate IP addresses, and the
+// controllers pause, while checker reports the maintenance freeze.
+func WithFreeze(checker configmanager.FreezeChecker) Option {
+	return func(o *Options) {
+		o.Freeze = checker
+	}
+}
+
+// WithClock makes the managers tell the time with c.
+func WithClock(c clock.Clock) Option {
+	return func(o *Options) {
+		if c != nil {
+			o.Clock = c
+		}
+	}
+}
+
+// WithScales makes the managers get the scale subresources of the third
+// party controllers with getter.
+func WithScales(getter ScaleGetter) Option {
+	return func(o *Options) {
+		o.Scales = getter
+	}
+}
+
+type noopMetricsRecorder struct{}
+
+func (noopMetricsRecorder) RecordRead(context.Context, string, bool) {}
+
+func (noopMetricsRecorder) RecordConflict(context.Context, string) {}
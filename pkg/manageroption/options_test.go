@@ -0,0 +1,75 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package manageroption_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+)
+
+type fakeRecorder struct {
+	reads int
+}
+
+func (r *fakeRecorder) RecordRead(context.Context, string, bool) { r.reads++ }
+
+func (r *fakeRecorder) RecordConflict(context.Context, string) {}
+
+var _ = Describe("ManagerOption", Label("manageroption_test"), func() {
+	It("returns the defaults without options", func() {
+		options := manageroption.New()
+		Expect(options.Cache).To(BeNil())
+		Expect(options.Indexer).To(BeNil())
+		Expect(options.AllocationPolicy).To(BeNil())
+		Expect(options.Freeze).To(BeNil())
+		Expect(options.Scales).To(BeNil())
+		Expect(options.RetryPolicy).To(Equal(manageroption.RetryPolicy{}))
+		Expect(options.Clock).To(Equal(clock.RealClock{}))
+
+		Expect(options.Metrics).NotTo(BeNil())
+		options.Metrics.RecordRead(context.TODO(), "Pod", true)
+		options.Metrics.RecordConflict(context.TODO(), "Pod")
+	})
+
+	It("applies the options", func() {
+		cache := fake.NewClientBuilder().Build()
+		recorder := &fakeRecorder{}
+		options := manageroption.New(
+			manageroption.WithCache(cache),
+			manageroption.WithMetrics(recorder),
+			manageroption.WithRetryPolicy(3, time.Second),
+		)
+		Expect(options.Cache).To(Equal(cache))
+		Expect(options.RetryPolicy).To(Equal(manageroption.RetryPolicy{
+			MaxConflictRetries:    3,
+			ConflictRetryUnitTime: time.Second,
+		}))
+
+		options.Metrics.RecordRead(context.TODO(), "Pod", true)
+		Expect(recorder.reads).To(Equal(1))
+	})
+
+	It("keeps the default metrics recorder with nil recorder", func() {
+		options := manageroption.New(manageroption.WithMetrics(nil))
+		Expect(options.Metrics).NotTo(BeNil())
+	})
+
+	It("tells the time with the injected clock", func() {
+		fakeClock := clocktesting.NewFakeClock(time.Unix(0, 0))
+		options := manageroption.New(manageroption.WithClock(fakeClock))
+		fakeClock.Step(time.Minute)
+		Expect(options.Clock.Now()).To(Equal(time.Unix(60, 0)))
+
+		options = manageroption.New(manageroption.WithClock(nil))
+		Expect(options.Clock).To(Equal(clock.RealClock{}))
+	})
+})
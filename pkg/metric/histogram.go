@@ -0,0 +1,89 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"fmt"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+)
+
+// DefaultHistogramPattern matches the histograms using the default bucket
+// boundaries, unless any HistogramBuckets matches them first.
+const DefaultHistogramPattern = "*_histogram"
+
+// DefaultHistogramBoundaries are the bucket boundaries in seconds of the
+// histograms matching DefaultHistogramPattern.
+var DefaultHistogramBoundaries = []float64{0.1, 0.3, 0.5, 1, 3, 5, 7, 10, 15}
+
+// HistogramBuckets overrides the bucket boundaries of the histograms whose
+// names match the pattern, in which "*" matches zero or more characters and
+// "?" matches exactly one character.
+type HistogramBuckets struct {
+	Pattern    string    `yaml:"pattern"`
+	Boundaries []float64 `yaml:"boundaries"`
+}
+
+// Option configures InitMetricController.
+type Option func(*options)
+
+type options struct {
+	histogramBuckets []HistogramBuckets
+}
+
+// WithHistogramBuckets sets the bucket boundaries of the histograms matching
+// the patterns, the first matching one takes effect.
+func WithHistogramBuckets(buckets ...HistogramBuckets) Option {
+	return func(o *options) {
+		o.histogramBuckets = append(o.histogramBuckets, buckets...)
+	}
+}
+
+// ValidateHistogramBuckets checks that each pattern is specified and that its
+// bucket boundaries are in strictly increasing order.
+func ValidateHistogramBuckets(buckets []HistogramBuckets) error {
+	for i, b := range buckets {
+		if b.Pattern == "" {
+			return fmt.Errorf("the pattern of histogram buckets %d is not specified", i)
+		}
+		if len(b.Boundaries) == 0 {
+			return fmt.Errorf("the boundaries of histogram buckets '%s' are not specified", b.Pattern)
+		}
+		for j := 1; j < len(b.Boundaries); j++ {
+			if b.Boundaries[j] <= b.Boundaries[j-1] {
+				return fmt.Errorf("the boundaries %v of histogram buckets '%s' are not in strictly increasing order", b.Boundaries, b.Pattern)
+			}
+		}
+	}
+
+	return nil
+}
+
+// histogramView applies the bucket boundaries of the first HistogramBuckets
+// matching the histogram, and then the default ones. Only one View could
+// match an instrument, otherwise it is exported as duplicate streams.
+func histogramView(buckets []HistogramBuckets) sdkmetric.View {
+	views := make([]sdkmetric.View, 0, len(buckets)+1)
+	for _, b := range buckets {
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{Name: b.Pattern, Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{Boundaries: b.Boundaries}},
+		))
+	}
+	views = append(views, sdkmetric.NewView(
+		sdkmetric.Instrument{Name: DefaultHistogramPattern},
+		sdkmetric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{Boundaries: DefaultHistogramBoundaries}},
+	))
+
+	return func(i sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+		for _, view := range views {
+			if stream, ok := view(i); ok {
+				return stream, true
+			}
+		}
+
+		return sdkmetric.Stream{}, false
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+)
+
+var _ = Describe("Histogram", Label("histogram_test"), func() {
+	DescribeTable("ValidateHistogramBuckets",
+		func(buckets []HistogramBuckets, message string) {
+			err := ValidateHistogramBuckets(buckets)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(message))
+		},
+		Entry("no buckets", nil, ""),
+		Entry("valid buckets",
+			[]HistogramBuckets{
+				{Pattern: "ipam_*_histogram", Boundaries: []float64{0.005, 0.01, 0.05}},
+				{Pattern: "*_seconds", Boundaries: []float64{1}},
+			},
+			"",
+		),
+		Entry("pattern not specified",
+			[]HistogramBuckets{
+				{Pattern: "*_seconds", Boundaries: []float64{1}},
+				{Boundaries: []float64{1}},
+			},
+			"the pattern of histogram buckets 1 is not specified",
+		),
+		Entry("boundaries not specified",
+			[]HistogramBuckets{{Pattern: "*_seconds"}},
+			"the boundaries of histogram buckets '*_seconds' are not specified",
+		),
+		Entry("boundaries in decreasing order",
+			[]HistogramBuckets{{Pattern: "*_seconds", Boundaries: []float64{1, 0.5}}},
+			"the boundaries [1 0.5] of histogram buckets '*_seconds' are not in strictly increasing order",
+		),
+		Entry("duplicate boundaries",
+			[]HistogramBuckets{{Pattern: "*_seconds", Boundaries: []float64{0.5, 1, 1}}},
+			"the boundaries [0.5 1 1] of histogram buckets '*_seconds' are not in strictly increasing order",
+		),
+	)
+
+	Describe("histogramView", func() {
+		view := histogramView([]HistogramBuckets{
+			{Pattern: "ipam_allocation_*", Boundaries: []float64{0.005, 0.01}},
+			{Pattern: "ipam_*_histogram", Boundaries: []float64{1, 2}},
+			{Pattern: "gc_??_seconds", Boundaries: []float64{3}},
+		})
+
+		DescribeTable("selects the bucket boundaries of the histograms",
+			func(instrument sdkmetric.Instrument, matched bool, boundaries []float64) {
+				stream, ok := view(instrument)
+				Expect(ok).To(Equal(matched))
+				if !matched {
+					return
+				}
+				Expect(stream.Aggregation).To(Equal(aggregation.ExplicitBucketHistogram{Boundaries: boundaries}))
+			},
+			Entry("the first matching pattern",
+				sdkmetric.Instrument{Name: "ipam_allocation_duration_seconds_histogram", Kind: sdkmetric.InstrumentKindHistogram},
+				true, []float64{0.005, 0.01},
+			),
+			Entry("the second matching pattern",
+				sdkmetric.Instrument{Name: "ipam_release_duration_seconds_histogram", Kind: sdkmetric.InstrumentKindHistogram},
+				true, []float64{1, 2},
+			),
+			Entry("the pattern matching exactly one character",
+				sdkmetric.Instrument{Name: "gc_ip_seconds", Kind: sdkmetric.InstrumentKindHistogram},
+				true, []float64{3},
+			),
+			Entry("the default pattern",
+				sdkmetric.Instrument{Name: "subnet_controller_key_duration_seconds_histogram", Kind: sdkmetric.InstrumentKindHistogram},
+				true, DefaultHistogramBoundaries,
+			),
+			Entry("the instruments other than histograms",
+				sdkmetric.Instrument{Name: "ipam_allocation_counts", Kind: sdkmetric.InstrumentKindCounter},
+				false, nil,
+			),
+			Entry("no matching pattern",
+				sdkmetric.Instrument{Name: "gc_ip_duration_seconds", Kind: sdkmetric.InstrumentKindHistogram},
+				false, nil,
+			),
+		)
+
+		It("keeps the default bucket boundaries without any HistogramBuckets", func() {
+			stream, ok := histogramView(nil)(sdkmetric.Instrument{Name: "ipam_allocation_duration_seconds_histogram", Kind: sdkmetric.InstrumentKindHistogram})
+			Expect(ok).To(BeTrue())
+			Expect(stream.Aggregation).To(Equal(aggregation.ExplicitBucketHistogram{Boundaries: DefaultHistogramBoundaries}))
+		})
+	})
+
+	Describe("InitMetricController", func() {
+		It("fails to init with invalid HistogramBuckets", func() {
+			_, err := InitMetricController(context.TODO(), "spiderpool-test", true,
+				WithHistogramBuckets(HistogramBuckets{Pattern: "*_seconds", Boundaries: []float64{1, 1}}))
+			Expect(err).To(MatchError(ContainSubstring("not in strictly increasing order")))
+		})
+
+		It("exports the histograms with the bucket boundaries of the matching patterns", func() {
+			handler, err := InitMetricController(context.TODO(), "spiderpool-test", true,
+				WithHistogramBuckets(HistogramBuckets{Pattern: "test_fast_*", Boundaries: []float64{0.005, 0.01}}),
+				WithHistogramBuckets(HistogramBuckets{Pattern: "test_*", Boundaries: []float64{30, 60}}))
+			Expect(err).NotTo(HaveOccurred())
+
+			fast, err := NewMetricFloat64Histogram("test_fast_duration_seconds", "")
+			Expect(err).NotTo(HaveOccurred())
+			fast.Record(context.TODO(), 0.007)
+			slow, err := NewMetricFloat64Histogram("test_slow_duration_seconds", "")
+			Expect(err).NotTo(HaveOccurred())
+			slow.Record(context.TODO(), 45)
+			defaults, err := NewMetricFloat64Histogram("other_duration_seconds_histogram", "")
+			Expect(err).NotTo(HaveOccurred())
+			defaults.Record(context.TODO(), 2)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			output := rr.Body.String()
+
+			Expect(output).To(MatchRegexp(`test_fast_duration_seconds_bucket\{[^}]*le="0.005"[^}]*\} 0`))
+			Expect(output).To(MatchRegexp(`test_fast_duration_seconds_bucket\{[^}]*le="0.01"[^}]*\} 1`))
+			Expect(output).NotTo(MatchRegexp(`test_fast_duration_seconds_bucket\{[^}]*le="30"`))
+
+			Expect(output).To(MatchRegexp(`test_slow_duration_seconds_bucket\{[^}]*le="30"[^}]*\} 0`))
+			Expect(output).To(MatchRegexp(`test_slow_duration_seconds_bucket\{[^}]*le="60"[^}]*\} 1`))
+
+			Expect(output).To(MatchRegexp(`other_duration_seconds_histogram_bucket\{[^}]*le="1"[^}]*\} 0`))
+			Expect(output).To(MatchRegexp(`other_duration_seconds_histogram_bucket\{[^}]*le="3"[^}]*\} 1`))
+		})
+	})
+})
@@ -0,0 +1,16 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMetric(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Metric Suite", Label("metric", "unitest"))
+}
@@ -15,7 +15,6 @@ import (
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/metric/instrument"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	"go.opentelemetry.io/otel/sdk/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
@@ -30,12 +29,21 @@ var (
 )
 
 // InitMetricController will set up meter with the input param(required) and create a prometheus exporter.
+// The options are optional, e.g. WithHistogramBuckets overrides the bucket boundaries of histograms.
 // returns http handler and error
-func InitMetricController(ctx context.Context, meterName string, enableMetric bool) (http.Handler, error) {
+func InitMetricController(ctx context.Context, meterName string, enableMetric bool, opts ...Option) (http.Handler, error) {
 	if len(meterName) == 0 {
 		return nil, fmt.Errorf("failed to init metric controller, meter name is asked to be set")
 	}
 
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := ValidateHistogramBuckets(o.histogramBuckets); err != nil {
+		return nil, err
+	}
+
 	otelResource, err := resource.New(ctx,
 		resource.WithAttributes(
 			semconv.ServiceNameKey.String(constant.SpiderpoolAPIGroup),
@@ -51,12 +59,7 @@ func InitMetricController(ctx context.Context, meterName string, enableMetric bo
 	provider := sdkmetric.NewMeterProvider(
 		sdkmetric.WithReader(exporter),
 		sdkmetric.WithResource(otelResource),
-		sdkmetric.WithView(sdkmetric.NewView(
-			sdkmetric.Instrument{Name: "*_histogram"},
-			sdkmetric.Stream{Aggregation: aggregation.ExplicitBucketHistogram{
-				Boundaries: []float64{0.1, 0.3, 0.5, 1, 3, 5, 7, 10, 15},
-			}},
-		)),
+		sdkmetric.WithView(histogramView(o.histogramBuckets)),
 	)
 	global.SetMeterProvider(provider)
 
@@ -82,7 +85,7 @@ func NewMetricInt64Counter(metricName string, description string) (instrument.In
 // NewMetricFloat64Histogram will create otel Float64Histogram metric.
 // The first param metricName is required and the second param is optional.
 // Notice: if you want to match the quantile {0.1, 0.3, 0.5, 1, 3, 5, 7, 10, 15}, please let the metric name match regex "*_histogram",
-// otherwise it will match the  otel default quantile.
+// otherwise it will match the  otel default quantile. The HistogramBuckets passed to InitMetricController take precedence.
 func NewMetricFloat64Histogram(metricName string, description string) (instrument.Float64Histogram, error) {
 	if len(metricName) == 0 {
 		return nil, fmt.Errorf("failed to create metric Float64Histogram, metric name is asked to be set")
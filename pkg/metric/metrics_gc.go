@@ -0,0 +1,47 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RecordIPGCReclaim records the IPs reclaimed by the IP GC for the reason,
+// e.g. the status of the traced Pod.
+func RecordIPGCReclaim(ctx context.Context, reason string, count int) {
+	if ipGCReclaimCounts == nil {
+		return
+	}
+
+	ipGCReclaimCounts.Add(ctx, int64(count), attribute.String("reason", reason))
+}
+
+// RecordIPGCSkip records an IP the IP GC skipped to reclaim for safety, it
+// is retried by the following scan.
+func RecordIPGCSkip(ctx context.Context, reason string) {
+	if ipGCSkippedCounts == nil {
+		return
+	}
+
+	ipGCSkippedCounts.Add(ctx, 1, attribute.String("reason", reason))
+}
+
+// RecordIPGCScanDuration records the duration of the IP GC scanning all
+// IPPools.
+func RecordIPGCScanDuration(ctx context.Context, duration float64) {
+	if ipGCScanDurationSecondsHistogram == nil {
+		return
+	}
+
+	ipGCScanDurationSecondsHistogram.Record(ctx, duration)
+}
+
+// RecordIPGCCandidates records the Pods traced by the IP GC, and the ones
+// timed out but waiting for the workers to reclaim their IPs.
+func RecordIPGCCandidates(candidates, queueDepth int) {
+	ipGCCandidateCounts.Record(int64(candidates))
+	ipGCQueueDepth.Record(int64(queueDepth))
+}
@@ -23,6 +23,10 @@ const (
 	ipam_allocation_err_no_available_pool_counts = "ipam_allocation_err_no_available_pool_counts"
 	ipam_allocation_err_retries_exhausted_counts = "ipam_allocation_err_retries_exhausted_counts"
 	ipam_allocation_err_ip_used_out_counts       = "ipam_allocation_err_ip_used_out_counts"
+	ipam_allocation_err_frozen_counts            = "ipam_allocation_err_frozen_counts"
+	ipam_allocation_err_unsupported_node_counts  = "ipam_allocation_err_unsupported_node_counts"
+	ipam_allocation_soft_reserved_ip_counts      = "ipam_allocation_soft_reserved_ip_counts"
+	ipam_deadline_exceeded_counts                = "ipam_deadline_exceeded_counts"
 
 	ipam_allocation_average_duration_seconds   = "ipam_allocation_average_duration_seconds"
 	ipam_allocation_max_duration_seconds       = "ipam_allocation_max_duration_seconds"
@@ -42,14 +46,34 @@ const (
 	ipam_release_latest_duration_seconds    = "ipam_release_latest_duration_seconds"
 	ipam_release_duration_seconds_histogram = "ipam_release_duration_seconds_histogram"
 
+	// spiderpool agent IP conflict monitor metrics name
+	ip_conflict_counts = "ip_conflict_counts"
+
 	// spiderpool controller IP GC metrics name
-	ip_gc_total_counts   = "ip_gc_total_counts"
-	ip_gc_failure_counts = "ip_gc_failure_counts"
+	ip_gc_total_counts                    = "ip_gc_total_counts"
+	ip_gc_failure_counts                  = "ip_gc_failure_counts"
+	ip_gc_reclaim_counts                  = "ip_gc_reclaim_counts"
+	ip_gc_skipped_counts                  = "ip_gc_skipped_counts"
+	ip_gc_candidate_counts                = "ip_gc_candidate_counts"
+	ip_gc_queue_depth                     = "ip_gc_queue_depth"
+	ip_gc_scan_duration_seconds_histogram = "ip_gc_scan_duration_seconds_histogram"
 
 	subnet_ippool_counts = "subnet_ippool_counts"
+	ippool_headroom      = "ippool_headroom"
+	ippool_info          = "ippool_info"
+	subnet_info          = "subnet_info"
+
+	ippool_top_consumer_ip_counts = "ippool_top_consumer_ip_counts"
+	ippool_exhaustion_eta_seconds = "ippool_exhaustion_eta_seconds"
+
+	informer_event_lag_seconds = "informer_event_lag_seconds"
+	informer_queue_depth       = "informer_queue_depth"
+
+	webhook_denial_counts = "webhook_denial_counts"
 
 	// spiderpool controller SpiderSubnet feature
 	auto_ippool_create_or_mark_conflict_counts    = "auto_ippool_create_or_mark_conflict_counts"
+	auto_pool_reconcile_suppressed_counts         = "auto_pool_reconcile_suppressed_counts"
 	ippool_informer_conflict_counts               = "ippool_informer_conflict_counts"
 	auto_pool_creation_average_duration           = "auto_pool_creation_average_duration"
 	auto_pool_creation_max_duration_seconds       = "auto_pool_creation_max_duration_seconds"
@@ -62,6 +86,17 @@ const (
 	auto_pool_scale_latest_duration_seconds       = "auto_pool_scale_latest_duration_seconds"
 	auto_pool_scale_duration_seconds_histogram    = "auto_pool_scale_duration_seconds_histogram"
 	auto_pool_scale_conflict_counts               = "auto_pool_scale_conflict_counts"
+
+	subnet_controller_queue_depth                    = "subnet_controller_queue_depth"
+	subnet_controller_key_duration_seconds_histogram = "subnet_controller_key_duration_seconds_histogram"
+
+	// spiderpool agent and controller managers metrics name
+	manager_read_counts            = "manager_read_counts"
+	manager_update_conflict_counts = "manager_update_conflict_counts"
+
+	// spiderpool agent and controller handlers metrics name
+	panic_recovered_counts = "panic_recovered_counts"
+	freeze_active          = "freeze_active"
 )
 
 var (
@@ -73,6 +108,10 @@ var (
 	IpamAllocationErrNoAvailablePoolCounts  instrument.Int64Counter
 	IpamAllocationErrRetriesExhaustedCounts instrument.Int64Counter
 	IpamAllocationErrIPUsedOutCounts        instrument.Int64Counter
+	IpamAllocationErrFrozenCounts           instrument.Int64Counter
+	IpamAllocationErrUnsupportedNodeCounts  instrument.Int64Counter
+	ipamAllocationSoftReservedIPCounts      instrument.Int64Counter
+	ipamDeadlineExceededCounts              instrument.Int64Counter
 	ipamAllocationAverageDurationSeconds    = new(asyncFloat64Gauge)
 	ipamAllocationMaxDurationSeconds        = new(asyncFloat64Gauge)
 	ipamAllocationMinDurationSeconds        = new(asyncFloat64Gauge)
@@ -90,14 +129,34 @@ var (
 	ipamReleaseLatestDurationSeconds     = new(asyncFloat64Gauge)
 	ipamReleaseDurationSecondsHistogram  instrument.Float64Histogram
 
+	// spiderpool agent IP conflict monitor metrics
+	IPConflictCounts instrument.Int64Counter
+
 	// spiderpool controller IP GC metrics
-	IPGCTotalCounts   instrument.Int64Counter
-	IPGCFailureCounts instrument.Int64Counter
+	IPGCTotalCounts                  instrument.Int64Counter
+	IPGCFailureCounts                instrument.Int64Counter
+	ipGCReclaimCounts                instrument.Int64Counter
+	ipGCSkippedCounts                instrument.Int64Counter
+	ipGCCandidateCounts              = new(asyncInt64Gauge)
+	ipGCQueueDepth                   = new(asyncInt64Gauge)
+	ipGCScanDurationSecondsHistogram instrument.Float64Histogram
 
 	SubnetPoolCounts = new(asyncInt64Gauge)
+	IPPoolHeadroom   = new(asyncInt64GaugeVec)
+	IPPoolInfo       = new(asyncInt64GaugeVec)
+	SubnetInfo       = new(asyncInt64GaugeVec)
+
+	IPPoolTopConsumerIPCounts  = new(asyncInt64GaugeVec)
+	IPPoolExhaustionETASeconds = new(asyncInt64GaugeVec)
+
+	InformerEventLagSeconds = new(asyncInt64GaugeVec)
+	InformerQueueDepth      = new(asyncInt64GaugeVec)
+
+	webhookDenialCounts instrument.Int64Counter
 
 	// SpiderSubnet feature
 	AutoPoolCreateOrMarkConflictCounts       instrument.Int64Counter
+	AutoPoolReconcileSuppressedCounts        instrument.Int64Counter
 	IPPoolInformerConflictCounts             instrument.Int64Counter
 	autoPoolCreationAverageDurationSeconds   = new(asyncFloat64Gauge)
 	autoPoolCreationMaxDurationSeconds       = new(asyncFloat64Gauge)
@@ -110,6 +169,17 @@ var (
 	autoPoolScaleLatestDurationSeconds       = new(asyncFloat64Gauge)
 	autoPoolScaleDurationSecondsHistogram    instrument.Float64Histogram
 	AutoPoolScaleConflictCounts              instrument.Int64Counter
+
+	SubnetControllerQueueDepth                  = new(asyncInt64GaugeVec)
+	SubnetControllerKeyDurationSecondsHistogram instrument.Float64Histogram
+
+	// managers
+	managerReadCounts           instrument.Int64Counter
+	managerUpdateConflictCounts instrument.Int64Counter
+
+	// handlers
+	panicRecoveredCounts instrument.Int64Counter
+	freezeActive         = new(asyncInt64Gauge)
 )
 
 // asyncFloat64Gauge is custom otel float64 gauge
@@ -194,6 +264,59 @@ func (a *asyncInt64Gauge) Record(value int64, attrs ...attribute.KeyValue) {
 	a.observerLock.Unlock()
 }
 
+// asyncInt64GaugeVec is custom otel int64 gauge, which reports one value
+// for each series rather than only the latest one.
+type asyncInt64GaugeVec struct {
+	gaugeMetric  instrument.Int64ObservableGauge
+	observations map[string]int64Observation
+	observerLock lock.RWMutex
+}
+
+type int64Observation struct {
+	value int64
+	attrs []attribute.KeyValue
+}
+
+// initGauge will new an otel int64 gauge metric and register a call back function
+func (a *asyncInt64GaugeVec) initGauge(metricName string, description string) error {
+	tmpGauge, err := NewMetricInt64Gauge(metricName, description)
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool metric '%s', error: %v", metricName, err)
+	}
+
+	a.gaugeMetric = tmpGauge
+	_, err = meter.RegisterCallback(func(_ context.Context, observer api.Observer) error {
+		a.observerLock.RLock()
+		defer a.observerLock.RUnlock()
+		for _, o := range a.observations {
+			observer.ObserveInt64(a.gaugeMetric, o.value, o.attrs...)
+		}
+		return nil
+	}, a.gaugeMetric)
+	if nil != err {
+		return fmt.Errorf("failed to register callback for spiderpool metric '%s', error: %v", metricName, err)
+	}
+
+	return nil
+}
+
+// Record sets the value of the series identified by key
+func (a *asyncInt64GaugeVec) Record(key string, value int64, attrs ...attribute.KeyValue) {
+	a.observerLock.Lock()
+	if a.observations == nil {
+		a.observations = map[string]int64Observation{}
+	}
+	a.observations[key] = int64Observation{value: value, attrs: attrs}
+	a.observerLock.Unlock()
+}
+
+// Delete stops reporting the series identified by key
+func (a *asyncInt64GaugeVec) Delete(key string) {
+	a.observerLock.Lock()
+	delete(a.observations, key)
+	a.observerLock.Unlock()
+}
+
 // InitSpiderpoolAgentMetrics serves for spiderpool agent metrics initialization
 func InitSpiderpoolAgentMetrics(ctx context.Context) error {
 	err := initSpiderpoolAgentAllocationMetrics(ctx)
@@ -211,7 +334,15 @@ func InitSpiderpoolAgentMetrics(ctx context.Context) error {
 		return err
 	}
 
-	return nil
+	ipConflictCounts, err := NewMetricInt64Counter(ip_conflict_counts, "spiderpool agent detected IP conflict counts")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %v", ip_conflict_counts, err)
+	}
+	IPConflictCounts = ipConflictCounts
+
+	IPConflictCounts.Add(ctx, 0)
+
+	return initManagerMetrics(ctx)
 }
 
 // InitSpiderpoolControllerMetrics serves for spiderpool-controller metrics initialization
@@ -236,15 +367,67 @@ func InitSpiderpoolControllerMetrics(ctx context.Context) error {
 		return err
 	}
 
+	err = IPPoolHeadroom.initGauge(ippool_headroom, "number of IP addresses that can still be allocated from the ippool")
+	if nil != err {
+		return err
+	}
+
+	err = IPPoolInfo.initGauge(ippool_info, "inventory of the ippool carried in the labels, always 1")
+	if nil != err {
+		return err
+	}
+
+	err = SubnetInfo.initGauge(subnet_info, "inventory of the spider subnet carried in the labels, always 1")
+	if nil != err {
+		return err
+	}
+
+	err = IPPoolTopConsumerIPCounts.initGauge(ippool_top_consumer_ip_counts, "number of IP addresses allocated to the applications consuming the most IP addresses of the ippool")
+	if nil != err {
+		return err
+	}
+
+	err = IPPoolExhaustionETASeconds.initGauge(ippool_exhaustion_eta_seconds, "forecast seconds until the ippool is exhausted with its allocation rate")
+	if nil != err {
+		return err
+	}
+
+	err = InformerEventLagSeconds.initGauge(informer_event_lag_seconds, "seconds between the latest write of an object and the informer event delivering it")
+	if nil != err {
+		return err
+	}
+
+	err = InformerQueueDepth.initGauge(informer_queue_depth, "number of events waiting in the work queue behind the informer")
+	if nil != err {
+		return err
+	}
+
+	err = SubnetControllerQueueDepth.initGauge(subnet_controller_queue_depth, "number of keys waiting in the work queue of the SpiderSubnet or application controller")
+	if nil != err {
+		return err
+	}
+
+	subnetControllerKeyHistogram, err := NewMetricFloat64Histogram(subnet_controller_key_duration_seconds_histogram, "histogram of the duration of processing a key of the SpiderSubnet or application controller")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", subnet_controller_key_duration_seconds_histogram, err)
+	}
+	SubnetControllerKeyDurationSecondsHistogram = subnetControllerKeyHistogram
+
 	poolInformerConflictCounts, err := NewMetricInt64Counter(ippool_informer_conflict_counts, "ippool informer operation conflict counts")
 	if nil != err {
 		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", ippool_informer_conflict_counts, err)
 	}
 	IPPoolInformerConflictCounts = poolInformerConflictCounts
 
+	denialCounts, err := NewMetricInt64Counter(webhook_denial_counts, "number of the requests denied by the webhooks, labeled by the kind, the operation and the reason")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", webhook_denial_counts, err)
+	}
+	webhookDenialCounts = denialCounts
+
 	IPPoolInformerConflictCounts.Add(ctx, 0)
 
-	return nil
+	return initManagerMetrics(ctx)
 }
 
 // initSpiderpoolAgentAllocationMetrics will init spiderpool-agent IPAM allocation metrics
@@ -298,6 +481,30 @@ func initSpiderpoolAgentAllocationMetrics(ctx context.Context) error {
 	}
 	IpamAllocationErrIPUsedOutCounts = allocationErrIPUsedOutCounts
 
+	allocationErrFrozenCounts, err := NewMetricInt64Counter(ipam_allocation_err_frozen_counts, "spiderpool agent ipam allocation refused by the maintenance freeze error counts")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %v", ipam_allocation_err_frozen_counts, err)
+	}
+	IpamAllocationErrFrozenCounts = allocationErrFrozenCounts
+
+	allocationErrUnsupportedNodeCounts, err := NewMetricInt64Counter(ipam_allocation_err_unsupported_node_counts, "spiderpool agent ipam allocation refused on the unsupported nodes error counts")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %v", ipam_allocation_err_unsupported_node_counts, err)
+	}
+	IpamAllocationErrUnsupportedNodeCounts = allocationErrUnsupportedNodeCounts
+
+	allocationSoftReservedIPCounts, err := NewMetricInt64Counter(ipam_allocation_soft_reserved_ip_counts, "spiderpool agent ipam allocation of the IP addresses reserved in mode soft counts")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %v", ipam_allocation_soft_reserved_ip_counts, err)
+	}
+	ipamAllocationSoftReservedIPCounts = allocationSoftReservedIPCounts
+
+	deadlineExceededCounts, err := NewMetricInt64Counter(ipam_deadline_exceeded_counts, "spiderpool agent ipam stages aborted by the timeouts or the CNI requests given up counts")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool agent metric '%s', error: %v", ipam_deadline_exceeded_counts, err)
+	}
+	ipamDeadlineExceededCounts = deadlineExceededCounts
+
 	// spiderpool agent ipam average allocation duration, metric type "float64 gauge"
 	err = ipamAllocationAverageDurationSeconds.initGauge(ipam_allocation_average_duration_seconds, "spiderpool agent ipam average allocation duration")
 	if nil != err {
@@ -424,6 +631,34 @@ func initSpiderpoolControllerGCMetrics(ctx context.Context) error {
 	}
 	IPGCFailureCounts = ipGCFailureCounts
 
+	reclaimCounts, err := NewMetricInt64Counter(ip_gc_reclaim_counts, "number of the IPs reclaimed by the ip gc, labeled by the reason")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", ip_gc_reclaim_counts, err)
+	}
+	ipGCReclaimCounts = reclaimCounts
+
+	skippedCounts, err := NewMetricInt64Counter(ip_gc_skipped_counts, "number of the IPs the ip gc skipped to reclaim for safety, labeled by the reason")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", ip_gc_skipped_counts, err)
+	}
+	ipGCSkippedCounts = skippedCounts
+
+	err = ipGCCandidateCounts.initGauge(ip_gc_candidate_counts, "number of the pods traced by the ip gc until their deadlines")
+	if nil != err {
+		return err
+	}
+
+	err = ipGCQueueDepth.initGauge(ip_gc_queue_depth, "number of the timed out pods waiting for the ip gc workers")
+	if nil != err {
+		return err
+	}
+
+	scanHistogram, err := NewMetricFloat64Histogram(ip_gc_scan_duration_seconds_histogram, "histogram of the duration of the ip gc scanning all IPPools")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", ip_gc_scan_duration_seconds_histogram, err)
+	}
+	ipGCScanDurationSecondsHistogram = scanHistogram
+
 	IPGCTotalCounts.Add(ctx, 0)
 	IPGCFailureCounts.Add(ctx, 0)
 
@@ -439,6 +674,12 @@ func initAutoPoolCreationMetrics(ctx context.Context) error {
 	}
 	AutoPoolCreateOrMarkConflictCounts = autoPoolCreateOrMarkConflictCounts
 
+	autoPoolReconcileSuppressedCounts, err := NewMetricInt64Counter(auto_pool_reconcile_suppressed_counts, "throttled application reconciliations of auto-created IPPool counts")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool controller metric '%s', error: %v", auto_pool_reconcile_suppressed_counts, err)
+	}
+	AutoPoolReconcileSuppressedCounts = autoPoolReconcileSuppressedCounts
+
 	err = autoPoolCreationAverageDurationSeconds.initGauge(auto_pool_creation_average_duration, "auto-created IPPool creation average duration")
 	if nil != err {
 		return err
@@ -466,6 +707,7 @@ func initAutoPoolCreationMetrics(ctx context.Context) error {
 	autoPoolCreationDurationSecondsHistogram = autoPoolCreationHistogram
 
 	AutoPoolCreateOrMarkConflictCounts.Add(ctx, 0)
+	AutoPoolReconcileSuppressedCounts.Add(ctx, 0)
 	autoPoolCreationDurationSecondsHistogram.Record(ctx, 0)
 
 	return nil
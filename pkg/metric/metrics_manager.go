@@ -0,0 +1,102 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ManagerMetrics records the reads and the update conflicts of the managers,
+// it is injected into the managers with manageroption.WithMetrics.
+type ManagerMetrics struct{}
+
+func (ManagerMetrics) RecordRead(ctx context.Context, resource string, cached bool) {
+	if managerReadCounts == nil {
+		return
+	}
+
+	managerReadCounts.Add(ctx, 1, attribute.String("resource", resource), attribute.Bool("cached", cached))
+}
+
+func (ManagerMetrics) RecordConflict(ctx context.Context, resource string) {
+	if managerUpdateConflictCounts == nil {
+		return
+	}
+
+	managerUpdateConflictCounts.Add(ctx, 1, attribute.String("resource", resource))
+}
+
+// RecordPanic records a panic recovered from the handler, e.g. a webhook or
+// the reconciliation of a controller.
+func RecordPanic(ctx context.Context, handler string) {
+	if panicRecoveredCounts == nil {
+		return
+	}
+
+	panicRecoveredCounts.Add(ctx, 1, attribute.String("handler", handler))
+}
+
+// RecordSoftReservedIPAllocation records an allocation of the IP address
+// reserved by the SpiderReservedIP in mode soft.
+func RecordSoftReservedIPAllocation(ctx context.Context, reservedIP, ipPool string) {
+	if ipamAllocationSoftReservedIPCounts == nil {
+		return
+	}
+
+	ipamAllocationSoftReservedIPCounts.Add(ctx, 1, attribute.String("reservedip", reservedIP), attribute.String("ippool", ipPool))
+}
+
+// RecordIPAMDeadlineExceeded records a stage of the IPAM pipeline aborted by
+// its timeout or by the CNI request given up.
+func RecordIPAMDeadlineExceeded(ctx context.Context, stage string) {
+	if ipamDeadlineExceededCounts == nil {
+		return
+	}
+
+	ipamDeadlineExceededCounts.Add(ctx, 1, attribute.String("stage", stage))
+}
+
+// RecordFreeze records whether Spiderpool is frozen.
+func RecordFreeze(frozen bool) {
+	var value int64
+	if frozen {
+		value = 1
+	}
+	freezeActive.Record(value)
+}
+
+// initManagerMetrics will init the metrics of the managers shared by
+// spiderpool-agent and spiderpool-controller
+func initManagerMetrics(ctx context.Context) error {
+	readCounts, err := NewMetricInt64Counter(manager_read_counts, "number of the reads of the managers, from the cache or API server")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool metric '%s', error: %v", manager_read_counts, err)
+	}
+	managerReadCounts = readCounts
+
+	updateConflictCounts, err := NewMetricInt64Counter(manager_update_conflict_counts, "number of the updates of the managers failed with conflict")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool metric '%s', error: %v", manager_update_conflict_counts, err)
+	}
+	managerUpdateConflictCounts = updateConflictCounts
+
+	managerUpdateConflictCounts.Add(ctx, 0)
+
+	recoveredCounts, err := NewMetricInt64Counter(panic_recovered_counts, "number of the panics recovered from the webhooks and the reconciliations")
+	if nil != err {
+		return fmt.Errorf("failed to new spiderpool metric '%s', error: %v", panic_recovered_counts, err)
+	}
+	panicRecoveredCounts = recoveredCounts
+
+	panicRecoveredCounts.Add(ctx, 0)
+
+	if err := freezeActive.initGauge(freeze_active, "whether Spiderpool is frozen for maintenance, 1 for frozen"); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package metric
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RecordWebhookDenial records a request of the kind denied by the webhook
+// for the reason.
+func RecordWebhookDenial(ctx context.Context, kind, operation, reason string) {
+	if webhookDenialCounts == nil {
+		return
+	}
+
+	webhookDenialCounts.Add(ctx, 1,
+		attribute.String("kind", kind),
+		attribute.String("operation", operation),
+		attribute.String("reason", reason),
+	)
+}
@@ -0,0 +1,36 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package networktestmanager
+
+import (
+	"time"
+)
+
+const (
+	defaultResyncPeriod    = 5 * time.Second
+	defaultProbeImage      = "docker.io/library/busybox:1.36"
+	defaultReplicas        = 2
+	defaultTimeoutSeconds  = 300
+	defaultInterface       = "eth0"
+	defaultMultusInterface = "net1"
+)
+
+type NetworkTestReconcilerConfig struct {
+	ResyncPeriod time.Duration
+	// ProbeImage is the image of probe Pods if SpiderNetworkTest does not
+	// specify one, it needs the commands sh, ip, arping and ping.
+	ProbeImage string
+}
+
+func setDefaultsForNetworkTestReconcilerConfig(config NetworkTestReconcilerConfig) NetworkTestReconcilerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	if config.ProbeImage == "" {
+		config.ProbeImage = defaultProbeImage
+	}
+
+	return config
+}
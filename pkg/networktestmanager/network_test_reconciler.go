@@ -0,0 +1,279 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package networktestmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// NetworkTestReconciler runs SpiderNetworkTests. It launches probe Pods for
+// each SpiderNetworkTest, collects their check results into its status, and
+// deletes the probe Pods to release their IP addresses once completed.
+type NetworkTestReconciler interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+}
+
+type networkTestReconciler struct {
+	config NetworkTestReconcilerConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+}
+
+func NewNetworkTestReconciler(config NetworkTestReconcilerConfig, client client.Client, leader election.SpiderLeaseElector) (NetworkTestReconciler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &networkTestReconciler{
+		config: setDefaultsForNetworkTestReconcilerConfig(config),
+		client: client,
+		leader: leader,
+	}, nil
+}
+
+// Start reconciles SpiderNetworkTests periodically until the context is done.
+// Only the leader of spiderpool-controller runs SpiderNetworkTests.
+func (r *networkTestReconciler) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if r.leader.IsElected() {
+				if err := r.Reconcile(ctx); err != nil {
+					logger.Sugar().Errorf("Failed to reconcile SpiderNetworkTests: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Reconcile drives all uncompleted SpiderNetworkTests forward.
+func (r *networkTestReconciler) Reconcile(ctx context.Context) error {
+	var testList spiderpoolv1.SpiderNetworkTestList
+	if err := r.client.List(ctx, &testList); err != nil {
+		return fmt.Errorf("failed to list SpiderNetworkTests: %w", err)
+	}
+
+	var errs error
+	for i := range testList.Items {
+		test := &testList.Items[i]
+		if test.DeletionTimestamp != nil {
+			continue
+		}
+
+		var err error
+		switch test.Status.Phase {
+		case spiderpoolv1.NetworkTestSucceeded, spiderpoolv1.NetworkTestFailed:
+			continue
+		case "":
+			err = r.startNetworkTest(ctx, test)
+		default:
+			err = r.syncNetworkTest(ctx, test)
+		}
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("SpiderNetworkTest %s/%s: %w", test.Namespace, test.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// startNetworkTest creates the probe Pods of the SpiderNetworkTest.
+func (r *networkTestReconciler) startNetworkTest(ctx context.Context, test *spiderpoolv1.SpiderNetworkTest) error {
+	logger := logutils.FromContext(ctx)
+
+	now := metav1.Now()
+	test.Status.StartTime = &now
+
+	gateways, err := r.getGateways(ctx, test)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.completeNetworkTest(ctx, test, spiderpoolv1.NetworkTestFailed, err.Error(), nil)
+		}
+		return err
+	}
+
+	for i := 0; i < probeReplicas(test); i++ {
+		pod, err := generateProbePod(test, i, gateways, r.config.ProbeImage)
+		if err != nil {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(test, pod, r.client.Scheme()); err != nil {
+			return err
+		}
+		if err := r.client.Create(ctx, pod); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create probe Pod %s: %w", pod.Name, err)
+		}
+	}
+	logger.Sugar().Infof("Succeed to create %d probe Pods for SpiderNetworkTest %s/%s", probeReplicas(test), test.Namespace, test.Name)
+
+	test.Status.Phase = spiderpoolv1.NetworkTestPending
+	test.Status.Message = "Waiting for probe Pods to start"
+
+	return r.client.Status().Update(ctx, test)
+}
+
+// getGateways gets the gateways of the IPPools used by the SpiderNetworkTest.
+func (r *networkTestReconciler) getGateways(ctx context.Context, test *spiderpoolv1.SpiderNetworkTest) ([]string, error) {
+	var gateways []string
+	pools := append(append([]string{}, test.Spec.IPv4IPPools...), test.Spec.IPv6IPPools...)
+	for _, poolName := range pools {
+		var pool spiderpoolv1.SpiderIPPool
+		if err := r.client.Get(ctx, apitypes.NamespacedName{Name: poolName}, &pool); err != nil {
+			return nil, fmt.Errorf("failed to get IPPool %s: %w", poolName, err)
+		}
+		if pool.Spec.Gateway != nil && *pool.Spec.Gateway != "" {
+			gateways = append(gateways, *pool.Spec.Gateway)
+		}
+	}
+
+	return gateways, nil
+}
+
+// syncNetworkTest collects the results of the probe Pods, and completes the
+// SpiderNetworkTest if all probe Pods terminated or it times out.
+func (r *networkTestReconciler) syncNetworkTest(ctx context.Context, test *spiderpoolv1.SpiderNetworkTest) error {
+	var podList corev1.PodList
+	if err := r.client.List(ctx, &podList,
+		client.InNamespace(test.Namespace),
+		client.MatchingLabels{constant.LabelNetworkTest: test.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list probe Pods: %w", err)
+	}
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[i].Name < podList.Items[j].Name
+	})
+
+	var results []spiderpoolv1.NetworkTestProbeResult
+	var failedPods, unfinishedPods []string
+	started := false
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !metav1.IsControlledBy(pod, test) {
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded, corev1.PodFailed:
+			started = true
+			result := parseProbeResult(pod)
+			results = append(results, result)
+			if !isProbePassed(pod, result) {
+				failedPods = append(failedPods, pod.Name)
+			}
+		case corev1.PodRunning:
+			started = true
+			unfinishedPods = append(unfinishedPods, pod.Name)
+		default:
+			unfinishedPods = append(unfinishedPods, fmt.Sprintf("%s(%s)", pod.Name, podWaitingReason(pod)))
+		}
+	}
+
+	replicas := probeReplicas(test)
+	if len(results) >= replicas {
+		if len(failedPods) != 0 {
+			msg := fmt.Sprintf("%d of %d probe Pods failed: %s", len(failedPods), replicas, strings.Join(failedPods, ", "))
+			return r.completeNetworkTest(ctx, test, spiderpoolv1.NetworkTestFailed, msg, results)
+		}
+		msg := fmt.Sprintf("All %d probe Pods passed", replicas)
+		return r.completeNetworkTest(ctx, test, spiderpoolv1.NetworkTestSucceeded, msg, results)
+	}
+
+	timeout := time.Duration(defaultTimeoutSeconds) * time.Second
+	if test.Spec.TimeoutSeconds != nil {
+		timeout = time.Duration(*test.Spec.TimeoutSeconds) * time.Second
+	}
+	if test.Status.StartTime != nil && time.Since(test.Status.StartTime.Time) > timeout {
+		msg := fmt.Sprintf("Timed out after %s waiting for probe Pods", timeout)
+		if len(unfinishedPods) != 0 {
+			msg = fmt.Sprintf("%s: %s", msg, strings.Join(unfinishedPods, ", "))
+		}
+		return r.completeNetworkTest(ctx, test, spiderpoolv1.NetworkTestFailed, msg, results)
+	}
+
+	if started && test.Status.Phase != spiderpoolv1.NetworkTestRunning {
+		test.Status.Phase = spiderpoolv1.NetworkTestRunning
+		test.Status.Message = "Waiting for probe Pods to complete"
+		return r.client.Status().Update(ctx, test)
+	}
+
+	return nil
+}
+
+// completeNetworkTest records the final results of the SpiderNetworkTest and
+// deletes its probe Pods.
+func (r *networkTestReconciler) completeNetworkTest(ctx context.Context, test *spiderpoolv1.SpiderNetworkTest,
+	phase spiderpoolv1.NetworkTestPhase, message string, results []spiderpoolv1.NetworkTestProbeResult) error {
+	logger := logutils.FromContext(ctx)
+
+	now := metav1.Now()
+	test.Status.Phase = phase
+	test.Status.Message = message
+	test.Status.CompletionTime = &now
+	test.Status.Results = results
+	if err := r.client.Status().Update(ctx, test); err != nil {
+		return err
+	}
+	logger.Sugar().Infof("SpiderNetworkTest %s/%s completed with phase %s: %s", test.Namespace, test.Name, phase, message)
+
+	for i := 0; i < probeReplicas(test); i++ {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      probePodName(test, i),
+				Namespace: test.Namespace,
+			},
+		}
+		if err := r.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete probe Pod %s: %w", pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func podWaitingReason(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return cs.State.Waiting.Reason
+		}
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue && cond.Reason != "" {
+			return cond.Reason
+		}
+	}
+
+	return string(corev1.PodPending)
+}
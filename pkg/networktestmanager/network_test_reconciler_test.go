@@ -0,0 +1,246 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package networktestmanager_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/networktestmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("NetworkTestReconciler", Label("network_test_reconciler_test"), func() {
+	Describe("New NetworkTestReconciler", func() {
+		It("inputs nil client", func() {
+			reconciler, err := networktestmanager.NewNetworkTestReconciler(networktestmanager.NetworkTestReconcilerConfig{}, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			reconciler, err := networktestmanager.NewNetworkTestReconciler(networktestmanager.NetworkTestReconcilerConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var count uint64
+		var ctx context.Context
+		var namespace, poolName string
+		var poolT *spiderpoolv1.SpiderIPPool
+		var testT *spiderpoolv1.SpiderNetworkTest
+		var reconciler networktestmanager.NetworkTestReconciler
+
+		getTest := func() *spiderpoolv1.SpiderNetworkTest {
+			var test spiderpoolv1.SpiderNetworkTest
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: testT.Name}, &test)
+			Expect(err).NotTo(HaveOccurred())
+			return &test
+		}
+
+		listProbePods := func() []corev1.Pod {
+			var podList corev1.PodList
+			err := fakeClient.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabels{constant.LabelNetworkTest: testT.Name})
+			Expect(err).NotTo(HaveOccurred())
+			return podList.Items
+		}
+
+		terminateProbePods := func(phase corev1.PodPhase, message string) {
+			for _, pod := range listProbePods() {
+				pod := pod
+				pod.Spec.NodeName = "node1"
+				pod.Status.Phase = phase
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name: "probe",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Message: message},
+					},
+				}}
+				err := fakeClient.Update(ctx, &pod)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			atomic.AddUint64(&count, 1)
+			namespace = fmt.Sprintf("ns-%v", count)
+			poolName = fmt.Sprintf("pool-%v", count)
+
+			poolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.10-172.18.40.20"},
+					Gateway:   pointer.String("172.18.40.1"),
+				},
+			}
+			err := fakeClient.Create(ctx, poolT)
+			Expect(err).NotTo(HaveOccurred())
+
+			testT = &spiderpoolv1.SpiderNetworkTest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: namespace,
+				},
+				Spec: spiderpoolv1.SpiderNetworkTestSpec{
+					IPv4IPPools: []string{poolName},
+					Replicas:    pointer.Int32(2),
+					Targets:     []string{"10.0.0.1"},
+				},
+			}
+			err = fakeClient.Create(ctx, testT)
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciler, err = networktestmanager.NewNetworkTestReconciler(networktestmanager.NetworkTestReconcilerConfig{}, fakeClient, fakeLeader{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			err := fakeClient.Delete(ctx, poolT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			err = fakeClient.Delete(ctx, testT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			for _, pod := range listProbePods() {
+				pod := pod
+				err := fakeClient.Delete(ctx, &pod)
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			}
+		})
+
+		It("launches probe Pods with the specified IPPools", func() {
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			test := getTest()
+			Expect(test.Status.Phase).To(Equal(spiderpoolv1.NetworkTestPending))
+			Expect(test.Status.StartTime).NotTo(BeNil())
+
+			pods := listProbePods()
+			Expect(pods).To(HaveLen(2))
+			for _, pod := range pods {
+				Expect(metav1.IsControlledBy(&pod, test)).To(BeTrue())
+
+				var anno types.AnnoPodIPPoolsValue
+				err := json.Unmarshal([]byte(pod.Annotations[constant.AnnoPodIPPools]), &anno)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(anno).To(Equal(types.AnnoPodIPPoolsValue{{NIC: "eth0", IPv4Pools: []string{poolName}}}))
+				Expect(pod.Spec.Containers[0].Env).To(ContainElements(
+					corev1.EnvVar{Name: "IFACE", Value: "eth0"},
+					corev1.EnvVar{Name: "GATEWAYS", Value: "172.18.40.1"},
+					corev1.EnvVar{Name: "TARGETS", Value: "10.0.0.1"},
+				))
+			}
+		})
+
+		It("attaches probe Pods to the NetworkAttachmentDefinition", func() {
+			testT.Spec.IPv4IPPools = nil
+			testT.Spec.NetworkAttachmentDefinition = pointer.String("kube-system/macvlan")
+			err := fakeClient.Update(ctx, testT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			pods := listProbePods()
+			Expect(pods).To(HaveLen(2))
+			Expect(pods[0].Annotations).To(Equal(map[string]string{constant.AnnoMultusNetworks: "kube-system/macvlan"}))
+			Expect(pods[0].Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "IFACE", Value: "net1"}))
+		})
+
+		It("fails if the IPPool does not exist", func() {
+			err := fakeClient.Delete(ctx, poolT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			test := getTest()
+			Expect(test.Status.Phase).To(Equal(spiderpoolv1.NetworkTestFailed))
+			Expect(test.Status.Message).To(ContainSubstring(poolName))
+			Expect(listProbePods()).To(BeEmpty())
+		})
+
+		It("succeeds if all checks of probe Pods passed", func() {
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			terminateProbePods(corev1.PodSucceeded, "duplicate-address|172.18.40.10|pass|\ngateway-arp|172.18.40.1|pass|\n")
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			test := getTest()
+			Expect(test.Status.Phase).To(Equal(spiderpoolv1.NetworkTestSucceeded))
+			Expect(test.Status.CompletionTime).NotTo(BeNil())
+			Expect(test.Status.Results).To(HaveLen(2))
+			Expect(test.Status.Results[0].Pod).To(Equal("test-probe-0"))
+			Expect(test.Status.Results[0].Node).To(Equal("node1"))
+			Expect(test.Status.Results[0].IPs).To(Equal([]string{"172.18.40.10"}))
+			Expect(test.Status.Results[0].Checks).To(Equal([]spiderpoolv1.NetworkTestCheck{
+				{Name: "duplicate-address", Target: "172.18.40.10", Passed: true},
+				{Name: "gateway-arp", Target: "172.18.40.1", Passed: true},
+			}))
+			Expect(listProbePods()).To(BeEmpty())
+		})
+
+		It("fails if any check of probe Pods failed", func() {
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			terminateProbePods(corev1.PodFailed, "duplicate-address|172.18.40.10|fail|another host replies ARP for the address\n")
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			test := getTest()
+			Expect(test.Status.Phase).To(Equal(spiderpoolv1.NetworkTestFailed))
+			Expect(test.Status.Message).To(Equal("2 of 2 probe Pods failed: test-probe-0, test-probe-1"))
+			Expect(test.Status.Results[1].Checks[0].Message).To(Equal("another host replies ARP for the address"))
+		})
+
+		It("fails if probe Pods do not complete in time", func() {
+			testT.Spec.TimeoutSeconds = pointer.Int64(1)
+			err := fakeClient.Update(ctx, testT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			test := getTest()
+			test.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			err = fakeClient.Status().Update(ctx, test)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			test = getTest()
+			Expect(test.Status.Phase).To(Equal(spiderpoolv1.NetworkTestFailed))
+			Expect(test.Status.Message).To(HavePrefix("Timed out after 1s waiting for probe Pods: test-probe-0(Pending)"))
+			Expect(listProbePods()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,253 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package networktestmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	probeContainerName = "probe"
+
+	checkDuplicateAddress = "duplicate-address"
+
+	resultPass = "pass"
+)
+
+// probeScript runs in probe Pods. Each line written to the termination log
+// is a check result in the form of '<name>|<target>|<pass|fail>|<message>',
+// and the script exits non-zero if any check fails.
+const probeScript = `
+RESULT=/dev/termination-log
+FAILED=0
+: > "$RESULT"
+
+report() {
+  echo "$1|$2|$3|$4" >> "$RESULT"
+  [ "$3" = "pass" ] || FAILED=1
+}
+
+# wait for IPv6 duplicate address detection of the kernel
+sleep 3
+
+IPV4S=$(ip -4 -o addr show dev "$IFACE" scope global | awk '{print $4}' | cut -d/ -f1)
+IPV6S=$(ip -6 -o addr show dev "$IFACE" scope global | awk '{print $4}' | cut -d/ -f1)
+if [ -z "$IPV4S$IPV6S" ]; then
+  report interface "$IFACE" fail "no IP address on the interface"
+fi
+
+for ip in $IPV4S; do
+  if arping -D -q -c 2 -w 3 -I "$IFACE" "$ip"; then
+    report duplicate-address "$ip" pass ""
+  else
+    report duplicate-address "$ip" fail "another host replies ARP for the address"
+  fi
+done
+
+for ip in $IPV6S; do
+  if ip -6 -o addr show dev "$IFACE" | grep -F " $ip/" | grep -q dadfailed; then
+    report duplicate-address "$ip" fail "IPv6 duplicate address detection failed"
+  else
+    report duplicate-address "$ip" pass ""
+  fi
+done
+
+# only check the gateways on the link of the interface, the IPPools which
+# the IP addresses are not allocated from may be in other subnets
+on_link() {
+  case "$1" in *" via "*|"") return 1 ;; esac
+  case "$1" in *"dev $IFACE "*) return 0 ;; esac
+  return 1
+}
+
+for gw in $GATEWAYS; do
+  case "$gw" in
+  *:*)
+    on_link "$(ip -6 route get "$gw" 2>/dev/null)" || continue
+    if ping6 -q -c 3 -w 5 -I "$IFACE" "$gw" >/dev/null 2>&1; then
+      report gateway-ping "$gw" pass ""
+    else
+      report gateway-ping "$gw" fail "no ICMPv6 echo reply"
+    fi
+    ;;
+  *)
+    on_link "$(ip -4 route get "$gw" 2>/dev/null)" || continue
+    if arping -q -c 3 -w 5 -I "$IFACE" "$gw"; then
+      report gateway-arp "$gw" pass ""
+    else
+      report gateway-arp "$gw" fail "no ARP reply"
+    fi
+    if ping -q -c 3 -w 5 -I "$IFACE" "$gw" >/dev/null 2>&1; then
+      report gateway-ping "$gw" pass ""
+    else
+      report gateway-ping "$gw" fail "no ICMP echo reply"
+    fi
+    ;;
+  esac
+done
+
+for target in $TARGETS; do
+  case "$target" in *:*) cmd=ping6 ;; *) cmd=ping ;; esac
+  if $cmd -q -c 3 -w 5 "$target" >/dev/null 2>&1; then
+    report target-ping "$target" pass ""
+  else
+    report target-ping "$target" fail "no ICMP echo reply"
+  fi
+done
+
+exit $FAILED
+`
+
+func probeInterface(test *spiderpoolv1.SpiderNetworkTest) string {
+	if test.Spec.Interface != nil && *test.Spec.Interface != "" {
+		return *test.Spec.Interface
+	}
+
+	if test.Spec.NetworkAttachmentDefinition != nil && *test.Spec.NetworkAttachmentDefinition != "" {
+		return defaultMultusInterface
+	}
+
+	return defaultInterface
+}
+
+func probeReplicas(test *spiderpoolv1.SpiderNetworkTest) int {
+	if test.Spec.Replicas != nil && *test.Spec.Replicas > 0 {
+		return int(*test.Spec.Replicas)
+	}
+
+	return defaultReplicas
+}
+
+func probePodName(test *spiderpoolv1.SpiderNetworkTest, index int) string {
+	return fmt.Sprintf("%s-probe-%d", test.Name, index)
+}
+
+// generateProbePod generates the index-th probe Pod of the SpiderNetworkTest.
+func generateProbePod(test *spiderpoolv1.SpiderNetworkTest, index int, gateways []string, image string) (*corev1.Pod, error) {
+	iface := probeInterface(test)
+
+	annotations := map[string]string{}
+	if len(test.Spec.IPv4IPPools) != 0 || len(test.Spec.IPv6IPPools) != 0 {
+		anno, err := json.Marshal(types.AnnoPodIPPoolsValue{{
+			NIC:       iface,
+			IPv4Pools: test.Spec.IPv4IPPools,
+			IPv6Pools: test.Spec.IPv6IPPools,
+		}})
+		if err != nil {
+			return nil, err
+		}
+		annotations[constant.AnnoPodIPPools] = string(anno)
+	}
+	if test.Spec.NetworkAttachmentDefinition != nil && *test.Spec.NetworkAttachmentDefinition != "" {
+		annotations[constant.AnnoMultusNetworks] = *test.Spec.NetworkAttachmentDefinition
+	}
+
+	if test.Spec.Image != nil && *test.Spec.Image != "" {
+		image = *test.Spec.Image
+	}
+
+	labels := map[string]string{constant.LabelNetworkTest: test.Name}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        probePodName(test, index),
+			Namespace:   test.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			TerminationGracePeriodSeconds: pointer.Int64(0),
+			NodeSelector:                  test.Spec.NodeSelector,
+			// spread probe Pods across nodes as much as possible
+			Affinity: &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+							TopologyKey:   corev1.LabelHostname,
+						},
+					}},
+				},
+			},
+			Containers: []corev1.Container{{
+				Name:    probeContainerName,
+				Image:   image,
+				Command: []string{"sh", "-c", probeScript},
+				Env: []corev1.EnvVar{
+					{Name: "IFACE", Value: iface},
+					{Name: "GATEWAYS", Value: strings.Join(gateways, " ")},
+					{Name: "TARGETS", Value: strings.Join(test.Spec.Targets, " ")},
+				},
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{
+						Add: []corev1.Capability{"NET_RAW"},
+					},
+				},
+			}},
+		},
+	}, nil
+}
+
+// parseProbeResult parses the check results written to the termination log
+// of the probe Pod.
+func parseProbeResult(pod *corev1.Pod) spiderpoolv1.NetworkTestProbeResult {
+	result := spiderpoolv1.NetworkTestProbeResult{
+		Pod:  pod.Name,
+		Node: pod.Spec.NodeName,
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != probeContainerName || cs.State.Terminated == nil {
+			continue
+		}
+
+		for _, line := range strings.Split(cs.State.Terminated.Message, "\n") {
+			fields := strings.SplitN(strings.TrimSpace(line), "|", 4)
+			if len(fields) != 4 {
+				continue
+			}
+
+			check := spiderpoolv1.NetworkTestCheck{
+				Name:    fields[0],
+				Target:  fields[1],
+				Passed:  fields[2] == resultPass,
+				Message: fields[3],
+			}
+			if check.Name == checkDuplicateAddress {
+				result.IPs = append(result.IPs, check.Target)
+			}
+			result.Checks = append(result.Checks, check)
+		}
+	}
+
+	return result
+}
+
+// isProbePassed checks whether the probe Pod succeeded and all of its checks
+// passed.
+func isProbePassed(pod *corev1.Pod, result spiderpoolv1.NetworkTestProbeResult) bool {
+	if pod.Status.Phase != corev1.PodSucceeded || len(result.Checks) == 0 {
+		return false
+	}
+
+	for _, check := range result.Checks {
+		if !check.Passed {
+			return false
+		}
+	}
+
+	return true
+}
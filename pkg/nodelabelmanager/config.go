@@ -0,0 +1,29 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodelabelmanager
+
+import (
+	"time"
+)
+
+const (
+	defaultResyncPeriod = time.Minute
+)
+
+type NodeLabelManagerConfig struct {
+	// NodeName is the name of the node where spiderpool-agent runs.
+	NodeName string
+
+	// ResyncPeriod is the interval of refreshing the labels of the node,
+	// besides the refresh on the changes of IPPools.
+	ResyncPeriod time.Duration
+}
+
+func setDefaultsForNodeLabelManagerConfig(config NodeLabelManagerConfig) NodeLabelManagerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	return config
+}
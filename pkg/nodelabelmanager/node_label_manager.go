@@ -0,0 +1,198 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodelabelmanager
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// NodeLabelManager advertises the IPPools usable on the node with the labels
+// 'ippool.ipam.spidernet.io/<IPPool name>: "true"', so that the Pods
+// requiring an IPPool could be scheduled to the nodes where it works with
+// node affinity.
+type NodeLabelManager interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+	// Enqueue triggers the refresh of the labels of the node.
+	Enqueue()
+}
+
+type nodeLabelManager struct {
+	config NodeLabelManagerConfig
+	client client.Client
+	queue  chan struct{}
+}
+
+func NewNodeLabelManager(config NodeLabelManagerConfig, client client.Client) (NodeLabelManager, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if config.NodeName == "" {
+		return nil, fmt.Errorf("node name %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &nodeLabelManager{
+		config: setDefaultsForNodeLabelManagerConfig(config),
+		client: client,
+		queue:  make(chan struct{}, 1),
+	}, nil
+}
+
+// Start refreshes the labels of the node periodically and on demand until
+// the context is done.
+func (m *nodeLabelManager) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			err := recovery.Call(ctx, "Node-Label-Manager", nil, func() error { return m.Reconcile(ctx) })
+			if err != nil {
+				logger.Sugar().Errorf("Failed to refresh the IPPool labels of Node %s: %v", m.config.NodeName, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-m.queue:
+			}
+		}
+	}()
+}
+
+func (m *nodeLabelManager) Enqueue() {
+	select {
+	case m.queue <- struct{}{}:
+	default:
+	}
+}
+
+// Reconcile labels the node with the IPPools usable on it, and removes the
+// labels of the IPPools no longer usable.
+func (m *nodeLabelManager) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var poolList spiderpoolv1.SpiderIPPoolList
+	if err := m.client.List(ctx, &poolList); err != nil {
+		return fmt.Errorf("failed to list IPPools: %w", err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var node corev1.Node
+		if err := m.client.Get(ctx, apitypes.NamespacedName{Name: m.config.NodeName}, &node); err != nil {
+			return fmt.Errorf("failed to get Node %s: %w", m.config.NodeName, err)
+		}
+
+		desired := map[string]string{}
+		for i := range poolList.Items {
+			pool := &poolList.Items[i]
+			key := constant.LabelNodeIPPoolPrefix + pool.Name
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				logger.Sugar().Debugf("Skip to advertise IPPool %s with invalid label key: %s", pool.Name, strings.Join(errs, "; "))
+				continue
+			}
+
+			usable, reason := m.isIPPoolUsable(&node, pool)
+			if !usable {
+				logger.Sugar().Debugf("IPPool %s is not usable on the Node: %s", pool.Name, reason)
+				continue
+			}
+			desired[key] = strconv.FormatBool(true)
+		}
+
+		changed := false
+		newLabels := map[string]string{}
+		for k, v := range node.Labels {
+			if strings.HasPrefix(k, constant.LabelNodeIPPoolPrefix) {
+				if _, ok := desired[k]; !ok {
+					changed = true
+					continue
+				}
+			}
+			newLabels[k] = v
+		}
+		for k, v := range desired {
+			if node.Labels[k] != v {
+				changed = true
+			}
+			newLabels[k] = v
+		}
+		if !changed {
+			return nil
+		}
+
+		node.Labels = newLabels
+		if err := m.client.Update(ctx, &node); err != nil {
+			return err
+		}
+		logger.Sugar().Infof("Advertise %d usable IPPools with the labels of Node %s", len(desired), m.config.NodeName)
+
+		return nil
+	})
+}
+
+// isIPPoolUsable checks whether the Pods on the node could use the IPPool,
+// with its node affinity and the host interface it requires. The labels of
+// the advertised IPPools are excluded from the node affinity.
+func (m *nodeLabelManager) isIPPoolUsable(node *corev1.Node, pool *spiderpoolv1.SpiderIPPool) (bool, string) {
+	if pool.DeletionTimestamp != nil {
+		return false, "terminating"
+	}
+	if pool.Spec.Disable != nil && *pool.Spec.Disable {
+		return false, "disabled"
+	}
+
+	if pool.Spec.NodeAffinity != nil {
+		selector, err := metav1.LabelSelectorAsSelector(pool.Spec.NodeAffinity)
+		if err != nil {
+			return false, fmt.Sprintf("invalid node affinity: %v", err)
+		}
+
+		nodeLabels := labels.Set{}
+		for k, v := range node.Labels {
+			if !strings.HasPrefix(k, constant.LabelNodeIPPoolPrefix) {
+				nodeLabels[k] = v
+			}
+		}
+		if !selector.Matches(nodeLabels) {
+			return false, "node affinity not matched"
+		}
+	}
+
+	if master, ok := pool.Annotations[constant.AnnoIPPoolMasterInterface]; ok {
+		// The VLAN sub-interface is created by the CNI plugins on demand,
+		// so the master interface is enough.
+		if !interfaceExists(master) {
+			return false, fmt.Sprintf("master interface %s not found", master)
+		}
+	}
+
+	return true, ""
+}
+
+func interfaceExists(name string) bool {
+	_, err := net.InterfaceByName(name)
+	return err == nil
+}
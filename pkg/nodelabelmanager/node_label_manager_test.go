@@ -0,0 +1,130 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodelabelmanager_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/nodelabelmanager"
+)
+
+var _ = Describe("NodeLabelManager", Label("node_label_manager_test"), func() {
+	const nodeName = "node1"
+
+	newPool := func(name string) *spiderpoolv1.SpiderIPPool {
+		return &spiderpoolv1.SpiderIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: spiderpoolv1.IPPoolSpec{
+				IPVersion: pointer.Int64(constant.IPv4),
+				Subnet:    "172.18.40.0/24",
+			},
+		}
+	}
+
+	poolLabels := func(c client.Client) map[string]string {
+		var node corev1.Node
+		err := c.Get(context.TODO(), apitypes.NamespacedName{Name: nodeName}, &node)
+		Expect(err).NotTo(HaveOccurred())
+
+		result := map[string]string{}
+		for k, v := range node.Labels {
+			if strings.HasPrefix(k, constant.LabelNodeIPPoolPrefix) {
+				result[strings.TrimPrefix(k, constant.LabelNodeIPPoolPrefix)] = v
+			}
+		}
+		return result
+	}
+
+	Describe("New NodeLabelManager", func() {
+		It("inputs nil client", func() {
+			manager, err := nodelabelmanager.NewNodeLabelManager(nodelabelmanager.NodeLabelManagerConfig{NodeName: nodeName}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+
+		It("inputs empty node name", func() {
+			manager, err := nodelabelmanager.NewNodeLabelManager(nodelabelmanager.NodeLabelManagerConfig{}, fake.NewClientBuilder().WithScheme(scheme).Build())
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		It("advertises the usable IPPools", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: nodeName,
+					Labels: map[string]string{
+						"zone": "a",
+						constant.LabelNodeIPPoolPrefix + "stale-pool": "true",
+					},
+				},
+			}
+
+			matched := newPool("matched-pool")
+			matched.Spec.NodeAffinity = &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "a"}}
+
+			unmatched := newPool("unmatched-pool")
+			unmatched.Spec.NodeAffinity = &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "b"}}
+
+			disabled := newPool("disabled-pool")
+			disabled.Spec.Disable = pointer.Bool(true)
+
+			loopback := newPool("loopback-pool")
+			loopback.Annotations = map[string]string{constant.AnnoIPPoolMasterInterface: "lo"}
+
+			missing := newPool("missing-interface-pool")
+			missing.Annotations = map[string]string{constant.AnnoIPPoolMasterInterface: "not-exist0"}
+
+			tooLong := newPool(strings.Repeat("a", 64))
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(node, newPool("default-pool"), matched, unmatched, disabled, loopback, missing, tooLong).
+				Build()
+
+			manager, err := nodelabelmanager.NewNodeLabelManager(nodelabelmanager.NodeLabelManagerConfig{NodeName: nodeName}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = manager.Reconcile(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(poolLabels(fakeClient)).To(Equal(map[string]string{
+				"default-pool":  "true",
+				"matched-pool":  "true",
+				"loopback-pool": "true",
+			}))
+
+			err = fakeClient.Delete(context.TODO(), matched)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = manager.Reconcile(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(poolLabels(fakeClient)).To(Equal(map[string]string{
+				"default-pool":  "true",
+				"loopback-pool": "true",
+			}))
+		})
+
+		It("fails to get the node", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			manager, err := nodelabelmanager.NewNodeLabelManager(nodelabelmanager.NodeLabelManagerConfig{NodeName: nodeName}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = manager.Reconcile(context.TODO())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,21 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodemanager
+
+import "time"
+
+const defaultUnsupportedNodeResyncPeriod = 5 * time.Minute
+
+type UnsupportedNodeReporterConfig struct {
+	// ResyncPeriod is the interval to check the conditions of all nodes.
+	ResyncPeriod time.Duration
+}
+
+func setDefaultsForUnsupportedNodeReporterConfig(config UnsupportedNodeReporterConfig) UnsupportedNodeReporterConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultUnsupportedNodeResyncPeriod
+	}
+
+	return config
+}
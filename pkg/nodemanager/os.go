@@ -0,0 +1,26 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodemanager
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const osWindows = "windows"
+
+// NodeOS returns the operating system of the node, by its well-known label or
+// by the one reported by the kubelet.
+func NodeOS(node *corev1.Node) string {
+	if os, ok := node.Labels[corev1.LabelOSStable]; ok && os != "" {
+		return os
+	}
+
+	return node.Status.NodeInfo.OperatingSystem
+}
+
+// IsWindowsNode tells whether the node runs Windows, on which the Linux
+// specific results of Spiderpool break the Pods.
+func IsWindowsNode(node *corev1.Node) bool {
+	return NodeOS(node) == osWindows
+}
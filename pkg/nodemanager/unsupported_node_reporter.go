@@ -0,0 +1,133 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodemanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// UnsupportedNodeReporter sets the condition SpiderpoolUnsupported on the
+// Windows nodes of the mixed-OS cluster, on which spiderpool-agent refuses to
+// allocate IP addresses, so that the failures of the Pods on them are told
+// apart with 'kubectl describe node'.
+type UnsupportedNodeReporter interface {
+	Start(ctx context.Context)
+	// Report sets the condition on all unsupported nodes lacking it.
+	Report(ctx context.Context) error
+}
+
+type unsupportedNodeReporter struct {
+	config UnsupportedNodeReporterConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+}
+
+func NewUnsupportedNodeReporter(config UnsupportedNodeReporterConfig, client client.Client, leader election.SpiderLeaseElector) (UnsupportedNodeReporter, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &unsupportedNodeReporter{
+		config: setDefaultsForUnsupportedNodeReporterConfig(config),
+		client: client,
+		leader: leader,
+	}, nil
+}
+
+// Start reports the unsupported nodes periodically until the context is
+// done. Only the leader of spiderpool-controller reports them.
+func (r *unsupportedNodeReporter) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if r.leader.IsElected() {
+				err := recovery.Call(ctx, "Unsupported-Node-Reporter", nil, func() error { return r.Report(ctx) })
+				if err != nil {
+					logger.Sugar().Errorf("Failed to report the unsupported nodes: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *unsupportedNodeReporter) Report(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var nodeList corev1.NodeList
+	if err := r.client.List(ctx, &nodeList); err != nil {
+		return fmt.Errorf("failed to list Nodes: %w", err)
+	}
+
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !IsWindowsNode(node) || hasUnsupportedCondition(node) {
+			continue
+		}
+
+		patch := client.StrategicMergeFrom(node.DeepCopy())
+		setUnsupportedCondition(node)
+		if err := r.client.Status().Patch(ctx, node, patch); err != nil {
+			return fmt.Errorf("failed to set condition %s of Node %s: %w", constant.NodeConditionSpiderpoolUnsupported, node.Name, err)
+		}
+		logger.Sugar().Infof("Node %s runs Windows, set its condition %s", node.Name, constant.NodeConditionSpiderpoolUnsupported)
+	}
+
+	return nil
+}
+
+// setUnsupportedCondition sets the condition on the node, in place of the
+// one with the same type, as the conditions are merged by their types.
+func setUnsupportedCondition(node *corev1.Node) {
+	now := metav1.Now()
+	condition := corev1.NodeCondition{
+		Type:               constant.NodeConditionSpiderpoolUnsupported,
+		Status:             corev1.ConditionTrue,
+		LastHeartbeatTime:  now,
+		LastTransitionTime: now,
+		Reason:             constant.ReasonWindowsNode,
+		Message:            "Spiderpool does not allocate IP addresses on Windows nodes",
+	}
+
+	for i, c := range node.Status.Conditions {
+		if c.Type == constant.NodeConditionSpiderpoolUnsupported {
+			node.Status.Conditions[i] = condition
+			return
+		}
+	}
+	node.Status.Conditions = append(node.Status.Conditions, condition)
+}
+
+func hasUnsupportedCondition(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == constant.NodeConditionSpiderpoolUnsupported && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package nodemanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/nodemanager"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("UnsupportedNodeReporter", Label("unsupported_node_reporter_test"), func() {
+	Describe("IsWindowsNode", func() {
+		It("tells the OS by the well-known label first", func() {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{corev1.LabelOSStable: "windows"}},
+				Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OperatingSystem: "linux"}},
+			}
+			Expect(nodemanager.IsWindowsNode(node)).To(BeTrue())
+		})
+
+		It("tells the OS by the one reported by the kubelet", func() {
+			node := &corev1.Node{Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{OperatingSystem: "windows"}}}
+			Expect(nodemanager.IsWindowsNode(node)).To(BeTrue())
+
+			node.Status.NodeInfo.OperatingSystem = "linux"
+			Expect(nodemanager.IsWindowsNode(node)).To(BeFalse())
+		})
+	})
+
+	Describe("New UnsupportedNodeReporter", func() {
+		It("inputs nil client", func() {
+			reporter, err := nodemanager.NewUnsupportedNodeReporter(nodemanager.UnsupportedNodeReporterConfig{}, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reporter).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			reporter, err := nodemanager.NewUnsupportedNodeReporter(nodemanager.UnsupportedNodeReporterConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reporter).To(BeNil())
+		})
+	})
+
+	Describe("Report", func() {
+		var ctx context.Context
+		var reporterClient client.Client
+		var reporter nodemanager.UnsupportedNodeReporter
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			reporterClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			var err error
+			reporter, err = nodemanager.NewUnsupportedNodeReporter(nodemanager.UnsupportedNodeReporterConfig{}, reporterClient, fakeLeader{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		conditionOf := func(name string) *corev1.NodeCondition {
+			var node corev1.Node
+			err := reporterClient.Get(ctx, client.ObjectKey{Name: name}, &node)
+			Expect(err).NotTo(HaveOccurred())
+			for i := range node.Status.Conditions {
+				if node.Status.Conditions[i].Type == constant.NodeConditionSpiderpoolUnsupported {
+					return &node.Status.Conditions[i]
+				}
+			}
+
+			return nil
+		}
+
+		It("sets the condition on the Windows nodes only", func() {
+			windowsNode := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "windows-node",
+					Labels: map[string]string{corev1.LabelOSStable: "windows"},
+				},
+				Status: corev1.NodeStatus{
+					Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			linuxNode := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "linux-node",
+					Labels: map[string]string{corev1.LabelOSStable: "linux"},
+				},
+			}
+			Expect(reporterClient.Create(ctx, windowsNode)).To(Succeed())
+			Expect(reporterClient.Create(ctx, linuxNode)).To(Succeed())
+
+			err := reporter.Report(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			condition := conditionOf(windowsNode.Name)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(constant.ReasonWindowsNode))
+			Expect(conditionOf(linuxNode.Name)).To(BeNil())
+
+			var node corev1.Node
+			err = reporterClient.Get(ctx, client.ObjectKeyFromObject(windowsNode), &node)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.Status.Conditions).To(HaveLen(2))
+
+			err = reporter.Report(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conditionOf(windowsNode.Name).LastTransitionTime).To(Equal(condition.LastTransitionTime))
+		})
+	})
+})
@@ -8,8 +8,10 @@ import (
 )
 
 type PodManagerConfig struct {
-	MaxConflictRetries    int
-	ConflictRetryUnitTime time.Duration
+	// ListPageSize and ListPageInterval control the pagination of
+	// IteratePods.
+	ListPageSize     int64
+	ListPageInterval time.Duration
 }
 
 func setDefaultsForPodManagerConfig(config PodManagerConfig) PodManagerConfig {
@@ -0,0 +1,331 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	subnetmanagercontrollers "github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+var annotationsField *field.Path = field.NewPath("metadata").Child("annotations")
+
+// validateAnnotations validates the Spiderpool annotations of the Pod in the
+// same way as IPAM, so that the Pod with malformed annotations, unknown
+// IPPools or SpiderSubnets, or the annotations which would be ignored by
+// IPAM is rejected at admission rather than failing the CNI ADD requests.
+// The errors of the annotations converted from "ipam.spidernet.io/config"
+// are reported on it.
+func (pw *PodWebhook) validateAnnotations(ctx context.Context, pod *corev1.Pod) field.ErrorList {
+	if pod.Spec.HostNetwork {
+		return nil
+	}
+
+	annotations := pod.Annotations
+	fieldOf := func(key string) *field.Path {
+		return annotationsField.Key(key)
+	}
+
+	if value, ok := pod.Annotations[constant.AnnoPodConfig]; ok {
+		converted, err := annotation.ConvertPodConfig(pod.Annotations)
+		if err != nil {
+			return field.ErrorList{field.Invalid(annotationsField.Key(constant.AnnoPodConfig), value, err.Error())}
+		}
+		annotations = converted
+		fieldOf = func(string) *field.Path {
+			return annotationsField.Key(constant.AnnoPodConfig)
+		}
+	}
+
+	subnetKey := constant.AnnoSpiderSubnets
+	_, withSubnets := annotations[subnetKey]
+	if !withSubnets {
+		subnetKey = constant.AnnoSpiderSubnet
+		_, withSubnets = annotations[subnetKey]
+	}
+
+	var errs field.ErrorList
+	if withSubnets {
+		if !pw.EnableSpiderSubnet {
+			return append(errs, field.Forbidden(fieldOf(subnetKey), "feature SpiderSubnet is disabled"))
+		}
+		for _, key := range []string{constant.AnnoPodIPPools, constant.AnnoPodIPPool} {
+			if _, ok := annotations[key]; ok {
+				errs = append(errs, field.Forbidden(fieldOf(key), "IPPools are ignored with SpiderSubnets"))
+			}
+		}
+		errs = append(errs, pw.validateSubnetAnnotations(ctx, pod, annotations, fieldOf(subnetKey), annotations[subnetKey])...)
+	} else if value, ok := annotations[constant.AnnoPodIPPools]; ok {
+		if _, ok := annotations[constant.AnnoPodIPPool]; ok {
+			errs = append(errs, field.Forbidden(fieldOf(constant.AnnoPodIPPool), fmt.Sprintf("it is ignored with '%s'", constant.AnnoPodIPPools)))
+		}
+		errs = append(errs, pw.validateIPPoolsAnnotation(ctx, value, fieldOf(constant.AnnoPodIPPools))...)
+	} else if value, ok := annotations[constant.AnnoPodIPPool]; ok {
+		errs = append(errs, pw.validateIPPoolAnnotation(ctx, value, fieldOf(constant.AnnoPodIPPool))...)
+	}
+
+	if value, ok := annotations[constant.AnnoPodRoutes]; ok {
+		errs = append(errs, validateRoutesAnnotation(annotations, value, fieldOf(constant.AnnoPodRoutes))...)
+	}
+
+	if value, ok := pod.Annotations[constant.AnnoPodMACs]; ok {
+		var macs types.AnnoPodMACsValue
+		if err := json.Unmarshal([]byte(value), &macs); err != nil {
+			errs = append(errs, field.Invalid(annotationsField.Key(constant.AnnoPodMACs), value, err.Error()))
+		}
+		for nic, mac := range macs {
+			if hw, err := net.ParseMAC(mac); err != nil || len(hw) != 6 {
+				errs = append(errs, field.Invalid(annotationsField.Key(constant.AnnoPodMACs), value, fmt.Sprintf("invalid MAC address %s of interface %s", mac, nic)))
+			}
+		}
+	}
+
+	if value, ok := pod.Annotations[constant.AnnoPodHostPortSNAT]; ok {
+		if _, err := strconv.ParseBool(value); err != nil {
+			errs = append(errs, field.Invalid(annotationsField.Key(constant.AnnoPodHostPortSNAT), value, err.Error()))
+		}
+	}
+
+	if value, ok := pod.Annotations[constant.AnnoPodDefaultRouteNIC]; ok && value == "" {
+		errs = append(errs, field.Invalid(annotationsField.Key(constant.AnnoPodDefaultRouteNIC), value, "must be an interface name"))
+	}
+	if value, ok := pod.Annotations[constant.AnnoDefaultRoutePolicy]; ok && !annotation.IsDefaultRoutePolicy(value) {
+		errs = append(errs, field.NotSupported(
+			annotationsField.Key(constant.AnnoDefaultRoutePolicy),
+			value,
+			[]string{constant.DefaultRoutePolicyAll, constant.DefaultRoutePolicyFirstNIC, constant.DefaultRoutePolicyUnderlay},
+		))
+	}
+	if value, ok := pod.Annotations[constant.AnnoPodIPFamilyOrder]; ok && !annotation.IsIPFamilyOrder(value) {
+		errs = append(errs, field.NotSupported(
+			annotationsField.Key(constant.AnnoPodIPFamilyOrder),
+			value,
+			[]string{constant.IPFamilyOrderIPv4First, constant.IPFamilyOrderIPv6First},
+		))
+	}
+	if value, ok := pod.Annotations[constant.AnnoPodBandwidth]; ok {
+		if _, err := annotation.ParsePodBandwidth(value); err != nil {
+			errs = append(errs, field.Invalid(annotationsField.Key(constant.AnnoPodBandwidth), value, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+// validateRoutesAnnotation checks the format of the custom routes, and that
+// the interfaces the routes are scoped to are requested by the annotation
+// "ipam.spidernet.io/ippools" if it is specified. Whether the gateways are
+// reachable is checked by IPAM with the allocated IP addresses.
+func validateRoutesAnnotation(annotations map[string]string, value string, fieldPath *field.Path) field.ErrorList {
+	var routes types.AnnoPodRoutesValue
+	if err := json.Unmarshal([]byte(value), &routes); err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, value, err.Error())}
+	}
+
+	var nicSet map[string]struct{}
+	var items types.AnnoPodIPPoolsValue
+	if err := json.Unmarshal([]byte(annotations[constant.AnnoPodIPPools]), &items); err == nil {
+		nicSet = map[string]struct{}{}
+		for _, item := range items {
+			nicSet[item.NIC] = struct{}{}
+		}
+	}
+
+	var errs field.ErrorList
+	for _, route := range routes {
+		if err := spiderpoolip.IsRouteWithoutIPVersion(route.Dst, route.Gw); err != nil {
+			errs = append(errs, field.Invalid(fieldPath, value, err.Error()))
+			continue
+		}
+		if route.Interface == "" || nicSet == nil {
+			continue
+		}
+		if _, ok := nicSet[route.Interface]; !ok {
+			errs = append(errs, field.Invalid(fieldPath, value, fmt.Sprintf("interface %s of route to %s is not requested by '%s'", route.Interface, route.Dst, constant.AnnoPodIPPools)))
+		}
+	}
+
+	return errs
+}
+
+func (pw *PodWebhook) validateIPPoolsAnnotation(ctx context.Context, value string, fieldPath *field.Path) field.ErrorList {
+	var items types.AnnoPodIPPoolsValue
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, value, err.Error())}
+	}
+	if len(items) == 0 {
+		return field.ErrorList{field.Invalid(fieldPath, value, "value requires at least one item")}
+	}
+
+	var errs field.ErrorList
+	nicSet := map[string]struct{}{}
+	for _, item := range items {
+		if item.NIC == "" {
+			errs = append(errs, field.Invalid(fieldPath, value, "interface must be specified"))
+			continue
+		}
+		if _, ok := nicSet[item.NIC]; ok {
+			errs = append(errs, field.Invalid(fieldPath, value, fmt.Sprintf("duplicate interface %s", item.NIC)))
+			continue
+		}
+		nicSet[item.NIC] = struct{}{}
+
+		errs = append(errs, pw.validatePoolCandidates(ctx, item.IPv4Pools, item.IPv6Pools, fieldPath)...)
+	}
+
+	return errs
+}
+
+func (pw *PodWebhook) validateIPPoolAnnotation(ctx context.Context, value string, fieldPath *field.Path) field.ErrorList {
+	var item types.AnnoPodIPPoolValue
+	if err := json.Unmarshal([]byte(value), &item); err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, value, err.Error())}
+	}
+
+	return pw.validatePoolCandidates(ctx, item.IPv4Pools, item.IPv6Pools, fieldPath)
+}
+
+// validatePoolCandidates checks that the IPPools of the enabled IP versions
+// are specified, exist, and belong to the right IP version.
+func (pw *PodWebhook) validatePoolCandidates(ctx context.Context, v4Pools, v6Pools []string, fieldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, e := range []struct {
+		version types.IPVersion
+		enabled bool
+		pools   []string
+	}{
+		{version: constant.IPv4, enabled: pw.EnableIPv4, pools: v4Pools},
+		{version: constant.IPv6, enabled: pw.EnableIPv6, pools: v6Pools},
+	} {
+		if e.enabled && len(e.pools) == 0 {
+			errs = append(errs, field.Required(fieldPath, fmt.Sprintf("IPv%d IPPools must be specified", e.version)))
+			continue
+		}
+
+		poolSet := map[string]struct{}{}
+		for _, poolName := range e.pools {
+			if _, ok := poolSet[poolName]; ok {
+				errs = append(errs, field.Duplicate(fieldPath, poolName))
+				continue
+			}
+			poolSet[poolName] = struct{}{}
+
+			var pool spiderpoolv1.SpiderIPPool
+			if err := pw.Get(ctx, apitypes.NamespacedName{Name: poolName}, &pool); err != nil {
+				if apierrors.IsNotFound(err) {
+					errs = append(errs, field.NotFound(fieldPath, fmt.Sprintf("%s %s", constant.SpiderIPPoolKind, poolName)))
+				} else {
+					errs = append(errs, field.InternalError(fieldPath, err))
+				}
+				continue
+			}
+			if pool.Spec.IPVersion != nil && *pool.Spec.IPVersion != e.version {
+				errs = append(errs, field.Invalid(fieldPath, poolName, fmt.Sprintf("%s %s is not IPv%d", constant.SpiderIPPoolKind, poolName, e.version)))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (pw *PodWebhook) validateSubnetAnnotations(ctx context.Context, pod *corev1.Pod, annotations map[string]string, fieldPath *field.Path, value string) field.ErrorList {
+	subnetConfig, err := subnetmanagercontrollers.GetSubnetAnnoConfig(annotations, nil, nil, logutils.FromContext(ctx))
+	if err != nil {
+		return field.ErrorList{field.Invalid(fieldPath, value, err.Error())}
+	}
+
+	items := subnetConfig.MultipleSubnets
+	if subnetConfig.SingleSubnet != nil {
+		items = append(items, *subnetConfig.SingleSubnet)
+	}
+
+	var errs field.ErrorList
+	fetched := map[string]*spiderpoolv1.SpiderSubnet{}
+	for _, item := range items {
+		for _, subnets := range [][]string{item.IPv4, item.IPv6} {
+			for _, subnetName := range subnets {
+				var subnet spiderpoolv1.SpiderSubnet
+				if err := pw.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet); err != nil {
+					if apierrors.IsNotFound(err) {
+						errs = append(errs, field.NotFound(fieldPath, fmt.Sprintf("%s %s", constant.SpiderSubnetKind, subnetName)))
+					} else {
+						errs = append(errs, field.InternalError(fieldPath, err))
+					}
+					continue
+				}
+				fetched[subnetName] = &subnet
+			}
+		}
+	}
+
+	for _, item := range items {
+		if len(item.IPv4) == 0 || len(item.IPv6) == 0 {
+			continue
+		}
+		v4Subnet, v6Subnet := fetched[item.IPv4[0]], fetched[item.IPv6[0]]
+		if v4Subnet == nil || v6Subnet == nil {
+			continue
+		}
+		if diff := subnetPairMismatch(v4Subnet, v6Subnet); diff != "" {
+			msg := fmt.Sprintf("IPv4 %s %s and IPv6 %s %s of interface '%s' have different %s, which leads to asymmetric routing",
+				constant.SpiderSubnetKind, v4Subnet.Name, constant.SpiderSubnetKind, v6Subnet.Name, item.Interface, diff)
+			logutils.FromContext(ctx).Sugar().Warnf("Admit with mismatched SpiderSubnets: %s", msg)
+			event.EventRecorder.Eventf(eventTargetOf(pod), corev1.EventTypeWarning, constant.EventReasonSubnetPairMismatch, msg)
+		}
+	}
+
+	return errs
+}
+
+// subnetPairMismatch returns how the IPv4 and IPv6 SpiderSubnets paired for
+// the same interface differ in VLAN and zone, or empty if they are compatible.
+func subnetPairMismatch(v4Subnet, v6Subnet *spiderpoolv1.SpiderSubnet) string {
+	var diffs []string
+	if v4Vlan, v6Vlan := pointer.Int64Deref(v4Subnet.Spec.Vlan, 0), pointer.Int64Deref(v6Subnet.Spec.Vlan, 0); v4Vlan != v6Vlan {
+		diffs = append(diffs, fmt.Sprintf("VLANs %d and %d", v4Vlan, v6Vlan))
+	}
+	if v4Zone, v6Zone := v4Subnet.Labels[corev1.LabelTopologyZone], v6Subnet.Labels[corev1.LabelTopologyZone]; v4Zone != v6Zone {
+		diffs = append(diffs, fmt.Sprintf("zones '%s' and '%s'", v4Zone, v6Zone))
+	}
+
+	return strings.Join(diffs, ", ")
+}
+
+// eventTargetOf returns the controller of the Pod to record the events on, as
+// the Pod being admitted may not have a name yet, or the Pod itself if it is
+// orphaned.
+func eventTargetOf(pod *corev1.Pod) runtime.Object {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return pod
+	}
+
+	return &corev1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Namespace:  pod.Namespace,
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}
+}
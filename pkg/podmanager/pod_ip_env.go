@@ -0,0 +1,184 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+// IPEnvPrefix is the prefix of the environment variables injected with the
+// IP addresses of the Pod, e.g. 'SPIDERPOOL_NET1_IPV4'.
+const IPEnvPrefix = "SPIDERPOOL_"
+
+// injectIPEnv injects the IP addresses which the Pod is going to be
+// allocated into the environment variables of its containers, so that the
+// applications know their addresses of the secondary interfaces at startup.
+// Only the IP addresses known before the allocation are injected, which
+// requires the Pod to specify a single IPPool for the NIC and IP version,
+// and the IPPool to have a single IP address or assign IP addresses by
+// StatefulSet ordinal. The environment variables already defined by the
+// containers are kept.
+func (pw *PodWebhook) injectIPEnv(ctx context.Context, pod *corev1.Pod) error {
+	logger := logutils.FromContext(ctx)
+
+	if pod.Spec.HostNetwork {
+		return nil
+	}
+
+	items, err := poolsOfNICs(pod)
+	if err != nil {
+		return err
+	}
+
+	podController := podControllerOf(pod)
+	var envs []corev1.EnvVar
+	for _, item := range items {
+		for _, c := range []struct {
+			enabled bool
+			pools   []string
+			suffix  string
+		}{
+			{pw.EnableIPv4, item.IPv4Pools, "_IPV4"},
+			{pw.EnableIPv6, item.IPv6Pools, "_IPV6"},
+		} {
+			if !c.enabled || len(c.pools) != 1 {
+				continue
+			}
+
+			var pool spiderpoolv1.SpiderIPPool
+			if err := pw.Get(ctx, apitypes.NamespacedName{Name: c.pools[0]}, &pool); err != nil {
+				return err
+			}
+			ip, ok := ippoolmanager.FixedIPOfPod(&pool, pod, podController)
+			if !ok {
+				logger.Sugar().Debugf("Skip to inject the IP address of IPPool %s, which is unknown before the allocation", pool.Name)
+				continue
+			}
+			envs = append(envs, corev1.EnvVar{
+				Name:  ipEnvName(item.NIC, c.suffix),
+				Value: ip.String(),
+			})
+		}
+	}
+	if len(envs) == 0 {
+		return nil
+	}
+
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = mergeEnv(pod.Spec.InitContainers[i].Env, envs)
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = mergeEnv(pod.Spec.Containers[i].Env, envs)
+	}
+	logger.Sugar().Debugf("Inject the IP addresses into environment variables %v", envs)
+
+	return nil
+}
+
+// poolsOfNICs returns the IPPools of each NIC specified by the annotations of
+// the Pod. The IPPools of the Namespace or the cluster are shared by many
+// Pods, so they are not taken as the request for fixed IP addresses.
+func poolsOfNICs(pod *corev1.Pod) (types.AnnoPodIPPoolsValue, error) {
+	annotations, err := annotation.ConvertPodConfig(pod.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := annotations[constant.AnnoSpiderSubnets]; ok {
+		return nil, nil
+	}
+	if _, ok := annotations[constant.AnnoSpiderSubnet]; ok {
+		return nil, nil
+	}
+
+	if anno, ok := annotations[constant.AnnoPodIPPools]; ok {
+		var annoPodIPPools types.AnnoPodIPPoolsValue
+		if err := json.Unmarshal([]byte(anno), &annoPodIPPools); err != nil {
+			return nil, fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodIPPools, err)
+		}
+		return annoPodIPPools, nil
+	}
+
+	if anno, ok := annotations[constant.AnnoPodIPPool]; ok {
+		var annoPodIPPool types.AnnoPodIPPoolValue
+		if err := json.Unmarshal([]byte(anno), &annoPodIPPool); err != nil {
+			return nil, fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodIPPool, err)
+		}
+		return types.AnnoPodIPPoolsValue{{
+			NIC:       constant.ClusterDefaultInterfaceName,
+			IPv4Pools: annoPodIPPool.IPv4Pools,
+			IPv6Pools: annoPodIPPool.IPv6Pools,
+		}}, nil
+	}
+
+	return nil, nil
+}
+
+// podControllerOf returns the controller of the Pod from its owner
+// references, the Pod itself if none.
+func podControllerOf(pod *corev1.Pod) types.PodTopController {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return types.PodTopController{
+			Kind:      owner.Kind,
+			Namespace: pod.Namespace,
+			Name:      owner.Name,
+			UID:       owner.UID,
+		}
+	}
+
+	return types.PodTopController{
+		Kind:      constant.KindPod,
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		UID:       pod.UID,
+	}
+}
+
+// ipEnvName returns the name of the environment variable with the IP
+// address of the NIC, whose characters not allowed in the names of
+// environment variables are replaced with '_'.
+func ipEnvName(nic, suffix string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, nic)
+
+	return IPEnvPrefix + name + suffix
+}
+
+// mergeEnv appends the environment variables not defined yet.
+func mergeEnv(existing, envs []corev1.EnvVar) []corev1.EnvVar {
+	defined := make(map[string]struct{}, len(existing))
+	for _, e := range existing {
+		defined[e.Name] = struct{}{}
+	}
+
+	for _, e := range envs {
+		if _, ok := defined[e.Name]; !ok {
+			existing = append(existing, e)
+		}
+	}
+
+	return existing
+}
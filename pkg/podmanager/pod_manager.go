@@ -5,45 +5,77 @@ package podmanager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/pager"
 )
 
 type PodManager interface {
-	GetPodByName(ctx context.Context, namespace, podName string) (*corev1.Pod, error)
+	GetPodByName(ctx context.Context, namespace, podName string, cached bool) (*corev1.Pod, error)
 	ListPods(ctx context.Context, opts ...client.ListOption) (*corev1.PodList, error)
+	IteratePods(ctx context.Context, fn func(pod *corev1.Pod) error, opts ...client.ListOption) error
 	GetPodTopController(ctx context.Context, pod *corev1.Pod) (types.PodTopController, error)
+	MergeAnnotations(ctx context.Context, namespace, podName string, annotations map[string]string) error
 }
 
 type podManager struct {
-	config PodManagerConfig
-	client client.Client
+	config    PodManagerConfig
+	client    client.Client
+	apiReader client.Reader
+	options   manageroption.Options
 }
 
-func NewPodManager(config PodManagerConfig, client client.Client) (PodManager, error) {
+func NewPodManager(config PodManagerConfig, client client.Client, apiReader client.Reader, opts ...manageroption.Option) (PodManager, error) {
 	if client == nil {
 		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
 	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
 
 	return &podManager{
-		config: setDefaultsForPodManagerConfig(config),
-		client: client,
+		config:    setDefaultsForPodManagerConfig(config),
+		client:    client,
+		apiReader: apiReader,
+		options:   manageroption.New(opts...),
 	}, nil
 }
 
-func (pm *podManager) GetPodByName(ctx context.Context, namespace, podName string) (*corev1.Pod, error) {
+// GetPodByName gets the Pod from the cache if cached and the cache is
+// configured, otherwise from the API server. The cache may lag behind, so the
+// Pod not found in the cache is got from the API server again, e.g. the Pod
+// just created.
+func (pm *podManager) GetPodByName(ctx context.Context, namespace, podName string, cached bool) (*corev1.Pod, error) {
+	fromCache := cached == constant.UseCache && pm.options.Cache != nil
+	reader := pm.apiReader
+	if fromCache {
+		reader = pm.options.Cache
+	}
+
+	key := apitypes.NamespacedName{Namespace: namespace, Name: podName}
 	var pod corev1.Pod
-	if err := pm.client.Get(ctx, apitypes.NamespacedName{Namespace: namespace, Name: podName}, &pod); err != nil {
+	err := reader.Get(ctx, key, &pod)
+	pm.options.Metrics.RecordRead(ctx, constant.KindPod, fromCache)
+	if fromCache && apierrors.IsNotFound(err) {
+		err = pm.apiReader.Get(ctx, key, &pod)
+		pm.options.Metrics.RecordRead(ctx, constant.KindPod, false)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -59,15 +91,52 @@ func (pm *podManager) ListPods(ctx context.Context, opts ...client.ListOption) (
 	return &podList, nil
 }
 
+// IteratePods lists the Pods page by page and calls fn with each of them,
+// until all of them are iterated or fn returns an error.
+func (pm *podManager) IteratePods(ctx context.Context, fn func(pod *corev1.Pod) error, opts ...client.ListOption) error {
+	return pager.ListPages(
+		ctx,
+		pm.client,
+		pager.Config{PageSize: pm.config.ListPageSize, PageInterval: pm.config.ListPageInterval},
+		func() client.ObjectList { return &corev1.PodList{} },
+		func(list client.ObjectList) error {
+			podList := list.(*corev1.PodList)
+			for i := range podList.Items {
+				if err := fn(&podList.Items[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		opts...,
+	)
+}
+
+// MergeAnnotations sets the annotations of the Pod with a merge patch,
+// leaving its other annotations untouched.
+func (pm *podManager) MergeAnnotations(ctx context.Context, namespace, podName string, annotations map[string]string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: podName}}
+
+	return pm.client.Patch(ctx, pod, client.RawPatch(apitypes.MergePatchType, patch))
+}
+
 // GetPodTopController will find the pod top owner controller with the given pod.
 // For example, once we create a deployment then it will create replicaset and the replicaset will create pods.
 // So, the pods' top owner is deployment. That's what the method implements.
 // Notice: if the application is a third party controller, the types.PodTopController property App would be nil!
+// Its property Scale is set if its scale subresource could be got.
 func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod) (types.PodTopController, error) {
 	logger := logutils.FromContext(ctx)
 
-	var ownerErr = fmt.Errorf("failed to get pod '%s/%s' owner", pod.Namespace, pod.Name)
-
 	podOwner := metav1.GetControllerOf(pod)
 	if podOwner == nil {
 		return types.PodTopController{
@@ -80,13 +149,10 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 	}
 
 	// third party controller
-	if podOwner.APIVersion != appsv1.SchemeGroupVersion.String() && podOwner.APIVersion != batchv1.SchemeGroupVersion.String() {
-		return types.PodTopController{
-			Kind:      constant.KindUnknown,
-			Namespace: pod.Namespace,
-			Name:      podOwner.Name,
-			UID:       podOwner.UID,
-		}, nil
+	if podOwner.APIVersion != appsv1.SchemeGroupVersion.String() &&
+		podOwner.APIVersion != batchv1.SchemeGroupVersion.String() &&
+		podOwner.APIVersion != corev1.SchemeGroupVersion.String() {
+		return pm.thirdPartyController(ctx, pod.Namespace, podOwner), nil
 	}
 
 	namespacedName := apitypes.NamespacedName{
@@ -99,16 +165,17 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 		var replicaset appsv1.ReplicaSet
 		err := pm.client.Get(ctx, namespacedName, &replicaset)
 		if nil != err {
-			return types.PodTopController{}, fmt.Errorf("%w: %v", ownerErr, err)
+			return types.PodTopController{}, ownerError(pod, constant.KindReplicaSet, namespacedName, err)
 		}
 
 		replicasetOwner := metav1.GetControllerOf(&replicaset)
 		if replicasetOwner != nil {
 			if replicasetOwner.Kind == constant.KindDeployment {
 				var deployment appsv1.Deployment
-				err = pm.client.Get(ctx, apitypes.NamespacedName{Namespace: replicaset.Namespace, Name: replicasetOwner.Name}, &deployment)
+				deploymentName := apitypes.NamespacedName{Namespace: replicaset.Namespace, Name: replicasetOwner.Name}
+				err = pm.client.Get(ctx, deploymentName, &deployment)
 				if nil != err {
-					return types.PodTopController{}, fmt.Errorf("%w: %v", ownerErr, err)
+					return types.PodTopController{}, ownerError(pod, constant.KindDeployment, deploymentName, err)
 				}
 				return types.PodTopController{
 					Kind:      constant.KindDeployment,
@@ -120,12 +187,7 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 			}
 
 			logger.Sugar().Warnf("the controller type '%s' of pod '%s/%s' is unknown", replicasetOwner.Kind, pod.Namespace, pod.Name)
-			return types.PodTopController{
-				Kind:      constant.KindUnknown,
-				Namespace: pod.Namespace,
-				Name:      replicasetOwner.Name,
-				UID:       replicasetOwner.UID,
-			}, nil
+			return pm.thirdPartyController(ctx, pod.Namespace, replicasetOwner), nil
 		}
 		return types.PodTopController{
 			Kind:      constant.KindReplicaSet,
@@ -139,15 +201,16 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 		var job batchv1.Job
 		err := pm.client.Get(ctx, namespacedName, &job)
 		if nil != err {
-			return types.PodTopController{}, fmt.Errorf("%w: %v", ownerErr, err)
+			return types.PodTopController{}, ownerError(pod, constant.KindJob, namespacedName, err)
 		}
 		jobOwner := metav1.GetControllerOf(&job)
 		if jobOwner != nil {
 			if jobOwner.Kind == constant.KindCronJob {
 				var cronJob batchv1.CronJob
-				err = pm.client.Get(ctx, apitypes.NamespacedName{Namespace: job.Namespace, Name: jobOwner.Name}, &cronJob)
+				cronJobName := apitypes.NamespacedName{Namespace: job.Namespace, Name: jobOwner.Name}
+				err = pm.client.Get(ctx, cronJobName, &cronJob)
 				if nil != err {
-					return types.PodTopController{}, fmt.Errorf("%w: %v", ownerErr, err)
+					return types.PodTopController{}, ownerError(pod, constant.KindCronJob, cronJobName, err)
 				}
 				return types.PodTopController{
 					Kind:      constant.KindCronJob,
@@ -159,12 +222,7 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 			}
 
 			logger.Sugar().Warnf("the controller type '%s' of pod '%s/%s' is unknown", jobOwner.Kind, pod.Namespace, pod.Name)
-			return types.PodTopController{
-				Kind:      constant.KindUnknown,
-				Namespace: job.Namespace,
-				Name:      jobOwner.Name,
-				UID:       jobOwner.UID,
-			}, nil
+			return pm.thirdPartyController(ctx, job.Namespace, jobOwner), nil
 		}
 		return types.PodTopController{
 			Kind:      constant.KindJob,
@@ -174,11 +232,49 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 			APP:       &job,
 		}, nil
 
+	case constant.KindReplicationController:
+		var replicationController corev1.ReplicationController
+		err := pm.client.Get(ctx, namespacedName, &replicationController)
+		if nil != err {
+			return types.PodTopController{}, ownerError(pod, constant.KindReplicationController, namespacedName, err)
+		}
+
+		replicationControllerOwner := metav1.GetControllerOf(&replicationController)
+		if replicationControllerOwner != nil {
+			if replicationControllerOwner.APIVersion == constant.DeploymentConfigAPIVersion && replicationControllerOwner.Kind == constant.KindDeploymentConfig {
+				deploymentConfig := &unstructured.Unstructured{}
+				deploymentConfig.SetAPIVersion(constant.DeploymentConfigAPIVersion)
+				deploymentConfig.SetKind(constant.KindDeploymentConfig)
+				deploymentConfigName := apitypes.NamespacedName{Namespace: replicationController.Namespace, Name: replicationControllerOwner.Name}
+				err = pm.client.Get(ctx, deploymentConfigName, deploymentConfig)
+				if nil != err {
+					return types.PodTopController{}, ownerError(pod, constant.KindDeploymentConfig, deploymentConfigName, err)
+				}
+				return types.PodTopController{
+					Kind:      constant.KindDeploymentConfig,
+					Namespace: deploymentConfig.GetNamespace(),
+					Name:      deploymentConfig.GetName(),
+					UID:       deploymentConfig.GetUID(),
+					APP:       deploymentConfig,
+				}, nil
+			}
+
+			logger.Sugar().Warnf("the controller type '%s' of pod '%s/%s' is unknown", replicationControllerOwner.Kind, pod.Namespace, pod.Name)
+			return pm.thirdPartyController(ctx, replicationController.Namespace, replicationControllerOwner), nil
+		}
+		return types.PodTopController{
+			Kind:      constant.KindReplicationController,
+			Namespace: replicationController.Namespace,
+			Name:      replicationController.Name,
+			UID:       replicationController.UID,
+			APP:       &replicationController,
+		}, nil
+
 	case constant.KindDaemonSet:
 		var daemonSet appsv1.DaemonSet
 		err := pm.client.Get(ctx, namespacedName, &daemonSet)
 		if nil != err {
-			return types.PodTopController{}, fmt.Errorf("%w: %v", ownerErr, err)
+			return types.PodTopController{}, ownerError(pod, constant.KindDaemonSet, namespacedName, err)
 		}
 		return types.PodTopController{
 			Kind:      constant.KindDaemonSet,
@@ -192,7 +288,7 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 		var statefulSet appsv1.StatefulSet
 		err := pm.client.Get(ctx, namespacedName, &statefulSet)
 		if nil != err {
-			return types.PodTopController{}, fmt.Errorf("%w: %v", ownerErr, err)
+			return types.PodTopController{}, ownerError(pod, constant.KindStatefulSet, namespacedName, err)
 		}
 		return types.PodTopController{
 			Kind:      constant.KindStatefulSet,
@@ -204,10 +300,44 @@ func (pm *podManager) GetPodTopController(ctx context.Context, pod *corev1.Pod)
 	}
 
 	logger.Sugar().Warnf("the controller type '%s' of pod '%s/%s' is unknown", podOwner.Kind, pod.Namespace, pod.Name)
-	return types.PodTopController{
+	return pm.thirdPartyController(ctx, pod.Namespace, podOwner), nil
+}
+
+// thirdPartyController returns the third party controller referred by the
+// owner reference, with its scale subresource if it could be got.
+func (pm *podManager) thirdPartyController(ctx context.Context, namespace string, owner *metav1.OwnerReference) types.PodTopController {
+	controller := types.PodTopController{
 		Kind:      constant.KindUnknown,
-		Namespace: pod.Namespace,
-		Name:      podOwner.Name,
-		UID:       podOwner.UID,
-	}, nil
+		Namespace: namespace,
+		Name:      owner.Name,
+		UID:       owner.UID,
+	}
+	if pm.options.Scales == nil {
+		return controller
+	}
+
+	scale, err := pm.options.Scales.GetScale(ctx, schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind), namespace, owner.Name)
+	if err != nil {
+		logger := logutils.FromContext(ctx)
+		logger.Sugar().Debugf("failed to get the scale subresource of %s %s/%s: %v", owner.Kind, namespace, owner.Name, err)
+		return controller
+	}
+	controller.Scale = scale
+
+	return controller
+}
+
+// ownerError wraps the error of getting the owner controller of the pod, it
+// is an OwnerNotFoundError if the owner does not exist.
+func ownerError(pod *corev1.Pod, kind string, owner apitypes.NamespacedName, err error) error {
+	if apierrors.IsNotFound(err) {
+		return &constant.OwnerNotFoundError{
+			Kind:      kind,
+			Namespace: owner.Namespace,
+			Name:      owner.Name,
+			Err:       err,
+		}
+	}
+
+	return fmt.Errorf("failed to get pod '%s/%s' owner %s '%s': %w", pod.Namespace, pod.Name, kind, owner, err)
 }
@@ -13,12 +13,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 )
 
 var scheme *runtime.Scheme
 var fakeClient client.Client
 var podManager podmanager.PodManager
+var podWebhook *podmanager.PodWebhook
 
 func TestPodManager(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -30,13 +32,24 @@ var _ = BeforeSuite(func() {
 	err := corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = spiderpoolv1.AddToScheme(scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	fakeClient = fake.NewClientBuilder().
 		WithScheme(scheme).
 		Build()
 
 	podManager, err = podmanager.NewPodManager(
-		podmanager.PodManagerConfig{MaxConflictRetries: 1},
+		podmanager.PodManagerConfig{},
+		fakeClient,
 		fakeClient,
 	)
 	Expect(err).NotTo(HaveOccurred())
+
+	podWebhook = &podmanager.PodWebhook{
+		Client:                fakeClient,
+		EnableExhaustionCheck: true,
+		EnableIPv4:            true,
+		EnableIPv6:            true,
+	}
 })
@@ -5,6 +5,7 @@ package podmanager_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync/atomic"
 
@@ -14,28 +15,48 @@ import (
 	kruiseapi "github.com/openkruise/kruise-api"
 	kruisev1 "github.com/openkruise/kruise-api/apps/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/podmanager"
 )
 
+type fakeScaleGetter struct {
+	scale *autoscalingv1.Scale
+	err   error
+}
+
+func (g fakeScaleGetter) GetScale(context.Context, schema.GroupVersionKind, string, string) (*autoscalingv1.Scale, error) {
+	return g.scale, g.err
+}
+
 var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 	Describe("New PodManager", func() {
 		It("sets default config", func() {
-			manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, fakeClient)
+			manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, fakeClient, fakeClient)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(manager).NotTo(BeNil())
 		})
 
 		It("inputs nil client", func() {
-			manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, nil)
+			manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, nil, fakeClient)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(manager).To(BeNil())
+		})
+
+		It("inputs nil API reader", func() {
+			manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, fakeClient, nil)
 			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			Expect(manager).To(BeNil())
 		})
@@ -87,7 +108,7 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 		Describe("GetPodByName", func() {
 			It("gets non-existent Pod", func() {
 				ctx := context.TODO()
-				pod, err := podManager.GetPodByName(ctx, namespace, podName)
+				pod, err := podManager.GetPodByName(ctx, namespace, podName, constant.IgnoreCache)
 				Expect(apierrors.IsNotFound(err)).To(BeTrue())
 				Expect(pod).To(BeNil())
 			})
@@ -97,12 +118,47 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 				err := fakeClient.Create(ctx, podT)
 				Expect(err).NotTo(HaveOccurred())
 
-				pod, err := podManager.GetPodByName(ctx, namespace, podName)
+				pod, err := podManager.GetPodByName(ctx, namespace, podName, constant.UseCache)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pod).NotTo(BeNil())
 
 				Expect(pod).To(Equal(podT))
 			})
+
+			It("gets the Pod not in the cache from the API server", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+
+				emptyCache := fake.NewClientBuilder().WithScheme(scheme).Build()
+				manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, fakeClient, fakeClient, manageroption.WithCache(emptyCache))
+				Expect(err).NotTo(HaveOccurred())
+
+				pod, err := manager.GetPodByName(ctx, namespace, podName, constant.UseCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod).To(Equal(podT))
+			})
+		})
+
+		Describe("MergeAnnotations", func() {
+			It("fails to annotate non-existent Pod", func() {
+				err := podManager.MergeAnnotations(context.TODO(), namespace, podName, map[string]string{"a": "b"})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("merges the annotations into the Pod", func() {
+				ctx := context.TODO()
+				podT.Annotations = map[string]string{"kept": "true", "updated": "old"}
+				err := fakeClient.Create(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = podManager.MergeAnnotations(ctx, namespace, podName, map[string]string{"updated": "new", "added": "true"})
+				Expect(err).NotTo(HaveOccurred())
+
+				pod, err := podManager.GetPodByName(ctx, namespace, podName, constant.IgnoreCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pod.Annotations).To(Equal(map[string]string{"kept": "true", "updated": "new", "added": "true"}))
+			})
 		})
 
 		Describe("ListPods", func() {
@@ -196,6 +252,31 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 			})
 		})
 
+		Describe("IteratePods", func() {
+			It("failed to list Pods due to some unknown errors", func() {
+				patches := gomonkey.ApplyMethodReturn(fakeClient, "List", constant.ErrUnknown)
+				defer patches.Reset()
+
+				ctx := context.TODO()
+				err := podManager.IteratePods(ctx, func(pod *corev1.Pod) error { return nil })
+				Expect(err).To(MatchError(constant.ErrUnknown))
+			})
+
+			It("iterates the Pods in the namespace", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+
+				var names []string
+				err = podManager.IteratePods(ctx, func(pod *corev1.Pod) error {
+					names = append(names, pod.Name)
+					return nil
+				}, client.InNamespace(namespace))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names).To(ConsistOf(podName))
+			})
+		})
+
 		Describe("GetPodTopController", func() {
 			It("Orphan Pod without any controllers", func() {
 				podTopController, err := podManager.GetPodTopController(ctx, podT)
@@ -213,6 +294,40 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 				podTopController, err := podManager.GetPodTopController(ctx, podT)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(podTopController.Kind).Should(Equal(constant.KindUnknown))
+				Expect(podTopController.Scale).To(BeNil())
+				_, known := podTopController.Replicas()
+				Expect(known).To(BeFalse())
+			})
+
+			It("Pod with third-party controller having the scale subresource", func() {
+				err := kruiseapi.AddToScheme(scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				cloneSet := &kruisev1.CloneSet{ObjectMeta: metav1.ObjectMeta{Name: "cloneset", Namespace: namespace}}
+				err = controllerutil.SetControllerReference(cloneSet, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				scales := fakeScaleGetter{scale: &autoscalingv1.Scale{
+					Spec:   autoscalingv1.ScaleSpec{Replicas: 3},
+					Status: autoscalingv1.ScaleStatus{Selector: "app=cloneset"},
+				}}
+				manager, err := podmanager.NewPodManager(podmanager.PodManagerConfig{}, fakeClient, fakeClient, manageroption.WithScales(scales))
+				Expect(err).NotTo(HaveOccurred())
+
+				podTopController, err := manager.GetPodTopController(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.Kind).Should(Equal(constant.KindUnknown))
+				replicas, known := podTopController.Replicas()
+				Expect(known).To(BeTrue())
+				Expect(replicas).To(Equal(3))
+				Expect(podTopController.Selector().MatchLabels).To(Equal(map[string]string{"app": "cloneset"}))
+
+				manager, err = podmanager.NewPodManager(podmanager.PodManagerConfig{}, fakeClient, fakeClient, manageroption.WithScales(fakeScaleGetter{err: constant.ErrUnknown}))
+				Expect(err).NotTo(HaveOccurred())
+
+				podTopController, err = manager.GetPodTopController(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.Scale).To(BeNil())
 			})
 
 			It("Pod with ReplicaSet controller", func() {
@@ -259,6 +374,29 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 				Expect(err).To(HaveOccurred())
 			})
 
+			It("ReplicaSet controller of Pod does not exist", func() {
+				err := appsv1.AddToScheme(scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				replicaSet := &appsv1.ReplicaSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName,
+						Namespace: namespace,
+					},
+				}
+				err = controllerutil.SetControllerReference(replicaSet, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = podManager.GetPodTopController(ctx, podT)
+				Expect(err).To(MatchError(constant.ErrOwnerNotFound))
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+				var ownerErr *constant.OwnerNotFoundError
+				Expect(errors.As(err, &ownerErr)).To(BeTrue())
+				Expect(ownerErr.Kind).To(Equal(constant.KindReplicaSet))
+				Expect(ownerErr.Name).To(Equal(podName))
+			})
+
 			It("Pod with Deployment controller", func() {
 				err := appsv1.AddToScheme(scheme)
 				Expect(err).NotTo(HaveOccurred())
@@ -572,6 +710,94 @@ var _ = Describe("PodManager", Label("pod_manager_test"), func() {
 				_, err = podManager.GetPodTopController(ctx, podT)
 				Expect(err).To(HaveOccurred())
 			})
+
+			It("Pod with ReplicationController controller", func() {
+				replicationController := &corev1.ReplicationController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName,
+						Namespace: namespace,
+					},
+					Spec: corev1.ReplicationControllerSpec{
+						Replicas: pointer.Int32(2),
+						Selector: labels,
+					},
+				}
+				err := fakeClient.Create(ctx, replicationController)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = controllerutil.SetControllerReference(replicationController, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				podTopController, err := podManager.GetPodTopController(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.Kind).Should(Equal(constant.KindReplicationController))
+				replicas, _ := podTopController.Replicas()
+				Expect(replicas).To(Equal(2))
+				Expect(podTopController.Selector().MatchLabels).To(Equal(labels))
+			})
+
+			It("Pod with DeploymentConfig controller", func() {
+				deploymentConfig := &unstructured.Unstructured{}
+				deploymentConfig.SetAPIVersion(constant.DeploymentConfigAPIVersion)
+				deploymentConfig.SetKind(constant.KindDeploymentConfig)
+				deploymentConfig.SetNamespace(namespace)
+				deploymentConfig.SetName(podName)
+				err := unstructured.SetNestedField(deploymentConfig.Object, int64(3), "spec", "replicas")
+				Expect(err).NotTo(HaveOccurred())
+				err = unstructured.SetNestedStringMap(deploymentConfig.Object, map[string]string{"foo": "bar"}, "spec", "template", "metadata", "annotations")
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, deploymentConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				replicationController := &corev1.ReplicationController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName + "-1",
+						Namespace: namespace,
+					},
+				}
+				err = controllerutil.SetControllerReference(deploymentConfig, replicationController, scheme)
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, replicationController)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = controllerutil.SetControllerReference(replicationController, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				podTopController, err := podManager.GetPodTopController(ctx, podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podTopController.Kind).Should(Equal(constant.KindDeploymentConfig))
+				Expect(podTopController.Name).Should(Equal(podName))
+				replicas, _ := podTopController.Replicas()
+				Expect(replicas).To(Equal(3))
+				Expect(podTopController.TemplateAnnotations()).To(Equal(map[string]string{"foo": "bar"}))
+			})
+
+			It("DeploymentConfig controller of Pod does not exist", func() {
+				deploymentConfig := &unstructured.Unstructured{}
+				deploymentConfig.SetAPIVersion(constant.DeploymentConfigAPIVersion)
+				deploymentConfig.SetKind(constant.KindDeploymentConfig)
+				deploymentConfig.SetNamespace(namespace)
+				deploymentConfig.SetName(podName)
+
+				replicationController := &corev1.ReplicationController{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName + "-1",
+						Namespace: namespace,
+					},
+				}
+				err := controllerutil.SetControllerReference(deploymentConfig, replicationController, scheme)
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, replicationController)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = controllerutil.SetControllerReference(replicationController, podT, scheme)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = podManager.GetPodTopController(ctx, podT)
+				var notFound *constant.OwnerNotFoundError
+				Expect(errors.As(err, &notFound)).To(BeTrue())
+				Expect(notFound.Kind).To(Equal(constant.KindDeploymentConfig))
+			})
 		})
 	})
 })
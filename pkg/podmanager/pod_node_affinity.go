@@ -0,0 +1,135 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// maxNodeSelectorTerms limits the required node selector terms of the Pod
+// after the injection, as the candidate groups are expanded into the
+// combinations of their IPPools.
+const maxNodeSelectorTerms = 32
+
+// injectNodeAffinity requires the Pod to be scheduled to the nodes labeled
+// with 'ippool.ipam.spidernet.io/<IPPool name>: "true"' by spiderpool-agent
+// for each of its candidate groups (IPPools of one NIC and one IP version),
+// so that the Pod stays pending instead of failing the CNI ADD requests on
+// the nodes where none of its candidate IPPools is usable. The groups with
+// IPPools which can't be advertised with labels are not required.
+func (pw *PodWebhook) injectNodeAffinity(ctx context.Context, pod *corev1.Pod) error {
+	logger := logutils.FromContext(ctx)
+
+	// The node affinity of the Pods bound to a node is also checked by kubelet.
+	if pod.Spec.HostNetwork || pod.Spec.NodeName != "" {
+		return nil
+	}
+
+	groups, err := pw.getPoolCandidateGroups(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	var requirements [][]corev1.NodeSelectorRequirement
+	for _, group := range groups {
+		alternatives, ok := nodeSelectorRequirementsOfPools(group)
+		if !ok {
+			logger.Sugar().Debugf("Skip to require the nodes of IPPools %v, some of them are not advertised", group)
+			continue
+		}
+		requirements = append(requirements, alternatives)
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	// The terms are ORed and the expressions of a term are ANDed, so the
+	// existing terms are expanded with each alternative of the groups.
+	terms := []corev1.NodeSelectorTerm{{}}
+	affinity := pod.Spec.Affinity
+	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		existing := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		if len(existing) != 0 {
+			terms = make([]corev1.NodeSelectorTerm, 0, len(existing))
+			for _, term := range existing {
+				terms = append(terms, *term.DeepCopy())
+			}
+		}
+	}
+
+	for _, alternatives := range requirements {
+		var expanded []corev1.NodeSelectorTerm
+		for _, term := range terms {
+			if hasNodeSelectorRequirement(term, alternatives) {
+				expanded = append(expanded, term)
+				continue
+			}
+			for _, r := range alternatives {
+				t := *term.DeepCopy()
+				t.MatchExpressions = append(t.MatchExpressions, r)
+				expanded = append(expanded, t)
+			}
+		}
+		if len(expanded) > maxNodeSelectorTerms {
+			return fmt.Errorf("too many node selector terms to require the nodes of IPPools, more than %d", maxNodeSelectorTerms)
+		}
+		terms = expanded
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+		NodeSelectorTerms: terms,
+	}
+	logger.Sugar().Debugf("Require the nodes of candidate IPPools %v", groups)
+
+	return nil
+}
+
+// nodeSelectorRequirementsOfPools returns the alternative requirements of the
+// nodes where any of the IPPools is usable, and false if any of the IPPools
+// can't be advertised with labels, e.g. the name is longer than 63
+// characters.
+func nodeSelectorRequirementsOfPools(pools []string) ([]corev1.NodeSelectorRequirement, bool) {
+	requirements := make([]corev1.NodeSelectorRequirement, 0, len(pools))
+	for _, pool := range pools {
+		key := constant.LabelNodeIPPoolPrefix + pool
+		if len(validation.IsQualifiedName(key)) != 0 {
+			return nil, false
+		}
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{"true"},
+		})
+	}
+
+	return requirements, true
+}
+
+// hasNodeSelectorRequirement checks whether the term already contains any
+// of the alternative requirements, e.g. the same IPPool of another NIC.
+func hasNodeSelectorRequirement(term corev1.NodeSelectorTerm, alternatives []corev1.NodeSelectorRequirement) bool {
+	for _, e := range term.MatchExpressions {
+		for _, r := range alternatives {
+			if reflect.DeepEqual(e, r) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
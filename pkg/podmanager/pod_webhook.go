@@ -0,0 +1,380 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/namespacemanager"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+var WebhookLogger *zap.Logger
+
+// PodWebhook rejects the creation of Pods with invalid Spiderpool
+// annotations, and the Pods whose candidate IPPools are all exhausted, so
+// that the Pods fail fast with the reason 'IPPoolExhausted' instead of being
+// stuck in the CNI ADD retries. It also requires the Pods to be scheduled to
+// the nodes where their candidate IPPools are usable, and injects the IP
+// addresses known before the allocation into their environment variables.
+type PodWebhook struct {
+	client.Client
+
+	EnableAnnotationValidation  bool
+	EnableExhaustionCheck       bool
+	EnableNodeAffinityInjection bool
+	EnableIPEnvInjection        bool
+
+	EnableIPv4               bool
+	EnableIPv6               bool
+	EnableSpiderSubnet       bool
+	ClusterDefaultIPv4IPPool []string
+	ClusterDefaultIPv6IPPool []string
+	ClusterDefaultIPv4Subnet []string
+	ClusterDefaultIPv6Subnet []string
+}
+
+func (pw *PodWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookLogger == nil {
+		WebhookLogger = logutils.Logger.Named("Pod-Webhook")
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&corev1.Pod{}).
+		WithDefaulter(recovery.Defaulter("Pod-Webhook", pw)).
+		WithValidator(recovery.Validator("Pod-Webhook", pw)).
+		Complete()
+}
+
+var _ webhook.CustomDefaulter = (*PodWebhook)(nil)
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type.
+func (pw *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	if !pw.EnableNodeAffinityInjection && !pw.EnableIPEnvInjection {
+		return nil
+	}
+
+	pod := obj.(*corev1.Pod)
+
+	logger := WebhookLogger.Named("Mutating").With(
+		zap.String("PodNamespace", pod.Namespace),
+		zap.String("PodName", podNameForLog(pod)),
+		zap.String("Operation", "CREATE"),
+	)
+
+	// Leave the Pod to IPAM rather than blocking its creation.
+	ctx = logutils.IntoContext(ctx, logger)
+	if pw.EnableNodeAffinityInjection {
+		if err := pw.injectNodeAffinity(ctx, pod); err != nil {
+			logger.Sugar().Warnf("Skip to require the nodes of candidate IPPools: %v", err)
+		}
+	}
+	if pw.EnableIPEnvInjection {
+		if err := pw.injectIPEnv(ctx, pod); err != nil {
+			logger.Sugar().Warnf("Skip to inject the IP addresses into environment variables: %v", err)
+		}
+	}
+
+	return nil
+}
+
+var _ webhook.CustomValidator = (*PodWebhook)(nil)
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (pw *PodWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	pod := obj.(*corev1.Pod)
+
+	logger := WebhookLogger.Named("Validating").With(
+		zap.String("PodNamespace", pod.Namespace),
+		zap.String("PodName", podNameForLog(pod)),
+		zap.String("Operation", "CREATE"),
+	)
+
+	ctx = logutils.IntoContext(ctx, logger)
+	if pw.EnableAnnotationValidation {
+		if errs := pw.validateAnnotations(ctx, pod); len(errs) != 0 {
+			logger.Sugar().Errorf("Reject Pod with invalid annotations: %v", errs.ToAggregate().Error())
+			return apierrors.NewInvalid(
+				schema.GroupKind{Kind: constant.KindPod},
+				podNameForLog(pod),
+				errs,
+			)
+		}
+	}
+
+	if !pw.EnableExhaustionCheck {
+		return nil
+	}
+
+	err := pw.checkPoolCandidates(ctx, pod)
+	if err == nil {
+		return nil
+	}
+
+	var exhaustedErr *constant.PoolExhaustedError
+	if !errors.As(err, &exhaustedErr) {
+		// Leave the errors to IPAM, which owns the final decision.
+		logger.Sugar().Warnf("Skip to check the headroom of IPPools: %v", err)
+		return nil
+	}
+
+	logger.Sugar().Infof("Reject Pod, all candidate IPPools %v are exhausted", exhaustedErr.Names)
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusForbidden,
+		Reason:  metav1.StatusReason(constant.ReasonIPPoolExhausted),
+		Message: fmt.Sprintf("%s: all candidate IPPools %v of Pod %s/%s are exhausted", constant.ReasonIPPoolExhausted, exhaustedErr.Names, pod.Namespace, podNameForLog(pod)),
+	}}
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (pw *PodWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (pw *PodWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+// checkPoolCandidates returns a PoolExhaustedError with the IPPools of the
+// first candidate group (IPPools of one NIC and one IP version) whose IPPools
+// are all exhausted.
+func (pw *PodWebhook) checkPoolCandidates(ctx context.Context, pod *corev1.Pod) error {
+	if pod.Spec.HostNetwork {
+		return nil
+	}
+
+	groups, err := pw.getPoolCandidateGroups(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		exhausted, err := pw.isPoolGroupExhausted(ctx, group, pod)
+		if err != nil {
+			return err
+		}
+		if exhausted {
+			return &constant.PoolExhaustedError{
+				Kind:  constant.SpiderIPPoolKind,
+				Names: group,
+			}
+		}
+	}
+
+	return nil
+}
+
+// getPoolCandidateGroups follows the precedence of IPAM to find the
+// candidate IPPools of the Pod. The IPPools specified in the CNI network
+// configuration are invisible here, nor are the auto-created IPPools of
+// SpiderSubnet whose IP addresses can be expanded.
+func (pw *PodWebhook) getPoolCandidateGroups(ctx context.Context, pod *corev1.Pod) ([][]string, error) {
+	annotations, err := annotation.ConvertPodConfig(pod.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := annotations[constant.AnnoSpiderSubnets]; ok {
+		return nil, nil
+	}
+	if _, ok := annotations[constant.AnnoSpiderSubnet]; ok {
+		return nil, nil
+	}
+
+	if anno, ok := annotations[constant.AnnoPodIPPools]; ok {
+		var annoPodIPPools types.AnnoPodIPPoolsValue
+		if err := json.Unmarshal([]byte(anno), &annoPodIPPools); err != nil {
+			return nil, fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodIPPools, err)
+		}
+
+		var groups [][]string
+		for _, item := range annoPodIPPools {
+			groups = append(groups, pw.groupsOfIPVersions(item.IPv4Pools, item.IPv6Pools)...)
+		}
+		return groups, nil
+	}
+
+	if anno, ok := annotations[constant.AnnoPodIPPool]; ok {
+		var annoPodIPPool types.AnnoPodIPPoolValue
+		if err := json.Unmarshal([]byte(anno), &annoPodIPPool); err != nil {
+			return nil, fmt.Errorf("%w, invalid format of Pod annotation '%s': %v", constant.ErrWrongInput, constant.AnnoPodIPPool, err)
+		}
+		return pw.groupsOfIPVersions(annoPodIPPool.IPv4Pools, annoPodIPPool.IPv6Pools), nil
+	}
+
+	var namespace corev1.Namespace
+	if err := pw.Get(ctx, apitypes.NamespacedName{Name: pod.Namespace}, &namespace); err != nil {
+		return nil, err
+	}
+
+	// the SpiderSubnet annotations of the application and the Namespace are
+	// inherited by the Pod
+	if pw.EnableSpiderSubnet {
+		appAnnotations, err := annotation.ConvertPodConfig(pw.appAnnotationsOf(ctx, pod))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := appAnnotations[constant.AnnoSpiderSubnets]; ok {
+			return nil, nil
+		}
+		if _, ok := appAnnotations[constant.AnnoSpiderSubnet]; ok {
+			return nil, nil
+		}
+		if _, ok := namespace.Annotations[constant.AnnoSpiderSubnets]; ok {
+			return nil, nil
+		}
+		if _, ok := namespace.Annotations[constant.AnnoSpiderSubnet]; ok {
+			return nil, nil
+		}
+		if len(pw.ClusterDefaultIPv4Subnet) != 0 || len(pw.ClusterDefaultIPv6Subnet) != 0 {
+			return nil, nil
+		}
+	}
+	nsDefaultV4Pools, nsDefaultV6Pools, err := namespacemanager.GetNSDefaultPools(&namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(nsDefaultV4Pools) != 0 || len(nsDefaultV6Pools) != 0 {
+		return pw.groupsOfIPVersions(nsDefaultV4Pools, nsDefaultV6Pools), nil
+	}
+
+	var ipPoolList spiderpoolv1.SpiderIPPoolList
+	if err := pw.List(ctx, &ipPoolList); err != nil {
+		return nil, err
+	}
+	v4Pools, v6Pools := defaultIPPoolsOf(ipPoolList.Items, pod.Namespace)
+	if len(v4Pools) != 0 || len(v6Pools) != 0 {
+		return pw.groupsOfIPVersions(v4Pools, v6Pools), nil
+	}
+
+	v4Pools, v6Pools = defaultIPPoolsOf(ipPoolList.Items, "")
+	return pw.groupsOfIPVersions(
+		appendMissingPools(pw.ClusterDefaultIPv4IPPool, v4Pools),
+		appendMissingPools(pw.ClusterDefaultIPv6IPPool, v6Pools),
+	), nil
+}
+
+// defaultIPPoolsOf returns the names of the IPPools which declare themselves
+// the default of namespace, or of the whole cluster if namespace is empty,
+// sorted by their names in each IP version.
+func defaultIPPoolsOf(ipPools []spiderpoolv1.SpiderIPPool, namespace string) (v4Pools, v6Pools []string) {
+	for i := range ipPools {
+		pool := &ipPools[i]
+		if pool.DeletionTimestamp != nil || pool.Spec.IPVersion == nil || !ippoolmanager.IsDefaultIPPoolOf(pool, namespace) {
+			continue
+		}
+		switch *pool.Spec.IPVersion {
+		case constant.IPv4:
+			v4Pools = append(v4Pools, pool.Name)
+		case constant.IPv6:
+			v6Pools = append(v6Pools, pool.Name)
+		}
+	}
+	sort.Strings(v4Pools)
+	sort.Strings(v6Pools)
+
+	return v4Pools, v6Pools
+}
+
+// appendMissingPools appends the IPPools of extra which are not in pools, to
+// a copy of pools.
+func appendMissingPools(pools, extra []string) []string {
+	existing := sets.NewString(pools...)
+	merged := append([]string{}, pools...)
+	for _, pool := range extra {
+		if !existing.Has(pool) {
+			merged = append(merged, pool)
+		}
+	}
+
+	return merged
+}
+
+// appAnnotationsOf returns the annotations of the application controlling
+// the Pod, nil if there is none or it could not be got.
+func (pw *PodWebhook) appAnnotationsOf(ctx context.Context, pod *corev1.Pod) map[string]string {
+	if metav1.GetControllerOf(pod) == nil {
+		return nil
+	}
+
+	pm := &podManager{client: pw.Client}
+	podController, err := pm.GetPodTopController(ctx, pod)
+	if err != nil {
+		logutils.FromContext(ctx).Sugar().Debugf("failed to get the application of Pod %s/%s: %v", pod.Namespace, podNameForLog(pod), err)
+		return nil
+	}
+	if podController.APP == nil {
+		return nil
+	}
+
+	return podController.APP.GetAnnotations()
+}
+
+func (pw *PodWebhook) groupsOfIPVersions(v4Pools, v6Pools []string) [][]string {
+	var groups [][]string
+	if pw.EnableIPv4 && len(v4Pools) != 0 {
+		groups = append(groups, v4Pools)
+	}
+	if pw.EnableIPv6 && len(v6Pools) != 0 {
+		groups = append(groups, v6Pools)
+	}
+
+	return groups
+}
+
+// isPoolGroupExhausted checks whether all the IPPools of the group have no
+// headroom for the Pod. The IPPools which do not exist or whose status has not been
+// calculated yet are considered as unexhausted, IPAM will report them.
+func (pw *PodWebhook) isPoolGroupExhausted(ctx context.Context, pools []string, pod *corev1.Pod) (bool, error) {
+	for _, poolName := range pools {
+		var pool spiderpoolv1.SpiderIPPool
+		if err := pw.Get(ctx, apitypes.NamespacedName{Name: poolName}, &pool); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		headroom, ok := ippoolmanager.IPPoolHeadroomOfPod(&pool, pod)
+		if !ok || headroom > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func podNameForLog(pod *corev1.Pod) string {
+	if pod.Name != "" {
+		return pod.Name
+	}
+
+	return strings.TrimSuffix(pod.GenerateName, "-") + "-<generated>"
+}
@@ -0,0 +1,612 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/podmanager"
+)
+
+var _ = Describe("PodWebhook", Label("pod_webhook_test"), func() {
+	BeforeEach(func() {
+		podmanager.WebhookLogger = logutils.Logger.Named("Pod-Webhook")
+	})
+
+	Describe("ValidateCreate", func() {
+		var count uint64
+		var namespace string
+		var poolName string
+		var podT *corev1.Pod
+		var ipPoolT *spiderpoolv1.SpiderIPPool
+		var namespaceT *corev1.Namespace
+
+		BeforeEach(func() {
+			atomic.AddUint64(&count, 1)
+			namespace = fmt.Sprintf("ns-%v", count)
+			poolName = fmt.Sprintf("ippool-%v", count)
+
+			namespaceT = &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			}
+			podT = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("pod-%v", count),
+					Namespace: namespace,
+					Annotations: map[string]string{
+						constant.AnnoPodIPPool: fmt.Sprintf(`{"ipv4": ["%s"]}`, poolName),
+					},
+				},
+			}
+			ipPoolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.10"},
+				},
+				Status: spiderpoolv1.IPPoolStatus{
+					TotalIPCount: pointer.Int64(1),
+				},
+			}
+		})
+
+		AfterEach(func() {
+			ctx := context.TODO()
+			err := fakeClient.Delete(ctx, ipPoolT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+
+			err = fakeClient.Delete(ctx, namespaceT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+
+		It("ignores the Pod with host network", func() {
+			podT.Spec.HostNetwork = true
+
+			ctx := context.TODO()
+			err := podWebhook.ValidateCreate(ctx, podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("ignores the Pod using SpiderSubnet", func() {
+			podT.Annotations = map[string]string{constant.AnnoSpiderSubnet: `{"ipv4": ["subnet"]}`}
+
+			ctx := context.TODO()
+			err := podWebhook.ValidateCreate(ctx, podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("leaves invalid annotations to IPAM", func() {
+			podT.Annotations[constant.AnnoPodIPPool] = "invalid"
+
+			ctx := context.TODO()
+			err := podWebhook.ValidateCreate(ctx, podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("leaves non-existent IPPools to IPAM", func() {
+			ctx := context.TODO()
+			err := podWebhook.ValidateCreate(ctx, podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("admits the Pod if the IPPool still has free IP addresses", func() {
+			ctx := context.TODO()
+			err := fakeClient.Create(ctx, ipPoolT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = podWebhook.ValidateCreate(ctx, podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the Pod if all candidate IPPools are exhausted", func() {
+			ipPoolT.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+				"172.18.40.10": spiderpoolv1.PoolIPAllocation{},
+			}
+
+			ctx := context.TODO()
+			err := fakeClient.Create(ctx, ipPoolT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = podWebhook.ValidateCreate(ctx, podT)
+			Expect(apierrors.ReasonForError(err)).To(Equal(metav1.StatusReason(constant.ReasonIPPoolExhausted)))
+		})
+
+		It("rejects the Pod if the namespace default IPPools are exhausted", func() {
+			podT.Annotations = nil
+			namespaceT.Annotations = map[string]string{
+				constant.AnnoNSDefautlV4Pool: fmt.Sprintf(`["%s"]`, poolName),
+			}
+			ipPoolT.Status.EgressIPs = spiderpoolv1.PoolEgressIPReservations{
+				"172.18.40.10": spiderpoolv1.EgressIPReservation{Kind: "EgressGateway", Name: "egress"},
+			}
+
+			ctx := context.TODO()
+			err := fakeClient.Create(ctx, namespaceT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fakeClient.Create(ctx, ipPoolT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = podWebhook.ValidateCreate(ctx, podT)
+			Expect(apierrors.ReasonForError(err)).To(Equal(metav1.StatusReason(constant.ReasonIPPoolExhausted)))
+		})
+	})
+
+	Describe("ValidateCreate with annotation validation", func() {
+		var count uint64
+		var poolName string
+		var webhook *podmanager.PodWebhook
+		var podT *corev1.Pod
+		var ipPoolT *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			atomic.AddUint64(&count, 1)
+			poolName = fmt.Sprintf("anno-ippool-%v", count)
+
+			webhook = &podmanager.PodWebhook{
+				Client:                     fakeClient,
+				EnableAnnotationValidation: true,
+				EnableIPv4:                 true,
+			}
+			podT = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("anno-pod-%v", count),
+					Namespace: "default",
+					Annotations: map[string]string{
+						constant.AnnoPodIPPool: fmt.Sprintf(`{"ipv4": ["%s"]}`, poolName),
+					},
+				},
+			}
+			ipPoolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: poolName},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.10"},
+				},
+			}
+
+			err := fakeClient.Create(context.TODO(), ipPoolT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			err := fakeClient.Delete(context.TODO(), ipPoolT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+
+		It("admits the Pod with valid annotations", func() {
+			podT.Annotations[constant.AnnoPodRoutes] = `[{"dst": "10.0.0.0/16", "gw": "172.18.40.1"}]`
+
+			err := webhook.ValidateCreate(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("admits the Pod with the routes scoped to the requested interface", func() {
+			podT.Annotations = map[string]string{
+				constant.AnnoPodIPPools: fmt.Sprintf(`[{"interface": "eth0", "ipv4": ["%s"]}]`, poolName),
+				constant.AnnoPodRoutes:  `[{"interface": "eth0", "dst": "10.0.0.0/16", "gw": "172.18.40.1"}]`,
+			}
+
+			err := webhook.ValidateCreate(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("admits the Pod with valid config", func() {
+			podT.Annotations = map[string]string{
+				constant.AnnoPodConfig: fmt.Sprintf(`{"interfaces": [{"interface": "eth0", "ipv4Pools": ["%s"]}]}`, poolName),
+				constant.AnnoPodIPPool: "ignored",
+			}
+
+			err := webhook.ValidateCreate(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("ignores the Pod with host network", func() {
+			podT.Spec.HostNetwork = true
+			podT.Annotations[constant.AnnoPodIPPool] = "invalid"
+
+			err := webhook.ValidateCreate(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the errors of the converted annotations on the config", func() {
+			podT.Annotations = map[string]string{
+				constant.AnnoPodConfig: `{"interfaces": [{"ipv4Pools": ["non-existent"]}]}`,
+			}
+
+			err := webhook.ValidateCreate(context.TODO(), podT)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(constant.AnnoPodConfig))
+			Expect(err.Error()).To(ContainSubstring("non-existent"))
+		})
+
+		DescribeTable("rejects the Pod with invalid annotations",
+			func(annotations map[string]string, enableSpiderSubnet bool) {
+				webhook.EnableSpiderSubnet = enableSpiderSubnet
+				podT.Annotations = map[string]string{}
+				for k, v := range annotations {
+					podT.Annotations[k] = strings.ReplaceAll(v, "<pool>", poolName)
+				}
+
+				err := webhook.ValidateCreate(context.TODO(), podT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			},
+			Entry("malformed IPPool annotation", map[string]string{constant.AnnoPodIPPool: "invalid"}, false),
+			Entry("non-existent IPPool", map[string]string{constant.AnnoPodIPPool: `{"ipv4": ["non-existent"]}`}, false),
+			Entry("IPPool of wrong IP version", map[string]string{constant.AnnoPodIPPool: `{"ipv4": ["<pool>"], "ipv6": ["<pool>"]}`}, false),
+			Entry("no IPPool of enabled IP version", map[string]string{constant.AnnoPodIPPool: `{"ipv6": ["<pool>"]}`}, false),
+			Entry("duplicate IPPools", map[string]string{constant.AnnoPodIPPool: `{"ipv4": ["<pool>", "<pool>"]}`}, false),
+			Entry("IPPools without interface", map[string]string{constant.AnnoPodIPPools: `[{"ipv4": ["<pool>"]}]`}, false),
+			Entry("duplicate interfaces", map[string]string{constant.AnnoPodIPPools: `[{"interface": "eth0", "ipv4": ["<pool>"]}, {"interface": "eth0", "ipv4": ["<pool>"]}]`}, false),
+			Entry("IPPool annotation ignored with IPPools annotation", map[string]string{
+				constant.AnnoPodIPPools: `[{"interface": "eth0", "ipv4": ["<pool>"]}]`,
+				constant.AnnoPodIPPool:  `{"ipv4": ["<pool>"]}`,
+			}, false),
+			Entry("invalid routes", map[string]string{
+				constant.AnnoPodIPPool: `{"ipv4": ["<pool>"]}`,
+				constant.AnnoPodRoutes: `[{"dst": "10.0.0.0/16", "gw": "invalid"}]`,
+			}, false),
+			Entry("routes scoped to the interface not requested", map[string]string{
+				constant.AnnoPodIPPools: `[{"interface": "eth0", "ipv4": ["<pool>"]}]`,
+				constant.AnnoPodRoutes:  `[{"interface": "net1", "dst": "10.0.0.0/16", "gw": "172.18.40.1"}]`,
+			}, false),
+			Entry("invalid MAC addresses", map[string]string{
+				constant.AnnoPodIPPool: `{"ipv4": ["<pool>"]}`,
+				constant.AnnoPodMACs:   `{"eth0": "invalid"}`,
+			}, false),
+			Entry("invalid hostPort SNAT", map[string]string{
+				constant.AnnoPodIPPool:       `{"ipv4": ["<pool>"]}`,
+				constant.AnnoPodHostPortSNAT: "invalid",
+			}, false),
+			Entry("invalid default route policy", map[string]string{
+				constant.AnnoPodIPPool:          `{"ipv4": ["<pool>"]}`,
+				constant.AnnoDefaultRoutePolicy: "last-nic",
+			}, false),
+			Entry("empty default route NIC", map[string]string{
+				constant.AnnoPodIPPool:          `{"ipv4": ["<pool>"]}`,
+				constant.AnnoPodDefaultRouteNIC: "",
+			}, false),
+			Entry("invalid IP family order", map[string]string{
+				constant.AnnoPodIPPool:        `{"ipv4": ["<pool>"]}`,
+				constant.AnnoPodIPFamilyOrder: "ipv6first",
+			}, false),
+			Entry("invalid bandwidth", map[string]string{
+				constant.AnnoPodIPPool:    `{"ipv4": ["<pool>"]}`,
+				constant.AnnoPodBandwidth: `{"ingressRate": "0"}`,
+			}, false),
+			Entry("SpiderSubnet with feature disabled", map[string]string{constant.AnnoSpiderSubnet: `{"ipv4": ["subnet"]}`}, false),
+			Entry("non-existent SpiderSubnet", map[string]string{constant.AnnoSpiderSubnet: `{"ipv4": ["subnet"]}`}, true),
+			Entry("IPPools ignored with SpiderSubnet", map[string]string{
+				constant.AnnoSpiderSubnet: `{"ipv4": ["subnet"]}`,
+				constant.AnnoPodIPPool:    `{"ipv4": ["<pool>"]}`,
+			}, true),
+			Entry("invalid IPPool IP number", map[string]string{
+				constant.AnnoSpiderSubnet:             `{"ipv4": ["subnet"]}`,
+				constant.AnnoSpiderSubnetPoolIPNumber: "invalid",
+			}, true),
+			Entry("invalid config", map[string]string{constant.AnnoPodConfig: `{"unknown": true}`}, false),
+		)
+
+		Describe("with paired IPv4 and IPv6 SpiderSubnets", func() {
+			var recorder *record.FakeRecorder
+			var v4SubnetT, v6SubnetT *spiderpoolv1.SpiderSubnet
+
+			BeforeEach(func() {
+				recorder = record.NewFakeRecorder(2)
+				event.EventRecorder = recorder
+				DeferCleanup(func() {
+					event.EventRecorder = record.NewFakeRecorder(event.FakeRecorderBufferSize)
+				})
+
+				webhook.EnableSpiderSubnet = true
+				v4SubnetT = &spiderpoolv1.SpiderSubnet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   fmt.Sprintf("anno-v4-subnet-%v", count),
+						Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"},
+					},
+					Spec: spiderpoolv1.SubnetSpec{
+						IPVersion: pointer.Int64(constant.IPv4),
+						Subnet:    "172.18.40.0/24",
+						Vlan:      pointer.Int64(100),
+					},
+				}
+				v6SubnetT = &spiderpoolv1.SpiderSubnet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   fmt.Sprintf("anno-v6-subnet-%v", count),
+						Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"},
+					},
+					Spec: spiderpoolv1.SubnetSpec{
+						IPVersion: pointer.Int64(constant.IPv6),
+						Subnet:    "abcd:1234::/120",
+						Vlan:      pointer.Int64(100),
+					},
+				}
+				podT.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       constant.KindReplicaSet,
+					Name:       "anno-replicaset",
+					UID:        "anno-replicaset-uid",
+					Controller: pointer.Bool(true),
+				}}
+				podT.Annotations = map[string]string{
+					constant.AnnoSpiderSubnet: fmt.Sprintf(`{"ipv4": ["%s"], "ipv6": ["%s"]}`, v4SubnetT.Name, v6SubnetT.Name),
+				}
+			})
+
+			JustBeforeEach(func() {
+				for _, subnet := range []*spiderpoolv1.SpiderSubnet{v4SubnetT, v6SubnetT} {
+					err := fakeClient.Create(context.TODO(), subnet)
+					Expect(err).NotTo(HaveOccurred())
+					DeferCleanup(fakeClient.Delete, context.TODO(), subnet)
+				}
+			})
+
+			It("admits the Pod silently if the SpiderSubnets are compatible", func() {
+				err := webhook.ValidateCreate(context.TODO(), podT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recorder.Events).NotTo(Receive())
+			})
+
+			When("the SpiderSubnets are in different VLANs", func() {
+				BeforeEach(func() {
+					v6SubnetT.Spec.Vlan = pointer.Int64(200)
+				})
+
+				It("admits the Pod with a warning on its controller", func() {
+					err := webhook.ValidateCreate(context.TODO(), podT)
+					Expect(err).NotTo(HaveOccurred())
+
+					var e string
+					Expect(recorder.Events).To(Receive(&e))
+					Expect(e).To(ContainSubstring(constant.EventReasonSubnetPairMismatch))
+					Expect(e).To(ContainSubstring("VLANs 100 and 200"))
+				})
+			})
+
+			When("the SpiderSubnets are in different zones", func() {
+				BeforeEach(func() {
+					delete(v6SubnetT.Labels, corev1.LabelTopologyZone)
+				})
+
+				It("admits the Pod with a warning", func() {
+					podT.OwnerReferences = nil
+
+					err := webhook.ValidateCreate(context.TODO(), podT)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(recorder.Events).To(Receive(ContainSubstring("zones 'zone-a' and ''")))
+				})
+			})
+		})
+	})
+
+	Describe("Default", func() {
+		var webhook *podmanager.PodWebhook
+		var podT *corev1.Pod
+
+		requirement := func(pool string) corev1.NodeSelectorRequirement {
+			return corev1.NodeSelectorRequirement{
+				Key:      constant.LabelNodeIPPoolPrefix + pool,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{"true"},
+			}
+		}
+
+		BeforeEach(func() {
+			webhook = &podmanager.PodWebhook{
+				Client:                      fakeClient,
+				EnableNodeAffinityInjection: true,
+				EnableIPv4:                  true,
+				EnableIPv6:                  true,
+			}
+			podT = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "affinity-pod",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constant.AnnoPodIPPool: `{"ipv4": ["v4-pool1", "v4-pool2"], "ipv6": ["v6-pool"]}`,
+					},
+				},
+			}
+		})
+
+		It("does nothing if the injection is disabled", func() {
+			webhook.EnableNodeAffinityInjection = false
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity).To(BeNil())
+		})
+
+		It("ignores the Pod bound to a node", func() {
+			podT.Spec.NodeName = "node1"
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity).To(BeNil())
+		})
+
+		It("ignores the Pod using SpiderSubnet", func() {
+			podT.Annotations = map[string]string{constant.AnnoSpiderSubnet: `{"ipv4": ["subnet"]}`}
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity).To(BeNil())
+		})
+
+		It("admits the Pod with invalid annotations as it is", func() {
+			podT.Annotations[constant.AnnoPodIPPool] = "invalid"
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity).To(BeNil())
+		})
+
+		It("requires the nodes of any IPPool of each IP version", func() {
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("v4-pool1"), requirement("v6-pool")}},
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("v4-pool2"), requirement("v6-pool")}},
+			))
+		})
+
+		It("keeps the existing node affinity of the Pod", func() {
+			zone := corev1.NodeSelectorRequirement{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+			podT.Annotations[constant.AnnoPodIPPool] = `{"ipv4": ["v4-pool1"]}`
+			podT.Spec.Affinity = &corev1.Affinity{
+				NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{zone}}},
+					},
+				},
+			}
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{zone, requirement("v4-pool1")}},
+			))
+		})
+
+		It("skips the IPPools which are not advertised", func() {
+			podT.Annotations[constant.AnnoPodIPPool] = fmt.Sprintf(`{"ipv4": ["%s"], "ipv6": ["v6-pool"]}`, strings.Repeat("a", 64))
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(ConsistOf(
+				corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{requirement("v6-pool")}},
+			))
+		})
+	})
+
+	Describe("Default with IP environment injection", func() {
+		var webhook *podmanager.PodWebhook
+		var podT *corev1.Pod
+		var singlePoolT, stsPoolT *spiderpoolv1.SpiderIPPool
+
+		BeforeEach(func() {
+			webhook = &podmanager.PodWebhook{
+				Client:               fakeClient,
+				EnableIPEnvInjection: true,
+				EnableIPv4:           true,
+				EnableIPv6:           true,
+			}
+			podT = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sts-1",
+					Namespace: "default",
+					Annotations: map[string]string{
+						constant.AnnoPodIPPools: `[{"interface": "eth0", "ipv4": ["env-single-pool"]}, {"interface": "net1", "ipv4": ["env-sts-pool"], "ipv6": ["v6-pool1", "v6-pool2"]}]`,
+					},
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1",
+						Kind:       constant.KindStatefulSet,
+						Name:       "sts",
+						UID:        "sts-uid",
+						Controller: pointer.Bool(true),
+					}},
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: "init"}},
+					Containers: []corev1.Container{{
+						Name: "app",
+						Env:  []corev1.EnvVar{{Name: "SPIDERPOOL_ETH0_IPV4", Value: "custom"}},
+					}},
+				},
+			}
+			singlePoolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "env-single-pool"},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.10"},
+				},
+			}
+			stsPoolT = &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "env-sts-pool",
+					Annotations: map[string]string{constant.AnnoIPPoolStatefulSetOrdinalIP: constant.True},
+				},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.41.0/24",
+					IPs:       []string{"172.18.41.10-172.18.41.20"},
+				},
+			}
+
+			ctx := context.TODO()
+			Expect(fakeClient.Create(ctx, singlePoolT)).To(Succeed())
+			Expect(fakeClient.Create(ctx, stsPoolT)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(client.IgnoreNotFound(fakeClient.Delete(ctx, singlePoolT))).To(Succeed())
+				Expect(client.IgnoreNotFound(fakeClient.Delete(ctx, stsPoolT))).To(Succeed())
+			})
+		})
+
+		It("does nothing if the injection is disabled", func() {
+			webhook.EnableIPEnvInjection = false
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.InitContainers[0].Env).To(BeEmpty())
+		})
+
+		It("ignores the Pod with host network", func() {
+			podT.Spec.HostNetwork = true
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.InitContainers[0].Env).To(BeEmpty())
+		})
+
+		It("injects the IP addresses known before the allocation", func() {
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.InitContainers[0].Env).To(ConsistOf(
+				corev1.EnvVar{Name: "SPIDERPOOL_ETH0_IPV4", Value: "172.18.40.10"},
+				corev1.EnvVar{Name: "SPIDERPOOL_NET1_IPV4", Value: "172.18.41.11"},
+			))
+			Expect(podT.Spec.Containers[0].Env).To(ConsistOf(
+				corev1.EnvVar{Name: "SPIDERPOOL_ETH0_IPV4", Value: "custom"},
+				corev1.EnvVar{Name: "SPIDERPOOL_NET1_IPV4", Value: "172.18.41.11"},
+			))
+		})
+
+		It("injects the IP address of the default NIC", func() {
+			podT.Annotations = map[string]string{constant.AnnoPodIPPool: `{"ipv4": ["env-single-pool"]}`}
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.InitContainers[0].Env).To(ConsistOf(
+				corev1.EnvVar{Name: "SPIDERPOOL_ETH0_IPV4", Value: "172.18.40.10"},
+			))
+		})
+
+		It("admits the Pod as it is if the IPPool does not exist", func() {
+			podT.Annotations = map[string]string{constant.AnnoPodIPPool: `{"ipv4": ["non-existent-pool"]}`}
+
+			err := webhook.Default(context.TODO(), podT)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podT.Spec.InitContainers[0].Env).To(BeEmpty())
+		})
+	})
+})
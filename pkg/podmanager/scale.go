@@ -0,0 +1,51 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package podmanager
+
+import (
+	"context"
+	"fmt"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+)
+
+type scaleGetter struct {
+	mapper        meta.RESTMapper
+	dynamicClient dynamic.Interface
+}
+
+// NewScaleGetter returns the getter of the scale subresources of any kind
+// of objects, the resources of the kinds are resolved with mapper.
+func NewScaleGetter(mapper meta.RESTMapper, dynamicClient dynamic.Interface) manageroption.ScaleGetter {
+	return &scaleGetter{
+		mapper:        mapper,
+		dynamicClient: dynamicClient,
+	}
+}
+
+func (s *scaleGetter) GetScale(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*autoscalingv1.Scale, error) {
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the resource of %s: %w", gvk, err)
+	}
+
+	obj, err := s.dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{}, "scale")
+	if err != nil {
+		return nil, err
+	}
+
+	var scale autoscalingv1.Scale
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &scale); err != nil {
+		return nil, fmt.Errorf("failed to convert the scale of %s %s/%s: %w", gvk.Kind, namespace, name, err)
+	}
+
+	return &scale, nil
+}
@@ -42,3 +42,15 @@ func CheckPodStatus(pod *corev1.Pod) (status types.PodStatus, allocatable bool)
 
 	return constant.PodRunning, true
 }
+
+// IsHostNetworkPod tells whether the Pod runs in host network, which never
+// gets IP addresses from Spiderpool.
+func IsHostNetworkPod(pod *corev1.Pod) bool {
+	return pod != nil && pod.Spec.HostNetwork
+}
+
+// IsCompletedPod tells whether the Pod is Succeeded or Failed, whose
+// containers never run again.
+func IsCompletedPod(pod *corev1.Pod) bool {
+	return pod != nil && (pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed)
+}
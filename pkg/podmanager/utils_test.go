@@ -92,4 +92,34 @@ var _ = Describe("PodManager utils", Label("pod_manager_utils_test"), func() {
 			Expect(allocatable).To(BeTrue())
 		})
 	})
+
+	Describe("Test IsHostNetworkPod", func() {
+		It("inputs nil Pod", func() {
+			Expect(podmanager.IsHostNetworkPod(nil)).To(BeFalse())
+		})
+
+		It("checks the Pod in host network", func() {
+			Expect(podmanager.IsHostNetworkPod(podT)).To(BeFalse())
+
+			podT.Spec.HostNetwork = true
+			Expect(podmanager.IsHostNetworkPod(podT)).To(BeTrue())
+		})
+	})
+
+	Describe("Test IsCompletedPod", func() {
+		It("inputs nil Pod", func() {
+			Expect(podmanager.IsCompletedPod(nil)).To(BeFalse())
+		})
+
+		It("checks the phases of Pod", func() {
+			podT.Status.Phase = corev1.PodRunning
+			Expect(podmanager.IsCompletedPod(podT)).To(BeFalse())
+
+			podT.Status.Phase = corev1.PodSucceeded
+			Expect(podmanager.IsCompletedPod(podT)).To(BeTrue())
+
+			podT.Status.Phase = corev1.PodFailed
+			Expect(podmanager.IsCompletedPod(podT)).To(BeTrue())
+		})
+	})
 })
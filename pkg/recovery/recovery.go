@@ -0,0 +1,99 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package recovery keeps spiderpool-controller and spiderpool-agent alive
+// on the panics of the handlers of a single object, e.g. a malformed CR,
+// which would otherwise crash-loop the whole process.
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+// Recover recovers the panic of the handler into err, it must be deferred
+// directly by the handler. The panic is logged with its stack, counted by
+// the metric panic_recovered_counts, and reported by a Warning event of obj
+// if obj is not nil and exists in the cluster.
+func Recover(ctx context.Context, handler string, obj runtime.Object, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	logutils.FromContext(ctx).Error(
+		fmt.Sprintf("Recovered from panic in %s: %v", handler, r),
+		zap.String("stack", string(debug.Stack())),
+	)
+	metric.RecordPanic(ctx, handler)
+	if obj != nil {
+		if accessor, e := apimeta.Accessor(obj); e == nil && accessor.GetUID() != "" {
+			event.EventRecorder.Eventf(obj, corev1.EventTypeWarning, constant.EventReasonPanic,
+				"Recovered from panic in %s: %v", handler, r)
+		}
+	}
+
+	if err != nil {
+		*err = fmt.Errorf("%w in %s: %v", constant.ErrPanic, handler, r)
+	}
+}
+
+// Call calls fn and recovers its panic into the returned error, see Recover.
+func Call(ctx context.Context, handler string, obj runtime.Object, fn func() error) (err error) {
+	defer Recover(ctx, handler, obj, &err)
+	return fn()
+}
+
+// Defaulter wraps the webhook.CustomDefaulter to deny the requests it
+// panics on, rather than crashing the webhook server.
+func Defaulter(handler string, defaulter webhook.CustomDefaulter) webhook.CustomDefaulter {
+	return &recoveredDefaulter{handler: handler, defaulter: defaulter}
+}
+
+type recoveredDefaulter struct {
+	handler   string
+	defaulter webhook.CustomDefaulter
+}
+
+func (d *recoveredDefaulter) Default(ctx context.Context, obj runtime.Object) (err error) {
+	defer Recover(ctx, d.handler, obj, &err)
+	return d.defaulter.Default(ctx, obj)
+}
+
+// Validator wraps the webhook.CustomValidator to deny the requests it
+// panics on, rather than crashing the webhook server.
+func Validator(handler string, validator webhook.CustomValidator) webhook.CustomValidator {
+	return &recoveredValidator{handler: handler, validator: validator}
+}
+
+type recoveredValidator struct {
+	handler   string
+	validator webhook.CustomValidator
+}
+
+func (v *recoveredValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (err error) {
+	defer Recover(ctx, v.handler, obj, &err)
+	return v.validator.ValidateCreate(ctx, obj)
+}
+
+func (v *recoveredValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (err error) {
+	defer Recover(ctx, v.handler, newObj, &err)
+	return v.validator.ValidateUpdate(ctx, oldObj, newObj)
+}
+
+func (v *recoveredValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (err error) {
+	defer Recover(ctx, v.handler, obj, &err)
+	return v.validator.ValidateDelete(ctx, obj)
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package recovery_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+type panicWebhook struct{}
+
+func (panicWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	panic("default")
+}
+
+func (panicWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	panic("create")
+}
+
+func (panicWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return errors.New("invalid")
+}
+
+func (panicWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+var _ = Describe("Recovery", Label("recovery_test"), func() {
+	var ctx context.Context
+	var recorder *record.FakeRecorder
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		recorder = record.NewFakeRecorder(10)
+		eventRecorder := event.EventRecorder
+		event.EventRecorder = recorder
+		DeferCleanup(func() {
+			event.EventRecorder = eventRecorder
+		})
+	})
+
+	Describe("Call", func() {
+		It("returns the error of the function", func() {
+			err := recovery.Call(ctx, "test", nil, func() error { return constant.ErrWrongInput })
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("recovers the panic into the error", func() {
+			err := recovery.Call(ctx, "test", nil, func() error { panic("boom") })
+			Expect(err).To(MatchError(constant.ErrPanic))
+			Expect(err.Error()).To(ContainSubstring("boom"))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("reports the panic by an event of the existing object", func() {
+			pool := &spiderpoolv1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool", UID: "uid"}}
+			err := recovery.Call(ctx, "test", pool, func() error { panic("boom") })
+			Expect(err).To(MatchError(constant.ErrPanic))
+			Expect(recorder.Events).To(Receive(ContainSubstring(constant.EventReasonPanic)))
+		})
+
+		It("doesn't report the panic by an event of the object not created yet", func() {
+			pool := &spiderpoolv1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			err := recovery.Call(ctx, "test", pool, func() error { panic("boom") })
+			Expect(err).To(MatchError(constant.ErrPanic))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Describe("Webhooks", func() {
+		pool := &spiderpoolv1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+
+		It("recovers the panic of the defaulter", func() {
+			err := recovery.Defaulter("test", panicWebhook{}).Default(ctx, pool)
+			Expect(err).To(MatchError(constant.ErrPanic))
+		})
+
+		It("recovers the panic of the validator", func() {
+			validator := recovery.Validator("test", panicWebhook{})
+			Expect(validator.ValidateCreate(ctx, pool)).To(MatchError(constant.ErrPanic))
+			Expect(validator.ValidateUpdate(ctx, pool, pool)).To(MatchError("invalid"))
+			Expect(validator.ValidateDelete(ctx, pool)).To(Succeed())
+		})
+	})
+})
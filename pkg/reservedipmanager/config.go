@@ -0,0 +1,25 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reservedipmanager
+
+import (
+	"time"
+)
+
+const defaultExpiryResyncPeriod = time.Minute
+
+type ReservedIPExpiryReconcilerConfig struct {
+	ResyncPeriod time.Duration
+	// WarningPeriod is how long before the expiry of a SpiderReservedIP a
+	// warning event is recorded on it, non-positive disables the warning.
+	WarningPeriod time.Duration
+}
+
+func setDefaultsForReservedIPExpiryReconcilerConfig(config ReservedIPExpiryReconcilerConfig) ReservedIPExpiryReconcilerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultExpiryResyncPeriod
+	}
+
+	return config
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reservedipmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// ReservedIPExpiryReconciler deletes the SpiderReservedIPs reaching their
+// 'spec.expiresAt', so that the temporary reservations are not forgotten,
+// and warns about them ahead of the expiry with events.
+type ReservedIPExpiryReconciler interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+}
+
+type reservedIPExpiryReconciler struct {
+	config ReservedIPExpiryReconcilerConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+	clock  clock.Clock
+}
+
+func NewReservedIPExpiryReconciler(config ReservedIPExpiryReconcilerConfig, client client.Client, leader election.SpiderLeaseElector, opts ...manageroption.Option) (ReservedIPExpiryReconciler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &reservedIPExpiryReconciler{
+		config: setDefaultsForReservedIPExpiryReconcilerConfig(config),
+		client: client,
+		leader: leader,
+		clock:  manageroption.New(opts...).Clock,
+	}, nil
+}
+
+// Start deletes the expired SpiderReservedIPs periodically until the context
+// is done. Only the leader of spiderpool-controller deletes them.
+func (r *reservedIPExpiryReconciler) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if r.leader.IsElected() {
+				err := recovery.Call(ctx, "ReservedIP-Expiry-Reconciler", nil, func() error { return r.Reconcile(ctx) })
+				if err != nil {
+					logger.Sugar().Errorf("Failed to delete the expired SpiderReservedIPs: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Reconcile deletes the SpiderReservedIPs expired by now, and records a
+// warning event on the ones entering the warning period since the last
+// resync, so the warning is recorded about once.
+func (r *reservedIPExpiryReconciler) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var rIPList spiderpoolv1.SpiderReservedIPList
+	if err := r.client.List(ctx, &rIPList); err != nil {
+		return err
+	}
+
+	now := r.clock.Now()
+	for i := range rIPList.Items {
+		rIP := &rIPList.Items[i]
+		if rIP.DeletionTimestamp != nil || rIP.Spec.ExpiresAt == nil {
+			continue
+		}
+		expiresAt := rIP.Spec.ExpiresAt.Time
+
+		if !IsReservedIPExpired(rIP, now) {
+			warnAt := expiresAt.Add(-r.config.WarningPeriod)
+			if r.config.WarningPeriod > 0 && !now.Before(warnAt) && now.Before(warnAt.Add(r.config.ResyncPeriod)) {
+				event.EventRecorder.Eventf(rIP, corev1.EventTypeWarning, constant.EventReasonReservedIPExpiring,
+					"The reservation of IP addresses %v expires at %s, extend 'spec.expiresAt' to keep them reserved",
+					rIP.Spec.IPs, expiresAt.UTC().Format(time.RFC3339))
+			}
+			continue
+		}
+
+		err := r.client.Delete(ctx, rIP, client.Preconditions{UID: &rIP.UID, ResourceVersion: &rIP.ResourceVersion})
+		if client.IgnoreNotFound(err) != nil && !apierrors.IsConflict(err) {
+			return fmt.Errorf("failed to delete SpiderReservedIP %s: %w", rIP.Name, err)
+		}
+		if err == nil {
+			logger.Sugar().Infof("Delete the SpiderReservedIP %s expired at %s", rIP.Name, expiresAt.UTC().Format(time.RFC3339))
+			event.EventRecorder.Eventf(rIP, corev1.EventTypeNormal, constant.EventReasonReservedIPExpired,
+				"The reservation of IP addresses %v expired, they are released back", rIP.Spec.IPs)
+		}
+	}
+
+	return nil
+}
+
+// IsReservedIPExpired reports whether the SpiderReservedIP is expired by now.
+// The SpiderReservedIPs without 'spec.expiresAt' never expire.
+func IsReservedIPExpired(rIP *spiderpoolv1.SpiderReservedIP, now time.Time) bool {
+	return rIP.Spec.ExpiresAt != nil && !now.Before(rIP.Spec.ExpiresAt.Time)
+}
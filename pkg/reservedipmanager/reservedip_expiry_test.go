@@ -0,0 +1,94 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package reservedipmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("ReservedIPExpiryReconciler", Label("reservedip_expiry_test"), func() {
+	Describe("New ReservedIPExpiryReconciler", func() {
+		It("inputs nil client", func() {
+			reconciler, err := reservedipmanager.NewReservedIPExpiryReconciler(reservedipmanager.ReservedIPExpiryReconcilerConfig{}, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			reconciler, err := reservedipmanager.NewReservedIPExpiryReconciler(reservedipmanager.ReservedIPExpiryReconcilerConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		It("deletes the expired SpiderReservedIPs only", func() {
+			ctx := context.TODO()
+			now := time.Now()
+			fakeClock := clocktesting.NewFakeClock(now)
+
+			expiresAt := metav1.NewTime(now.Add(time.Hour))
+			expiring := &spiderpoolv1.SpiderReservedIP{
+				ObjectMeta: metav1.ObjectMeta{Name: "expiring"},
+				Spec: spiderpoolv1.ReservedIPSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					IPs:       []string{"172.18.40.1"},
+					ExpiresAt: &expiresAt,
+				},
+			}
+			permanent := &spiderpoolv1.SpiderReservedIP{
+				ObjectMeta: metav1.ObjectMeta{Name: "permanent"},
+				Spec: spiderpoolv1.ReservedIPSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					IPs:       []string{"172.18.40.2"},
+				},
+			}
+			Expect(fakeClient.Create(ctx, expiring)).To(Succeed())
+			Expect(fakeClient.Create(ctx, permanent)).To(Succeed())
+			DeferCleanup(func() {
+				_ = fakeClient.Delete(ctx, expiring)
+				_ = fakeClient.Delete(ctx, permanent)
+			})
+
+			reconciler, err := reservedipmanager.NewReservedIPExpiryReconciler(
+				reservedipmanager.ReservedIPExpiryReconcilerConfig{WarningPeriod: time.Hour},
+				fakeClient,
+				fakeLeader{},
+				manageroption.WithClock(fakeClock),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			var rIP spiderpoolv1.SpiderReservedIP
+			Expect(fakeClient.Get(ctx, apitypes.NamespacedName{Name: expiring.Name}, &rIP)).To(Succeed())
+
+			fakeClock.Step(time.Hour)
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Name: expiring.Name}, &rIP)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(fakeClient.Get(ctx, apitypes.NamespacedName{Name: permanent.Name}, &rIP)).To(Succeed())
+		})
+	})
+})
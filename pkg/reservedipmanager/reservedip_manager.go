@@ -10,11 +10,13 @@ import (
 	"strconv"
 
 	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 )
 
@@ -22,22 +24,45 @@ type ReservedIPManager interface {
 	GetReservedIPByName(ctx context.Context, rIPName string) (*spiderpoolv1.SpiderReservedIP, error)
 	ListReservedIPs(ctx context.Context, opts ...client.ListOption) (*spiderpoolv1.SpiderReservedIPList, error)
 	AssembleReservedIPs(ctx context.Context, version types.IPVersion) ([]net.IP, error)
+	MatchSoftReservedIP(ctx context.Context, version types.IPVersion, ip net.IP) (*spiderpoolv1.SpiderReservedIP, error)
 }
 
+// ipVersionIndex is the field index of the SpiderReservedIPs by their IP
+// version, to assemble the reserved IP addresses of the IP version.
+const ipVersionIndex = "spec.ipVersion"
+
 type reservedIPManager struct {
 	client client.Client
+	clock  clock.Clock
 }
 
-func NewReservedIPManager(client client.Client) (ReservedIPManager, error) {
+func NewReservedIPManager(client client.Client, opts ...manageroption.Option) (ReservedIPManager, error) {
 	if client == nil {
 		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
 	}
 
+	options := manageroption.New(opts...)
+	if options.Indexer != nil {
+		if err := options.Indexer.IndexField(context.Background(), &spiderpoolv1.SpiderReservedIP{}, ipVersionIndex, indexByIPVersion); err != nil {
+			return nil, fmt.Errorf("failed to index SpiderReservedIPs by %s: %w", ipVersionIndex, err)
+		}
+	}
+
 	return &reservedIPManager{
 		client: client,
+		clock:  options.Clock,
 	}, nil
 }
 
+func indexByIPVersion(obj client.Object) []string {
+	rIP := obj.(*spiderpoolv1.SpiderReservedIP)
+	if rIP.Spec.IPVersion == nil {
+		return nil
+	}
+
+	return []string{strconv.FormatInt(*rIP.Spec.IPVersion, 10)}
+}
+
 func (rm *reservedIPManager) GetReservedIPByName(ctx context.Context, rIPName string) (*spiderpoolv1.SpiderReservedIP, error) {
 	var rIP spiderpoolv1.SpiderReservedIP
 	if err := rm.client.Get(ctx, apitypes.NamespacedName{Name: rIPName}, &rIP); err != nil {
@@ -56,19 +81,18 @@ func (rm *reservedIPManager) ListReservedIPs(ctx context.Context, opts ...client
 	return &rIPList, nil
 }
 
+// AssembleReservedIPs returns the IP addresses reserved by the
+// SpiderReservedIPs in mode 'hard' of the IP version, which are never
+// allocated.
 func (rm *reservedIPManager) AssembleReservedIPs(ctx context.Context, version types.IPVersion) ([]net.IP, error) {
-	if err := spiderpoolip.IsIPVersion(version); err != nil {
-		return nil, err
-	}
-
-	rIPList, err := rm.ListReservedIPs(ctx, client.MatchingFields{"spec.ipVersion": strconv.FormatInt(version, 10)})
+	rIPs, err := rm.listEffectiveReservedIPs(ctx, version)
 	if err != nil {
 		return nil, err
 	}
 
 	var ranges []string
-	for _, r := range rIPList.Items {
-		if r.DeletionTimestamp == nil {
+	for _, r := range rIPs {
+		if !IsSoftReservedIP(r) {
 			ranges = append(ranges, r.Spec.IPs...)
 		}
 	}
@@ -80,3 +104,62 @@ func (rm *reservedIPManager) AssembleReservedIPs(ctx context.Context, version ty
 
 	return ips, nil
 }
+
+// MatchSoftReservedIP returns the SpiderReservedIP in mode 'soft' reserving
+// the IP address, or nil if there is none.
+func (rm *reservedIPManager) MatchSoftReservedIP(ctx context.Context, version types.IPVersion, ip net.IP) (*spiderpoolv1.SpiderReservedIP, error) {
+	rIPs, err := rm.listEffectiveReservedIPs(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rIPs {
+		if !IsSoftReservedIP(r) {
+			continue
+		}
+
+		ips, err := spiderpoolip.ParseIPRanges(version, r.Spec.IPs)
+		if err != nil {
+			return nil, err
+		}
+		for _, reservedIP := range ips {
+			if reservedIP.Equal(ip) {
+				return r, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// listEffectiveReservedIPs lists the SpiderReservedIPs of the IP version
+// which are neither terminating nor expired. The expired ones release their
+// IP addresses at once, without waiting for spiderpool-controller to delete
+// them.
+func (rm *reservedIPManager) listEffectiveReservedIPs(ctx context.Context, version types.IPVersion) ([]*spiderpoolv1.SpiderReservedIP, error) {
+	if err := spiderpoolip.IsIPVersion(version); err != nil {
+		return nil, err
+	}
+
+	rIPList, err := rm.ListReservedIPs(ctx, client.MatchingFields{ipVersionIndex: strconv.FormatInt(version, 10)})
+	if err != nil {
+		return nil, err
+	}
+
+	now := rm.clock.Now()
+	rIPs := make([]*spiderpoolv1.SpiderReservedIP, 0, len(rIPList.Items))
+	for i := range rIPList.Items {
+		r := &rIPList.Items[i]
+		if r.DeletionTimestamp == nil && !IsReservedIPExpired(r, now) {
+			rIPs = append(rIPs, r)
+		}
+	}
+
+	return rIPs, nil
+}
+
+// IsSoftReservedIP reports whether the SpiderReservedIP is in mode 'soft',
+// whose IP addresses are still allocatable.
+func IsSoftReservedIP(rIP *spiderpoolv1.SpiderReservedIP) bool {
+	return rIP.Spec.Enforcement != nil && *rIP.Spec.Enforcement == constant.ReservedIPEnforcementSoft
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	. "github.com/onsi/ginkgo/v2"
@@ -20,9 +21,26 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
 )
 
+type fakeIndexer struct {
+	fields       []string
+	extractValue client.IndexerFunc
+	err          error
+}
+
+func (f *fakeIndexer) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.fields = append(f.fields, field)
+	f.extractValue = extractValue
+
+	return nil
+}
+
 var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 	Describe("New ReservedIPManager", func() {
 		It("inputs nil client", func() {
@@ -30,6 +48,27 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
 			Expect(manager).To(BeNil())
 		})
+
+		It("registers the index of IP version with the indexer", func() {
+			indexer := &fakeIndexer{}
+			manager, err := reservedipmanager.NewReservedIPManager(fakeClient, manageroption.WithIndexer(indexer))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manager).NotTo(BeNil())
+			Expect(indexer.fields).To(Equal([]string{"spec.ipVersion"}))
+
+			values := indexer.extractValue(&spiderpoolv1.SpiderReservedIP{
+				Spec: spiderpoolv1.ReservedIPSpec{IPVersion: pointer.Int64(constant.IPv6)},
+			})
+			Expect(values).To(Equal([]string{"6"}))
+			Expect(indexer.extractValue(&spiderpoolv1.SpiderReservedIP{})).To(BeEmpty())
+		})
+
+		It("failed to register the index with the indexer", func() {
+			indexer := &fakeIndexer{err: constant.ErrUnknown}
+			manager, err := reservedipmanager.NewReservedIPManager(fakeClient, manageroption.WithIndexer(indexer))
+			Expect(err).To(MatchError(constant.ErrUnknown))
+			Expect(manager).To(BeNil())
+		})
 	})
 
 	Describe("Test ReservedIPManager's method", func() {
@@ -227,6 +266,35 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 				))
 			})
 
+			It("does not assemble expired IPv4 reserved-IP addresses", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+				expiredAt := metav1.NewTime(time.Now().Add(-time.Minute))
+				rIPT.Spec.ExpiresAt = &expiredAt
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
+			It("does not assemble soft IPv4 reserved-IP addresses", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+				rIPT.Spec.Enforcement = pointer.String(constant.ReservedIPEnforcementSoft)
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				ips, err := rIPManager.AssembleReservedIPs(ctx, constant.IPv4)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
 			It("exists invalid ReservedIPs in the cluster", func() {
 				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
 				rIPT.Spec.IPs = append(rIPT.Spec.IPs, constant.InvalidIPRange)
@@ -240,5 +308,39 @@ var _ = Describe("ReservedIPManager", Label("reservedip_manager_test"), func() {
 				Expect(ips).To(BeEmpty())
 			})
 		})
+
+		Describe("MatchSoftReservedIP", func() {
+			It("matches the soft ReservedIP reserving the IP address", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1-172.18.40.2"}
+				rIPT.Spec.Enforcement = pointer.String(constant.ReservedIPEnforcementSoft)
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				rIP, err := rIPManager.MatchSoftReservedIP(ctx, constant.IPv4, net.IPv4(172, 18, 40, 2))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rIP).NotTo(BeNil())
+				Expect(rIP.Name).To(Equal(rIPName))
+
+				rIP, err = rIPManager.MatchSoftReservedIP(ctx, constant.IPv4, net.IPv4(172, 18, 40, 3))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rIP).To(BeNil())
+			})
+
+			It("ignores hard ReservedIPs", func() {
+				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				rIPT.Spec.IPs = []string{"172.18.40.1"}
+
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+
+				rIP, err := rIPManager.MatchSoftReservedIP(ctx, constant.IPv4, net.IPv4(172, 18, 40, 1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rIP).To(BeNil())
+			})
+		})
 	})
 })
@@ -7,6 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
@@ -43,6 +46,17 @@ func (rw *ReservedIPWebhook) mutateReservedIP(ctx context.Context, rIP *spiderpo
 		logger.Sugar().Infof("Set 'spec.ipVersion' to %d", version)
 	}
 
+	if rIP.Spec.ExpiresAt == nil && rIP.Spec.TTL != nil && rIP.Spec.TTL.Duration > 0 {
+		createdAt := rIP.CreationTimestamp.Time
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		expiresAt := metav1.NewTime(createdAt.Add(rIP.Spec.TTL.Duration).Truncate(time.Second))
+		rIP.Spec.ExpiresAt = &expiresAt
+		logger.Sugar().Infof("Set 'spec.expiresAt' to %s", expiresAt.UTC().Format(time.RFC3339))
+	}
+
 	if len(rIP.Spec.IPs) > 1 {
 		mergedIPs, err := spiderpoolip.MergeIPRanges(*rIP.Spec.IPVersion, rIP.Spec.IPs)
 		if err != nil {
@@ -6,6 +6,7 @@ package reservedipmanager
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -18,6 +19,8 @@ import (
 var (
 	ipVersionField *field.Path = field.NewPath("spec").Child("ipVersion")
 	ipsField       *field.Path = field.NewPath("spec").Child("ips")
+	expiresAtField *field.Path = field.NewPath("spec").Child("expiresAt")
+	ttlField       *field.Path = field.NewPath("spec").Child("ttl")
 )
 
 func (rw *ReservedIPWebhook) validateCreateReservedIP(ctx context.Context, rIP *spiderpoolv1.SpiderReservedIP) field.ErrorList {
@@ -29,6 +32,16 @@ func (rw *ReservedIPWebhook) validateCreateReservedIP(ctx context.Context, rIP *
 	if err := rw.validateReservedIPSpec(ctx, rIP); err != nil {
 		errs = append(errs, err)
 	}
+	if err := validateReservedIPExpiry(rIP); err != nil {
+		errs = append(errs, err)
+	}
+	if rIP.Spec.ExpiresAt != nil && !rIP.Spec.ExpiresAt.After(time.Now()) {
+		errs = append(errs, field.Invalid(
+			expiresAtField,
+			rIP.Spec.ExpiresAt,
+			"is already expired",
+		))
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -50,6 +63,9 @@ func (rw *ReservedIPWebhook) validateUpdateReservedIP(ctx context.Context, oldRI
 	if err := rw.validateReservedIPSpec(ctx, newRIP); err != nil {
 		errs = append(errs, err)
 	}
+	if err := validateReservedIPExpiry(newRIP); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -70,6 +86,18 @@ func validateReservedIPShouldNotBeChanged(oldRIP, newRIP *spiderpoolv1.SpiderRes
 	return nil
 }
 
+func validateReservedIPExpiry(rIP *spiderpoolv1.SpiderReservedIP) *field.Error {
+	if rIP.Spec.TTL != nil && rIP.Spec.TTL.Duration <= 0 {
+		return field.Invalid(
+			ttlField,
+			rIP.Spec.TTL.Duration.String(),
+			"must be positive",
+		)
+	}
+
+	return nil
+}
+
 func (rw *ReservedIPWebhook) validateReservedIPSpec(ctx context.Context, rIP *spiderpoolv1.SpiderReservedIP) *field.Error {
 	return rw.validateReservedIPs(ctx, *rIP.Spec.IPVersion, rIP.Spec.IPs)
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
 )
 
 var WebhookLogger *zap.Logger
@@ -36,8 +37,8 @@ func (rw *ReservedIPWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&spiderpoolv1.SpiderReservedIP{}).
-		WithDefaulter(rw).
-		WithValidator(rw).
+		WithDefaulter(recovery.Defaulter("ReservedIP-Webhook", rw)).
+		WithValidator(recovery.Validator("ReservedIP-Webhook", rw)).
 		Complete()
 }
 
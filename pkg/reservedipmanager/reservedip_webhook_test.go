@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -111,6 +112,17 @@ var _ = Describe("ReservedIPWebhook", Label("reservedip_webhook_test"), func() {
 				Expect(*rIPT.Spec.IPVersion).To(Equal(constant.IPv4))
 			})
 
+			It("sets 'spec.expiresAt' from 'spec.ttl'", func() {
+				rIPT.Spec.IPs = append(rIPT.Spec.IPs, "172.18.40.1-172.18.40.2")
+				rIPT.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+
+				ctx := context.TODO()
+				err := rIPWebhook.Default(ctx, rIPT)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rIPT.Spec.ExpiresAt).NotTo(BeNil())
+				Expect(rIPT.Spec.ExpiresAt.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			})
+
 			It("sets 'spec.ipVersion' to 6", func() {
 				rIPT.Spec.IPs = append(rIPT.Spec.IPs, "abcd:1234::1-abcd:1234::2")
 
@@ -255,6 +267,27 @@ var _ = Describe("ReservedIPWebhook", Label("reservedip_webhook_test"), func() {
 				})
 			})
 
+			When("Validating the expiry", func() {
+				It("inputs non-positive 'spec.ttl'", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					rIPT.Spec.TTL = &metav1.Duration{Duration: -time.Hour}
+
+					ctx := context.TODO()
+					err := rIPWebhook.ValidateCreate(ctx, rIPT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				})
+
+				It("inputs expired 'spec.expiresAt'", func() {
+					rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					expiredAt := metav1.NewTime(time.Now().Add(-time.Hour))
+					rIPT.Spec.ExpiresAt = &expiredAt
+
+					ctx := context.TODO()
+					err := rIPWebhook.ValidateCreate(ctx, rIPT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				})
+			})
+
 			It("creates IPv4 ReservedIP with all fields valid", func() {
 				rIPT.Spec.IPVersion = pointer.Int64(constant.IPv4)
 				rIPT.Spec.IPs = append(rIPT.Spec.IPs,
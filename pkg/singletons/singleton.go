@@ -6,13 +6,16 @@ package singletons
 import "github.com/spidernet-io/spiderpool/pkg/types"
 
 // ClusterDefaultPool is a singleton recording cluster default IPPool and Subnet configurations
-var ClusterDefaultPool = new(types.ClusterDefaultPoolConfig)
+var ClusterDefaultPool = &types.ClusterDefaultPoolConfig{
+	ClusterSubnetDefaultReclaimIPPool: true,
+}
 
 // InitClusterDefaultPool will init ClusterDefaultPool with the given params
-func InitClusterDefaultPool(clusterDefaultV4IPPool, clusterDefaultV6IPPool, clusterDefaultV4Subnet, clusterDefaultV6Subnet []string, flexibleIPNumber int) {
+func InitClusterDefaultPool(clusterDefaultV4IPPool, clusterDefaultV6IPPool, clusterDefaultV4Subnet, clusterDefaultV6Subnet []string, flexibleIPNumber int, reclaimIPPool bool) {
 	ClusterDefaultPool.ClusterDefaultIPv4IPPool = clusterDefaultV4IPPool
 	ClusterDefaultPool.ClusterDefaultIPv6IPPool = clusterDefaultV6IPPool
 	ClusterDefaultPool.ClusterDefaultIPv4Subnet = clusterDefaultV4Subnet
 	ClusterDefaultPool.ClusterDefaultIPv6Subnet = clusterDefaultV6Subnet
 	ClusterDefaultPool.ClusterSubnetDefaultFlexibleIPNumber = flexibleIPNumber
+	ClusterDefaultPool.ClusterSubnetDefaultReclaimIPPool = reclaimIPPool
 }
@@ -0,0 +1,44 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetdiscovery
+
+import (
+	"time"
+)
+
+const (
+	// ModePropose reports the discovered segments with the events of the
+	// node only.
+	ModePropose = "propose"
+	// ModeCreate creates the SpiderSubnet drafts of the discovered segments.
+	ModeCreate = "create"
+)
+
+const (
+	defaultResyncPeriod = 10 * time.Minute
+)
+
+type SubnetDiscoveryConfig struct {
+	// NodeName is the name of the node where spiderpool-agent runs.
+	NodeName string
+
+	// Mode is ModePropose or ModeCreate.
+	Mode string
+
+	// Interfaces limits the discovery to the given interfaces. All the
+	// interfaces of the node except the virtual ones of container networks
+	// are inspected if empty.
+	Interfaces []string
+
+	// ResyncPeriod is the interval of inspecting the interfaces of the node.
+	ResyncPeriod time.Duration
+}
+
+func setDefaultsForSubnetDiscoveryConfig(config SubnetDiscoveryConfig) SubnetDiscoveryConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	return config
+}
@@ -0,0 +1,217 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetdiscovery
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	procVlanConfig = "/proc/net/vlan/config"
+	procRoute      = "/proc/net/route"
+)
+
+// virtualInterfacePrefixes are the prefixes of the interfaces created by
+// container networks, tunnels and bridges, which are not the L2 segments of
+// the physical network.
+var virtualInterfacePrefixes = []string{
+	"veth", "cali", "cilium", "lxc", "docker", "br-", "cni", "flannel", "vxlan",
+	"tunl", "tun", "tap", "virbr", "kube-", "nodelocaldns", "weave", "genev", "dummy",
+}
+
+// Segment is an L2 segment which the node is attached to.
+type Segment struct {
+	// Interface is the name of the interface attached to the segment.
+	Interface string
+	// Vlan is the VLAN ID of the interface, 0 if untagged.
+	Vlan int64
+	// IPVersion is the IP version of CIDR.
+	IPVersion types.IPVersion
+	// CIDR is the network of the segment, e.g. '172.18.40.0/24'.
+	CIDR string
+	// Gateway is the IPv4 default gateway of the node via the interface,
+	// empty if not found.
+	Gateway string
+}
+
+func (s Segment) String() string {
+	return fmt.Sprintf("%s on interface %s (VLAN %d)", s.CIDR, s.Interface, s.Vlan)
+}
+
+// DiscoverSegments inspects the addresses of the given interfaces of the
+// node, or all its interfaces except the virtual ones if empty, and returns
+// the L2 segments they are attached to, sorted by CIDR.
+func DiscoverSegments(interfaces []string) ([]Segment, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interfaces: %w", err)
+	}
+
+	// VLANs and routes are read from procfs, which may be unavailable, e.g.
+	// the 8021q module is not loaded.
+	vlans := map[string]int64{}
+	if f, err := os.Open(procVlanConfig); err == nil {
+		vlans = parseVlanConfig(f)
+		f.Close()
+	}
+	gateways := map[string]string{}
+	if f, err := os.Open(procRoute); err == nil {
+		gateways = parseIPv4Gateways(f)
+		f.Close()
+	}
+
+	seen := map[string]struct{}{}
+	var segments []Segment
+	for i := range ifaces {
+		iface := &ifaces[i]
+		if !isCandidateInterface(iface, interfaces) {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the addresses of interface %s: %w", iface.Name, err)
+		}
+
+		for _, addr := range addrs {
+			segment, ok := newSegment(iface.Name, addr, vlans[iface.Name], gateways[iface.Name])
+			if !ok {
+				continue
+			}
+			if _, ok := seen[segment.CIDR]; ok {
+				continue
+			}
+			seen[segment.CIDR] = struct{}{}
+			segments = append(segments, segment)
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].CIDR < segments[j].CIDR
+	})
+
+	return segments, nil
+}
+
+func isCandidateInterface(iface *net.Interface, interfaces []string) bool {
+	if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 || iface.Flags&net.FlagPointToPoint != 0 {
+		return false
+	}
+
+	if len(interfaces) != 0 {
+		for _, name := range interfaces {
+			if iface.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, prefix := range virtualInterfacePrefixes {
+		if strings.HasPrefix(iface.Name, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newSegment returns the segment of the address of the interface. The
+// link-local addresses and the host addresses without a network are
+// skipped.
+func newSegment(iface string, addr net.Addr, vlan int64, gateway string) (Segment, bool) {
+	ipNet, ok := addr.(*net.IPNet)
+	if !ok || ipNet.IP.IsLinkLocalUnicast() {
+		return Segment{}, false
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	if ones == bits {
+		return Segment{}, false
+	}
+
+	version := constant.IPv6
+	if ipNet.IP.To4() != nil {
+		version = constant.IPv4
+	}
+
+	network := &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask}
+	segment := Segment{
+		Interface: iface,
+		Vlan:      vlan,
+		IPVersion: version,
+		CIDR:      network.String(),
+	}
+	if gw := net.ParseIP(gateway); version == constant.IPv4 && gw != nil && network.Contains(gw) {
+		segment.Gateway = gateway
+	}
+
+	return segment, true
+}
+
+// parseVlanConfig parses /proc/net/vlan/config into the VLAN IDs of the
+// VLAN interfaces, e.g. 'ens192.100 | 100 | ens192'.
+func parseVlanConfig(r io.Reader) map[string]int64 {
+	vlans := map[string]int64{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "|")
+		if len(fields) != 3 {
+			continue
+		}
+
+		id, err := strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		vlans[strings.TrimSpace(fields[0])] = id
+	}
+
+	return vlans
+}
+
+// parseIPv4Gateways parses /proc/net/route into the default gateways of the
+// interfaces.
+func parseIPv4Gateways(r io.Reader) map[string]string {
+	gateways := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[1] != "00000000" || fields[7] != "00000000" {
+			continue
+		}
+
+		b, err := hex.DecodeString(fields[2])
+		if err != nil || len(b) != net.IPv4len {
+			continue
+		}
+		// The address is in the host byte order, which is little endian on
+		// the platforms spiderpool supports.
+		gw := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(gw, binary.LittleEndian.Uint32(b))
+		if gw.IsUnspecified() {
+			continue
+		}
+		if _, ok := gateways[fields[0]]; !ok {
+			gateways[fields[0]] = gw.String()
+		}
+	}
+
+	return gateways
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetdiscovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// DiscoverySourceNodeInterface is the value of the label
+// 'ipam.spidernet.io/discovery-source' of the SpiderSubnet drafts discovered
+// from the addresses of node interfaces.
+const DiscoverySourceNodeInterface = "node-interface"
+
+// SubnetDiscovery inspects the interfaces of the node, and proposes the L2
+// segments not covered by any SpiderSubnet yet with the events of the node,
+// or creates the SpiderSubnet drafts of them, so that users could start with
+// an inventory of the physical network rather than entering CIDRs manually.
+//
+// The drafts own no IP addresses, users fill 'spec.ips' with the ranges
+// free for Pods before using them.
+type SubnetDiscovery interface {
+	Start(ctx context.Context)
+	// Reconcile discovers the segments of the node and syncs them.
+	Reconcile(ctx context.Context) error
+	// Sync proposes or creates the SpiderSubnet drafts of the segments.
+	Sync(ctx context.Context, segments []Segment) error
+}
+
+type subnetDiscovery struct {
+	config SubnetDiscoveryConfig
+	client client.Client
+
+	// proposed are the CIDRs already reported with the events of the node,
+	// to avoid repeating them on each resync.
+	proposed map[string]struct{}
+}
+
+func NewSubnetDiscovery(config SubnetDiscoveryConfig, client client.Client) (SubnetDiscovery, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if config.NodeName == "" {
+		return nil, fmt.Errorf("node name %w", constant.ErrMissingRequiredParam)
+	}
+	if config.Mode != ModePropose && config.Mode != ModeCreate {
+		return nil, fmt.Errorf("invalid subnet discovery mode '%s', must be '%s' or '%s'", config.Mode, ModePropose, ModeCreate)
+	}
+
+	return &subnetDiscovery{
+		config:   setDefaultsForSubnetDiscoveryConfig(config),
+		client:   client,
+		proposed: map[string]struct{}{},
+	}, nil
+}
+
+// Start discovers the segments of the node periodically until the context
+// is done.
+func (d *subnetDiscovery) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(d.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			err := recovery.Call(ctx, "Subnet-Discovery", nil, func() error { return d.Reconcile(ctx) })
+			if err != nil {
+				logger.Sugar().Errorf("Failed to discover the subnets of Node %s: %v", d.config.NodeName, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (d *subnetDiscovery) Reconcile(ctx context.Context) error {
+	segments, err := DiscoverSegments(d.config.Interfaces)
+	if err != nil {
+		return err
+	}
+
+	return d.Sync(ctx, segments)
+}
+
+func (d *subnetDiscovery) Sync(ctx context.Context, segments []Segment) error {
+	logger := logutils.FromContext(ctx)
+
+	var subnetList spiderpoolv1.SpiderSubnetList
+	if err := d.client.List(ctx, &subnetList); err != nil {
+		return fmt.Errorf("failed to list SpiderSubnets: %w", err)
+	}
+
+	var node corev1.Node
+	if err := d.client.Get(ctx, apitypes.NamespacedName{Name: d.config.NodeName}, &node); err != nil {
+		return fmt.Errorf("failed to get Node %s: %w", d.config.NodeName, err)
+	}
+
+	for _, segment := range segments {
+		covered, err := isCovered(subnetList.Items, segment)
+		if err != nil {
+			return err
+		}
+		if covered {
+			logger.Sugar().Debugf("Skip the segment %s covered by SpiderSubnets", segment)
+			continue
+		}
+
+		if d.config.Mode == ModePropose {
+			if _, ok := d.proposed[segment.CIDR]; ok {
+				continue
+			}
+			d.proposed[segment.CIDR] = struct{}{}
+
+			logger.Sugar().Infof("Discovered the segment %s not covered by SpiderSubnets", segment)
+			event.EventRecorder.Eventf(&node, corev1.EventTypeNormal, constant.EventReasonSubnetDiscovered,
+				"Discovered the segment %s not covered by SpiderSubnets", segment)
+			continue
+		}
+
+		draft, err := d.newDraft(segment)
+		if err != nil {
+			return err
+		}
+		if err := d.client.Create(ctx, draft); err != nil {
+			// The same segment may be discovered on the other nodes at the
+			// same time, or rejected by the webhook for the overlap with
+			// the SpiderSubnets created just now.
+			if apierrors.IsAlreadyExists(err) || apierrors.IsInvalid(err) {
+				logger.Sugar().Debugf("Skip to create the SpiderSubnet draft %s: %v", draft.Name, err)
+				continue
+			}
+			return fmt.Errorf("failed to create the SpiderSubnet draft %s: %w", draft.Name, err)
+		}
+
+		logger.Sugar().Infof("Create the SpiderSubnet draft %s of the segment %s", draft.Name, segment)
+		event.EventRecorder.Eventf(&node, corev1.EventTypeNormal, constant.EventReasonSubnetDiscovered,
+			"Create the SpiderSubnet draft %s of the segment %s", draft.Name, segment)
+	}
+
+	return nil
+}
+
+func (d *subnetDiscovery) newDraft(segment Segment) (*spiderpoolv1.SpiderSubnet, error) {
+	cidr, err := spiderpoolip.CIDRToLabelValue(segment.IPVersion, segment.CIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &spiderpoolv1.SpiderSubnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("discovered-v%d-%s", segment.IPVersion, cidr),
+			Labels: map[string]string{
+				constant.LabelSubnetDiscoverySource: DiscoverySourceNodeInterface,
+			},
+			Annotations: map[string]string{
+				constant.AnnoSubnetDiscoveredBy: d.config.NodeName + "/" + segment.Interface,
+			},
+		},
+		Spec: spiderpoolv1.SubnetSpec{
+			IPVersion: pointer.Int64(segment.IPVersion),
+			Subnet:    segment.CIDR,
+			IPs:       []string{},
+		},
+	}
+	if segment.Gateway != "" {
+		draft.Spec.Gateway = pointer.String(segment.Gateway)
+	}
+	if segment.Vlan != 0 {
+		draft.Spec.Vlan = pointer.Int64(segment.Vlan)
+	}
+
+	return draft, nil
+}
+
+// isCovered checks whether the segment overlaps with any SpiderSubnet of the
+// same IP version.
+func isCovered(subnets []spiderpoolv1.SpiderSubnet, segment Segment) (bool, error) {
+	for i := range subnets {
+		subnet := &subnets[i]
+		if subnet.Spec.IPVersion == nil || *subnet.Spec.IPVersion != segment.IPVersion {
+			continue
+		}
+
+		overlap, err := spiderpoolip.IsCIDROverlap(segment.IPVersion, subnet.Spec.Subnet, segment.CIDR)
+		if err != nil {
+			return false, fmt.Errorf("failed to compare SpiderSubnet %s with the segment %s: %w", subnet.Name, segment, err)
+		}
+		if overlap {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
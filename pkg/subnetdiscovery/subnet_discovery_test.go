@@ -0,0 +1,153 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetdiscovery_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/subnetdiscovery"
+)
+
+var _ = Describe("SubnetDiscovery", Label("subnet_discovery_test"), func() {
+	const nodeName = "node1"
+
+	var fakeClient client.Client
+	var segments []subnetdiscovery.Segment
+
+	BeforeEach(func() {
+		existing := &spiderpoolv1.SpiderSubnet{
+			ObjectMeta: metav1.ObjectMeta{Name: "existing-v4"},
+			Spec: spiderpoolv1.SubnetSpec{
+				IPVersion: pointer.Int64(constant.IPv4),
+				Subnet:    "172.18.0.0/16",
+			},
+		}
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(existing, node).
+			Build()
+
+		segments = []subnetdiscovery.Segment{
+			{
+				Interface: "ens192",
+				IPVersion: constant.IPv4,
+				CIDR:      "172.18.40.0/24",
+				Gateway:   "172.18.40.1",
+			},
+			{
+				Interface: "ens192.100",
+				Vlan:      100,
+				IPVersion: constant.IPv4,
+				CIDR:      "10.6.0.0/24",
+				Gateway:   "10.6.0.1",
+			},
+			{
+				Interface: "ens192.100",
+				Vlan:      100,
+				IPVersion: constant.IPv6,
+				CIDR:      "fd00:10:6::/64",
+			},
+		}
+	})
+
+	Describe("New SubnetDiscovery", func() {
+		It("inputs nil client", func() {
+			discovery, err := subnetdiscovery.NewSubnetDiscovery(subnetdiscovery.SubnetDiscoveryConfig{NodeName: nodeName, Mode: subnetdiscovery.ModeCreate}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(discovery).To(BeNil())
+		})
+
+		It("inputs empty node name", func() {
+			discovery, err := subnetdiscovery.NewSubnetDiscovery(subnetdiscovery.SubnetDiscoveryConfig{Mode: subnetdiscovery.ModeCreate}, fakeClient)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(discovery).To(BeNil())
+		})
+
+		It("inputs invalid mode", func() {
+			discovery, err := subnetdiscovery.NewSubnetDiscovery(subnetdiscovery.SubnetDiscoveryConfig{NodeName: nodeName, Mode: "apply"}, fakeClient)
+			Expect(err).To(HaveOccurred())
+			Expect(discovery).To(BeNil())
+		})
+	})
+
+	Describe("Sync", func() {
+		It("creates the drafts of the segments not covered by SpiderSubnets", func() {
+			discovery, err := subnetdiscovery.NewSubnetDiscovery(subnetdiscovery.SubnetDiscoveryConfig{NodeName: nodeName, Mode: subnetdiscovery.ModeCreate}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx := context.TODO()
+			err = discovery.Sync(ctx, segments)
+			Expect(err).NotTo(HaveOccurred())
+
+			var subnetList spiderpoolv1.SpiderSubnetList
+			err = fakeClient.List(ctx, &subnetList, client.MatchingLabels{constant.LabelSubnetDiscoverySource: subnetdiscovery.DiscoverySourceNodeInterface})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnetList.Items).To(HaveLen(2))
+
+			drafts := map[string]spiderpoolv1.SpiderSubnet{}
+			for _, subnet := range subnetList.Items {
+				drafts[subnet.Name] = subnet
+			}
+
+			Expect(drafts).To(HaveKey("discovered-v4-10-6-0-0-24"))
+			v4 := drafts["discovered-v4-10-6-0-0-24"]
+			Expect(v4.Spec.Subnet).To(Equal("10.6.0.0/24"))
+			Expect(v4.Spec.Gateway).To(Equal(pointer.String("10.6.0.1")))
+			Expect(v4.Spec.Vlan).To(Equal(pointer.Int64(100)))
+			Expect(v4.Spec.IPs).To(BeEmpty())
+			Expect(v4.Annotations).To(HaveKeyWithValue(constant.AnnoSubnetDiscoveredBy, nodeName+"/ens192.100"))
+
+			Expect(drafts).To(HaveKey("discovered-v6-fd00-10-6---64"))
+			v6 := drafts["discovered-v6-fd00-10-6---64"]
+			Expect(v6.Spec.IPVersion).To(Equal(pointer.Int64(constant.IPv6)))
+			Expect(v6.Spec.Gateway).To(BeNil())
+
+			// The drafts are kept as they are on the next discovery.
+			err = discovery.Sync(ctx, segments)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("proposes the segments without creating drafts", func() {
+			discovery, err := subnetdiscovery.NewSubnetDiscovery(subnetdiscovery.SubnetDiscoveryConfig{NodeName: nodeName, Mode: subnetdiscovery.ModePropose}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx := context.TODO()
+			err = discovery.Sync(ctx, segments)
+			Expect(err).NotTo(HaveOccurred())
+
+			var subnetList spiderpoolv1.SpiderSubnetList
+			err = fakeClient.List(ctx, &subnetList)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subnetList.Items).To(HaveLen(1))
+		})
+
+		It("fails to get the node", func() {
+			discovery, err := subnetdiscovery.NewSubnetDiscovery(subnetdiscovery.SubnetDiscoveryConfig{NodeName: "node2", Mode: subnetdiscovery.ModeCreate}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = discovery.Sync(context.TODO(), segments)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("DiscoverSegments", func() {
+		It("skips the loopback interface", func() {
+			segments, err := subnetdiscovery.DiscoverSegments([]string{"lo"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(segments).To(BeEmpty())
+		})
+	})
+})
@@ -14,24 +14,41 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
 	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	metrics "github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
 	"github.com/spidernet-io/spiderpool/pkg/types"
 )
@@ -43,6 +60,8 @@ type SubnetAppController struct {
 	subnetMgr     SubnetManager
 	workQueue     workqueue.RateLimitingInterface
 	appController *controllers.Controller
+	throttle      *controllers.ReconcileThrottle
+	pending       *controllers.PendingApps
 
 	deploymentsLister  appslisters.DeploymentLister
 	deploymentInformer cache.SharedIndexInformer
@@ -62,6 +81,15 @@ type SubnetAppController struct {
 	cronJobLister   batchlisters.CronJobLister
 	cronJobInformer cache.SharedIndexInformer
 
+	replicationControllerLister   corelisters.ReplicationControllerLister
+	replicationControllerInformer cache.SharedIndexInformer
+
+	deploymentConfigLister   cache.GenericLister
+	deploymentConfigInformer cache.SharedIndexInformer
+
+	hpaLister   autoscalinglisters.HorizontalPodAutoscalerLister
+	hpaInformer cache.SharedIndexInformer
+
 	SubnetAppControllerConfig
 }
 
@@ -72,6 +100,23 @@ type SubnetAppControllerConfig struct {
 	MaxWorkqueueLength            int
 	WorkQueueRequeueDelayDuration time.Duration
 	LeaderRetryElectGap           time.Duration
+	// AppReconcileInterval is the minimum interval between two reconciliations
+	// of the same application, zero means no limit.
+	AppReconcileInterval time.Duration
+	// EnableSubnetMissingFallback makes the applications referencing the
+	// SpiderSubnets that don't exist wait for them with a warning event,
+	// and creates their IPPools once the SpiderSubnets are created.
+	EnableSubnetMissingFallback bool
+	// EnableHPAScaleEvents makes the IPPools of the applications scaled by
+	// HorizontalPodAutoscalers resized on the transitions of their desired
+	// replicas, rather than waiting for the applications to be scaled.
+	EnableHPAScaleEvents bool
+	// Freeze is optional, the reconciliation is paused while it reports
+	// Spiderpool frozen.
+	Freeze configmanager.FreezeChecker
+	// DynamicClient is optional, the DeploymentConfigs of OpenShift are
+	// reconciled with it if the API server serves them.
+	DynamicClient dynamic.Interface
 }
 
 func (sac *SubnetAppController) SetupInformer(ctx context.Context, client kubernetes.Interface, controllerLeader election.SpiderLeaseElector) error {
@@ -114,7 +159,11 @@ func (sac *SubnetAppController) SetupInformer(ctx context.Context, client kubern
 			informerLogger.Info("create SpiderSubnet App informer")
 			factory := kubeinformers.NewSharedInformerFactory(client, 0)
 			sac.addEventHandlers(factory)
+			sac.addDeploymentConfigHandlers(client.Discovery())
 			factory.Start(innerCtx.Done())
+			if sac.deploymentConfigInformer != nil {
+				go sac.deploymentConfigInformer.Run(innerCtx.Done())
+			}
 			err := sac.Run(innerCtx.Done())
 			if nil != err {
 				informerLogger.Sugar().Errorf("failed to run SpiderSubnet App controller, error: %v", err)
@@ -132,262 +181,106 @@ func (sac *SubnetAppController) ControllerAddOrUpdateHandler() controllers.AppIn
 	return func(ctx context.Context, oldObj, newObj interface{}) error {
 		log := logutils.FromContext(ctx)
 
-		var err error
 		var oldSubnetConfig, newSubnetConfig *types.PodSubnetAnnoConfig
 		var appKind string
-		var app metav1.Object
 		var oldAppReplicas, newAppReplicas int
 
+		newApp, err := meta.Accessor(newObj)
+		if nil != err {
+			return fmt.Errorf("%w: %v", constant.ErrWrongInput, err)
+		}
+		nsAnnotations, err := sac.getNamespaceAnnotations(ctx, newApp.GetNamespace())
+		if nil != err {
+			return err
+		}
+
+		var hostNetwork bool
 		switch newObject := newObj.(type) {
 		case *appsv1.Deployment:
 			appKind = constant.KindDeployment
-			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
-
-			// no need reconcile for HostNetwork application
-			if newObject.Spec.Template.Spec.HostNetwork {
-				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
-				return nil
-			}
-
-			// check the app whether is the top controller or not
-			owner := metav1.GetControllerOf(newObject)
-			if owner != nil {
-				log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
-				return nil
-			}
-
-			newAppReplicas = controllers.GetAppReplicas(newObject.Spec.Replicas)
-			newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
-			if nil != err {
-				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
-			}
-
-			// default IPAM mode
-			if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
-				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
-				return nil
-			}
-
-			app = newObject.DeepCopy()
-
-			if oldObj != nil {
-				oldDeployment := oldObj.(*appsv1.Deployment)
-				oldAppReplicas = controllers.GetAppReplicas(oldDeployment.Spec.Replicas)
-				oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldDeployment.Spec.Template.Annotations, log)
-				if nil != err {
-					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
-				}
-			}
-
+			hostNetwork = newObject.Spec.Template.Spec.HostNetwork
 		case *appsv1.ReplicaSet:
 			appKind = constant.KindReplicaSet
-			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
-
-			// no need reconcile for HostNetwork application
-			if newObject.Spec.Template.Spec.HostNetwork {
-				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
-				return nil
-			}
-
-			// check the app whether is the top controller or not
-			owner := metav1.GetControllerOf(newObject)
-			if owner != nil {
-				log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
-				return nil
-			}
-
-			newAppReplicas = controllers.GetAppReplicas(newObject.Spec.Replicas)
-			newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
-			if nil != err {
-				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
-			}
-
-			// default IPAM mode
-			if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
-				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
-				return nil
-			}
-
-			app = newObject.DeepCopy()
-
-			if oldObj != nil {
-				oldReplicaSet := oldObj.(*appsv1.ReplicaSet)
-				oldAppReplicas = controllers.GetAppReplicas(oldReplicaSet.Spec.Replicas)
-				oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldReplicaSet.Spec.Template.Annotations, log)
-				if nil != err {
-					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
-				}
-			}
-
+			hostNetwork = newObject.Spec.Template.Spec.HostNetwork
 		case *appsv1.StatefulSet:
 			appKind = constant.KindStatefulSet
-			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
-
-			// no need reconcile for HostNetwork application
-			if newObject.Spec.Template.Spec.HostNetwork {
-				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
-				return nil
-			}
-
-			// check the app whether is the top controller or not
-			owner := metav1.GetControllerOf(newObject)
-			if owner != nil {
-				log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
-				return nil
-			}
-
-			newAppReplicas = controllers.GetAppReplicas(newObject.Spec.Replicas)
-			newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
-			if nil != err {
-				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
-			}
-
-			// default IPAM mode
-			if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
-				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
-				return nil
-			}
-
-			app = newObject.DeepCopy()
-
-			if oldObj != nil {
-				oldStatefulSet := oldObj.(*appsv1.StatefulSet)
-				oldAppReplicas = controllers.GetAppReplicas(oldStatefulSet.Spec.Replicas)
-				oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldStatefulSet.Spec.Template.Annotations, log)
-				if nil != err {
-					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
-				}
-			}
-
+			hostNetwork = newObject.Spec.Template.Spec.HostNetwork
 		case *batchv1.Job:
 			appKind = constant.KindJob
-			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
-
-			// no need reconcile for HostNetwork application
-			if newObject.Spec.Template.Spec.HostNetwork {
-				log.Sugar().Debugf("HostNetwork mode, we would not create or scale IPPool for it")
-				return nil
-			}
-
-			// check the app whether is the top controller or not
-			owner := metav1.GetControllerOf(newObject)
-			if owner != nil {
-				log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
-				return nil
-			}
-
-			newAppReplicas = controllers.CalculateJobPodNum(newObject.Spec.Parallelism, newObject.Spec.Completions)
-			newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
-			if nil != err {
-				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
-			}
-
-			// default IPAM mode
-			if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
-				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
-				return nil
-			}
-
-			app = newObject.DeepCopy()
-
-			if oldObj != nil {
-				oldJob := oldObj.(*batchv1.Job)
-				oldAppReplicas = controllers.CalculateJobPodNum(oldJob.Spec.Parallelism, oldJob.Spec.Completions)
-				oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldJob.Spec.Template.Annotations, log)
-				if nil != err {
-					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
-				}
-			}
-
+			hostNetwork = newObject.Spec.Template.Spec.HostNetwork
 		case *batchv1.CronJob:
 			appKind = constant.KindCronJob
-			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
-
-			// no need reconcile for HostNetwork application
-			if newObject.Spec.JobTemplate.Spec.Template.Spec.HostNetwork {
-				log.Sugar().Debugf("HostNetwork mode, we would not create or scale IPPool for it")
-				return nil
-			}
-
-			// check the app whether is the top controller or not
-			owner := metav1.GetControllerOf(newObject)
-			if owner != nil {
-				log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
-				return nil
-			}
-
-			newAppReplicas = controllers.CalculateJobPodNum(newObject.Spec.JobTemplate.Spec.Parallelism, newObject.Spec.JobTemplate.Spec.Completions)
-			newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newObject.Spec.JobTemplate.Spec.Template.Annotations, log)
-			if nil != err {
-				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
-			}
-
-			// default IPAM mode
-			if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
-				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
-				return nil
-			}
-
-			app = newObject.DeepCopy()
-
-			if oldObj != nil {
-				oldCronJob := oldObj.(*batchv1.CronJob)
-				oldAppReplicas = controllers.CalculateJobPodNum(oldCronJob.Spec.JobTemplate.Spec.Parallelism, oldCronJob.Spec.JobTemplate.Spec.Completions)
-				oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldCronJob.Spec.JobTemplate.Spec.Template.Annotations, log)
-				if nil != err {
-					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
-				}
-			}
-
+			hostNetwork = newObject.Spec.JobTemplate.Spec.Template.Spec.HostNetwork
 		case *appsv1.DaemonSet:
 			appKind = constant.KindDaemonSet
-			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newObject.GetNamespace(), newObject.GetName())))
-
-			// no need reconcile for HostNetwork application
-			if newObject.Spec.Template.Spec.HostNetwork {
-				log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
-				return nil
+			hostNetwork = newObject.Spec.Template.Spec.HostNetwork
+		case *corev1.ReplicationController:
+			appKind = constant.KindReplicationController
+			hostNetwork = newObject.Spec.Template != nil && newObject.Spec.Template.Spec.HostNetwork
+		case *unstructured.Unstructured:
+			if newObject.GetKind() != constant.KindDeploymentConfig {
+				return fmt.Errorf("unrecognized application: %+v", newObj)
 			}
+			appKind = constant.KindDeploymentConfig
+			hostNetwork, _, _ = unstructured.NestedBool(newObject.Object, "spec", "template", "spec", "hostNetwork")
+		default:
+			return fmt.Errorf("unrecognized application: %+v", newObj)
+		}
+		log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", newApp.GetNamespace(), newApp.GetName())))
 
-			// check the app whether is the top controller or not
-			owner := metav1.GetControllerOf(newObject)
-			if owner != nil {
-				log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
-				return nil
-			}
+		// no need reconcile for HostNetwork application
+		if hostNetwork {
+			log.Debug("HostNetwork mode, we would not create or scale IPPool for it")
+			return nil
+		}
 
-			newAppReplicas = int(newObject.Status.DesiredNumberScheduled)
-			newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newObject.Spec.Template.Annotations, log)
-			if nil != err {
-				return fmt.Errorf("failed to get app subnet configuration, error: %v", err)
-			}
+		// check the app whether is the top controller or not
+		owner := metav1.GetControllerOf(newApp)
+		if owner != nil {
+			log.Sugar().Debugf("app has a owner '%s/%s', we would not create or scale IPPool for it", owner.Kind, owner.Name)
+			return nil
+		}
 
-			// default IPAM mode
-			if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
-				log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
-				return nil
-			}
+		newController := types.PodTopController{Kind: appKind, APP: newApp}
+		newAppReplicas, _ = newController.Replicas()
+		newSubnetConfig, err = controllers.GetSubnetAnnoConfig(newController.TemplateAnnotations(), newApp.GetAnnotations(), nsAnnotations, log)
+		if nil != err {
+			return fmt.Errorf("failed to get app subnet configuration, error: %w", err)
+		}
 
-			app = newObject.DeepCopy()
+		// default IPAM mode
+		if controllers.IsDefaultIPPoolMode(newSubnetConfig) {
+			log.Debug("app will use default IPAM mode, because there's no subnet annotation or no ClusterDefaultSubnets")
+			return nil
+		}
 
-			if oldObj != nil {
-				oldDaemonSet := oldObj.(*appsv1.DaemonSet)
-				oldAppReplicas = int(oldDaemonSet.Status.DesiredNumberScheduled)
-				oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldDaemonSet.Spec.Template.Annotations, log)
-				if nil != err {
-					return fmt.Errorf("failed to get old app subnet configuration, error: %v", err)
-				}
+		if oldObj != nil {
+			oldApp, err := meta.Accessor(oldObj)
+			if nil != err {
+				return fmt.Errorf("%w: %v", constant.ErrWrongInput, err)
 			}
 
-		default:
-			return fmt.Errorf("unrecognized application: %+v", newObj)
+			oldController := types.PodTopController{Kind: appKind, APP: oldApp}
+			oldAppReplicas, _ = oldController.Replicas()
+			oldSubnetConfig, err = controllers.GetSubnetAnnoConfig(oldController.TemplateAnnotations(), oldApp.GetAnnotations(), nsAnnotations, log)
+			if nil != err {
+				return fmt.Errorf("failed to get old app subnet configuration, error: %w", err)
+			}
 		}
 
 		ctx = logutils.IntoContext(ctx, log)
-		// check the difference between the two object and choose to reconcile or not
-		if sac.hasSubnetConfigChanged(ctx, oldSubnetConfig, newSubnetConfig, oldAppReplicas, newAppReplicas) {
+		// check the difference between the two object and choose to reconcile or not,
+		// the application is reconciled once its resizing is paused or resumed too.
+		var oldPaused bool
+		if oldObj != nil {
+			if oldApp, err := meta.Accessor(oldObj); nil == err {
+				oldPaused = ippoolmanager.IsReconcilePaused(oldApp.GetAnnotations())
+			}
+		}
+		if sac.hasSubnetConfigChanged(ctx, oldSubnetConfig, newSubnetConfig, oldAppReplicas, newAppReplicas) ||
+			oldPaused != ippoolmanager.IsReconcilePaused(newApp.GetAnnotations()) {
 			log.Debug("try to add app to application controller workequeue")
-			sac.enqueueApp(ctx, app, appKind)
+			sac.enqueueApp(ctx, newApp, appKind)
 		}
 
 		return nil
@@ -401,6 +294,8 @@ func NewSubnetAppController(client client.Client, subnetMgr SubnetManager, subne
 		client:                    client,
 		subnetMgr:                 subnetMgr,
 		SubnetAppControllerConfig: subnetAppControllerConfig,
+		throttle:                  controllers.NewReconcileThrottle(subnetAppControllerConfig.AppReconcileInterval),
+		pending:                   controllers.NewPendingApps(),
 	}
 
 	appController, err := controllers.NewApplicationController(c.ControllerAddOrUpdateHandler(), c.ControllerDeleteHandler(), informerLogger)
@@ -437,9 +332,115 @@ func (sac *SubnetAppController) addEventHandlers(factory kubeinformers.SharedInf
 	sac.cronJobInformer = factory.Batch().V1().CronJobs().Informer()
 	sac.appController.AddCronJobHandler(sac.cronJobInformer)
 
+	sac.replicationControllerLister = factory.Core().V1().ReplicationControllers().Lister()
+	sac.replicationControllerInformer = factory.Core().V1().ReplicationControllers().Informer()
+	sac.appController.AddReplicationControllerHandler(sac.replicationControllerInformer)
+
+	if sac.EnableHPAScaleEvents {
+		sac.hpaLister = factory.Autoscaling().V2().HorizontalPodAutoscalers().Lister()
+		sac.hpaInformer = factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer()
+		sac.hpaInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				sac.onHPAAddOrUpdate(nil, obj)
+			},
+			UpdateFunc: sac.onHPAAddOrUpdate,
+		})
+	}
+
 	// Once we lost the leader but get leader later, we have to use a new workqueue.
 	// Because the former workqueue was already shut down and wouldn't be re-start forever.
-	sac.workQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Application-Controllers")
+	sac.workQueue = workqueue.NewNamedRateLimitingQueue(newKeyedRateLimiter(), "Application-Controllers")
+}
+
+// addDeploymentConfigHandlers watches the DeploymentConfigs of OpenShift as
+// unstructured with the dynamic client, only if the API server serves them.
+func (sac *SubnetAppController) addDeploymentConfigHandlers(discoveryClient discovery.DiscoveryInterface) {
+	sac.deploymentConfigLister = nil
+	sac.deploymentConfigInformer = nil
+	if sac.DynamicClient == nil || !isDeploymentConfigServed(discoveryClient) {
+		return
+	}
+
+	gv, _ := schema.ParseGroupVersion(constant.DeploymentConfigAPIVersion)
+	gvr := gv.WithResource(constant.DeploymentConfigResource)
+	resource := sac.DynamicClient.Resource(gvr)
+	sac.deploymentConfigInformer = cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return resource.List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return resource.Watch(context.TODO(), options)
+			},
+		},
+		&unstructured.Unstructured{},
+		0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+	sac.deploymentConfigLister = cache.NewGenericLister(sac.deploymentConfigInformer.GetIndexer(), gvr.GroupResource())
+	sac.appController.AddDeploymentConfigHandler(sac.deploymentConfigInformer)
+}
+
+func isDeploymentConfigServed(discoveryClient discovery.DiscoveryInterface) bool {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(constant.DeploymentConfigAPIVersion)
+	if nil != err {
+		if !apierrors.IsNotFound(err) {
+			informerLogger.Sugar().Warnf("failed to discover DeploymentConfigs, they are not reconciled: %v", err)
+		}
+		return false
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == constant.DeploymentConfigResource {
+			return true
+		}
+	}
+
+	return false
+}
+
+// onHPAAddOrUpdate enqueues the application scaled by the
+// HorizontalPodAutoscaler once its desired replicas change, the application
+// itself is enqueued again when it is actually scaled.
+func (sac *SubnetAppController) onHPAAddOrUpdate(oldObj, newObj interface{}) {
+	newHPA, ok := newObj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return
+	}
+
+	appKind, ok := controllers.GetHPATargetAppKind(newHPA.Spec.ScaleTargetRef)
+	if !ok {
+		return
+	}
+
+	if oldHPA, ok := oldObj.(*autoscalingv2.HorizontalPodAutoscaler); ok &&
+		oldHPA.Status.DesiredReplicas == newHPA.Status.DesiredReplicas &&
+		reflect.DeepEqual(oldHPA.Spec.ScaleTargetRef, newHPA.Spec.ScaleTargetRef) {
+		return
+	}
+
+	log := informerLogger.With(
+		zap.String("HorizontalPodAutoscaler", fmt.Sprintf("%s/%s", newHPA.Namespace, newHPA.Name)),
+		zap.String(appKind, fmt.Sprintf("%s/%s", newHPA.Namespace, newHPA.Spec.ScaleTargetRef.Name)),
+	)
+	log.Sugar().Debugf("HorizontalPodAutoscaler desires %d replicas, try to add app to application controller workequeue", newHPA.Status.DesiredReplicas)
+	sac.enqueueApp(logutils.IntoContext(context.TODO(), log),
+		cache.ExplicitKey(newHPA.Namespace+"/"+newHPA.Spec.ScaleTargetRef.Name), appKind)
+}
+
+// getHPAScaledReplicas returns the replicas of the application taking the
+// desired replicas of the HorizontalPodAutoscalers scaling it into account.
+func (sac *SubnetAppController) getHPAScaledReplicas(appKind, namespace, name string, appReplicas int) (int, error) {
+	if sac.hpaLister == nil {
+		return appReplicas, nil
+	}
+
+	hpas, err := sac.hpaLister.HorizontalPodAutoscalers(namespace).List(labels.Everything())
+	if nil != err {
+		return 0, fmt.Errorf("failed to list HorizontalPodAutoscalers in Namespace '%s', error: %w", namespace, err)
+	}
+
+	return controllers.GetHPAScaledReplicas(appKind, name, appReplicas, hpas), nil
 }
 
 // appWorkQueueKey involves application object meta namespaceKey and application kind
@@ -470,6 +471,15 @@ func (sac *SubnetAppController) enqueueApp(ctx context.Context, obj interface{},
 		return
 	}
 
+	// The workqueue merges the same keys, so the throttled application is
+	// reconciled once when the delay is over.
+	if delay := sac.throttle.Delay(appKey, time.Now()); delay > 0 {
+		metrics.AutoPoolReconcileSuppressedCounts.Add(ctx, 1)
+		sac.workQueue.AddAfter(appKey, delay)
+		log.Sugar().Debugf("throttled '%v', add it to application controller workequeue after '%v'", appKey, delay)
+		return
+	}
+
 	sac.workQueue.Add(appKey)
 	log.Sugar().Debugf("added '%v' to application controller workequeue", appKey)
 }
@@ -487,6 +497,15 @@ func (sac *SubnetAppController) Run(stopCh <-chan struct{}) error {
 		sac.jobInformer.HasSynced,
 		sac.cronJobInformer.HasSynced,
 	)
+	if ok {
+		ok = cache.WaitForCacheSync(stopCh, sac.replicationControllerInformer.HasSynced)
+	}
+	if ok && sac.hpaInformer != nil {
+		ok = cache.WaitForCacheSync(stopCh, sac.hpaInformer.HasSynced)
+	}
+	if ok && sac.deploymentConfigInformer != nil {
+		ok = cache.WaitForCacheSync(stopCh, sac.deploymentConfigInformer.HasSynced)
+	}
 	if !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
@@ -530,7 +549,20 @@ func (sac *SubnetAppController) processNextWorkItem() bool {
 			zap.String("Application", fmt.Sprintf("%s/%s", key.AppKind, key.MetaNamespaceKey)),
 		)
 
-		err := sac.syncHandler(key, log)
+		if err := configmanager.CheckFrozen(sac.Freeze); err != nil {
+			log.Sugar().Debugf("Postpone syncing: %v", err)
+			sac.workQueue.Forget(obj)
+			sac.workQueue.AddAfter(obj, sac.WorkQueueRequeueDelayDuration)
+			return nil
+		}
+
+		start := time.Now()
+		defer observeWorkItem(context.TODO(), applicationQueueName, key.AppKind, sac.workQueue, start)
+
+		sac.throttle.Reconciled(key, start)
+		err := recovery.Call(logutils.IntoContext(context.TODO(), log), "Application-Controller", nil, func() error {
+			return sac.syncHandler(key, log)
+		})
 		if nil != err {
 			// discard wrong input items
 			if errors.Is(err, constant.ErrWrongInput) {
@@ -581,9 +613,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 
 	var app metav1.Object
 	var subnetConfig *types.PodSubnetAnnoConfig
-	var podAnno map[string]string
-	var podSelector *metav1.LabelSelector
-	var appReplicas int
 
 	switch appKey.AppKind {
 	case constant.KindDeployment:
@@ -596,9 +625,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 			return err
 		}
 
-		podAnno = deployment.Spec.Template.Annotations
-		podSelector = deployment.Spec.Selector
-		appReplicas = controllers.GetAppReplicas(deployment.Spec.Replicas)
 		app = deployment.DeepCopy()
 
 	case constant.KindReplicaSet:
@@ -611,9 +637,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 			return err
 		}
 
-		podAnno = replicaSet.Spec.Template.Annotations
-		podSelector = replicaSet.Spec.Selector
-		appReplicas = controllers.GetAppReplicas(replicaSet.Spec.Replicas)
 		app = replicaSet.DeepCopy()
 
 	case constant.KindDaemonSet:
@@ -626,9 +649,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 			return err
 		}
 
-		podAnno = daemonSet.Spec.Template.Annotations
-		podSelector = daemonSet.Spec.Selector
-		appReplicas = int(daemonSet.Status.DesiredNumberScheduled)
 		app = daemonSet.DeepCopy()
 
 	case constant.KindStatefulSet:
@@ -641,9 +661,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 			return err
 		}
 
-		podAnno = statefulSet.Spec.Template.Annotations
-		podSelector = statefulSet.Spec.Selector
-		appReplicas = controllers.GetAppReplicas(statefulSet.Spec.Replicas)
 		app = statefulSet.DeepCopy()
 
 	case constant.KindJob:
@@ -656,9 +673,6 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 			return err
 		}
 
-		podAnno = job.Spec.Template.Annotations
-		podSelector = job.Spec.Selector
-		appReplicas = controllers.CalculateJobPodNum(job.Spec.Parallelism, job.Spec.Completions)
 		app = job.DeepCopy()
 
 	case constant.KindCronJob:
@@ -671,16 +685,62 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 			return err
 		}
 
-		podAnno = cronJob.Spec.JobTemplate.Spec.Template.Annotations
-		podSelector = cronJob.Spec.JobTemplate.Spec.Selector
-		appReplicas = controllers.CalculateJobPodNum(cronJob.Spec.JobTemplate.Spec.Parallelism, cronJob.Spec.JobTemplate.Spec.Completions)
 		app = cronJob.DeepCopy()
 
+	case constant.KindReplicationController:
+		replicationController, err := sac.replicationControllerLister.ReplicationControllers(namespace).Get(name)
+		if nil != err {
+			if apierrors.IsNotFound(err) {
+				log.Sugar().Debugf("application in work queue no longer exists")
+				return nil
+			}
+			return err
+		}
+
+		app = replicationController.DeepCopy()
+
+	case constant.KindDeploymentConfig:
+		if sac.deploymentConfigLister == nil {
+			return fmt.Errorf("%w: DeploymentConfigs are not watched", constant.ErrWrongInput)
+		}
+		deploymentConfig, err := sac.deploymentConfigLister.ByNamespace(namespace).Get(name)
+		if nil != err {
+			if apierrors.IsNotFound(err) {
+				log.Sugar().Debugf("application in work queue no longer exists")
+				return nil
+			}
+			return err
+		}
+
+		app = deploymentConfig.(*unstructured.Unstructured).DeepCopy()
+
 	default:
 		return fmt.Errorf("%w: unexpected appWorkQueueKey in workQueue '%+v'", constant.ErrWrongInput, appKey)
 	}
 
-	subnetConfig, err = controllers.GetSubnetAnnoConfig(podAnno, log)
+	podController := types.PodTopController{
+		Kind:      appKey.AppKind,
+		Namespace: app.GetNamespace(),
+		Name:      app.GetName(),
+		UID:       app.GetUID(),
+		APP:       app,
+	}
+	appReplicas, _ := podController.Replicas()
+
+	switch appKey.AppKind {
+	case constant.KindDeployment, constant.KindReplicaSet, constant.KindStatefulSet:
+		appReplicas, err = sac.getHPAScaledReplicas(appKey.AppKind, namespace, name, appReplicas)
+		if nil != err {
+			return err
+		}
+	}
+
+	nsAnnotations, err := sac.getNamespaceAnnotations(context.TODO(), namespace)
+	if nil != err {
+		return err
+	}
+
+	subnetConfig, err = controllers.GetSubnetAnnoConfig(podController.TemplateAnnotations(), app.GetAnnotations(), nsAnnotations, log)
 	if nil != err {
 		return fmt.Errorf("%w: failed to get pod annotation subnet config, error: %v", constant.ErrWrongInput, err)
 	}
@@ -688,14 +748,8 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 	log.Debug("Going to create IPPool or mark IPPool desired IP number")
 	err = sac.createOrMarkIPPool(logutils.IntoContext(context.TODO(), log),
 		*subnetConfig,
-		types.PodTopController{
-			Kind:      appKey.AppKind,
-			Namespace: app.GetNamespace(),
-			Name:      app.GetName(),
-			UID:       app.GetUID(),
-			APP:       app,
-		},
-		podSelector,
+		podController,
+		podController.Selector(),
 		appReplicas)
 	if nil != err {
 		return fmt.Errorf("failed to create or scale IPPool: %w", err)
@@ -704,6 +758,17 @@ func (sac *SubnetAppController) syncHandler(appKey appWorkQueueKey, log *zap.Log
 	return nil
 }
 
+// getNamespaceAnnotations gets the annotations of the Namespace, which serve as
+// the defaults of the SpiderSubnet annotations of applications in it.
+func (sac *SubnetAppController) getNamespaceAnnotations(ctx context.Context, namespace string) (map[string]string, error) {
+	var ns corev1.Namespace
+	if err := sac.client.Get(ctx, apitypes.NamespacedName{Name: namespace}, &ns); nil != err {
+		return nil, fmt.Errorf("failed to get Namespace '%s', error: %w", namespace, err)
+	}
+
+	return ns.Annotations, nil
+}
+
 // createOrMarkIPPool try to create an IPPool or mark IPPool desired IP number with the give SpiderSubnet configuration
 func (sac *SubnetAppController) createOrMarkIPPool(ctx context.Context, podSubnetConfig types.PodSubnetAnnoConfig,
 	podController types.PodTopController, podSelector *metav1.LabelSelector, appReplicas int) error {
@@ -721,12 +786,39 @@ func (sac *SubnetAppController) createOrMarkIPPool(ctx context.Context, podSubne
 		// verify whether the pool IPs need to be expanded or not
 		if len(poolList.Items) == 0 {
 			log.Sugar().Debugf("there's no 'IPv%d' IPPoolList retrieved from SpiderSubent '%s' with matchLabel '%v'", ipVersion, subnetName, matchLabel)
+			if sac.EnableSubnetMissingFallback {
+				pending, err := sac.pendOnMissingSubnet(ctx, podController, subnetName, ipVersion, ifName)
+				if nil != err {
+					return err
+				}
+				if pending {
+					return nil
+				}
+			}
+
 			// create an empty IPPool and mark the desired IP number when the subnet name was specified,
 			// and the IPPool informer will implement the scale action
-			_, err = sac.subnetMgr.AllocateEmptyIPPool(ctx, subnetName, podController, podSelector, ipNum, ipVersion, podSubnetConfig.ReclaimIPPool, ifName)
+			_, err = sac.subnetMgr.AllocateEmptyIPPool(ctx, subnetName, podController, podSelector, ipNum, ipVersion, podSubnetConfig.ReclaimIPPool, ifName, podSubnetConfig.BlockPrefixLength)
 		} else if len(poolList.Items) == 1 {
 			pool := poolList.Items[0]
 			log.Sugar().Debugf("found SpiderSubnet '%s' IPPool '%s' with matchLabel '%v', check it whether need to be scaled", subnetName, pool.Name, matchLabel)
+			err = sac.subnetMgr.SyncApplicationLabels(ctx, &pool, podController)
+			if nil != err {
+				return err
+			}
+
+			paused := ippoolmanager.IsReconcilePaused(podController.APP.GetAnnotations())
+			if ippoolmanager.SetReconcilePaused(&pool, paused, constant.ReasonApplicationPaused) {
+				err = sac.client.Status().Update(ctx, &pool)
+				if nil != err {
+					return err
+				}
+			}
+			if paused {
+				log.Sugar().Infof("the resizing of IPPool '%s' is paused by the application", pool.Name)
+				return nil
+			}
+
 			_, err = sac.subnetMgr.CheckScaleIPPool(ctx, &pool, subnetName, ipNum)
 		} else {
 			err = fmt.Errorf("%w: it's invalid that SpiderSubnet '%s' owns multiple matchLabel '%v' corresponding IPPools '%v' for one specify application",
@@ -820,6 +912,52 @@ func (sac *SubnetAppController) createOrMarkIPPool(ctx context.Context, podSubne
 	return nil
 }
 
+// pendOnMissingSubnet checks whether the SpiderSubnet exists. If not, it
+// records the application as pending on the SpiderSubnet and emits a warning
+// event on the application, instead of failing the reconciliation over and
+// over again. It returns true if the application is pending.
+func (sac *SubnetAppController) pendOnMissingSubnet(ctx context.Context, podController types.PodTopController,
+	subnetName string, ipVersion types.IPVersion, ifName string) (bool, error) {
+	_, err := sac.subnetMgr.GetSubnetByName(ctx, subnetName, constant.UseCache)
+	if nil == err {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	appKey := appWorkQueueKey{
+		MetaNamespaceKey: podController.Namespace + "/" + podController.Name,
+		AppKind:          podController.Kind,
+	}
+	if !sac.pending.Add(subnetName, appKey) {
+		return true, nil
+	}
+
+	log := logutils.FromContext(ctx)
+	log.Sugar().Warnf("SpiderSubnet '%s' not found, the IPv%d IPPool of interface '%s' is pending on it", subnetName, ipVersion, ifName)
+	if obj, ok := podController.APP.(runtime.Object); ok {
+		event.EventRecorder.Eventf(obj, corev1.EventTypeWarning, constant.EventReasonSubnetNotFound,
+			"SpiderSubnet '%s' not found, create it or fix the SpiderSubnet annotation of the Pod template; the IPv%d IPPool of interface '%s' will be created once the SpiderSubnet is created",
+			subnetName, ipVersion, ifName)
+	}
+
+	return true, nil
+}
+
+// EnqueuePendingApps reconciles the applications pending on the SpiderSubnet
+// again, it is supposed to be called once the SpiderSubnet is created.
+func (sac *SubnetAppController) EnqueuePendingApps(subnetName string) {
+	if sac.workQueue == nil {
+		return
+	}
+
+	for _, appKey := range sac.pending.Pop(subnetName) {
+		informerLogger.Sugar().Infof("SpiderSubnet '%s' is available, reconcile the pending application '%v'", subnetName, appKey)
+		sac.workQueue.Add(appKey)
+	}
+}
+
 // hasSubnetConfigChanged checks whether application subnet configuration changed and the application replicas changed or not.
 // The second parameter newSubnetConfig must not be nil.
 func (sac *SubnetAppController) hasSubnetConfigChanged(ctx context.Context, oldSubnetConfig, newSubnetConfig *types.PodSubnetAnnoConfig,
@@ -926,10 +1064,35 @@ func (sac *SubnetAppController) ControllerDeleteHandler() controllers.APPInforme
 
 			app = object
 
+		case *corev1.ReplicationController:
+			appKind = constant.KindReplicationController
+			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", object.Namespace, object.Name)))
+
+			owner := metav1.GetControllerOf(object)
+			if owner != nil {
+				log.Sugar().Debugf("the application has a owner '%s/%s', we would not clean up legacy for it", owner.Kind, owner.Name)
+				return nil
+			}
+
+			app = object
+
+		case *unstructured.Unstructured:
+			if object.GetKind() != constant.KindDeploymentConfig {
+				return fmt.Errorf("unrecognized application: %+v", obj)
+			}
+			appKind = constant.KindDeploymentConfig
+			log = log.With(zap.String(appKind, fmt.Sprintf("%s/%s", object.GetNamespace(), object.GetName())))
+
+			app = object
+
 		default:
 			return fmt.Errorf("unrecognized application: %+v", obj)
 		}
 
+		if metaKey, err := cache.MetaNamespaceKeyFunc(app); err == nil {
+			sac.throttle.Forget(appWorkQueueKey{MetaNamespaceKey: metaKey, AppKind: appKind})
+		}
+
 		// clean up all legacy IPPools that matched with the application UID
 		err := sac.tryToCleanUpLegacyIPPools(logutils.IntoContext(ctx, log), app)
 		if nil != err {
@@ -8,8 +8,13 @@ import (
 )
 
 type SubnetManagerConfig struct {
-	MaxConflictRetries    int
-	ConflictRetryUnitTime time.Duration
+	// ApplicationLabelKeys are the keys of application labels copied onto
+	// the auto-created IPPools, for chargeback or auditing.
+	ApplicationLabelKeys []string
+	// ListPageSize and ListPageInterval control the pagination of
+	// IterateSubnets.
+	ListPageSize     int64
+	ListPageInterval time.Duration
 }
 
 func setDefaultsForSubnetManagerConfig(config SubnetManagerConfig) SubnetManagerConfig {
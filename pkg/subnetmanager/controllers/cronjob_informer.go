@@ -29,6 +29,10 @@ func (c *Controller) onCronJobAdd(obj interface{}) {
 }
 
 func (c *Controller) onCronJobUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
 	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
 	if nil != err {
 		controllersLogger.Sugar().Errorf("onCronJobUpdate: %v", err)
@@ -29,6 +29,10 @@ func (c *Controller) onDaemonSetAdd(obj interface{}) {
 }
 
 func (c *Controller) onDaemonSetUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
 	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
 	if nil != err {
 		controllersLogger.Sugar().Errorf("onDaemonSetUpdate: %v", err)
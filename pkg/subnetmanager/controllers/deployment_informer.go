@@ -29,6 +29,10 @@ func (c *Controller) onDeploymentAdd(obj interface{}) {
 }
 
 func (c *Controller) onDeploymentUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
 	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
 	if nil != err {
 		controllersLogger.Sugar().Errorf("onDeploymentUpdate: %v", err)
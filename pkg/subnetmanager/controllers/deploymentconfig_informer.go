@@ -0,0 +1,47 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+func (c *Controller) AddDeploymentConfigHandler(informer cache.SharedIndexInformer) {
+	controllersLogger.Info("Setting up DeploymentConfig handlers")
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onDeploymentConfigAdd,
+		UpdateFunc: c.onDeploymentConfigUpdate,
+		DeleteFunc: c.onDeploymentConfigDelete,
+	})
+}
+
+func (c *Controller) onDeploymentConfigAdd(obj interface{}) {
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), nil, obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onDeploymentConfigAdd: %v", err)
+	}
+}
+
+func (c *Controller) onDeploymentConfigUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onDeploymentConfigUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onDeploymentConfigDelete(obj interface{}) {
+	err := c.cleanupFunc(logutils.IntoContext(context.TODO(), controllersLogger), obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onDeploymentConfigDelete: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+)
+
+// PendingApps records the applications whose auto-created IPPools are
+// pending on the SpiderSubnets that don't exist yet, indexed by the name of
+// SpiderSubnet, so that they are reconciled again once the SpiderSubnet is
+// created rather than retried until the workqueue gives up.
+type PendingApps struct {
+	lock lock.Mutex
+	apps map[string]map[interface{}]struct{}
+}
+
+func NewPendingApps() *PendingApps {
+	return &PendingApps{
+		apps: map[string]map[interface{}]struct{}{},
+	}
+}
+
+// Add records that the application of the key is pending on the SpiderSubnet,
+// it returns false if the application is already pending on it.
+func (p *PendingApps) Add(subnetName string, key interface{}) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	keys, ok := p.apps[subnetName]
+	if !ok {
+		keys = map[interface{}]struct{}{}
+		p.apps[subnetName] = keys
+	}
+	if _, ok := keys[key]; ok {
+		return false
+	}
+	keys[key] = struct{}{}
+
+	return true
+}
+
+// Pop returns and drops the keys of applications pending on the SpiderSubnet.
+func (p *PendingApps) Pop(subnetName string) []interface{} {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	keys, ok := p.apps[subnetName]
+	if !ok {
+		return nil
+	}
+	delete(p.apps, subnetName)
+
+	result := make([]interface{}, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+
+	return result
+}
+
+// Len returns the count of applications pending on the SpiderSubnets.
+func (p *PendingApps) Len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var n int
+	for _, keys := range p.apps {
+		n += len(keys)
+	}
+
+	return n
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+)
+
+var _ = Describe("PendingApps", Label("pending_test"), func() {
+	It("records the application pending on the SpiderSubnet once", func() {
+		pending := controllers.NewPendingApps()
+
+		Expect(pending.Add("subnet", "Deployment/default/app")).To(BeTrue())
+		Expect(pending.Add("subnet", "Deployment/default/app")).To(BeFalse())
+		Expect(pending.Add("other-subnet", "Deployment/default/app")).To(BeTrue())
+		Expect(pending.Len()).To(Equal(2))
+	})
+
+	It("pops the applications pending on the SpiderSubnet", func() {
+		pending := controllers.NewPendingApps()
+		pending.Add("subnet", "Deployment/default/app")
+		pending.Add("subnet", "StatefulSet/default/app")
+		pending.Add("other-subnet", "Deployment/default/other")
+
+		Expect(pending.Pop("subnet")).To(ConsistOf("Deployment/default/app", "StatefulSet/default/app"))
+		Expect(pending.Pop("subnet")).To(BeEmpty())
+		Expect(pending.Len()).To(Equal(1))
+	})
+
+	It("pops nothing for the SpiderSubnet without pending applications", func() {
+		pending := controllers.NewPendingApps()
+		Expect(pending.Pop("subnet")).To(BeNil())
+	})
+})
@@ -0,0 +1,99 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// appUpdatePredicate only lets through the updates of the applications
+// changing their spec, e.g. the Pod template, their replicas or their
+// Spiderpool annotations. The other status updates, which are the majority on
+// busy clusters, are dropped before the subnet configuration is parsed.
+var appUpdatePredicate = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	ReplicasChangedPredicate{},
+	SpiderpoolAnnotationChangedPredicate{},
+)
+
+// ReplicasChangedPredicate lets through the updates changing the replicas of
+// the applications, including the ones not in their spec, e.g. the number of
+// the nodes a DaemonSet is scheduled to.
+type ReplicasChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements default UpdateEvent filter for the changes of the
+// replicas.
+func (ReplicasChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldReplicas, _ := types.PodTopController{APP: e.ObjectOld}.Replicas()
+	newReplicas, _ := types.PodTopController{APP: e.ObjectNew}.Replicas()
+
+	return oldReplicas != newReplicas
+}
+
+// SpiderpoolAnnotationChangedPredicate lets through the updates changing the
+// annotations prefixed with constant.AnnotationPre.
+type SpiderpoolAnnotationChangedPredicate struct {
+	predicate.Funcs
+}
+
+// Update implements default UpdateEvent filter for the changes of the
+// Spiderpool annotations.
+func (SpiderpoolAnnotationChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	oldAnnotations := spiderpoolAnnotations(e.ObjectOld.GetAnnotations())
+	newAnnotations := spiderpoolAnnotations(e.ObjectNew.GetAnnotations())
+	if len(oldAnnotations) != len(newAnnotations) {
+		return true
+	}
+	for k, v := range newAnnotations {
+		if oldValue, ok := oldAnnotations[k]; !ok || oldValue != v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func spiderpoolAnnotations(annotations map[string]string) map[string]string {
+	result := map[string]string{}
+	for k, v := range annotations {
+		if strings.HasPrefix(k, constant.AnnotationPre+"/") {
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
+// ShouldReconcileUpdate tells whether the update of the application should be
+// reconciled. The objects which are not recognized are always reconciled, so
+// that their errors are reported.
+func ShouldReconcileUpdate(oldObj, newObj interface{}) bool {
+	oldApp, ok := oldObj.(client.Object)
+	if !ok {
+		return true
+	}
+	newApp, ok := newObj.(client.Object)
+	if !ok {
+		return true
+	}
+
+	return appUpdatePredicate.Update(event.UpdateEvent{ObjectOld: oldApp, ObjectNew: newApp})
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+)
+
+var _ = Describe("Predicate", Label("predicate_test"), func() {
+	Describe("ShouldReconcileUpdate", func() {
+		var deployT *appsv1.Deployment
+
+		BeforeEach(func() {
+			deployT = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deploy",
+					Namespace:   "default",
+					Generation:  1,
+					Annotations: map[string]string{"foo": "bar"},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: pointer.Int32(2),
+				},
+			}
+		})
+
+		It("drops the status updates", func() {
+			newDeploy := deployT.DeepCopy()
+			newDeploy.Status.ReadyReplicas = 2
+			Expect(controllers.ShouldReconcileUpdate(deployT, newDeploy)).To(BeFalse())
+		})
+
+		It("drops the changes of the other annotations", func() {
+			newDeploy := deployT.DeepCopy()
+			newDeploy.Annotations["foo"] = "baz"
+			Expect(controllers.ShouldReconcileUpdate(deployT, newDeploy)).To(BeFalse())
+		})
+
+		It("reconciles the spec changes", func() {
+			newDeploy := deployT.DeepCopy()
+			newDeploy.Generation = 2
+			newDeploy.Spec.Replicas = pointer.Int32(3)
+			Expect(controllers.ShouldReconcileUpdate(deployT, newDeploy)).To(BeTrue())
+		})
+
+		It("reconciles the changes of the Spiderpool annotations", func() {
+			newDeploy := deployT.DeepCopy()
+			newDeploy.Annotations[constant.AnnoReconcile] = constant.AnnoReconcilePaused
+			Expect(controllers.ShouldReconcileUpdate(deployT, newDeploy)).To(BeTrue())
+
+			Expect(controllers.ShouldReconcileUpdate(newDeploy, deployT)).To(BeTrue())
+		})
+
+		It("reconciles the changes of the replicas in status", func() {
+			daemonSet := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "default", Generation: 1},
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2},
+			}
+			newDaemonSet := daemonSet.DeepCopy()
+			newDaemonSet.Status.NumberReady = 2
+			Expect(controllers.ShouldReconcileUpdate(daemonSet, newDaemonSet)).To(BeFalse())
+
+			newDaemonSet.Status.DesiredNumberScheduled = 3
+			Expect(controllers.ShouldReconcileUpdate(daemonSet, newDaemonSet)).To(BeTrue())
+		})
+
+		It("reconciles the unrecognized objects", func() {
+			Expect(controllers.ShouldReconcileUpdate(nil, deployT)).To(BeTrue())
+		})
+	})
+})
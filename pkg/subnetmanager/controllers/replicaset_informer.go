@@ -29,6 +29,10 @@ func (c *Controller) onReplicaSetAdd(obj interface{}) {
 }
 
 func (c *Controller) onReplicaSetUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
 	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
 	if nil != err {
 		controllersLogger.Sugar().Errorf("onReplicaSetUpdate: %v", err)
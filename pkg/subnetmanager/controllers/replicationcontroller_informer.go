@@ -0,0 +1,47 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+func (c *Controller) AddReplicationControllerHandler(informer cache.SharedIndexInformer) {
+	controllersLogger.Info("Setting up ReplicationController handlers")
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onReplicationControllerAdd,
+		UpdateFunc: c.onReplicationControllerUpdate,
+		DeleteFunc: c.onReplicationControllerDelete,
+	})
+}
+
+func (c *Controller) onReplicationControllerAdd(obj interface{}) {
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), nil, obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onReplicationControllerAdd: %v", err)
+	}
+}
+
+func (c *Controller) onReplicationControllerUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
+	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onReplicationControllerUpdate: %v", err)
+	}
+}
+
+func (c *Controller) onReplicationControllerDelete(obj interface{}) {
+	err := c.cleanupFunc(logutils.IntoContext(context.TODO(), controllersLogger), obj)
+	if nil != err {
+		controllersLogger.Sugar().Errorf("onReplicationControllerDelete: %v", err)
+	}
+}
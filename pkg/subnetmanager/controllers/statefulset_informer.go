@@ -29,6 +29,10 @@ func (c *Controller) onStatefulSetAdd(obj interface{}) {
 }
 
 func (c *Controller) onStatefulSetUpdate(oldObj interface{}, newObj interface{}) {
+	if !ShouldReconcileUpdate(oldObj, newObj) {
+		return
+	}
+
 	err := c.reconcileFunc(logutils.IntoContext(context.TODO(), controllersLogger), oldObj, newObj)
 	if nil != err {
 		controllersLogger.Sugar().Errorf("onStatefulSetUpdate: %v", err)
@@ -0,0 +1,84 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/spidernet-io/spiderpool/pkg/lock"
+)
+
+// ReconcileThrottle limits each application to be reconciled at most once
+// per interval, so that an application flapping its replicas, e.g. a
+// crash-looping Deployment, doesn't resize its auto-created IPPools over and
+// over again. A zero interval disables the throttle.
+type ReconcileThrottle struct {
+	interval time.Duration
+
+	lock           lock.Mutex
+	lastReconciled map[interface{}]time.Time
+	lastPruned     time.Time
+}
+
+func NewReconcileThrottle(interval time.Duration) *ReconcileThrottle {
+	return &ReconcileThrottle{
+		interval:       interval,
+		lastReconciled: map[interface{}]time.Time{},
+	}
+}
+
+// Delay returns how long the reconciliation of the key should be postponed,
+// zero means it could be reconciled right now.
+func (t *ReconcileThrottle) Delay(key interface{}, now time.Time) time.Duration {
+	if t.interval <= 0 {
+		return 0
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	last, ok := t.lastReconciled[key]
+	if !ok {
+		return 0
+	}
+
+	delay := t.interval - now.Sub(last)
+	if delay <= 0 {
+		delete(t.lastReconciled, key)
+		return 0
+	}
+
+	return delay
+}
+
+// Reconciled records that the key is reconciled at the time. The records
+// older than the interval are pruned at most once per interval.
+func (t *ReconcileThrottle) Reconciled(key interface{}, now time.Time) {
+	if t.interval <= 0 {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.lastReconciled[key] = now
+	if now.Sub(t.lastPruned) < t.interval {
+		return
+	}
+
+	for k, last := range t.lastReconciled {
+		if now.Sub(last) >= t.interval {
+			delete(t.lastReconciled, k)
+		}
+	}
+	t.lastPruned = now
+}
+
+// Forget drops the record of the key, e.g. the application is deleted.
+func (t *ReconcileThrottle) Forget(key interface{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.lastReconciled, key)
+}
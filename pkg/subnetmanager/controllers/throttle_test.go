@@ -0,0 +1,59 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+)
+
+var _ = Describe("ReconcileThrottle", Label("throttle_test"), func() {
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Now()
+	})
+
+	It("reconciles the application never reconciled right now", func() {
+		throttle := controllers.NewReconcileThrottle(10 * time.Second)
+		Expect(throttle.Delay("Deployment/default/app", now)).To(BeZero())
+	})
+
+	It("postpones the application reconciled within the interval", func() {
+		throttle := controllers.NewReconcileThrottle(10 * time.Second)
+		throttle.Reconciled("Deployment/default/app", now)
+
+		Expect(throttle.Delay("Deployment/default/app", now.Add(4*time.Second))).To(Equal(6 * time.Second))
+		Expect(throttle.Delay("Deployment/default/other", now.Add(4*time.Second))).To(BeZero())
+		Expect(throttle.Delay("Deployment/default/app", now.Add(10*time.Second))).To(BeZero())
+	})
+
+	It("forgets the application", func() {
+		throttle := controllers.NewReconcileThrottle(10 * time.Second)
+		throttle.Reconciled("Deployment/default/app", now)
+		throttle.Forget("Deployment/default/app")
+
+		Expect(throttle.Delay("Deployment/default/app", now)).To(BeZero())
+	})
+
+	It("prunes the stale records", func() {
+		throttle := controllers.NewReconcileThrottle(10 * time.Second)
+		throttle.Reconciled("Deployment/default/app", now)
+		throttle.Reconciled("Deployment/default/other", now.Add(20*time.Second))
+
+		Expect(throttle.Delay("Deployment/default/app", now.Add(21*time.Second))).To(BeZero())
+		Expect(throttle.Delay("Deployment/default/other", now.Add(21*time.Second))).To(Equal(9 * time.Second))
+	})
+
+	It("disables the throttle with zero interval", func() {
+		throttle := controllers.NewReconcileThrottle(0)
+		throttle.Reconciled("Deployment/default/app", now)
+
+		Expect(throttle.Delay("Deployment/default/app", now)).To(BeZero())
+	})
+})
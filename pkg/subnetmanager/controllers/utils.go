@@ -12,6 +12,8 @@ import (
 	"strings"
 
 	"go.uber.org/zap"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 
@@ -20,8 +22,12 @@ import (
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/singletons"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
 )
 
+// maxSubnetBlockBits limits a reserved SpiderSubnet block to 2^16 IP addresses at most.
+const maxSubnetBlockBits = 16
+
 var errInvalidInput = func(str string) error {
 	return fmt.Errorf("invalid input '%s'", str)
 }
@@ -58,12 +64,39 @@ func ParseAppLabelValue(str string) (appKind, appNS, appName string, isFound boo
 	return
 }
 
-func GetAppReplicas(replicas *int32) int {
-	if replicas == nil {
-		return 0
+// GetHPATargetAppKind returns the kind of the application scaled by the
+// HorizontalPodAutoscaler, only the ones whose IPPools are sized with their
+// replicas are supported.
+func GetHPATargetAppKind(ref autoscalingv2.CrossVersionObjectReference) (string, bool) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || gv.Group != "apps" {
+		return "", false
+	}
+
+	switch ref.Kind {
+	case constant.KindDeployment, constant.KindReplicaSet, constant.KindStatefulSet:
+		return ref.Kind, true
+	default:
+		return "", false
+	}
+}
+
+// GetHPAScaledReplicas returns the larger one of the replicas of the
+// application and the desired replicas of the HorizontalPodAutoscalers
+// scaling it, so that its IPPools are expanded before the HPAs scale it up.
+func GetHPAScaledReplicas(appKind, appName string, appReplicas int, hpas []*autoscalingv2.HorizontalPodAutoscaler) int {
+	for _, hpa := range hpas {
+		kind, ok := GetHPATargetAppKind(hpa.Spec.ScaleTargetRef)
+		if !ok || kind != appKind || hpa.Spec.ScaleTargetRef.Name != appName {
+			continue
+		}
+
+		if desired := int(hpa.Status.DesiredReplicas); desired > appReplicas {
+			appReplicas = desired
+		}
 	}
 
-	return int(*replicas)
+	return appReplicas
 }
 
 func GenSubnetFreeIPs(subnet *spiderpoolv1.SpiderSubnet) ([]net.IP, error) {
@@ -85,11 +118,192 @@ func GenSubnetFreeIPs(subnet *spiderpoolv1.SpiderSubnet) ([]net.IP, error) {
 	return freeIPs, nil
 }
 
+// ValidateIPPoolAdoption checks whether the orphan IPPool could be adopted by the SpiderSubnet. The total IP
+// addresses of the IPPool and the IP addresses in use of it must be contained in the SpiderSubnet, and the total
+// IP addresses must not overlap with the ones of the IPPools already controlled by the SpiderSubnet.
+func ValidateIPPoolAdoption(subnet *spiderpoolv1.SpiderSubnet, pool *spiderpoolv1.SpiderIPPool, controlledPools []*spiderpoolv1.SpiderIPPool) error {
+	version := *subnet.Spec.IPVersion
+	if pool.Spec.IPVersion == nil || *pool.Spec.IPVersion != version {
+		return fmt.Errorf("the IP version of IPPool %s mismatches with SpiderSubnet %s", pool.Name, subnet.Name)
+	}
+
+	subnetTotalIPs, err := spiderpoolip.AssembleTotalIPSet(version, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+	if err != nil {
+		return err
+	}
+	poolTotalIPs, err := spiderpoolip.AssembleTotalIPSet(version, pool.Spec.IPs, pool.Spec.ExcludeIPs)
+	if err != nil {
+		return err
+	}
+
+	if outIPs := poolTotalIPs.Difference(subnetTotalIPs); outIPs.Len() > 0 {
+		ranges, _ := spiderpoolip.ConvertIPsToIPRanges(version, outIPs.List(false))
+		return fmt.Errorf("the IP ranges %v of IPPool %s are not contained in SpiderSubnet %s", ranges, pool.Name, subnet.Name)
+	}
+
+	for ip, allocation := range pool.Status.AllocatedIPs {
+		if !subnetTotalIPs.Contains(net.ParseIP(ip)) {
+			return fmt.Errorf("the IP address %s of IPPool %s allocated to Pod %s/%s is not contained in SpiderSubnet %s", ip, pool.Name, allocation.Namespace, allocation.Pod, subnet.Name)
+		}
+	}
+
+	for _, controlledPool := range controlledPools {
+		if controlledPool.Name == pool.Name {
+			continue
+		}
+
+		controlledIPs, err := spiderpoolip.AssembleTotalIPSet(version, controlledPool.Spec.IPs, controlledPool.Spec.ExcludeIPs)
+		if err != nil {
+			return err
+		}
+		if overlapIPs := poolTotalIPs.Intersection(controlledIPs); overlapIPs.Len() > 0 {
+			ranges, _ := spiderpoolip.ConvertIPsToIPRanges(version, overlapIPs.List(false))
+			return fmt.Errorf("IPPool %s cannot be adopted by SpiderSubnet %s: %w", pool.Name, subnet.Name,
+				&constant.OverlapError{Kind: constant.SpiderIPPoolKind, Name: controlledPool.Name, Ranges: ranges})
+		}
+	}
+
+	return nil
+}
+
+// GenSubnetReservedBlockIPs returns the SpiderSubnet IPs which are in the blocks reserved for the other IPPools.
+func GenSubnetReservedBlockIPs(subnet *spiderpoolv1.SpiderSubnet, poolName string) ([]net.IP, error) {
+	if len(subnet.Status.ReservedBlocks) == 0 {
+		return nil, nil
+	}
+
+	blocks, err := parseReservedBlocks(subnet, poolName)
+	if err != nil {
+		return nil, err
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockIPs []net.IP
+	for _, ip := range totalIPs {
+		for _, block := range blocks {
+			if block.Contains(ip) {
+				blockIPs = append(blockIPs, ip)
+				break
+			}
+		}
+	}
+
+	return blockIPs, nil
+}
+
+// SelectSubnetBlock picks the first aligned block with the given prefix length from the SpiderSubnet for the IPPool,
+// the block must not overlap with the blocks reserved for the other IPPools or contain the IPs pre-allocated to them.
+// It returns an empty string if there is no such block left.
+func SelectSubnetBlock(subnet *spiderpoolv1.SpiderSubnet, poolName string, prefixLength int) (string, error) {
+	_, subnetNet, err := net.ParseCIDR(subnet.Spec.Subnet)
+	if err != nil {
+		return "", err
+	}
+	_, bits := subnetNet.Mask.Size()
+	mask := net.CIDRMask(prefixLength, bits)
+
+	var used []string
+	for name, preAllocation := range subnet.Status.ControlledIPPools {
+		if name == poolName {
+			continue
+		}
+		used = append(used, preAllocation.IPs...)
+	}
+	usedIPs, err := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, used)
+	if err != nil {
+		return "", err
+	}
+
+	blocks, err := parseReservedBlocks(subnet, poolName)
+	if err != nil {
+		return "", err
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
+	if err != nil {
+		return "", err
+	}
+
+	checked := map[string]struct{}{}
+	for _, ip := range spiderpoolip.IPsDiffSet(totalIPs, nil, true) {
+		candidate := &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+		if _, ok := checked[candidate.String()]; ok {
+			continue
+		}
+		checked[candidate.String()] = struct{}{}
+
+		if isBlockAvailable(candidate, blocks, usedIPs) {
+			return candidate.String(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// IsValidSubnetBlockSize checks whether a block with the given prefix length fits in the SpiderSubnet CIDR.
+func IsValidSubnetBlockSize(subnetCIDR string, prefixLength int) bool {
+	_, subnetNet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return false
+	}
+	ones, bits := subnetNet.Mask.Size()
+
+	return prefixLength > ones && prefixLength <= bits && bits-prefixLength <= maxSubnetBlockBits
+}
+
+func parseReservedBlocks(subnet *spiderpoolv1.SpiderSubnet, poolName string) ([]*net.IPNet, error) {
+	var blocks []*net.IPNet
+	for name, block := range subnet.Status.ReservedBlocks {
+		if name == poolName {
+			continue
+		}
+		_, blockNet, err := net.ParseCIDR(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the reserved block '%s' of IPPool '%s': %w", block, name, err)
+		}
+		blocks = append(blocks, blockNet)
+	}
+
+	return blocks, nil
+}
+
+func isBlockAvailable(candidate *net.IPNet, blocks []*net.IPNet, usedIPs []net.IP) bool {
+	for _, block := range blocks {
+		if block.Contains(candidate.IP) || candidate.Contains(block.IP) {
+			return false
+		}
+	}
+	for _, ip := range usedIPs {
+		if candidate.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // GetSubnetAnnoConfig generates SpiderSubnet configuration from pod annotation,
 // if the pod doesn't have the related subnet annotation but has IPPools/IPPool relative annotation it will return nil.
 // If the pod doesn't have any subnet/ippool annotations, it will use the cluster default subnet configuration.
-func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*types.PodSubnetAnnoConfig, error) {
+// The annotation "ipam.spidernet.io/config" is converted into the separate annotations first.
+// The SpiderSubnet annotations of the application object itself, e.g. the Deployment rather than its pod template,
+// serve as defaults, and then the ones of the pod's Namespace, refer to MergeSubnetAnnotations. So that the
+// application could be patched to change its SpiderSubnets without rolling out its pods.
+func GetSubnetAnnoConfig(podAnnotations, appAnnotations, nsAnnotations map[string]string, log *zap.Logger) (*types.PodSubnetAnnoConfig, error) {
 	var subnetAnnoConfig types.PodSubnetAnnoConfig
+	podAnnotations, err := annotation.ConvertPodConfig(podAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	appAnnotations, err = annotation.ConvertPodConfig(appAnnotations)
+	if err != nil {
+		return nil, err
+	}
+	podAnnotations = MergeSubnetAnnotations(MergeSubnetAnnotations(podAnnotations, appAnnotations), nsAnnotations)
 
 	// annotation: ipam.spidernet.io/subnets
 	subnets, ok := podAnnotations[constant.AnnoSpiderSubnets]
@@ -97,7 +311,7 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 		log.Sugar().Debugf("found SpiderSubnet feature annotation '%s' value '%s'", constant.AnnoSpiderSubnets, subnets)
 		err := json.Unmarshal([]byte(subnets), &subnetAnnoConfig.MultipleSubnets)
 		if nil != err {
-			return nil, fmt.Errorf("failed to parse anntation '%s' value '%s', error: %v", constant.AnnoSpiderSubnets, subnets, err)
+			return nil, fmt.Errorf("failed to parse anntation '%s' value '%s', error: %w", constant.AnnoSpiderSubnets, subnets, err)
 		}
 	} else {
 		// annotation: ipam.spidernet.io/subnet
@@ -107,7 +321,7 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 			subnetAnnoConfig.SingleSubnet = new(types.AnnoSubnetItem)
 			err := json.Unmarshal([]byte(subnet), &subnetAnnoConfig.SingleSubnet)
 			if nil != err {
-				return nil, fmt.Errorf("failed to parse anntation '%s' value '%s', error: %v", constant.AnnoSpiderSubnet, subnet, err)
+				return nil, fmt.Errorf("failed to parse anntation '%s' value '%s', error: %w", constant.AnnoSpiderSubnet, subnet, err)
 			}
 		} else {
 			log.Debug("no SpiderSubnet feature annotation found, use default IPAM mode")
@@ -117,7 +331,6 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 
 	var isFlexible bool
 	var ipNum int
-	var err error
 
 	// annotation: ipam.spidernet.io/ippool-ip-number
 	poolIPNum, ok := podAnnotations[constant.AnnoSpiderSubnetPoolIPNumber]
@@ -145,13 +358,20 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 		subnetAnnoConfig.FlexibleIPNum = pointer.Int(singletons.ClusterDefaultPool.ClusterSubnetDefaultFlexibleIPNumber)
 	}
 
-	// annotation: "ipam.spidernet.io/reclaim-ippool", reclaim IPPool or not (default true)
-	reclaimPool, err := ShouldReclaimIPPool(podAnnotations)
+	// annotation: "ipam.spidernet.io/ippool-reclaim", reclaim IPPool or not (default to the cluster default)
+	reclaimPool, err := ShouldReclaimIPPool(podAnnotations, nil, nil)
 	if nil != err {
 		return nil, err
 	}
 	subnetAnnoConfig.ReclaimIPPool = reclaimPool
 
+	// annotation: "ipam.spidernet.io/subnet-block-size", reserve a contiguous block for the auto-created IPPool
+	blockPrefixLength, err := GetSubnetBlockSize(podAnnotations)
+	if nil != err {
+		return nil, err
+	}
+	subnetAnnoConfig.BlockPrefixLength = blockPrefixLength
+
 	err = mutateAndValidateSubnetAnno(&subnetAnnoConfig)
 	if nil != err {
 		return nil, err
@@ -160,6 +380,48 @@ func GetSubnetAnnoConfig(podAnnotations map[string]string, log *zap.Logger) (*ty
 	return &subnetAnnoConfig, nil
 }
 
+// MergeSubnetAnnotations merges the SpiderSubnet annotations of the Namespace
+// into the pod annotations as defaults, and the pod-level values take precedence.
+// The subnet annotations "ipam.spidernet.io/subnet" and "ipam.spidernet.io/subnets"
+// are inherited as a whole, only if the pod specifies neither subnets nor IPPools.
+// The others, such as "ipam.spidernet.io/ippool-ip-number", are inherited one by one.
+func MergeSubnetAnnotations(podAnnotations, nsAnnotations map[string]string) map[string]string {
+	if len(nsAnnotations) == 0 {
+		return podAnnotations
+	}
+
+	merged := make(map[string]string, len(podAnnotations))
+	for k, v := range podAnnotations {
+		merged[k] = v
+	}
+
+	selected := false
+	for _, k := range []string{constant.AnnoSpiderSubnets, constant.AnnoSpiderSubnet, constant.AnnoPodIPPools, constant.AnnoPodIPPool} {
+		if _, ok := podAnnotations[k]; ok {
+			selected = true
+			break
+		}
+	}
+	if !selected {
+		for _, k := range []string{constant.AnnoSpiderSubnets, constant.AnnoSpiderSubnet} {
+			if v, ok := nsAnnotations[k]; ok {
+				merged[k] = v
+			}
+		}
+	}
+
+	for _, k := range []string{constant.AnnoSpiderSubnetPoolIPNumber, constant.AnnoSpiderSubnetReclaimIPPool, constant.AnnoSpiderSubnetBlockSize} {
+		if _, ok := podAnnotations[k]; ok {
+			continue
+		}
+		if v, ok := nsAnnotations[k]; ok {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
 // mutateAndValidateSubnetAnno will filter multiple subnets you specified and only leaves you the first one to use.
 // And it also checks Interface name or subnets you specified whether are duplicate.
 func mutateAndValidateSubnetAnno(subnetConfig *types.PodSubnetAnnoConfig) error {
@@ -253,42 +515,6 @@ func GetPoolIPNumber(str string) (isFlexible bool, ipNum int, err error) {
 	return false, -1, errInvalidInput(str)
 }
 
-// CalculateJobPodNum will calculate the job replicas
-// once Parallelism and Completions are unset, the API-server will set them to 1
-// reference: https://kubernetes.io/docs/concepts/workloads/controllers/job/
-func CalculateJobPodNum(jobSpecParallelism, jobSpecCompletions *int32) int {
-	switch {
-	case jobSpecParallelism != nil && jobSpecCompletions == nil:
-		// parallel Jobs with a work queue
-		if *jobSpecParallelism == 0 {
-			return 1
-		}
-
-		// ignore negative integer, cause API-server will refuse the job creation
-		return int(*jobSpecParallelism)
-
-	case jobSpecParallelism == nil && jobSpecCompletions != nil:
-		// non-parallel Jobs
-		if *jobSpecCompletions == 0 {
-			return 1
-		}
-
-		// ignore negative integer, cause API-server will refuse the job creation
-		return int(*jobSpecCompletions)
-
-	case jobSpecParallelism != nil && jobSpecCompletions != nil:
-		// parallel Jobs with a fixed completion count
-		if *jobSpecCompletions == 0 {
-			return 1
-		}
-
-		// ignore negative integer, cause API-server will refuse the job creation
-		return int(*jobSpecCompletions)
-	}
-
-	return 1
-}
-
 // IsDefaultIPPoolMode judges whether we use subnet feature or not with the given parameter types.PodSubnetAnnoConfig
 func IsDefaultIPPoolMode(subnetConfig *types.PodSubnetAnnoConfig) bool {
 	if subnetConfig == nil {
@@ -319,17 +545,39 @@ func containsDuplicate(arr []string) bool {
 	return false
 }
 
-// ShouldReclaimIPPool will check pod annotation "ipam.spidernet.io/ippool-reclaim"
-func ShouldReclaimIPPool(anno map[string]string) (bool, error) {
-	reclaimPool, ok := anno[constant.AnnoSpiderSubnetReclaimIPPool]
-	if ok {
+// ShouldReclaimIPPool will check the annotation "ipam.spidernet.io/ippool-reclaim" of the pod, and then the ones of
+// its application and Namespace in turn, the first one specified takes effect. If none of them specifies it, the
+// cluster default 'clusterSubnetDefaultReclaimIPPool' of ConfigMap spiderpool-conf is used.
+func ShouldReclaimIPPool(podAnnotations, appAnnotations, nsAnnotations map[string]string) (bool, error) {
+	for _, anno := range []map[string]string{podAnnotations, appAnnotations, nsAnnotations} {
+		reclaimPool, ok := anno[constant.AnnoSpiderSubnetReclaimIPPool]
+		if !ok {
+			continue
+		}
+
 		parseBool, err := strconv.ParseBool(reclaimPool)
 		if nil != err {
-			return false, fmt.Errorf("failed to parse spider subnet '%s', error: %v", constant.AnnoSpiderSubnetReclaimIPPool, err)
+			return false, fmt.Errorf("failed to parse spider subnet '%s', error: %w", constant.AnnoSpiderSubnetReclaimIPPool, err)
 		}
 		return parseBool, nil
 	}
 
-	// no specified reclaim-IPPool, default to set it true
-	return true, nil
+	return singletons.ClusterDefaultPool.ClusterSubnetDefaultReclaimIPPool, nil
+}
+
+// GetSubnetBlockSize will check pod annotation "ipam.spidernet.io/subnet-block-size" and return the prefix length of
+// the block, such as 27 for '/27'. It returns 0 if no block is required.
+func GetSubnetBlockSize(anno map[string]string) (int, error) {
+	blockSize, ok := anno[constant.AnnoSpiderSubnetBlockSize]
+	if !ok {
+		return 0, nil
+	}
+
+	prefixLength, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(blockSize), "/"))
+	if nil != err || prefixLength <= 0 || prefixLength > 128 {
+		return 0, fmt.Errorf("%w: failed to parse spider subnet '%s' value '%s', it should be a prefix length such as '/27'",
+			constant.ErrWrongInput, constant.AnnoSpiderSubnetBlockSize, blockSize)
+	}
+
+	return prefixLength, nil
 }
@@ -0,0 +1,516 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/singletons"
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+const (
+	podSubnet = `{"ipv4":["pod-subnet"]}`
+	nsSubnet  = `{"ipv4":["ns-subnet"]}`
+	nsSubnets = `[{"interface":"eth0","ipv4":["ns-subnet"]},{"interface":"net1","ipv4":["ns-subnet1"]}]`
+	podPool   = `{"ipv4":["pod-ippool"]}`
+)
+
+var _ = Describe("Controllers utils", Label("utils_test"), func() {
+	DescribeTable("MergeSubnetAnnotations",
+		func(podAnno, nsAnno, expected map[string]string) {
+			Expect(controllers.MergeSubnetAnnotations(podAnno, nsAnno)).To(Equal(expected))
+		},
+		Entry("no Namespace annotations",
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+			nil,
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+		),
+		Entry("inherits the subnet of the Namespace",
+			map[string]string{"foo": "bar"},
+			map[string]string{constant.AnnoSpiderSubnet: nsSubnet, "ns": "anno"},
+			map[string]string{"foo": "bar", constant.AnnoSpiderSubnet: nsSubnet},
+		),
+		Entry("inherits the subnets of the Namespace",
+			nil,
+			map[string]string{constant.AnnoSpiderSubnets: nsSubnets},
+			map[string]string{constant.AnnoSpiderSubnets: nsSubnets},
+		),
+		Entry("the subnet of the Pod takes precedence",
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+			map[string]string{constant.AnnoSpiderSubnet: nsSubnet},
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+		),
+		Entry("the subnet of the Pod takes precedence over the subnets of the Namespace",
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+			map[string]string{constant.AnnoSpiderSubnets: nsSubnets},
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+		),
+		Entry("the IPPool of the Pod takes precedence over the subnet of the Namespace",
+			map[string]string{constant.AnnoPodIPPool: podPool},
+			map[string]string{constant.AnnoSpiderSubnet: nsSubnet},
+			map[string]string{constant.AnnoPodIPPool: podPool},
+		),
+		Entry("the IPPools of the Pod take precedence over the subnets of the Namespace",
+			map[string]string{constant.AnnoPodIPPools: `[` + podPool + `]`},
+			map[string]string{constant.AnnoSpiderSubnets: nsSubnets},
+			map[string]string{constant.AnnoPodIPPools: `[` + podPool + `]`},
+		),
+		Entry("inherits the other annotations one by one",
+			map[string]string{constant.AnnoSpiderSubnet: podSubnet, constant.AnnoSpiderSubnetPoolIPNumber: "2"},
+			map[string]string{
+				constant.AnnoSpiderSubnet:              nsSubnet,
+				constant.AnnoSpiderSubnetPoolIPNumber:  "+3",
+				constant.AnnoSpiderSubnetReclaimIPPool: "false",
+				constant.AnnoSpiderSubnetBlockSize:     "/28",
+			},
+			map[string]string{
+				constant.AnnoSpiderSubnet:              podSubnet,
+				constant.AnnoSpiderSubnetPoolIPNumber:  "2",
+				constant.AnnoSpiderSubnetReclaimIPPool: "false",
+				constant.AnnoSpiderSubnetBlockSize:     "/28",
+			},
+		),
+	)
+
+	Describe("ShouldReclaimIPPool", func() {
+		var originalDefault bool
+
+		BeforeEach(func() {
+			originalDefault = singletons.ClusterDefaultPool.ClusterSubnetDefaultReclaimIPPool
+			DeferCleanup(func() {
+				singletons.ClusterDefaultPool.ClusterSubnetDefaultReclaimIPPool = originalDefault
+			})
+		})
+
+		reclaim := func(value string) map[string]string {
+			return map[string]string{constant.AnnoSpiderSubnetReclaimIPPool: value}
+		}
+
+		DescribeTable("consults the Pod, the application, the Namespace and the cluster default in turn",
+			func(clusterDefault bool, podAnno, appAnno, nsAnno map[string]string, expected bool) {
+				singletons.ClusterDefaultPool.ClusterSubnetDefaultReclaimIPPool = clusterDefault
+
+				reclaimIPPool, err := controllers.ShouldReclaimIPPool(podAnno, appAnno, nsAnno)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reclaimIPPool).To(Equal(expected))
+			},
+			Entry("the Pod takes precedence", false, reclaim("true"), reclaim("false"), reclaim("false"), true),
+			Entry("the application takes precedence over the Namespace", false, map[string]string{"foo": "bar"}, reclaim("true"), reclaim("false"), true),
+			Entry("the Namespace takes precedence over the cluster default", false, nil, nil, reclaim("true"), true),
+			Entry("the Namespace of false takes precedence over the cluster default of true", true, nil, nil, reclaim("false"), false),
+			Entry("the cluster default", false, nil, map[string]string{"foo": "bar"}, nil, false),
+			Entry("the cluster default of true", true, nil, nil, nil, true),
+			Entry("the cluster default of true without the annotation", true, map[string]string{"foo": "bar"}, map[string]string{"foo": "bar"}, map[string]string{"foo": "bar"}, true),
+		)
+
+		It("fails to parse the annotation", func() {
+			_, err := controllers.ShouldReclaimIPPool(nil, reclaim("invalid"), reclaim("true"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("GetSubnetAnnoConfig", func() {
+		It("does not modify the Pod annotations", func() {
+			podAnno := map[string]string{"foo": "bar"}
+			nsAnno := map[string]string{constant.AnnoSpiderSubnet: nsSubnet}
+
+			config, err := controllers.GetSubnetAnnoConfig(podAnno, nil, nsAnno, zap.NewNop())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).NotTo(BeNil())
+			Expect(podAnno).To(Equal(map[string]string{"foo": "bar"}))
+		})
+
+		DescribeTable("merges the Namespace annotations",
+			func(podAnno, nsAnno map[string]string, expected *types.PodSubnetAnnoConfig) {
+				config, err := controllers.GetSubnetAnnoConfig(podAnno, nil, nsAnno, zap.NewNop())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config).To(Equal(expected))
+			},
+			Entry("neither the Pod nor the Namespace specifies subnets",
+				map[string]string{constant.AnnoSpiderSubnetPoolIPNumber: "2"},
+				map[string]string{constant.AnnoSpiderSubnetReclaimIPPool: "false"},
+				nil,
+			),
+			Entry("the Pod specifies an IPPool",
+				map[string]string{constant.AnnoPodIPPool: podPool},
+				map[string]string{constant.AnnoSpiderSubnet: nsSubnet},
+				nil,
+			),
+			Entry("inherits all from the Namespace",
+				nil,
+				map[string]string{
+					constant.AnnoSpiderSubnet:              nsSubnet,
+					constant.AnnoSpiderSubnetPoolIPNumber:  "2",
+					constant.AnnoSpiderSubnetReclaimIPPool: "false",
+				},
+				&types.PodSubnetAnnoConfig{
+					SingleSubnet:  &types.AnnoSubnetItem{Interface: constant.ClusterDefaultInterfaceName, IPv4: []string{"ns-subnet"}},
+					AssignIPNum:   2,
+					ReclaimIPPool: false,
+				},
+			),
+			Entry("the Pod overrides the subnet and inherits the IP number",
+				map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+				map[string]string{
+					constant.AnnoSpiderSubnet:             nsSubnet,
+					constant.AnnoSpiderSubnetPoolIPNumber: "+3",
+				},
+				&types.PodSubnetAnnoConfig{
+					SingleSubnet:  &types.AnnoSubnetItem{Interface: constant.ClusterDefaultInterfaceName, IPv4: []string{"pod-subnet"}},
+					FlexibleIPNum: pointer.Int(3),
+					ReclaimIPPool: true,
+				},
+			),
+			Entry("the Pod overrides all",
+				map[string]string{
+					constant.AnnoSpiderSubnets:             `[{"interface":"eth0","ipv4":["pod-subnet"]}]`,
+					constant.AnnoSpiderSubnetPoolIPNumber:  "1",
+					constant.AnnoSpiderSubnetReclaimIPPool: "true",
+				},
+				map[string]string{
+					constant.AnnoSpiderSubnet:              nsSubnet,
+					constant.AnnoSpiderSubnetPoolIPNumber:  "+3",
+					constant.AnnoSpiderSubnetReclaimIPPool: "false",
+				},
+				&types.PodSubnetAnnoConfig{
+					MultipleSubnets: []types.AnnoSubnetItem{{Interface: "eth0", IPv4: []string{"pod-subnet"}}},
+					AssignIPNum:     1,
+					ReclaimIPPool:   true,
+				},
+			),
+			Entry("the Pod config overrides the separate annotations",
+				map[string]string{
+					constant.AnnoPodConfig:                 `{"interfaces":[{"interface":"eth0","ipv4Subnets":["config-subnet"]}],"ippoolIPNumber":"+1"}`,
+					constant.AnnoSpiderSubnet:              podSubnet,
+					constant.AnnoSpiderSubnetReclaimIPPool: "false",
+				},
+				map[string]string{constant.AnnoSpiderSubnetBlockSize: "/28"},
+				&types.PodSubnetAnnoConfig{
+					MultipleSubnets:   []types.AnnoSubnetItem{{Interface: "eth0", IPv4: []string{"config-subnet"}}},
+					FlexibleIPNum:     pointer.Int(1),
+					ReclaimIPPool:     true,
+					BlockPrefixLength: 28,
+				},
+			),
+		)
+
+		It("reports the invalid annotations inherited from the Namespace", func() {
+			_, err := controllers.GetSubnetAnnoConfig(
+				map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+				nil,
+				map[string]string{constant.AnnoSpiderSubnetBlockSize: "invalid"},
+				zap.NewNop(),
+			)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		DescribeTable("merges the application annotations",
+			func(podAnno, appAnno, nsAnno map[string]string, expected *types.PodSubnetAnnoConfig) {
+				config, err := controllers.GetSubnetAnnoConfig(podAnno, appAnno, nsAnno, zap.NewNop())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config).To(Equal(expected))
+			},
+			Entry("inherits the subnet from the application rather than the Namespace",
+				nil,
+				map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+				map[string]string{
+					constant.AnnoSpiderSubnet:             nsSubnet,
+					constant.AnnoSpiderSubnetPoolIPNumber: "2",
+				},
+				&types.PodSubnetAnnoConfig{
+					SingleSubnet:  &types.AnnoSubnetItem{Interface: constant.ClusterDefaultInterfaceName, IPv4: []string{"pod-subnet"}},
+					AssignIPNum:   2,
+					ReclaimIPPool: true,
+				},
+			),
+			Entry("the Pod overrides the subnet of the application",
+				map[string]string{constant.AnnoSpiderSubnet: nsSubnet},
+				map[string]string{
+					constant.AnnoSpiderSubnet:              podSubnet,
+					constant.AnnoSpiderSubnetReclaimIPPool: "false",
+				},
+				nil,
+				&types.PodSubnetAnnoConfig{
+					SingleSubnet:  &types.AnnoSubnetItem{Interface: constant.ClusterDefaultInterfaceName, IPv4: []string{"ns-subnet"}},
+					FlexibleIPNum: pointer.Int(0),
+					ReclaimIPPool: false,
+				},
+			),
+			Entry("the IPPool of the Pod takes precedence over the subnet of the application",
+				map[string]string{constant.AnnoPodIPPool: podPool},
+				map[string]string{constant.AnnoSpiderSubnet: podSubnet},
+				nil,
+				nil,
+			),
+			Entry("converts the config annotation of the application",
+				nil,
+				map[string]string{constant.AnnoPodConfig: `{"interfaces":[{"interface":"eth0","ipv4Subnets":["config-subnet"]}],"ippoolIPNumber":"1"}`},
+				nil,
+				&types.PodSubnetAnnoConfig{
+					MultipleSubnets: []types.AnnoSubnetItem{{Interface: "eth0", IPv4: []string{"config-subnet"}}},
+					AssignIPNum:     1,
+					ReclaimIPPool:   true,
+				},
+			),
+		)
+	})
+
+	Describe("HorizontalPodAutoscaler", func() {
+		newHPA := func(apiVersion, kind, name string, desired int32) *autoscalingv2.HorizontalPodAutoscaler {
+			return &autoscalingv2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: apiVersion,
+						Kind:       kind,
+						Name:       name,
+					},
+				},
+				Status: autoscalingv2.HorizontalPodAutoscalerStatus{DesiredReplicas: desired},
+			}
+		}
+
+		DescribeTable("GetHPATargetAppKind",
+			func(apiVersion, kind string, expectedKind string, expectedOK bool) {
+				appKind, ok := controllers.GetHPATargetAppKind(autoscalingv2.CrossVersionObjectReference{
+					APIVersion: apiVersion,
+					Kind:       kind,
+					Name:       "app",
+				})
+				Expect(ok).To(Equal(expectedOK))
+				Expect(appKind).To(Equal(expectedKind))
+			},
+			Entry("Deployment", "apps/v1", constant.KindDeployment, constant.KindDeployment, true),
+			Entry("ReplicaSet", "apps/v1", constant.KindReplicaSet, constant.KindReplicaSet, true),
+			Entry("StatefulSet", "apps/v1", constant.KindStatefulSet, constant.KindStatefulSet, true),
+			Entry("DaemonSet isn't scalable", "apps/v1", constant.KindDaemonSet, "", false),
+			Entry("custom resource", "example.io/v1", constant.KindDeployment, "", false),
+			Entry("invalid API version", "apps/v1/v1", constant.KindDeployment, "", false),
+		)
+
+		It("takes the larger desired replicas of HPAs", func() {
+			hpas := []*autoscalingv2.HorizontalPodAutoscaler{
+				newHPA("apps/v1", constant.KindDeployment, "app", 5),
+				newHPA("apps/v1", constant.KindDeployment, "other", 10),
+				newHPA("apps/v1", constant.KindStatefulSet, "app", 10),
+			}
+			Expect(controllers.GetHPAScaledReplicas(constant.KindDeployment, "app", 2, hpas)).To(Equal(5))
+			Expect(controllers.GetHPAScaledReplicas(constant.KindDeployment, "app", 8, hpas)).To(Equal(8))
+			Expect(controllers.GetHPAScaledReplicas(constant.KindReplicaSet, "app", 2, hpas)).To(Equal(2))
+		})
+	})
+
+	Describe("ValidateIPPoolAdoption", func() {
+		var subnet *spiderpoolv1.SpiderSubnet
+		var pool, controlledPool *spiderpoolv1.SpiderIPPool
+
+		newPool := func(name string, ips ...string) *spiderpoolv1.SpiderIPPool {
+			return &spiderpoolv1.SpiderIPPool{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: spiderpoolv1.IPPoolSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       ips,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			subnet = &spiderpoolv1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+				Spec: spiderpoolv1.SubnetSpec{
+					IPVersion:  pointer.Int64(constant.IPv4),
+					Subnet:     "172.18.40.0/24",
+					IPs:        []string{"172.18.40.1-172.18.40.100"},
+					ExcludeIPs: []string{"172.18.40.50"},
+				},
+			}
+			pool = newPool("orphan", "172.18.40.10-172.18.40.20")
+			pool.Status.AllocatedIPs = spiderpoolv1.PoolIPAllocations{
+				"172.18.40.10": {Namespace: "default", Pod: "pod"},
+			}
+			controlledPool = newPool("controlled", "172.18.40.21-172.18.40.30")
+		})
+
+		It("adopts the IPPool contained in the SpiderSubnet", func() {
+			err := controllers.ValidateIPPoolAdoption(subnet, pool, []*spiderpoolv1.SpiderIPPool{controlledPool})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("refuses the IPPool of the other IP version", func() {
+			pool.Spec.IPVersion = pointer.Int64(constant.IPv6)
+			err := controllers.ValidateIPPoolAdoption(subnet, pool, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refuses the IPPool with IP addresses out of the SpiderSubnet", func() {
+			pool.Spec.IPs = []string{"172.18.40.45-172.18.40.55"}
+			err := controllers.ValidateIPPoolAdoption(subnet, pool, nil)
+			Expect(err).To(MatchError(ContainSubstring("172.18.40.50")))
+		})
+
+		It("refuses the IPPool with allocated IP addresses out of the SpiderSubnet", func() {
+			pool.Spec.ExcludeIPs = []string{"172.18.40.101"}
+			pool.Status.AllocatedIPs["172.18.40.101"] = spiderpoolv1.PoolIPAllocation{Namespace: "default", Pod: "other"}
+			err := controllers.ValidateIPPoolAdoption(subnet, pool, nil)
+			Expect(err).To(MatchError(ContainSubstring("default/other")))
+		})
+
+		It("refuses the IPPool overlapping with the controlled IPPools", func() {
+			controlledPool.Spec.IPs = []string{"172.18.40.20-172.18.40.30"}
+			err := controllers.ValidateIPPoolAdoption(subnet, pool, []*spiderpoolv1.SpiderIPPool{controlledPool})
+			Expect(err).To(MatchError(ContainSubstring(controlledPool.Name)))
+			Expect(err).To(MatchError(constant.ErrOverlap))
+		})
+	})
+
+	DescribeTable("GetSubnetBlockSize",
+		func(anno map[string]string, expected int, expectErr bool) {
+			prefixLength, err := controllers.GetSubnetBlockSize(anno)
+			if expectErr {
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefixLength).To(Equal(expected))
+		},
+		Entry("no block required", map[string]string{"foo": "bar"}, 0, false),
+		Entry("prefix length with slash", map[string]string{constant.AnnoSpiderSubnetBlockSize: "/27"}, 27, false),
+		Entry("prefix length without slash", map[string]string{constant.AnnoSpiderSubnetBlockSize: " 120 "}, 120, false),
+		Entry("not a number", map[string]string{constant.AnnoSpiderSubnetBlockSize: "/abc"}, 0, true),
+		Entry("zero prefix length", map[string]string{constant.AnnoSpiderSubnetBlockSize: "/0"}, 0, true),
+		Entry("too long prefix length", map[string]string{constant.AnnoSpiderSubnetBlockSize: "/129"}, 0, true),
+	)
+
+	DescribeTable("IsValidSubnetBlockSize",
+		func(subnetCIDR string, prefixLength int, expected bool) {
+			Expect(controllers.IsValidSubnetBlockSize(subnetCIDR, prefixLength)).To(Equal(expected))
+		},
+		Entry("block in the subnet", "172.18.40.0/24", 28, true),
+		Entry("single IP block", "172.18.40.0/24", 32, true),
+		Entry("block as large as the subnet", "172.18.40.0/24", 24, false),
+		Entry("block beyond the IP length", "172.18.40.0/24", 33, false),
+		Entry("block too large", "abcd:1234::/64", 104, false),
+		Entry("IPv6 block", "abcd:1234::/64", 120, true),
+		Entry("invalid subnet", "172.18.40.0", 28, false),
+	)
+
+	Describe("Subnet blocks", func() {
+		var subnet *spiderpoolv1.SpiderSubnet
+
+		BeforeEach(func() {
+			subnet = &spiderpoolv1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet"},
+				Spec: spiderpoolv1.SubnetSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					IPs:       []string{"172.18.40.1-172.18.40.100"},
+				},
+			}
+		})
+
+		Describe("SelectSubnetBlock", func() {
+			It("selects the first aligned block", func() {
+				block, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block).To(Equal("172.18.40.0/28"))
+			})
+
+			It("skips the blocks reserved for the other IPPools", func() {
+				subnet.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{
+					"other": "172.18.40.0/28",
+					"pool":  "172.18.40.32/28",
+				}
+
+				block, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block).To(Equal("172.18.40.16/28"))
+			})
+
+			It("skips the blocks containing the IPs pre-allocated to the other IPPools", func() {
+				subnet.Status.ControlledIPPools = spiderpoolv1.PoolIPPreAllocations{
+					"other": {IPs: []string{"172.18.40.20"}},
+					"pool":  {IPs: []string{"172.18.40.1-172.18.40.5"}},
+				}
+
+				block, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block).To(Equal("172.18.40.0/28"))
+
+				subnet.Status.ControlledIPPools["other"] = spiderpoolv1.PoolIPPreAllocation{IPs: []string{"172.18.40.1"}}
+				block, err = controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block).To(Equal("172.18.40.16/28"))
+			})
+
+			It("returns an empty block if there is no block left", func() {
+				subnet.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{"other": "172.18.40.0/25"}
+
+				block, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(block).To(BeEmpty())
+			})
+
+			It("fails to parse the reserved blocks", func() {
+				subnet.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{"other": "invalid"}
+
+				_, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).To(MatchError(ContainSubstring("other")))
+			})
+
+			It("fails to parse the subnet", func() {
+				subnet.Spec.Subnet = "invalid"
+
+				_, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("fails to parse the pre-allocated IPs", func() {
+				subnet.Status.ControlledIPPools = spiderpoolv1.PoolIPPreAllocations{"other": {IPs: []string{"invalid"}}}
+
+				_, err := controllers.SelectSubnetBlock(subnet, "pool", 28)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("GenSubnetReservedBlockIPs", func() {
+			It("returns nothing without reserved blocks", func() {
+				ips, err := controllers.GenSubnetReservedBlockIPs(subnet, "pool")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ips).To(BeEmpty())
+			})
+
+			It("returns the IPs of the blocks reserved for the other IPPools", func() {
+				subnet.Spec.ExcludeIPs = []string{"172.18.40.3"}
+				subnet.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{
+					"other": "172.18.40.0/30",
+					"pool":  "172.18.40.16/28",
+				}
+
+				ips, err := controllers.GenSubnetReservedBlockIPs(subnet, "pool")
+				Expect(err).NotTo(HaveOccurred())
+				var blockIPs []string
+				for _, ip := range ips {
+					blockIPs = append(blockIPs, ip.String())
+				}
+				Expect(blockIPs).To(ConsistOf("172.18.40.1", "172.18.40.2"))
+			})
+
+			It("fails to parse the reserved blocks", func() {
+				subnet.Status.ReservedBlocks = spiderpoolv1.PoolReservedBlocks{"other": "172.18.40.0"}
+
+				_, err := controllers.GenSubnetReservedBlockIPs(subnet, "pool")
+				Expect(err).To(MatchError(ContainSubstring("172.18.40.0")))
+			})
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -18,13 +19,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
+	"github.com/spidernet-io/spiderpool/pkg/configmanager"
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	"github.com/spidernet-io/spiderpool/pkg/election"
+	"github.com/spidernet-io/spiderpool/pkg/event"
+	"github.com/spidernet-io/spiderpool/pkg/informerstatus"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	clientset "github.com/spidernet-io/spiderpool/pkg/k8s/client/clientset/versioned"
 	"github.com/spidernet-io/spiderpool/pkg/k8s/client/informers/externalversions"
@@ -32,6 +38,8 @@ import (
 	listers "github.com/spidernet-io/spiderpool/pkg/k8s/client/listers/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
 )
 
 const (
@@ -57,6 +65,14 @@ type SubnetController struct {
 	ResyncPeriod            time.Duration
 	SubnetControllerWorkers int
 	MaxWorkqueueLength      int
+
+	// OnSubnetAvailable is called with the name of SpiderSubnet when it is
+	// added or resynced, e.g. to create the IPPools pending on it.
+	OnSubnetAvailable func(subnetName string)
+
+	// Freeze is optional, the reconciliation is paused while it reports
+	// Spiderpool frozen.
+	Freeze configmanager.FreezeChecker
 }
 
 func (sc *SubnetController) SetupInformer(ctx context.Context, client clientset.Interface, leader election.SpiderLeaseElector) error {
@@ -124,13 +140,14 @@ func (sc *SubnetController) addEventHandlers(subnetInformer informers.SpiderSubn
 	sc.IPPoolsLister = ipPoolInformer.Lister()
 	sc.SubnetsSynced = subnetInformer.Informer().HasSynced
 	sc.IPPoolsSynced = ipPoolInformer.Informer().HasSynced
-	sc.Workqueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), constant.SpiderSubnetKind)
+	sc.Workqueue = workqueue.NewNamedRateLimitingQueue(newKeyedRateLimiter(), constant.SpiderSubnetKind)
 
 	subnetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    sc.enqueueSubnetOnAdd,
 		UpdateFunc: sc.enqueueSubnetOnUpdate,
-		DeleteFunc: nil,
+		DeleteFunc: sc.onSubnetDelete,
 	})
+	subnetInformer.Informer().AddEventHandler(informerstatus.Register(constant.SpiderSubnetKind, sc.Workqueue.Len).EventHandler())
 
 	ipPoolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: sc.enqueueSubnetOnIPPoolChange,
@@ -161,6 +178,10 @@ func (sc *SubnetController) enqueueSubnetOnAdd(obj interface{}) {
 
 	sc.Workqueue.Add(subnet.Name)
 	logger.Debug(MessageEnqueueSubnet)
+
+	if sc.OnSubnetAvailable != nil && subnet.DeletionTimestamp == nil {
+		sc.OnSubnetAvailable(subnet.Name)
+	}
 }
 
 func (sc *SubnetController) enqueueSubnetOnUpdate(oldObj, newObj interface{}) {
@@ -177,6 +198,28 @@ func (sc *SubnetController) enqueueSubnetOnUpdate(oldObj, newObj interface{}) {
 
 	sc.Workqueue.Add(newSubnet.Name)
 	logger.Debug(MessageEnqueueSubnet)
+
+	if sc.OnSubnetAvailable != nil && newSubnet.DeletionTimestamp == nil {
+		sc.OnSubnetAvailable(newSubnet.Name)
+	}
+}
+
+func (sc *SubnetController) onSubnetDelete(obj interface{}) {
+	var subnet *spiderpoolv1.SpiderSubnet
+	switch t := obj.(type) {
+	case *spiderpoolv1.SpiderSubnet:
+		subnet = t
+	case cache.DeletedFinalStateUnknown:
+		s, ok := t.Obj.(*spiderpoolv1.SpiderSubnet)
+		if !ok {
+			return
+		}
+		subnet = s
+	default:
+		return
+	}
+
+	metric.SubnetInfo.Delete(subnet.Name)
 }
 
 func (sc *SubnetController) enqueueSubnetOnIPPoolChange(obj interface{}) {
@@ -236,13 +279,25 @@ func (sc *SubnetController) processNextWorkItem(ctx context.Context) bool {
 		return false
 	}
 	defer sc.Workqueue.Done(obj)
+	defer observeWorkItem(ctx, subnetQueueName, constant.SpiderSubnetKind, sc.Workqueue, time.Now())
 
 	logger := logutils.FromContext(ctx).With(
 		zap.String("SubnetName", obj.(string)),
 		zap.String("Operation", "PROCESS"),
 	)
 
-	if err := sc.syncHandler(logutils.IntoContext(ctx, logger), obj.(string)); err != nil {
+	if err := configmanager.CheckFrozen(sc.Freeze); err != nil {
+		logger.Sugar().Debugf("Postpone syncing: %v", err)
+		sc.Workqueue.Forget(obj)
+		sc.Workqueue.AddAfter(obj, sc.ResyncPeriod)
+		return true
+	}
+
+	ctx = logutils.IntoContext(ctx, logger)
+	err := recovery.Call(ctx, "Subnet-Controller", nil, func() error {
+		return sc.syncHandler(ctx, obj.(string))
+	})
+	if err != nil {
 		logger.Sugar().Warnf("Failed to handle, requeuing: %v", err)
 		sc.Workqueue.AddRateLimited(obj)
 		return true
@@ -260,58 +315,105 @@ func (sc *SubnetController) syncHandler(ctx context.Context, subnetName string)
 
 	// Record the metric of how many IPPools the Subnet has.
 	metric.SubnetPoolCounts.Record(int64(len(subnet.Status.ControlledIPPools)), attribute.String(constant.SpiderSubnetKind, subnet.Name))
+	recordSubnetInfo(subnet)
 
 	if err := sc.syncControllerSubnet(ctx, subnet); err != nil {
-		return fmt.Errorf("failed to sync reference for controller Subnet: %v", err)
+		return fmt.Errorf("failed to sync reference for controller Subnet: %w", err)
 	}
 
 	subnetCopy := subnet.DeepCopy()
 	if err := sc.syncControlledIPPoolIPs(ctx, subnetCopy); err != nil {
-		return fmt.Errorf("failed to sync the IP ranges of controlled IPPools of Subnet: %v", err)
+		return fmt.Errorf("failed to sync the IP ranges of controlled IPPools of Subnet: %w", err)
 	}
 
 	if subnet.DeletionTimestamp != nil {
 		if err := sc.removeFinalizer(ctx, subnetCopy); err != nil {
-			return fmt.Errorf("failed to remove finalizer: %v", err)
+			return fmt.Errorf("failed to remove finalizer: %w", err)
 		}
 	}
 
 	return nil
 }
 
+// syncControllerSubnet makes the SpiderSubnet control the IPPools with the
+// same 'spec.subnet'. The orphan IPPools, e.g. the ones created manually
+// before the SpiderSubnet, are adopted only if their IP addresses could be
+// pre-allocated from the SpiderSubnet, otherwise they are left orphan with
+// a warning event.
 func (sc *SubnetController) syncControllerSubnet(ctx context.Context, subnet *spiderpoolv1.SpiderSubnet) error {
+	logger := logutils.FromContext(ctx)
+
 	ipPools, err := sc.IPPoolsLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
 
+	var controlledPools, orphanPools []*spiderpoolv1.SpiderIPPool
 	for _, pool := range ipPools {
 		if pool.Spec.Subnet != subnet.Spec.Subnet {
 			continue
 		}
 
-		poolCopy := pool.DeepCopy()
-		orphan := false
-		if !metav1.IsControlledBy(poolCopy, subnet) {
-			if err := ctrl.SetControllerReference(subnet, poolCopy, sc.Scheme); err != nil {
-				return err
-			}
-			orphan = true
+		if metav1.IsControlledBy(pool, subnet) {
+			controlledPools = append(controlledPools, pool)
+		} else {
+			orphanPools = append(orphanPools, pool)
+		}
+	}
+
+	for _, pool := range controlledPools {
+		if v, ok := pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]; ok && v == subnet.Name {
+			continue
 		}
 
+		poolCopy := pool.DeepCopy()
 		if poolCopy.Labels == nil {
 			poolCopy.Labels = make(map[string]string)
 		}
-		if v, ok := poolCopy.Labels[constant.LabelIPPoolOwnerSpiderSubnet]; !ok || v != subnet.Name {
-			poolCopy.Labels[constant.LabelIPPoolOwnerSpiderSubnet] = subnet.Name
-			orphan = true
+		poolCopy.Labels[constant.LabelIPPoolOwnerSpiderSubnet] = subnet.Name
+		if err := sc.Update(ctx, poolCopy); err != nil {
+			return err
+		}
+	}
+
+	// The terminating SpiderSubnet never adopts IPPools.
+	if subnet.DeletionTimestamp != nil {
+		return nil
+	}
+
+	for _, pool := range orphanPools {
+		if pool.DeletionTimestamp != nil {
+			continue
 		}
 
-		if orphan {
-			if err := sc.Update(ctx, poolCopy); err != nil {
-				return err
-			}
+		// The IP addresses allocated may be held by the SpiderIPPoolShards.
+		poolCopy := pool.DeepCopy()
+		if err := ippoolmanager.AssembleIPPoolShards(ctx, sc.Client, nil, poolCopy); err != nil {
+			return err
+		}
+
+		if err := controllers.ValidateIPPoolAdoption(subnet, poolCopy, controlledPools); err != nil {
+			logger.Sugar().Warnf("Refuse to adopt orphan IPPool %s: %v", pool.Name, err)
+			event.EventRecorder.Eventf(pool, corev1.EventTypeWarning, constant.EventReasonAdoptIPPool,
+				"Failed to be adopted by SpiderSubnet %s: %v", subnet.Name, err)
+			continue
+		}
+
+		if err := ctrl.SetControllerReference(subnet, poolCopy, sc.Scheme); err != nil {
+			return err
+		}
+		if poolCopy.Labels == nil {
+			poolCopy.Labels = make(map[string]string)
+		}
+		poolCopy.Labels[constant.LabelIPPoolOwnerSpiderSubnet] = subnet.Name
+		if err := sc.Update(ctx, poolCopy); err != nil {
+			return err
 		}
+
+		logger.Sugar().Infof("Adopt orphan IPPool %s", pool.Name)
+		event.EventRecorder.Eventf(pool, corev1.EventTypeNormal, constant.EventReasonAdoptIPPool,
+			"Adopted by SpiderSubnet %s", subnet.Name)
+		controlledPools = append(controlledPools, poolCopy)
 	}
 
 	return nil
@@ -349,6 +451,13 @@ func (sc *SubnetController) syncControlledIPPoolIPs(ctx context.Context, subnet
 	}
 	subnet.Status.ControlledIPPools = controlledIPPools
 
+	// Release the blocks reserved for the IPPools that no longer exist.
+	for poolName := range subnet.Status.ReservedBlocks {
+		if _, ok := controlledIPPools[poolName]; !ok {
+			delete(subnet.Status.ReservedBlocks, poolName)
+		}
+	}
+
 	// Update the count of total IP addresses.
 	totalIPCount := int64(len(subnetTotalIPs))
 	subnet.Status.TotalIPCount = &totalIPCount
@@ -391,3 +500,15 @@ func (sc *SubnetController) removeFinalizer(ctx context.Context, subnet *spiderp
 
 	return nil
 }
+
+// recordSubnetInfo reports the inventory of the SpiderSubnet in the labels of
+// the info metric.
+func recordSubnetInfo(subnet *spiderpoolv1.SpiderSubnet) {
+	metric.SubnetInfo.Record(subnet.Name, 1,
+		attribute.String("subnet", subnet.Name),
+		attribute.Int64("ip_version", pointer.Int64Deref(subnet.Spec.IPVersion, 0)),
+		attribute.String("cidr", subnet.Spec.Subnet),
+		attribute.Int64("vlan", pointer.Int64Deref(subnet.Spec.Vlan, 0)),
+		attribute.String("gateway", pointer.StringDeref(subnet.Spec.Gateway, "")),
+	)
+}
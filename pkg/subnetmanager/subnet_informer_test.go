@@ -0,0 +1,79 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetmanager
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+var _ = Describe("SubnetController", Label("subnet_informer_test"), func() {
+	Describe("record the info metric of SpiderSubnets", func() {
+		var sc *SubnetController
+		var subnetT *spiderpoolv1.SpiderSubnet
+
+		BeforeEach(func() {
+			initMetrics()
+
+			sc = &SubnetController{}
+			subnetT = &spiderpoolv1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "info-subnet",
+				},
+				Spec: spiderpoolv1.SubnetSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+					Gateway:   pointer.String("172.18.40.1"),
+					Vlan:      pointer.Int64(100),
+				},
+			}
+			DeferCleanup(sc.onSubnetDelete, subnetT)
+		})
+
+		It("carries the inventory of the SpiderSubnet in the labels", func() {
+			recordSubnetInfo(subnetT)
+
+			Expect(scrapeMetrics()).To(MatchRegexp(`subnet_info\{cidr="172\.18\.40\.0/24",gateway="172\.18\.40\.1",ip_version="4",[^}]*subnet="info-subnet",vlan="100"\} 1`))
+		})
+
+		It("replaces the series once the SpiderSubnet changes", func() {
+			recordSubnetInfo(subnetT)
+			subnetT.Spec.Gateway = nil
+			subnetT.Spec.Vlan = nil
+			recordSubnetInfo(subnetT)
+
+			output := scrapeMetrics()
+			Expect(output).To(MatchRegexp(`subnet_info\{cidr="172\.18\.40\.0/24",gateway="",ip_version="4",[^}]*subnet="info-subnet",vlan="0"\} 1`))
+			Expect(output).NotTo(ContainSubstring(`gateway="172.18.40.1"`))
+		})
+
+		It("deletes the series of the deleted SpiderSubnet", func() {
+			recordSubnetInfo(subnetT)
+
+			sc.onSubnetDelete(subnetT)
+			Expect(scrapeMetrics()).NotTo(ContainSubstring(`subnet="info-subnet"`))
+		})
+
+		It("deletes the series of the SpiderSubnet in a tombstone", func() {
+			recordSubnetInfo(subnetT)
+
+			sc.onSubnetDelete(cache.DeletedFinalStateUnknown{Key: subnetT.Name, Obj: subnetT})
+			Expect(scrapeMetrics()).NotTo(ContainSubstring(`subnet="info-subnet"`))
+		})
+
+		It("ignores the unknown objects in a tombstone", func() {
+			recordSubnetInfo(subnetT)
+
+			sc.onSubnetDelete(cache.DeletedFinalStateUnknown{Key: subnetT.Name, Obj: &spiderpoolv1.SpiderIPPool{}})
+			sc.onSubnetDelete("info-subnet")
+			Expect(scrapeMetrics()).To(ContainSubstring(`subnet="info-subnet"`))
+		})
+	})
+})
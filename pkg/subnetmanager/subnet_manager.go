@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apitypes "k8s.io/apimachinery/pkg/types"
@@ -15,32 +16,48 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/features"
 	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/metric"
 	"github.com/spidernet-io/spiderpool/pkg/subnetmanager/controllers"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/label"
+	"github.com/spidernet-io/spiderpool/pkg/utils/pager"
 )
 
 type SubnetManager interface {
-	GetSubnetByName(ctx context.Context, subnetName string) (*spiderpoolv1.SpiderSubnet, error)
+	GetSubnetByName(ctx context.Context, subnetName string, cached bool) (*spiderpoolv1.SpiderSubnet, error)
 	ListSubnets(ctx context.Context, opts ...client.ListOption) (*spiderpoolv1.SpiderSubnetList, error)
-	AllocateEmptyIPPool(ctx context.Context, subnetMgrName string, podController types.PodTopController, podSelector *metav1.LabelSelector, ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string) (*spiderpoolv1.SpiderIPPool, error)
+	IterateSubnets(ctx context.Context, fn func(subnet *spiderpoolv1.SpiderSubnet) error, opts ...client.ListOption) error
+	AllocateEmptyIPPool(ctx context.Context, subnetMgrName string, podController types.PodTopController, podSelector *metav1.LabelSelector, ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string, blockPrefixLength int) (*spiderpoolv1.SpiderIPPool, error)
 	CheckScaleIPPool(ctx context.Context, pool *spiderpoolv1.SpiderIPPool, subnetManagerName string, ipNum int) (bool, error)
+	SyncApplicationLabels(ctx context.Context, pool *spiderpoolv1.SpiderIPPool, podController types.PodTopController) error
+	PreProvisionIPPool(ctx context.Context, subnetName string, podController types.PodTopController, ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string) (*spiderpoolv1.SpiderIPPool, bool, error)
 }
 
+// preProvisionedPoolSuffix takes the place of the application UID in the
+// names of the pre-provisioned IPPools.
+const preProvisionedPoolSuffix = "preprovisioned"
+
 type subnetManager struct {
 	config        SubnetManagerConfig
 	client        client.Client
+	apiReader     client.Reader
 	ipPoolManager ippoolmanager.IPPoolManager
 	Scheme        *runtime.Scheme
+	options       manageroption.Options
 }
 
-func NewSubnetManager(config SubnetManagerConfig, client client.Client, ipPoolManager ippoolmanager.IPPoolManager, scheme *runtime.Scheme) (SubnetManager, error) {
+func NewSubnetManager(config SubnetManagerConfig, client client.Client, apiReader client.Reader, ipPoolManager ippoolmanager.IPPoolManager, scheme *runtime.Scheme, opts ...manageroption.Option) (SubnetManager, error) {
 	if client == nil {
 		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
 	}
+	if apiReader == nil {
+		return nil, fmt.Errorf("api reader %w", constant.ErrMissingRequiredParam)
+	}
 	if ipPoolManager == nil {
 		return nil, fmt.Errorf("ippool manager %w", constant.ErrMissingRequiredParam)
 	}
@@ -51,14 +68,32 @@ func NewSubnetManager(config SubnetManagerConfig, client client.Client, ipPoolMa
 	return &subnetManager{
 		config:        setDefaultsForSubnetManagerConfig(config),
 		client:        client,
+		apiReader:     apiReader,
 		ipPoolManager: ipPoolManager,
 		Scheme:        scheme,
+		options:       manageroption.New(opts...),
 	}, nil
 }
 
-func (sm *subnetManager) GetSubnetByName(ctx context.Context, subnetName string) (*spiderpoolv1.SpiderSubnet, error) {
+// GetSubnetByName gets the SpiderSubnet from the cache if cached and the
+// cache is configured, otherwise from the API server. The SpiderSubnet not
+// found in the cache is got from the API server again, as the cache may lag
+// behind.
+func (sm *subnetManager) GetSubnetByName(ctx context.Context, subnetName string, cached bool) (*spiderpoolv1.SpiderSubnet, error) {
+	fromCache := cached == constant.UseCache && sm.options.Cache != nil
+	reader := sm.apiReader
+	if fromCache {
+		reader = sm.options.Cache
+	}
+
 	var subnet spiderpoolv1.SpiderSubnet
-	if err := sm.client.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet); err != nil {
+	err := reader.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet)
+	sm.options.Metrics.RecordRead(ctx, constant.SpiderSubnetKind, fromCache)
+	if fromCache && apierrors.IsNotFound(err) {
+		err = sm.apiReader.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet)
+		sm.options.Metrics.RecordRead(ctx, constant.SpiderSubnetKind, false)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -74,10 +109,31 @@ func (sm *subnetManager) ListSubnets(ctx context.Context, opts ...client.ListOpt
 	return &subnetList, nil
 }
 
+// IterateSubnets lists the SpiderSubnets page by page and calls fn with each
+// of them, until all of them are iterated or fn returns an error.
+func (sm *subnetManager) IterateSubnets(ctx context.Context, fn func(subnet *spiderpoolv1.SpiderSubnet) error, opts ...client.ListOption) error {
+	return pager.ListPages(
+		ctx,
+		sm.client,
+		pager.Config{PageSize: sm.config.ListPageSize, PageInterval: sm.config.ListPageInterval},
+		func() client.ObjectList { return &spiderpoolv1.SpiderSubnetList{} },
+		func(list client.ObjectList) error {
+			subnetList := list.(*spiderpoolv1.SpiderSubnetList)
+			for i := range subnetList.Items {
+				if err := fn(&subnetList.Items[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		opts...,
+	)
+}
+
 // AllocateEmptyIPPool will create an empty IPPool and mark the status.AutoDesiredIPCount
 // notice: this function only serves for auto-created IPPool
 func (sm *subnetManager) AllocateEmptyIPPool(ctx context.Context, subnetName string, podController types.PodTopController,
-	podSelector *metav1.LabelSelector, ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string) (*spiderpoolv1.SpiderIPPool, error) {
+	podSelector *metav1.LabelSelector, ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string, blockPrefixLength int) (*spiderpoolv1.SpiderIPPool, error) {
 	if len(subnetName) == 0 {
 		return nil, fmt.Errorf("%w: spider subnet name must be specified", constant.ErrWrongInput)
 	}
@@ -86,7 +142,7 @@ func (sm *subnetManager) AllocateEmptyIPPool(ctx context.Context, subnetName str
 	}
 
 	log := logutils.FromContext(ctx)
-	subnet, err := sm.GetSubnetByName(ctx, subnetName)
+	subnet, err := sm.GetSubnetByName(ctx, subnetName, constant.IgnoreCache)
 	if nil != err {
 		return nil, err
 	}
@@ -96,6 +152,162 @@ func (sm *subnetManager) AllocateEmptyIPPool(ctx context.Context, subnetName str
 			constant.ErrWrongInput, subnet.Name)
 	}
 
+	pool, err := sm.adoptPreProvisionedIPPool(ctx, subnet, podController, podSelector, ipNum, ipVersion, reclaimIPPool, ifName)
+	if nil != err {
+		return nil, err
+	}
+	if pool != nil {
+		return pool, nil
+	}
+
+	sp := sm.newAutoIPPool(subnet, podController, podSelector, ipVersion, reclaimIPPool, ifName)
+	if blockPrefixLength > 0 && features.DefaultFeatureGate.Enabled(features.SubnetBlockReservation) {
+		if controllers.IsValidSubnetBlockSize(subnet.Spec.Subnet, blockPrefixLength) {
+			sp.Annotations = map[string]string{
+				constant.AnnoSpiderSubnetBlockSize: fmt.Sprintf("/%d", blockPrefixLength),
+			}
+		} else {
+			log.Sugar().Warnf("block '/%d' doesn't fit in SpiderSubnet '%s' subnet '%s', skip reserving block for IPPool '%s'",
+				blockPrefixLength, subnet.Name, subnet.Spec.Subnet, sp.Name)
+		}
+	}
+
+	if err := sm.createAutoIPPool(ctx, subnet, sp, ipNum); err != nil {
+		return nil, err
+	}
+
+	return sp, nil
+}
+
+// PreProvisionIPPool creates the auto-created IPPool of the application
+// before the application is created, so that its first Pods don't wait for
+// the creation of the IPPool. The IPPool is adopted by the application once
+// created. The IPPool already existing for the application is returned
+// instead, and it reports whether the IPPool is created.
+func (sm *subnetManager) PreProvisionIPPool(ctx context.Context, subnetName string, podController types.PodTopController,
+	ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string) (*spiderpoolv1.SpiderIPPool, bool, error) {
+	if len(subnetName) == 0 {
+		return nil, false, fmt.Errorf("%w: spider subnet name must be specified", constant.ErrWrongInput)
+	}
+	if ipNum <= 0 {
+		return nil, false, fmt.Errorf("%w: the required IP numbers '%d' is invalid", constant.ErrWrongInput, ipNum)
+	}
+	if ipVersion != constant.IPv4 && ipVersion != constant.IPv6 {
+		return nil, false, fmt.Errorf("%w: invalid IP version '%d'", constant.ErrWrongInput, ipVersion)
+	}
+	if len(podController.Namespace) == 0 || len(podController.Name) == 0 || len(ifName) == 0 {
+		return nil, false, fmt.Errorf("%w: namespace, name and interface of the application must be specified", constant.ErrWrongInput)
+	}
+	switch podController.Kind {
+	case constant.KindDeployment, constant.KindReplicaSet, constant.KindStatefulSet,
+		constant.KindDaemonSet, constant.KindJob, constant.KindCronJob,
+		constant.KindReplicationController, constant.KindDeploymentConfig:
+	default:
+		return nil, false, fmt.Errorf("%w: unsupported application kind '%s'", constant.ErrWrongInput, podController.Kind)
+	}
+
+	log := logutils.FromContext(ctx)
+	subnet, err := sm.GetSubnetByName(ctx, subnetName, constant.IgnoreCache)
+	if nil != err {
+		return nil, false, err
+	}
+	if subnet.DeletionTimestamp != nil {
+		return nil, false, fmt.Errorf("%w: SpiderSubnet '%s' is terminating, we can't create a corresponding IPPool",
+			constant.ErrWrongInput, subnet.Name)
+	}
+
+	var poolList spiderpoolv1.SpiderIPPoolList
+	err = sm.apiReader.List(ctx, &poolList, autoIPPoolMatchingLabels(subnet.Name, podController, ipVersion, ifName))
+	if nil != err {
+		return nil, false, err
+	}
+	for i := range poolList.Items {
+		pool := poolList.Items[i].DeepCopy()
+		if pool.DeletionTimestamp != nil {
+			continue
+		}
+		if pool.Labels[constant.LabelIPPoolPreProvisioned] == constant.True &&
+			int64(ipNum) > pointer.Int64Deref(pool.Status.AutoDesiredIPCount, 0) {
+			log.Sugar().Infof("try to update pre-provisioned IPPool '%s' status DesiredIPNumber to '%d'", pool.Name, ipNum)
+			if err := sm.ipPoolManager.UpdateDesiredIPNumber(ctx, pool, ipNum); err != nil {
+				return nil, false, err
+			}
+		}
+
+		return pool, false, nil
+	}
+
+	podController.UID = preProvisionedPoolSuffix
+	podController.APP = nil
+	sp := sm.newAutoIPPool(subnet, podController, nil, ipVersion, reclaimIPPool, ifName)
+	delete(sp.Labels, constant.LabelIPPoolOwnerApplicationUID)
+	sp.Labels[constant.LabelIPPoolPreProvisioned] = constant.True
+
+	if err := sm.createAutoIPPool(ctx, subnet, sp, ipNum); err != nil {
+		return nil, false, err
+	}
+
+	return sp, true, nil
+}
+
+// adoptPreProvisionedIPPool binds the pre-provisioned IPPool of the
+// application to it, if any. The IP number desired by the pre-provisioning
+// is kept if it is larger.
+func (sm *subnetManager) adoptPreProvisionedIPPool(ctx context.Context, subnet *spiderpoolv1.SpiderSubnet, podController types.PodTopController,
+	podSelector *metav1.LabelSelector, ipNum int, ipVersion types.IPVersion, reclaimIPPool bool, ifName string) (*spiderpoolv1.SpiderIPPool, error) {
+	matchLabels := autoIPPoolMatchingLabels(subnet.Name, podController, ipVersion, ifName)
+	matchLabels[constant.LabelIPPoolPreProvisioned] = constant.True
+
+	var poolList spiderpoolv1.SpiderIPPoolList
+	if err := sm.apiReader.List(ctx, &poolList, matchLabels); err != nil {
+		return nil, err
+	}
+
+	var pool *spiderpoolv1.SpiderIPPool
+	for i := range poolList.Items {
+		if poolList.Items[i].DeletionTimestamp == nil {
+			pool = poolList.Items[i].DeepCopy()
+			break
+		}
+	}
+	if pool == nil {
+		return nil, nil
+	}
+
+	log := logutils.FromContext(ctx)
+	log.Sugar().Infof("try to adopt pre-provisioned IPPool '%s' for application '%s/%s/%s'",
+		pool.Name, podController.Kind, podController.Namespace, podController.Name)
+
+	delete(pool.Labels, constant.LabelIPPoolPreProvisioned)
+	pool.Labels[constant.LabelIPPoolOwnerApplicationUID] = string(podController.UID)
+	if reclaimIPPool {
+		pool.Labels[constant.LabelIPPoolReclaimIPPool] = constant.True
+	} else {
+		delete(pool.Labels, constant.LabelIPPoolReclaimIPPool)
+	}
+	if podController.APP != nil {
+		pool.Labels, _ = label.StampApplicationLabels(pool.Labels, podController.APP.GetLabels(), sm.config.ApplicationLabelKeys)
+	}
+	pool.Spec.PodAffinity = podSelector
+
+	if err := sm.client.Update(ctx, pool); err != nil {
+		return nil, fmt.Errorf("failed to adopt pre-provisioned IPPool '%s': %w", pool.Name, err)
+	}
+
+	if desired := pointer.Int64Deref(pool.Status.AutoDesiredIPCount, 0); int64(ipNum) > desired {
+		if err := sm.ipPoolManager.UpdateDesiredIPNumber(ctx, pool, ipNum); err != nil {
+			return nil, err
+		}
+	}
+	log.Sugar().Infof("adopt pre-provisioned IPPool '%s' successfully", pool.Name)
+
+	return pool, nil
+}
+
+// newAutoIPPool builds the auto-created IPPool of the application from the
+// SpiderSubnet.
+func (sm *subnetManager) newAutoIPPool(subnet *spiderpoolv1.SpiderSubnet, podController types.PodTopController,
+	podSelector *metav1.LabelSelector, ipVersion types.IPVersion, reclaimIPPool bool, ifName string) *spiderpoolv1.SpiderIPPool {
 	sp := &spiderpoolv1.SpiderIPPool{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: controllers.SubnetPoolName(podController.Kind, podController.Namespace, podController.Name, ipVersion, ifName, podController.UID),
@@ -109,29 +321,33 @@ func (sm *subnetManager) AllocateEmptyIPPool(ctx context.Context, subnetName str
 		},
 	}
 
-	poolLabels := map[string]string{
-		constant.LabelIPPoolOwnerSpiderSubnet:   subnet.Name,
-		constant.LabelIPPoolOwnerApplication:    controllers.AppLabelValue(podController.Kind, podController.Namespace, podController.Name),
-		constant.LabelIPPoolOwnerApplicationUID: string(podController.UID),
-		constant.LabelIPPoolInterface:           ifName,
-	}
-
+	poolLabels := autoIPPoolMatchingLabels(subnet.Name, podController, ipVersion, ifName)
+	poolLabels[constant.LabelIPPoolOwnerApplicationUID] = string(podController.UID)
 	if ipVersion == constant.IPv4 {
 		sp.Spec.IPVersion = pointer.Int64(constant.IPv4)
-		poolLabels[constant.LabelIPPoolVersion] = constant.LabelIPPoolVersionV4
 	} else {
 		sp.Spec.IPVersion = pointer.Int64(constant.IPv6)
-		poolLabels[constant.LabelIPPoolVersion] = constant.LabelIPPoolVersionV6
 	}
 
 	if reclaimIPPool {
 		poolLabels[constant.LabelIPPoolReclaimIPPool] = constant.True
 	}
+	if podController.APP != nil {
+		poolLabels, _ = label.StampApplicationLabels(poolLabels, podController.APP.GetLabels(), sm.config.ApplicationLabelKeys)
+	}
 	sp.Labels = poolLabels
 
-	err = ctrl.SetControllerReference(subnet, sp, sm.Scheme)
+	return sp
+}
+
+// createAutoIPPool creates the auto-created IPPool owned by the SpiderSubnet
+// and marks its status.AutoDesiredIPCount.
+func (sm *subnetManager) createAutoIPPool(ctx context.Context, subnet *spiderpoolv1.SpiderSubnet, sp *spiderpoolv1.SpiderIPPool, ipNum int) error {
+	log := logutils.FromContext(ctx)
+
+	err := ctrl.SetControllerReference(subnet, sp, sm.Scheme)
 	if nil != err {
-		return nil, fmt.Errorf("failed to set SpiderIPPool %s owner reference with SpiderSubnet %s: %v", sp.Name, subnetName, err)
+		return fmt.Errorf("failed to set SpiderIPPool %s owner reference with SpiderSubnet %s: %w", sp.Name, subnet.Name, err)
 	}
 
 	timeRecorder := metric.NewTimeRecorder()
@@ -144,17 +360,33 @@ func (sm *subnetManager) AllocateEmptyIPPool(ctx context.Context, subnetName str
 	log.Sugar().Infof("try to create IPPool '%v'", sp)
 	err = sm.client.Create(ctx, sp)
 	if nil != err {
-		return nil, err
+		return err
 	}
 
 	log.Sugar().Infof("try to update IPPool '%v' status DesiredIPNumber '%d'", sp, ipNum)
 	err = sm.ipPoolManager.UpdateDesiredIPNumber(ctx, sp, ipNum)
 	if nil != err {
-		return nil, err
+		return err
 	}
 	log.Sugar().Infof("create and mark IPPool '%v' successfully", sp)
 
-	return sp, nil
+	return nil
+}
+
+// autoIPPoolMatchingLabels returns the labels shared by the auto-created
+// IPPools of the application, regardless of the application UID.
+func autoIPPoolMatchingLabels(subnetName string, podController types.PodTopController, ipVersion types.IPVersion, ifName string) client.MatchingLabels {
+	poolLabels := client.MatchingLabels{
+		constant.LabelIPPoolOwnerSpiderSubnet: subnetName,
+		constant.LabelIPPoolOwnerApplication:  controllers.AppLabelValue(podController.Kind, podController.Namespace, podController.Name),
+		constant.LabelIPPoolInterface:         ifName,
+		constant.LabelIPPoolVersion:           constant.LabelIPPoolVersionV4,
+	}
+	if ipVersion == constant.IPv6 {
+		poolLabels[constant.LabelIPPoolVersion] = constant.LabelIPPoolVersionV6
+	}
+
+	return poolLabels
 }
 
 // CheckScaleIPPool will fetch some IPs from the specified subnet manager to expand the pool IPs
@@ -166,6 +398,9 @@ func (sm *subnetManager) CheckScaleIPPool(ctx context.Context, pool *spiderpoolv
 		return false, fmt.Errorf("%w: assign IP number '%d' is invalid", constant.ErrWrongInput, ipNum)
 	}
 
+	// keep the IP addresses expanded on the utilization pressure of the IPPool
+	ipNum += int(pointer.Int64Deref(pool.Status.AutoExpandedIPCount, 0))
+
 	log := logutils.FromContext(ctx)
 	needUpdate := false
 	if pool.Status.AutoDesiredIPCount == nil {
@@ -192,3 +427,29 @@ func (sm *subnetManager) CheckScaleIPPool(ctx context.Context, pool *spiderpoolv
 
 	return false, nil
 }
+
+// SyncApplicationLabels copies the configured application labels onto the
+// auto-created IPPool, so that the IPPools created before the application
+// label keys were configured (or changed) are migrated.
+func (sm *subnetManager) SyncApplicationLabels(ctx context.Context, pool *spiderpoolv1.SpiderIPPool, podController types.PodTopController) error {
+	if pool == nil {
+		return fmt.Errorf("%w: IPPool must be specified", constant.ErrWrongInput)
+	}
+	if podController.APP == nil || len(sm.config.ApplicationLabelKeys) == 0 {
+		return nil
+	}
+
+	poolLabels, changed := label.StampApplicationLabels(pool.GetLabels(), podController.APP.GetLabels(), sm.config.ApplicationLabelKeys)
+	if !changed {
+		return nil
+	}
+
+	log := logutils.FromContext(ctx)
+	log.Sugar().Infof("try to stamp application labels onto IPPool '%s'", pool.Name)
+	pool.SetLabels(poolLabels)
+	if err := sm.client.Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to stamp application labels onto IPPool '%s': %w", pool.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/features"
+	"github.com/spidernet-io/spiderpool/pkg/ippoolmanager"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/reservedipmanager"
+	"github.com/spidernet-io/spiderpool/pkg/subnetmanager"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+var _ = Describe("SubnetManager", Label("subnet_manager_test"), func() {
+	Describe("PreProvisionIPPool", func() {
+		var ctx context.Context
+		var subnetT *spiderpoolv1.SpiderSubnet
+		var manager subnetmanager.SubnetManager
+		var app types.PodTopController
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			subnetT = &spiderpoolv1.SpiderSubnet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       constant.SpiderSubnetKind,
+					APIVersion: spiderpoolv1.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "subnet-v4",
+					UID:  "subnet-uid",
+				},
+				Spec: spiderpoolv1.SubnetSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+				},
+			}
+			Expect(fakeClient.Create(ctx, subnetT)).To(Succeed())
+
+			rIPManager, err := reservedipmanager.NewReservedIPManager(fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			ipPoolManager, err := ippoolmanager.NewIPPoolManager(ippoolmanager.IPPoolManagerConfig{}, fakeClient, fakeClient, rIPManager)
+			Expect(err).NotTo(HaveOccurred())
+			manager, err = subnetmanager.NewSubnetManager(subnetmanager.SubnetManagerConfig{}, fakeClient, fakeClient, ipPoolManager, scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			app = types.PodTopController{
+				Kind:      constant.KindDeployment,
+				Namespace: "default",
+				Name:      "demo",
+			}
+		})
+
+		AfterEach(func() {
+			Expect(fakeClient.DeleteAllOf(ctx, &spiderpoolv1.SpiderIPPool{})).To(Succeed())
+			Expect(fakeClient.Delete(ctx, subnetT)).To(Succeed())
+		})
+
+		It("rejects the invalid input", func() {
+			_, _, err := manager.PreProvisionIPPool(ctx, "", app, 5, constant.IPv4, true, "eth0")
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+
+			_, _, err = manager.PreProvisionIPPool(ctx, subnetT.Name, app, 0, constant.IPv4, true, "eth0")
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+
+			app.Kind = constant.KindPod
+			_, _, err = manager.PreProvisionIPPool(ctx, subnetT.Name, app, 5, constant.IPv4, true, "eth0")
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("pre-provisions the IPPool and lets the application adopt it", func() {
+			pool, created, err := manager.PreProvisionIPPool(ctx, subnetT.Name, app, 5, constant.IPv4, true, "eth0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+			Expect(pool.Labels).To(HaveKeyWithValue(constant.LabelIPPoolPreProvisioned, constant.True))
+			Expect(pool.Labels).NotTo(HaveKey(constant.LabelIPPoolOwnerApplicationUID))
+			Expect(pool.Status.AutoDesiredIPCount).To(Equal(pointer.Int64(5)))
+
+			again, created, err := manager.PreProvisionIPPool(ctx, subnetT.Name, app, 8, constant.IPv4, true, "eth0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeFalse())
+			Expect(again.Name).To(Equal(pool.Name))
+			Expect(again.Status.AutoDesiredIPCount).To(Equal(pointer.Int64(8)))
+
+			app.UID = "a3c6c6a2-6c1e-4b52-9a3c-0b3f1d0e3f1a"
+			podSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}}
+			adopted, err := manager.AllocateEmptyIPPool(ctx, subnetT.Name, app, podSelector, 6, constant.IPv4, false, "eth0", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(adopted.Name).To(Equal(pool.Name))
+			Expect(adopted.Labels).NotTo(HaveKey(constant.LabelIPPoolPreProvisioned))
+			Expect(adopted.Labels).NotTo(HaveKey(constant.LabelIPPoolReclaimIPPool))
+			Expect(adopted.Labels).To(HaveKeyWithValue(constant.LabelIPPoolOwnerApplicationUID, string(app.UID)))
+			Expect(adopted.Spec.PodAffinity).To(Equal(podSelector))
+			Expect(adopted.Status.AutoDesiredIPCount).To(Equal(pointer.Int64(8)))
+
+			var poolList spiderpoolv1.SpiderIPPoolList
+			Expect(fakeClient.List(ctx, &poolList, client.MatchingLabels{constant.LabelIPPoolOwnerSpiderSubnet: subnetT.Name})).To(Succeed())
+			Expect(poolList.Items).To(HaveLen(1))
+
+			var got spiderpoolv1.SpiderIPPool
+			Expect(fakeClient.Get(ctx, apitypes.NamespacedName{Name: pool.Name}, &got)).To(Succeed())
+			Expect(got.Labels).To(HaveKeyWithValue(constant.LabelIPPoolOwnerApplicationUID, string(app.UID)))
+		})
+	})
+
+	Describe("AllocateEmptyIPPool", func() {
+		var ctx context.Context
+		var subnetT *spiderpoolv1.SpiderSubnet
+		var manager subnetmanager.SubnetManager
+		var app types.PodTopController
+		var podSelector *metav1.LabelSelector
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			subnetT = &spiderpoolv1.SpiderSubnet{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       constant.SpiderSubnetKind,
+					APIVersion: spiderpoolv1.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "subnet-v4",
+					UID:  "subnet-uid",
+				},
+				Spec: spiderpoolv1.SubnetSpec{
+					IPVersion: pointer.Int64(constant.IPv4),
+					Subnet:    "172.18.40.0/24",
+				},
+			}
+			Expect(fakeClient.Create(ctx, subnetT)).To(Succeed())
+
+			rIPManager, err := reservedipmanager.NewReservedIPManager(fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+			ipPoolManager, err := ippoolmanager.NewIPPoolManager(ippoolmanager.IPPoolManagerConfig{}, fakeClient, fakeClient, rIPManager)
+			Expect(err).NotTo(HaveOccurred())
+			manager, err = subnetmanager.NewSubnetManager(subnetmanager.SubnetManagerConfig{}, fakeClient, fakeClient, ipPoolManager, scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			app = types.PodTopController{
+				Kind:      constant.KindDeployment,
+				Namespace: "default",
+				Name:      "demo",
+				UID:       "a3c6c6a2-6c1e-4b52-9a3c-0b3f1d0e3f1a",
+			}
+			podSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}}
+		})
+
+		AfterEach(func() {
+			Expect(fakeClient.DeleteAllOf(ctx, &spiderpoolv1.SpiderIPPool{})).To(Succeed())
+			Expect(fakeClient.Delete(ctx, subnetT)).To(Succeed())
+		})
+
+		It("rejects the invalid input", func() {
+			_, err := manager.AllocateEmptyIPPool(ctx, "", app, podSelector, 5, constant.IPv4, true, "eth0", 0)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+
+			_, err = manager.AllocateEmptyIPPool(ctx, subnetT.Name, app, podSelector, -1, constant.IPv4, true, "eth0", 0)
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("requires a block of the SpiderSubnet for the IPPool", func() {
+			pool, err := manager.AllocateEmptyIPPool(ctx, subnetT.Name, app, podSelector, 5, constant.IPv4, true, "eth0", 28)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Annotations).To(HaveKeyWithValue(constant.AnnoSpiderSubnetBlockSize, "/28"))
+		})
+
+		It("does not require a block not fitting in the SpiderSubnet", func() {
+			pool, err := manager.AllocateEmptyIPPool(ctx, subnetT.Name, app, podSelector, 5, constant.IPv4, true, "eth0", 24)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Annotations).NotTo(HaveKey(constant.AnnoSpiderSubnetBlockSize))
+		})
+
+		It("does not require a block if the feature is disabled", func() {
+			err := features.DefaultMutableFeatureGate.SetFromMap(map[string]bool{string(features.SubnetBlockReservation): false})
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				err := features.DefaultMutableFeatureGate.SetFromMap(map[string]bool{string(features.SubnetBlockReservation): true})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			pool, err := manager.AllocateEmptyIPPool(ctx, subnetT.Name, app, podSelector, 5, constant.IPv4, true, "eth0", 28)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pool.Annotations).NotTo(HaveKey(constant.AnnoSpiderSubnetBlockSize))
+		})
+	})
+})
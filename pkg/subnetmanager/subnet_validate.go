@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"strconv"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
@@ -25,6 +28,7 @@ var (
 	gatewayField           *field.Path = field.NewPath("spec").Child("gateway")
 	routesField            *field.Path = field.NewPath("spec").Child("routes")
 	controlledIPPoolsField *field.Path = field.NewPath("status").Child("controlledIPPools")
+	tenantField            *field.Path = field.NewPath("metadata").Child("labels").Key(constant.LabelSubnetTenant)
 )
 
 func (sw *SubnetWebhook) validateCreateSubnet(ctx context.Context, subnet *spiderpoolv1.SpiderSubnet) field.ErrorList {
@@ -40,6 +44,12 @@ func (sw *SubnetWebhook) validateCreateSubnet(ctx context.Context, subnet *spide
 	if err := sw.validateSubnetSpec(ctx, subnet); err != nil {
 		errs = append(errs, err)
 	}
+	if err := sw.validateSubnetTenant(ctx, nil, subnet); err != nil {
+		errs = append(errs, err)
+	}
+	if err := ippoolmanager.ValidateNetworkAttachment(ctx, sw.Client, subnet, sw.NetworkAttachmentWarningOnly); err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -65,6 +75,16 @@ func (sw *SubnetWebhook) validateUpdateSubnet(ctx context.Context, oldSubnet, ne
 	if err := validateSubnetIPInUse(newSubnet); err != nil {
 		errs = append(errs, err)
 	}
+	if err := sw.validateSubnetTenant(ctx, oldSubnet, newSubnet); err != nil {
+		errs = append(errs, err)
+	}
+	// The NetworkAttachmentDefinition is only checked once it is changed, so
+	// that the SpiderSubnet could still be updated after it is removed.
+	if newSubnet.Annotations[constant.AnnoNetworkAttachmentDefinition] != oldSubnet.Annotations[constant.AnnoNetworkAttachmentDefinition] {
+		if err := ippoolmanager.ValidateNetworkAttachment(ctx, sw.Client, newSubnet, sw.NetworkAttachmentWarningOnly); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -109,13 +129,13 @@ func (sw *SubnetWebhook) validateSubnetSpec(ctx context.Context, subnet *spiderp
 func validateSubnetIPInUse(subnet *spiderpoolv1.SpiderSubnet) *field.Error {
 	totalIPs, err := spiderpoolip.AssembleTotalIPs(*subnet.Spec.IPVersion, subnet.Spec.IPs, subnet.Spec.ExcludeIPs)
 	if err != nil {
-		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %v", subnet.Name, err))
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %w", subnet.Name, err))
 	}
 
 	for poolName, preAllocation := range subnet.Status.ControlledIPPools {
 		poolTotalIPs, err := spiderpoolip.ParseIPRanges(*subnet.Spec.IPVersion, preAllocation.IPs)
 		if err != nil {
-			return field.InternalError(controlledIPPoolsField, fmt.Errorf("failed to parse the pre-allocation of the IPPool %s: %v", poolName, err))
+			return field.InternalError(controlledIPPoolsField, fmt.Errorf("failed to parse the pre-allocation of the IPPool %s: %w", poolName, err))
 		}
 		invalidIPs := spiderpoolip.IPsDiffSet(poolTotalIPs, totalIPs, false)
 		if len(invalidIPs) > 0 {
@@ -130,6 +150,65 @@ func validateSubnetIPInUse(subnet *spiderpoolv1.SpiderSubnet) *field.Error {
 	return nil
 }
 
+// validateSubnetTenant checks that the SpiderTenant which the Subnet joins
+// exists, and that the IP addresses of all its Subnets do not exceed its
+// quota. The Subnets already in the tenant are only checked if they grow, so
+// that lowering the quota does not block the other updates of them.
+func (sw *SubnetWebhook) validateSubnetTenant(ctx context.Context, oldSubnet, newSubnet *spiderpoolv1.SpiderSubnet) *field.Error {
+	tenantName, ok := newSubnet.Labels[constant.LabelSubnetTenant]
+	if !ok {
+		return nil
+	}
+
+	totalIPs, err := spiderpoolip.AssembleTotalIPs(*newSubnet.Spec.IPVersion, newSubnet.Spec.IPs, newSubnet.Spec.ExcludeIPs)
+	if err != nil {
+		return field.InternalError(ipsField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %w", newSubnet.Name, err))
+	}
+	if oldSubnet != nil && oldSubnet.Labels[constant.LabelSubnetTenant] == tenantName {
+		oldTotalIPs, err := spiderpoolip.AssembleTotalIPs(*oldSubnet.Spec.IPVersion, oldSubnet.Spec.IPs, oldSubnet.Spec.ExcludeIPs)
+		if err == nil && len(totalIPs) <= len(oldTotalIPs) {
+			return nil
+		}
+	}
+
+	var tenant spiderpoolv1.SpiderTenant
+	if err := sw.Get(ctx, apitypes.NamespacedName{Name: tenantName}, &tenant); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.Invalid(tenantField, tenantName, fmt.Sprintf("%s %s not found", constant.SpiderTenantKind, tenantName))
+		}
+		return field.InternalError(tenantField, fmt.Errorf("failed to get %s %s: %w", constant.SpiderTenantKind, tenantName, err))
+	}
+	if tenant.Spec.MaxIPCount == nil {
+		return nil
+	}
+
+	subnetList := spiderpoolv1.SpiderSubnetList{}
+	if err := sw.List(ctx, &subnetList, client.MatchingLabels{constant.LabelSubnetTenant: tenantName}); err != nil {
+		return field.InternalError(tenantField, fmt.Errorf("failed to list Subnets: %w", err))
+	}
+
+	count := int64(len(totalIPs))
+	for _, s := range subnetList.Items {
+		if s.Name == newSubnet.Name {
+			continue
+		}
+		ips, err := spiderpoolip.AssembleTotalIPs(*s.Spec.IPVersion, s.Spec.IPs, s.Spec.ExcludeIPs)
+		if err != nil {
+			return field.InternalError(tenantField, fmt.Errorf("failed to assemble the total IP addresses of the Subnet %s: %w", s.Name, err))
+		}
+		count += int64(len(ips))
+	}
+
+	if count > *tenant.Spec.MaxIPCount {
+		return field.Forbidden(
+			ipsField,
+			fmt.Sprintf("the Subnets of %s %s would have %d IP addresses, exceeding its quota %d", constant.SpiderTenantKind, tenantName, count, *tenant.Spec.MaxIPCount),
+		)
+	}
+
+	return nil
+}
+
 func (sw *SubnetWebhook) validateSubnetIPVersion(version *types.IPVersion) *field.Error {
 	if version == nil {
 		return field.Invalid(
@@ -178,7 +257,7 @@ func (sw *SubnetWebhook) validateSubnetCIDR(ctx context.Context, subnet *spiderp
 	// TODO(iiiceoo): Use label selector.
 	subnetList := spiderpoolv1.SpiderSubnetList{}
 	if err := sw.List(ctx, &subnetList); err != nil {
-		return field.InternalError(subnetField, fmt.Errorf("failed to list Subnets: %v", err))
+		return field.InternalError(subnetField, fmt.Errorf("failed to list Subnets: %w", err))
 	}
 
 	for _, s := range subnetList.Items {
@@ -189,14 +268,14 @@ func (sw *SubnetWebhook) validateSubnetCIDR(ctx context.Context, subnet *spiderp
 
 			overlap, err := spiderpoolip.IsCIDROverlap(*subnet.Spec.IPVersion, subnet.Spec.Subnet, s.Spec.Subnet)
 			if err != nil {
-				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps: %v", err))
+				return field.InternalError(subnetField, fmt.Errorf("failed to compare whether 'spec.subnet' overlaps: %w", err))
 			}
 
 			if overlap {
-				return field.Invalid(
+				return ippoolmanager.FieldErrorOf(
 					subnetField,
 					subnet.Spec.Subnet,
-					fmt.Sprintf("overlap with Subnet %s which 'spec.subnet' is %s", s.Name, s.Spec.Subnet),
+					&constant.OverlapError{Kind: constant.SpiderSubnetKind, Name: s.Name, Ranges: []string{s.Spec.Subnet}},
 				)
 			}
 		}
@@ -19,6 +19,8 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
 )
 
 var WebhookLogger *zap.Logger
@@ -28,6 +30,15 @@ type SubnetWebhook struct {
 
 	EnableIPv4 bool
 	EnableIPv6 bool
+
+	// NetworkAttachmentWarningOnly reports the SpiderSubnet bound to an
+	// invalid NetworkAttachmentDefinition with a warning event instead of
+	// rejecting it.
+	NetworkAttachmentWarningOnly bool
+
+	// Reporter aggregates the denials of the webhook into the
+	// SpiderWebhookReport, it is optional.
+	Reporter webhookmanager.WebhookReporter
 }
 
 func (sw *SubnetWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -37,8 +48,8 @@ func (sw *SubnetWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&spiderpoolv1.SpiderSubnet{}).
-		WithDefaulter(sw).
-		WithValidator(sw).
+		WithDefaulter(recovery.Defaulter("Subnet-Webhook", sw)).
+		WithValidator(recovery.Validator("Subnet-Webhook", webhookmanager.DenialValidator(constant.SpiderSubnetKind, sw, sw.Reporter))).
 		Complete()
 }
 
@@ -542,6 +542,34 @@ var _ = Describe("SubnetWebhook", Label("subnet_webhook_test"), func() {
 				})
 			})
 
+			When("Validating the bound NetworkAttachmentDefinition", func() {
+				BeforeEach(func() {
+					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+					subnetT.Spec.Subnet = "172.18.40.0/24"
+					subnetT.Spec.IPs = append(subnetT.Spec.IPs, "172.18.40.10")
+					subnetT.SetAnnotations(map[string]string{constant.AnnoNetworkAttachmentDefinition: "kube-system/macvlan-ens192"})
+				})
+
+				AfterEach(func() {
+					subnetWebhook.NetworkAttachmentWarningOnly = false
+				})
+
+				It("binds to the NetworkAttachmentDefinition that does not exist", func() {
+					ctx := context.TODO()
+					err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(apierrors.IsInvalid(err)).To(BeTrue())
+					Expect(err.Error()).To(ContainSubstring(constant.AnnoNetworkAttachmentDefinition))
+				})
+
+				It("admits the NetworkAttachmentDefinition that does not exist in warning-only mode", func() {
+					subnetWebhook.NetworkAttachmentWarningOnly = true
+
+					ctx := context.TODO()
+					err := subnetWebhook.ValidateCreate(ctx, subnetT)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			When("Validating 'spec.excludeIPs'", func() {
 				It("inputs invalid 'spec.excludeIPs'", func() {
 					subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
@@ -724,6 +752,92 @@ var _ = Describe("SubnetWebhook", Label("subnet_webhook_test"), func() {
 			})
 		})
 
+		Describe("validate the SpiderTenant quota", func() {
+			var tenantT *spiderpoolv1.SpiderTenant
+
+			BeforeEach(func() {
+				tenantT = &spiderpoolv1.SpiderTenant{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: fmt.Sprintf("tenant-%v", count),
+					},
+					Spec: spiderpoolv1.TenantSpec{
+						MaxIPCount: pointer.Int64(5),
+					},
+				}
+
+				existSubnetT.Labels = map[string]string{constant.LabelSubnetTenant: tenantT.Name}
+				existSubnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				existSubnetT.Spec.Subnet = "172.18.41.0/24"
+				existSubnetT.Spec.IPs = []string{"172.18.41.1-172.18.41.3"}
+
+				subnetT.Labels = map[string]string{constant.LabelSubnetTenant: tenantT.Name}
+				subnetT.Spec.IPVersion = pointer.Int64(constant.IPv4)
+				subnetT.Spec.Subnet = "172.18.40.0/24"
+				subnetT.Spec.IPs = []string{"172.18.40.1-172.18.40.2"}
+
+				DeferCleanup(func() {
+					err := fakeClient.Delete(context.TODO(), tenantT)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				})
+			})
+
+			It("joins a SpiderTenant that does not exist", func() {
+				ctx := context.TODO()
+				err := subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			})
+
+			It("creates Subnet within the quota", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, tenantT)
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, existSubnetT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates Subnet exceeding the quota", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, tenantT)
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, existSubnetT)
+				Expect(err).NotTo(HaveOccurred())
+
+				subnetT.Spec.IPs = []string{"172.18.40.1-172.18.40.3"}
+				err = subnetWebhook.ValidateCreate(ctx, subnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			})
+
+			It("appends IP ranges exceeding the quota", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, tenantT)
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, existSubnetT)
+				Expect(err).NotTo(HaveOccurred())
+
+				newSubnetT := existSubnetT.DeepCopy()
+				newSubnetT.Spec.IPs = append(newSubnetT.Spec.IPs, "172.18.41.10-172.18.41.12")
+				err = subnetWebhook.ValidateUpdate(ctx, existSubnetT, newSubnetT)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			})
+
+			It("updates Subnet without growing after the quota is lowered", func() {
+				ctx := context.TODO()
+				tenantT.Spec.MaxIPCount = pointer.Int64(1)
+				err := fakeClient.Create(ctx, tenantT)
+				Expect(err).NotTo(HaveOccurred())
+				err = fakeClient.Create(ctx, existSubnetT)
+				Expect(err).NotTo(HaveOccurred())
+
+				newSubnetT := existSubnetT.DeepCopy()
+				newSubnetT.Spec.Gateway = pointer.String("172.18.41.254")
+				err = subnetWebhook.ValidateUpdate(ctx, existSubnetT, newSubnetT)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		Describe("ValidateUpdate", func() {
 			When("Validating 'spec.ipVersion'", func() {
 				It("updates 'spec.ipVersion' to nil", func() {
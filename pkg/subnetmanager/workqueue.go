@@ -0,0 +1,41 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetmanager
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/client-go/util/workqueue"
+
+	metrics "github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+const (
+	subnetQueueName      = "SpiderSubnet"
+	applicationQueueName = "Application"
+)
+
+// newKeyedRateLimiter returns the rate limiter of the work queues keyed by
+// SpiderSubnet or application. Unlike workqueue.DefaultControllerRateLimiter,
+// there is no bucket shared by all keys, so the retries of a key never delay
+// the unrelated ones.
+func newKeyedRateLimiter() workqueue.RateLimiter {
+	return workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second)
+}
+
+// observeWorkItem records the depth of the work queue and the duration of
+// processing a key of the kind, which started at start.
+func observeWorkItem(ctx context.Context, queueName, kind string, queue workqueue.Interface, start time.Time) {
+	metrics.SubnetControllerQueueDepth.Record(queueName, int64(queue.Len()), attribute.String("queue", queueName))
+
+	if metrics.SubnetControllerKeyDurationSecondsHistogram == nil {
+		return
+	}
+	metrics.SubnetControllerKeyDurationSecondsHistogram.Record(ctx, time.Since(start).Seconds(),
+		attribute.String("queue", queueName),
+		attribute.String("kind", kind),
+	)
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package subnetmanager
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	metrics "github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+var (
+	initMetricsOnce sync.Once
+	metricHandler   http.Handler
+)
+
+// initMetrics initializes the metrics of spiderpool-controller once for all
+// specs, the instruments are not recorded before.
+func initMetrics() {
+	initMetricsOnce.Do(func() {
+		var err error
+		metricHandler, err = metrics.InitMetricController(context.TODO(), "spiderpool-controller-test", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(metrics.InitSpiderpoolControllerMetrics(context.TODO())).To(Succeed())
+	})
+}
+
+// scrapeMetrics returns the metrics of spiderpool-controller in the text
+// format of Prometheus.
+func scrapeMetrics() string {
+	initMetrics()
+
+	rr := httptest.NewRecorder()
+	metricHandler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	Expect(rr.Code).To(Equal(http.StatusOK))
+
+	return rr.Body.String()
+}
+
+var _ = Describe("Work queue", Label("workqueue_test"), func() {
+	Describe("newKeyedRateLimiter", func() {
+		It("delays the retries of each key independently", func() {
+			limiter := newKeyedRateLimiter()
+
+			var delay time.Duration
+			for i := 0; i < 10; i++ {
+				delay = limiter.When("subnet1")
+			}
+			Expect(delay).To(Equal(5 * time.Millisecond << 9))
+			Expect(limiter.NumRequeues("subnet1")).To(Equal(10))
+
+			Expect(limiter.When("subnet2")).To(Equal(5 * time.Millisecond))
+			Expect(limiter.NumRequeues("subnet2")).To(Equal(1))
+		})
+
+		It("never delays a retry longer than the maximum", func() {
+			limiter := newKeyedRateLimiter()
+
+			var delay time.Duration
+			for i := 0; i < 30; i++ {
+				delay = limiter.When("subnet")
+			}
+			Expect(delay).To(Equal(1000 * time.Second))
+		})
+
+		It("restarts the delay once the key is forgotten", func() {
+			limiter := newKeyedRateLimiter()
+			limiter.When("subnet")
+			limiter.When("subnet")
+
+			limiter.Forget("subnet")
+			Expect(limiter.NumRequeues("subnet")).To(BeZero())
+			Expect(limiter.When("subnet")).To(Equal(5 * time.Millisecond))
+		})
+	})
+
+	Describe("observeWorkItem", func() {
+		It("records the depth of the queues and the duration of the keys", func() {
+			initMetrics()
+
+			subnetQueue := workqueue.NewNamed("subnet-test")
+			DeferCleanup(subnetQueue.ShutDown)
+			subnetQueue.Add("subnet1")
+			subnetQueue.Add("subnet2")
+			appQueue := workqueue.NewNamed("app-test")
+			DeferCleanup(appQueue.ShutDown)
+
+			observeWorkItem(context.TODO(), subnetQueueName, constant.SpiderSubnetKind, subnetQueue, time.Now().Add(-time.Second))
+			observeWorkItem(context.TODO(), applicationQueueName, constant.KindDeployment, appQueue, time.Now())
+
+			output := scrapeMetrics()
+			Expect(output).To(MatchRegexp(`subnet_controller_queue_depth\{[^}]*queue="SpiderSubnet"[^}]*\} 2`))
+			Expect(output).To(MatchRegexp(`subnet_controller_queue_depth\{[^}]*queue="Application"[^}]*\} 0`))
+			Expect(output).To(MatchRegexp(`subnet_controller_key_duration_seconds_histogram_count\{[^}]*kind="SpiderSubnet"[^}]*queue="SpiderSubnet"[^}]*\} 1`))
+			Expect(output).To(MatchRegexp(`subnet_controller_key_duration_seconds_histogram_count\{[^}]*kind="Deployment"[^}]*queue="Application"[^}]*\} 1`))
+		})
+	})
+})
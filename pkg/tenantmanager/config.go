@@ -0,0 +1,22 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmanager
+
+import (
+	"time"
+)
+
+const defaultResyncPeriod = time.Minute
+
+type TenantReconcilerConfig struct {
+	ResyncPeriod time.Duration
+}
+
+func setDefaultsForTenantReconcilerConfig(config TenantReconcilerConfig) TenantReconcilerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	return config
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// TenantReconciler reconciles the SpiderSubnets of each SpiderTenant and
+// their IP usage into the status of the SpiderTenant.
+type TenantReconciler interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+}
+
+type tenantReconciler struct {
+	config TenantReconcilerConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+}
+
+func NewTenantReconciler(config TenantReconcilerConfig, client client.Client, leader election.SpiderLeaseElector) (TenantReconciler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &tenantReconciler{
+		config: setDefaultsForTenantReconcilerConfig(config),
+		client: client,
+		leader: leader,
+	}, nil
+}
+
+// Start reconciles the SpiderTenants periodically until the context is
+// done. Only the leader of spiderpool-controller reconciles them.
+func (r *tenantReconciler) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if r.leader.IsElected() {
+				err := recovery.Call(ctx, "Tenant-Reconciler", nil, func() error { return r.Reconcile(ctx) })
+				if err != nil {
+					logger.Sugar().Errorf("Failed to reconcile SpiderTenants: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Reconcile syncs the status of all the SpiderTenants with the SpiderSubnets
+// labeled with their names.
+func (r *tenantReconciler) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var tenantList spiderpoolv1.SpiderTenantList
+	if err := r.client.List(ctx, &tenantList); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list SpiderTenants: %w", err)
+	}
+	if len(tenantList.Items) == 0 {
+		return nil
+	}
+
+	var subnetList spiderpoolv1.SpiderSubnetList
+	if err := r.client.List(ctx, &subnetList, client.HasLabels{constant.LabelSubnetTenant}); err != nil {
+		return fmt.Errorf("failed to list SpiderSubnets: %w", err)
+	}
+	subnetsOfTenants := map[string][]spiderpoolv1.SpiderSubnet{}
+	for _, subnet := range subnetList.Items {
+		tenant := subnet.Labels[constant.LabelSubnetTenant]
+		subnetsOfTenants[tenant] = append(subnetsOfTenants[tenant], subnet)
+	}
+
+	for i := range tenantList.Items {
+		tenant := &tenantList.Items[i]
+		if tenant.DeletionTimestamp != nil {
+			continue
+		}
+
+		status := TenantStatusOf(subnetsOfTenants[tenant.Name])
+		if equalStatus(tenant.Status, status) {
+			continue
+		}
+
+		tenant.Status = status
+		if err := r.client.Status().Update(ctx, tenant); err != nil {
+			return fmt.Errorf("failed to update the status of SpiderTenant %s: %w", tenant.Name, err)
+		}
+		logger.Sugar().Debugf("Succeed to update the status of SpiderTenant %s: %+v", tenant.Name, status)
+	}
+
+	return nil
+}
+
+// TenantStatusOf summarizes the SpiderSubnets of a tenant into the status of
+// the SpiderTenant.
+func TenantStatusOf(subnets []spiderpoolv1.SpiderSubnet) spiderpoolv1.TenantStatus {
+	var totalIPCount, allocatedIPCount int64
+	names := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		names = append(names, subnet.Name)
+		totalIPCount += pointer.Int64Deref(subnet.Status.TotalIPCount, 0)
+		allocatedIPCount += pointer.Int64Deref(subnet.Status.AllocatedIPCount, 0)
+	}
+	sort.Strings(names)
+
+	return spiderpoolv1.TenantStatus{
+		Subnets:          names,
+		TotalIPCount:     pointer.Int64(totalIPCount),
+		AllocatedIPCount: pointer.Int64(allocatedIPCount),
+	}
+}
+
+func equalStatus(a, b spiderpoolv1.TenantStatus) bool {
+	return strings.Join(a.Subnets, ",") == strings.Join(b.Subnets, ",") &&
+		pointer.Int64Equal(a.TotalIPCount, b.TotalIPCount) &&
+		pointer.Int64Equal(a.AllocatedIPCount, b.AllocatedIPCount)
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/tenantmanager"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("TenantReconciler", Label("tenant_reconciler_test"), func() {
+	Describe("New TenantReconciler", func() {
+		It("inputs nil client", func() {
+			reconciler, err := tenantmanager.NewTenantReconciler(tenantmanager.TenantReconcilerConfig{}, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			reconciler, err := tenantmanager.NewTenantReconciler(tenantmanager.TenantReconcilerConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var ctx context.Context
+		var objs []client.Object
+		var reconciler tenantmanager.TenantReconciler
+
+		create := func(obj client.Object) {
+			err := fakeClient.Create(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			objs = append(objs, obj)
+		}
+
+		newSubnet := func(name, tenant string, total, allocated int64) *spiderpoolv1.SpiderSubnet {
+			subnet := &spiderpoolv1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: spiderpoolv1.SubnetStatus{
+					TotalIPCount:     pointer.Int64(total),
+					AllocatedIPCount: pointer.Int64(allocated),
+				},
+			}
+			if tenant != "" {
+				subnet.Labels = map[string]string{constant.LabelSubnetTenant: tenant}
+			}
+			return subnet
+		}
+
+		getTenant := func(name string) *spiderpoolv1.SpiderTenant {
+			var tenant spiderpoolv1.SpiderTenant
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Name: name}, &tenant)
+			Expect(err).NotTo(HaveOccurred())
+			return &tenant
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			objs = nil
+
+			var err error
+			reconciler, err = tenantmanager.NewTenantReconciler(tenantmanager.TenantReconcilerConfig{}, fakeClient, fakeLeader{})
+			Expect(err).NotTo(HaveOccurred())
+
+			DeferCleanup(func() {
+				for _, obj := range objs {
+					err := fakeClient.Delete(ctx, obj)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				}
+			})
+		})
+
+		It("does nothing without SpiderTenants", func() {
+			create(newSubnet("subnet", "tenant-a", 10, 5))
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("summarizes the SpiderSubnets of each SpiderTenant", func() {
+			create(&spiderpoolv1.SpiderTenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a"}})
+			create(&spiderpoolv1.SpiderTenant{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b"}})
+			create(newSubnet("subnet2", "tenant-a", 20, 10))
+			create(newSubnet("subnet1", "tenant-a", 10, 5))
+			create(newSubnet("subnet3", "", 100, 100))
+
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			tenantA := getTenant("tenant-a")
+			Expect(tenantA.Status.Subnets).To(Equal([]string{"subnet1", "subnet2"}))
+			Expect(tenantA.Status.TotalIPCount).To(Equal(pointer.Int64(30)))
+			Expect(tenantA.Status.AllocatedIPCount).To(Equal(pointer.Int64(15)))
+
+			tenantB := getTenant("tenant-b")
+			Expect(tenantB.Status.Subnets).To(BeEmpty())
+			Expect(tenantB.Status.TotalIPCount).To(Equal(pointer.Int64(0)))
+			Expect(tenantB.Status.AllocatedIPCount).To(Equal(pointer.Int64(0)))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmanager
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+)
+
+// IsSubnetInTenant checks whether the SpiderSubnet belongs to the tenant.
+func IsSubnetInTenant(subnet *spiderpoolv1.SpiderSubnet, tenant string) bool {
+	return subnet.Labels[constant.LabelSubnetTenant] == tenant
+}
+
+// IsIPPoolInTenant checks whether the IPPool is allocated from a SpiderSubnet
+// of the tenant. The IPPools out of any SpiderSubnet belong to no tenant.
+func IsIPPoolInTenant(ctx context.Context, reader client.Reader, pool *spiderpoolv1.SpiderIPPool, tenant string) (bool, error) {
+	subnetName, ok := pool.Labels[constant.LabelIPPoolOwnerSpiderSubnet]
+	if !ok {
+		return false, nil
+	}
+
+	var subnet spiderpoolv1.SpiderSubnet
+	if err := reader.Get(ctx, apitypes.NamespacedName{Name: subnetName}, &subnet); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return IsSubnetInTenant(&subnet, tenant), nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package tenantmanager_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/tenantmanager"
+)
+
+var _ = Describe("Utils", Label("utils_test"), func() {
+	Describe("IsIPPoolInTenant", func() {
+		var ctx context.Context
+		var subnetT *spiderpoolv1.SpiderSubnet
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			subnetT = &spiderpoolv1.SpiderSubnet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "tenant-subnet",
+					Labels: map[string]string{constant.LabelSubnetTenant: "tenant-a"},
+				},
+			}
+			err := fakeClient.Create(ctx, subnetT)
+			Expect(err).NotTo(HaveOccurred())
+
+			DeferCleanup(func() {
+				err := fakeClient.Delete(ctx, subnetT)
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			})
+		})
+
+		newIPPool := func(subnetName string) *spiderpoolv1.SpiderIPPool {
+			pool := &spiderpoolv1.SpiderIPPool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			if subnetName != "" {
+				pool.Labels = map[string]string{constant.LabelIPPoolOwnerSpiderSubnet: subnetName}
+			}
+			return pool
+		}
+
+		It("checks the IPPool allocated from the SpiderSubnet of the tenant", func() {
+			ok, err := tenantmanager.IsIPPoolInTenant(ctx, fakeClient, newIPPool(subnetT.Name), "tenant-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+
+			ok, err = tenantmanager.IsIPPoolInTenant(ctx, fakeClient, newIPPool(subnetT.Name), "tenant-b")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("checks the IPPool out of any SpiderSubnet", func() {
+			ok, err := tenantmanager.IsIPPoolInTenant(ctx, fakeClient, newIPPool(""), "tenant-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("checks the IPPool whose SpiderSubnet is gone", func() {
+			ok, err := tenantmanager.IsIPPoolInTenant(ctx, fakeClient, newIPPool("missing"), "tenant-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
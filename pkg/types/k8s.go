@@ -7,7 +7,13 @@ import (
 	"fmt"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	apitypes "k8s.io/apimachinery/pkg/types"
 
 	stringutil "github.com/spidernet-io/spiderpool/pkg/utils/string"
@@ -15,12 +21,174 @@ import (
 
 type PodStatus string
 
+// PodTopController is the top owner controller of a Pod, or the Pod itself
+// if it is an orphan. APP is unstructured for the DeploymentConfigs of
+// OpenShift, and it is nil for the third party controllers, whose
+// replicas and selector are only known from their scale subresource if Scale
+// is set.
 type PodTopController struct {
 	Kind      string
 	Namespace string
 	Name      string
 	UID       apitypes.UID
 	APP       metav1.Object
+	Scale     *autoscalingv1.Scale
+}
+
+// Replicas returns the number of the Pods desired by the controller, and
+// whether it is known. The replicas of Deployments, ReplicaSets and
+// StatefulSets default to 1 if unset, the ones of DaemonSets are the number
+// of the nodes they are scheduled to.
+func (c PodTopController) Replicas() (int, bool) {
+	switch app := c.APP.(type) {
+	case *corev1.Pod:
+		return 1, true
+	case *appsv1.Deployment:
+		return int(derefReplicas(app.Spec.Replicas)), true
+	case *appsv1.ReplicaSet:
+		return int(derefReplicas(app.Spec.Replicas)), true
+	case *appsv1.StatefulSet:
+		return int(derefReplicas(app.Spec.Replicas)), true
+	case *appsv1.DaemonSet:
+		return int(app.Status.DesiredNumberScheduled), true
+	case *batchv1.Job:
+		return jobPodNum(app.Spec.Parallelism, app.Spec.Completions), true
+	case *batchv1.CronJob:
+		return jobPodNum(app.Spec.JobTemplate.Spec.Parallelism, app.Spec.JobTemplate.Spec.Completions), true
+	case *corev1.ReplicationController:
+		return int(derefReplicas(app.Spec.Replicas)), true
+	case *unstructured.Unstructured:
+		if isDeploymentConfig(app) {
+			replicas, found, _ := unstructured.NestedInt64(app.Object, "spec", "replicas")
+			if !found {
+				return 1, true
+			}
+			return int(replicas), true
+		}
+	}
+
+	if c.Scale != nil {
+		return int(c.Scale.Spec.Replicas), true
+	}
+
+	return 0, false
+}
+
+// Selector returns the label selector of the Pods of the controller, nil if
+// it is unknown. The selector of an orphan Pod matches its own labels.
+func (c PodTopController) Selector() *metav1.LabelSelector {
+	switch app := c.APP.(type) {
+	case *corev1.Pod:
+		return &metav1.LabelSelector{MatchLabels: app.Labels}
+	case *appsv1.Deployment:
+		return app.Spec.Selector
+	case *appsv1.ReplicaSet:
+		return app.Spec.Selector
+	case *appsv1.StatefulSet:
+		return app.Spec.Selector
+	case *appsv1.DaemonSet:
+		return app.Spec.Selector
+	case *batchv1.Job:
+		return app.Spec.Selector
+	case *batchv1.CronJob:
+		return app.Spec.JobTemplate.Spec.Selector
+	case *corev1.ReplicationController:
+		return &metav1.LabelSelector{MatchLabels: app.Spec.Selector}
+	case *unstructured.Unstructured:
+		if isDeploymentConfig(app) {
+			selector, _, _ := unstructured.NestedStringMap(app.Object, "spec", "selector")
+			return &metav1.LabelSelector{MatchLabels: selector}
+		}
+	}
+
+	if c.Scale != nil && c.Scale.Status.Selector != "" {
+		selector, err := metav1.ParseToLabelSelector(c.Scale.Status.Selector)
+		if err == nil {
+			return selector
+		}
+	}
+
+	return nil
+}
+
+// TemplateAnnotations returns the annotations of the Pod template of the
+// controller, the ones of the Pod itself if it is an orphan, and nil for the
+// third party controllers.
+func (c PodTopController) TemplateAnnotations() map[string]string {
+	switch app := c.APP.(type) {
+	case *corev1.Pod:
+		return app.Annotations
+	case *appsv1.Deployment:
+		return app.Spec.Template.Annotations
+	case *appsv1.ReplicaSet:
+		return app.Spec.Template.Annotations
+	case *appsv1.StatefulSet:
+		return app.Spec.Template.Annotations
+	case *appsv1.DaemonSet:
+		return app.Spec.Template.Annotations
+	case *batchv1.Job:
+		return app.Spec.Template.Annotations
+	case *batchv1.CronJob:
+		return app.Spec.JobTemplate.Spec.Template.Annotations
+	case *corev1.ReplicationController:
+		if app.Spec.Template == nil {
+			return nil
+		}
+		return app.Spec.Template.Annotations
+	case *unstructured.Unstructured:
+		if isDeploymentConfig(app) {
+			annotations, _, _ := unstructured.NestedStringMap(app.Object, "spec", "template", "metadata", "annotations")
+			return annotations
+		}
+	}
+
+	return nil
+}
+
+// isDeploymentConfig tells whether the object is a DeploymentConfig of
+// OpenShift, which is only read as unstructured.
+func isDeploymentConfig(obj *unstructured.Unstructured) bool {
+	return obj.GetAPIVersion() == "apps.openshift.io/v1" && obj.GetKind() == "DeploymentConfig"
+}
+
+func derefReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+// jobPodNum calculates the number of the Pods of the Job, Parallelism and
+// Completions default to 1 if unset.
+// reference: https://kubernetes.io/docs/concepts/workloads/controllers/job/
+func jobPodNum(parallelism, completions *int32) int {
+	switch {
+	case parallelism != nil && completions == nil:
+		// parallel Jobs with a work queue
+		if *parallelism == 0 {
+			return 1
+		}
+		return int(*parallelism)
+
+	case completions != nil:
+		// non-parallel Jobs, or parallel Jobs with a fixed completion count
+		if *completions == 0 {
+			return 1
+		}
+		return int(*completions)
+	}
+
+	return 1
+}
+
+// IPConsumer is an application consuming the IP addresses of IPPools, the
+// Pods not controlled by any application are consumers on their own.
+type IPConsumer struct {
+	Namespace string
+	Kind      string
+	Name      string
+	IPCount   int
 }
 
 type AnnoPodIPPoolValue struct {
@@ -39,9 +207,62 @@ type AnnoIPPoolItem struct {
 
 type AnnoPodRoutesValue []AnnoRouteItem
 
+// AnnoPodMACsValue is the value of the Pod annotation "ipam.spidernet.io/macs",
+// mapping the NICs to their MAC addresses.
+type AnnoPodMACsValue map[string]string
+
+// AnnoPodBandwidthValue is the value of the Pod annotation "ipam.spidernet.io/bandwidth",
+// the same as 'spec.bandwidth' of IPPools.
+type AnnoPodBandwidthValue struct {
+	IngressRate  *resource.Quantity `json:"ingressRate,omitempty"`
+	IngressBurst *resource.Quantity `json:"ingressBurst,omitempty"`
+	EgressRate   *resource.Quantity `json:"egressRate,omitempty"`
+	EgressBurst  *resource.Quantity `json:"egressBurst,omitempty"`
+}
+
+// AnnoPodAssignedValue is the value of the Pod annotation "ipam.spidernet.io/assigned",
+// sorted by the NICs.
+type AnnoPodAssignedValue []AnnoAssignedItem
+
+// AnnoAssignedItem is the IP addresses assigned to a NIC of the Pod and their
+// IPPools.
+type AnnoAssignedItem struct {
+	NIC        string `json:"interface"`
+	IPv4IPPool string `json:"ipv4pool,omitempty"`
+	IPv6IPPool string `json:"ipv6pool,omitempty"`
+	IPv4       string `json:"ipv4,omitempty"`
+	IPv6       string `json:"ipv6,omitempty"`
+	Vlan       int64  `json:"vlan,omitempty"`
+}
+
+// AnnoRouteItem is a custom route of the Pod. The route is injected to the
+// NIC specified by Interface, or to the NIC whose subnet contains the gateway
+// if Interface is omitted.
 type AnnoRouteItem struct {
-	Dst string `json:"dst"`
-	Gw  string `json:"gw"`
+	Interface string `json:"interface,omitempty"`
+	Dst       string `json:"dst"`
+	Gw        string `json:"gw"`
+}
+
+// AnnoPodConfigValue is the value of the Pod annotation "ipam.spidernet.io/config",
+// in JSON or YAML.
+type AnnoPodConfigValue struct {
+	Interfaces      []AnnoPodConfigInterface `json:"interfaces,omitempty"`
+	Routes          AnnoPodRoutesValue       `json:"routes,omitempty"`
+	IPPoolIPNumber  string                   `json:"ippoolIPNumber,omitempty"`
+	ReclaimIPPool   *bool                    `json:"reclaimIPPool,omitempty"`
+	SubnetBlockSize int                      `json:"subnetBlockSize,omitempty"`
+}
+
+// AnnoPodConfigInterface describes the IPPools or SpiderSubnets of a NIC, the
+// interface could be omitted only if there is only one NIC.
+type AnnoPodConfigInterface struct {
+	Interface    string   `json:"interface,omitempty"`
+	IPv4Pools    []string `json:"ipv4Pools,omitempty"`
+	IPv6Pools    []string `json:"ipv6Pools,omitempty"`
+	IPv4Subnets  []string `json:"ipv4Subnets,omitempty"`
+	IPv6Subnets  []string `json:"ipv6Subnets,omitempty"`
+	CleanGateway bool     `json:"cleanGateway,omitempty"`
 }
 
 type AnnoNSDefautlV4PoolValue []string
@@ -54,14 +275,16 @@ type ClusterDefaultPoolConfig struct {
 	ClusterDefaultIPv4Subnet             []string
 	ClusterDefaultIPv6Subnet             []string
 	ClusterSubnetDefaultFlexibleIPNumber int
+	ClusterSubnetDefaultReclaimIPPool    bool
 }
 
 type PodSubnetAnnoConfig struct {
-	MultipleSubnets []AnnoSubnetItem
-	SingleSubnet    *AnnoSubnetItem
-	FlexibleIPNum   *int
-	AssignIPNum     int
-	ReclaimIPPool   bool
+	MultipleSubnets   []AnnoSubnetItem
+	SingleSubnet      *AnnoSubnetItem
+	FlexibleIPNum     *int
+	AssignIPNum       int
+	ReclaimIPPool     bool
+	BlockPrefixLength int
 }
 
 func (in *PodSubnetAnnoConfig) String() string {
@@ -74,7 +297,8 @@ func (in *PodSubnetAnnoConfig) String() string {
 		`SingleSubnet:` + strings.Replace(strings.Replace(in.SingleSubnet.String(), "AnnoSubnetItem", "", 1), `&`, ``, 1) + `,`,
 		`FlexibleIPNum:` + stringutil.ValueToStringGenerated(in.FlexibleIPNum) + `,`,
 		`AssignIPNumber:` + fmt.Sprintf("%v", in.AssignIPNum) + `,`,
-		`ReclaimIPPool:` + fmt.Sprintf("%v", in.ReclaimIPPool),
+		`ReclaimIPPool:` + fmt.Sprintf("%v", in.ReclaimIPPool) + `,`,
+		`BlockPrefixLength:` + fmt.Sprintf("%v", in.BlockPrefixLength),
 		`}`,
 	}, "")
 	return s
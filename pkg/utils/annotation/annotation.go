@@ -0,0 +1,217 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package annotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolip "github.com/spidernet-io/spiderpool/pkg/ip"
+	"github.com/spidernet-io/spiderpool/pkg/types"
+)
+
+// legacyPodAnnotations are the separate Pod annotations replaced by the
+// annotation "ipam.spidernet.io/config".
+var legacyPodAnnotations = []string{
+	constant.AnnoPodIPPool,
+	constant.AnnoPodIPPools,
+	constant.AnnoSpiderSubnet,
+	constant.AnnoSpiderSubnets,
+	constant.AnnoPodRoutes,
+	constant.AnnoSpiderSubnetPoolIPNumber,
+	constant.AnnoSpiderSubnetReclaimIPPool,
+	constant.AnnoSpiderSubnetBlockSize,
+}
+
+// ParsePodConfig parses the value of the Pod annotation "ipam.spidernet.io/config"
+// in JSON or YAML. Unlike the separate annotations, unknown fields and the
+// configurations that could only be honored in part, such as multiple
+// SpiderSubnets of one IP version, are rejected rather than ignored.
+func ParsePodConfig(value string) (*types.AnnoPodConfigValue, error) {
+	errPrefix := fmt.Errorf("%w, invalid format of Pod annotation '%s'", constant.ErrWrongInput, constant.AnnoPodConfig)
+
+	var config types.AnnoPodConfigValue
+	if err := yaml.UnmarshalStrict([]byte(value), &config); err != nil {
+		return nil, fmt.Errorf("%w: %v", errPrefix, err)
+	}
+
+	nicSet := map[string]struct{}{}
+	var withPools, withSubnets bool
+	for _, v := range config.Interfaces {
+		if v.Interface == "" && (len(config.Interfaces) > 1 || v.CleanGateway) {
+			return nil, fmt.Errorf("%w: interface must be specified with multiple interfaces or cleanGateway", errPrefix)
+		}
+		if _, ok := nicSet[v.Interface]; ok {
+			return nil, fmt.Errorf("%w: duplicate interface %s", errPrefix, v.Interface)
+		}
+		nicSet[v.Interface] = struct{}{}
+
+		pools := len(v.IPv4Pools) != 0 || len(v.IPv6Pools) != 0
+		subnets := len(v.IPv4Subnets) != 0 || len(v.IPv6Subnets) != 0
+		if pools == subnets {
+			return nil, fmt.Errorf("%w: interface '%s' requires either IPPools or SpiderSubnets", errPrefix, v.Interface)
+		}
+		if len(v.IPv4Subnets) > 1 || len(v.IPv6Subnets) > 1 {
+			return nil, fmt.Errorf("%w: interface '%s' supports only one SpiderSubnet of each IP version", errPrefix, v.Interface)
+		}
+		withPools = withPools || pools
+		withSubnets = withSubnets || subnets
+	}
+	if withPools && withSubnets {
+		return nil, fmt.Errorf("%w: IPPools and SpiderSubnets can't be mixed", errPrefix)
+	}
+	if withPools && (config.IPPoolIPNumber != "" || config.ReclaimIPPool != nil || config.SubnetBlockSize != 0) {
+		return nil, fmt.Errorf("%w: ippoolIPNumber, reclaimIPPool and subnetBlockSize only work with SpiderSubnets", errPrefix)
+	}
+
+	for _, route := range config.Routes {
+		if err := spiderpoolip.IsRouteWithoutIPVersion(route.Dst, route.Gw); err != nil {
+			return nil, fmt.Errorf("%w: %v", errPrefix, err)
+		}
+	}
+
+	if config.SubnetBlockSize < 0 || config.SubnetBlockSize > 128 {
+		return nil, fmt.Errorf("%w: invalid subnetBlockSize %d", errPrefix, config.SubnetBlockSize)
+	}
+
+	return &config, nil
+}
+
+// ConvertPodConfig converts the Pod annotation "ipam.spidernet.io/config" into
+// the separate annotations, so that they are handled in the same way. If the
+// annotation exists, it returns new annotations where the separate ones are
+// replaced as a whole, otherwise it returns the annotations as is.
+func ConvertPodConfig(annotations map[string]string) (map[string]string, error) {
+	value, ok := annotations[constant.AnnoPodConfig]
+	if !ok {
+		return annotations, nil
+	}
+
+	config, err := ParsePodConfig(value)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		converted[k] = v
+	}
+	for _, k := range legacyPodAnnotations {
+		delete(converted, k)
+	}
+
+	set := func(key string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		converted[key] = string(data)
+		return nil
+	}
+
+	var pools types.AnnoPodIPPoolsValue
+	var subnets []types.AnnoSubnetItem
+	for _, v := range config.Interfaces {
+		if len(v.IPv4Subnets) != 0 || len(v.IPv6Subnets) != 0 {
+			subnets = append(subnets, types.AnnoSubnetItem{
+				Interface: v.Interface,
+				IPv4:      v.IPv4Subnets,
+				IPv6:      v.IPv6Subnets,
+			})
+			continue
+		}
+		pools = append(pools, types.AnnoIPPoolItem{
+			NIC:          v.Interface,
+			IPv4Pools:    v.IPv4Pools,
+			IPv6Pools:    v.IPv6Pools,
+			CleanGateway: v.CleanGateway,
+		})
+	}
+
+	switch {
+	case len(pools) == 1 && pools[0].NIC == "":
+		err = set(constant.AnnoPodIPPool, types.AnnoPodIPPoolValue{IPv4Pools: pools[0].IPv4Pools, IPv6Pools: pools[0].IPv6Pools})
+	case len(pools) != 0:
+		err = set(constant.AnnoPodIPPools, pools)
+	case len(subnets) == 1 && subnets[0].Interface == "":
+		err = set(constant.AnnoSpiderSubnet, subnets[0])
+	case len(subnets) != 0:
+		err = set(constant.AnnoSpiderSubnets, subnets)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(config.Routes) != 0 {
+		if err := set(constant.AnnoPodRoutes, config.Routes); err != nil {
+			return nil, err
+		}
+	}
+	if config.IPPoolIPNumber != "" {
+		converted[constant.AnnoSpiderSubnetPoolIPNumber] = config.IPPoolIPNumber
+	}
+	if config.ReclaimIPPool != nil {
+		converted[constant.AnnoSpiderSubnetReclaimIPPool] = strconv.FormatBool(*config.ReclaimIPPool)
+	}
+	if config.SubnetBlockSize != 0 {
+		converted[constant.AnnoSpiderSubnetBlockSize] = strconv.Itoa(config.SubnetBlockSize)
+	}
+
+	return converted, nil
+}
+
+// IsDefaultRoutePolicy reports whether the value of the annotation
+// "ipam.spidernet.io/default-route-policy" is valid.
+func IsDefaultRoutePolicy(policy string) bool {
+	switch policy {
+	case constant.DefaultRoutePolicyAll, constant.DefaultRoutePolicyFirstNIC, constant.DefaultRoutePolicyUnderlay:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsIPFamilyOrder reports whether the value of the annotation
+// "ipam.spidernet.io/ip-family-order" is valid.
+func IsIPFamilyOrder(order string) bool {
+	switch order {
+	case constant.IPFamilyOrderIPv4First, constant.IPFamilyOrderIPv6First:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParsePodBandwidth parses the value of the Pod annotation
+// "ipam.spidernet.io/bandwidth", the rates and bursts must be positive.
+func ParsePodBandwidth(value string) (*types.AnnoPodBandwidthValue, error) {
+	errPrefix := fmt.Errorf("%w, invalid format of Pod annotation '%s'", constant.ErrWrongInput, constant.AnnoPodBandwidth)
+
+	var bandwidth types.AnnoPodBandwidthValue
+	if err := json.Unmarshal([]byte(value), &bandwidth); err != nil {
+		return nil, fmt.Errorf("%w: %v", errPrefix, err)
+	}
+
+	limits := []struct {
+		name string
+		q    *resource.Quantity
+	}{
+		{"ingressRate", bandwidth.IngressRate},
+		{"ingressBurst", bandwidth.IngressBurst},
+		{"egressRate", bandwidth.EgressRate},
+		{"egressBurst", bandwidth.EgressBurst},
+	}
+	for _, l := range limits {
+		if l.q != nil && l.q.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: %s must be positive", errPrefix, l.name)
+		}
+	}
+
+	return &bandwidth, nil
+}
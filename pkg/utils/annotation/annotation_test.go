@@ -0,0 +1,133 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package annotation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/utils/annotation"
+)
+
+var _ = Describe("Annotation", Label("annotation_test"), func() {
+	Describe("ConvertPodConfig", func() {
+		It("returns the annotations without config as is", func() {
+			annotations := map[string]string{constant.AnnoPodIPPool: `{"ipv4":["pool"]}`}
+
+			converted, err := annotation.ConvertPodConfig(annotations)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(converted).To(Equal(annotations))
+		})
+
+		DescribeTable("converts the config into the separate annotations",
+			func(config string, expected map[string]string) {
+				annotations := map[string]string{
+					constant.AnnoPodConfig:                 config,
+					constant.AnnoPodIPPool:                 `{"ipv4":["legacy-pool"]}`,
+					constant.AnnoSpiderSubnetReclaimIPPool: "false",
+					"foo":                                  "bar",
+				}
+
+				converted, err := annotation.ConvertPodConfig(annotations)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected[constant.AnnoPodConfig] = config
+				expected["foo"] = "bar"
+				Expect(converted).To(Equal(expected))
+				Expect(annotations).To(HaveKeyWithValue(constant.AnnoPodIPPool, `{"ipv4":["legacy-pool"]}`))
+			},
+			Entry("IPPools without interface",
+				`{"interfaces":[{"ipv4Pools":["pool"]}]}`,
+				map[string]string{constant.AnnoPodIPPool: `{"ipv4":["pool"]}`},
+			),
+			Entry("IPPools of multiple interfaces in YAML",
+				"interfaces:\n- interface: eth0\n  ipv4Pools: [pool]\n- interface: net1\n  ipv6Pools: [pool6]\n  cleanGateway: true\n",
+				map[string]string{constant.AnnoPodIPPools: `[{"interface":"eth0","ipv4":["pool"],"cleangateway":false},{"interface":"net1","ipv6":["pool6"],"cleangateway":true}]`},
+			),
+			Entry("SpiderSubnet without interface",
+				`{"interfaces":[{"ipv4Subnets":["subnet"]}],"ippoolIPNumber":"+1","reclaimIPPool":true,"subnetBlockSize":28}`,
+				map[string]string{
+					constant.AnnoSpiderSubnet:              `{"ipv4":["subnet"]}`,
+					constant.AnnoSpiderSubnetPoolIPNumber:  "+1",
+					constant.AnnoSpiderSubnetReclaimIPPool: "true",
+					constant.AnnoSpiderSubnetBlockSize:     "28",
+				},
+			),
+			Entry("SpiderSubnets of an interface and routes",
+				`{"interfaces":[{"interface":"eth0","ipv4Subnets":["subnet"]}],"routes":[{"dst":"10.0.0.0/16","gw":"172.18.40.1"}]}`,
+				map[string]string{
+					constant.AnnoSpiderSubnets: `[{"interface":"eth0","ipv4":["subnet"]}]`,
+					constant.AnnoPodRoutes:     `[{"dst":"10.0.0.0/16","gw":"172.18.40.1"}]`,
+				},
+			),
+			Entry("only routes",
+				`{"routes":[{"dst":"10.0.0.0/16","gw":"172.18.40.1"}]}`,
+				map[string]string{constant.AnnoPodRoutes: `[{"dst":"10.0.0.0/16","gw":"172.18.40.1"}]`},
+			),
+		)
+
+		DescribeTable("rejects the invalid config",
+			func(config string) {
+				_, err := annotation.ConvertPodConfig(map[string]string{constant.AnnoPodConfig: config})
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			},
+			Entry("malformed document", `{"interfaces":`),
+			Entry("unknown field", `{"interfaces":[{"ipv4":["pool"]}]}`),
+			Entry("multiple interfaces without name", `{"interfaces":[{"ipv4Pools":["a"]},{"interface":"net1","ipv4Pools":["b"]}]}`),
+			Entry("cleanGateway without interface", `{"interfaces":[{"ipv4Pools":["a"],"cleanGateway":true}]}`),
+			Entry("duplicate interfaces", `{"interfaces":[{"interface":"eth0","ipv4Pools":["a"]},{"interface":"eth0","ipv4Pools":["b"]}]}`),
+			Entry("interface without IPPools or SpiderSubnets", `{"interfaces":[{"interface":"eth0"}]}`),
+			Entry("IPPools and SpiderSubnets of an interface", `{"interfaces":[{"ipv4Pools":["a"],"ipv4Subnets":["b"]}]}`),
+			Entry("IPPools and SpiderSubnets of interfaces", `{"interfaces":[{"interface":"eth0","ipv4Pools":["a"]},{"interface":"net1","ipv4Subnets":["b"]}]}`),
+			Entry("multiple SpiderSubnets of an IP version", `{"interfaces":[{"ipv4Subnets":["a","b"]}]}`),
+			Entry("ippoolIPNumber with IPPools", `{"interfaces":[{"ipv4Pools":["a"]}],"ippoolIPNumber":"1"}`),
+			Entry("invalid route", `{"routes":[{"dst":"10.0.0.0/16","gw":"invalid"}]}`),
+			Entry("invalid subnetBlockSize", `{"subnetBlockSize":129}`),
+		)
+	})
+
+	DescribeTable("IsDefaultRoutePolicy",
+		func(policy string, valid bool) {
+			Expect(annotation.IsDefaultRoutePolicy(policy)).To(Equal(valid))
+		},
+		Entry("all", constant.DefaultRoutePolicyAll, true),
+		Entry("first NIC", constant.DefaultRoutePolicyFirstNIC, true),
+		Entry("underlay", constant.DefaultRoutePolicyUnderlay, true),
+		Entry("unknown", "last-nic", false),
+		Entry("empty", "", false),
+	)
+
+	DescribeTable("IsIPFamilyOrder",
+		func(order string, valid bool) {
+			Expect(annotation.IsIPFamilyOrder(order)).To(Equal(valid))
+		},
+		Entry("IPv4 first", constant.IPFamilyOrderIPv4First, true),
+		Entry("IPv6 first", constant.IPFamilyOrderIPv6First, true),
+		Entry("lower case", "ipv6first", false),
+		Entry("empty", "", false),
+	)
+
+	Describe("ParsePodBandwidth", func() {
+		It("parses the rates and bursts", func() {
+			bandwidth, err := annotation.ParsePodBandwidth(`{"ingressRate":"100M","egressRate":"50M","egressBurst":"10M"}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bandwidth.IngressRate.Value()).To(Equal(int64(100000000)))
+			Expect(bandwidth.IngressBurst).To(BeNil())
+			Expect(bandwidth.EgressRate.Value()).To(Equal(int64(50000000)))
+			Expect(bandwidth.EgressBurst.Value()).To(Equal(int64(10000000)))
+		})
+
+		DescribeTable("rejects the invalid bandwidth",
+			func(value string) {
+				_, err := annotation.ParsePodBandwidth(value)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			},
+			Entry("invalid JSON", `{"ingressRate":`),
+			Entry("invalid quantity", `{"ingressRate":"fast"}`),
+			Entry("zero rate", `{"ingressRate":"0"}`),
+			Entry("negative burst", `{"egressBurst":"-1M"}`),
+		)
+	})
+})
@@ -0,0 +1,78 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cachescope scopes the objects cached by the runtime managers with
+// label and field selectors, so that the components only hold the objects
+// relevant to Spiderpool in memory.
+package cachescope
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+type Config struct {
+	// PodLabelSelector scopes the cached Pods by their labels, e.g.
+	// 'app in (a, b)', empty means all Pods.
+	PodLabelSelector string
+	// PodFieldSelector scopes the cached Pods by their fields, e.g.
+	// 'spec.hostNetwork=false', empty means all Pods.
+	PodFieldSelector string
+}
+
+// IsScoped tells whether any object is scoped.
+func (c Config) IsScoped() bool {
+	return c.PodLabelSelector != "" || c.PodFieldSelector != ""
+}
+
+// CacheOptions parses the selectors into the options of the cache of the
+// runtime manager. The objects out of the scope are neither listed nor got
+// from the cache, so the readers falling back to API server on NotFound are
+// not affected for Get.
+func (c Config) CacheOptions() (cache.Options, error) {
+	if !c.IsScoped() {
+		return cache.Options{}, nil
+	}
+
+	var podSelector cache.ObjectSelector
+	if c.PodLabelSelector != "" {
+		selector, err := labels.Parse(c.PodLabelSelector)
+		if err != nil {
+			return cache.Options{}, fmt.Errorf("%w: invalid Pod label selector '%s': %v", constant.ErrWrongInput, c.PodLabelSelector, err)
+		}
+		podSelector.Label = selector
+	}
+	if c.PodFieldSelector != "" {
+		selector, err := fields.ParseSelector(c.PodFieldSelector)
+		if err != nil {
+			return cache.Options{}, fmt.Errorf("%w: invalid Pod field selector '%s': %v", constant.ErrWrongInput, c.PodFieldSelector, err)
+		}
+		podSelector.Field = selector
+	}
+
+	return cache.Options{
+		SelectorsByObject: cache.SelectorsByObject{
+			&corev1.Pod{}: podSelector,
+		},
+	}, nil
+}
+
+// JoinSelectors joins the non-empty selectors, so that the objects match all
+// of them.
+func JoinSelectors(selectors ...string) string {
+	var nonEmpty []string
+	for _, s := range selectors {
+		if s = strings.TrimSpace(s); s != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+
+	return strings.Join(nonEmpty, ",")
+}
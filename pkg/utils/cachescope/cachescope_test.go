@@ -0,0 +1,64 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package cachescope_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/utils/cachescope"
+)
+
+var _ = Describe("CacheScope", Label("cachescope_test"), func() {
+	Describe("CacheOptions", func() {
+		It("scopes nothing without selectors", func() {
+			config := cachescope.Config{}
+			Expect(config.IsScoped()).To(BeFalse())
+
+			options, err := config.CacheOptions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.SelectorsByObject).To(BeEmpty())
+		})
+
+		It("scopes the Pods by labels and fields", func() {
+			config := cachescope.Config{
+				PodLabelSelector: "app in (a, b)",
+				PodFieldSelector: "spec.hostNetwork=false",
+			}
+			Expect(config.IsScoped()).To(BeTrue())
+
+			options, err := config.CacheOptions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(options.SelectorsByObject).To(HaveLen(1))
+			for object, selector := range options.SelectorsByObject {
+				Expect(object).To(BeAssignableToTypeOf(&corev1.Pod{}))
+				Expect(selector.Label.Matches(labels.Set{"app": "a"})).To(BeTrue())
+				Expect(selector.Label.Matches(labels.Set{"app": "c"})).To(BeFalse())
+				Expect(selector.Field.Matches(fields.Set{"spec.hostNetwork": "false"})).To(BeTrue())
+				Expect(selector.Field.Matches(fields.Set{"spec.hostNetwork": "true"})).To(BeFalse())
+			}
+		})
+
+		It("inputs invalid label selector", func() {
+			_, err := cachescope.Config{PodLabelSelector: "app in ("}.CacheOptions()
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+
+		It("inputs invalid field selector", func() {
+			_, err := cachescope.Config{PodFieldSelector: "spec.hostNetwork"}.CacheOptions()
+			Expect(err).To(MatchError(constant.ErrWrongInput))
+		})
+	})
+
+	Describe("JoinSelectors", func() {
+		It("joins the non-empty selectors", func() {
+			Expect(cachescope.JoinSelectors("spec.hostNetwork=false", " ", "status.phase!=Failed")).To(Equal("spec.hostNetwork=false,status.phase!=Failed"))
+			Expect(cachescope.JoinSelectors("", "")).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,53 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package label
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+// ValidateApplicationLabelKeys checks whether the configured application
+// label keys are qualified label keys. Keys with the Spiderpool prefix are
+// reserved for Spiderpool itself and are rejected.
+func ValidateApplicationLabelKeys(keys []string) error {
+	for _, key := range keys {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("%w: invalid application label key '%s': %s", constant.ErrWrongInput, key, strings.Join(errs, "; "))
+		}
+		if strings.HasPrefix(key, constant.AnnotationPre+"/") {
+			return fmt.Errorf("%w: application label key '%s' is reserved by Spiderpool", constant.ErrWrongInput, key)
+		}
+	}
+
+	return nil
+}
+
+// StampApplicationLabels copies the values of keys in source onto target,
+// and returns the new labels of target and whether they changed. Labels
+// already on target but missing from source are left untouched.
+func StampApplicationLabels(target, source map[string]string, keys []string) (map[string]string, bool) {
+	changed := false
+	for _, key := range keys {
+		value, ok := source[key]
+		if !ok {
+			continue
+		}
+		if old, ok := target[key]; ok && old == value {
+			continue
+		}
+
+		if target == nil {
+			target = make(map[string]string, len(keys))
+		}
+		target[key] = value
+		changed = true
+	}
+
+	return target, changed
+}
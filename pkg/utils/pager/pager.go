@@ -0,0 +1,75 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pager lists Kubernetes objects page by page, so that the callers
+// only hold one page of the objects in memory at a time.
+package pager
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DefaultPageSize int64 = 500
+)
+
+type Config struct {
+	// PageSize is the maximum number of objects listed per page, default to
+	// DefaultPageSize.
+	PageSize int64
+	// PageInterval is the pause between the requests of two consecutive
+	// pages, to limit the rate of the requests to the API server.
+	PageInterval time.Duration
+}
+
+// ListPages lists the objects page by page into the lists created by newList,
+// and calls fn with each page until the last one is listed or fn returns an
+// error. The page passed to fn is not reused, so its objects could be kept.
+//
+// The readers backed by the informer cache neither paginate nor report the
+// resource version of the list, their first page is truncated by the page
+// size, so the objects are listed at once from them.
+func ListPages(ctx context.Context, reader client.Reader, config Config, newList func() client.ObjectList, fn func(list client.ObjectList) error, opts ...client.ListOption) error {
+	pageSize := config.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	continueToken := ""
+	for {
+		list := newList()
+		pageOpts := append(append([]client.ListOption{}, opts...), client.Limit(pageSize), client.Continue(continueToken))
+		if err := reader.List(ctx, list, pageOpts...); err != nil {
+			return err
+		}
+
+		if continueToken == "" && list.GetResourceVersion() == "" && int64(meta.LenList(list)) >= pageSize {
+			list = newList()
+			if err := reader.List(ctx, list, opts...); err != nil {
+				return err
+			}
+			return fn(list)
+		}
+
+		if err := fn(list); err != nil {
+			return err
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+
+		if config.PageInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(config.PageInterval):
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package pager_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/utils/pager"
+)
+
+// pagingReader paginates the Pods with the index of the next one as the
+// continue token like the API server, or returns the first Limit Pods
+// without the resource version like the informer cache.
+type pagingReader struct {
+	client.Reader
+	pods     []corev1.Pod
+	cache    bool
+	requests int
+}
+
+func (r *pagingReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	r.requests++
+
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	start := 0
+	if listOpts.Continue != "" {
+		var err error
+		if start, err = strconv.Atoi(listOpts.Continue); err != nil {
+			return err
+		}
+	}
+	end := len(r.pods)
+	if listOpts.Limit > 0 && start+int(listOpts.Limit) < end {
+		end = start + int(listOpts.Limit)
+	}
+
+	podList := list.(*corev1.PodList)
+	podList.Items = append([]corev1.Pod{}, r.pods[start:end]...)
+	if !r.cache {
+		podList.ResourceVersion = "1"
+		if end < len(r.pods) {
+			podList.Continue = strconv.Itoa(end)
+		}
+	}
+
+	return nil
+}
+
+var _ = Describe("Pager", Label("pager_test"), func() {
+	var reader *pagingReader
+
+	newPodList := func() client.ObjectList { return &corev1.PodList{} }
+
+	BeforeEach(func() {
+		reader = &pagingReader{}
+		for i := 0; i < 5; i++ {
+			reader.pods = append(reader.pods, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}})
+		}
+	})
+
+	collect := func(names *[]string) func(client.ObjectList) error {
+		return func(list client.ObjectList) error {
+			for _, pod := range list.(*corev1.PodList).Items {
+				*names = append(*names, pod.Name)
+			}
+			return nil
+		}
+	}
+
+	It("lists the objects page by page", func() {
+		var names []string
+		err := pager.ListPages(context.TODO(), reader, pager.Config{PageSize: 2}, newPodList, collect(&names))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"pod-0", "pod-1", "pod-2", "pod-3", "pod-4"}))
+		Expect(reader.requests).To(Equal(3))
+	})
+
+	It("lists the objects at once from the reader not paginating", func() {
+		reader.cache = true
+
+		var names []string
+		err := pager.ListPages(context.TODO(), reader, pager.Config{PageSize: 2}, newPodList, collect(&names))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(HaveLen(5))
+		Expect(reader.requests).To(Equal(2))
+	})
+
+	It("stops once fn returns an error", func() {
+		pages := 0
+		err := pager.ListPages(context.TODO(), reader, pager.Config{PageSize: 2}, newPodList, func(list client.ObjectList) error {
+			pages++
+			return constant.ErrUnknown
+		})
+		Expect(err).To(MatchError(constant.ErrUnknown))
+		Expect(pages).To(Equal(1))
+	})
+
+	It("stops waiting for the next page once the context is done", func() {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+
+		err := pager.ListPages(ctx, reader, pager.Config{PageSize: 2, PageInterval: time.Hour}, newPodList, func(client.ObjectList) error { return nil })
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(reader.requests).To(Equal(1))
+	})
+})
@@ -0,0 +1,85 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+)
+
+const (
+	defaultWebhookConfigurationName = constant.SpiderpoolController
+	defaultResyncPeriod             = 60 * time.Second
+)
+
+type WebhookConfigReconcilerConfig struct {
+	// WebhookConfigurationName is the name of both MutatingWebhookConfiguration
+	// and ValidatingWebhookConfiguration of Spiderpool.
+	WebhookConfigurationName string
+	NamespaceSelector        *metav1.LabelSelector
+	ObjectSelector           *metav1.LabelSelector
+	ResyncPeriod             time.Duration
+
+	// URL is the base URL of the webhook server of spiderpool-controller, e.g.
+	// https://10.6.0.10:5722. If specified, the webhooks are called with the URL
+	// instead of the Service reference, which is used when spiderpool-controller
+	// runs out of the cluster.
+	URL string
+	// CABundle is the PEM encoded CA bundle used to verify the certificate of
+	// the webhook server.
+	CABundle []byte
+
+	// The following fields are only used to install the webhook
+	// configurations and the conversion webhook of CRD. ServiceName,
+	// ServiceNamespace and ServicePort refer to the Service of the webhook
+	// server, and Version is the version of spiderpool-controller recorded on
+	// the webhook configurations.
+	ServiceName              string
+	ServiceNamespace         string
+	ServicePort              int32
+	EnablePodWebhook         bool
+	EnablePodMutatingWebhook bool
+	Version                  string
+	// FieldManager is the field manager of server-side apply.
+	FieldManager string
+}
+
+func setDefaultsForWebhookConfigReconcilerConfig(config WebhookConfigReconcilerConfig) WebhookConfigReconcilerConfig {
+	if config.WebhookConfigurationName == "" {
+		config.WebhookConfigurationName = defaultWebhookConfigurationName
+	}
+
+	if config.ServiceName == "" {
+		config.ServiceName = constant.SpiderpoolController
+	}
+
+	if config.FieldManager == "" {
+		config.FieldManager = constant.SpiderpoolController
+	}
+
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultResyncPeriod
+	}
+
+	return config
+}
+
+const defaultReportFlushPeriod = 30 * time.Second
+
+type WebhookReporterConfig struct {
+	// FlushPeriod is the period to merge the denials recorded by this
+	// replica into the SpiderWebhookReport.
+	FlushPeriod time.Duration
+}
+
+func setDefaultsForWebhookReporterConfig(config WebhookReporterConfig) WebhookReporterConfig {
+	if config.FlushPeriod <= 0 {
+		config.FlushPeriod = defaultReportFlushPeriod
+	}
+
+	return config
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/spidernet-io/spiderpool/pkg/metric"
+)
+
+// The reasons of the requests denied by the webhooks. The field errors not
+// classified are reported as 'invalid_<field>', e.g. 'invalid_routes'.
+const (
+	DenialReasonOverlap     = "overlap"
+	DenialReasonHeadroom    = "headroom"
+	DenialReasonGateway     = "gateway"
+	DenialReasonTerminating = "terminating"
+	DenialReasonImmutable   = "immutable"
+	DenialReasonIPInUse     = "ip_in_use"
+	DenialReasonInternal    = "internal"
+	DenialReasonOther       = "other"
+)
+
+const maxDenialMessageLength = 512
+
+// Denial is a request denied by the webhooks.
+type Denial struct {
+	Kind      string
+	Operation string
+	Reason    string
+	Message   string
+	Time      time.Time
+}
+
+// DenialReason classifies the error returned by the validating webhooks
+// into the kind of the misconfiguration, with the first field error if
+// there are many.
+func DenialReason(err error) string {
+	var apiStatus apierrors.APIStatus
+	if !errors.As(err, &apiStatus) {
+		return DenialReasonOther
+	}
+
+	status := apiStatus.Status()
+	if strings.Contains(status.Message, "terminating") {
+		return DenialReasonTerminating
+	}
+	if status.Details == nil || len(status.Details.Causes) == 0 {
+		return DenialReasonOther
+	}
+
+	cause := status.Details.Causes[0]
+	switch {
+	case cause.Type == metav1.CauseType(field.ErrorTypeInternal):
+		return DenialReasonInternal
+	case strings.Contains(cause.Message, "minFreeIPs"):
+		return DenialReasonHeadroom
+	case strings.Contains(cause.Message, "overlap") || strings.Contains(cause.Message, "share the subnet"):
+		return DenialReasonOverlap
+	case strings.Contains(cause.Message, "is not changeable"):
+		return DenialReasonImmutable
+	case strings.Contains(cause.Message, "being used by") || strings.Contains(cause.Message, "reserved for"):
+		return DenialReasonIPInUse
+	case cause.Field == "spec.gateway":
+		return DenialReasonGateway
+	}
+
+	// Keep the cardinality of the reasons bounded by the top-level fields,
+	// e.g. 'spec.routes[0].dst' is reported as 'invalid_routes'.
+	root := strings.TrimPrefix(cause.Field, "spec.")
+	root = strings.TrimPrefix(root, "metadata.")
+	if i := strings.IndexAny(root, ".["); i >= 0 {
+		root = root[:i]
+	}
+	if root == "" {
+		return DenialReasonOther
+	}
+
+	return "invalid_" + root
+}
+
+// DenialValidator wraps the validator of the kind to record the requests it
+// denies, with the metric 'webhook_denial_counts' and the optional
+// reporter.
+func DenialValidator(kind string, validator webhook.CustomValidator, reporter WebhookReporter) webhook.CustomValidator {
+	return &denialValidator{kind: kind, validator: validator, reporter: reporter}
+}
+
+type denialValidator struct {
+	kind      string
+	validator webhook.CustomValidator
+	reporter  WebhookReporter
+}
+
+func (v *denialValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	err := v.validator.ValidateCreate(ctx, obj)
+	v.record(ctx, "CREATE", err)
+
+	return err
+}
+
+func (v *denialValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	err := v.validator.ValidateUpdate(ctx, oldObj, newObj)
+	v.record(ctx, "UPDATE", err)
+
+	return err
+}
+
+func (v *denialValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	err := v.validator.ValidateDelete(ctx, obj)
+	v.record(ctx, "DELETE", err)
+
+	return err
+}
+
+func (v *denialValidator) record(ctx context.Context, operation string, err error) {
+	if err == nil {
+		return
+	}
+
+	reason := DenialReason(err)
+	metric.RecordWebhookDenial(ctx, v.kind, operation, reason)
+	if v.reporter == nil {
+		return
+	}
+
+	message := err.Error()
+	if len(message) > maxDenialMessageLength {
+		message = message[:maxDenialMessageLength]
+	}
+	v.reporter.Record(Denial{
+		Kind:      v.kind,
+		Operation: operation,
+		Reason:    reason,
+		Message:   message,
+		Time:      time.Now(),
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
+)
+
+type fakeValidator struct {
+	err error
+}
+
+func (v *fakeValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.err
+}
+
+func (v *fakeValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return v.err
+}
+
+func (v *fakeValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return v.err
+}
+
+type fakeReporter struct {
+	webhookmanager.WebhookReporter
+	denials []webhookmanager.Denial
+}
+
+func (r *fakeReporter) Record(denial webhookmanager.Denial) {
+	r.denials = append(r.denials, denial)
+}
+
+func invalid(errs ...*field.Error) error {
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: constant.SpiderpoolAPIGroup, Kind: constant.SpiderIPPoolKind},
+		"pool",
+		errs,
+	)
+}
+
+var _ = Describe("Denial", Label("denial_test"), func() {
+	DescribeTable("classifies the denial reason",
+		func(err error, reason string) {
+			Expect(webhookmanager.DenialReason(err)).To(Equal(reason))
+		},
+		Entry("overlapping CIDR",
+			invalid(field.Invalid(field.NewPath("spec").Child("subnet"), "172.18.40.0/24", "overlap with SpiderIPPool pool1 in [172.18.40.0/24]")),
+			webhookmanager.DenialReasonOverlap,
+		),
+		Entry("IPPool sharing the subnet of the delegating one",
+			invalid(field.Forbidden(field.NewPath("spec").Child("subnet"), "share the subnet with IPPool pool1")),
+			webhookmanager.DenialReasonOverlap,
+		),
+		Entry("exhausted headroom",
+			invalid(field.Forbidden(field.NewPath("spec").Child("ips"), "only 1 free IP addresses would be left in controller Subnet subnet, which keeps 'spec.minFreeIPs' 5")),
+			webhookmanager.DenialReasonHeadroom,
+		),
+		Entry("bad gateway",
+			invalid(field.Invalid(field.NewPath("spec").Child("gateway"), "172.18.41.1", "not pertains to the 'spec.subnet' 172.18.40.0/24 of IPPool")),
+			webhookmanager.DenialReasonGateway,
+		),
+		Entry("terminating object",
+			apierrors.NewForbidden(schema.GroupResource{}, "", errors.New("cannot update a terminating IPPool")),
+			webhookmanager.DenialReasonTerminating,
+		),
+		Entry("immutable field",
+			invalid(field.Forbidden(field.NewPath("spec").Child("ipVersion"), "is not changeable")),
+			webhookmanager.DenialReasonImmutable,
+		),
+		Entry("IP address in use",
+			invalid(field.Forbidden(field.NewPath("spec").Child("ips"), "remove an IP address 172.18.40.10 that is being used by Pod default/pod")),
+			webhookmanager.DenialReasonIPInUse,
+		),
+		Entry("internal error",
+			invalid(field.InternalError(field.NewPath("spec").Child("subnet"), errors.New("failed to list IPPools"))),
+			webhookmanager.DenialReasonInternal,
+		),
+		Entry("invalid nested field",
+			invalid(field.Invalid(field.NewPath("spec").Child("routes").Index(0).Child("dst"), "invalid", "invalid CIDR")),
+			"invalid_routes",
+		),
+		Entry("invalid annotation",
+			invalid(field.Invalid(field.NewPath("metadata").Child("annotations").Key(constant.AnnoIPPoolMasterInterface), "a b", "must be a valid interface name")),
+			"invalid_annotations",
+		),
+		Entry("non-API error",
+			errors.New("unknown"),
+			webhookmanager.DenialReasonOther,
+		),
+	)
+
+	Describe("DenialValidator", func() {
+		var reporter *fakeReporter
+
+		BeforeEach(func() {
+			reporter = &fakeReporter{}
+		})
+
+		It("records nothing for the admitted requests", func() {
+			validator := webhookmanager.DenialValidator(constant.SpiderIPPoolKind, &fakeValidator{}, reporter)
+
+			err := validator.ValidateCreate(context.TODO(), &spiderpoolv1.SpiderIPPool{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reporter.denials).To(BeEmpty())
+		})
+
+		It("records the denied requests with the operation", func() {
+			denyErr := invalid(field.Forbidden(field.NewPath("spec").Child("subnet"), "is not changeable"))
+			validator := webhookmanager.DenialValidator(constant.SpiderSubnetKind, &fakeValidator{err: denyErr}, reporter)
+
+			err := validator.ValidateUpdate(context.TODO(), &spiderpoolv1.SpiderSubnet{}, &spiderpoolv1.SpiderSubnet{})
+			Expect(err).To(Equal(denyErr))
+			err = validator.ValidateDelete(context.TODO(), &spiderpoolv1.SpiderSubnet{})
+			Expect(err).To(Equal(denyErr))
+
+			Expect(reporter.denials).To(HaveLen(2))
+			Expect(reporter.denials[0].Kind).To(Equal(constant.SpiderSubnetKind))
+			Expect(reporter.denials[0].Operation).To(Equal("UPDATE"))
+			Expect(reporter.denials[0].Reason).To(Equal(webhookmanager.DenialReasonImmutable))
+			Expect(reporter.denials[0].Message).To(Equal(denyErr.Error()))
+			Expect(reporter.denials[1].Operation).To(Equal("DELETE"))
+		})
+
+		It("works without the reporter", func() {
+			validator := webhookmanager.DenialValidator(constant.SpiderIPPoolKind, &fakeValidator{err: errors.New("unknown")}, nil)
+
+			err := validator.ValidateCreate(context.TODO(), &spiderpoolv1.SpiderIPPool{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
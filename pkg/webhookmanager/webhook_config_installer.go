@@ -0,0 +1,218 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/crdmanager"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+)
+
+// webhookSpec describes a Spiderpool webhook, which is the same as the one in
+// the static manifests of the Helm chart.
+type webhookSpec struct {
+	name          string
+	path          string
+	apiGroup      string
+	apiVersion    string
+	resource      string
+	operations    []admissionregistrationv1.OperationType
+	failurePolicy admissionregistrationv1.FailurePolicyType
+}
+
+var (
+	createAndUpdate          = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	createAndUpdateAndDelete = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete}
+)
+
+var mutatingWebhookSpecs = []webhookSpec{
+	{name: "spidersubnet", path: "/mutate-spiderpool-spidernet-io-v1-spidersubnet", apiGroup: constant.SpiderpoolAPIGroup, apiVersion: constant.SpiderpoolAPIVersionV1, resource: "spidersubnets", operations: createAndUpdate, failurePolicy: admissionregistrationv1.Fail},
+	{name: "spiderippool", path: "/mutate-spiderpool-spidernet-io-v1-spiderippool", apiGroup: constant.SpiderpoolAPIGroup, apiVersion: constant.SpiderpoolAPIVersionV1, resource: "spiderippools", operations: createAndUpdate, failurePolicy: admissionregistrationv1.Fail},
+	{name: "spiderreservedip", path: "/mutate-spiderpool-spidernet-io-v1-spiderreservedip", apiGroup: constant.SpiderpoolAPIGroup, apiVersion: constant.SpiderpoolAPIVersionV1, resource: "spiderreservedips", operations: createAndUpdate, failurePolicy: admissionregistrationv1.Fail},
+}
+
+var validatingWebhookSpecs = []webhookSpec{
+	{name: "spidersubnet", path: "/validate-spiderpool-spidernet-io-v1-spidersubnet", apiGroup: constant.SpiderpoolAPIGroup, apiVersion: constant.SpiderpoolAPIVersionV1, resource: "spidersubnets", operations: createAndUpdate, failurePolicy: admissionregistrationv1.Fail},
+	{name: "spiderippool", path: "/validate-spiderpool-spidernet-io-v1-spiderippool", apiGroup: constant.SpiderpoolAPIGroup, apiVersion: constant.SpiderpoolAPIVersionV1, resource: "spiderippools", operations: createAndUpdateAndDelete, failurePolicy: admissionregistrationv1.Fail},
+	{name: "spiderreservedip", path: "/validate-spiderpool-spidernet-io-v1-spiderreservedip", apiGroup: constant.SpiderpoolAPIGroup, apiVersion: constant.SpiderpoolAPIVersionV1, resource: "spiderreservedips", operations: createAndUpdate, failurePolicy: admissionregistrationv1.Fail},
+}
+
+var podWebhookSpec = webhookSpec{
+	name:          "pod",
+	path:          "/validate--v1-pod",
+	apiGroup:      corev1.GroupName,
+	apiVersion:    corev1.SchemeGroupVersion.Version,
+	resource:      "pods",
+	operations:    []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	failurePolicy: admissionregistrationv1.Ignore,
+}
+
+var podMutatingWebhookSpec = webhookSpec{
+	name:          "pod",
+	path:          "/mutate--v1-pod",
+	apiGroup:      corev1.GroupName,
+	apiVersion:    corev1.SchemeGroupVersion.Version,
+	resource:      "pods",
+	operations:    []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+	failurePolicy: admissionregistrationv1.Ignore,
+}
+
+// Install creates or patches the MutatingWebhookConfiguration and
+// ValidatingWebhookConfiguration of Spiderpool with server-side apply, with
+// the selectors and client config of the configuration. The webhook
+// configurations installed by a newer version of spiderpool-controller are
+// skipped.
+func (r *webhookConfigReconciler) Install(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	mwc, vwc := r.DesiredWebhookConfigs()
+
+	if installed, err := r.installedVersion(ctx, &admissionregistrationv1.MutatingWebhookConfiguration{}); err != nil {
+		return err
+	} else if crdmanager.IsNewerVersion(installed, r.config.Version) {
+		logger.Sugar().Infof("MutatingWebhookConfiguration %s is installed by newer version %s, skip to apply it", mwc.Name, installed)
+	} else {
+		if err := r.client.Patch(ctx, mwc, client.Apply, client.FieldOwner(r.config.FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to apply MutatingWebhookConfiguration: %w", err)
+		}
+		logger.Sugar().Infof("Succeed to apply MutatingWebhookConfiguration %s", mwc.Name)
+	}
+
+	if installed, err := r.installedVersion(ctx, &admissionregistrationv1.ValidatingWebhookConfiguration{}); err != nil {
+		return err
+	} else if crdmanager.IsNewerVersion(installed, r.config.Version) {
+		logger.Sugar().Infof("ValidatingWebhookConfiguration %s is installed by newer version %s, skip to apply it", vwc.Name, installed)
+	} else {
+		if err := r.client.Patch(ctx, vwc, client.Apply, client.FieldOwner(r.config.FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to apply ValidatingWebhookConfiguration: %w", err)
+		}
+		logger.Sugar().Infof("Succeed to apply ValidatingWebhookConfiguration %s", vwc.Name)
+	}
+
+	return nil
+}
+
+// DesiredWebhookConfigs returns the webhook configurations of Spiderpool to
+// be installed.
+func (r *webhookConfigReconciler) DesiredWebhookConfigs() (*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1.ValidatingWebhookConfiguration) {
+	objectMeta := metav1.ObjectMeta{
+		Name:        r.config.WebhookConfigurationName,
+		Annotations: map[string]string{constant.AnnoInstalledVersion: r.config.Version},
+	}
+
+	mwc := &admissionregistrationv1.MutatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+			Kind:       "MutatingWebhookConfiguration",
+		},
+		ObjectMeta: *objectMeta.DeepCopy(),
+	}
+	mutatingWebhooks := make([]admissionregistrationv1.ValidatingWebhook, 0, len(mutatingWebhookSpecs)+1)
+	for _, spec := range mutatingWebhookSpecs {
+		mutatingWebhooks = append(mutatingWebhooks, r.desiredWebhook(spec))
+	}
+	if r.config.EnablePodMutatingWebhook {
+		mutatingWebhooks = append(mutatingWebhooks, r.desiredPodWebhook(podMutatingWebhookSpec))
+	}
+	for _, webhook := range mutatingWebhooks {
+		mwc.Webhooks = append(mwc.Webhooks, admissionregistrationv1.MutatingWebhook{
+			Name:                    webhook.Name,
+			ClientConfig:            webhook.ClientConfig,
+			Rules:                   webhook.Rules,
+			FailurePolicy:           webhook.FailurePolicy,
+			NamespaceSelector:       webhook.NamespaceSelector,
+			ObjectSelector:          webhook.ObjectSelector,
+			SideEffects:             webhook.SideEffects,
+			TimeoutSeconds:          webhook.TimeoutSeconds,
+			AdmissionReviewVersions: webhook.AdmissionReviewVersions,
+		})
+	}
+
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: *objectMeta.DeepCopy(),
+	}
+	for _, spec := range validatingWebhookSpecs {
+		vwc.Webhooks = append(vwc.Webhooks, r.desiredWebhook(spec))
+	}
+	if r.config.EnablePodWebhook {
+		vwc.Webhooks = append(vwc.Webhooks, r.desiredPodWebhook(podWebhookSpec))
+	}
+
+	return mwc, vwc
+}
+
+// desiredPodWebhook returns the Pod webhook of the spec, which never blocks
+// the Pods of Spiderpool itself.
+func (r *webhookConfigReconciler) desiredPodWebhook(spec webhookSpec) admissionregistrationv1.ValidatingWebhook {
+	webhook := r.desiredWebhook(spec)
+	if webhook.NamespaceSelector == nil {
+		webhook.NamespaceSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      corev1.LabelMetadataName,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{r.config.ServiceNamespace},
+			}},
+		}
+	}
+	webhook.TimeoutSeconds = pointer.Int32(5)
+
+	return webhook
+}
+
+// desiredWebhook returns the webhook of the spec, with the selectors and
+// client config of the configuration.
+func (r *webhookConfigReconciler) desiredWebhook(spec webhookSpec) admissionregistrationv1.ValidatingWebhook {
+	failurePolicy := spec.failurePolicy
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	webhook := admissionregistrationv1.ValidatingWebhook{
+		Name: spec.name + "." + constant.SpiderpoolAPIGroup,
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Name:      r.config.ServiceName,
+				Namespace: r.config.ServiceNamespace,
+				Path:      pointer.String(spec.path),
+				Port:      pointer.Int32(r.config.ServicePort),
+			},
+		},
+		Rules: []admissionregistrationv1.RuleWithOperations{{
+			Operations: spec.operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{spec.apiGroup},
+				APIVersions: []string{spec.apiVersion},
+				Resources:   []string{spec.resource},
+			},
+		}},
+		FailurePolicy:           &failurePolicy,
+		SideEffects:             &sideEffects,
+		AdmissionReviewVersions: []string{"v1"},
+	}
+	r.setClientConfig(webhook.Name, &webhook.ClientConfig)
+	r.setSelectors(webhook.Name, &webhook.NamespaceSelector, &webhook.ObjectSelector)
+
+	return webhook
+}
+
+func (r *webhookConfigReconciler) installedVersion(ctx context.Context, obj client.Object) (string, error) {
+	if err := r.client.Get(ctx, client.ObjectKey{Name: r.config.WebhookConfigurationName}, obj); err != nil {
+		if err := client.IgnoreNotFound(err); err != nil {
+			return "", fmt.Errorf("failed to get webhook configuration %s: %w", r.config.WebhookConfigurationName, err)
+		}
+		return "", nil
+	}
+
+	return obj.GetAnnotations()[constant.AnnoInstalledVersion], nil
+}
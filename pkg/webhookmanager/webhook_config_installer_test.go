@@ -0,0 +1,173 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
+)
+
+// applyClient records the objects applied with server-side apply, which the
+// fake client doesn't support, and creates or updates them instead.
+type applyClient struct {
+	client.Client
+	applied []string
+}
+
+func (c *applyClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch != client.Apply {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	c.applied = append(c.applied, fmt.Sprintf("%T", obj))
+
+	if err := c.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	obj.SetResourceVersion("")
+
+	return c.Client.Create(ctx, obj)
+}
+
+var _ = Describe("WebhookConfigInstaller", Label("webhook_config_installer_test"), func() {
+	var count uint64
+	var configName string
+	var config webhookmanager.WebhookConfigReconcilerConfig
+
+	BeforeEach(func() {
+		atomic.AddUint64(&count, 1)
+		configName = fmt.Sprintf("spiderpool-controller-installed-%v", count)
+		config = webhookmanager.WebhookConfigReconcilerConfig{
+			WebhookConfigurationName: configName,
+			ServiceNamespace:         "kube-system",
+			ServicePort:              5722,
+			Version:                  "v0.4.0",
+		}
+	})
+
+	Describe("DesiredWebhookConfigs", func() {
+		It("refers to the Service of the webhook server", func() {
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(config, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			mwc, vwc := reconciler.DesiredWebhookConfigs()
+			Expect(mwc.Name).To(Equal(configName))
+			Expect(mwc.Annotations).To(HaveKeyWithValue(constant.AnnoInstalledVersion, "v0.4.0"))
+			Expect(mwc.Webhooks).To(HaveLen(3))
+			Expect(vwc.Webhooks).To(HaveLen(3))
+
+			for _, webhook := range vwc.Webhooks {
+				Expect(webhook.ClientConfig.URL).To(BeNil())
+				Expect(webhook.ClientConfig.Service).NotTo(BeNil())
+				Expect(webhook.ClientConfig.Service.Name).To(Equal(constant.SpiderpoolController))
+				Expect(webhook.ClientConfig.Service.Namespace).To(Equal("kube-system"))
+				Expect(*webhook.ClientConfig.Service.Port).To(Equal(int32(5722)))
+				Expect(*webhook.FailurePolicy).To(Equal(admissionregistrationv1.Fail))
+			}
+		})
+
+		It("installs the Pod webhook excluding the namespace of Spiderpool", func() {
+			config.EnablePodWebhook = true
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(config, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, vwc := reconciler.DesiredWebhookConfigs()
+			Expect(vwc.Webhooks).To(HaveLen(4))
+
+			webhook := vwc.Webhooks[3]
+			Expect(webhook.Name).To(Equal("pod.spiderpool.spidernet.io"))
+			Expect(*webhook.FailurePolicy).To(Equal(admissionregistrationv1.Ignore))
+			Expect(*webhook.TimeoutSeconds).To(Equal(int32(5)))
+			Expect(webhook.NamespaceSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      "kubernetes.io/metadata.name",
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"kube-system"},
+			}))
+		})
+
+		It("installs the mutating Pod webhook", func() {
+			config.EnablePodMutatingWebhook = true
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(config, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			mwc, vwc := reconciler.DesiredWebhookConfigs()
+			Expect(mwc.Webhooks).To(HaveLen(4))
+			Expect(vwc.Webhooks).To(HaveLen(3))
+
+			webhook := mwc.Webhooks[3]
+			Expect(webhook.Name).To(Equal("pod.spiderpool.spidernet.io"))
+			Expect(*webhook.ClientConfig.Service.Path).To(Equal("/mutate--v1-pod"))
+			Expect(*webhook.FailurePolicy).To(Equal(admissionregistrationv1.Ignore))
+			Expect(*webhook.TimeoutSeconds).To(Equal(int32(5)))
+			Expect(webhook.NamespaceSelector.MatchExpressions).To(HaveLen(1))
+		})
+
+		It("applies the URL, CA bundle and selectors", func() {
+			config.URL = "https://10.6.0.10:5722/"
+			config.CABundle = []byte("ca")
+			config.ObjectSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(config, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			mwc, _ := reconciler.DesiredWebhookConfigs()
+			webhook := mwc.Webhooks[0]
+			Expect(webhook.ClientConfig.Service).To(BeNil())
+			Expect(*webhook.ClientConfig.URL).To(Equal("https://10.6.0.10:5722/mutate-spiderpool-spidernet-io-v1-spidersubnet"))
+			Expect(webhook.ClientConfig.CABundle).To(Equal([]byte("ca")))
+			Expect(webhook.ObjectSelector).To(Equal(config.ObjectSelector))
+		})
+	})
+
+	Describe("Install", func() {
+		var c *applyClient
+
+		BeforeEach(func() {
+			c = &applyClient{Client: fakeClient}
+		})
+
+		It("applies the webhook configurations", func() {
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(config, c)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx := context.TODO()
+			err = reconciler.Install(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.applied).To(HaveLen(2))
+
+			var vwc admissionregistrationv1.ValidatingWebhookConfiguration
+			err = fakeClient.Get(ctx, client.ObjectKey{Name: configName}, &vwc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vwc.Webhooks).To(HaveLen(3))
+		})
+
+		It("skips the webhook configurations installed by newer version", func() {
+			ctx := context.TODO()
+			newer, err := webhookmanager.NewWebhookConfigReconciler(
+				webhookmanager.WebhookConfigReconcilerConfig{
+					WebhookConfigurationName: configName,
+					Version:                  "v0.5.0",
+				},
+				c,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = newer.Install(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(config, c)
+			Expect(err).NotTo(HaveOccurred())
+			err = reconciler.Install(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.applied).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,283 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// WebhookConfigReconciler keeps the namespaceSelector, objectSelector and
+// clientConfig of all Spiderpool webhooks in sync with the configuration of
+// spiderpool-controller, instead of relying on the static manifests. It also
+// points the conversion of the SpiderIPPool CRD, which serves the versions
+// v1 and v2beta1, to the conversion webhook of spiderpool-controller.
+type WebhookConfigReconciler interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+	Install(ctx context.Context) error
+	DesiredWebhookConfigs() (*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1.ValidatingWebhookConfiguration)
+}
+
+type webhookConfigReconciler struct {
+	config WebhookConfigReconcilerConfig
+	client client.Client
+}
+
+func NewWebhookConfigReconciler(config WebhookConfigReconcilerConfig, client client.Client) (WebhookConfigReconciler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &webhookConfigReconciler{
+		config: setDefaultsForWebhookConfigReconcilerConfig(config),
+		client: client,
+	}, nil
+}
+
+// Start reconciles the webhook configurations periodically until the context
+// is done.
+func (r *webhookConfigReconciler) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			err := recovery.Call(ctx, "Webhook-Config-Reconciler", nil, func() error { return r.Reconcile(ctx) })
+			if err != nil {
+				logger.Sugar().Errorf("Failed to reconcile webhook configuration %s: %v", r.config.WebhookConfigurationName, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Reconcile sets the desired namespaceSelector, objectSelector and
+// clientConfig to all Spiderpool webhooks, and the conversion webhook to the
+// SpiderIPPool CRD.
+func (r *webhookConfigReconciler) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	var mwc admissionregistrationv1.MutatingWebhookConfiguration
+	if err := r.client.Get(ctx, apitypes.NamespacedName{Name: r.config.WebhookConfigurationName}, &mwc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get MutatingWebhookConfiguration: %w", err)
+		}
+		logger.Sugar().Debugf("MutatingWebhookConfiguration %s not found", r.config.WebhookConfigurationName)
+	} else {
+		update := false
+		for i := range mwc.Webhooks {
+			webhook := &mwc.Webhooks[i]
+			if r.setSelectors(webhook.Name, &webhook.NamespaceSelector, &webhook.ObjectSelector) {
+				update = true
+			}
+			if r.setClientConfig(webhook.Name, &webhook.ClientConfig) {
+				update = true
+			}
+		}
+
+		if update {
+			if err := r.client.Update(ctx, &mwc); err != nil {
+				return fmt.Errorf("failed to update MutatingWebhookConfiguration: %w", err)
+			}
+			logger.Sugar().Infof("Succeed to sync MutatingWebhookConfiguration %s", mwc.Name)
+		}
+	}
+
+	caBundle := r.config.CABundle
+	var vwc admissionregistrationv1.ValidatingWebhookConfiguration
+	if err := r.client.Get(ctx, apitypes.NamespacedName{Name: r.config.WebhookConfigurationName}, &vwc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get ValidatingWebhookConfiguration: %w", err)
+		}
+		logger.Sugar().Debugf("ValidatingWebhookConfiguration %s not found", r.config.WebhookConfigurationName)
+	} else {
+		update := false
+		for i := range vwc.Webhooks {
+			webhook := &vwc.Webhooks[i]
+			if r.setSelectors(webhook.Name, &webhook.NamespaceSelector, &webhook.ObjectSelector) {
+				update = true
+			}
+			if r.setClientConfig(webhook.Name, &webhook.ClientConfig) {
+				update = true
+			}
+			if len(caBundle) == 0 && isSpiderpoolWebhook(webhook.Name) {
+				caBundle = webhook.ClientConfig.CABundle
+			}
+		}
+
+		if update {
+			if err := r.client.Update(ctx, &vwc); err != nil {
+				return fmt.Errorf("failed to update ValidatingWebhookConfiguration: %w", err)
+			}
+			logger.Sugar().Infof("Succeed to sync ValidatingWebhookConfiguration %s", vwc.Name)
+		}
+	}
+
+	return r.reconcileCRDConversion(ctx, caBundle)
+}
+
+var crdGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// conversionWebhookPath is the path controller-runtime serves the conversion
+// webhook at.
+const conversionWebhookPath = "/convert"
+
+// reconcileCRDConversion sets the conversion webhook of spiderpool-controller
+// to the SpiderIPPool CRD, verified with the CA bundle of the Spiderpool
+// webhooks. The CRD is skipped if it's not installed yet or if there is no
+// CA bundle to verify the webhook server with.
+func (r *webhookConfigReconciler) reconcileCRDConversion(ctx context.Context, caBundle []byte) error {
+	logger := logutils.FromContext(ctx)
+
+	if len(caBundle) == 0 {
+		logger.Debug("No CA bundle of Spiderpool webhooks, skip to set the conversion webhook of CRD")
+		return nil
+	}
+
+	var crd unstructured.Unstructured
+	crd.SetGroupVersionKind(crdGVK)
+	if err := r.client.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderIPPoolCRDName}, &crd); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get CRD %s: %w", constant.SpiderIPPoolCRDName, err)
+		}
+		logger.Sugar().Debugf("CRD %s not found", constant.SpiderIPPoolCRDName)
+		return nil
+	}
+
+	desired := r.desiredConversion(caBundle)
+	current, _, _ := unstructured.NestedMap(crd.Object, "spec", "conversion")
+	if reflect.DeepEqual(current, desired) {
+		return nil
+	}
+
+	original := crd.DeepCopy()
+	if err := unstructured.SetNestedMap(crd.Object, desired, "spec", "conversion"); err != nil {
+		return err
+	}
+	if err := r.client.Patch(ctx, &crd, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to patch the conversion of CRD %s: %w", constant.SpiderIPPoolCRDName, err)
+	}
+	logger.Sugar().Infof("Succeed to sync the conversion webhook of CRD %s", constant.SpiderIPPoolCRDName)
+
+	return nil
+}
+
+// desiredConversion returns the conversion of CRD in the unstructured form,
+// calling the webhook server with the URL if specified, or with the Service
+// reference.
+func (r *webhookConfigReconciler) desiredConversion(caBundle []byte) map[string]interface{} {
+	clientConfig := map[string]interface{}{
+		"caBundle": base64.StdEncoding.EncodeToString(caBundle),
+	}
+	if r.config.URL != "" {
+		clientConfig["url"] = strings.TrimSuffix(r.config.URL, "/") + conversionWebhookPath
+	} else {
+		clientConfig["service"] = map[string]interface{}{
+			"namespace": r.config.ServiceNamespace,
+			"name":      r.config.ServiceName,
+			"path":      conversionWebhookPath,
+			"port":      int64(r.config.ServicePort),
+		}
+	}
+
+	return map[string]interface{}{
+		"strategy": "Webhook",
+		"webhook": map[string]interface{}{
+			"clientConfig":             clientConfig,
+			"conversionReviewVersions": []interface{}{"v1"},
+		},
+	}
+}
+
+// setSelectors overwrites the selectors of the webhook named with the suffix
+// of Spiderpool API group, and reports whether anything changed.
+func (r *webhookConfigReconciler) setSelectors(webhookName string, namespaceSelector, objectSelector **metav1.LabelSelector) bool {
+	if !isSpiderpoolWebhook(webhookName) {
+		return false
+	}
+
+	changed := false
+	if r.config.NamespaceSelector != nil && !reflect.DeepEqual(*namespaceSelector, r.config.NamespaceSelector) {
+		*namespaceSelector = r.config.NamespaceSelector.DeepCopy()
+		changed = true
+	}
+
+	if r.config.ObjectSelector != nil && !reflect.DeepEqual(*objectSelector, r.config.ObjectSelector) {
+		*objectSelector = r.config.ObjectSelector.DeepCopy()
+		changed = true
+	}
+
+	return changed
+}
+
+// setClientConfig switches the webhook named with the suffix of Spiderpool
+// API group to URL mode and sets the CA bundle, and reports whether anything
+// changed. The path of the Service reference is kept in the URL.
+func (r *webhookConfigReconciler) setClientConfig(webhookName string, clientConfig *admissionregistrationv1.WebhookClientConfig) bool {
+	if !isSpiderpoolWebhook(webhookName) {
+		return false
+	}
+
+	changed := false
+	if r.config.URL != "" {
+		var path string
+		if clientConfig.Service != nil {
+			if clientConfig.Service.Path != nil {
+				path = *clientConfig.Service.Path
+			}
+		} else if clientConfig.URL != nil {
+			if u, err := url.Parse(*clientConfig.URL); err == nil {
+				path = u.Path
+			}
+		}
+
+		desiredURL := strings.TrimSuffix(r.config.URL, "/") + path
+		if clientConfig.Service != nil || clientConfig.URL == nil || *clientConfig.URL != desiredURL {
+			clientConfig.Service = nil
+			clientConfig.URL = &desiredURL
+			changed = true
+		}
+	}
+
+	if len(r.config.CABundle) != 0 && !bytes.Equal(clientConfig.CABundle, r.config.CABundle) {
+		clientConfig.CABundle = r.config.CABundle
+		changed = true
+	}
+
+	return changed
+}
+
+func isSpiderpoolWebhook(webhookName string) bool {
+	return strings.HasSuffix(webhookName, "."+constant.SpiderpoolAPIGroup)
+}
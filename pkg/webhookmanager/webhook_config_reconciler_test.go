@@ -0,0 +1,285 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/agiledragon/gomonkey/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
+)
+
+var _ = Describe("WebhookConfigReconciler", Label("webhook_config_reconciler_test"), func() {
+	Describe("New WebhookConfigReconciler", func() {
+		It("inputs nil client", func() {
+			reconciler, err := webhookmanager.NewWebhookConfigReconciler(webhookmanager.WebhookConfigReconcilerConfig{}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var count uint64
+		var configName string
+		var namespaceSelector *metav1.LabelSelector
+		var mwcT *admissionregistrationv1.MutatingWebhookConfiguration
+		var vwcT *admissionregistrationv1.ValidatingWebhookConfiguration
+		var reconciler webhookmanager.WebhookConfigReconciler
+
+		BeforeEach(func() {
+			atomic.AddUint64(&count, 1)
+			configName = fmt.Sprintf("spiderpool-controller-%v", count)
+			namespaceSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "kubernetes.io/metadata.name",
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{"kube-system"},
+				}},
+			}
+
+			sideEffects := admissionregistrationv1.SideEffectClassNone
+			mwcT = &admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: configName},
+				Webhooks: []admissionregistrationv1.MutatingWebhook{
+					{
+						Name:                    "spiderippool.spiderpool.spidernet.io",
+						SideEffects:             &sideEffects,
+						AdmissionReviewVersions: []string{"v1"},
+					},
+					{
+						Name:                    "others.example.io",
+						SideEffects:             &sideEffects,
+						AdmissionReviewVersions: []string{"v1"},
+					},
+				},
+			}
+			vwcT = &admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: configName},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{
+					{
+						Name:                    "spiderippool.spiderpool.spidernet.io",
+						SideEffects:             &sideEffects,
+						AdmissionReviewVersions: []string{"v1"},
+					},
+				},
+			}
+
+			var err error
+			reconciler, err = webhookmanager.NewWebhookConfigReconciler(
+				webhookmanager.WebhookConfigReconcilerConfig{
+					WebhookConfigurationName: configName,
+					NamespaceSelector:        namespaceSelector,
+				},
+				fakeClient,
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			ctx := context.TODO()
+			err := fakeClient.Delete(ctx, mwcT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+
+			err = fakeClient.Delete(ctx, vwcT)
+			Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		})
+
+		It("failed to get webhook configuration due to some unknown errors", func() {
+			patches := gomonkey.ApplyMethodReturn(fakeClient, "Get", constant.ErrUnknown)
+			defer patches.Reset()
+
+			ctx := context.TODO()
+			err := reconciler.Reconcile(ctx)
+			Expect(err).To(MatchError(constant.ErrUnknown))
+		})
+
+		It("ignores non-existent webhook configurations", func() {
+			ctx := context.TODO()
+			err := reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("syncs the selectors of Spiderpool webhooks", func() {
+			ctx := context.TODO()
+			err := fakeClient.Create(ctx, mwcT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fakeClient.Create(ctx, vwcT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			var mwc admissionregistrationv1.MutatingWebhookConfiguration
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Name: configName}, &mwc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mwc.Webhooks[0].NamespaceSelector).To(Equal(namespaceSelector))
+			Expect(mwc.Webhooks[0].ObjectSelector).To(BeNil())
+			Expect(mwc.Webhooks[1].NamespaceSelector).To(BeNil())
+
+			var vwc admissionregistrationv1.ValidatingWebhookConfiguration
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Name: configName}, &vwc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vwc.Webhooks[0].NamespaceSelector).To(Equal(namespaceSelector))
+		})
+
+		It("switches Spiderpool webhooks to URL mode with the CA bundle", func() {
+			ctx := context.TODO()
+			path := "/validate-spiderpool-spidernet-io-v2beta1-spiderippool"
+			mwcT.Webhooks[0].ClientConfig.Service = &admissionregistrationv1.ServiceReference{
+				Namespace: "kube-system",
+				Name:      "spiderpool-controller",
+				Path:      pointer.String(path),
+			}
+			vwcT.Webhooks[0].ClientConfig.URL = pointer.String("https://10.6.0.1:5722" + path)
+
+			err := fakeClient.Create(ctx, mwcT)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = fakeClient.Create(ctx, vwcT)
+			Expect(err).NotTo(HaveOccurred())
+
+			caBundle := []byte("ca")
+			reconciler, err = webhookmanager.NewWebhookConfigReconciler(
+				webhookmanager.WebhookConfigReconcilerConfig{
+					WebhookConfigurationName: configName,
+					URL:                      "https://10.6.0.10:5722/",
+					CABundle:                 caBundle,
+				},
+				fakeClient,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = reconciler.Reconcile(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			var mwc admissionregistrationv1.MutatingWebhookConfiguration
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Name: configName}, &mwc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mwc.Webhooks[0].ClientConfig.Service).To(BeNil())
+			Expect(mwc.Webhooks[0].ClientConfig.URL).To(Equal(pointer.String("https://10.6.0.10:5722" + path)))
+			Expect(mwc.Webhooks[0].ClientConfig.CABundle).To(Equal(caBundle))
+			Expect(mwc.Webhooks[0].NamespaceSelector).To(BeNil())
+			Expect(mwc.Webhooks[1].ClientConfig.URL).To(BeNil())
+			Expect(mwc.Webhooks[1].ClientConfig.CABundle).To(BeEmpty())
+
+			var vwc admissionregistrationv1.ValidatingWebhookConfiguration
+			err = fakeClient.Get(ctx, apitypes.NamespacedName{Name: configName}, &vwc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vwc.Webhooks[0].ClientConfig.URL).To(Equal(pointer.String("https://10.6.0.10:5722" + path)))
+			Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(caBundle))
+		})
+
+		Describe("the conversion webhook of SpiderIPPool CRD", func() {
+			var crdT *unstructured.Unstructured
+
+			BeforeEach(func() {
+				crdT = &unstructured.Unstructured{}
+				crdT.SetAPIVersion("apiextensions.k8s.io/v1")
+				crdT.SetKind("CustomResourceDefinition")
+				crdT.SetName(constant.SpiderIPPoolCRDName)
+			})
+
+			AfterEach(func() {
+				err := fakeClient.Delete(context.TODO(), crdT)
+				Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			})
+
+			getConversion := func(ctx context.Context) map[string]interface{} {
+				crd := &unstructured.Unstructured{}
+				crd.SetGroupVersionKind(crdT.GroupVersionKind())
+				err := fakeClient.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderIPPoolCRDName}, crd)
+				Expect(err).NotTo(HaveOccurred())
+
+				conversion, _, err := unstructured.NestedMap(crd.Object, "spec", "conversion")
+				Expect(err).NotTo(HaveOccurred())
+
+				return conversion
+			}
+
+			It("skips the CRD without CA bundle", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, crdT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = reconciler.Reconcile(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getConversion(ctx)).To(BeNil())
+			})
+
+			It("calls the Service with the CA bundle of Spiderpool webhooks", func() {
+				ctx := context.TODO()
+				vwcT.Webhooks[0].ClientConfig.CABundle = []byte("ca")
+				err := fakeClient.Create(ctx, vwcT)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = fakeClient.Create(ctx, crdT)
+				Expect(err).NotTo(HaveOccurred())
+
+				reconciler, err = webhookmanager.NewWebhookConfigReconciler(
+					webhookmanager.WebhookConfigReconcilerConfig{
+						WebhookConfigurationName: configName,
+						ServiceNamespace:         "kube-system",
+						ServicePort:              5722,
+					},
+					fakeClient,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = reconciler.Reconcile(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getConversion(ctx)).To(Equal(map[string]interface{}{
+					"strategy": "Webhook",
+					"webhook": map[string]interface{}{
+						"clientConfig": map[string]interface{}{
+							"caBundle": "Y2E=",
+							"service": map[string]interface{}{
+								"namespace": "kube-system",
+								"name":      constant.SpiderpoolController,
+								"path":      "/convert",
+								"port":      int64(5722),
+							},
+						},
+						"conversionReviewVersions": []interface{}{"v1"},
+					},
+				}))
+			})
+
+			It("calls the URL with the CA bundle specified", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, crdT)
+				Expect(err).NotTo(HaveOccurred())
+
+				reconciler, err = webhookmanager.NewWebhookConfigReconciler(
+					webhookmanager.WebhookConfigReconcilerConfig{
+						WebhookConfigurationName: configName,
+						URL:                      "https://10.6.0.10:5722/",
+						CABundle:                 []byte("ca"),
+					},
+					fakeClient,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = reconciler.Reconcile(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				url, _, err := unstructured.NestedString(getConversion(ctx), "webhook", "clientConfig", "url")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(url).To(Equal("https://10.6.0.10:5722/convert"))
+			})
+		})
+	})
+})
@@ -0,0 +1,200 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+)
+
+// WebhookReporter aggregates the requests denied by the webhooks into the
+// cluster-wide SpiderWebhookReport, so that administrators could see what
+// kinds of misconfigurations are attempted most. Each replica of
+// spiderpool-controller merges the denials recorded by itself.
+type WebhookReporter interface {
+	Start(ctx context.Context)
+	// Record counts the denial in memory until the next flush.
+	Record(denial Denial)
+	// Flush merges the denials recorded since the last flush into the
+	// SpiderWebhookReport.
+	Flush(ctx context.Context) error
+}
+
+type denialKey struct {
+	kind, operation, reason string
+}
+
+type pendingDenial struct {
+	count   int64
+	message string
+	time    time.Time
+}
+
+type webhookReporter struct {
+	config WebhookReporterConfig
+	client client.Client
+
+	lock    sync.Mutex
+	pending map[denialKey]*pendingDenial
+}
+
+func NewWebhookReporter(config WebhookReporterConfig, client client.Client) (WebhookReporter, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &webhookReporter{
+		config:  setDefaultsForWebhookReporterConfig(config),
+		client:  client,
+		pending: map[denialKey]*pendingDenial{},
+	}, nil
+}
+
+// Start flushes the recorded denials periodically until the context is done.
+func (r *webhookReporter) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.FlushPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := recovery.Call(ctx, "Webhook-Reporter", nil, func() error { return r.Flush(ctx) }); err != nil {
+				logger.Sugar().Errorf("Failed to flush the denials of webhooks: %v", err)
+			}
+		}
+	}()
+}
+
+func (r *webhookReporter) Record(denial Denial) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.merge(denialKey{kind: denial.Kind, operation: denial.Operation, reason: denial.Reason},
+		&pendingDenial{count: 1, message: denial.Message, time: denial.Time})
+}
+
+func (r *webhookReporter) merge(key denialKey, d *pendingDenial) {
+	p, ok := r.pending[key]
+	if !ok {
+		r.pending[key] = d
+		return
+	}
+
+	p.count += d.count
+	if d.time.After(p.time) {
+		p.message = d.message
+		p.time = d.time
+	}
+}
+
+func (r *webhookReporter) Flush(ctx context.Context) error {
+	r.lock.Lock()
+	pending := r.pending
+	r.pending = map[denialKey]*pendingDenial{}
+	r.lock.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := r.flush(ctx, pending); err != nil {
+		// Keep the denials for the next flush.
+		r.lock.Lock()
+		for key, d := range pending {
+			r.merge(key, d)
+		}
+		r.lock.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookReporter) flush(ctx context.Context, pending map[denialKey]*pendingDenial) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var report spiderpoolv1.SpiderWebhookReport
+		err := r.client.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderWebhookReportName}, &report)
+		if apierrors.IsNotFound(err) {
+			report.Name = constant.SpiderWebhookReportName
+			if err := r.client.Create(ctx, &report); err != nil && !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create SpiderWebhookReport %s: %w", constant.SpiderWebhookReportName, err)
+			}
+			err = r.client.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderWebhookReportName}, &report)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get SpiderWebhookReport %s: %w", constant.SpiderWebhookReportName, err)
+		}
+
+		mergeDenials(&report.Status, pending)
+
+		return r.client.Status().Update(ctx, &report)
+	})
+}
+
+// mergeDenials adds the pending denials to the status, sorted by the kind,
+// the operation and the reason.
+func mergeDenials(status *spiderpoolv1.WebhookReportStatus, pending map[denialKey]*pendingDenial) {
+	index := map[denialKey]int{}
+	for i, d := range status.Denials {
+		index[denialKey{kind: d.Kind, operation: d.Operation, reason: d.Reason}] = i
+	}
+
+	for key, p := range pending {
+		i, ok := index[key]
+		if !ok {
+			status.Denials = append(status.Denials, spiderpoolv1.WebhookDenial{
+				Kind:      key.kind,
+				Operation: key.operation,
+				Reason:    key.reason,
+			})
+			i = len(status.Denials) - 1
+			index[key] = i
+		}
+
+		d := &status.Denials[i]
+		d.Count += p.count
+		if d.LastTime == nil || p.time.After(d.LastTime.Time) {
+			d.LastMessage = p.message
+			d.LastTime = &metav1.Time{Time: p.time}
+		}
+	}
+
+	sort.Slice(status.Denials, func(i, j int) bool {
+		a, b := status.Denials[i], status.Denials[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Operation != b.Operation {
+			return a.Operation < b.Operation
+		}
+		return a.Reason < b.Reason
+	})
+
+	status.TotalDenials = 0
+	for _, d := range status.Denials {
+		status.TotalDenials += d.Count
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/webhookmanager"
+)
+
+var _ = Describe("WebhookReporter", Label("webhook_reporter_test"), func() {
+	Describe("New WebhookReporter", func() {
+		It("inputs nil client", func() {
+			reporter, err := webhookmanager.NewWebhookReporter(webhookmanager.WebhookReporterConfig{}, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reporter).To(BeNil())
+		})
+	})
+
+	Describe("Flush", func() {
+		var ctx context.Context
+		var reporter webhookmanager.WebhookReporter
+
+		getReport := func() *spiderpoolv1.SpiderWebhookReport {
+			var report spiderpoolv1.SpiderWebhookReport
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Name: constant.SpiderWebhookReportName}, &report)
+			Expect(err).NotTo(HaveOccurred())
+
+			return &report
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			fakeClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				Build()
+
+			var err error
+			reporter, err = webhookmanager.NewWebhookReporter(webhookmanager.WebhookReporterConfig{}, fakeClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does nothing without denials", func() {
+			err := reporter.Flush(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			var reportList spiderpoolv1.SpiderWebhookReportList
+			err = fakeClient.List(ctx, &reportList)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reportList.Items).To(BeEmpty())
+		})
+
+		It("creates the report and merges the denials", func() {
+			now := time.Now().Truncate(time.Second)
+			reporter.Record(webhookmanager.Denial{
+				Kind:      constant.SpiderIPPoolKind,
+				Operation: "CREATE",
+				Reason:    webhookmanager.DenialReasonOverlap,
+				Message:   "first",
+				Time:      now,
+			})
+			reporter.Record(webhookmanager.Denial{
+				Kind:      constant.SpiderIPPoolKind,
+				Operation: "CREATE",
+				Reason:    webhookmanager.DenialReasonOverlap,
+				Message:   "second",
+				Time:      now.Add(time.Second),
+			})
+			reporter.Record(webhookmanager.Denial{
+				Kind:      constant.SpiderSubnetKind,
+				Operation: "UPDATE",
+				Reason:    webhookmanager.DenialReasonGateway,
+				Message:   "gateway",
+				Time:      now,
+			})
+
+			err := reporter.Flush(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			report := getReport()
+			Expect(report.Status.TotalDenials).To(Equal(int64(3)))
+			Expect(report.Status.Denials).To(HaveLen(2))
+			Expect(report.Status.Denials[0].Kind).To(Equal(constant.SpiderIPPoolKind))
+			Expect(report.Status.Denials[0].Count).To(Equal(int64(2)))
+			Expect(report.Status.Denials[0].LastMessage).To(Equal("second"))
+			Expect(report.Status.Denials[1].Kind).To(Equal(constant.SpiderSubnetKind))
+			Expect(report.Status.Denials[1].Reason).To(Equal(webhookmanager.DenialReasonGateway))
+
+			reporter.Record(webhookmanager.Denial{
+				Kind:      constant.SpiderIPPoolKind,
+				Operation: "CREATE",
+				Reason:    webhookmanager.DenialReasonOverlap,
+				Message:   "third",
+				Time:      now.Add(2 * time.Second),
+			})
+			err = reporter.Flush(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			report = getReport()
+			Expect(report.Status.TotalDenials).To(Equal(int64(4)))
+			Expect(report.Status.Denials[0].Count).To(Equal(int64(3)))
+			Expect(report.Status.Denials[0].LastMessage).To(Equal("third"))
+		})
+	})
+})
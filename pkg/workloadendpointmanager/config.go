@@ -13,13 +13,23 @@ import (
 
 const (
 	defaultMaxHistoryRecords = 100
+	defaultTTLResyncPeriod   = 10 * time.Minute
 )
 
+// EndpointTTLNever makes the SpiderEndpoints of a kind never expire.
+const EndpointTTLNever time.Duration = -1
+
+// endpointTTLOtherKinds is the key of the TTL of the kinds not listed.
+const endpointTTLOtherKinds = "*"
+
 type EndpointManagerConfig struct {
 	MaxConflictRetries    int
 	ConflictRetryUnitTime time.Duration
 	scheme                *runtime.Scheme
 	MaxHistoryRecords     *int
+	// ApplicationLabelKeys are the keys of Pod labels copied onto the
+	// Endpoint, for chargeback or auditing.
+	ApplicationLabelKeys []string
 }
 
 func setDefaultsForEndpointManagerConfig(config EndpointManagerConfig) EndpointManagerConfig {
@@ -35,3 +45,24 @@ func setDefaultsForEndpointManagerConfig(config EndpointManagerConfig) EndpointM
 
 	return config
 }
+
+type EndpointTTLReconcilerConfig struct {
+	// TTLs are how long the SpiderEndpoints are kept after their Pods are
+	// terminated, by the kind of their owner controllers. The key "*" applies
+	// to the kinds not listed, and the SpiderEndpoints of StatefulSets never
+	// expire unless the kind is listed.
+	TTLs         map[string]time.Duration
+	ResyncPeriod time.Duration
+	// ListPageSize and ListPageInterval control the pagination of listing
+	// the SpiderEndpoints.
+	ListPageSize     int64
+	ListPageInterval time.Duration
+}
+
+func setDefaultsForEndpointTTLReconcilerConfig(config EndpointTTLReconcilerConfig) EndpointTTLReconcilerConfig {
+	if config.ResyncPeriod <= 0 {
+		config.ResyncPeriod = defaultTTLResyncPeriod
+	}
+
+	return config
+}
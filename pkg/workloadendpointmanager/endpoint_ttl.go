@@ -0,0 +1,168 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadendpointmanager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	"github.com/spidernet-io/spiderpool/pkg/election"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/recovery"
+	"github.com/spidernet-io/spiderpool/pkg/utils/pager"
+)
+
+// EndpointTTLReconciler deletes the SpiderEndpoints of the terminated Pods
+// once they outlive the TTL of their owner controller kind, so that the
+// SpiderEndpoints of the completed Jobs don't pile up in the clusters
+// running batch workloads.
+type EndpointTTLReconciler interface {
+	Start(ctx context.Context)
+	Reconcile(ctx context.Context) error
+}
+
+type endpointTTLReconciler struct {
+	config EndpointTTLReconcilerConfig
+	client client.Client
+	leader election.SpiderLeaseElector
+	clock  clock.Clock
+}
+
+func NewEndpointTTLReconciler(config EndpointTTLReconcilerConfig, client client.Client, leader election.SpiderLeaseElector, opts ...manageroption.Option) (EndpointTTLReconciler, error) {
+	if client == nil {
+		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
+	}
+	if leader == nil {
+		return nil, fmt.Errorf("controller leader %w", constant.ErrMissingRequiredParam)
+	}
+
+	return &endpointTTLReconciler{
+		config: setDefaultsForEndpointTTLReconcilerConfig(config),
+		client: client,
+		leader: leader,
+		clock:  manageroption.New(opts...).Clock,
+	}, nil
+}
+
+// Start deletes the expired SpiderEndpoints periodically until the context
+// is done. Only the leader of spiderpool-controller deletes them.
+func (r *endpointTTLReconciler) Start(ctx context.Context) {
+	logger := logutils.FromContext(ctx)
+
+	go func() {
+		ticker := time.NewTicker(r.config.ResyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			if r.leader.IsElected() {
+				err := recovery.Call(ctx, "Endpoint-TTL-Reconciler", nil, func() error { return r.Reconcile(ctx) })
+				if err != nil {
+					logger.Sugar().Errorf("Failed to delete the expired SpiderEndpoints: %v", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Reconcile deletes the SpiderEndpoints expired by now. The SpiderEndpoints
+// still holding the finalizer are skipped, their IP addresses are to be
+// released by the IP garbage collection first.
+func (r *endpointTTLReconciler) Reconcile(ctx context.Context) error {
+	logger := logutils.FromContext(ctx)
+
+	now := r.clock.Now()
+	deleted := 0
+	err := pager.ListPages(
+		ctx,
+		r.client,
+		pager.Config{PageSize: r.config.ListPageSize, PageInterval: r.config.ListPageInterval},
+		func() client.ObjectList { return &spiderpoolv1.SpiderEndpointList{} },
+		func(list client.ObjectList) error {
+			endpointList := list.(*spiderpoolv1.SpiderEndpointList)
+			for i := range endpointList.Items {
+				endpoint := &endpointList.Items[i]
+				expired, err := r.isExpired(ctx, endpoint, now)
+				if err != nil {
+					return err
+				}
+				if !expired {
+					continue
+				}
+
+				err = r.client.Delete(ctx, endpoint, client.Preconditions{UID: &endpoint.UID, ResourceVersion: &endpoint.ResourceVersion})
+				if client.IgnoreNotFound(err) != nil && !apierrors.IsConflict(err) {
+					return fmt.Errorf("failed to delete SpiderEndpoint %s/%s: %w", endpoint.Namespace, endpoint.Name, err)
+				}
+				if err == nil {
+					deleted++
+					logger.Sugar().Debugf("Delete the expired SpiderEndpoint %s/%s of %s", endpoint.Namespace, endpoint.Name, endpoint.Status.OwnerControllerType)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if deleted != 0 {
+		logger.Sugar().Infof("Delete %d expired SpiderEndpoints", deleted)
+	}
+
+	return nil
+}
+
+func (r *endpointTTLReconciler) isExpired(ctx context.Context, endpoint *spiderpoolv1.SpiderEndpoint, now time.Time) (bool, error) {
+	if endpoint.DeletionTimestamp != nil || controllerutil.ContainsFinalizer(endpoint, constant.SpiderFinalizer) {
+		return false, nil
+	}
+
+	ttl := EndpointTTLOf(r.config.TTLs, endpoint.Status.OwnerControllerType)
+	if ttl < 0 {
+		return false, nil
+	}
+
+	var pod corev1.Pod
+	err := r.client.Get(ctx, apitypes.NamespacedName{Namespace: endpoint.Namespace, Name: endpoint.Name}, &pod)
+	if client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to get Pod %s/%s: %w", endpoint.Namespace, endpoint.Name, err)
+	}
+
+	var terminatedAt time.Time
+	if apierrors.IsNotFound(err) {
+		terminatedAt = endpoint.CreationTimestamp.Time
+		if endpoint.Status.Current != nil && endpoint.Status.Current.CreationTime != nil {
+			terminatedAt = endpoint.Status.Current.CreationTime.Time
+		}
+	} else {
+		// The Pod of the same name may be recreated, e.g. by StatefulSet, and
+		// the SpiderEndpoint is taken over by the new one.
+		if endpoint.Status.Current != nil && endpoint.Status.Current.UID != "" && endpoint.Status.Current.UID != string(pod.UID) {
+			return false, nil
+		}
+		var terminated bool
+		if terminatedAt, terminated = PodTerminatedTime(&pod); !terminated {
+			return false, nil
+		}
+	}
+
+	return !now.Before(terminatedAt.Add(ttl)), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Authors of spidernet-io
+// SPDX-License-Identifier: Apache-2.0
+
+package workloadendpointmanager_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
+	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
+	"github.com/spidernet-io/spiderpool/pkg/workloadendpointmanager"
+)
+
+type fakeLeader struct{}
+
+func (fakeLeader) Run(ctx context.Context, clientSet kubernetes.Interface) error { return nil }
+
+func (fakeLeader) IsElected() bool { return true }
+
+var _ = Describe("EndpointTTLReconciler", Label("endpoint_ttl_test"), func() {
+	Describe("New EndpointTTLReconciler", func() {
+		It("inputs nil client", func() {
+			reconciler, err := workloadendpointmanager.NewEndpointTTLReconciler(workloadendpointmanager.EndpointTTLReconcilerConfig{}, nil, fakeLeader{})
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+
+		It("inputs nil leader", func() {
+			reconciler, err := workloadendpointmanager.NewEndpointTTLReconciler(workloadendpointmanager.EndpointTTLReconcilerConfig{}, fakeClient, nil)
+			Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			Expect(reconciler).To(BeNil())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		var ctx context.Context
+		var objs []client.Object
+		var fakeClock *clocktesting.FakeClock
+		var reconciler workloadendpointmanager.EndpointTTLReconciler
+
+		finishedAt := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		create := func(obj client.Object) {
+			err := fakeClient.Create(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			objs = append(objs, obj)
+		}
+
+		newEndpoint := func(name, kind, uid string) *spiderpoolv1.SpiderEndpoint {
+			return &spiderpoolv1.SpiderEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Status: spiderpoolv1.WorkloadEndpointStatus{
+					Current: &spiderpoolv1.PodIPAllocation{
+						UID:          uid,
+						CreationTime: &metav1.Time{Time: finishedAt.Add(-time.Hour)},
+					},
+					OwnerControllerType: kind,
+				},
+			}
+		}
+
+		newPod := func(name, uid string, phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					UID:       apitypes.UID(uid),
+				},
+				Status: corev1.PodStatus{
+					Phase: phase,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(finishedAt)},
+						},
+					}},
+				},
+			}
+		}
+
+		exists := func(name string) bool {
+			var endpoint spiderpoolv1.SpiderEndpoint
+			err := fakeClient.Get(ctx, apitypes.NamespacedName{Namespace: "default", Name: name}, &endpoint)
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+
+		BeforeEach(func() {
+			ctx = context.TODO()
+			objs = nil
+			fakeClock = clocktesting.NewFakeClock(finishedAt.Add(30 * time.Minute))
+
+			var err error
+			reconciler, err = workloadendpointmanager.NewEndpointTTLReconciler(
+				workloadendpointmanager.EndpointTTLReconcilerConfig{
+					TTLs: map[string]time.Duration{
+						constant.KindJob: 10 * time.Minute,
+						"*":              time.Hour,
+					},
+				},
+				fakeClient,
+				fakeLeader{},
+				manageroption.WithClock(fakeClock),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			DeferCleanup(func() {
+				for _, obj := range objs {
+					err := fakeClient.Delete(ctx, obj)
+					Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+				}
+			})
+		})
+
+		It("deletes the SpiderEndpoints of the Pods terminated longer than the TTL of their kinds", func() {
+			create(newPod("job", "job-uid", corev1.PodSucceeded))
+			create(newEndpoint("job", constant.KindJob, "job-uid"))
+			create(newPod("deploy", "deploy-uid", corev1.PodFailed))
+			create(newEndpoint("deploy", constant.KindDeployment, "deploy-uid"))
+
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			Expect(exists("job")).To(BeFalse())
+			Expect(exists("deploy")).To(BeTrue())
+
+			fakeClock.Step(time.Hour)
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			Expect(exists("deploy")).To(BeFalse())
+		})
+
+		It("deletes the SpiderEndpoints of the deleted Pods", func() {
+			create(newEndpoint("job", constant.KindJob, "job-uid"))
+
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			Expect(exists("job")).To(BeFalse())
+		})
+
+		It("keeps the SpiderEndpoints of the running or recreated Pods", func() {
+			create(newPod("running", "running-uid", corev1.PodRunning))
+			create(newEndpoint("running", constant.KindJob, "running-uid"))
+			create(newPod("recreated", "new-uid", corev1.PodSucceeded))
+			create(newEndpoint("recreated", constant.KindJob, "old-uid"))
+
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			Expect(exists("running")).To(BeTrue())
+			Expect(exists("recreated")).To(BeTrue())
+		})
+
+		It("keeps the SpiderEndpoints holding the finalizer or of StatefulSets", func() {
+			endpoint := newEndpoint("job", constant.KindJob, "job-uid")
+			endpoint.Finalizers = []string{constant.SpiderFinalizer}
+			create(endpoint)
+			create(newEndpoint("sts", constant.KindStatefulSet, "sts-uid"))
+
+			fakeClock.Step(24 * time.Hour)
+			Expect(reconciler.Reconcile(ctx)).To(Succeed())
+			Expect(exists("job")).To(BeTrue())
+			Expect(exists("sts")).To(BeTrue())
+
+			// Remove the finalizer so that the cleanup is able to delete it.
+			endpoint.Finalizers = nil
+			Expect(fakeClient.Update(ctx, endpoint)).To(Succeed())
+		})
+	})
+})
@@ -4,8 +4,14 @@
 package workloadendpointmanager
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
 	"github.com/spidernet-io/spiderpool/pkg/types"
@@ -30,6 +36,32 @@ func RetrieveIPAllocation(containerID, nic string, endpoint *spiderpoolv1.Spider
 	return nil
 }
 
+// RetrieveRestartedSandboxIPAllocation retrieves the current IP allocation of
+// the Pod whose sandbox is recreated with a new container ID, e.g. on
+// container restarts or in-place updates. The allocation is only retrieved
+// if it was made for the same Pod UID on the same node.
+func RetrieveRestartedSandboxIPAllocation(containerID, nic string, pod *corev1.Pod, endpoint *spiderpoolv1.SpiderEndpoint) *spiderpoolv1.PodIPAllocation {
+	if pod == nil || endpoint == nil || endpoint.Status.Current == nil {
+		return nil
+	}
+
+	current := endpoint.Status.Current
+	if current.ContainerID == containerID || current.UID == "" || current.UID != string(pod.UID) {
+		return nil
+	}
+	if current.Node == nil || *current.Node != pod.Spec.NodeName {
+		return nil
+	}
+
+	for _, d := range current.IPs {
+		if d.NIC == nic {
+			return current
+		}
+	}
+
+	return nil
+}
+
 // ListAllHistoricalIPs collect wep history IPs and classify them with each pool name.
 func ListAllHistoricalIPs(endpoint *spiderpoolv1.SpiderEndpoint) map[string][]types.IPAndCID {
 	// key: IPPool name
@@ -71,3 +103,76 @@ func ListAllHistoricalIPs(endpoint *spiderpoolv1.SpiderEndpoint) map[string][]ty
 
 	return wepHistoryIPs
 }
+
+// EndpointTTLOf returns the TTL of the SpiderEndpoints whose owner controller
+// is of the kind, the TTL of "*" if the kind is not listed, or
+// EndpointTTLNever if neither is. The SpiderEndpoints of StatefulSets never
+// expire unless the kind is listed, since they are reused by the Pods of the
+// same ordinal.
+func EndpointTTLOf(ttls map[string]time.Duration, kind string) time.Duration {
+	if ttl, ok := ttls[kind]; ok {
+		return ttl
+	}
+	if kind == constant.KindStatefulSet {
+		return EndpointTTLNever
+	}
+	if ttl, ok := ttls[endpointTTLOtherKinds]; ok {
+		return ttl
+	}
+
+	return EndpointTTLNever
+}
+
+// ParseEndpointTTLs parses the TTLs of SpiderEndpoints in the format
+// "<kind>=<seconds>,...", the kind "*" matches the kinds not listed and
+// the seconds "never" makes the SpiderEndpoints never expire.
+func ParseEndpointTTLs(s string) (map[string]time.Duration, error) {
+	ttls := map[string]time.Duration{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		kind, value, ok := strings.Cut(item, "=")
+		kind, value = strings.TrimSpace(kind), strings.TrimSpace(value)
+		if !ok || kind == "" {
+			return nil, fmt.Errorf("%w: invalid SpiderEndpoint TTL '%s', expect '<kind>=<seconds>'", constant.ErrWrongInput, item)
+		}
+		if value == "never" {
+			ttls[kind] = EndpointTTLNever
+			continue
+		}
+
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("%w: invalid seconds of SpiderEndpoint TTL '%s'", constant.ErrWrongInput, item)
+		}
+		ttls[kind] = time.Duration(seconds) * time.Second
+	}
+
+	return ttls, nil
+}
+
+// PodTerminatedTime returns when the containers of the terminated Pod
+// finished, and false if the Pod is not terminated.
+func PodTerminatedTime(pod *corev1.Pod) (time.Time, bool) {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return time.Time{}, false
+	}
+
+	var finishedAt time.Time
+	for _, status := range pod.Status.ContainerStatuses {
+		if t := status.State.Terminated; t != nil && t.FinishedAt.After(finishedAt) {
+			finishedAt = t.FinishedAt.Time
+		}
+	}
+	if finishedAt.IsZero() {
+		finishedAt = pod.CreationTimestamp.Time
+		if pod.Status.StartTime != nil {
+			finishedAt = pod.Status.StartTime.Time
+		}
+	}
+
+	return finishedAt, true
+}
@@ -5,11 +5,14 @@ package workloadendpointmanager_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/moby/moby/pkg/stringid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/utils/pointer"
 
 	"github.com/spidernet-io/spiderpool/pkg/constant"
@@ -88,5 +91,143 @@ var _ = Describe("WorkloadEndpointManager utils", Label("workloadendpoint_manage
 		})
 	})
 
+	Describe("Test RetrieveRestartedSandboxIPAllocation", func() {
+		var containerID string
+		var podT *corev1.Pod
+		var allocationT *spiderpoolv1.PodIPAllocation
+
+		BeforeEach(func() {
+			containerID = stringid.GenerateRandomID()
+			podT = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "default",
+					UID:       uuid.NewUUID(),
+				},
+				Spec: corev1.PodSpec{NodeName: "node1"},
+			}
+			allocationT = &spiderpoolv1.PodIPAllocation{
+				ContainerID: containerID,
+				UID:         string(podT.UID),
+				Node:        pointer.String("node1"),
+				IPs: []spiderpoolv1.IPAllocationDetail{
+					{
+						NIC:      "eth0",
+						IPv4:     pointer.String("172.18.40.10/24"),
+						IPv4Pool: pointer.String("ipv4-ippool-1"),
+					},
+				},
+			}
+			endpointT.Status.Current = allocationT
+		})
+
+		It("retrieves the IP allocation of the restarted sandbox", func() {
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(stringid.GenerateRandomID(), "eth0", podT, endpointT)
+			Expect(allocation).To(Equal(allocationT))
+		})
+
+		It("retrieves nothing with the same container ID", func() {
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(containerID, "eth0", podT, endpointT)
+			Expect(allocation).To(BeNil())
+		})
+
+		It("retrieves nothing for the recreated Pod", func() {
+			podT.SetUID(uuid.NewUUID())
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(stringid.GenerateRandomID(), "eth0", podT, endpointT)
+			Expect(allocation).To(BeNil())
+		})
+
+		It("retrieves nothing for the allocation without Pod UID", func() {
+			allocationT.UID = ""
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(stringid.GenerateRandomID(), "eth0", podT, endpointT)
+			Expect(allocation).To(BeNil())
+		})
+
+		It("retrieves nothing for the Pod on another node", func() {
+			podT.Spec.NodeName = "node2"
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(stringid.GenerateRandomID(), "eth0", podT, endpointT)
+			Expect(allocation).To(BeNil())
+		})
+
+		It("retrieves nothing for another NIC", func() {
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(stringid.GenerateRandomID(), "net1", podT, endpointT)
+			Expect(allocation).To(BeNil())
+		})
+
+		It("retrieves nothing without the current IP allocation", func() {
+			endpointT.Status.Current = nil
+			allocation := workloadendpointmanager.RetrieveRestartedSandboxIPAllocation(stringid.GenerateRandomID(), "eth0", podT, endpointT)
+			Expect(allocation).To(BeNil())
+		})
+	})
+
 	PDescribe("Test ListAllHistoricalIPs", func() {})
 })
+
+var _ = Describe("SpiderEndpoint TTL utils", Label("endpoint_ttl_utils_test"), func() {
+	Describe("Test ParseEndpointTTLs", func() {
+		It("parses the TTLs by kinds", func() {
+			ttls, err := workloadendpointmanager.ParseEndpointTTLs(" Job=3600, *=86400,StatefulSet=never,")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttls).To(Equal(map[string]time.Duration{
+				constant.KindJob:         time.Hour,
+				"*":                      24 * time.Hour,
+				constant.KindStatefulSet: workloadendpointmanager.EndpointTTLNever,
+			}))
+		})
+
+		It("parses an empty string", func() {
+			ttls, err := workloadendpointmanager.ParseEndpointTTLs("")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ttls).To(BeEmpty())
+		})
+
+		DescribeTable("rejects the invalid TTLs",
+			func(s string) {
+				ttls, err := workloadendpointmanager.ParseEndpointTTLs(s)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+				Expect(ttls).To(BeNil())
+			},
+			Entry("without seconds", "Job"),
+			Entry("without kind", "=3600"),
+			Entry("with negative seconds", "Job=-1"),
+			Entry("with invalid seconds", "Job=1h"),
+		)
+	})
+
+	Describe("Test EndpointTTLOf", func() {
+		ttls := map[string]time.Duration{constant.KindJob: time.Hour, "*": 24 * time.Hour}
+
+		It("returns the TTL of the kind or of the other kinds", func() {
+			Expect(workloadendpointmanager.EndpointTTLOf(ttls, constant.KindJob)).To(Equal(time.Hour))
+			Expect(workloadendpointmanager.EndpointTTLOf(ttls, constant.KindDeployment)).To(Equal(24 * time.Hour))
+			Expect(workloadendpointmanager.EndpointTTLOf(nil, constant.KindJob)).To(Equal(workloadendpointmanager.EndpointTTLNever))
+		})
+
+		It("never expires the SpiderEndpoints of StatefulSets unless the kind is listed", func() {
+			Expect(workloadendpointmanager.EndpointTTLOf(ttls, constant.KindStatefulSet)).To(Equal(workloadendpointmanager.EndpointTTLNever))
+			Expect(workloadendpointmanager.EndpointTTLOf(map[string]time.Duration{constant.KindStatefulSet: time.Hour}, constant.KindStatefulSet)).To(Equal(time.Hour))
+		})
+	})
+
+	Describe("Test PodTerminatedTime", func() {
+		It("returns false for the running Pods", func() {
+			_, terminated := workloadendpointmanager.PodTerminatedTime(&corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}})
+			Expect(terminated).To(BeFalse())
+		})
+
+		It("returns the latest finished time of the containers", func() {
+			t := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+			pod := &corev1.Pod{Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(t)}}},
+					{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(t.Add(time.Minute))}}},
+				},
+			}}
+			finishedAt, terminated := workloadendpointmanager.PodTerminatedTime(pod)
+			Expect(terminated).To(BeTrue())
+			Expect(finishedAt).To(Equal(t.Add(time.Minute)))
+		})
+	})
+})
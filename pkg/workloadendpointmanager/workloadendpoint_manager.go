@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -20,7 +21,9 @@ import (
 	"github.com/spidernet-io/spiderpool/pkg/constant"
 	spiderpoolv1 "github.com/spidernet-io/spiderpool/pkg/k8s/apis/spiderpool.spidernet.io/v1"
 	"github.com/spidernet-io/spiderpool/pkg/logutils"
+	"github.com/spidernet-io/spiderpool/pkg/manageroption"
 	"github.com/spidernet-io/spiderpool/pkg/types"
+	"github.com/spidernet-io/spiderpool/pkg/utils/label"
 )
 
 type WorkloadEndpointManager interface {
@@ -33,21 +36,24 @@ type WorkloadEndpointManager interface {
 	PatchIPAllocation(ctx context.Context, allocation *spiderpoolv1.PodIPAllocation, endpoint *spiderpoolv1.SpiderEndpoint) error
 	ClearCurrentIPAllocation(ctx context.Context, containerID string, endpoint *spiderpoolv1.SpiderEndpoint) error
 	ReallocateCurrentIPAllocation(ctx context.Context, containerID, nodeName string, endpoint *spiderpoolv1.SpiderEndpoint) error
+	RecordSLAACIPs(ctx context.Context, containerID, nic string, ips []string, endpoint *spiderpoolv1.SpiderEndpoint) error
 }
 
 type workloadEndpointManager struct {
-	config EndpointManagerConfig
-	client client.Client
+	config  EndpointManagerConfig
+	client  client.Client
+	options manageroption.Options
 }
 
-func NewWorkloadEndpointManager(config EndpointManagerConfig, client client.Client) (WorkloadEndpointManager, error) {
+func NewWorkloadEndpointManager(config EndpointManagerConfig, client client.Client, opts ...manageroption.Option) (WorkloadEndpointManager, error) {
 	if client == nil {
 		return nil, fmt.Errorf("k8s client %w", constant.ErrMissingRequiredParam)
 	}
 
 	return &workloadEndpointManager{
-		config: setDefaultsForEndpointManagerConfig(config),
-		client: client,
+		config:  setDefaultsForEndpointManagerConfig(config),
+		client:  client,
+		options: manageroption.New(opts...),
 	}, nil
 }
 
@@ -96,7 +102,7 @@ func (em *workloadEndpointManager) RemoveFinalizer(ctx context.Context, namespac
 			if i == em.config.MaxConflictRetries {
 				return fmt.Errorf("%w (%d times), failed to remove finalizer %s from Endpoint %s/%s", constant.ErrRetriesExhausted, em.config.MaxConflictRetries, constant.SpiderFinalizer, namespace, podName)
 			}
-			time.Sleep(time.Duration(rand.Intn(1<<(i+1))) * em.config.ConflictRetryUnitTime)
+			em.options.Clock.Sleep(time.Duration(rand.Intn(1<<(i+1))) * em.config.ConflictRetryUnitTime)
 			continue
 		}
 		break
@@ -118,6 +124,7 @@ func (em *workloadEndpointManager) MarkIPAllocation(ctx context.Context, contain
 			Namespace: pod.Namespace,
 		},
 	}
+	endpoint.Labels, _ = label.StampApplicationLabels(endpoint.Labels, pod.Labels, em.config.ApplicationLabelKeys)
 
 	// Do not set ownerReference for Endpoint when its corresponding Pod is
 	// controlled by StatefulSet. Once the Pod of StatefulSet is recreated,
@@ -137,8 +144,9 @@ func (em *workloadEndpointManager) MarkIPAllocation(ctx context.Context, contain
 
 	allocation := &spiderpoolv1.PodIPAllocation{
 		ContainerID:  containerID,
+		UID:          string(pod.UID),
 		Node:         &pod.Spec.NodeName,
-		CreationTime: &metav1.Time{Time: time.Now()},
+		CreationTime: &metav1.Time{Time: em.options.Clock.Now()},
 	}
 
 	endpoint.Status.Current = allocation
@@ -178,14 +186,26 @@ func (em *workloadEndpointManager) ReMarkIPAllocation(ctx context.Context, conta
 		}
 	}
 
+	// Endpoints created before the application label keys were configured
+	// (or changed) are migrated here.
+	var changed bool
+	endpoint.Labels, changed = label.StampApplicationLabels(endpoint.Labels, pod.Labels, em.config.ApplicationLabelKeys)
+	if changed {
+		logger.Sugar().Debugf("Stamp application labels onto the Endpoint %s/%s", endpoint.Namespace, endpoint.Name)
+		if err := em.client.Update(ctx, endpoint); err != nil {
+			return err
+		}
+	}
+
 	if endpoint.Status.Current != nil && endpoint.Status.Current.ContainerID == containerID {
 		return nil
 	}
 
 	allocation := &spiderpoolv1.PodIPAllocation{
 		ContainerID:  containerID,
+		UID:          string(pod.UID),
 		Node:         &pod.Spec.NodeName,
-		CreationTime: &metav1.Time{Time: time.Now()},
+		CreationTime: &metav1.Time{Time: em.options.Clock.Now()},
 	}
 
 	endpoint.Status.Current = allocation
@@ -228,6 +248,43 @@ func (em *workloadEndpointManager) PatchIPAllocation(ctx context.Context, alloca
 	return em.client.Status().Update(ctx, endpoint)
 }
 
+// RecordSLAACIPs records the IPv6 addresses derived from router
+// advertisements on the interface in the current IP allocation of the
+// Endpoint, which are reported by the coordinator for the IPPools coexisting
+// with SLAAC.
+func (em *workloadEndpointManager) RecordSLAACIPs(ctx context.Context, containerID, nic string, ips []string, endpoint *spiderpoolv1.SpiderEndpoint) error {
+	if endpoint == nil {
+		return fmt.Errorf("endpoint %w", constant.ErrMissingRequiredParam)
+	}
+
+	if endpoint.Status.Current == nil || endpoint.Status.Current.ContainerID != containerID {
+		return errors.New("record SLAAC IP addresses in a mismarked Endpoint")
+	}
+
+	for _, ip := range ips {
+		if netIP := net.ParseIP(ip); netIP == nil || netIP.To4() != nil {
+			return fmt.Errorf("%w, invalid SLAAC IPv6 address '%s'", constant.ErrWrongInput, ip)
+		}
+	}
+
+	recorded := false
+	for i := range endpoint.Status.Current.IPs {
+		if endpoint.Status.Current.IPs[i].NIC == nic {
+			endpoint.Status.Current.IPs[i].SLAACIPv6 = ips
+			recorded = true
+		}
+	}
+	if !recorded {
+		return fmt.Errorf("%w, no IP allocation of interface %s in Endpoint %s/%s", constant.ErrWrongInput, nic, endpoint.Namespace, endpoint.Name)
+	}
+
+	if len(endpoint.Status.History) != 0 && endpoint.Status.History[0].ContainerID == containerID {
+		endpoint.Status.History[0] = *endpoint.Status.Current.DeepCopy()
+	}
+
+	return em.client.Status().Update(ctx, endpoint)
+}
+
 func (em *workloadEndpointManager) ClearCurrentIPAllocation(ctx context.Context, containerID string, endpoint *spiderpoolv1.SpiderEndpoint) error {
 	if endpoint == nil || endpoint.Status.Current == nil {
 		return nil
@@ -377,6 +377,34 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				Expect(err).NotTo(HaveOccurred())
 				Expect(endpoint).NotTo(BeNil())
 			})
+
+			It("stamps the application labels of Pod onto the Endpoint", func() {
+				manager, err := workloadendpointmanager.NewWorkloadEndpointManager(
+					workloadendpointmanager.EndpointManagerConfig{
+						ApplicationLabelKeys: []string{"team", "cost-center"},
+					},
+					fakeClient,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				podT.SetLabels(map[string]string{"team": "network", "app": "nginx"})
+
+				ctx := context.TODO()
+				endpoint, err := manager.MarkIPAllocation(
+					ctx,
+					stringid.GenerateRandomID(),
+					podT,
+					spiderpooltypes.PodTopController{
+						Kind:      constant.KindPod,
+						Namespace: podT.Namespace,
+						Name:      podT.Name,
+						UID:       podT.UID,
+						APP:       podT,
+					},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(endpoint.Labels).To(Equal(map[string]string{"team": "network"}))
+			})
 		})
 
 		Describe("ReMarkIPAllocation", func() {
@@ -478,11 +506,37 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 				err = endpointManager.ReMarkIPAllocation(ctx, newContainerID, &endpoint, podT)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(endpoint.Status.Current.ContainerID).To(Equal(newContainerID))
+				Expect(endpoint.Status.Current.UID).To(Equal(string(podT.UID)))
 
 				By("Truncate the extra history records")
 				Expect(endpoint.Status.History).To(HaveLen(1))
 				Expect(*endpoint.Status.Current).To(Equal(endpoint.Status.History[0]))
 			})
+
+			It("migrates the application labels of the existing Endpoint", func() {
+				manager, err := workloadendpointmanager.NewWorkloadEndpointManager(
+					workloadendpointmanager.EndpointManagerConfig{
+						MaxHistoryRecords:    pointer.Int(1),
+						ApplicationLabelKeys: []string{"team"},
+					},
+					fakeClient,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx := context.TODO()
+				err = fakeClient.Create(ctx, endpointT)
+				Expect(err).NotTo(HaveOccurred())
+
+				podT.SetLabels(map[string]string{"team": "network"})
+				err = manager.ReMarkIPAllocation(ctx, containerID, endpointT, podT)
+				Expect(err).NotTo(HaveOccurred())
+
+				var endpoint spiderpoolv1.SpiderEndpoint
+				err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: endpointName}, &endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(endpoint.Labels).To(HaveKeyWithValue("team", "network"))
+				Expect(endpoint.Labels).To(HaveKeyWithValue("foo", labels["foo"]))
+			})
 		})
 
 		Describe("PatchIPAllocation", func() {
@@ -593,6 +647,68 @@ var _ = Describe("WorkloadEndpointManager", Label("workloadendpoint_manager_test
 			})
 		})
 
+		Describe("RecordSLAACIPs", func() {
+			var containerID string
+
+			BeforeEach(func() {
+				containerID = stringid.GenerateRandomID()
+				current := spiderpoolv1.PodIPAllocation{
+					ContainerID: containerID,
+					Node:        pointer.String("node"),
+					IPs: []spiderpoolv1.IPAllocationDetail{
+						{
+							NIC:      "eth0",
+							IPv6:     pointer.String("fd00:40::10/64"),
+							IPv6Pool: pointer.String("default-ipv6-ippool"),
+						},
+					},
+					CreationTime: &metav1.Time{Time: time.Now()},
+				}
+				endpointT.Status.Current = current.DeepCopy()
+				endpointT.Status.History = []spiderpoolv1.PodIPAllocation{current}
+			})
+
+			It("inputs nil Endpoint", func() {
+				ctx := context.TODO()
+				err := endpointManager.RecordSLAACIPs(ctx, containerID, "eth0", []string{"fd00:40::250:56ff:feaa:bbcc"}, nil)
+				Expect(err).To(MatchError(constant.ErrMissingRequiredParam))
+			})
+
+			It("records SLAAC IP addresses with mismatched container ID", func() {
+				ctx := context.TODO()
+				err := endpointManager.RecordSLAACIPs(ctx, stringid.GenerateRandomID(), "eth0", []string{"fd00:40::250:56ff:feaa:bbcc"}, endpointT)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("records invalid SLAAC IP addresses", func() {
+				ctx := context.TODO()
+				err := endpointManager.RecordSLAACIPs(ctx, containerID, "eth0", []string{"172.18.40.10"}, endpointT)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			})
+
+			It("records SLAAC IP addresses of unknown interface", func() {
+				ctx := context.TODO()
+				err := endpointManager.RecordSLAACIPs(ctx, containerID, "net1", []string{"fd00:40::250:56ff:feaa:bbcc"}, endpointT)
+				Expect(err).To(MatchError(constant.ErrWrongInput))
+			})
+
+			It("records SLAAC IP addresses", func() {
+				ctx := context.TODO()
+				err := fakeClient.Create(ctx, endpointT)
+				Expect(err).NotTo(HaveOccurred())
+
+				slaacIPs := []string{"fd00:40::250:56ff:feaa:bbcc"}
+				err = endpointManager.RecordSLAACIPs(ctx, containerID, "eth0", slaacIPs, endpointT)
+				Expect(err).NotTo(HaveOccurred())
+
+				var endpoint spiderpoolv1.SpiderEndpoint
+				err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: endpointName}, &endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(endpoint.Status.Current.IPs[0].SLAACIPv6).To(Equal(slaacIPs))
+				Expect(*endpoint.Status.Current).To(Equal(endpoint.Status.History[0]))
+			})
+		})
+
 		Describe("ClearCurrentIPAllocation", func() {
 			It("inputs nil Endpoint", func() {
 				ctx := context.TODO()
@@ -19,6 +19,8 @@ OUTPUT_BASE_DIR=${OUTPUT_BASE_DIR:-${PROJECT_ROOT}/charts/spiderpool}
 OUTPUT_TMP_DIR=${OUTPUT_TMP_DIR:-${CONTROLLER_GEN_TMP_DIR}/old}
 # Defines the output path of the latest artifacts for diffing
 OUTPUT_DIFF_DIR=${OUTPUT_DIFF_DIR:-${CONTROLLER_GEN_TMP_DIR}/new}
+# Defines the path of the CRDs embedded in spiderpool-controller
+EMBEDDED_CRD_DIR=${EMBEDDED_CRD_DIR:-${PROJECT_ROOT}/pkg/crdmanager/crds}
 
 
 
@@ -31,18 +33,23 @@ manifests_gen() {
 
   controller-gen \
   crd rbac:roleName="spiderpool-admin" \
-  paths="${PWD}/${PROJECT_ROOT}/pkg/k8s/apis/spiderpool.spidernet.io/v1" \
+  paths="${PWD}/${PROJECT_ROOT}/pkg/k8s/apis/spiderpool.spidernet.io/..." \
   output:crd:artifacts:config="${output_dir}/crds" \
   output:rbac:artifacts:config="${output_dir}/templates"
 }
 
+embedded_crds_sync() {
+  rm -f ${EMBEDDED_CRD_DIR}/*.yaml
+  cp -a ${OUTPUT_BASE_DIR}/crds/*.yaml ${EMBEDDED_CRD_DIR}
+}
+
 deepcopy_gen() {
   tmp_header_file=${CONTROLLER_GEN_TMP_DIR}/boilerplate.go.txt
   cat ${PROJECT_ROOT}/tools/spdx-copyright-header.txt | sed -E 's?(.*)?// \1?' > ${tmp_header_file}
 
   controller-gen \
     object:headerFile="${tmp_header_file}" \
-    paths="${PWD}/${PROJECT_ROOT}/pkg/k8s/apis/spiderpool.spidernet.io/v1"
+    paths="${PWD}/${PROJECT_ROOT}/pkg/k8s/apis/spiderpool.spidernet.io/..."
 }
 
 manifests_verify() {
@@ -63,6 +70,8 @@ manifests_verify() {
   ret=0
   diff -Naupr ${OUTPUT_TMP_DIR} ${OUTPUT_DIFF_DIR} || ret=$?
 
+  diff -Naupr ${OUTPUT_BASE_DIR}/crds ${EMBEDDED_CRD_DIR} || ret=$?
+
   if [[ $ret -eq 0 ]];then
     echo "The Artifacts is up to date."
   else
@@ -87,6 +96,7 @@ main() {
   case ${1:-none} in
     manifests)
       manifests_gen ${OUTPUT_BASE_DIR}
+      embedded_crds_sync
       ;;
     deepcopy)
       deepcopy_gen
@@ -14,5 +14,11 @@ done <<< "$ALL_EP_INFO"
 
 kubectl delete crd spiderendpoints.spiderpool.spidernet.io
 kubectl delete crd spiderippools.spiderpool.spidernet.io
+kubectl delete crd spiderippoolshards.spiderpool.spidernet.io
 kubectl delete crd spiderreservedips.spiderpool.spidernet.io
 kubectl delete crd spidersubnets.spiderpool.spidernet.io
+kubectl delete crd spiderpoolconfigurations.spiderpool.spidernet.io
+kubectl delete crd spidernetworktests.spiderpool.spidernet.io
+kubectl delete crd spidercoordinators.spiderpool.spidernet.io
+kubectl delete crd spidertenants.spiderpool.spidernet.io
+kubectl delete crd spiderwebhookreports.spiderpool.spidernet.io